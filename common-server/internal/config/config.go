@@ -186,7 +186,11 @@ func (c *ServerConfig) BuildServer(ctx context.Context, dynamicClient dynamic.In
 			resourceStore := inmemory.NewSortableOrDie[*unstructured.Unstructured](ctx, storeOpts)
 			if len(resource.Secrets) > 0 {
 				log.V(1).Info("Wrapping store with secret resolver", "id", resourceId, "secrets", resource.Secrets)
-				resourceStore = secrets.WrapStore(resourceStore, resource.Secrets, secrets.NewDefaultSecretManagerResolver())
+				resolver, err := secrets.NewDefaultSecretManagerResolver()
+				if err != nil {
+					return nil, errors.Wrap(err, "failed to create secret resolver")
+				}
+				resourceStore = secrets.WrapStore(resourceStore, resource.Secrets, resolver)
 			}
 
 			stores[resourceId] = resourceStore
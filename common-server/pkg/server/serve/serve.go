@@ -3,6 +3,7 @@ package serve
 import (
 	"context"
 	"crypto/tls"
+	"crypto/x509"
 	"net"
 	"time"
 
@@ -12,7 +13,19 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/certwatcher"
 )
 
-func ServeTLS(ctx context.Context, app *fiber.App, addr, certFile, keyFile string) error {
+// Option configures the TLS listener
+type Option func(*tls.Config)
+
+// WithClientCAs requests client certificates and verifies them using the CAs.
+// Clients without certificate are still accepted, so they can authenticate otherwise.
+func WithClientCAs(pool *x509.CertPool) Option {
+	return func(cfg *tls.Config) {
+		cfg.ClientAuth = tls.VerifyClientCertIfGiven
+		cfg.ClientCAs = pool
+	}
+}
+
+func ServeTLS(ctx context.Context, app *fiber.App, addr, certFile, keyFile string, opts ...Option) error {
 	cw, err := certwatcher.New(certFile, keyFile)
 	if err != nil {
 		return err
@@ -29,10 +42,14 @@ func ServeTLS(ctx context.Context, app *fiber.App, addr, certFile, keyFile strin
 	if err != nil {
 		return errors.Wrap(err, "failed to listen")
 	}
-	ln = tls.NewListener(ln, &tls.Config{
+	tlsCfg := &tls.Config{
 		GetCertificate: cw.GetCertificate,
 		MinVersion:     tls.VersionTLS13,
-	})
+	}
+	for _, opt := range opts {
+		opt(tlsCfg)
+	}
+	ln = tls.NewListener(ln, tlsCfg)
 
 	return app.Listener(ln)
 }
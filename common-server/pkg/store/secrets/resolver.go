@@ -14,8 +14,10 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
-var _ Replacer = &SecretManagerResolver{}
+var _ BatchReplacer = &SecretManagerResolver{}
 
+// SecretManagerResolver replaces the secret placeholders with their values.
+// All placeholders are collected first and then retrieved from the Secret Manager using a single request.
 type SecretManagerResolver struct {
 	M secrets.SecretsApi
 }
@@ -26,10 +28,14 @@ func NewSecretManagerResolver(api secrets.SecretsApi) *SecretManagerResolver {
 	}
 }
 
-func NewDefaultSecretManagerResolver() *SecretManagerResolver {
-	return &SecretManagerResolver{
-		M: secrets.NewSecrets(),
+func NewDefaultSecretManagerResolver() (*SecretManagerResolver, error) {
+	api, err := secrets.NewSecrets()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create secret-manager client")
 	}
+	return &SecretManagerResolver{
+		M: api,
+	}, nil
 }
 
 func (s *SecretManagerResolver) ReplaceAll(ctx context.Context, obj any, jsonPaths []string) (any, error) {
@@ -40,97 +46,196 @@ func (s *SecretManagerResolver) ReplaceAll(ctx context.Context, obj any, jsonPat
 		return obj, nil
 	}
 
-	b, ok := obj.([]byte)
-	if ok {
-		return s.ReplaceAllFromBytes(ctx, b, jsonPaths)
+	doc, err := newDocument(obj)
+	if err != nil {
+		return nil, err
 	}
-	str, ok := obj.(string)
-	if ok {
-		b, err := s.ReplaceAllFromBytes(ctx, []byte(str), jsonPaths)
-		if b != nil {
-			return string(b), err
-		}
+	if err := s.replace(ctx, []document{doc}, jsonPaths); err != nil {
 		return nil, err
 	}
-	m, ok := obj.(map[string]any)
-	if ok {
-		return s.ReplaceAllFromMap(ctx, m, jsonPaths)
+	return doc.result(), nil
+}
+
+// ReplaceAllBatch replaces the secret placeholders of all objects using a single request.
+func (s *SecretManagerResolver) ReplaceAllBatch(ctx context.Context, objs []any, jsonPaths []string) ([]any, error) {
+	if len(jsonPaths) == 0 {
+		return objs, nil
 	}
 
-	u, ok := obj.(*unstructured.Unstructured)
-	if ok {
-		m, err := s.ReplaceAllFromMap(ctx, u.UnstructuredContent(), jsonPaths)
+	docs := make([]document, 0, len(objs))
+	for _, obj := range objs {
+		if obj == nil {
+			docs = append(docs, nil)
+			continue
+		}
+		doc, err := newDocument(obj)
 		if err != nil {
-			return nil, errors.Wrap(err, "failed to replace all from unstructured")
+			return nil, err
 		}
-		u.SetUnstructuredContent(m)
-		return u, nil
+		docs = append(docs, doc)
+	}
+	if err := s.replace(ctx, docs, jsonPaths); err != nil {
+		return nil, err
 	}
 
-	return nil, fmt.Errorf("unsupported type %T", obj)
+	res := make([]any, len(docs))
+	for i, doc := range docs {
+		if doc != nil {
+			res[i] = doc.result()
+		}
+	}
+	return res, nil
 }
 
 func (s *SecretManagerResolver) ReplaceAllFromBytes(ctx context.Context, b []byte, jsonPaths []string) ([]byte, error) {
+	doc := &bytesDocument{b: b}
+	if err := s.replace(ctx, []document{doc}, jsonPaths); err != nil {
+		return nil, err
+	}
+	return doc.b, nil
+}
+
+func (s *SecretManagerResolver) ReplaceAllFromMap(ctx context.Context, m map[string]any, jsonPaths []string) (map[string]any, error) {
+	doc := &mapDocument{m: m}
+	if err := s.replace(ctx, []document{doc}, jsonPaths); err != nil {
+		return nil, err
+	}
+	return doc.m, nil
+}
+
+func (s *SecretManagerResolver) replace(ctx context.Context, docs []document, jsonPaths []string) error {
 	log := logr.FromContextOrDiscard(ctx)
-	for _, jsonPath := range jsonPaths {
-		result := gjson.GetBytes(b, jsonPath)
-		if !result.Exists() {
-			continue
-		}
-		if result.IsArray() {
-			return nil, errors.New("array not supported")
+
+	// Collect the placeholders of all documents first
+	secretRefs := []string{}
+	seen := map[string]bool{}
+	err := forEachPlaceholder(docs, jsonPaths, func(_ document, _, secretRef string) error {
+		if !seen[secretRef] {
+			seen[secretRef] = true
+			secretRefs = append(secretRefs, secretRef)
 		}
-		if result.IsObject() {
-			return nil, errors.New("object not supported")
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if len(secretRefs) == 0 {
+		log.V(1).Info("No secret placeholders found, skipping ...")
+		return nil
+	}
+
+	secretValues, err := s.M.GetMany(ctx, secretRefs)
+	if err != nil {
+		return errors.Wrap(err, "failed to get secret values")
+	}
+
+	return forEachPlaceholder(docs, jsonPaths, func(doc document, jsonPath, secretRef string) error {
+		if err := doc.set(jsonPath, secretValues[secretRef]); err != nil {
+			return errors.Wrap(err, "failed to set secret value")
 		}
+		return nil
+	})
+}
 
-		possibleSecret := result.String()
-		secretRef, ok := api.FromRef(possibleSecret)
-		if !ok {
-			log.V(1).Info("Secret is not a placeholder, skipping ...")
+func forEachPlaceholder(docs []document, jsonPaths []string, fn func(doc document, jsonPath, secretRef string) error) error {
+	for _, doc := range docs {
+		if doc == nil {
 			continue
 		}
-		secretValue, err := s.M.Get(ctx, secretRef)
-		if err != nil {
-			return nil, errors.Wrap(err, "failed to get secret value")
+		for _, jsonPath := range jsonPaths {
+			possibleSecret, ok, err := doc.get(jsonPath)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				continue
+			}
+			secretRef, ok := api.FromRef(possibleSecret)
+			if !ok {
+				continue
+			}
+			if err := fn(doc, jsonPath, secretRef); err != nil {
+				return err
+			}
 		}
+	}
+	return nil
+}
 
-		b, err = sjson.SetBytes(b, jsonPath, secretValue)
-		if err != nil {
-			return nil, errors.Wrap(err, "failed to set secret value")
-		}
+// document provides access to the values at the json paths of the supported types
+type document interface {
+	get(jsonPath string) (string, bool, error)
+	set(jsonPath, value string) error
+	result() any
+}
+
+func newDocument(obj any) (document, error) {
+	switch o := obj.(type) {
+	case []byte:
+		return &bytesDocument{b: o}, nil
+	case string:
+		return &bytesDocument{b: []byte(o), str: true}, nil
+	case map[string]any:
+		return &mapDocument{m: o}, nil
+	case *unstructured.Unstructured:
+		return &mapDocument{m: o.UnstructuredContent(), u: o}, nil
+	default:
+		return nil, fmt.Errorf("unsupported type %T", obj)
 	}
+}
 
-	return b, nil
+type bytesDocument struct {
+	b   []byte
+	str bool
 }
 
-func (s *SecretManagerResolver) ReplaceAllFromMap(ctx context.Context, m map[string]any, jsonPaths []string) (map[string]any, error) {
-	log := logr.FromContextOrDiscard(ctx)
-	for _, jsonPath := range jsonPaths {
-		parts := strings.Split(jsonPath, ".")
-		result, ok, err := unstructured.NestedString(m, parts...)
-		if err != nil {
-			return nil, errors.Wrap(err, "failed to get json path")
-		}
-		if !ok || result == "" {
-			continue
-		}
-		possibleSecret := result
-		secretRef, ok := api.FromRef(possibleSecret)
-		if !ok {
-			log.V(1).Info("Secret is not a placeholder, skipping ...")
-			continue
-		}
-		secretValue, err := s.M.Get(ctx, secretRef)
-		if err != nil {
-			return nil, errors.Wrap(err, "failed to get secret value")
-		}
+func (d *bytesDocument) get(jsonPath string) (string, bool, error) {
+	result := gjson.GetBytes(d.b, jsonPath)
+	if !result.Exists() {
+		return "", false, nil
+	}
+	if result.IsArray() {
+		return "", false, errors.New("array not supported")
+	}
+	if result.IsObject() {
+		return "", false, errors.New("object not supported")
+	}
+	return result.String(), true, nil
+}
 
-		err = unstructured.SetNestedField(m, secretValue, parts...)
-		if err != nil {
-			return nil, errors.Wrap(err, "failed to set secret value")
-		}
+func (d *bytesDocument) set(jsonPath, value string) (err error) {
+	d.b, err = sjson.SetBytes(d.b, jsonPath, value)
+	return err
+}
+
+func (d *bytesDocument) result() any {
+	if d.str {
+		return string(d.b)
+	}
+	return d.b
+}
+
+type mapDocument struct {
+	m map[string]any
+	u *unstructured.Unstructured
+}
+
+func (d *mapDocument) get(jsonPath string) (string, bool, error) {
+	result, ok, err := unstructured.NestedString(d.m, strings.Split(jsonPath, ".")...)
+	if err != nil {
+		return "", false, errors.Wrap(err, "failed to get json path")
 	}
+	return result, ok && result != "", nil
+}
+
+func (d *mapDocument) set(jsonPath, value string) error {
+	return unstructured.SetNestedField(d.m, value, strings.Split(jsonPath, ".")...)
+}
 
-	return m, nil
+func (d *mapDocument) result() any {
+	if d.u != nil {
+		d.u.SetUnstructuredContent(d.m)
+		return d.u
+	}
+	return d.m
 }
@@ -28,7 +28,7 @@ var _ = Describe("Secrets Resolver", func() {
 		b := []byte(`{"root": "$<test:::mySecret:>", "sub": {"key": "$<test:::mySecret:>"}}`)
 
 		It("should replace all secrets in a byte array", func() {
-			mockedSecretManager.EXPECT().Get(ctx, "test:::mySecret:").Return("mySecretValue", nil).Times(2)
+			mockedSecretManager.EXPECT().GetMany(ctx, []string{"test:::mySecret:"}).Return(map[string]string{"test:::mySecret:": "mySecretValue"}, nil).Times(1)
 			result, err := resolver.ReplaceAll(ctx, b, []string{"root", "sub.key"})
 			Expect(err).ToNot(HaveOccurred())
 			b, ok := result.([]byte)
@@ -37,7 +37,7 @@ var _ = Describe("Secrets Resolver", func() {
 		})
 
 		It("should return an error if the secret is not found", func() {
-			mockedSecretManager.EXPECT().Get(ctx, "test:::mySecret:").Return("", api.ErrNotFound).Times(1)
+			mockedSecretManager.EXPECT().GetMany(ctx, []string{"test:::mySecret:"}).Return(map[string]string{}, &api.BatchError{Errors: map[string]error{"test:::mySecret:": api.ErrNotFound}}).Times(1)
 			result, err := resolver.ReplaceAll(ctx, b, []string{"root", "sub.key"})
 			Expect(err).To(HaveOccurred())
 			Expect(result).To(BeNil())
@@ -45,7 +45,7 @@ var _ = Describe("Secrets Resolver", func() {
 		})
 
 		It("should also work with strings", func() {
-			mockedSecretManager.EXPECT().Get(ctx, "test:::mySecret:").Return("mySecretValue", nil).Times(2)
+			mockedSecretManager.EXPECT().GetMany(ctx, []string{"test:::mySecret:"}).Return(map[string]string{"test:::mySecret:": "mySecretValue"}, nil).Times(1)
 			result, err := resolver.ReplaceAll(ctx, string(b), []string{"root", "sub.key"})
 			Expect(err).ToNot(HaveOccurred())
 			str, ok := result.(string)
@@ -63,7 +63,7 @@ var _ = Describe("Secrets Resolver", func() {
 				"sub":  map[string]any{"key": "$<test:::mySecret:>"},
 			}
 
-			mockedSecretManager.EXPECT().Get(ctx, "test:::mySecret:").Return("mySecretValue", nil).Times(2)
+			mockedSecretManager.EXPECT().GetMany(ctx, []string{"test:::mySecret:"}).Return(map[string]string{"test:::mySecret:": "mySecretValue"}, nil).Times(1)
 			result, err := resolver.ReplaceAll(ctx, m, []string{"root", "sub.key"})
 			Expect(err).ToNot(HaveOccurred())
 			resMap, ok := result.(map[string]any)
@@ -78,7 +78,7 @@ var _ = Describe("Secrets Resolver", func() {
 				"sub":  map[string]any{"key": "$<test:::mySecret:>"},
 			}
 
-			mockedSecretManager.EXPECT().Get(ctx, "test:::mySecret:").Return("", api.ErrNotFound).Times(1)
+			mockedSecretManager.EXPECT().GetMany(ctx, []string{"test:::mySecret:"}).Return(map[string]string{}, &api.BatchError{Errors: map[string]error{"test:::mySecret:": api.ErrNotFound}}).Times(1)
 			result, err := resolver.ReplaceAll(ctx, m, []string{"root", "sub.key"})
 			Expect(err).To(HaveOccurred())
 			Expect(result).To(BeNil())
@@ -98,7 +98,7 @@ var _ = Describe("Secrets Resolver", func() {
 				},
 			}
 
-			mockedSecretManager.EXPECT().Get(ctx, "test:::mySecret:").Return("mySecretValue", nil).Times(2)
+			mockedSecretManager.EXPECT().GetMany(ctx, []string{"test:::mySecret:"}).Return(map[string]string{"test:::mySecret:": "mySecretValue"}, nil).Times(1)
 			result, err := resolver.ReplaceAll(ctx, u, []string{"spec.root", "spec.sub.key"})
 			Expect(err).ToNot(HaveOccurred())
 			resUnstructured, ok := result.(*unstructured.Unstructured)
@@ -114,11 +114,42 @@ var _ = Describe("Secrets Resolver", func() {
 				"sub":  map[string]any{"key": "$<test:::mySecret:>"},
 			}
 
-			mockedSecretManager.EXPECT().Get(ctx, "test:::mySecret:").Return("", api.ErrNotFound).Times(1)
+			mockedSecretManager.EXPECT().GetMany(ctx, []string{"test:::mySecret:"}).Return(map[string]string{}, &api.BatchError{Errors: map[string]error{"test:::mySecret:": api.ErrNotFound}}).Times(1)
 			result, err := resolver.ReplaceAll(ctx, u, []string{"root", "sub.key"})
 			Expect(err).To(HaveOccurred())
 			Expect(result).To(BeNil())
 			Expect(err.Error()).To(ContainSubstring("failed to get secret value"))
 		})
 	})
+
+	Context("Resolve in batch", func() {
+
+		It("should replace the secrets of all objects using a single request", func() {
+			objs := []any{
+				map[string]any{"root": "$<test:::mySecret:>"},
+				[]byte(`{"root": "$<test:::otherSecret:>"}`),
+				map[string]any{"root": "not-a-secret"},
+			}
+
+			mockedSecretManager.EXPECT().GetMany(ctx, []string{"test:::mySecret:", "test:::otherSecret:"}).Return(map[string]string{
+				"test:::mySecret:":    "mySecretValue",
+				"test:::otherSecret:": "otherSecretValue",
+			}, nil).Times(1)
+
+			result, err := resolver.(secrets.BatchReplacer).ReplaceAllBatch(ctx, objs, []string{"root"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result).To(HaveLen(3))
+			Expect(result[0].(map[string]any)["root"]).To(Equal("mySecretValue"))
+			Expect(string(result[1].([]byte))).To(Equal(`{"root": "otherSecretValue"}`))
+			Expect(result[2].(map[string]any)["root"]).To(Equal("not-a-secret"))
+		})
+
+		It("should not call the secret manager if there are no placeholders", func() {
+			objs := []any{map[string]any{"root": "not-a-secret"}}
+
+			result, err := resolver.(secrets.BatchReplacer).ReplaceAllBatch(ctx, objs, []string{"root"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result).To(Equal(objs))
+		})
+	})
 })
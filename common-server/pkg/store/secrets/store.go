@@ -14,6 +14,13 @@ type Replacer interface {
 	ReplaceAll(ctx context.Context, obj any, jsonPaths []string) (any, error)
 }
 
+// BatchReplacer is a Replacer that can also replace the secret values of multiple objects at once,
+// e.g. to resolve the secrets of a list using a single request.
+type BatchReplacer interface {
+	Replacer
+	ReplaceAllBatch(ctx context.Context, objs []any, jsonPaths []string) ([]any, error)
+}
+
 type SecretStore[T store.Object] struct {
 	store.ObjectStore[T]
 
@@ -66,6 +73,14 @@ func (s *SecretStore[T]) List(ctx context.Context, opts store.ListOpts) (*store.
 		replacer = s.obfuscator
 	}
 
+	if batchReplacer, ok := replacer.(BatchReplacer); ok {
+		res.Items, err = s.forItems(ctx, batchReplacer, res.Items)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to replace secret values")
+		}
+		return res, nil
+	}
+
 	for i := range res.Items {
 		res.Items[i], err = s.forItem(ctx, replacer, res.Items[i])
 		if err != nil {
@@ -76,6 +91,25 @@ func (s *SecretStore[T]) List(ctx context.Context, opts store.ListOpts) (*store.
 	return res, nil
 }
 
+func (s *SecretStore[T]) forItems(ctx context.Context, replacer BatchReplacer, items []T) ([]T, error) {
+	objs := make([]any, len(items))
+	for i := range items {
+		objs[i] = items[i]
+	}
+	objs, err := replacer.ReplaceAllBatch(ctx, objs, s.secretJsonPaths)
+	if err != nil {
+		return nil, err
+	}
+	for i := range objs {
+		item, ok := objs[i].(T)
+		if !ok {
+			return nil, fmt.Errorf("failed to cast object to type %T", items[i])
+		}
+		items[i] = item
+	}
+	return items, nil
+}
+
 func (s *SecretStore[T]) forItem(ctx context.Context, replacer Replacer, item T) (T, error) {
 	o, err := replacer.ReplaceAll(ctx, item, s.secretJsonPaths)
 	if err != nil {
@@ -62,7 +62,7 @@ var _ = Describe("Secrets Store", func() {
 			obj := NewObject("default", "foo")
 
 			mockStore.EXPECT().Get(ctx, "default", "foo").Return(obj, nil)
-			secretManager.EXPECT().Get(ctx, "my-secret-placeholder").Return("topsecret", nil)
+			secretManager.EXPECT().GetMany(ctx, []string{"my-secret-placeholder"}).Return(map[string]string{"my-secret-placeholder": "topsecret"}, nil)
 
 			result, err := secretsStore.Get(ctx, "default", "foo")
 			Expect(err).ToNot(HaveOccurred())
@@ -119,7 +119,7 @@ var _ = Describe("Secrets Store", func() {
 				Items: items,
 			}, nil)
 
-			secretManager.EXPECT().Get(ctx, "my-secret-placeholder").Return("topsecret", nil).Times(2)
+			secretManager.EXPECT().GetMany(ctx, []string{"my-secret-placeholder"}).Return(map[string]string{"my-secret-placeholder": "topsecret"}, nil).Times(1)
 
 			result, err := secretsStore.List(ctx, store.ListOpts{})
 			Expect(err).ToNot(HaveOccurred())
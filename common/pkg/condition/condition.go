@@ -7,6 +7,8 @@ import (
 const (
 	ConditionTypeProcessing = "Processing"
 	ConditionTypeReady      = "Ready"
+
+	ReasonBlocked = "Blocked"
 )
 
 var (
@@ -25,7 +27,7 @@ func NewBlockedCondition(message string) metav1.Condition {
 	condition := ProcessingCondition
 	condition.Status = metav1.ConditionFalse
 	condition.Message = message
-	condition.Reason = "Blocked"
+	condition.Reason = ReasonBlocked
 	return condition
 }
 
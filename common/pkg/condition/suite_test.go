@@ -34,6 +34,24 @@ var _ = Describe("Condition Tests", func() {
 		})
 	})
 
+	Context("IsBlocked function", func() {
+		It("should return false if the object is not blocked", func() {
+			obj := test.NewObject("fake", "default")
+			obj.SetCondition(NewProcessingCondition("Reason", "Processing"))
+			message, blocked := IsBlocked(obj)
+			Expect(blocked).To(BeFalse())
+			Expect(message).To(BeEmpty())
+		})
+
+		It("should return the message if the object is blocked", func() {
+			obj := test.NewObject("fake", "default")
+			obj.SetCondition(NewBlockedCondition("Dependency is not reachable"))
+			message, blocked := IsBlocked(obj)
+			Expect(blocked).To(BeTrue())
+			Expect(message).To(Equal("Dependency is not reachable"))
+		})
+	})
+
 	Context("Constructor functions", func() {
 
 		It("should return a new BlockedCondition", func() {
@@ -6,6 +6,7 @@ import (
 	"github.com/telekom/controlplane-mono/common/pkg/types"
 
 	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // EnsureReady returns an error if the provided obj is not ready
@@ -17,3 +18,13 @@ func EnsureReady(obj types.Object) error {
 	}
 	return nil
 }
+
+// IsBlocked returns true if the processing of the provided obj is blocked
+// The message of the blocked condition is returned as well and explains why
+func IsBlocked(obj types.Object) (string, bool) {
+	processing := meta.FindStatusCondition(obj.GetConditions(), ConditionTypeProcessing)
+	if processing == nil || processing.Status != metav1.ConditionFalse || processing.Reason != ReasonBlocked {
+		return "", false
+	}
+	return processing.Message, true
+}
@@ -140,14 +140,11 @@ func NewFeatureBuilder(ctx context.Context, route *gatewayv1.Route) (features.Fe
 		return nil, nil
 	}
 
-	gateway.Spec.Admin.ClientSecret, err = secrets.Get(ctx, gateway.Spec.Admin.ClientSecret)
+	values, err := secrets.GetMany(ctx, gateway.Spec.Admin.ClientSecret, gateway.Spec.Redis.Password)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to get gateway client secret")
-	}
-	gateway.Spec.Redis.Password, err = secrets.Get(ctx, gateway.Spec.Redis.Password)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to get gateway redis password")
+		return nil, errors.Wrap(err, "failed to get gateway client secret and redis password")
 	}
+	gateway.Spec.Admin.ClientSecret, gateway.Spec.Redis.Password = values[0], values[1]
 
 	kc, err := kongutil.GetClientFor(gateway)
 	if err != nil {
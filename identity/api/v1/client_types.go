@@ -27,6 +27,18 @@ type ClientSpec struct {
 	Realm        *types.ObjectRef `json:"realm"`
 	ClientId     string           `json:"clientId"`
 	ClientSecret string           `json:"clientSecret"`
+	// Roles that are assigned to the service-account user of this client
+	// +optional
+	Roles []RoleAssignment `json:"roles,omitempty"`
+}
+
+// RoleAssignment references a realm role or, if ClientId is set, a client role
+type RoleAssignment struct {
+	Name string `json:"name"`
+	// ClientId of the Keycloak client that owns the role.
+	// If it is empty, the role is treated as a realm role.
+	// +optional
+	ClientId string `json:"clientId,omitempty"`
 }
 
 // ClientStatus defines the observed state of Client
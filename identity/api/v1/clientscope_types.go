@@ -0,0 +1,94 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"github.com/telekom/controlplane-mono/common/pkg/types"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ClientScopeSpec defines the desired state of ClientScope
+type ClientScopeSpec struct {
+	Realm *types.ObjectRef `json:"realm"`
+	// Name of the client scope as it is requested in the scope parameter
+	Name string `json:"name"`
+	// +optional
+	Description string `json:"description,omitempty"`
+	// +kubebuilder:validation:Enum=openid-connect;saml
+	// +kubebuilder:default=openid-connect
+	// +optional
+	Protocol string `json:"protocol,omitempty"`
+	// Attributes are passed to Keycloak as they are, e.g. "include.in.token.scope"
+	// +optional
+	Attributes map[string]string `json:"attributes,omitempty"`
+}
+
+// ClientScopeStatus defines the observed state of ClientScope
+type ClientScopeStatus struct {
+	// +listType=map
+	// +listMapKey=type
+	// +patchStrategy=merge
+	// +patchMergeKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,1,rep,name=conditions"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+
+// ClientScope is the Schema for the clientscopes API
+type ClientScope struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ClientScopeSpec   `json:"spec,omitempty"`
+	Status ClientScopeStatus `json:"status,omitempty"`
+}
+
+var _ types.Object = &ClientScope{}
+
+func (e *ClientScope) GetConditions() []metav1.Condition {
+	return e.Status.Conditions
+}
+
+func (e *ClientScope) SetCondition(condition metav1.Condition) bool {
+	return meta.SetStatusCondition(&e.Status.Conditions, condition)
+}
+
+// +kubebuilder:object:root=true
+
+// ClientScopeList contains a list of ClientScope
+type ClientScopeList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClientScope `json:"items"`
+}
+
+var _ types.ObjectList = &ClientScopeList{}
+
+func (el *ClientScopeList) GetItems() []types.Object {
+	items := make([]types.Object, len(el.Items))
+	for i := range el.Items {
+		items[i] = &el.Items[i]
+	}
+	return items
+}
+
+func init() {
+	SchemeBuilder.Register(&ClientScope{}, &ClientScopeList{})
+}
@@ -0,0 +1,171 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"github.com/telekom/controlplane-mono/common/pkg/types"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	IdentityBrokerTypeOIDC = "oidc"
+	IdentityBrokerTypeSAML = "saml"
+)
+
+// IdentityBrokerSpec defines the desired state of IdentityBroker
+type IdentityBrokerSpec struct {
+	Realm *types.ObjectRef `json:"realm"`
+	// Alias uniquely identifies the upstream identity provider within the realm
+	Alias string `json:"alias"`
+	// +optional
+	DisplayName string `json:"displayName,omitempty"`
+	// +kubebuilder:validation:Enum=oidc;saml
+	ProviderType string `json:"providerType"`
+	// +kubebuilder:default=true
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+	// +optional
+	TrustEmail bool `json:"trustEmail,omitempty"`
+	// +optional
+	FirstBrokerLoginFlowAlias string `json:"firstBrokerLoginFlowAlias,omitempty"`
+	// OIDC is required if ProviderType is oidc
+	// +optional
+	OIDC *OIDCBrokerConfig `json:"oidc,omitempty"`
+	// SAML is required if ProviderType is saml
+	// +optional
+	SAML *SAMLBrokerConfig `json:"saml,omitempty"`
+	// Mappers that import attributes or roles of the upstream identity provider.
+	// Mappers that are not listed here are removed from the identity provider.
+	// +optional
+	Mappers []IdentityBrokerMapper `json:"mappers,omitempty"`
+}
+
+// OIDCBrokerConfig configures an upstream OpenID Connect identity provider
+type OIDCBrokerConfig struct {
+	AuthorizationUrl string `json:"authorizationUrl"`
+	TokenUrl         string `json:"tokenUrl"`
+	// +optional
+	UserInfoUrl string `json:"userInfoUrl,omitempty"`
+	// +optional
+	LogoutUrl string `json:"logoutUrl,omitempty"`
+	// +optional
+	JwksUrl string `json:"jwksUrl,omitempty"`
+	// +optional
+	Issuer   string `json:"issuer,omitempty"`
+	ClientId string `json:"clientId"`
+	// ClientSecret is a secret-manager reference, e.g. $<...>
+	ClientSecret string `json:"clientSecret"`
+	// +kubebuilder:validation:Enum=client_secret_post;client_secret_basic;client_secret_jwt
+	// +kubebuilder:default=client_secret_post
+	// +optional
+	ClientAuthMethod string `json:"clientAuthMethod,omitempty"`
+	// +optional
+	DefaultScopes []string `json:"defaultScopes,omitempty"`
+	// +optional
+	ValidateSignature bool `json:"validateSignature,omitempty"`
+	// +optional
+	PkceEnabled bool `json:"pkceEnabled,omitempty"`
+}
+
+// SAMLBrokerConfig configures an upstream SAML identity provider
+type SAMLBrokerConfig struct {
+	SingleSignOnServiceUrl string `json:"singleSignOnServiceUrl"`
+	// +optional
+	SingleLogoutServiceUrl string `json:"singleLogoutServiceUrl,omitempty"`
+	// +optional
+	IdpEntityId string `json:"idpEntityId,omitempty"`
+	// +optional
+	NameIDPolicyFormat string `json:"nameIDPolicyFormat,omitempty"`
+	// +optional
+	PrincipalType string `json:"principalType,omitempty"`
+	// SigningCertificate is a secret-manager reference or the PEM encoded certificate of the upstream
+	// +optional
+	SigningCertificate string `json:"signingCertificate,omitempty"`
+	// +optional
+	ValidateSignature bool `json:"validateSignature,omitempty"`
+	// +optional
+	WantAssertionsSigned bool `json:"wantAssertionsSigned,omitempty"`
+	// +optional
+	PostBindingResponse bool `json:"postBindingResponse,omitempty"`
+}
+
+// IdentityBrokerMapper is a Keycloak identity provider mapper
+type IdentityBrokerMapper struct {
+	Name string `json:"name"`
+	// Type of the mapper, e.g. oidc-user-attribute-idp-mapper or saml-user-attribute-idp-mapper
+	Type string `json:"type"`
+	// +optional
+	Config map[string]string `json:"config,omitempty"`
+}
+
+// IdentityBrokerStatus defines the observed state of IdentityBroker
+type IdentityBrokerStatus struct {
+	// +listType=map
+	// +listMapKey=type
+	// +patchStrategy=merge
+	// +patchMergeKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,1,rep,name=conditions"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:validation:XValidation:rule="self.spec.providerType != 'oidc' || has(self.spec.oidc)",message="spec.oidc is required for providerType oidc"
+// +kubebuilder:validation:XValidation:rule="self.spec.providerType != 'saml' || has(self.spec.saml)",message="spec.saml is required for providerType saml"
+
+// IdentityBroker is the Schema for the identitybrokers API
+type IdentityBroker struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   IdentityBrokerSpec   `json:"spec,omitempty"`
+	Status IdentityBrokerStatus `json:"status,omitempty"`
+}
+
+var _ types.Object = &IdentityBroker{}
+
+func (e *IdentityBroker) GetConditions() []metav1.Condition {
+	return e.Status.Conditions
+}
+
+func (e *IdentityBroker) SetCondition(condition metav1.Condition) bool {
+	return meta.SetStatusCondition(&e.Status.Conditions, condition)
+}
+
+// +kubebuilder:object:root=true
+
+// IdentityBrokerList contains a list of IdentityBroker
+type IdentityBrokerList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []IdentityBroker `json:"items"`
+}
+
+var _ types.ObjectList = &IdentityBrokerList{}
+
+func (el *IdentityBrokerList) GetItems() []types.Object {
+	items := make([]types.Object, len(el.Items))
+	for i := range el.Items {
+		items[i] = &el.Items[i]
+	}
+	return items
+}
+
+func init() {
+	SchemeBuilder.Register(&IdentityBroker{}, &IdentityBrokerList{})
+}
@@ -37,6 +37,12 @@ type IdentityProviderStatus struct {
 	AdminUrl        string `json:"adminUrl"`
 	AdminTokenUrl   string `json:"adminTokenUrl"`
 	AdminConsoleUrl string `json:"adminConsoleUrl,omitempty"`
+	// Version of the Keycloak server as reported by its server-info endpoint
+	// +optional
+	Version string `json:"version,omitempty"`
+	// LastLoginTime is the time of the last successful login with the admin credentials
+	// +optional
+	LastLoginTime *metav1.Time `json:"lastLoginTime,omitempty"`
 	// +listType=map
 	// +listMapKey=type
 	// +patchStrategy=merge
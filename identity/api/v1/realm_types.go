@@ -27,14 +27,14 @@ type RealmSpec struct {
 	IdentityProvider *types.ObjectRef `json:"identityProvider"`
 }
 
-// RealmStatus defines the observed state of Realm
+// RealmStatus defines the observed state of Realm.
+// It only references the IdentityProvider, the admin credentials are resolved
+// from it at use time and are never copied into the status.
 type RealmStatus struct {
-	IssuerUrl     string `json:"issuerUrl"`
-	AdminClientId string `json:"adminClientId"`
-	AdminUserName string `json:"adminUserName"`
-	AdminPassword string `json:"adminPassword"`
-	AdminUrl      string `json:"adminUrl"`
-	AdminTokenUrl string `json:"adminTokenUrl"`
+	IssuerUrl string `json:"issuerUrl"`
+	// IdentityProvider that the realm has been provisioned on
+	// +optional
+	IdentityProvider *types.ObjectRef `json:"identityProvider,omitempty"`
 	// +listType=map
 	// +listMapKey=type
 	// +patchStrategy=merge
@@ -0,0 +1,95 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"github.com/telekom/controlplane-mono/common/pkg/types"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RoleSpec defines the desired state of Role
+type RoleSpec struct {
+	Realm *types.ObjectRef `json:"realm"`
+	Name  string           `json:"name"`
+	// ClientId of the Keycloak client that owns the role.
+	// If it is empty, the role is created as a realm role.
+	// +optional
+	ClientId string `json:"clientId,omitempty"`
+	// +optional
+	Description string `json:"description,omitempty"`
+}
+
+// RoleStatus defines the observed state of Role
+type RoleStatus struct {
+	// +listType=map
+	// +listMapKey=type
+	// +patchStrategy=merge
+	// +patchMergeKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,1,rep,name=conditions"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+
+// Role is the Schema for the roles API
+type Role struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RoleSpec   `json:"spec,omitempty"`
+	Status RoleStatus `json:"status,omitempty"`
+}
+
+var _ types.Object = &Role{}
+
+func (e *Role) GetConditions() []metav1.Condition {
+	return e.Status.Conditions
+}
+
+func (e *Role) SetCondition(condition metav1.Condition) bool {
+	return meta.SetStatusCondition(&e.Status.Conditions, condition)
+}
+
+// IsClientRole returns true if the role belongs to a client instead of the realm
+func (e *Role) IsClientRole() bool {
+	return e.Spec.ClientId != ""
+}
+
+// +kubebuilder:object:root=true
+
+// RoleList contains a list of Role
+type RoleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Role `json:"items"`
+}
+
+var _ types.ObjectList = &RoleList{}
+
+func (el *RoleList) GetItems() []types.Object {
+	items := make([]types.Object, len(el.Items))
+	for i := range el.Items {
+		items[i] = &el.Items[i]
+	}
+	return items
+}
+
+func init() {
+	SchemeBuilder.Register(&Role{}, &RoleList{})
+}
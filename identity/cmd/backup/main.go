@@ -0,0 +1,240 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Command backup exports realms from keycloak into a backup file, imports such a file
+// into keycloak and converts it into Realm and Client manifests.
+//
+//	backup export    --admin-url <url> --username <user> --password <password> --realms a,b --out backup.json
+//	backup import    --admin-url <url> --username <user> --password <password> --in backup.json
+//	backup manifests --in backup.json --namespace <ns> --identity-provider <idp> --out manifests.yaml
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	"github.com/telekom/controlplane-mono/identity/pkg/api"
+	"github.com/telekom/controlplane-mono/identity/pkg/backup"
+	"github.com/telekom/controlplane-mono/identity/pkg/keycloak"
+)
+
+type adminFlags struct {
+	adminUrl string
+	clientId string
+	username string
+	password string
+}
+
+func (a *adminFlags) bind(fs *flag.FlagSet) {
+	fs.StringVar(&a.adminUrl, "admin-url", "", "Keycloak admin URL, e.g. https://<host>/auth/admin/realms/")
+	fs.StringVar(&a.clientId, "client-id", "admin-cli", "Client ID used to log in to the master realm")
+	fs.StringVar(&a.username, "username", "admin", "Admin username")
+	fs.StringVar(&a.password, "password", os.Getenv("KEYCLOAK_ADMIN_PASSWORD"),
+		"Admin password or secret-manager reference. Defaults to $KEYCLOAK_ADMIN_PASSWORD")
+}
+
+func (a *adminFlags) newClient(ctx context.Context) (api.KeycloakClient, error) {
+	if a.adminUrl == "" {
+		return nil, fmt.Errorf("--admin-url is required")
+	}
+	password, err := backup.ResolveSecretsFromSecretManager(ctx, a.password)
+	if err != nil {
+		return nil, err
+	}
+	config := keycloak.NewKeycloakClientConfig(
+		a.adminUrl,
+		keycloak.DetermineIssuerUrlFrom(a.adminUrl, keycloak.MasterRealm),
+		keycloak.DetermineAdminTokenUrlFrom(a.adminUrl, keycloak.MasterRealm),
+		a.clientId,
+		keycloak.EmptyString,
+		a.username,
+		password,
+	)
+	return keycloak.NewClientFor(config)
+}
+
+func main() {
+	opts := zap.Options{
+		Development: true,
+	}
+	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
+	ctx := log.IntoContext(context.Background(), ctrl.Log.WithName("backup"))
+
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	var err error
+	switch os.Args[1] {
+	case "export":
+		err = runExport(ctx, os.Args[2:])
+	case "import":
+		err = runImport(ctx, os.Args[2:])
+	case "manifests":
+		err = runManifests(os.Args[2:])
+	default:
+		usage()
+		os.Exit(2)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ %s\n", err)
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "Usage: %s <export|import|manifests> [flags]\n", os.Args[0])
+}
+
+func runExport(ctx context.Context, args []string) error {
+	var admin adminFlags
+	var realms, out, secretRefTemplate string
+	var storeSecrets bool
+
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+	admin.bind(fs)
+	fs.StringVar(&realms, "realms", "", "Comma separated list of realms to export")
+	fs.StringVar(&out, "out", "-", "Output file, - for stdout")
+	fs.StringVar(&secretRefTemplate, "secret-ref-template", string(backup.DefaultSecretRefTemplate),
+		"Template of the secret-manager IDs env:team:app:path:checksum that replace secrets. Supports {realm}, {name} and {key}")
+	fs.BoolVar(&storeSecrets, "store-secrets", false,
+		"Store the exported secrets in the secret-manager. Otherwise only references are written")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	realmNames := splitList(realms)
+	if len(realmNames) == 0 {
+		return fmt.Errorf("--realms is required")
+	}
+	if err := backup.SecretRefTemplate(secretRefTemplate).Validate(); err != nil {
+		return err
+	}
+
+	client, err := admin.newClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	storeSecret := backup.DiscardSecrets
+	if storeSecrets {
+		storeSecret = backup.StoreSecretsInSecretManager
+	}
+	exporter := backup.NewExporter(client, backup.SecretRefTemplate(secretRefTemplate), storeSecret, admin.adminUrl)
+	result, err := exporter.Export(ctx, realmNames...)
+	if err != nil {
+		return err
+	}
+
+	return withOutput(out, func(w io.Writer) error {
+		return backup.Write(w, result)
+	})
+}
+
+func runImport(ctx context.Context, args []string) error {
+	var admin adminFlags
+	var in string
+
+	fs := flag.NewFlagSet("import", flag.ExitOnError)
+	admin.bind(fs)
+	fs.StringVar(&in, "in", "-", "Backup file, - for stdin")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	input, err := readBackup(in)
+	if err != nil {
+		return err
+	}
+	client, err := admin.newClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	return backup.NewImporter(client, backup.ResolveSecretsFromSecretManager).Import(ctx, input)
+}
+
+func runManifests(args []string) error {
+	var in, out string
+	var options backup.ManifestOptions
+
+	fs := flag.NewFlagSet("manifests", flag.ExitOnError)
+	fs.StringVar(&in, "in", "-", "Backup file, - for stdin")
+	fs.StringVar(&out, "out", "-", "Output file, - for stdout")
+	fs.StringVar(&options.Namespace, "namespace", "", "Namespace of the generated resources")
+	fs.StringVar(&options.Environment, "environment", "", "Environment label of the generated resources")
+	fs.StringVar(&options.IdentityProvider, "identity-provider", "",
+		"Name of the IdentityProvider that the realms are provisioned on")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	input, err := readBackup(in)
+	if err != nil {
+		return err
+	}
+
+	return withOutput(out, func(w io.Writer) error {
+		return backup.WriteManifests(w, input, options)
+	})
+}
+
+func readBackup(path string) (*backup.Backup, error) {
+	if path == "-" {
+		return backup.Read(os.Stdin)
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close() //nolint:errcheck
+	return backup.Read(file)
+}
+
+func withOutput(path string, write func(w io.Writer) error) error {
+	if path == "-" {
+		return write(os.Stdout)
+	}
+	// The backup does not contain secrets, but it should still only be readable by the owner
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	if err = write(file); err != nil {
+		_ = file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -30,7 +30,6 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
-	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	identityv1 "github.com/telekom/controlplane-mono/identity/api/v1"
@@ -65,7 +64,7 @@ func (r *ClientReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		For(&identityv1.Client{}).
 		Watches(&identityv1.Realm{},
 			handler.EnqueueRequestsFromMapFunc(r.mapRealmObjToIdentityClient),
-			builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+			builder.WithPredicates(dependencyChangedPredicate)).
 		WithOptions(controller.Options{
 			MaxConcurrentReconciles: 10,
 			RateLimiter:             workqueue.DefaultTypedItemBasedRateLimiter[reconcile.Request](),
@@ -0,0 +1,120 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/telekom/controlplane-mono/common/pkg/condition"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	identityv1 "github.com/telekom/controlplane-mono/identity/api/v1"
+	clientScopeModel "github.com/telekom/controlplane-mono/identity/internal/model/clientscope"
+	identityproviderModel "github.com/telekom/controlplane-mono/identity/internal/model/identityprovider"
+	realmModel "github.com/telekom/controlplane-mono/identity/internal/model/realm"
+)
+
+var _ = Describe("ClientScope Controller", func() {
+	Context("When reconciling a resource", func() {
+		ctx := context.Background()
+
+		// IDP related
+		clientScopeIdpName := "keycloak-test-clientscope"
+		clientScopeIdpRef := k8sclient.ObjectKey{
+			Name:      clientScopeIdpName,
+			Namespace: testNamespace,
+		}
+		clientScopeIdp := identityproviderModel.NewIdentityProvider(clientScopeIdpName, testNamespace, testEnvironment)
+
+		// Realm related
+		clientScopeRealmName := "realm-test-client"
+		clientScopeRealmRef := k8sclient.ObjectKey{
+			Name:      clientScopeRealmName,
+			Namespace: testNamespace,
+		}
+		clientScopeRealm := realmModel.NewRealm(clientScopeRealmName, testNamespace, testEnvironment, clientScopeIdpName)
+
+		// ClientScope related
+		clientScopeName := "test-clientscope"
+		clientScopeRef := k8sclient.ObjectKey{
+			Name:      clientScopeName,
+			Namespace: testNamespace,
+		}
+		testClientScope := clientScopeModel.NewClientScope(clientScopeName, testNamespace, testEnvironment, clientScopeRealmName)
+
+		BeforeEach(func() {
+			By("creating the custom resource for the Kind IdentityProvider")
+			NewIdentityProvider(ctx, clientScopeIdpRef, clientScopeIdp)
+
+			By("creating the custom resource for the Kind Realm")
+			NewRealm(ctx, clientScopeRealmRef, clientScopeRealm)
+			VerifyRealmIsAvailable(clientScopeRealmRef)
+
+			By("creating the custom resource for the Kind ClientScope")
+			NewClientScope(ctx, clientScopeRef, testClientScope)
+		})
+
+		AfterEach(func() {
+			By("Cleanup the specific resource instance ClientScope")
+			DeleteClientScope(ctx, clientScopeRef)
+
+			By("Cleanup the specific resource instance Realm")
+			DeleteRealm(ctx, clientScopeRealmRef)
+
+			By("deleting the custom resource for the Kind IdentityProvider")
+			DeleteIdentityProvider(ctx, clientScopeIdpRef)
+		})
+		It("should successfully reconcile the resource", func() {
+			Eventually(func(g Gomega) {
+				VerifyClientScope(ctx, g, clientScopeRef, testClientScope)
+			}, timeout, interval).Should(Succeed())
+		})
+	})
+})
+
+func VerifyClientScope(ctx context.Context, gomega Gomega, namespacedName k8sclient.ObjectKey,
+	clientScopeToVerify *identityv1.ClientScope) {
+	clientScopeResource := &identityv1.ClientScope{}
+	err := k8sClient.Get(ctx, namespacedName, clientScopeResource)
+
+	gomega.Expect(err).NotTo(HaveOccurred())
+
+	gomega.Expect(clientScopeResource.Spec).To(Equal(clientScopeToVerify.Spec))
+	gomega.Expect(clientScopeResource.Status.Conditions).To(HaveLen(2))
+	gomega.Expect(meta.IsStatusConditionTrue(clientScopeResource.Status.Conditions, condition.ConditionTypeProcessing)).To(BeFalse())
+	gomega.Expect(meta.IsStatusConditionTrue(clientScopeResource.Status.Conditions, condition.ConditionTypeReady)).To(BeTrue())
+}
+
+func NewClientScope(ctx context.Context, namespacedName k8sclient.ObjectKey, clientScope *identityv1.ClientScope) {
+	clientScopeResource := &identityv1.ClientScope{}
+	err := k8sClient.Get(ctx, namespacedName, clientScopeResource)
+	if err != nil && errors.IsNotFound(err) {
+		Expect(k8sClient.Create(ctx, clientScope)).To(Succeed())
+	}
+}
+
+func DeleteClientScope(ctx context.Context, namespacedName k8sclient.ObjectKey) {
+	clientScopeResource := &identityv1.ClientScope{}
+	err := k8sClient.Get(ctx, namespacedName, clientScopeResource)
+	Expect(err).NotTo(HaveOccurred())
+
+	Expect(k8sClient.Delete(ctx, clientScopeResource)).To(Succeed())
+}
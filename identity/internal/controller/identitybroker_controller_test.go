@@ -0,0 +1,120 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/telekom/controlplane-mono/common/pkg/condition"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	identityv1 "github.com/telekom/controlplane-mono/identity/api/v1"
+	identityBrokerModel "github.com/telekom/controlplane-mono/identity/internal/model/identitybroker"
+	identityproviderModel "github.com/telekom/controlplane-mono/identity/internal/model/identityprovider"
+	realmModel "github.com/telekom/controlplane-mono/identity/internal/model/realm"
+)
+
+var _ = Describe("IdentityBroker Controller", func() {
+	Context("When reconciling a resource", func() {
+		ctx := context.Background()
+
+		// IDP related
+		identityBrokerIdpName := "keycloak-test-identitybroker"
+		identityBrokerIdpRef := k8sclient.ObjectKey{
+			Name:      identityBrokerIdpName,
+			Namespace: testNamespace,
+		}
+		identityBrokerIdp := identityproviderModel.NewIdentityProvider(identityBrokerIdpName, testNamespace, testEnvironment)
+
+		// Realm related
+		identityBrokerRealmName := "realm-test-client"
+		identityBrokerRealmRef := k8sclient.ObjectKey{
+			Name:      identityBrokerRealmName,
+			Namespace: testNamespace,
+		}
+		identityBrokerRealm := realmModel.NewRealm(identityBrokerRealmName, testNamespace, testEnvironment, identityBrokerIdpName)
+
+		// IdentityBroker related
+		identityBrokerName := "test-identitybroker"
+		identityBrokerRef := k8sclient.ObjectKey{
+			Name:      identityBrokerName,
+			Namespace: testNamespace,
+		}
+		testIdentityBroker := identityBrokerModel.NewIdentityBroker(identityBrokerName, testNamespace, testEnvironment, identityBrokerRealmName)
+
+		BeforeEach(func() {
+			By("creating the custom resource for the Kind IdentityProvider")
+			NewIdentityProvider(ctx, identityBrokerIdpRef, identityBrokerIdp)
+
+			By("creating the custom resource for the Kind Realm")
+			NewRealm(ctx, identityBrokerRealmRef, identityBrokerRealm)
+			VerifyRealmIsAvailable(identityBrokerRealmRef)
+
+			By("creating the custom resource for the Kind IdentityBroker")
+			NewIdentityBroker(ctx, identityBrokerRef, testIdentityBroker)
+		})
+
+		AfterEach(func() {
+			By("Cleanup the specific resource instance IdentityBroker")
+			DeleteIdentityBroker(ctx, identityBrokerRef)
+
+			By("Cleanup the specific resource instance Realm")
+			DeleteRealm(ctx, identityBrokerRealmRef)
+
+			By("deleting the custom resource for the Kind IdentityProvider")
+			DeleteIdentityProvider(ctx, identityBrokerIdpRef)
+		})
+		It("should successfully reconcile the resource", func() {
+			Eventually(func(g Gomega) {
+				VerifyIdentityBroker(ctx, g, identityBrokerRef, testIdentityBroker)
+			}, timeout, interval).Should(Succeed())
+		})
+	})
+})
+
+func VerifyIdentityBroker(ctx context.Context, gomega Gomega, namespacedName k8sclient.ObjectKey,
+	identityBrokerToVerify *identityv1.IdentityBroker) {
+	identityBrokerResource := &identityv1.IdentityBroker{}
+	err := k8sClient.Get(ctx, namespacedName, identityBrokerResource)
+
+	gomega.Expect(err).NotTo(HaveOccurred())
+
+	gomega.Expect(identityBrokerResource.Spec).To(Equal(identityBrokerToVerify.Spec))
+	gomega.Expect(identityBrokerResource.Status.Conditions).To(HaveLen(2))
+	gomega.Expect(meta.IsStatusConditionTrue(identityBrokerResource.Status.Conditions, condition.ConditionTypeProcessing)).To(BeFalse())
+	gomega.Expect(meta.IsStatusConditionTrue(identityBrokerResource.Status.Conditions, condition.ConditionTypeReady)).To(BeTrue())
+}
+
+func NewIdentityBroker(ctx context.Context, namespacedName k8sclient.ObjectKey, identityBroker *identityv1.IdentityBroker) {
+	identityBrokerResource := &identityv1.IdentityBroker{}
+	err := k8sClient.Get(ctx, namespacedName, identityBrokerResource)
+	if err != nil && errors.IsNotFound(err) {
+		Expect(k8sClient.Create(ctx, identityBroker)).To(Succeed())
+	}
+}
+
+func DeleteIdentityBroker(ctx context.Context, namespacedName k8sclient.ObjectKey) {
+	identityBrokerResource := &identityv1.IdentityBroker{}
+	err := k8sClient.Get(ctx, namespacedName, identityBrokerResource)
+	Expect(err).NotTo(HaveOccurred())
+
+	Expect(k8sClient.Delete(ctx, identityBrokerResource)).To(Succeed())
+}
@@ -27,6 +27,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	identityv1 "github.com/telekom/controlplane-mono/identity/api/v1"
+	identityproviderHandler "github.com/telekom/controlplane-mono/identity/internal/handler/identityprovider"
 	identityproviderModel "github.com/telekom/controlplane-mono/identity/internal/model/identityprovider"
 )
 
@@ -71,9 +72,13 @@ func VerifyIdentityProvider(ctx context.Context, gomega Gomega, namespacedName c
 	gomega.Expect(idpResource.Status.AdminUrl).To(Equal(idpToVerify.Spec.AdminUrl))
 	gomega.Expect(idpResource.Status.AdminTokenUrl).NotTo(BeEmpty())
 	gomega.Expect(idpResource.Status.AdminConsoleUrl).NotTo(BeEmpty())
-	gomega.Expect(idpResource.Status.Conditions).To(HaveLen(2))
+	gomega.Expect(idpResource.Status.Version).To(Equal("24.0.5"))
+	gomega.Expect(idpResource.Status.LastLoginTime).NotTo(BeNil())
+	gomega.Expect(idpResource.Status.Conditions).To(HaveLen(5))
 	gomega.Expect(meta.IsStatusConditionTrue(idpResource.Status.Conditions, condition.ConditionTypeProcessing)).To(BeFalse())
 	gomega.Expect(meta.IsStatusConditionTrue(idpResource.Status.Conditions, condition.ConditionTypeReady)).To(BeTrue())
+	gomega.Expect(meta.IsStatusConditionTrue(idpResource.Status.Conditions, identityproviderHandler.ConditionTypeReachable)).To(BeTrue())
+	gomega.Expect(meta.IsStatusConditionTrue(idpResource.Status.Conditions, identityproviderHandler.ConditionTypeAuthenticated)).To(BeTrue())
 }
 
 func NewIdentityProvider(ctx context.Context, namespacedName client.ObjectKey, idp *identityv1.IdentityProvider) {
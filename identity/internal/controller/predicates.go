@@ -0,0 +1,51 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"github.com/telekom/controlplane-mono/common/pkg/condition"
+	"github.com/telekom/controlplane-mono/common/pkg/types"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// readyConditionChangedPredicate lets update events pass if the Ready condition of the object changed.
+// Dependent resources use it to react on status-only changes, e.g. an IdentityProvider becoming unreachable.
+var readyConditionChangedPredicate = predicate.Funcs{
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		oldObj, ok := e.ObjectOld.(types.Object)
+		if !ok {
+			return false
+		}
+		newObj, ok := e.ObjectNew.(types.Object)
+		if !ok {
+			return false
+		}
+		oldReady := meta.FindStatusCondition(oldObj.GetConditions(), condition.ConditionTypeReady)
+		newReady := meta.FindStatusCondition(newObj.GetConditions(), condition.ConditionTypeReady)
+		if oldReady == nil || newReady == nil {
+			return oldReady != newReady
+		}
+		return oldReady.Status != newReady.Status || oldReady.Reason != newReady.Reason
+	},
+}
+
+// dependencyChangedPredicate lets events pass if the spec or the readiness of a dependency changed
+var dependencyChangedPredicate = predicate.Or[client.Object](
+	predicate.GenerationChangedPredicate{}, readyConditionChangedPredicate)
@@ -0,0 +1,37 @@
+package controller
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/telekom/controlplane-mono/common/pkg/condition"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	identityv1 "github.com/telekom/controlplane-mono/identity/api/v1"
+)
+
+func TestReadyConditionChangedPredicateIgnoresUnchangedReadiness(t *testing.T) {
+	oldIdp := &identityv1.IdentityProvider{}
+	oldIdp.SetCondition(condition.NewReadyCondition("Ready", "IdentityProvider is ready"))
+	newIdp := oldIdp.DeepCopy()
+	newIdp.Status.Version = "24.0.5"
+
+	assert.False(t, readyConditionChangedPredicate.Update(event.UpdateEvent{ObjectOld: oldIdp, ObjectNew: newIdp}))
+}
+
+func TestReadyConditionChangedPredicateDetectsChangedReadiness(t *testing.T) {
+	oldIdp := &identityv1.IdentityProvider{}
+	oldIdp.SetCondition(condition.NewReadyCondition("Ready", "IdentityProvider is ready"))
+	newIdp := oldIdp.DeepCopy()
+	newIdp.SetCondition(condition.NewNotReadyCondition("Unreachable", "Keycloak is not reachable"))
+
+	assert.True(t, readyConditionChangedPredicate.Update(event.UpdateEvent{ObjectOld: oldIdp, ObjectNew: newIdp}))
+}
+
+func TestReadyConditionChangedPredicateDetectsFirstReadiness(t *testing.T) {
+	oldRealm := &identityv1.Realm{}
+	newRealm := oldRealm.DeepCopy()
+	newRealm.SetCondition(condition.NewReadyCondition("Ready", "Realm is ready"))
+
+	assert.True(t, readyConditionChangedPredicate.Update(event.UpdateEvent{ObjectOld: oldRealm, ObjectNew: newRealm}))
+}
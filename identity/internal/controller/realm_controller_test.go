@@ -23,6 +23,7 @@ import (
 	. "github.com/onsi/gomega"
 	ghErrors "github.com/pkg/errors"
 	"github.com/telekom/controlplane-mono/common/pkg/condition"
+	"github.com/telekom/controlplane-mono/common/pkg/types"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -53,12 +54,11 @@ var _ = Describe("Realm Controller", func() {
 		testRealm := realmModel.NewRealm(realmName, testNamespace, testEnvironment, realmIdpName)
 
 		expectedRealmStatus := identityv1.RealmStatus{
-			IssuerUrl:     "https://iris-distcp1-dataplane1.dev.dhei.telekom.de/auth/realms/test-realm",
-			AdminClientId: "admin-cli",
-			AdminUserName: "admin",
-			AdminPassword: "password",
-			AdminUrl:      "https://iris-distcp1-dataplane1.dev.dhei.telekom.de/auth/admin/realms/",
-			AdminTokenUrl: "https://iris-distcp1-dataplane1.dev.dhei.telekom.de/auth/realms/master/protocol/openid-connect/token",
+			IssuerUrl: "https://iris-distcp1-dataplane1.dev.dhei.telekom.de/auth/realms/test-realm",
+			IdentityProvider: &types.ObjectRef{
+				Name:      realmIdpName,
+				Namespace: testNamespace,
+			},
 		}
 
 		BeforeEach(func() {
@@ -94,11 +94,10 @@ func VerifyRealm(ctx context.Context, gomega Gomega, namespacedName client.Objec
 	gomega.Expect(realmResource.Spec).To(Equal(realmToVerify.Spec))
 	gomega.Expect(realmResource.Status.Conditions).To(HaveLen(2))
 	gomega.Expect(realmResource.Status.IssuerUrl).To(Equal(expectedRealmStatus.IssuerUrl))
-	gomega.Expect(realmResource.Status.AdminClientId).To(Equal(expectedRealmStatus.AdminClientId))
-	gomega.Expect(realmResource.Status.AdminUserName).To(Equal(expectedRealmStatus.AdminUserName))
-	gomega.Expect(realmResource.Status.AdminPassword).To(Equal(expectedRealmStatus.AdminPassword))
-	gomega.Expect(realmResource.Status.AdminUrl).To(Equal(expectedRealmStatus.AdminUrl))
-	gomega.Expect(realmResource.Status.AdminTokenUrl).To(Equal(expectedRealmStatus.AdminTokenUrl))
+	gomega.Expect(realmResource.Status.IdentityProvider).NotTo(BeNil())
+	gomega.Expect(realmResource.Status.IdentityProvider.Name).To(Equal(expectedRealmStatus.IdentityProvider.Name))
+	gomega.Expect(realmResource.Status.IdentityProvider.Namespace).
+		To(Equal(expectedRealmStatus.IdentityProvider.Namespace))
 	gomega.Expect(meta.IsStatusConditionTrue(realmResource.Status.Conditions, condition.ConditionTypeProcessing)).To(BeFalse())
 	gomega.Expect(meta.IsStatusConditionTrue(realmResource.Status.Conditions, condition.ConditionTypeReady)).To(BeTrue())
 
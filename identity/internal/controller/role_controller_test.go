@@ -0,0 +1,120 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/telekom/controlplane-mono/common/pkg/condition"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	identityv1 "github.com/telekom/controlplane-mono/identity/api/v1"
+	identityproviderModel "github.com/telekom/controlplane-mono/identity/internal/model/identityprovider"
+	realmModel "github.com/telekom/controlplane-mono/identity/internal/model/realm"
+	roleModel "github.com/telekom/controlplane-mono/identity/internal/model/role"
+)
+
+var _ = Describe("Role Controller", func() {
+	Context("When reconciling a resource", func() {
+		ctx := context.Background()
+
+		// IDP related
+		roleIdpName := "keycloak-test-role"
+		roleIdpRef := k8sclient.ObjectKey{
+			Name:      roleIdpName,
+			Namespace: testNamespace,
+		}
+		roleIdp := identityproviderModel.NewIdentityProvider(roleIdpName, testNamespace, testEnvironment)
+
+		// Realm related
+		roleRealmName := "realm-test-client"
+		roleRealmRef := k8sclient.ObjectKey{
+			Name:      roleRealmName,
+			Namespace: testNamespace,
+		}
+		roleRealm := realmModel.NewRealm(roleRealmName, testNamespace, testEnvironment, roleIdpName)
+
+		// Role related
+		roleName := "test-role"
+		roleRef := k8sclient.ObjectKey{
+			Name:      roleName,
+			Namespace: testNamespace,
+		}
+		testRole := roleModel.NewRole(roleName, testNamespace, testEnvironment, roleRealmName)
+
+		BeforeEach(func() {
+			By("creating the custom resource for the Kind IdentityProvider")
+			NewIdentityProvider(ctx, roleIdpRef, roleIdp)
+
+			By("creating the custom resource for the Kind Realm")
+			NewRealm(ctx, roleRealmRef, roleRealm)
+			VerifyRealmIsAvailable(roleRealmRef)
+
+			By("creating the custom resource for the Kind Role")
+			NewRole(ctx, roleRef, testRole)
+		})
+
+		AfterEach(func() {
+			By("Cleanup the specific resource instance Role")
+			DeleteRole(ctx, roleRef)
+
+			By("Cleanup the specific resource instance Realm")
+			DeleteRealm(ctx, roleRealmRef)
+
+			By("deleting the custom resource for the Kind IdentityProvider")
+			DeleteIdentityProvider(ctx, roleIdpRef)
+		})
+		It("should successfully reconcile the resource", func() {
+			Eventually(func(g Gomega) {
+				VerifyRole(ctx, g, roleRef, testRole)
+			}, timeout, interval).Should(Succeed())
+		})
+	})
+})
+
+func VerifyRole(ctx context.Context, gomega Gomega, namespacedName k8sclient.ObjectKey,
+	roleToVerify *identityv1.Role) {
+	roleResource := &identityv1.Role{}
+	err := k8sClient.Get(ctx, namespacedName, roleResource)
+
+	gomega.Expect(err).NotTo(HaveOccurred())
+
+	gomega.Expect(roleResource.Spec).To(Equal(roleToVerify.Spec))
+	gomega.Expect(roleResource.Status.Conditions).To(HaveLen(2))
+	gomega.Expect(meta.IsStatusConditionTrue(roleResource.Status.Conditions, condition.ConditionTypeProcessing)).To(BeFalse())
+	gomega.Expect(meta.IsStatusConditionTrue(roleResource.Status.Conditions, condition.ConditionTypeReady)).To(BeTrue())
+}
+
+func NewRole(ctx context.Context, namespacedName k8sclient.ObjectKey, role *identityv1.Role) {
+	roleResource := &identityv1.Role{}
+	err := k8sClient.Get(ctx, namespacedName, roleResource)
+	if err != nil && errors.IsNotFound(err) {
+		Expect(k8sClient.Create(ctx, role)).To(Succeed())
+	}
+}
+
+func DeleteRole(ctx context.Context, namespacedName k8sclient.ObjectKey) {
+	roleResource := &identityv1.Role{}
+	err := k8sClient.Get(ctx, namespacedName, roleResource)
+	Expect(err).NotTo(HaveOccurred())
+
+	Expect(k8sClient.Delete(ctx, roleResource)).To(Succeed())
+}
@@ -126,12 +126,30 @@ var _ = BeforeSuite(func() {
 	}).SetupWithManager(k8sManager)
 	Expect(err).ToNot(HaveOccurred())
 
+	err = (&ClientScopeReconciler{
+		Client: k8sManager.GetClient(),
+		Scheme: k8sManager.GetScheme(),
+	}).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
+	err = (&RoleReconciler{
+		Client: k8sManager.GetClient(),
+		Scheme: k8sManager.GetScheme(),
+	}).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
+	err = (&IdentityBrokerReconciler{
+		Client: k8sManager.GetClient(),
+		Scheme: k8sManager.GetScheme(),
+	}).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
 	By("Setting up the required mocks")
-	keycloak.GetClientFor = func(realmStatus identityv1.RealmStatus) (keycloak.RealmClient, error) {
+	keycloak.GetClientFor = func(config keycloak.AdminConfig) (keycloak.RealmClient, error) {
 		if mockKeycloak {
 			return utils.NewRealmClientMock(GinkgoT()), nil
 		} else {
-			return keycloak.GetClientForRealm(realmStatus)
+			return keycloak.GetClientForConfig(config)
 		}
 	}
 
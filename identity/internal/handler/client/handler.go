@@ -5,14 +5,15 @@ import (
 	"fmt"
 
 	"github.com/pkg/errors"
+	"github.com/telekom/controlplane-mono/common/pkg/condition"
 	"github.com/telekom/controlplane-mono/common/pkg/handler"
 	"github.com/telekom/controlplane-mono/common/pkg/util/contextutil"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	identityv1 "github.com/telekom/controlplane-mono/identity/api/v1"
 	realmHandler "github.com/telekom/controlplane-mono/identity/internal/handler/realm"
-	"github.com/telekom/controlplane-mono/identity/pkg/keycloak"
 	secrets "github.com/telekom/controlplane-mono/secret-manager/pkg/api"
 )
 
@@ -34,7 +35,7 @@ func (h *HandlerClient) CreateOrUpdate(ctx context.Context, client *identityv1.C
 		return errors.Wrap(err, "failed to get client secret from secret-manager")
 	}
 
-	realm, err := realmHandler.GetRealmByName(ctx, client.Spec.Realm)
+	realm, err := realmHandler.GetRealm(ctx, client.Spec.Realm)
 	if err != nil {
 		if apierrors.IsNotFound(err) {
 			contextutil.RecorderFromContextOrDie(ctx).
@@ -45,8 +46,17 @@ func (h *HandlerClient) CreateOrUpdate(ctx context.Context, client *identityv1.C
 		}
 		return err
 	}
-	realmStatus := realmHandler.ObfuscateRealm(realm.Status)
-	logger.V(0).Info("Found Realm", "realm", realmStatus)
+	if reason, blocked := condition.IsBlocked(realm); blocked {
+		message := fmt.Sprintf("Realm '%s' is blocked: %s", client.Spec.Realm.String(), reason)
+		contextutil.RecorderFromContextOrDie(ctx).Event(client, "Warning", "RealmBlocked", message)
+		SetStatusBlockedBy(&client.Status, client, "RealmBlocked", message)
+		return nil
+	}
+	if !meta.IsStatusConditionTrue(realm.GetConditions(), condition.ConditionTypeReady) {
+		SetStatusWaiting(&client.Status, client)
+		return nil
+	}
+	logger.V(0).Info("Found Realm", "realm", realm.Status)
 
 	var clientStatus = MapToClientStatus(&realm.Status)
 	err = realmHandler.ValidateRealmStatus(&realm.Status)
@@ -58,13 +68,9 @@ func (h *HandlerClient) CreateOrUpdate(ctx context.Context, client *identityv1.C
 		return errors.Wrap(err, "❌ failed to validate realm")
 	}
 
-	realmStatus.AdminPassword, err = secrets.Get(ctx, realmStatus.AdminPassword)
-	if err != nil {
-		return errors.Wrap(err, "failed to retrieve password from secret manager")
-	}
-	realmClient, err := keycloak.GetClientFor(realm.Status)
+	realmClient, err := realmHandler.GetKeycloakClientFor(ctx, realm)
 	if err != nil {
-		return errors.Wrap(err, "❌ failed to get keycloak client")
+		return err
 	}
 
 	err = realmClient.CreateOrUpdateRealmClient(ctx, realm, client)
@@ -72,6 +78,11 @@ func (h *HandlerClient) CreateOrUpdate(ctx context.Context, client *identityv1.C
 		return errors.Wrap(err, "❌ failed to create or update client")
 	}
 
+	err = realmClient.AssignServiceAccountRoles(ctx, realm, client)
+	if err != nil {
+		return errors.Wrap(err, "❌ failed to assign roles to client")
+	}
+
 	SetStatusReady(&clientStatus, client)
 	var message = fmt.Sprintf("✅ RealmClient %s is ready", client.Spec.ClientId)
 	logger.V(1).Info(message, "IssuerUrl", clientStatus.IssuerUrl)
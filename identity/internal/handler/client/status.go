@@ -46,6 +46,13 @@ func SetStatusBlocked(currentStatus *identityv1.ClientStatus, client *identityv1
 	client.SetCondition(blockedNotReadyCondition)
 }
 
+// SetStatusBlockedBy blocks the client because a dependency is not available
+func SetStatusBlockedBy(currentStatus *identityv1.ClientStatus, client *identityv1.Client, reason, message string) {
+	client.Status = *currentStatus
+	client.SetCondition(condition.NewBlockedCondition(message))
+	client.SetCondition(condition.NewNotReadyCondition(reason, message))
+}
+
 func SetStatusWaiting(currentStatus *identityv1.ClientStatus, client *identityv1.Client) {
 	client.Status = *currentStatus
 	client.SetCondition(waitingCondition)
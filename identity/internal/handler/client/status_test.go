@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/telekom/controlplane-mono/common/pkg/condition"
+	"k8s.io/apimachinery/pkg/api/meta"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	identityv1 "github.com/telekom/controlplane-mono/identity/api/v1"
@@ -43,6 +45,20 @@ func TestSetStatusBlockedSetsClientStatusCorrectly(t *testing.T) {
 	assert.True(t, HasConditions(t, client, []v1.Condition{blockedCondition, blockedNotReadyCondition}))
 }
 
+func TestSetStatusBlockedBySetsClientStatusCorrectly(t *testing.T) {
+	currentStatus := &identityv1.ClientStatus{
+		IssuerUrl: "https://issuer.example.com",
+	}
+	client := &identityv1.Client{}
+
+	SetStatusBlockedBy(currentStatus, client, "RealmBlocked", "Realm 'default/realm' is blocked")
+
+	message, blocked := condition.IsBlocked(client)
+	assert.True(t, blocked)
+	assert.Equal(t, "Realm 'default/realm' is blocked", message)
+	assert.Equal(t, "RealmBlocked", meta.FindStatusCondition(client.GetConditions(), condition.ConditionTypeReady).Reason)
+}
+
 func TestSetStatusWaitingSetsClientStatusCorrectly(t *testing.T) {
 	currentStatus := &identityv1.ClientStatus{
 		IssuerUrl: "https://issuer.example.com",
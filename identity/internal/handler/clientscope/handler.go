@@ -0,0 +1,91 @@
+package clientscope
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/telekom/controlplane-mono/common/pkg/condition"
+	"github.com/telekom/controlplane-mono/common/pkg/handler"
+	"github.com/telekom/controlplane-mono/common/pkg/util/contextutil"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	identityv1 "github.com/telekom/controlplane-mono/identity/api/v1"
+	realmHandler "github.com/telekom/controlplane-mono/identity/internal/handler/realm"
+)
+
+var _ handler.Handler[*identityv1.ClientScope] = &HandlerClientScope{}
+
+type HandlerClientScope struct{}
+
+func (h *HandlerClientScope) CreateOrUpdate(ctx context.Context, clientScope *identityv1.ClientScope) error {
+	logger := log.FromContext(ctx)
+
+	if clientScope == nil {
+		return fmt.Errorf("clientScope is nil")
+	}
+
+	SetStatusProcessing(clientScope)
+
+	realm, err := realmHandler.GetRealm(ctx, clientScope.Spec.Realm)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			contextutil.RecorderFromContextOrDie(ctx).
+				Eventf(clientScope, "Warning", "RealmNotFound",
+					"Realm '%s' not found", clientScope.Spec.Realm.String())
+			SetStatusBlocked(clientScope)
+			return nil
+		}
+		return err
+	}
+	if reason, blocked := condition.IsBlocked(realm); blocked {
+		message := fmt.Sprintf("Realm '%s' is blocked: %s", clientScope.Spec.Realm.String(), reason)
+		contextutil.RecorderFromContextOrDie(ctx).Event(clientScope, "Warning", "RealmBlocked", message)
+		SetStatusBlockedBy(clientScope, "RealmBlocked", message)
+		return nil
+	}
+	if !meta.IsStatusConditionTrue(realm.GetConditions(), condition.ConditionTypeReady) {
+		SetStatusWaiting(clientScope)
+		return nil
+	}
+
+	realmClient, err := realmHandler.GetKeycloakClientFor(ctx, realm)
+	if err != nil {
+		return err
+	}
+
+	err = realmClient.CreateOrUpdateRealmClientScope(ctx, realm, clientScope)
+	if err != nil {
+		return errors.Wrap(err, "❌ failed to create or update client scope")
+	}
+
+	SetStatusReady(clientScope)
+	var message = fmt.Sprintf("✅ ClientScope %s is ready", clientScope.Spec.Name)
+	logger.V(1).Info(message)
+
+	return nil
+}
+
+func (h *HandlerClientScope) Delete(ctx context.Context, clientScope *identityv1.ClientScope) error {
+	realm, err := realmHandler.GetRealmByName(ctx, clientScope.Spec.Realm)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			// Without a realm there is nothing left to clean up in keycloak
+			return nil
+		}
+		return err
+	}
+	if realm == nil {
+		return fmt.Errorf("realm %s is not ready", clientScope.Spec.Realm.String())
+	}
+
+	realmClient, err := realmHandler.GetKeycloakClientFor(ctx, realm)
+	if err != nil {
+		return err
+	}
+
+	return errors.Wrap(realmClient.DeleteRealmClientScope(ctx, realm, clientScope),
+		"❌ failed to delete client scope")
+}
@@ -0,0 +1,55 @@
+package clientscope
+
+import (
+	"github.com/telekom/controlplane-mono/common/pkg/condition"
+
+	identityv1 "github.com/telekom/controlplane-mono/identity/api/v1"
+)
+
+var (
+	// Processing
+	processingCondition = condition.NewProcessingCondition("ClientScopeProcessing",
+		"Processing client scope")
+	processingNotReadyCondition = condition.NewNotReadyCondition("ClientScopeNotReady",
+		"ClientScope not ready")
+
+	// Blocked
+	blockedCondition         = condition.NewBlockedCondition("Realm not found")
+	blockedNotReadyCondition = condition.NewNotReadyCondition("RealmNotFound", "Realm not found")
+
+	// Waiting
+	waitingCondition = condition.NewProcessingCondition("ClientScopeProcessing",
+		"Waiting for Realm to be processed")
+	waitingNotReadyCondition = condition.NewNotReadyCondition("ClientScopeProcessing",
+		"Waiting for Realm to be processed")
+
+	// Ready
+	doneProcessingCondition = condition.NewDoneProcessingCondition("Created ClientScope")
+	readyCondition          = condition.NewReadyCondition("Ready", "ClientScope is ready")
+)
+
+func SetStatusProcessing(clientScope *identityv1.ClientScope) {
+	clientScope.SetCondition(processingCondition)
+	clientScope.SetCondition(processingNotReadyCondition)
+}
+
+func SetStatusBlocked(clientScope *identityv1.ClientScope) {
+	clientScope.SetCondition(blockedCondition)
+	clientScope.SetCondition(blockedNotReadyCondition)
+}
+
+// SetStatusBlockedBy blocks the client scope because a dependency is not available
+func SetStatusBlockedBy(clientScope *identityv1.ClientScope, reason, message string) {
+	clientScope.SetCondition(condition.NewBlockedCondition(message))
+	clientScope.SetCondition(condition.NewNotReadyCondition(reason, message))
+}
+
+func SetStatusWaiting(clientScope *identityv1.ClientScope) {
+	clientScope.SetCondition(waitingCondition)
+	clientScope.SetCondition(waitingNotReadyCondition)
+}
+
+func SetStatusReady(clientScope *identityv1.ClientScope) {
+	clientScope.SetCondition(doneProcessingCondition)
+	clientScope.SetCondition(readyCondition)
+}
@@ -0,0 +1,67 @@
+package clientscope
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/telekom/controlplane-mono/common/pkg/condition"
+	"k8s.io/apimachinery/pkg/api/meta"
+
+	identityv1 "github.com/telekom/controlplane-mono/identity/api/v1"
+)
+
+func TestSetStatusProcessingSetsConditions(t *testing.T) {
+	clientScope := &identityv1.ClientScope{}
+
+	SetStatusProcessing(clientScope)
+
+	assert.True(t, meta.IsStatusConditionTrue(clientScope.GetConditions(), condition.ConditionTypeProcessing))
+	assert.True(t, meta.IsStatusConditionFalse(clientScope.GetConditions(), condition.ConditionTypeReady))
+}
+
+func TestSetStatusBlockedSetsConditions(t *testing.T) {
+	clientScope := &identityv1.ClientScope{}
+
+	SetStatusBlocked(clientScope)
+
+	assert.Equal(t, blockedCondition.Reason,
+		meta.FindStatusCondition(clientScope.GetConditions(), condition.ConditionTypeProcessing).Reason)
+	assert.True(t, meta.IsStatusConditionFalse(clientScope.GetConditions(), condition.ConditionTypeReady))
+}
+
+func TestSetStatusBlockedBySetsConditions(t *testing.T) {
+	clientScope := &identityv1.ClientScope{}
+
+	SetStatusBlockedBy(clientScope, "RealmBlocked", "Realm 'default/realm' is blocked")
+
+	message, blocked := condition.IsBlocked(clientScope)
+	assert.True(t, blocked)
+	assert.Equal(t, "Realm 'default/realm' is blocked", message)
+	assert.Equal(t, "RealmBlocked", meta.FindStatusCondition(clientScope.GetConditions(), condition.ConditionTypeReady).Reason)
+}
+
+func TestSetStatusWaitingSetsConditions(t *testing.T) {
+	clientScope := &identityv1.ClientScope{}
+
+	SetStatusWaiting(clientScope)
+
+	assert.True(t, meta.IsStatusConditionTrue(clientScope.GetConditions(), condition.ConditionTypeProcessing))
+	assert.True(t, meta.IsStatusConditionFalse(clientScope.GetConditions(), condition.ConditionTypeReady))
+}
+
+func TestSetStatusReadySetsConditions(t *testing.T) {
+	clientScope := &identityv1.ClientScope{}
+	SetStatusProcessing(clientScope)
+
+	SetStatusReady(clientScope)
+
+	assert.True(t, meta.IsStatusConditionFalse(clientScope.GetConditions(), condition.ConditionTypeProcessing))
+	assert.True(t, meta.IsStatusConditionTrue(clientScope.GetConditions(), condition.ConditionTypeReady))
+}
+
+func TestSetStatusReadyHandlesNilClientScope(t *testing.T) {
+	var clientScope *identityv1.ClientScope
+	assert.Panics(t, func() {
+		SetStatusReady(clientScope)
+	})
+}
@@ -0,0 +1,119 @@
+package identitybroker
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/telekom/controlplane-mono/common/pkg/condition"
+	"github.com/telekom/controlplane-mono/common/pkg/handler"
+	"github.com/telekom/controlplane-mono/common/pkg/util/contextutil"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	identityv1 "github.com/telekom/controlplane-mono/identity/api/v1"
+	realmHandler "github.com/telekom/controlplane-mono/identity/internal/handler/realm"
+	secrets "github.com/telekom/controlplane-mono/secret-manager/pkg/api"
+)
+
+var _ handler.Handler[*identityv1.IdentityBroker] = &HandlerIdentityBroker{}
+
+type HandlerIdentityBroker struct{}
+
+func (h *HandlerIdentityBroker) CreateOrUpdate(ctx context.Context, broker *identityv1.IdentityBroker) error {
+	logger := log.FromContext(ctx)
+
+	if broker == nil {
+		return fmt.Errorf("identityBroker is nil")
+	}
+
+	SetStatusProcessing(broker)
+
+	realm, err := realmHandler.GetRealm(ctx, broker.Spec.Realm)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			contextutil.RecorderFromContextOrDie(ctx).
+				Eventf(broker, "Warning", "RealmNotFound",
+					"Realm '%s' not found", broker.Spec.Realm.String())
+			SetStatusBlocked(broker)
+			return nil
+		}
+		return err
+	}
+	if reason, blocked := condition.IsBlocked(realm); blocked {
+		message := fmt.Sprintf("Realm '%s' is blocked: %s", broker.Spec.Realm.String(), reason)
+		contextutil.RecorderFromContextOrDie(ctx).Event(broker, "Warning", "RealmBlocked", message)
+		SetStatusBlockedBy(broker, "RealmBlocked", message)
+		return nil
+	}
+	if !meta.IsStatusConditionTrue(realm.GetConditions(), condition.ConditionTypeReady) {
+		SetStatusWaiting(broker)
+		return nil
+	}
+
+	resolvedBroker, err := ResolveSecrets(ctx, broker)
+	if err != nil {
+		return err
+	}
+
+	realmClient, err := realmHandler.GetKeycloakClientFor(ctx, realm)
+	if err != nil {
+		return err
+	}
+
+	err = realmClient.CreateOrUpdateRealmIdentityBroker(ctx, realm, resolvedBroker)
+	if err != nil {
+		return errors.Wrap(err, "❌ failed to create or update identity broker")
+	}
+
+	SetStatusReady(broker)
+	var message = fmt.Sprintf("✅ IdentityBroker %s is ready", broker.Spec.Alias)
+	logger.V(1).Info(message)
+
+	return nil
+}
+
+func (h *HandlerIdentityBroker) Delete(ctx context.Context, broker *identityv1.IdentityBroker) error {
+	realm, err := realmHandler.GetRealmByName(ctx, broker.Spec.Realm)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			// Without a realm there is nothing left to clean up in keycloak
+			return nil
+		}
+		return err
+	}
+	if realm == nil {
+		return fmt.Errorf("realm %s is not ready", broker.Spec.Realm.String())
+	}
+
+	realmClient, err := realmHandler.GetKeycloakClientFor(ctx, realm)
+	if err != nil {
+		return err
+	}
+
+	return errors.Wrap(realmClient.DeleteRealmIdentityBroker(ctx, realm, broker),
+		"❌ failed to delete identity broker")
+}
+
+// ResolveSecrets returns a copy of the broker with all secret-manager references replaced by their values.
+// The original broker is never modified so that the secrets are not accidentally written back to the cluster.
+func ResolveSecrets(ctx context.Context, broker *identityv1.IdentityBroker) (*identityv1.IdentityBroker, error) {
+	var err error
+	resolvedBroker := broker.DeepCopy()
+
+	if resolvedBroker.Spec.OIDC != nil {
+		resolvedBroker.Spec.OIDC.ClientSecret, err = secrets.Get(ctx, broker.Spec.OIDC.ClientSecret)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get client secret from secret-manager")
+		}
+	}
+	if resolvedBroker.Spec.SAML != nil && resolvedBroker.Spec.SAML.SigningCertificate != "" {
+		resolvedBroker.Spec.SAML.SigningCertificate, err = secrets.Get(ctx, broker.Spec.SAML.SigningCertificate)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get signing certificate from secret-manager")
+		}
+	}
+
+	return resolvedBroker, nil
+}
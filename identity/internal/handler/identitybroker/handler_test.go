@@ -0,0 +1,42 @@
+package identitybroker
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	identityv1 "github.com/telekom/controlplane-mono/identity/api/v1"
+)
+
+func TestResolveSecretsDoesNotModifyBroker(t *testing.T) {
+	broker := &identityv1.IdentityBroker{
+		Spec: identityv1.IdentityBrokerSpec{
+			ProviderType: identityv1.IdentityBrokerTypeOIDC,
+			OIDC: &identityv1.OIDCBrokerConfig{
+				ClientId:     "test-client",
+				ClientSecret: "plain-secret",
+			},
+		},
+	}
+
+	resolvedBroker, err := ResolveSecrets(context.Background(), broker)
+
+	assert.NoError(t, err)
+	assert.NotSame(t, broker.Spec.OIDC, resolvedBroker.Spec.OIDC)
+	assert.Equal(t, "plain-secret", resolvedBroker.Spec.OIDC.ClientSecret)
+}
+
+func TestResolveSecretsSkipsMissingSigningCertificate(t *testing.T) {
+	broker := &identityv1.IdentityBroker{
+		Spec: identityv1.IdentityBrokerSpec{
+			ProviderType: identityv1.IdentityBrokerTypeSAML,
+			SAML:         &identityv1.SAMLBrokerConfig{SingleSignOnServiceUrl: "https://idp.example.com/sso"},
+		},
+	}
+
+	resolvedBroker, err := ResolveSecrets(context.Background(), broker)
+
+	assert.NoError(t, err)
+	assert.Empty(t, resolvedBroker.Spec.SAML.SigningCertificate)
+}
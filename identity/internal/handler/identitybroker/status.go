@@ -0,0 +1,55 @@
+package identitybroker
+
+import (
+	"github.com/telekom/controlplane-mono/common/pkg/condition"
+
+	identityv1 "github.com/telekom/controlplane-mono/identity/api/v1"
+)
+
+var (
+	// Processing
+	processingCondition = condition.NewProcessingCondition("IdentityBrokerProcessing",
+		"Processing identity broker")
+	processingNotReadyCondition = condition.NewNotReadyCondition("IdentityBrokerNotReady",
+		"IdentityBroker not ready")
+
+	// Blocked
+	blockedCondition         = condition.NewBlockedCondition("Realm not found")
+	blockedNotReadyCondition = condition.NewNotReadyCondition("RealmNotFound", "Realm not found")
+
+	// Waiting
+	waitingCondition = condition.NewProcessingCondition("IdentityBrokerProcessing",
+		"Waiting for Realm to be processed")
+	waitingNotReadyCondition = condition.NewNotReadyCondition("IdentityBrokerProcessing",
+		"Waiting for Realm to be processed")
+
+	// Ready
+	doneProcessingCondition = condition.NewDoneProcessingCondition("Created IdentityBroker")
+	readyCondition          = condition.NewReadyCondition("Ready", "IdentityBroker is ready")
+)
+
+func SetStatusProcessing(broker *identityv1.IdentityBroker) {
+	broker.SetCondition(processingCondition)
+	broker.SetCondition(processingNotReadyCondition)
+}
+
+func SetStatusBlocked(broker *identityv1.IdentityBroker) {
+	broker.SetCondition(blockedCondition)
+	broker.SetCondition(blockedNotReadyCondition)
+}
+
+// SetStatusBlockedBy blocks the identity broker because a dependency is not available
+func SetStatusBlockedBy(broker *identityv1.IdentityBroker, reason, message string) {
+	broker.SetCondition(condition.NewBlockedCondition(message))
+	broker.SetCondition(condition.NewNotReadyCondition(reason, message))
+}
+
+func SetStatusWaiting(broker *identityv1.IdentityBroker) {
+	broker.SetCondition(waitingCondition)
+	broker.SetCondition(waitingNotReadyCondition)
+}
+
+func SetStatusReady(broker *identityv1.IdentityBroker) {
+	broker.SetCondition(doneProcessingCondition)
+	broker.SetCondition(readyCondition)
+}
@@ -0,0 +1,67 @@
+package identitybroker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/telekom/controlplane-mono/common/pkg/condition"
+	"k8s.io/apimachinery/pkg/api/meta"
+
+	identityv1 "github.com/telekom/controlplane-mono/identity/api/v1"
+)
+
+func TestSetStatusProcessingSetsConditions(t *testing.T) {
+	broker := &identityv1.IdentityBroker{}
+
+	SetStatusProcessing(broker)
+
+	assert.True(t, meta.IsStatusConditionTrue(broker.GetConditions(), condition.ConditionTypeProcessing))
+	assert.True(t, meta.IsStatusConditionFalse(broker.GetConditions(), condition.ConditionTypeReady))
+}
+
+func TestSetStatusBlockedSetsConditions(t *testing.T) {
+	broker := &identityv1.IdentityBroker{}
+
+	SetStatusBlocked(broker)
+
+	assert.Equal(t, blockedCondition.Reason,
+		meta.FindStatusCondition(broker.GetConditions(), condition.ConditionTypeProcessing).Reason)
+	assert.True(t, meta.IsStatusConditionFalse(broker.GetConditions(), condition.ConditionTypeReady))
+}
+
+func TestSetStatusBlockedBySetsConditions(t *testing.T) {
+	broker := &identityv1.IdentityBroker{}
+
+	SetStatusBlockedBy(broker, "RealmBlocked", "Realm 'default/realm' is blocked")
+
+	message, blocked := condition.IsBlocked(broker)
+	assert.True(t, blocked)
+	assert.Equal(t, "Realm 'default/realm' is blocked", message)
+	assert.Equal(t, "RealmBlocked", meta.FindStatusCondition(broker.GetConditions(), condition.ConditionTypeReady).Reason)
+}
+
+func TestSetStatusWaitingSetsConditions(t *testing.T) {
+	broker := &identityv1.IdentityBroker{}
+
+	SetStatusWaiting(broker)
+
+	assert.True(t, meta.IsStatusConditionTrue(broker.GetConditions(), condition.ConditionTypeProcessing))
+	assert.True(t, meta.IsStatusConditionFalse(broker.GetConditions(), condition.ConditionTypeReady))
+}
+
+func TestSetStatusReadySetsConditions(t *testing.T) {
+	broker := &identityv1.IdentityBroker{}
+	SetStatusProcessing(broker)
+
+	SetStatusReady(broker)
+
+	assert.True(t, meta.IsStatusConditionFalse(broker.GetConditions(), condition.ConditionTypeProcessing))
+	assert.True(t, meta.IsStatusConditionTrue(broker.GetConditions(), condition.ConditionTypeReady))
+}
+
+func TestSetStatusReadyHandlesNilClientScope(t *testing.T) {
+	var broker *identityv1.IdentityBroker
+	assert.Panics(t, func() {
+		SetStatusReady(broker)
+	})
+}
@@ -12,7 +12,8 @@ import (
 	identityv1 "github.com/telekom/controlplane-mono/identity/api/v1"
 )
 
-func GetIdentityProviderByName(
+// GetIdentityProvider returns the identity provider regardless of its readiness
+func GetIdentityProvider(
 	ctx context.Context,
 	identityProviderRef *common.ObjectRef) (*identityv1.IdentityProvider, error) {
 	clientFromContext := client.ClientFromContextOrDie(ctx)
@@ -23,6 +24,17 @@ func GetIdentityProviderByName(
 		return nil,
 			errors.Wrapf(err, "failed to get identityProvider %s", identityProviderRef.String())
 	}
+	return identityProvider, nil
+}
+
+// GetIdentityProviderByName returns the identity provider or nil, if it is not ready yet
+func GetIdentityProviderByName(
+	ctx context.Context,
+	identityProviderRef *common.ObjectRef) (*identityv1.IdentityProvider, error) {
+	identityProvider, err := GetIdentityProvider(ctx, identityProviderRef)
+	if err != nil {
+		return nil, err
+	}
 	if !meta.IsStatusConditionTrue(identityProvider.GetConditions(), condition.ConditionTypeReady) {
 		return nil, nil
 	}
@@ -4,10 +4,14 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/pkg/errors"
 	"github.com/telekom/controlplane-mono/common/pkg/handler"
+	"github.com/telekom/controlplane-mono/common/pkg/util/contextutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	identityv1 "github.com/telekom/controlplane-mono/identity/api/v1"
+	"github.com/telekom/controlplane-mono/identity/pkg/keycloak"
 )
 
 var _ handler.Handler[*identityv1.IdentityProvider] = &HandlerIdentityProvider{}
@@ -16,11 +20,49 @@ type HandlerIdentityProvider struct{}
 
 func (h *HandlerIdentityProvider) CreateOrUpdate(ctx context.Context, idp *identityv1.IdentityProvider) error {
 	logger := log.FromContext(ctx)
+
 	if idp == nil {
 		return fmt.Errorf("IdentityProvider is nil")
 	}
 
 	var idpStatus = MapToIdpStatus(&idp.Spec)
+	// Keep the last known values, if keycloak can not be reached
+	idpStatus.Version = idp.Status.Version
+	idpStatus.LastLoginTime = idp.Status.LastLoginTime
+
+	adminConfig, err := keycloak.Credentials.AdminConfigFor(ctx, idp)
+	if err != nil {
+		return errors.Wrap(err, "failed to resolve admin credentials")
+	}
+
+	// Logging in is part of creating the client
+	realmClient, err := keycloak.GetClientFor(adminConfig)
+	if err != nil {
+		if keycloak.IsAuthenticationError(err) {
+			SetStatusLoginFailed(&idpStatus, idp, err)
+			contextutil.RecorderFromContextOrDie(ctx).
+				Eventf(idp, "Warning", "LoginFailed", "Login with admin credentials failed")
+		} else {
+			SetStatusUnreachable(&idpStatus, idp, err)
+			contextutil.RecorderFromContextOrDie(ctx).
+				Eventf(idp, "Warning", "Unreachable", "Keycloak is not reachable")
+		}
+		logger.V(0).Info("❌ IdentityProvider is not available", "error", err.Error())
+		return nil
+	}
+	loginTime := metav1.Now()
+	idpStatus.LastLoginTime = &loginTime
+
+	serverInfo, err := realmClient.GetServerInfo(ctx)
+	if err != nil {
+		SetStatusUnreachable(&idpStatus, idp, err)
+		contextutil.RecorderFromContextOrDie(ctx).
+			Eventf(idp, "Warning", "Unreachable", "Keycloak server info is not available")
+		logger.V(0).Info("❌ IdentityProvider is not available", "error", err.Error())
+		return nil
+	}
+	idpStatus.Version = keycloak.GetServerVersion(serverInfo)
+
 	SetStatusReady(&idpStatus, idp)
 	var message = fmt.Sprintf("✅ IdentityProvider %s is ready", idp.Name)
 	logger.V(1).Info(message, "IdentityProviderStatus", idpStatus)
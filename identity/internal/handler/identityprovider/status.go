@@ -1,13 +1,26 @@
 package identityprovider
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/telekom/controlplane-mono/common/pkg/condition"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/telekom/controlplane-mono/identity/pkg/keycloak"
 
 	identityv1 "github.com/telekom/controlplane-mono/identity/api/v1"
 )
 
+const (
+	// ConditionTypeReachable is true if the server-info endpoint of keycloak could be called
+	ConditionTypeReachable = "Reachable"
+	// ConditionTypeAuthenticated is true if the last login with the admin credentials succeeded
+	ConditionTypeAuthenticated = "Authenticated"
+	// ConditionTypeVersionDiscovered is true if keycloak reported its version
+	ConditionTypeVersionDiscovered = "VersionDiscovered"
+)
+
 var (
 	// Ready
 	doneProcessingCondition = condition.NewDoneProcessingCondition("Created IdentityProvider")
@@ -22,8 +35,51 @@ func MapToIdpStatus(idpSpec *identityv1.IdentityProviderSpec) identityv1.Identit
 	}
 }
 
+func newHealthCondition(conditionType string, status metav1.ConditionStatus, reason, message string) metav1.Condition {
+	return metav1.Condition{
+		Type:    conditionType,
+		Status:  status,
+		Reason:  reason,
+		Message: message,
+	}
+}
+
 func SetStatusReady(currentStatus *identityv1.IdentityProviderStatus, idp *identityv1.IdentityProvider) {
 	idp.Status = *currentStatus
 	idp.SetCondition(doneProcessingCondition)
 	idp.SetCondition(readyCondition)
+	idp.SetCondition(newHealthCondition(ConditionTypeReachable, metav1.ConditionTrue,
+		"Reachable", "Keycloak server info received"))
+	if currentStatus.LastLoginTime != nil {
+		idp.SetCondition(newHealthCondition(ConditionTypeAuthenticated, metav1.ConditionTrue,
+			"LoginSucceeded", fmt.Sprintf("Last successful login at %s",
+				currentStatus.LastLoginTime.UTC().Format(time.RFC3339))))
+	}
+	if currentStatus.Version != "" {
+		idp.SetCondition(newHealthCondition(ConditionTypeVersionDiscovered, metav1.ConditionTrue,
+			"VersionDiscovered", fmt.Sprintf("Keycloak version %s", currentStatus.Version)))
+	}
+}
+
+// SetStatusUnreachable blocks the identity provider because keycloak could not be reached
+func SetStatusUnreachable(currentStatus *identityv1.IdentityProviderStatus, idp *identityv1.IdentityProvider,
+	err error) {
+	message := fmt.Sprintf("Keycloak is not reachable: %s", err.Error())
+	idp.Status = *currentStatus
+	idp.SetCondition(condition.NewBlockedCondition(message))
+	idp.SetCondition(condition.NewNotReadyCondition("Unreachable", message))
+	idp.SetCondition(newHealthCondition(ConditionTypeReachable, metav1.ConditionFalse, "Unreachable", message))
+}
+
+// SetStatusLoginFailed blocks the identity provider because keycloak rejected the admin credentials
+func SetStatusLoginFailed(currentStatus *identityv1.IdentityProviderStatus, idp *identityv1.IdentityProvider,
+	err error) {
+	message := fmt.Sprintf("Login with admin credentials failed: %s", err.Error())
+	idp.Status = *currentStatus
+	idp.SetCondition(condition.NewBlockedCondition(message))
+	idp.SetCondition(condition.NewNotReadyCondition("LoginFailed", message))
+	// Keycloak answered the token request, so it is reachable
+	idp.SetCondition(newHealthCondition(ConditionTypeReachable, metav1.ConditionTrue,
+		"Reachable", "Keycloak answered the token request"))
+	idp.SetCondition(newHealthCondition(ConditionTypeAuthenticated, metav1.ConditionFalse, "LoginFailed", message))
 }
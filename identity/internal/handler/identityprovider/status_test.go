@@ -1,9 +1,12 @@
 package identityprovider
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/telekom/controlplane-mono/common/pkg/condition"
+	"k8s.io/apimachinery/pkg/api/meta"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	identityv1 "github.com/telekom/controlplane-mono/identity/api/v1"
@@ -36,6 +39,50 @@ func TestSetStatusReadySetsIdpStatusCorrectly(t *testing.T) {
 	assert.True(t, HasConditions(t, idp, []v1.Condition{doneProcessingCondition, readyCondition}))
 }
 
+func TestSetStatusReadySetsHealthConditions(t *testing.T) {
+	loginTime := v1.Now()
+	currentStatus := &identityv1.IdentityProviderStatus{
+		Version:       "24.0.5",
+		LastLoginTime: &loginTime,
+	}
+	idp := &identityv1.IdentityProvider{}
+
+	SetStatusReady(currentStatus, idp)
+
+	assert.True(t, meta.IsStatusConditionTrue(idp.GetConditions(), ConditionTypeReachable))
+	assert.True(t, meta.IsStatusConditionTrue(idp.GetConditions(), ConditionTypeAuthenticated))
+	assert.Contains(t, meta.FindStatusCondition(idp.GetConditions(), ConditionTypeVersionDiscovered).Message,
+		"24.0.5")
+}
+
+func TestSetStatusUnreachableBlocksIdp(t *testing.T) {
+	currentStatus := &identityv1.IdentityProviderStatus{Version: "24.0.5"}
+	idp := &identityv1.IdentityProvider{}
+
+	SetStatusUnreachable(currentStatus, idp, fmt.Errorf("connection refused"))
+
+	message, blocked := condition.IsBlocked(idp)
+	assert.True(t, blocked)
+	assert.Contains(t, message, "connection refused")
+	assert.Equal(t, "24.0.5", idp.Status.Version)
+	assert.True(t, meta.IsStatusConditionFalse(idp.GetConditions(), condition.ConditionTypeReady))
+	assert.True(t, meta.IsStatusConditionFalse(idp.GetConditions(), ConditionTypeReachable))
+}
+
+func TestSetStatusLoginFailedBlocksIdp(t *testing.T) {
+	currentStatus := &identityv1.IdentityProviderStatus{}
+	idp := &identityv1.IdentityProvider{}
+
+	SetStatusLoginFailed(currentStatus, idp, fmt.Errorf("invalid_grant"))
+
+	message, blocked := condition.IsBlocked(idp)
+	assert.True(t, blocked)
+	assert.Contains(t, message, "invalid_grant")
+	assert.Equal(t, "LoginFailed", meta.FindStatusCondition(idp.GetConditions(), condition.ConditionTypeReady).Reason)
+	assert.True(t, meta.IsStatusConditionTrue(idp.GetConditions(), ConditionTypeReachable))
+	assert.True(t, meta.IsStatusConditionFalse(idp.GetConditions(), ConditionTypeAuthenticated))
+}
+
 func TestSetStatusReadyHandlesNilIdp(t *testing.T) {
 	currentStatus := &identityv1.IdentityProviderStatus{
 		AdminUrl: "https://admin.example.com",
@@ -11,9 +11,12 @@ import (
 	"k8s.io/apimachinery/pkg/api/meta"
 
 	identityv1 "github.com/telekom/controlplane-mono/identity/api/v1"
+	"github.com/telekom/controlplane-mono/identity/internal/handler/identityprovider"
+	"github.com/telekom/controlplane-mono/identity/pkg/keycloak"
 )
 
-func GetRealmByName(ctx context.Context, realmRef *common.ObjectRef) (*identityv1.Realm, error) {
+// GetRealm returns the realm regardless of its readiness
+func GetRealm(ctx context.Context, realmRef *common.ObjectRef) (*identityv1.Realm, error) {
 	clientFromContext := client.ClientFromContextOrDie(ctx)
 
 	realm := &identityv1.Realm{}
@@ -21,6 +24,15 @@ func GetRealmByName(ctx context.Context, realmRef *common.ObjectRef) (*identityv
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to get realm %s", realmRef.String())
 	}
+	return realm, nil
+}
+
+// GetRealmByName returns the realm or nil, if it is not ready yet
+func GetRealmByName(ctx context.Context, realmRef *common.ObjectRef) (*identityv1.Realm, error) {
+	realm, err := GetRealm(ctx, realmRef)
+	if err != nil {
+		return nil, err
+	}
 	if !meta.IsStatusConditionTrue(realm.GetConditions(), condition.ConditionTypeReady) {
 		return nil, nil
 	}
@@ -34,35 +46,29 @@ func ValidateRealmStatus(realmStatus *identityv1.RealmStatus) error {
 	if realmStatus.IssuerUrl == "" {
 		return fmt.Errorf("realmStatus.IssuerUrl is empty")
 	}
-	if realmStatus.AdminClientId == "" {
-		return fmt.Errorf("realmStatus.AdminClientId is empty")
-	}
-	if realmStatus.AdminUserName == "" {
-		return fmt.Errorf("realmStatus.AdminUserName is empty")
-	}
-	if realmStatus.AdminPassword == "" {
-		return fmt.Errorf("realmStatus.AdminPassword is empty")
-	}
-	if realmStatus.AdminUrl == "" {
-		return fmt.Errorf("realmStatus.AdminUrl is empty")
-	}
-	if realmStatus.AdminTokenUrl == "" {
-		return fmt.Errorf("realmStatus.AdminTokenUrl is empty")
+	if realmStatus.IdentityProvider == nil {
+		return fmt.Errorf("realmStatus.IdentityProvider is empty")
 	}
 	return nil
 }
 
-func ObfuscateRealm(status identityv1.RealmStatus) identityv1.RealmStatus {
-	// Create a copy of the status to avoid modifying the original
-	obfuscatedStatus := status
-
-	// Obfuscate sensitive fields
-	if obfuscatedStatus.AdminUserName != "" {
-		obfuscatedStatus.AdminUserName = "****"
+// GetKeycloakClientFor validates the status of the realm and returns a keycloak client
+// that is authenticated with the admin credentials of the referenced IdentityProvider.
+// The credentials are resolved at use time and are never stored in the realm.
+func GetKeycloakClientFor(ctx context.Context, realm *identityv1.Realm) (keycloak.RealmClient, error) {
+	err := ValidateRealmStatus(&realm.Status)
+	if err != nil {
+		return nil, errors.Wrap(err, "❌ failed to validate realm")
 	}
-	if obfuscatedStatus.AdminPassword != "" {
-		obfuscatedStatus.AdminPassword = "****"
+
+	identityProvider, err := identityprovider.GetIdentityProvider(ctx, realm.Status.IdentityProvider)
+	if err != nil {
+		return nil, err
 	}
 
-	return obfuscatedStatus
+	realmClient, err := keycloak.GetClientForIdentityProvider(ctx, identityProvider)
+	if err != nil {
+		return nil, errors.Wrap(err, "❌ failed to get keycloak client")
+	}
+	return realmClient, nil
 }
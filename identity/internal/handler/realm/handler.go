@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/pkg/errors"
+	"github.com/telekom/controlplane-mono/common/pkg/condition"
 	"github.com/telekom/controlplane-mono/common/pkg/handler"
 	"github.com/telekom/controlplane-mono/common/pkg/util/contextutil"
 	"sigs.k8s.io/controller-runtime/pkg/log"
@@ -13,8 +14,8 @@ import (
 	"github.com/telekom/controlplane-mono/identity/internal/handler/identityprovider"
 	"github.com/telekom/controlplane-mono/identity/pkg/keycloak"
 
-	secrets "github.com/telekom/controlplane-mono/secret-manager/pkg/api"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 )
 
 var _ handler.Handler[*identityv1.Realm] = &HandlerRealm{}
@@ -29,17 +30,27 @@ func (h *HandlerRealm) CreateOrUpdate(ctx context.Context, realm *identityv1.Rea
 
 	SetStatusProcessing(&realm.Status, realm)
 
-	identityProvider, err := identityprovider.GetIdentityProviderByName(ctx, realm.Spec.IdentityProvider)
+	identityProvider, err := identityprovider.GetIdentityProvider(ctx, realm.Spec.IdentityProvider)
 	if err != nil {
 		if apierrors.IsNotFound(err) {
 			contextutil.RecorderFromContextOrDie(ctx).
-				Eventf(identityProvider, "Warning", "IdentityProviderNotFound",
+				Eventf(realm, "Warning", "IdentityProviderNotFound",
 					"IdentityProvider '%s' not found", realm.Spec.IdentityProvider.String())
 			SetStatusBlocked(&realm.Status, realm)
 			return nil
 		}
 		return err
 	}
+	if reason, blocked := condition.IsBlocked(identityProvider); blocked {
+		message := fmt.Sprintf("IdentityProvider '%s' is blocked: %s", realm.Spec.IdentityProvider.String(), reason)
+		contextutil.RecorderFromContextOrDie(ctx).Event(realm, "Warning", "IdentityProviderBlocked", message)
+		SetStatusBlockedBy(&realm.Status, realm, "IdentityProviderBlocked", message)
+		return nil
+	}
+	if !meta.IsStatusConditionTrue(identityProvider.GetConditions(), condition.ConditionTypeReady) {
+		SetStatusWaiting(&realm.Status, realm)
+		return nil
+	}
 	idpSpec := identityprovider.ObfuscateIdentityProvider(identityProvider.Spec)
 	logger.V(0).Info("Found IdentityProvider", "idp", idpSpec)
 
@@ -53,15 +64,7 @@ func (h *HandlerRealm) CreateOrUpdate(ctx context.Context, realm *identityv1.Rea
 		return errors.Wrap(err, "❌ failed to validate IdentityProvider")
 	}
 
-	// Create a copy of the realmStatus so that we NEVER modify the original status
-	// and accidentally write the secrets back to the cluster
-	replacedRealmStatus := realmStatus.DeepCopy()
-	replacedRealmStatus.AdminPassword, err = secrets.Get(ctx, realmStatus.AdminPassword)
-	if err != nil {
-		return errors.Wrap(err, "failed to retrieve password from secret manager")
-	}
-
-	realmClient, err := keycloak.GetClientFor(*replacedRealmStatus)
+	realmClient, err := keycloak.GetClientForIdentityProvider(ctx, identityProvider)
 	if err != nil {
 		return errors.Wrap(err, "❌ failed to get keycloak client")
 	}
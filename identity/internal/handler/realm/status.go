@@ -2,6 +2,7 @@ package realm
 
 import (
 	"github.com/telekom/controlplane-mono/common/pkg/condition"
+	common "github.com/telekom/controlplane-mono/common/pkg/types"
 
 	identityv1 "github.com/telekom/controlplane-mono/identity/api/v1"
 	"github.com/telekom/controlplane-mono/identity/pkg/keycloak"
@@ -32,12 +33,8 @@ var (
 
 func MapToRealmStatus(identityProvider *identityv1.IdentityProvider, realmName string) identityv1.RealmStatus {
 	return identityv1.RealmStatus{
-		IssuerUrl:     keycloak.DetermineIssuerUrlFrom(identityProvider.Spec.AdminUrl, realmName),
-		AdminClientId: identityProvider.Spec.AdminClientId,
-		AdminUserName: identityProvider.Spec.AdminUserName,
-		AdminPassword: identityProvider.Spec.AdminPassword,
-		AdminUrl:      identityProvider.Status.AdminUrl,
-		AdminTokenUrl: identityProvider.Status.AdminTokenUrl,
+		IssuerUrl:        keycloak.DetermineIssuerUrlFrom(identityProvider.Spec.AdminUrl, realmName),
+		IdentityProvider: common.ObjectRefFromObject(identityProvider),
 	}
 }
 
@@ -53,6 +50,13 @@ func SetStatusBlocked(currentStatus *identityv1.RealmStatus, realm *identityv1.R
 	realm.SetCondition(blockedNotReadyCondition)
 }
 
+// SetStatusBlockedBy blocks the realm because a dependency is not available
+func SetStatusBlockedBy(currentStatus *identityv1.RealmStatus, realm *identityv1.Realm, reason, message string) {
+	realm.Status = *currentStatus
+	realm.SetCondition(condition.NewBlockedCondition(message))
+	realm.SetCondition(condition.NewNotReadyCondition(reason, message))
+}
+
 func SetStatusWaiting(currentStatus *identityv1.RealmStatus, realm *identityv1.Realm) {
 	realm.Status = *currentStatus
 	realm.SetCondition(waitingCondition)
@@ -4,6 +4,9 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/telekom/controlplane-mono/common/pkg/condition"
+	common "github.com/telekom/controlplane-mono/common/pkg/types"
+	"k8s.io/apimachinery/pkg/api/meta"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	identityv1 "github.com/telekom/controlplane-mono/identity/api/v1"
@@ -12,6 +15,10 @@ import (
 
 func TestMapToRealmStatusMapsCorrectly(t *testing.T) {
 	identityProvider := &identityv1.IdentityProvider{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "idp",
+			Namespace: "default",
+		},
 		Spec: identityv1.IdentityProviderSpec{
 			AdminUrl:      "https://admin.example.com",
 			AdminClientId: "admin-client-id",
@@ -28,110 +35,84 @@ func TestMapToRealmStatusMapsCorrectly(t *testing.T) {
 	realmStatus := MapToRealmStatus(identityProvider, realmName)
 
 	assert.Equal(t, keycloak.DetermineIssuerUrlFrom(identityProvider.Spec.AdminUrl, realmName), realmStatus.IssuerUrl)
-	assert.Equal(t, identityProvider.Spec.AdminClientId, realmStatus.AdminClientId)
-	assert.Equal(t, identityProvider.Spec.AdminUserName, realmStatus.AdminUserName)
-	assert.Equal(t, identityProvider.Spec.AdminPassword, realmStatus.AdminPassword)
-
-	assert.Equal(t, identityProvider.Status.AdminUrl, realmStatus.AdminUrl)
-	assert.Equal(t, identityProvider.Status.AdminTokenUrl, realmStatus.AdminTokenUrl)
+	assert.Equal(t, &common.ObjectRef{Name: "idp", Namespace: "default"}, realmStatus.IdentityProvider)
 }
 
 func TestSetStatusBlockedSetsRealmStatusCorrectly(t *testing.T) {
 	currentStatus := &identityv1.RealmStatus{
-		IssuerUrl:     "https://issuer.example.com",
-		AdminClientId: "admin-client-id",
-		AdminUserName: "admin-username",
-		AdminPassword: "admin-password",
-		AdminUrl:      "https://admin.example.com",
-		AdminTokenUrl: "https://admin.example.com/token",
+		IssuerUrl:        "https://issuer.example.com",
+		IdentityProvider: &common.ObjectRef{Name: "idp", Namespace: "default"},
 	}
 	realm := &identityv1.Realm{}
 
 	SetStatusBlocked(currentStatus, realm)
 
 	assert.Equal(t, currentStatus.IssuerUrl, realm.Status.IssuerUrl)
-	assert.Equal(t, currentStatus.AdminClientId, realm.Status.AdminClientId)
-	assert.Equal(t, currentStatus.AdminUserName, realm.Status.AdminUserName)
-	assert.Equal(t, currentStatus.AdminPassword, realm.Status.AdminPassword)
-	assert.Equal(t, currentStatus.AdminUrl, realm.Status.AdminUrl)
-	assert.Equal(t, currentStatus.AdminTokenUrl, realm.Status.AdminTokenUrl)
+	assert.Equal(t, currentStatus.IdentityProvider, realm.Status.IdentityProvider)
 	assert.True(t, HasConditions(t, realm, []v1.Condition{blockedCondition, blockedNotReadyCondition}))
 }
 
+func TestSetStatusBlockedBySetsRealmStatusCorrectly(t *testing.T) {
+	currentStatus := &identityv1.RealmStatus{
+		IssuerUrl: "https://issuer.example.com",
+	}
+	realm := &identityv1.Realm{}
+
+	SetStatusBlockedBy(currentStatus, realm, "IdentityProviderBlocked", "IdentityProvider 'default/idp' is blocked")
+
+	message, blocked := condition.IsBlocked(realm)
+	assert.True(t, blocked)
+	assert.Equal(t, "IdentityProvider 'default/idp' is blocked", message)
+	assert.Equal(t, "IdentityProviderBlocked",
+		meta.FindStatusCondition(realm.GetConditions(), condition.ConditionTypeReady).Reason)
+}
+
 func TestSetStatusProcessingSetsRealmStatusCorrectly(t *testing.T) {
 	currentStatus := &identityv1.RealmStatus{
-		IssuerUrl:     "https://issuer.example.com",
-		AdminClientId: "admin-client-id",
-		AdminUserName: "admin-username",
-		AdminPassword: "admin-password",
-		AdminUrl:      "https://admin.example.com",
-		AdminTokenUrl: "https://admin.example.com/token",
+		IssuerUrl:        "https://issuer.example.com",
+		IdentityProvider: &common.ObjectRef{Name: "idp", Namespace: "default"},
 	}
 	realm := &identityv1.Realm{}
 
 	SetStatusProcessing(currentStatus, realm)
 
 	assert.Equal(t, currentStatus.IssuerUrl, realm.Status.IssuerUrl)
-	assert.Equal(t, currentStatus.AdminClientId, realm.Status.AdminClientId)
-	assert.Equal(t, currentStatus.AdminUserName, realm.Status.AdminUserName)
-	assert.Equal(t, currentStatus.AdminPassword, realm.Status.AdminPassword)
-	assert.Equal(t, currentStatus.AdminUrl, realm.Status.AdminUrl)
-	assert.Equal(t, currentStatus.AdminTokenUrl, realm.Status.AdminTokenUrl)
+	assert.Equal(t, currentStatus.IdentityProvider, realm.Status.IdentityProvider)
 	assert.True(t, HasConditions(t, realm, []v1.Condition{processingCondition, processingNotReadyCondition}))
 }
 
 func TestSetStatusWaitingSetsRealmStatusCorrectly(t *testing.T) {
 	currentStatus := &identityv1.RealmStatus{
-		IssuerUrl:     "https://issuer.example.com",
-		AdminClientId: "admin-client-id",
-		AdminUserName: "admin-username",
-		AdminPassword: "admin-password",
-		AdminUrl:      "https://admin.example.com",
-		AdminTokenUrl: "https://admin.example.com/token",
+		IssuerUrl:        "https://issuer.example.com",
+		IdentityProvider: &common.ObjectRef{Name: "idp", Namespace: "default"},
 	}
 	realm := &identityv1.Realm{}
 
 	SetStatusWaiting(currentStatus, realm)
 
 	assert.Equal(t, currentStatus.IssuerUrl, realm.Status.IssuerUrl)
-	assert.Equal(t, currentStatus.AdminClientId, realm.Status.AdminClientId)
-	assert.Equal(t, currentStatus.AdminUserName, realm.Status.AdminUserName)
-	assert.Equal(t, currentStatus.AdminPassword, realm.Status.AdminPassword)
-	assert.Equal(t, currentStatus.AdminUrl, realm.Status.AdminUrl)
-	assert.Equal(t, currentStatus.AdminTokenUrl, realm.Status.AdminTokenUrl)
+	assert.Equal(t, currentStatus.IdentityProvider, realm.Status.IdentityProvider)
 	assert.True(t, HasConditions(t, realm, []v1.Condition{waitingCondition, waitingNotReadyCondition}))
 }
 
 func TestSetStatusReadySetsRealmStatusCorrectly(t *testing.T) {
 	currentStatus := &identityv1.RealmStatus{
-		IssuerUrl:     "https://issuer.example.com",
-		AdminClientId: "admin-client-id",
-		AdminUserName: "admin-username",
-		AdminPassword: "admin-password",
-		AdminUrl:      "https://admin.example.com",
-		AdminTokenUrl: "https://admin.example.com/token",
+		IssuerUrl:        "https://issuer.example.com",
+		IdentityProvider: &common.ObjectRef{Name: "idp", Namespace: "default"},
 	}
 	realm := &identityv1.Realm{}
 
 	SetStatusReady(currentStatus, realm)
 
 	assert.Equal(t, currentStatus.IssuerUrl, realm.Status.IssuerUrl)
-	assert.Equal(t, currentStatus.AdminClientId, realm.Status.AdminClientId)
-	assert.Equal(t, currentStatus.AdminUserName, realm.Status.AdminUserName)
-	assert.Equal(t, currentStatus.AdminPassword, realm.Status.AdminPassword)
-	assert.Equal(t, currentStatus.AdminUrl, realm.Status.AdminUrl)
-	assert.Equal(t, currentStatus.AdminTokenUrl, realm.Status.AdminTokenUrl)
+	assert.Equal(t, currentStatus.IdentityProvider, realm.Status.IdentityProvider)
 	assert.True(t, HasConditions(t, realm, []v1.Condition{doneProcessingCondition, readyCondition}))
 }
 
 func TestSetStatusReadyHandlesNilRealm(t *testing.T) {
 	currentStatus := &identityv1.RealmStatus{
-		IssuerUrl:     "https://issuer.example.com",
-		AdminClientId: "admin-client-id",
-		AdminUserName: "admin-username",
-		AdminPassword: "admin-password",
-		AdminUrl:      "https://admin.example.com",
-		AdminTokenUrl: "https://admin.example.com/token",
+		IssuerUrl:        "https://issuer.example.com",
+		IdentityProvider: &common.ObjectRef{Name: "idp", Namespace: "default"},
 	}
 	var realm *identityv1.Realm
 
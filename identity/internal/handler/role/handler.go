@@ -0,0 +1,91 @@
+package role
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/telekom/controlplane-mono/common/pkg/condition"
+	"github.com/telekom/controlplane-mono/common/pkg/handler"
+	"github.com/telekom/controlplane-mono/common/pkg/util/contextutil"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	identityv1 "github.com/telekom/controlplane-mono/identity/api/v1"
+	realmHandler "github.com/telekom/controlplane-mono/identity/internal/handler/realm"
+)
+
+var _ handler.Handler[*identityv1.Role] = &HandlerRole{}
+
+type HandlerRole struct{}
+
+func (h *HandlerRole) CreateOrUpdate(ctx context.Context, role *identityv1.Role) error {
+	logger := log.FromContext(ctx)
+
+	if role == nil {
+		return fmt.Errorf("role is nil")
+	}
+
+	SetStatusProcessing(role)
+
+	realm, err := realmHandler.GetRealm(ctx, role.Spec.Realm)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			contextutil.RecorderFromContextOrDie(ctx).
+				Eventf(role, "Warning", "RealmNotFound",
+					"Realm '%s' not found", role.Spec.Realm.String())
+			SetStatusBlocked(role)
+			return nil
+		}
+		return err
+	}
+	if reason, blocked := condition.IsBlocked(realm); blocked {
+		message := fmt.Sprintf("Realm '%s' is blocked: %s", role.Spec.Realm.String(), reason)
+		contextutil.RecorderFromContextOrDie(ctx).Event(role, "Warning", "RealmBlocked", message)
+		SetStatusBlockedBy(role, "RealmBlocked", message)
+		return nil
+	}
+	if !meta.IsStatusConditionTrue(realm.GetConditions(), condition.ConditionTypeReady) {
+		SetStatusWaiting(role)
+		return nil
+	}
+
+	realmClient, err := realmHandler.GetKeycloakClientFor(ctx, realm)
+	if err != nil {
+		return err
+	}
+
+	err = realmClient.CreateOrUpdateRealmRole(ctx, realm, role)
+	if err != nil {
+		return errors.Wrap(err, "❌ failed to create or update role")
+	}
+
+	SetStatusReady(role)
+	var message = fmt.Sprintf("✅ Role %s is ready", role.Spec.Name)
+	logger.V(1).Info(message)
+
+	return nil
+}
+
+func (h *HandlerRole) Delete(ctx context.Context, role *identityv1.Role) error {
+	realm, err := realmHandler.GetRealmByName(ctx, role.Spec.Realm)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			// Without a realm there is nothing left to clean up in keycloak
+			return nil
+		}
+		return err
+	}
+	if realm == nil {
+		return fmt.Errorf("realm %s is not ready", role.Spec.Realm.String())
+	}
+
+	realmClient, err := realmHandler.GetKeycloakClientFor(ctx, realm)
+	if err != nil {
+		return err
+	}
+
+	return errors.Wrap(realmClient.DeleteRealmRole(ctx, realm, role),
+		"❌ failed to delete role")
+}
@@ -0,0 +1,55 @@
+package role
+
+import (
+	"github.com/telekom/controlplane-mono/common/pkg/condition"
+
+	identityv1 "github.com/telekom/controlplane-mono/identity/api/v1"
+)
+
+var (
+	// Processing
+	processingCondition = condition.NewProcessingCondition("RoleProcessing",
+		"Processing role")
+	processingNotReadyCondition = condition.NewNotReadyCondition("RoleNotReady",
+		"Role not ready")
+
+	// Blocked
+	blockedCondition         = condition.NewBlockedCondition("Realm not found")
+	blockedNotReadyCondition = condition.NewNotReadyCondition("RealmNotFound", "Realm not found")
+
+	// Waiting
+	waitingCondition = condition.NewProcessingCondition("RoleProcessing",
+		"Waiting for Realm to be processed")
+	waitingNotReadyCondition = condition.NewNotReadyCondition("RoleProcessing",
+		"Waiting for Realm to be processed")
+
+	// Ready
+	doneProcessingCondition = condition.NewDoneProcessingCondition("Created Role")
+	readyCondition          = condition.NewReadyCondition("Ready", "Role is ready")
+)
+
+func SetStatusProcessing(role *identityv1.Role) {
+	role.SetCondition(processingCondition)
+	role.SetCondition(processingNotReadyCondition)
+}
+
+func SetStatusBlocked(role *identityv1.Role) {
+	role.SetCondition(blockedCondition)
+	role.SetCondition(blockedNotReadyCondition)
+}
+
+// SetStatusBlockedBy blocks the role because a dependency is not available
+func SetStatusBlockedBy(role *identityv1.Role, reason, message string) {
+	role.SetCondition(condition.NewBlockedCondition(message))
+	role.SetCondition(condition.NewNotReadyCondition(reason, message))
+}
+
+func SetStatusWaiting(role *identityv1.Role) {
+	role.SetCondition(waitingCondition)
+	role.SetCondition(waitingNotReadyCondition)
+}
+
+func SetStatusReady(role *identityv1.Role) {
+	role.SetCondition(doneProcessingCondition)
+	role.SetCondition(readyCondition)
+}
@@ -0,0 +1,67 @@
+package role
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/telekom/controlplane-mono/common/pkg/condition"
+	"k8s.io/apimachinery/pkg/api/meta"
+
+	identityv1 "github.com/telekom/controlplane-mono/identity/api/v1"
+)
+
+func TestSetStatusProcessingSetsConditions(t *testing.T) {
+	role := &identityv1.Role{}
+
+	SetStatusProcessing(role)
+
+	assert.True(t, meta.IsStatusConditionTrue(role.GetConditions(), condition.ConditionTypeProcessing))
+	assert.True(t, meta.IsStatusConditionFalse(role.GetConditions(), condition.ConditionTypeReady))
+}
+
+func TestSetStatusBlockedSetsConditions(t *testing.T) {
+	role := &identityv1.Role{}
+
+	SetStatusBlocked(role)
+
+	assert.Equal(t, blockedCondition.Reason,
+		meta.FindStatusCondition(role.GetConditions(), condition.ConditionTypeProcessing).Reason)
+	assert.True(t, meta.IsStatusConditionFalse(role.GetConditions(), condition.ConditionTypeReady))
+}
+
+func TestSetStatusBlockedBySetsConditions(t *testing.T) {
+	role := &identityv1.Role{}
+
+	SetStatusBlockedBy(role, "RealmBlocked", "Realm 'default/realm' is blocked")
+
+	message, blocked := condition.IsBlocked(role)
+	assert.True(t, blocked)
+	assert.Equal(t, "Realm 'default/realm' is blocked", message)
+	assert.Equal(t, "RealmBlocked", meta.FindStatusCondition(role.GetConditions(), condition.ConditionTypeReady).Reason)
+}
+
+func TestSetStatusWaitingSetsConditions(t *testing.T) {
+	role := &identityv1.Role{}
+
+	SetStatusWaiting(role)
+
+	assert.True(t, meta.IsStatusConditionTrue(role.GetConditions(), condition.ConditionTypeProcessing))
+	assert.True(t, meta.IsStatusConditionFalse(role.GetConditions(), condition.ConditionTypeReady))
+}
+
+func TestSetStatusReadySetsConditions(t *testing.T) {
+	role := &identityv1.Role{}
+	SetStatusProcessing(role)
+
+	SetStatusReady(role)
+
+	assert.True(t, meta.IsStatusConditionFalse(role.GetConditions(), condition.ConditionTypeProcessing))
+	assert.True(t, meta.IsStatusConditionTrue(role.GetConditions(), condition.ConditionTypeReady))
+}
+
+func TestSetStatusReadyHandlesNilRole(t *testing.T) {
+	var role *identityv1.Role
+	assert.Panics(t, func() {
+		SetStatusReady(role)
+	})
+}
@@ -0,0 +1,38 @@
+package clientscope
+
+import (
+	"github.com/telekom/controlplane-mono/common/pkg/config"
+	"github.com/telekom/controlplane-mono/common/pkg/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	identityv1 "github.com/telekom/controlplane-mono/identity/api/v1"
+)
+
+func NewClientScopeSpec(realmName string, namespace string) *identityv1.ClientScopeSpec {
+	return &identityv1.ClientScopeSpec{
+		Realm: &types.ObjectRef{
+			Name:      realmName,
+			Namespace: namespace,
+		},
+		Name:        "test-scope",
+		Description: "test-description",
+		Protocol:    "openid-connect",
+	}
+}
+
+func NewClientScopeMeta(name string, namespace string, environment string) *metav1.ObjectMeta {
+	return &metav1.ObjectMeta{
+		Name:      name,
+		Namespace: namespace,
+		Labels: map[string]string{
+			config.EnvironmentLabelKey: environment,
+		},
+	}
+}
+
+func NewClientScope(name string, namespace string, environment string, realmName string) *identityv1.ClientScope {
+	return &identityv1.ClientScope{
+		ObjectMeta: *NewClientScopeMeta(name, namespace, environment),
+		Spec:       *NewClientScopeSpec(realmName, namespace),
+	}
+}
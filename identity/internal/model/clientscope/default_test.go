@@ -0,0 +1,48 @@
+package clientscope
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/telekom/controlplane-mono/common/pkg/config"
+)
+
+const (
+	name        = "test-name"
+	namespace   = "test-namespace"
+	environment = "test-environment"
+	scopeName   = "test-scope"
+)
+
+func TestNewClientScopeSpecIsCreatedCorrectly(t *testing.T) {
+	spec := NewClientScopeSpec(name, namespace)
+
+	assert.NotNil(t, spec)
+	assert.Equal(t, name, spec.Realm.Name)
+	assert.Equal(t, namespace, spec.Realm.Namespace)
+	assert.Equal(t, scopeName, spec.Name)
+	assert.Equal(t, "openid-connect", spec.Protocol)
+}
+
+func TestNewClientScopeMetaIsCreatedCorrectly(t *testing.T) {
+	meta := NewClientScopeMeta(name, namespace, environment)
+
+	assert.NotNil(t, meta)
+	assert.Equal(t, name, meta.Name)
+	assert.Equal(t, namespace, meta.Namespace)
+	assert.Equal(t, environment, meta.Labels[config.EnvironmentLabelKey])
+}
+
+func TestNewClientScopeIsCreatedCorrectly(t *testing.T) {
+	realmName := "test-realm"
+
+	clientScope := NewClientScope(name, namespace, environment, realmName)
+
+	assert.NotNil(t, clientScope)
+	assert.Equal(t, name, clientScope.ObjectMeta.Name)
+	assert.Equal(t, namespace, clientScope.ObjectMeta.Namespace)
+	assert.Equal(t, environment, clientScope.ObjectMeta.Labels[config.EnvironmentLabelKey])
+	assert.Equal(t, realmName, clientScope.Spec.Realm.Name)
+	assert.Equal(t, namespace, clientScope.Spec.Realm.Namespace)
+	assert.Equal(t, scopeName, clientScope.Spec.Name)
+}
@@ -0,0 +1,58 @@
+package identitybroker
+
+import (
+	"github.com/telekom/controlplane-mono/common/pkg/config"
+	"github.com/telekom/controlplane-mono/common/pkg/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	identityv1 "github.com/telekom/controlplane-mono/identity/api/v1"
+)
+
+func NewIdentityBrokerSpec(realmName string, namespace string) *identityv1.IdentityBrokerSpec {
+	return &identityv1.IdentityBrokerSpec{
+		Realm: &types.ObjectRef{
+			Name:      realmName,
+			Namespace: namespace,
+		},
+		Alias:        "test-broker",
+		DisplayName:  "Test Broker",
+		ProviderType: identityv1.IdentityBrokerTypeOIDC,
+		Enabled:      ptr.To(true),
+		OIDC: &identityv1.OIDCBrokerConfig{
+			AuthorizationUrl: "https://upstream.example.com/auth",
+			TokenUrl:         "https://upstream.example.com/token",
+			ClientId:         "test-client",
+			ClientSecret:     "test-secret",
+			ClientAuthMethod: "client_secret_post",
+		},
+		Mappers: []identityv1.IdentityBrokerMapper{
+			{
+				Name: "email",
+				Type: "oidc-user-attribute-idp-mapper",
+				Config: map[string]string{
+					"claim":          "email",
+					"user.attribute": "email",
+				},
+			},
+		},
+	}
+}
+
+func NewIdentityBrokerMeta(name string, namespace string, environment string) *metav1.ObjectMeta {
+	return &metav1.ObjectMeta{
+		Name:      name,
+		Namespace: namespace,
+		Labels: map[string]string{
+			config.EnvironmentLabelKey: environment,
+		},
+	}
+}
+
+func NewIdentityBroker(name string, namespace string, environment string,
+	realmName string) *identityv1.IdentityBroker {
+	return &identityv1.IdentityBroker{
+		ObjectMeta: *NewIdentityBrokerMeta(name, namespace, environment),
+		Spec:       *NewIdentityBrokerSpec(realmName, namespace),
+	}
+}
@@ -0,0 +1,51 @@
+package identitybroker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/telekom/controlplane-mono/common/pkg/config"
+
+	identityv1 "github.com/telekom/controlplane-mono/identity/api/v1"
+)
+
+const (
+	name        = "test-name"
+	namespace   = "test-namespace"
+	environment = "test-environment"
+	alias       = "test-broker"
+)
+
+func TestNewIdentityBrokerSpecIsCreatedCorrectly(t *testing.T) {
+	spec := NewIdentityBrokerSpec(name, namespace)
+
+	assert.NotNil(t, spec)
+	assert.Equal(t, name, spec.Realm.Name)
+	assert.Equal(t, namespace, spec.Realm.Namespace)
+	assert.Equal(t, alias, spec.Alias)
+	assert.Equal(t, identityv1.IdentityBrokerTypeOIDC, spec.ProviderType)
+	assert.NotNil(t, spec.OIDC)
+	assert.Len(t, spec.Mappers, 1)
+}
+
+func TestNewIdentityBrokerMetaIsCreatedCorrectly(t *testing.T) {
+	meta := NewIdentityBrokerMeta(name, namespace, environment)
+
+	assert.NotNil(t, meta)
+	assert.Equal(t, name, meta.Name)
+	assert.Equal(t, namespace, meta.Namespace)
+	assert.Equal(t, environment, meta.Labels[config.EnvironmentLabelKey])
+}
+
+func TestNewIdentityBrokerIsCreatedCorrectly(t *testing.T) {
+	realmName := "test-realm"
+
+	broker := NewIdentityBroker(name, namespace, environment, realmName)
+
+	assert.NotNil(t, broker)
+	assert.Equal(t, name, broker.ObjectMeta.Name)
+	assert.Equal(t, namespace, broker.ObjectMeta.Namespace)
+	assert.Equal(t, environment, broker.ObjectMeta.Labels[config.EnvironmentLabelKey])
+	assert.Equal(t, realmName, broker.Spec.Realm.Name)
+	assert.Equal(t, alias, broker.Spec.Alias)
+}
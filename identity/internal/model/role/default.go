@@ -0,0 +1,37 @@
+package role
+
+import (
+	"github.com/telekom/controlplane-mono/common/pkg/config"
+	"github.com/telekom/controlplane-mono/common/pkg/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	identityv1 "github.com/telekom/controlplane-mono/identity/api/v1"
+)
+
+func NewRoleSpec(realmName string, namespace string) *identityv1.RoleSpec {
+	return &identityv1.RoleSpec{
+		Realm: &types.ObjectRef{
+			Name:      realmName,
+			Namespace: namespace,
+		},
+		Name:        "test-role",
+		Description: "test-description",
+	}
+}
+
+func NewRoleMeta(name string, namespace string, environment string) *metav1.ObjectMeta {
+	return &metav1.ObjectMeta{
+		Name:      name,
+		Namespace: namespace,
+		Labels: map[string]string{
+			config.EnvironmentLabelKey: environment,
+		},
+	}
+}
+
+func NewRole(name string, namespace string, environment string, realmName string) *identityv1.Role {
+	return &identityv1.Role{
+		ObjectMeta: *NewRoleMeta(name, namespace, environment),
+		Spec:       *NewRoleSpec(realmName, namespace),
+	}
+}
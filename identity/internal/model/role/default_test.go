@@ -0,0 +1,49 @@
+package role
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/telekom/controlplane-mono/common/pkg/config"
+)
+
+const (
+	name        = "test-name"
+	namespace   = "test-namespace"
+	environment = "test-environment"
+	roleName    = "test-role"
+)
+
+func TestNewRoleSpecIsCreatedCorrectly(t *testing.T) {
+	spec := NewRoleSpec(name, namespace)
+
+	assert.NotNil(t, spec)
+	assert.Equal(t, name, spec.Realm.Name)
+	assert.Equal(t, namespace, spec.Realm.Namespace)
+	assert.Equal(t, roleName, spec.Name)
+	assert.Empty(t, spec.ClientId)
+}
+
+func TestNewRoleMetaIsCreatedCorrectly(t *testing.T) {
+	meta := NewRoleMeta(name, namespace, environment)
+
+	assert.NotNil(t, meta)
+	assert.Equal(t, name, meta.Name)
+	assert.Equal(t, namespace, meta.Namespace)
+	assert.Equal(t, environment, meta.Labels[config.EnvironmentLabelKey])
+}
+
+func TestNewRoleIsCreatedCorrectly(t *testing.T) {
+	realmName := "test-realm"
+
+	role := NewRole(name, namespace, environment, realmName)
+
+	assert.NotNil(t, role)
+	assert.Equal(t, name, role.ObjectMeta.Name)
+	assert.Equal(t, namespace, role.ObjectMeta.Namespace)
+	assert.Equal(t, environment, role.ObjectMeta.Labels[config.EnvironmentLabelKey])
+	assert.Equal(t, realmName, role.Spec.Realm.Name)
+	assert.Equal(t, namespace, role.Spec.Realm.Namespace)
+	assert.Equal(t, roleName, role.Spec.Name)
+	assert.False(t, role.IsClientRole())
+}
@@ -11,6 +11,8 @@ type keycloakClient struct {
 }
 
 type KeycloakClient interface {
+	GetWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetResponse, error)
+
 	GetRealmWithResponse(ctx context.Context, realm string,
 		reqEditors ...RequestEditorFn) (*GetRealmResponse, error)
 	PutRealmWithResponse(ctx context.Context, realm string, body PutRealmJSONRequestBody,
@@ -24,4 +26,72 @@ type KeycloakClient interface {
 		reqEditors ...RequestEditorFn) (*PutRealmClientsIdResponse, error)
 	PostRealmClientsWithResponse(ctx context.Context, realm string, body PostRealmClientsJSONRequestBody,
 		reqEditors ...RequestEditorFn) (*PostRealmClientsResponse, error)
+	GetRealmClientsIdServiceAccountUserWithResponse(ctx context.Context, realm string, id string,
+		reqEditors ...RequestEditorFn) (*GetRealmClientsIdServiceAccountUserResponse, error)
+
+	GetRealmClientScopesWithResponse(ctx context.Context, realm string,
+		reqEditors ...RequestEditorFn) (*GetRealmClientScopesResponse, error)
+	PutRealmClientScopesIdWithResponse(ctx context.Context, realm string, id string,
+		body PutRealmClientScopesIdJSONRequestBody,
+		reqEditors ...RequestEditorFn) (*PutRealmClientScopesIdResponse, error)
+	PostRealmClientScopesWithResponse(ctx context.Context, realm string, body PostRealmClientScopesJSONRequestBody,
+		reqEditors ...RequestEditorFn) (*PostRealmClientScopesResponse, error)
+	DeleteRealmClientScopesIdWithResponse(ctx context.Context, realm string, id string,
+		reqEditors ...RequestEditorFn) (*DeleteRealmClientScopesIdResponse, error)
+
+	GetRealmRolesRoleNameWithResponse(ctx context.Context, realm string, roleName string,
+		reqEditors ...RequestEditorFn) (*GetRealmRolesRoleNameResponse, error)
+	PutRealmRolesRoleNameWithResponse(ctx context.Context, realm string, roleName string,
+		body PutRealmRolesRoleNameJSONRequestBody,
+		reqEditors ...RequestEditorFn) (*PutRealmRolesRoleNameResponse, error)
+	PostRealmRolesWithResponse(ctx context.Context, realm string, body PostRealmRolesJSONRequestBody,
+		reqEditors ...RequestEditorFn) (*PostRealmRolesResponse, error)
+	DeleteRealmRolesRoleNameWithResponse(ctx context.Context, realm string, roleName string,
+		reqEditors ...RequestEditorFn) (*DeleteRealmRolesRoleNameResponse, error)
+
+	GetRealmClientsIdRolesRoleNameWithResponse(ctx context.Context, realm string, id string, roleName string,
+		reqEditors ...RequestEditorFn) (*GetRealmClientsIdRolesRoleNameResponse, error)
+	PutRealmClientsIdRolesRoleNameWithResponse(ctx context.Context, realm string, id string, roleName string,
+		body PutRealmClientsIdRolesRoleNameJSONRequestBody,
+		reqEditors ...RequestEditorFn) (*PutRealmClientsIdRolesRoleNameResponse, error)
+	PostRealmClientsIdRolesWithResponse(ctx context.Context, realm string, id string,
+		body PostRealmClientsIdRolesJSONRequestBody,
+		reqEditors ...RequestEditorFn) (*PostRealmClientsIdRolesResponse, error)
+	DeleteRealmClientsIdRolesRoleNameWithResponse(ctx context.Context, realm string, id string, roleName string,
+		reqEditors ...RequestEditorFn) (*DeleteRealmClientsIdRolesRoleNameResponse, error)
+
+	GetRealmUsersIdRoleMappingsRealmWithResponse(ctx context.Context, realm string, id string,
+		reqEditors ...RequestEditorFn) (*GetRealmUsersIdRoleMappingsRealmResponse, error)
+	PostRealmUsersIdRoleMappingsRealmWithResponse(ctx context.Context, realm string, id string,
+		body PostRealmUsersIdRoleMappingsRealmJSONRequestBody,
+		reqEditors ...RequestEditorFn) (*PostRealmUsersIdRoleMappingsRealmResponse, error)
+	GetRealmUsersIdRoleMappingsClientsClientWithResponse(ctx context.Context, realm string, id string, client string,
+		reqEditors ...RequestEditorFn) (*GetRealmUsersIdRoleMappingsClientsClientResponse, error)
+	PostRealmUsersIdRoleMappingsClientsClientWithResponse(ctx context.Context, realm string, id string, client string,
+		body PostRealmUsersIdRoleMappingsClientsClientJSONRequestBody,
+		reqEditors ...RequestEditorFn) (*PostRealmUsersIdRoleMappingsClientsClientResponse, error)
+
+	GetRealmIdentityProviderInstancesWithResponse(ctx context.Context, realm string,
+		reqEditors ...RequestEditorFn) (*GetRealmIdentityProviderInstancesResponse, error)
+	GetRealmIdentityProviderInstancesAliasWithResponse(ctx context.Context, realm string, alias string,
+		reqEditors ...RequestEditorFn) (*GetRealmIdentityProviderInstancesAliasResponse, error)
+	PutRealmIdentityProviderInstancesAliasWithResponse(ctx context.Context, realm string, alias string,
+		body PutRealmIdentityProviderInstancesAliasJSONRequestBody,
+		reqEditors ...RequestEditorFn) (*PutRealmIdentityProviderInstancesAliasResponse, error)
+	PostRealmIdentityProviderInstancesWithResponse(ctx context.Context, realm string,
+		body PostRealmIdentityProviderInstancesJSONRequestBody,
+		reqEditors ...RequestEditorFn) (*PostRealmIdentityProviderInstancesResponse, error)
+	DeleteRealmIdentityProviderInstancesAliasWithResponse(ctx context.Context, realm string, alias string,
+		reqEditors ...RequestEditorFn) (*DeleteRealmIdentityProviderInstancesAliasResponse, error)
+
+	GetRealmIdentityProviderInstancesAliasMappersWithResponse(ctx context.Context, realm string, alias string,
+		reqEditors ...RequestEditorFn) (*GetRealmIdentityProviderInstancesAliasMappersResponse, error)
+	PutRealmIdentityProviderInstancesAliasMappersIdWithResponse(ctx context.Context, realm string, alias string,
+		id string, body PutRealmIdentityProviderInstancesAliasMappersIdJSONRequestBody,
+		reqEditors ...RequestEditorFn) (*PutRealmIdentityProviderInstancesAliasMappersIdResponse, error)
+	PostRealmIdentityProviderInstancesAliasMappersWithResponse(ctx context.Context, realm string, alias string,
+		body PostRealmIdentityProviderInstancesAliasMappersJSONRequestBody,
+		reqEditors ...RequestEditorFn) (*PostRealmIdentityProviderInstancesAliasMappersResponse, error)
+	DeleteRealmIdentityProviderInstancesAliasMappersIdWithResponse(ctx context.Context, realm string, alias string,
+		id string, reqEditors ...RequestEditorFn) (*DeleteRealmIdentityProviderInstancesAliasMappersIdResponse, error)
 }
@@ -0,0 +1,64 @@
+package backup
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/telekom/controlplane-mono/identity/pkg/api"
+)
+
+// FormatVersion is the version of the backup file format
+const FormatVersion = "v1"
+
+// Backup is a snapshot of the realms that are managed by the operator.
+// It never contains plain secrets, they are replaced by secret-manager references.
+type Backup struct {
+	Version   string        `json:"version"`
+	CreatedAt time.Time     `json:"createdAt"`
+	Source    string        `json:"source,omitempty"`
+	Realms    []RealmBackup `json:"realms"`
+}
+
+// RealmBackup contains a realm together with its clients and identity brokers
+type RealmBackup struct {
+	Realm             api.RealmRepresentation    `json:"realm"`
+	Clients           []api.ClientRepresentation `json:"clients,omitempty"`
+	IdentityProviders []IdentityProviderBackup   `json:"identityProviders,omitempty"`
+}
+
+// IdentityProviderBackup contains an identity broker of a realm together with its mappers
+type IdentityProviderBackup struct {
+	IdentityProvider api.IdentityProviderRepresentation         `json:"identityProvider"`
+	Mappers          []api.IdentityProviderMapperRepresentation `json:"mappers,omitempty"`
+}
+
+// Name returns the name of the realm or an empty string
+func (r *RealmBackup) Name() string {
+	if r.Realm.Realm == nil {
+		return ""
+	}
+	return *r.Realm.Realm
+}
+
+func Write(w io.Writer, backup *Backup) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(backup); err != nil {
+		return errors.Wrap(err, "failed to write backup")
+	}
+	return nil
+}
+
+func Read(r io.Reader) (*Backup, error) {
+	backup := &Backup{}
+	if err := json.NewDecoder(r).Decode(backup); err != nil {
+		return nil, errors.Wrap(err, "failed to read backup")
+	}
+	if backup.Version != FormatVersion {
+		return nil, errors.Errorf("unsupported backup version %q, expected %q", backup.Version, FormatVersion)
+	}
+	return backup, nil
+}
@@ -0,0 +1,192 @@
+package backup
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/pkg/errors"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/telekom/controlplane-mono/identity/pkg/api"
+	"github.com/telekom/controlplane-mono/identity/pkg/keycloak"
+)
+
+// DefaultClients are created by keycloak for every realm and are therefore not exported
+var DefaultClients = []string{
+	"account",
+	"account-console",
+	"admin-cli",
+	"broker",
+	"realm-management",
+	"security-admin-console",
+}
+
+type Exporter struct {
+	client      api.KeycloakClient
+	secretRefs  SecretRefTemplate
+	storeSecret SecretStore
+	source      string
+}
+
+func NewExporter(client api.KeycloakClient, secretRefs SecretRefTemplate, storeSecret SecretStore,
+	source string) *Exporter {
+	if secretRefs == "" {
+		secretRefs = DefaultSecretRefTemplate
+	}
+	if storeSecret == nil {
+		storeSecret = DiscardSecrets
+	}
+	return &Exporter{
+		client:      client,
+		secretRefs:  secretRefs,
+		storeSecret: storeSecret,
+		source:      source,
+	}
+}
+
+// Export creates a backup of the given realms
+func (e *Exporter) Export(ctx context.Context, realmNames ...string) (*Backup, error) {
+	if e.client == nil {
+		return nil, fmt.Errorf("keycloak client is required")
+	}
+
+	backup := &Backup{
+		Version:   FormatVersion,
+		CreatedAt: time.Now().UTC(),
+		Source:    e.source,
+		Realms:    make([]RealmBackup, 0, len(realmNames)),
+	}
+	for _, realmName := range realmNames {
+		realmBackup, err := e.exportRealm(ctx, realmName)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to export realm %s", realmName)
+		}
+		backup.Realms = append(backup.Realms, *realmBackup)
+	}
+	return backup, nil
+}
+
+func (e *Exporter) exportRealm(ctx context.Context, realmName string) (*RealmBackup, error) {
+	logger := log.FromContext(ctx)
+
+	getRealm, err := e.client.GetRealmWithResponse(ctx, realmName)
+	if err != nil {
+		return nil, err
+	}
+	if responseErr := keycloak.CheckStatusCode(getRealm, http.StatusOK); responseErr != nil {
+		return nil, fmt.Errorf("❌ failed to get realm: %d -- Response for GET is: %s",
+			getRealm.StatusCode(), string(getRealm.Body))
+	}
+	realmBackup := &RealmBackup{
+		Realm: *getRealm.JSON2XX,
+	}
+
+	clients, err := e.exportClients(ctx, realmName)
+	if err != nil {
+		return nil, err
+	}
+	realmBackup.Clients = clients
+
+	identityProviders, err := e.exportIdentityProviders(ctx, realmName)
+	if err != nil {
+		return nil, err
+	}
+	realmBackup.IdentityProviders = identityProviders
+
+	logger.V(0).Info("✅ exported realm", "realm", realmName,
+		"clients", len(clients), "identityProviders", len(identityProviders))
+	return realmBackup, nil
+}
+
+func (e *Exporter) exportClients(ctx context.Context, realmName string) ([]api.ClientRepresentation, error) {
+	getClients, err := e.client.GetRealmClientsWithResponse(ctx, realmName, nil)
+	if err != nil {
+		return nil, err
+	}
+	if responseErr := keycloak.CheckStatusCode(getClients, http.StatusOK); responseErr != nil {
+		return nil, fmt.Errorf("❌ failed to list clients: %d -- Response for GET is: %s",
+			getClients.StatusCode(), string(getClients.Body))
+	}
+	if getClients.JSON2XX == nil {
+		return nil, nil
+	}
+
+	clients := make([]api.ClientRepresentation, 0, len(*getClients.JSON2XX))
+	for _, client := range *getClients.JSON2XX {
+		clientId := ptr.Deref(client.ClientId, "")
+		if clientId == "" || slices.Contains(DefaultClients, clientId) {
+			continue
+		}
+		if secret := ptr.Deref(client.Secret, ""); secret != "" {
+			secretRef, err := e.replaceSecret(ctx, realmName, clientId, secret)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to replace secret of client %s", clientId)
+			}
+			client.Secret = &secretRef
+		}
+		clients = append(clients, client)
+	}
+	return clients, nil
+}
+
+func (e *Exporter) exportIdentityProviders(ctx context.Context,
+	realmName string) ([]IdentityProviderBackup, error) {
+	getIdentityProviders, err := e.client.GetRealmIdentityProviderInstancesWithResponse(ctx, realmName)
+	if err != nil {
+		return nil, err
+	}
+	if responseErr := keycloak.CheckStatusCode(getIdentityProviders, http.StatusOK); responseErr != nil {
+		return nil, fmt.Errorf("❌ failed to list identity providers: %d -- Response for GET is: %s",
+			getIdentityProviders.StatusCode(), string(getIdentityProviders.Body))
+	}
+	if getIdentityProviders.JSON2XX == nil {
+		return nil, nil
+	}
+
+	identityProviders := make([]IdentityProviderBackup, 0, len(*getIdentityProviders.JSON2XX))
+	for _, identityProvider := range *getIdentityProviders.JSON2XX {
+		alias := ptr.Deref(identityProvider.Alias, "")
+		if identityProvider.Config != nil {
+			config := *identityProvider.Config
+			if secret, ok := config[SecretKeyClientSecret].(string); ok && secret != "" {
+				secretRef, err := e.replaceSecret(ctx, realmName, alias, secret)
+				if err != nil {
+					return nil, errors.Wrapf(err, "failed to replace secret of identity provider %s", alias)
+				}
+				config[SecretKeyClientSecret] = secretRef
+			}
+		}
+
+		getMappers, err := e.client.GetRealmIdentityProviderInstancesAliasMappersWithResponse(ctx, realmName, alias)
+		if err != nil {
+			return nil, err
+		}
+		if responseErr := keycloak.CheckStatusCode(getMappers, http.StatusOK); responseErr != nil {
+			return nil, fmt.Errorf("❌ failed to list identity provider mappers: %d -- Response for GET is: %s",
+				getMappers.StatusCode(), string(getMappers.Body))
+		}
+
+		identityProviderBackup := IdentityProviderBackup{
+			IdentityProvider: identityProvider,
+		}
+		if getMappers.JSON2XX != nil {
+			identityProviderBackup.Mappers = *getMappers.JSON2XX
+		}
+		identityProviders = append(identityProviders, identityProviderBackup)
+	}
+	return identityProviders, nil
+}
+
+// replaceSecret stores the secret if possible and returns the reference that replaces it in the backup
+func (e *Exporter) replaceSecret(ctx context.Context, realmName, name, value string) (string, error) {
+	secretRef := e.secretRefs.RefFor(realmName, name, SecretKeyClientSecret)
+	if value == MaskedSecret {
+		// keycloak does not return the actual value, so it can only be referenced
+		return secretRef, nil
+	}
+	return e.storeSecret(ctx, secretRef, value)
+}
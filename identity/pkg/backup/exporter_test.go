@@ -0,0 +1,174 @@
+package backup
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"k8s.io/utils/ptr"
+
+	"github.com/telekom/controlplane-mono/identity/pkg/api"
+	"github.com/telekom/controlplane-mono/identity/test/mocks"
+)
+
+const Realm = "test-realm"
+
+func mockExportRealm(mockedClient *mocks.MockKeycloakClient) {
+	mockedClient.EXPECT().GetRealmWithResponse(mock.Anything, Realm).
+		Return(&api.GetRealmResponse{
+			HTTPResponse: ptr.To(http.Response{StatusCode: http.StatusOK}),
+			JSON2XX:      &api.RealmRepresentation{Realm: ptr.To(Realm), Enabled: ptr.To(true)},
+		}, nil)
+	mockedClient.EXPECT().GetRealmClientsWithResponse(mock.Anything, Realm, (*api.GetRealmClientsParams)(nil)).
+		Return(&api.GetRealmClientsResponse{
+			HTTPResponse: ptr.To(http.Response{StatusCode: http.StatusOK}),
+			JSON2XX: &[]api.ClientRepresentation{
+				{Id: ptr.To("1"), ClientId: ptr.To("admin-cli")},
+				{Id: ptr.To("2"), ClientId: ptr.To("my-client"), Secret: ptr.To("plain-secret")},
+			},
+		}, nil)
+	mockedClient.EXPECT().GetRealmIdentityProviderInstancesWithResponse(mock.Anything, Realm).
+		Return(&api.GetRealmIdentityProviderInstancesResponse{
+			HTTPResponse: ptr.To(http.Response{StatusCode: http.StatusOK}),
+			JSON2XX: &[]api.IdentityProviderRepresentation{
+				{
+					Alias:  ptr.To("upstream"),
+					Config: &map[string]interface{}{"clientId": "upstream-client", "clientSecret": MaskedSecret},
+				},
+			},
+		}, nil)
+	mockedClient.EXPECT().GetRealmIdentityProviderInstancesAliasMappersWithResponse(mock.Anything, Realm, "upstream").
+		Return(&api.GetRealmIdentityProviderInstancesAliasMappersResponse{
+			HTTPResponse: ptr.To(http.Response{StatusCode: http.StatusOK}),
+			JSON2XX: &[]api.IdentityProviderMapperRepresentation{
+				{Id: ptr.To("m1"), Name: ptr.To("email")},
+			},
+		}, nil)
+}
+
+func TestExportReplacesSecretsWithReferences(t *testing.T) {
+	mockedClient := mocks.NewMockKeycloakClient(t)
+	mockExportRealm(mockedClient)
+
+	exporter := NewExporter(mockedClient, "", nil, "https://keycloak.example.com")
+	result, err := exporter.Export(context.Background(), Realm)
+
+	assert.NoError(t, err)
+	assert.Equal(t, FormatVersion, result.Version)
+	assert.Equal(t, "https://keycloak.example.com", result.Source)
+	assert.Len(t, result.Realms, 1)
+
+	realmBackup := result.Realms[0]
+	assert.Equal(t, Realm, realmBackup.Name())
+	// default clients are not exported
+	assert.Len(t, realmBackup.Clients, 1)
+	assert.Equal(t, "my-client", *realmBackup.Clients[0].ClientId)
+	assert.Equal(t, "$<test-realm:::my-client/clientSecret:>", *realmBackup.Clients[0].Secret)
+
+	assert.Len(t, realmBackup.IdentityProviders, 1)
+	config := *realmBackup.IdentityProviders[0].IdentityProvider.Config
+	assert.Equal(t, "$<test-realm:::upstream/clientSecret:>", config["clientSecret"])
+	assert.Len(t, realmBackup.IdentityProviders[0].Mappers, 1)
+
+	var buffer bytes.Buffer
+	assert.NoError(t, Write(&buffer, result))
+	assert.NotContains(t, buffer.String(), "plain-secret")
+}
+
+func TestExportStoresSecrets(t *testing.T) {
+	mockedClient := mocks.NewMockKeycloakClient(t)
+	mockExportRealm(mockedClient)
+
+	stored := map[string]string{}
+	storeSecret := func(ctx context.Context, secretRef, value string) (string, error) {
+		stored[secretRef] = value
+		return secretRef + "-stored", nil
+	}
+
+	exporter := NewExporter(mockedClient, "env:identity:{realm}:{name}/{key}:", storeSecret, "")
+	result, err := exporter.Export(context.Background(), Realm)
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"$<env:identity:test-realm:my-client/clientSecret:>": "plain-secret"}, stored)
+	assert.Equal(t, "$<env:identity:test-realm:my-client/clientSecret:>-stored", *result.Realms[0].Clients[0].Secret)
+	// masked secrets can not be stored
+	config := *result.Realms[0].IdentityProviders[0].IdentityProvider.Config
+	assert.Equal(t, "$<env:identity:test-realm:upstream/clientSecret:>", config["clientSecret"])
+}
+
+func TestExportFailsWhenRealmIsNotFound(t *testing.T) {
+	mockedClient := mocks.NewMockKeycloakClient(t)
+	mockedClient.EXPECT().GetRealmWithResponse(mock.Anything, Realm).
+		Return(&api.GetRealmResponse{
+			HTTPResponse: ptr.To(http.Response{StatusCode: http.StatusNotFound}),
+		}, nil)
+
+	result, err := NewExporter(mockedClient, "", nil, "").Export(context.Background(), Realm)
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Contains(t, err.Error(), "failed to export realm test-realm")
+}
+
+func TestExportFailsWhenSecretCanNotBeStored(t *testing.T) {
+	mockedClient := mocks.NewMockKeycloakClient(t)
+	mockedClient.EXPECT().GetRealmWithResponse(mock.Anything, Realm).
+		Return(&api.GetRealmResponse{
+			HTTPResponse: ptr.To(http.Response{StatusCode: http.StatusOK}),
+			JSON2XX:      &api.RealmRepresentation{Realm: ptr.To(Realm)},
+		}, nil)
+	mockedClient.EXPECT().GetRealmClientsWithResponse(mock.Anything, Realm, (*api.GetRealmClientsParams)(nil)).
+		Return(&api.GetRealmClientsResponse{
+			HTTPResponse: ptr.To(http.Response{StatusCode: http.StatusOK}),
+			JSON2XX: &[]api.ClientRepresentation{
+				{Id: ptr.To("2"), ClientId: ptr.To("my-client"), Secret: ptr.To("plain-secret")},
+			},
+		}, nil)
+	storeSecret := func(ctx context.Context, secretRef, value string) (string, error) {
+		return "", fmt.Errorf("secret-manager unavailable")
+	}
+
+	result, err := NewExporter(mockedClient, "", storeSecret, "").Export(context.Background(), Realm)
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+	assert.Contains(t, err.Error(), "secret-manager unavailable")
+}
+
+func TestExportReturnsClientError(t *testing.T) {
+	result, err := NewExporter(nil, "", nil, "").Export(context.Background(), Realm)
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+}
+
+func TestDefaultSecretRefTemplateProducesValidIds(t *testing.T) {
+	assert.NoError(t, DefaultSecretRefTemplate.Validate())
+	assert.Equal(t, "$<test-realm:::my-client/clientSecret:>", DefaultSecretRefTemplate.RefFor(Realm, "my-client", "clientSecret"))
+}
+
+func TestSecretRefTemplateValidate(t *testing.T) {
+	assert.NoError(t, SecretRefTemplate("prod:identity:{realm}:{name}/{key}:").Validate())
+
+	for _, template := range []SecretRefTemplate{
+		"{realm}:{name}:{key}",
+		"{realm}:{name}:{key}::",
+		":team::{realm}/{name}/{key}:",
+		"env::app:{realm}/{name}/{key}:",
+		"env:team:{realm}-{name}-{key}::",
+		"env:team:{realm}:{name}:",
+	} {
+		assert.Error(t, template.Validate(), string(template))
+	}
+}
+
+func TestReadRejectsUnknownVersion(t *testing.T) {
+	result, err := Read(bytes.NewBufferString(`{"version": "v0", "realms": []}`))
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+}
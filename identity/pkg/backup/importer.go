@@ -0,0 +1,250 @@
+package backup
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net/http"
+
+	"github.com/pkg/errors"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/telekom/controlplane-mono/identity/pkg/api"
+	"github.com/telekom/controlplane-mono/identity/pkg/keycloak"
+)
+
+type Importer struct {
+	client        api.KeycloakClient
+	resolveSecret SecretResolver
+}
+
+func NewImporter(client api.KeycloakClient, resolveSecret SecretResolver) *Importer {
+	if resolveSecret == nil {
+		resolveSecret = ResolveSecretsFromSecretManager
+	}
+	return &Importer{
+		client:        client,
+		resolveSecret: resolveSecret,
+	}
+}
+
+// Import creates or updates all realms of the backup including their clients and identity brokers.
+// Secret references are resolved before they are sent to keycloak.
+func (i *Importer) Import(ctx context.Context, backup *Backup) error {
+	if i.client == nil {
+		return fmt.Errorf("keycloak client is required")
+	}
+	if backup == nil {
+		return fmt.Errorf("backup is nil")
+	}
+
+	for _, realmBackup := range backup.Realms {
+		if err := i.importRealm(ctx, &realmBackup); err != nil {
+			return errors.Wrapf(err, "failed to import realm %s", realmBackup.Name())
+		}
+	}
+	return nil
+}
+
+func (i *Importer) importRealm(ctx context.Context, realmBackup *RealmBackup) error {
+	logger := log.FromContext(ctx)
+	realmName := realmBackup.Name()
+	if realmName == "" {
+		return fmt.Errorf("realm name is empty")
+	}
+
+	getRealm, err := i.client.GetRealmWithResponse(ctx, realmName)
+	if err != nil {
+		return err
+	}
+	if getRealm.StatusCode() == http.StatusNotFound {
+		post, err := i.client.PostWithResponse(ctx, realmBackup.Realm)
+		if err != nil {
+			return err
+		}
+		if responseErr := keycloak.CheckStatusCode(post, http.StatusCreated); responseErr != nil {
+			return fmt.Errorf("❌ failed to create realm: %d -- Response for POST is: %s",
+				post.StatusCode(), string(post.Body))
+		}
+	} else {
+		if responseErr := keycloak.CheckStatusCode(getRealm, http.StatusOK); responseErr != nil {
+			return fmt.Errorf("❌ failed to get realm: %d -- Response for GET is: %s",
+				getRealm.StatusCode(), string(getRealm.Body))
+		}
+		put, err := i.client.PutRealmWithResponse(ctx, realmName, realmBackup.Realm)
+		if err != nil {
+			return err
+		}
+		if responseErr := keycloak.CheckStatusCode(put, http.StatusNoContent); responseErr != nil {
+			return fmt.Errorf("❌ failed to update realm: %d -- Response for PUT is: %s",
+				put.StatusCode(), string(put.Body))
+		}
+	}
+
+	for _, client := range realmBackup.Clients {
+		if err := i.importClient(ctx, realmName, client); err != nil {
+			return errors.Wrapf(err, "failed to import client %s", ptr.Deref(client.ClientId, ""))
+		}
+	}
+	for _, identityProvider := range realmBackup.IdentityProviders {
+		if err := i.importIdentityProvider(ctx, realmName, identityProvider); err != nil {
+			return errors.Wrapf(err, "failed to import identity provider %s",
+				ptr.Deref(identityProvider.IdentityProvider.Alias, ""))
+		}
+	}
+
+	logger.V(0).Info("✅ imported realm", "realm", realmName,
+		"clients", len(realmBackup.Clients), "identityProviders", len(realmBackup.IdentityProviders))
+	return nil
+}
+
+func (i *Importer) importClient(ctx context.Context, realmName string, client api.ClientRepresentation) error {
+	if client.Secret != nil {
+		secret, err := i.resolveSecret(ctx, *client.Secret)
+		if err != nil {
+			return errors.Wrap(err, "failed to resolve client secret")
+		}
+		client.Secret = &secret
+	}
+
+	getClients, err := i.client.GetRealmClientsWithResponse(ctx, realmName,
+		&api.GetRealmClientsParams{ClientId: client.ClientId})
+	if err != nil {
+		return err
+	}
+	if responseErr := keycloak.CheckStatusCode(getClients, http.StatusOK); responseErr != nil {
+		return fmt.Errorf("❌ failed to list clients: %d -- Response for GET is: %s",
+			getClients.StatusCode(), string(getClients.Body))
+	}
+
+	if getClients.JSON2XX != nil && len(*getClients.JSON2XX) > 0 {
+		id := ptr.Deref((*getClients.JSON2XX)[0].Id, "")
+		client.Id = &id
+		put, err := i.client.PutRealmClientsIdWithResponse(ctx, realmName, id, client)
+		if err != nil {
+			return err
+		}
+		if responseErr := keycloak.CheckStatusCode(put, http.StatusNoContent); responseErr != nil {
+			return fmt.Errorf("❌ failed to update client: %d -- Response for PUT is: %s",
+				put.StatusCode(), string(put.Body))
+		}
+		return nil
+	}
+
+	// The ID is generated by the target keycloak, which might already use it for another realm
+	client.Id = nil
+	post, err := i.client.PostRealmClientsWithResponse(ctx, realmName, client)
+	if err != nil {
+		return err
+	}
+	if responseErr := keycloak.CheckStatusCode(post, http.StatusCreated); responseErr != nil {
+		return fmt.Errorf("❌ failed to create client: %d -- Response for POST is: %s",
+			post.StatusCode(), string(post.Body))
+	}
+	return nil
+}
+
+func (i *Importer) importIdentityProvider(ctx context.Context, realmName string,
+	identityProviderBackup IdentityProviderBackup) error {
+	identityProvider := identityProviderBackup.IdentityProvider
+	alias := ptr.Deref(identityProvider.Alias, "")
+
+	if identityProvider.Config != nil {
+		// Copy the config, so that the resolved secret is never written back to the backup
+		config := maps.Clone(*identityProvider.Config)
+		if secretRef, ok := config[SecretKeyClientSecret].(string); ok && secretRef != "" {
+			secret, err := i.resolveSecret(ctx, secretRef)
+			if err != nil {
+				return errors.Wrap(err, "failed to resolve client secret")
+			}
+			config[SecretKeyClientSecret] = secret
+		}
+		identityProvider.Config = &config
+	}
+
+	getIdentityProvider, err := i.client.GetRealmIdentityProviderInstancesAliasWithResponse(ctx, realmName, alias)
+	if err != nil {
+		return err
+	}
+	if getIdentityProvider.StatusCode() == http.StatusNotFound {
+		identityProvider.InternalId = nil
+		post, err := i.client.PostRealmIdentityProviderInstancesWithResponse(ctx, realmName, identityProvider)
+		if err != nil {
+			return err
+		}
+		if responseErr := keycloak.CheckStatusCode(post, http.StatusCreated); responseErr != nil {
+			return fmt.Errorf("❌ failed to create identity provider: %d -- Response for POST is: %s",
+				post.StatusCode(), string(post.Body))
+		}
+	} else {
+		if responseErr := keycloak.CheckStatusCode(getIdentityProvider, http.StatusOK); responseErr != nil {
+			return fmt.Errorf("❌ failed to get identity provider: %d -- Response for GET is: %s",
+				getIdentityProvider.StatusCode(), string(getIdentityProvider.Body))
+		}
+		if getIdentityProvider.JSON2XX != nil {
+			identityProvider.InternalId = getIdentityProvider.JSON2XX.InternalId
+		}
+		put, err := i.client.PutRealmIdentityProviderInstancesAliasWithResponse(ctx, realmName, alias,
+			identityProvider)
+		if err != nil {
+			return err
+		}
+		if responseErr := keycloak.CheckStatusCode(put, http.StatusNoContent); responseErr != nil {
+			return fmt.Errorf("❌ failed to update identity provider: %d -- Response for PUT is: %s",
+				put.StatusCode(), string(put.Body))
+		}
+	}
+
+	return i.importIdentityProviderMappers(ctx, realmName, alias, identityProviderBackup.Mappers)
+}
+
+func (i *Importer) importIdentityProviderMappers(ctx context.Context, realmName, alias string,
+	mappers []api.IdentityProviderMapperRepresentation) error {
+	if len(mappers) == 0 {
+		return nil
+	}
+
+	getMappers, err := i.client.GetRealmIdentityProviderInstancesAliasMappersWithResponse(ctx, realmName, alias)
+	if err != nil {
+		return err
+	}
+	if responseErr := keycloak.CheckStatusCode(getMappers, http.StatusOK); responseErr != nil {
+		return fmt.Errorf("❌ failed to list identity provider mappers: %d -- Response for GET is: %s",
+			getMappers.StatusCode(), string(getMappers.Body))
+	}
+	existingIds := map[string]string{}
+	if getMappers.JSON2XX != nil {
+		for _, existing := range *getMappers.JSON2XX {
+			existingIds[ptr.Deref(existing.Name, "")] = ptr.Deref(existing.Id, "")
+		}
+	}
+
+	for _, mapper := range mappers {
+		mapper.IdentityProviderAlias = &alias
+		if id, ok := existingIds[ptr.Deref(mapper.Name, "")]; ok {
+			mapper.Id = &id
+			put, err := i.client.PutRealmIdentityProviderInstancesAliasMappersIdWithResponse(ctx,
+				realmName, alias, id, mapper)
+			if err != nil {
+				return err
+			}
+			if responseErr := keycloak.CheckStatusCode(put, http.StatusNoContent); responseErr != nil {
+				return fmt.Errorf("❌ failed to update identity provider mapper: %d -- Response for PUT is: %s",
+					put.StatusCode(), string(put.Body))
+			}
+			continue
+		}
+
+		mapper.Id = nil
+		post, err := i.client.PostRealmIdentityProviderInstancesAliasMappersWithResponse(ctx, realmName, alias, mapper)
+		if err != nil {
+			return err
+		}
+		if responseErr := keycloak.CheckStatusCode(post, http.StatusCreated); responseErr != nil {
+			return fmt.Errorf("❌ failed to create identity provider mapper: %d -- Response for POST is: %s",
+				post.StatusCode(), string(post.Body))
+		}
+	}
+	return nil
+}
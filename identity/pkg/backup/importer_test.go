@@ -0,0 +1,182 @@
+package backup
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"k8s.io/utils/ptr"
+
+	"github.com/telekom/controlplane-mono/identity/pkg/api"
+	"github.com/telekom/controlplane-mono/identity/test/mocks"
+)
+
+func newTestBackup() *Backup {
+	return &Backup{
+		Version: FormatVersion,
+		Realms: []RealmBackup{
+			{
+				Realm: api.RealmRepresentation{Realm: ptr.To(Realm)},
+				Clients: []api.ClientRepresentation{
+					{Id: ptr.To("source-id"), ClientId: ptr.To("my-client"), Secret: ptr.To("$<my-client-secret>")},
+				},
+				IdentityProviders: []IdentityProviderBackup{
+					{
+						IdentityProvider: api.IdentityProviderRepresentation{
+							Alias:  ptr.To("upstream"),
+							Config: &map[string]interface{}{"clientSecret": "$<upstream-secret>"},
+						},
+						Mappers: []api.IdentityProviderMapperRepresentation{
+							{Id: ptr.To("source-mapper"), Name: ptr.To("email")},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resolveTestSecret(ctx context.Context, secretRef string) (string, error) {
+	return "resolved-" + secretRef, nil
+}
+
+func TestImportCreatesMissingResources(t *testing.T) {
+	mockedClient := mocks.NewMockKeycloakClient(t)
+	mockedClient.EXPECT().GetRealmWithResponse(mock.Anything, Realm).
+		Return(&api.GetRealmResponse{HTTPResponse: ptr.To(http.Response{StatusCode: http.StatusNotFound})}, nil)
+	mockedClient.EXPECT().PostWithResponse(mock.Anything, mock.Anything).
+		Return(&api.PostResponse{HTTPResponse: ptr.To(http.Response{StatusCode: http.StatusCreated})}, nil)
+
+	mockedClient.EXPECT().GetRealmClientsWithResponse(mock.Anything, Realm, mock.Anything).
+		Return(&api.GetRealmClientsResponse{
+			HTTPResponse: ptr.To(http.Response{StatusCode: http.StatusOK}),
+			JSON2XX:      &[]api.ClientRepresentation{},
+		}, nil)
+	mockedClient.EXPECT().PostRealmClientsWithResponse(mock.Anything, Realm,
+		mock.MatchedBy(func(client api.ClientRepresentation) bool {
+			return client.Id == nil && *client.Secret == "resolved-$<my-client-secret>"
+		})).
+		Return(&api.PostRealmClientsResponse{HTTPResponse: ptr.To(http.Response{StatusCode: http.StatusCreated})}, nil)
+
+	mockedClient.EXPECT().GetRealmIdentityProviderInstancesAliasWithResponse(mock.Anything, Realm, "upstream").
+		Return(&api.GetRealmIdentityProviderInstancesAliasResponse{
+			HTTPResponse: ptr.To(http.Response{StatusCode: http.StatusNotFound}),
+		}, nil)
+	mockedClient.EXPECT().PostRealmIdentityProviderInstancesWithResponse(mock.Anything, Realm,
+		mock.MatchedBy(func(idp api.IdentityProviderRepresentation) bool {
+			return (*idp.Config)["clientSecret"] == "resolved-$<upstream-secret>"
+		})).
+		Return(&api.PostRealmIdentityProviderInstancesResponse{
+			HTTPResponse: ptr.To(http.Response{StatusCode: http.StatusCreated}),
+		}, nil)
+	mockedClient.EXPECT().GetRealmIdentityProviderInstancesAliasMappersWithResponse(mock.Anything, Realm, "upstream").
+		Return(&api.GetRealmIdentityProviderInstancesAliasMappersResponse{
+			HTTPResponse: ptr.To(http.Response{StatusCode: http.StatusOK}),
+			JSON2XX:      &[]api.IdentityProviderMapperRepresentation{},
+		}, nil)
+	mockedClient.EXPECT().PostRealmIdentityProviderInstancesAliasMappersWithResponse(mock.Anything, Realm, "upstream",
+		mock.MatchedBy(func(mapper api.IdentityProviderMapperRepresentation) bool {
+			return mapper.Id == nil && *mapper.IdentityProviderAlias == "upstream"
+		})).
+		Return(&api.PostRealmIdentityProviderInstancesAliasMappersResponse{
+			HTTPResponse: ptr.To(http.Response{StatusCode: http.StatusCreated}),
+		}, nil)
+
+	input := newTestBackup()
+	err := NewImporter(mockedClient, resolveTestSecret).Import(context.Background(), input)
+
+	assert.NoError(t, err)
+	// resolved secrets are never written back to the backup
+	assert.Equal(t, "$<my-client-secret>", *input.Realms[0].Clients[0].Secret)
+	assert.Equal(t, "$<upstream-secret>", (*input.Realms[0].IdentityProviders[0].IdentityProvider.Config)["clientSecret"])
+}
+
+func TestImportUpdatesExistingResources(t *testing.T) {
+	mockedClient := mocks.NewMockKeycloakClient(t)
+	mockedClient.EXPECT().GetRealmWithResponse(mock.Anything, Realm).
+		Return(&api.GetRealmResponse{
+			HTTPResponse: ptr.To(http.Response{StatusCode: http.StatusOK}),
+			JSON2XX:      &api.RealmRepresentation{Realm: ptr.To(Realm)},
+		}, nil)
+	mockedClient.EXPECT().PutRealmWithResponse(mock.Anything, Realm, mock.Anything).
+		Return(&api.PutRealmResponse{HTTPResponse: ptr.To(http.Response{StatusCode: http.StatusNoContent})}, nil)
+
+	mockedClient.EXPECT().GetRealmClientsWithResponse(mock.Anything, Realm, mock.Anything).
+		Return(&api.GetRealmClientsResponse{
+			HTTPResponse: ptr.To(http.Response{StatusCode: http.StatusOK}),
+			JSON2XX:      &[]api.ClientRepresentation{{Id: ptr.To("target-id"), ClientId: ptr.To("my-client")}},
+		}, nil)
+	mockedClient.EXPECT().PutRealmClientsIdWithResponse(mock.Anything, Realm, "target-id", mock.Anything).
+		Return(&api.PutRealmClientsIdResponse{HTTPResponse: ptr.To(http.Response{StatusCode: http.StatusNoContent})}, nil)
+
+	mockedClient.EXPECT().GetRealmIdentityProviderInstancesAliasWithResponse(mock.Anything, Realm, "upstream").
+		Return(&api.GetRealmIdentityProviderInstancesAliasResponse{
+			HTTPResponse: ptr.To(http.Response{StatusCode: http.StatusOK}),
+			JSON2XX:      &api.IdentityProviderRepresentation{Alias: ptr.To("upstream"), InternalId: ptr.To("internal")},
+		}, nil)
+	mockedClient.EXPECT().PutRealmIdentityProviderInstancesAliasWithResponse(mock.Anything, Realm, "upstream",
+		mock.MatchedBy(func(idp api.IdentityProviderRepresentation) bool {
+			return *idp.InternalId == "internal"
+		})).
+		Return(&api.PutRealmIdentityProviderInstancesAliasResponse{
+			HTTPResponse: ptr.To(http.Response{StatusCode: http.StatusNoContent}),
+		}, nil)
+	mockedClient.EXPECT().GetRealmIdentityProviderInstancesAliasMappersWithResponse(mock.Anything, Realm, "upstream").
+		Return(&api.GetRealmIdentityProviderInstancesAliasMappersResponse{
+			HTTPResponse: ptr.To(http.Response{StatusCode: http.StatusOK}),
+			JSON2XX:      &[]api.IdentityProviderMapperRepresentation{{Id: ptr.To("target-mapper"), Name: ptr.To("email")}},
+		}, nil)
+	mockedClient.EXPECT().PutRealmIdentityProviderInstancesAliasMappersIdWithResponse(mock.Anything, Realm, "upstream",
+		"target-mapper", mock.Anything).
+		Return(&api.PutRealmIdentityProviderInstancesAliasMappersIdResponse{
+			HTTPResponse: ptr.To(http.Response{StatusCode: http.StatusNoContent}),
+		}, nil)
+
+	err := NewImporter(mockedClient, resolveTestSecret).Import(context.Background(), newTestBackup())
+
+	assert.NoError(t, err)
+}
+
+func TestImportFailsWhenSecretCanNotBeResolved(t *testing.T) {
+	mockedClient := mocks.NewMockKeycloakClient(t)
+	mockedClient.EXPECT().GetRealmWithResponse(mock.Anything, Realm).
+		Return(&api.GetRealmResponse{
+			HTTPResponse: ptr.To(http.Response{StatusCode: http.StatusOK}),
+			JSON2XX:      &api.RealmRepresentation{Realm: ptr.To(Realm)},
+		}, nil)
+	mockedClient.EXPECT().PutRealmWithResponse(mock.Anything, Realm, mock.Anything).
+		Return(&api.PutRealmResponse{HTTPResponse: ptr.To(http.Response{StatusCode: http.StatusNoContent})}, nil)
+	resolveSecret := func(ctx context.Context, secretRef string) (string, error) {
+		return "", fmt.Errorf("secret-manager unavailable")
+	}
+
+	err := NewImporter(mockedClient, resolveSecret).Import(context.Background(), newTestBackup())
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to import client my-client")
+}
+
+func TestImportFailsWhenRealmCanNotBeCreated(t *testing.T) {
+	mockedClient := mocks.NewMockKeycloakClient(t)
+	mockedClient.EXPECT().GetRealmWithResponse(mock.Anything, Realm).
+		Return(&api.GetRealmResponse{HTTPResponse: ptr.To(http.Response{StatusCode: http.StatusNotFound})}, nil)
+	mockedClient.EXPECT().PostWithResponse(mock.Anything, mock.Anything).
+		Return(&api.PostResponse{
+			HTTPResponse: ptr.To(http.Response{StatusCode: http.StatusConflict}),
+			Body:         []byte("conflict"),
+		}, nil)
+
+	err := NewImporter(mockedClient, resolveTestSecret).Import(context.Background(), newTestBackup())
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to create realm: 409")
+}
+
+func TestImportReturnsClientError(t *testing.T) {
+	err := NewImporter(nil, nil).Import(context.Background(), newTestBackup())
+
+	assert.Error(t, err)
+}
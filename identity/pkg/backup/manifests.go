@@ -0,0 +1,115 @@
+package backup
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/telekom/controlplane-mono/common/pkg/config"
+	"github.com/telekom/controlplane-mono/common/pkg/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	identityv1 "github.com/telekom/controlplane-mono/identity/api/v1"
+)
+
+var invalidNameCharacters = regexp.MustCompile(`[^a-z0-9-]+`)
+
+// ManifestOptions configure where the generated custom resources are placed
+type ManifestOptions struct {
+	Namespace   string
+	Environment string
+	// IdentityProvider is the name of the IdentityProvider that the realms are provisioned on
+	IdentityProvider string
+}
+
+// Manifests converts the backup into Realm and Client custom resources.
+// Client secrets are kept as secret-manager references.
+func Manifests(backup *Backup, options ManifestOptions) ([]client.Object, error) {
+	if backup == nil {
+		return nil, fmt.Errorf("backup is nil")
+	}
+	if options.Namespace == "" {
+		return nil, fmt.Errorf("namespace is required")
+	}
+	if options.IdentityProvider == "" {
+		return nil, fmt.Errorf("identity provider is required")
+	}
+
+	var objects []client.Object
+	for _, realmBackup := range backup.Realms {
+		realmName := realmBackup.Name()
+		if realmName == "" {
+			return nil, fmt.Errorf("realm name is empty")
+		}
+
+		realm := &identityv1.Realm{
+			TypeMeta:   metav1.TypeMeta{APIVersion: identityv1.GroupVersion.String(), Kind: "Realm"},
+			ObjectMeta: newObjectMeta(ToResourceName(realmName), options),
+			Spec: identityv1.RealmSpec{
+				IdentityProvider: &types.ObjectRef{
+					Name:      options.IdentityProvider,
+					Namespace: options.Namespace,
+				},
+			},
+		}
+		objects = append(objects, realm)
+
+		for _, realmClient := range realmBackup.Clients {
+			clientId := ptr.Deref(realmClient.ClientId, "")
+			objects = append(objects, &identityv1.Client{
+				TypeMeta:   metav1.TypeMeta{APIVersion: identityv1.GroupVersion.String(), Kind: "Client"},
+				ObjectMeta: newObjectMeta(ToResourceName(clientId), options),
+				Spec: identityv1.ClientSpec{
+					Realm: &types.ObjectRef{
+						Name:      realm.Name,
+						Namespace: options.Namespace,
+					},
+					ClientId:     clientId,
+					ClientSecret: ptr.Deref(realmClient.Secret, ""),
+				},
+			})
+		}
+	}
+	return objects, nil
+}
+
+// WriteManifests writes the custom resources as multi-document yaml
+func WriteManifests(w io.Writer, backup *Backup, options ManifestOptions) error {
+	objects, err := Manifests(backup, options)
+	if err != nil {
+		return err
+	}
+	for _, obj := range objects {
+		out, err := yaml.Marshal(obj)
+		if err != nil {
+			return errors.Wrapf(err, "failed to marshal %s", obj.GetName())
+		}
+		if _, err = fmt.Fprintf(w, "---\n%s", out); err != nil {
+			return errors.Wrap(err, "failed to write manifests")
+		}
+	}
+	return nil
+}
+
+// ToResourceName converts a keycloak name into a valid kubernetes resource name
+func ToResourceName(name string) string {
+	return strings.Trim(invalidNameCharacters.ReplaceAllString(strings.ToLower(name), "-"), "-")
+}
+
+func newObjectMeta(name string, options ManifestOptions) metav1.ObjectMeta {
+	meta := metav1.ObjectMeta{
+		Name:      name,
+		Namespace: options.Namespace,
+	}
+	if options.Environment != "" {
+		meta.Labels = map[string]string{
+			config.EnvironmentLabelKey: options.Environment,
+		}
+	}
+	return meta
+}
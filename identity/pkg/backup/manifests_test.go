@@ -0,0 +1,72 @@
+package backup
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/telekom/controlplane-mono/common/pkg/config"
+	"k8s.io/utils/ptr"
+
+	identityv1 "github.com/telekom/controlplane-mono/identity/api/v1"
+	"github.com/telekom/controlplane-mono/identity/pkg/api"
+)
+
+func TestManifestsCreatesRealmsAndClients(t *testing.T) {
+	input := &Backup{
+		Version: FormatVersion,
+		Realms: []RealmBackup{
+			{
+				Realm: api.RealmRepresentation{Realm: ptr.To("Test-Realm")},
+				Clients: []api.ClientRepresentation{
+					{ClientId: ptr.To("my_client"), Secret: ptr.To("$<test-realm:::my_client/clientSecret:>")},
+				},
+			},
+		},
+	}
+	options := ManifestOptions{
+		Namespace:        "default",
+		Environment:      "test",
+		IdentityProvider: "keycloak",
+	}
+
+	objects, err := Manifests(input, options)
+
+	assert.NoError(t, err)
+	assert.Len(t, objects, 2)
+
+	realm := objects[0].(*identityv1.Realm)
+	assert.Equal(t, "test-realm", realm.Name)
+	assert.Equal(t, "default", realm.Namespace)
+	assert.Equal(t, "test", realm.Labels[config.EnvironmentLabelKey])
+	assert.Equal(t, "keycloak", realm.Spec.IdentityProvider.Name)
+
+	client := objects[1].(*identityv1.Client)
+	assert.Equal(t, "my-client", client.Name)
+	assert.Equal(t, "my_client", client.Spec.ClientId)
+	assert.Equal(t, "$<test-realm:::my_client/clientSecret:>", client.Spec.ClientSecret)
+	assert.Equal(t, "test-realm", client.Spec.Realm.Name)
+
+	var buffer bytes.Buffer
+	assert.NoError(t, WriteManifests(&buffer, input, options))
+	assert.Contains(t, buffer.String(), "kind: Realm")
+	assert.Contains(t, buffer.String(), "kind: Client")
+}
+
+func TestManifestsRequiresOptions(t *testing.T) {
+	input := &Backup{Version: FormatVersion}
+
+	_, err := Manifests(input, ManifestOptions{IdentityProvider: "keycloak"})
+	assert.EqualError(t, err, "namespace is required")
+
+	_, err = Manifests(input, ManifestOptions{Namespace: "default"})
+	assert.EqualError(t, err, "identity provider is required")
+
+	_, err = Manifests(nil, ManifestOptions{})
+	assert.Error(t, err)
+}
+
+func TestToResourceName(t *testing.T) {
+	assert.Equal(t, "my-client", ToResourceName("My_Client"))
+	assert.Equal(t, "a-b", ToResourceName("--a..b--"))
+}
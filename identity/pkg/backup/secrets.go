@@ -0,0 +1,73 @@
+package backup
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	secrets "github.com/telekom/controlplane-mono/secret-manager/pkg/api"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/backend"
+)
+
+const (
+	SecretKeyClientSecret = "clientSecret"
+
+	// MaskedSecret is returned by keycloak instead of secrets that can not be read back
+	MaskedSecret = "**********"
+
+	// DefaultSecretRefTemplate is used to build the secret-manager references of exported secrets.
+	// Supported placeholders are {realm}, {name} and {key}.
+	// The references are secret IDs env:team:app:path:checksum, by default the secrets of the environment named like the realm.
+	DefaultSecretRefTemplate SecretRefTemplate = "{realm}:::{name}/{key}:"
+)
+
+var secretRefPlaceholders = []string{"{realm}", "{name}", "{key}"}
+
+// SecretRefTemplate builds the secret-manager reference for a secret of a realm
+type SecretRefTemplate string
+
+// RefFor returns the secret-manager reference for the given secret
+func (t SecretRefTemplate) RefFor(realm, name, key string) string {
+	replacer := strings.NewReplacer("{realm}", realm, "{name}", name, "{key}", key)
+	return secrets.ToRef(replacer.Replace(string(t)))
+}
+
+// Validate checks that the template produces valid secret-manager IDs that are unique for each secret
+func (t SecretRefTemplate) Validate() error {
+	for _, placeholder := range secretRefPlaceholders {
+		if !strings.Contains(string(t), placeholder) {
+			return fmt.Errorf("secret reference template %q must contain %s", t, placeholder)
+		}
+	}
+	replacer := strings.NewReplacer("{realm}", "realm", "{name}", "name", "{key}", "key")
+	id, err := backend.ParseId(replacer.Replace(string(t)))
+	if err != nil {
+		return fmt.Errorf("secret reference template %q must produce IDs env:team:app:path:checksum: %w", t, err)
+	}
+	if id.Path == "" {
+		return fmt.Errorf("secret reference template %q must produce IDs with a path", t)
+	}
+	return nil
+}
+
+// SecretStore stores the value of a secret and returns the reference that must be used to retrieve it
+type SecretStore func(ctx context.Context, secretRef, value string) (string, error)
+
+// SecretResolver resolves a secret-manager reference to its value
+type SecretResolver func(ctx context.Context, secretRef string) (string, error)
+
+// DiscardSecrets does not store the secret and keeps the reference as is.
+// The secrets must then be provided to the secret-manager by other means before importing.
+func DiscardSecrets(_ context.Context, secretRef, _ string) (string, error) {
+	return secretRef, nil
+}
+
+// StoreSecretsInSecretManager stores the secret in the secret-manager
+func StoreSecretsInSecretManager(ctx context.Context, secretRef, value string) (string, error) {
+	return secrets.Set(ctx, secretRef, value)
+}
+
+// ResolveSecretsFromSecretManager resolves secret-manager references, other values are returned as is
+func ResolveSecretsFromSecretManager(ctx context.Context, secretRef string) (string, error) {
+	return secrets.Get(ctx, secretRef)
+}
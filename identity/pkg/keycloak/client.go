@@ -8,6 +8,10 @@ import (
 )
 
 type RealmClient interface {
+	// Server related operations
+
+	GetServerInfo(ctx context.Context) (*api.ServerInfoRepresentation, error)
+
 	// Realm related operations
 
 	GetRealm(ctx context.Context, realm string) (*api.GetRealmResponse, error)
@@ -24,4 +28,25 @@ type RealmClient interface {
 	PostRealmClient(ctx context.Context, realmName string,
 		client *identityv1.Client) (*api.PostRealmClientsResponse, error)
 	CreateOrUpdateRealmClient(ctx context.Context, realm *identityv1.Realm, client *identityv1.Client) error
+	AssignServiceAccountRoles(ctx context.Context, realm *identityv1.Realm, client *identityv1.Client) error
+
+	// ClientScope related operations
+	GetRealmClientScopes(ctx context.Context, realm string) (*api.GetRealmClientScopesResponse, error)
+	PutRealmClientScope(ctx context.Context, realmName, id string,
+		clientScope *identityv1.ClientScope) (*api.PutRealmClientScopesIdResponse, error)
+	PostRealmClientScope(ctx context.Context, realmName string,
+		clientScope *identityv1.ClientScope) (*api.PostRealmClientScopesResponse, error)
+	CreateOrUpdateRealmClientScope(ctx context.Context, realm *identityv1.Realm, clientScope *identityv1.ClientScope) error
+	DeleteRealmClientScope(ctx context.Context, realm *identityv1.Realm, clientScope *identityv1.ClientScope) error
+
+	// Role related operations
+	GetRealmRole(ctx context.Context, realmName string, role *identityv1.Role) (*api.RoleRepresentation, error)
+	CreateOrUpdateRealmRole(ctx context.Context, realm *identityv1.Realm, role *identityv1.Role) error
+	DeleteRealmRole(ctx context.Context, realm *identityv1.Realm, role *identityv1.Role) error
+
+	// IdentityBroker related operations
+	GetRealmIdentityBroker(ctx context.Context, realmName, alias string) (*api.IdentityProviderRepresentation, error)
+	CreateOrUpdateRealmIdentityBroker(ctx context.Context, realm *identityv1.Realm,
+		broker *identityv1.IdentityBroker) error
+	DeleteRealmIdentityBroker(ctx context.Context, realm *identityv1.Realm, broker *identityv1.IdentityBroker) error
 }
@@ -1,8 +1,11 @@
 package keycloak
 
 import (
+	"errors"
 	"net/http"
 	"slices"
+
+	"golang.org/x/oauth2"
 )
 
 type ApiResponse interface {
@@ -47,3 +50,13 @@ func CheckStatusCode(res ApiResponse, okStatusCodes ...int) ApiError {
 		RetryAllowed: false,
 	}
 }
+
+// IsAuthenticationError returns true if Keycloak rejected the admin credentials while retrieving a token.
+// Network errors and server errors are not authentication errors.
+func IsAuthenticationError(err error) bool {
+	var retrieveError *oauth2.RetrieveError
+	if !errors.As(err, &retrieveError) || retrieveError.Response == nil {
+		return false
+	}
+	return retrieveError.Response.StatusCode < http.StatusInternalServerError
+}
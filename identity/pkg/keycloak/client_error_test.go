@@ -1,10 +1,13 @@
 package keycloak
 
 import (
+	"fmt"
 	"net/http"
 	"testing"
 
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
+	"golang.org/x/oauth2"
 )
 
 type MockApiResponse struct {
@@ -52,3 +55,20 @@ func TestStatusCodeIsServiceUnavailable(t *testing.T) {
 	assert.Equal(t, "Keycloak server error", err.Error())
 	assert.True(t, err.Retriable())
 }
+
+func TestIsAuthenticationErrorForRejectedCredentials(t *testing.T) {
+	err := errors.Wrap(&oauth2.RetrieveError{Response: &http.Response{StatusCode: http.StatusUnauthorized}},
+		"failed to retrieve token")
+	assert.True(t, IsAuthenticationError(err))
+}
+
+func TestIsAuthenticationErrorForServerError(t *testing.T) {
+	err := errors.Wrap(&oauth2.RetrieveError{Response: &http.Response{StatusCode: http.StatusBadGateway}},
+		"failed to retrieve token")
+	assert.False(t, IsAuthenticationError(err))
+}
+
+func TestIsAuthenticationErrorForNetworkError(t *testing.T) {
+	err := errors.Wrap(fmt.Errorf("connection refused"), "failed to retrieve token")
+	assert.False(t, IsAuthenticationError(err))
+}
@@ -9,7 +9,6 @@ import (
 	"github.com/pkg/errors"
 	"golang.org/x/oauth2"
 
-	identityv1 "github.com/telekom/controlplane-mono/identity/api/v1"
 	"github.com/telekom/controlplane-mono/identity/pkg/api"
 )
 
@@ -130,26 +129,14 @@ var NewClientFor = func(config AdminConfig) (*api.ClientWithResponses, error) {
 
 const EmptyString = ""
 
-func GetClientForRealm(realmStatus identityv1.RealmStatus) (RealmClient, error) {
-	keycloakClientConfig := NewKeycloakClientConfig(
-		realmStatus.AdminUrl,
-		realmStatus.IssuerUrl,
-		realmStatus.AdminTokenUrl,
-		realmStatus.AdminClientId,
-		EmptyString, // client secret is not needed for the admin client, because PasswordCredentialsFlow must be used
-		realmStatus.AdminUserName,
-		realmStatus.AdminPassword,
-	)
-
-	clientWithResponses, err := NewClientFor(keycloakClientConfig)
+func GetClientForConfig(config AdminConfig) (RealmClient, error) {
+	clientWithResponses, err := NewClientFor(config)
 	if err != nil {
 		return nil, err
-	} else {
-		client := NewRealmClient(clientWithResponses)
-		return client, nil
 	}
+	return NewRealmClient(clientWithResponses), nil
 }
 
-var GetClientFor = func(realmStatus identityv1.RealmStatus) (RealmClient, error) {
-	return GetClientForRealm(realmStatus)
+var GetClientFor = func(config AdminConfig) (RealmClient, error) {
+	return GetClientForConfig(config)
 }
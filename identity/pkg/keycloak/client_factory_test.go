@@ -4,8 +4,6 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
-
-	identityv1 "github.com/telekom/controlplane-mono/identity/api/v1"
 )
 
 type MockAdminConfig struct {
@@ -66,30 +64,30 @@ func TestOauth2ClientCreationFailureInvalidCredentials(t *testing.T) {
 	assert.Nil(t, client)
 }
 
-func TestClientForRealmCreationHostError(t *testing.T) {
-	realmStatus := identityv1.RealmStatus{
-		AdminUrl:      "https://example.com/auth/admin/realms/",
-		IssuerUrl:     "https://example.com/auth/realms/test-realm",
-		AdminTokenUrl: "https://example.com/auth/realms/test-realm/protocol/openid-connect/token",
-		AdminClientId: "admin-client-id",
-		AdminUserName: "admin-username",
-		AdminPassword: "admin-password",
+func TestClientForConfigCreationHostError(t *testing.T) {
+	config := &MockAdminConfig{
+		endpointUrl: "https://example.com/auth/admin/realms/",
+		issuerUrl:   "https://example.com/auth/realms/test-realm",
+		tokenUrl:    "https://example.com/auth/realms/test-realm/protocol/openid-connect/token",
+		clientId:    "admin-client-id",
+		username:    "admin-username",
+		password:    "admin-password",
 	}
-	client, err := GetClientForRealm(realmStatus)
+	client, err := GetClientForConfig(config)
 	assert.Error(t, err)
 	assert.Nil(t, client)
 }
 
-func TestClientForRealmCreationFailure(t *testing.T) {
-	realmStatus := identityv1.RealmStatus{
-		AdminUrl:      "https://example.com/auth/admin/realms/",
-		IssuerUrl:     "https://example.com/auth/realms/test-realm",
-		AdminTokenUrl: "://invalid-url",
-		AdminClientId: "admin-client-id",
-		AdminUserName: "admin-username",
-		AdminPassword: "admin-password",
+func TestClientForConfigCreationFailure(t *testing.T) {
+	config := &MockAdminConfig{
+		endpointUrl: "https://example.com/auth/admin/realms/",
+		issuerUrl:   "https://example.com/auth/realms/test-realm",
+		tokenUrl:    "://invalid-url",
+		clientId:    "admin-client-id",
+		username:    "admin-username",
+		password:    "admin-password",
 	}
-	client, err := GetClientForRealm(realmStatus)
+	client, err := GetClientForConfig(config)
 	assert.Error(t, err)
 	assert.Nil(t, client)
 }
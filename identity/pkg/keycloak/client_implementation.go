@@ -175,13 +175,18 @@ func (k *realmClient) CreateOrUpdateRealm(ctx context.Context, realm *identityv1
 
 func (k *realmClient) GetRealmClients(ctx context.Context, realm string,
 	client *identityv1.Client) (*api.GetRealmClientsResponse, error) {
-	logger := log.FromContext(ctx)
 	if k.clientWithResponses == nil {
 		return nil, fmt.Errorf("keycloak client is required")
 	}
 
+	return k.getRealmClientsByClientId(ctx, realm, client.Spec.ClientId)
+}
+
+func (k *realmClient) getRealmClientsByClientId(ctx context.Context, realm string,
+	clientId string) (*api.GetRealmClientsResponse, error) {
+	logger := log.FromContext(ctx)
 	var getRealmClientsParams = &api.GetRealmClientsParams{
-		ClientId:     ptr.To(client.Spec.ClientId),
+		ClientId:     ptr.To(clientId),
 		Search:       ptr.To(false), // Exact search only
 		ViewableOnly: ptr.To(true),
 	}
@@ -210,7 +215,12 @@ func (k *realmClient) GetRealmClients(ctx context.Context, realm string,
 
 func (k *realmClient) getRealmClient(ctx context.Context, realmName string,
 	client *identityv1.Client) (*api.ClientRepresentation, error) {
-	var getRealmClients, err = k.GetRealmClients(ctx, realmName, client)
+	return k.getRealmClientByClientId(ctx, realmName, client.Spec.ClientId)
+}
+
+func (k *realmClient) getRealmClientByClientId(ctx context.Context, realmName string,
+	clientId string) (*api.ClientRepresentation, error) {
+	var getRealmClients, err = k.getRealmClientsByClientId(ctx, realmName, clientId)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,312 @@
+package keycloak
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	identityv1 "github.com/telekom/controlplane-mono/identity/api/v1"
+	"github.com/telekom/controlplane-mono/identity/pkg/api"
+	"github.com/telekom/controlplane-mono/identity/pkg/keycloak/mapper"
+)
+
+func (k *realmClient) GetRealmIdentityBroker(ctx context.Context, realmName,
+	alias string) (*api.IdentityProviderRepresentation, error) {
+	logger := log.FromContext(ctx)
+	if k.clientWithResponses == nil {
+		return nil, fmt.Errorf("keycloak client is required")
+	}
+
+	logger.V(1).Info("GetRealmIdentityBroker", "ℹ️ request realm", realmName)
+	logger.V(1).Info("GetRealmIdentityBroker", "ℹ️ request alias", alias)
+	start := time.Now()
+	get, err := k.clientWithResponses.GetRealmIdentityProviderInstancesAliasWithResponse(ctx, realmName, alias)
+	IncreaseDurationMetrics(start, "GET", "GetRealmIdentityBroker")
+	if err != nil {
+		IncreaseErrorMetrics()
+		return nil, err
+	}
+
+	if responseErr := CheckStatusCode(get, http.StatusOK, http.StatusNotFound); responseErr != nil {
+		IncreaseErrorMetrics()
+		return nil, fmt.Errorf("❌ failed to get identity broker: %d -- Response for GET is: %s",
+			get.StatusCode(), string(get.Body))
+	}
+
+	IncreaseStatusMetrics(strconv.Itoa(get.StatusCode()), "GET", "GetRealmIdentityBroker")
+	if get.StatusCode() == http.StatusNotFound {
+		return nil, nil
+	}
+	return get.JSON2XX, nil
+}
+
+func CheckForIdentityBrokerChanges(broker *identityv1.IdentityBroker,
+	existingIdp *api.IdentityProviderRepresentation,
+	logger logr.Logger) *api.IdentityProviderRepresentation {
+
+	idpRepresentation := mapper.MapToIdentityProviderRepresentation(broker)
+	if mapper.CompareIdentityProviderRepresentation(existingIdp, &idpRepresentation) {
+		var message = fmt.Sprintf("ℹ️ No changes detected for identity broker %s", broker.Spec.Alias)
+		logger.V(1).Info(message)
+	} else {
+		var message = fmt.Sprintf("🧹 Changes found for identity broker %s in keycloak", broker.Spec.Alias)
+		logger.V(1).Info(message)
+	}
+	// Merge existing identity provider with new identity provider and update it in keycloak
+	return mapper.MergeIdentityProviderRepresentation(existingIdp, &idpRepresentation)
+}
+
+func (k *realmClient) putIdentityBroker(ctx context.Context, realmName, alias string,
+	body api.IdentityProviderRepresentation) error {
+	logger := log.FromContext(ctx)
+
+	// The body is not logged, it contains the resolved client secret
+	logger.V(1).Info("PutRealmIdentityBroker", "ℹ️ request realm", realmName)
+	logger.V(1).Info("PutRealmIdentityBroker", "ℹ️ request alias", alias)
+
+	start := time.Now()
+	put, err := k.clientWithResponses.PutRealmIdentityProviderInstancesAliasWithResponse(ctx, realmName, alias, body)
+	IncreaseDurationMetrics(start, "PUT", "PutRealmIdentityBroker")
+	if err != nil {
+		IncreaseErrorMetrics()
+		return err
+	}
+
+	if responseErr := CheckStatusCode(put, http.StatusNoContent); responseErr != nil {
+		IncreaseErrorMetrics()
+		return fmt.Errorf("❌ failed to update identity broker: %d -- Response for PUT is: %s",
+			put.StatusCode(), string(put.Body))
+	}
+
+	IncreaseStatusMetrics(strconv.Itoa(put.StatusCode()), "PUT", "PutRealmIdentityBroker")
+	return nil
+}
+
+func (k *realmClient) postIdentityBroker(ctx context.Context, realmName string,
+	body api.IdentityProviderRepresentation) error {
+	logger := log.FromContext(ctx)
+
+	// The body is not logged, it contains the resolved client secret
+	logger.V(1).Info("PostRealmIdentityBroker", "ℹ️ request realm", realmName)
+	logger.V(1).Info("PostRealmIdentityBroker", "ℹ️ request alias", ptr.Deref(body.Alias, ""))
+
+	start := time.Now()
+	post, err := k.clientWithResponses.PostRealmIdentityProviderInstancesWithResponse(ctx, realmName, body)
+	IncreaseDurationMetrics(start, "POST", "PostRealmIdentityBroker")
+	if err != nil {
+		IncreaseErrorMetrics()
+		return err
+	}
+
+	if responseErr := CheckStatusCode(post, http.StatusCreated); responseErr != nil {
+		IncreaseErrorMetrics()
+		return fmt.Errorf("❌ failed to create identity broker: %d -- Response for POST is: %s",
+			post.StatusCode(), string(post.Body))
+	}
+
+	IncreaseStatusMetrics(strconv.Itoa(post.StatusCode()), "POST", "PostRealmIdentityBroker")
+	return nil
+}
+
+// CreateOrUpdateRealmIdentityBroker creates or updates the upstream identity provider and its mappers.
+// All secret-manager references of the broker must already be resolved.
+func (k *realmClient) CreateOrUpdateRealmIdentityBroker(ctx context.Context, realm *identityv1.Realm,
+	broker *identityv1.IdentityBroker) error {
+	logger := log.FromContext(ctx)
+
+	existingIdp, err := k.GetRealmIdentityBroker(ctx, realm.Name, broker.Spec.Alias)
+	if err != nil {
+		return err
+	}
+
+	if existingIdp != nil {
+		var message = fmt.Sprintf("🔍 found existing identity broker %s in keycloak", broker.Spec.Alias)
+		logger.V(1).Info(message)
+		body := CheckForIdentityBrokerChanges(broker, existingIdp, logger)
+		if err := k.putIdentityBroker(ctx, realm.Name, broker.Spec.Alias, *body); err != nil {
+			return err
+		}
+		var successMessage = fmt.Sprintf("✅ updated existing identity broker %s in realm %s",
+			broker.Spec.Alias, realm.Name)
+		logger.V(1).Info(successMessage)
+	} else {
+		var message = fmt.Sprintf("identity broker %s not found in keycloak", broker.Spec.Alias)
+		logger.V(1).Info(message)
+		body := mapper.MapToIdentityProviderRepresentation(broker)
+		if err := k.postIdentityBroker(ctx, realm.Name, body); err != nil {
+			return err
+		}
+		var successMessage = fmt.Sprintf("✅ created identity broker %s in realm %s", broker.Spec.Alias, realm.Name)
+		logger.V(1).Info(successMessage)
+	}
+
+	return k.reconcileIdentityBrokerMappers(ctx, realm.Name, broker)
+}
+
+// reconcileIdentityBrokerMappers makes the mappers of the identity provider match the broker spec.
+// Unlike protocol mappers, the mappers are owned by the broker and unknown mappers are removed.
+func (k *realmClient) reconcileIdentityBrokerMappers(ctx context.Context, realmName string,
+	broker *identityv1.IdentityBroker) error {
+	logger := log.FromContext(ctx)
+	alias := broker.Spec.Alias
+
+	existingMappers, err := k.getIdentityBrokerMappers(ctx, realmName, alias)
+	if err != nil {
+		return err
+	}
+
+	for _, brokerMapper := range broker.Spec.Mappers {
+		body := mapper.MapToIdentityProviderMapperRepresentation(alias, brokerMapper)
+		existingMapper := mapper.GetIdentityProviderMapper(existingMappers, brokerMapper.Name)
+		if existingMapper == nil || existingMapper.Id == nil {
+			if err := k.postIdentityBrokerMapper(ctx, realmName, alias, body); err != nil {
+				return err
+			}
+			continue
+		}
+		if mapper.CompareIdentityProviderMapperRepresentation(existingMapper, &body) {
+			var message = fmt.Sprintf("ℹ️ No changes detected for mapper %s of identity broker %s",
+				brokerMapper.Name, alias)
+			logger.V(1).Info(message)
+			continue
+		}
+		body.Id = existingMapper.Id
+		if err := k.putIdentityBrokerMapper(ctx, realmName, alias, body); err != nil {
+			return err
+		}
+	}
+
+	for _, obsoleteMapper := range mapper.FindObsoleteIdentityProviderMappers(existingMappers, broker.Spec.Mappers) {
+		if obsoleteMapper.Id == nil {
+			continue
+		}
+		if err := k.deleteIdentityBrokerMapper(ctx, realmName, alias, *obsoleteMapper.Id); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (k *realmClient) getIdentityBrokerMappers(ctx context.Context, realmName,
+	alias string) (*[]api.IdentityProviderMapperRepresentation, error) {
+	start := time.Now()
+	get, err := k.clientWithResponses.GetRealmIdentityProviderInstancesAliasMappersWithResponse(ctx, realmName, alias)
+	IncreaseDurationMetrics(start, "GET", "GetRealmIdentityBrokerMappers")
+	if err != nil {
+		IncreaseErrorMetrics()
+		return nil, err
+	}
+
+	if responseErr := CheckStatusCode(get, http.StatusOK); responseErr != nil {
+		IncreaseErrorMetrics()
+		return nil, fmt.Errorf("❌ failed to get identity broker mappers: %d -- Response for GET is: %s",
+			get.StatusCode(), string(get.Body))
+	}
+
+	IncreaseStatusMetrics(strconv.Itoa(get.StatusCode()), "GET", "GetRealmIdentityBrokerMappers")
+	return get.JSON2XX, nil
+}
+
+func (k *realmClient) putIdentityBrokerMapper(ctx context.Context, realmName, alias string,
+	body api.IdentityProviderMapperRepresentation) error {
+	logger := log.FromContext(ctx)
+	logger.V(1).Info("PutRealmIdentityBrokerMapper", "ℹ️ request body", body)
+
+	start := time.Now()
+	put, err := k.clientWithResponses.PutRealmIdentityProviderInstancesAliasMappersIdWithResponse(ctx, realmName,
+		alias, *body.Id, body)
+	IncreaseDurationMetrics(start, "PUT", "PutRealmIdentityBrokerMapper")
+	if err != nil {
+		IncreaseErrorMetrics()
+		return err
+	}
+
+	if responseErr := CheckStatusCode(put, http.StatusNoContent); responseErr != nil {
+		IncreaseErrorMetrics()
+		return fmt.Errorf("❌ failed to update identity broker mapper: %d -- Response for PUT is: %s",
+			put.StatusCode(), string(put.Body))
+	}
+
+	IncreaseStatusMetrics(strconv.Itoa(put.StatusCode()), "PUT", "PutRealmIdentityBrokerMapper")
+	return nil
+}
+
+func (k *realmClient) postIdentityBrokerMapper(ctx context.Context, realmName, alias string,
+	body api.IdentityProviderMapperRepresentation) error {
+	logger := log.FromContext(ctx)
+	logger.V(1).Info("PostRealmIdentityBrokerMapper", "ℹ️ request body", body)
+
+	start := time.Now()
+	post, err := k.clientWithResponses.PostRealmIdentityProviderInstancesAliasMappersWithResponse(ctx, realmName,
+		alias, body)
+	IncreaseDurationMetrics(start, "POST", "PostRealmIdentityBrokerMapper")
+	if err != nil {
+		IncreaseErrorMetrics()
+		return err
+	}
+
+	if responseErr := CheckStatusCode(post, http.StatusCreated); responseErr != nil {
+		IncreaseErrorMetrics()
+		return fmt.Errorf("❌ failed to create identity broker mapper: %d -- Response for POST is: %s",
+			post.StatusCode(), string(post.Body))
+	}
+
+	IncreaseStatusMetrics(strconv.Itoa(post.StatusCode()), "POST", "PostRealmIdentityBrokerMapper")
+	return nil
+}
+
+func (k *realmClient) deleteIdentityBrokerMapper(ctx context.Context, realmName, alias, id string) error {
+	start := time.Now()
+	del, err := k.clientWithResponses.DeleteRealmIdentityProviderInstancesAliasMappersIdWithResponse(ctx, realmName,
+		alias, id)
+	IncreaseDurationMetrics(start, "DELETE", "DeleteRealmIdentityBrokerMapper")
+	if err != nil {
+		IncreaseErrorMetrics()
+		return err
+	}
+
+	if responseErr := CheckStatusCode(del, http.StatusNoContent, http.StatusNotFound); responseErr != nil {
+		IncreaseErrorMetrics()
+		return fmt.Errorf("❌ failed to delete identity broker mapper: %d -- Response for DELETE is: %s",
+			del.StatusCode(), string(del.Body))
+	}
+
+	IncreaseStatusMetrics(strconv.Itoa(del.StatusCode()), "DELETE", "DeleteRealmIdentityBrokerMapper")
+	return nil
+}
+
+func (k *realmClient) DeleteRealmIdentityBroker(ctx context.Context, realm *identityv1.Realm,
+	broker *identityv1.IdentityBroker) error {
+	logger := log.FromContext(ctx)
+	if k.clientWithResponses == nil {
+		return fmt.Errorf("keycloak client is required")
+	}
+
+	// Mappers are removed by keycloak together with the identity provider
+	start := time.Now()
+	del, err := k.clientWithResponses.DeleteRealmIdentityProviderInstancesAliasWithResponse(ctx, realm.Name,
+		broker.Spec.Alias)
+	IncreaseDurationMetrics(start, "DELETE", "DeleteRealmIdentityBroker")
+	if err != nil {
+		IncreaseErrorMetrics()
+		return err
+	}
+
+	if responseErr := CheckStatusCode(del, http.StatusNoContent, http.StatusNotFound); responseErr != nil {
+		IncreaseErrorMetrics()
+		return fmt.Errorf("❌ failed to delete identity broker: %d -- Response for DELETE is: %s",
+			del.StatusCode(), string(del.Body))
+	}
+
+	IncreaseStatusMetrics(strconv.Itoa(del.StatusCode()), "DELETE", "DeleteRealmIdentityBroker")
+	var successMessage = fmt.Sprintf("✅ deleted identity broker %s in realm %s", broker.Spec.Alias, realm.Name)
+	logger.V(1).Info(successMessage)
+	return nil
+}
@@ -0,0 +1,223 @@
+package keycloak
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"k8s.io/utils/ptr"
+
+	identityv1 "github.com/telekom/controlplane-mono/identity/api/v1"
+	"github.com/telekom/controlplane-mono/identity/pkg/api"
+	"github.com/telekom/controlplane-mono/identity/test/mocks"
+)
+
+const BrokerAlias = "test-broker"
+
+func newTestIdentityBroker() *identityv1.IdentityBroker {
+	return &identityv1.IdentityBroker{
+		Spec: identityv1.IdentityBrokerSpec{
+			Alias:        BrokerAlias,
+			DisplayName:  "Test Broker",
+			ProviderType: identityv1.IdentityBrokerTypeOIDC,
+			OIDC: &identityv1.OIDCBrokerConfig{
+				AuthorizationUrl: "https://upstream.example.com/auth",
+				TokenUrl:         "https://upstream.example.com/token",
+				ClientId:         "upstream-client",
+				ClientSecret:     "upstream-secret",
+				DefaultScopes:    []string{"openid", "email"},
+			},
+			Mappers: []identityv1.IdentityBrokerMapper{
+				{
+					Name:   "email",
+					Type:   "oidc-user-attribute-idp-mapper",
+					Config: map[string]string{"claim": "email", "user.attribute": "email"},
+				},
+			},
+		},
+	}
+}
+
+func mockGetRealmIdentityProviderMappers(mockedClient *mocks.MockKeycloakClient,
+	mappers ...api.IdentityProviderMapperRepresentation) {
+	mockedClient.EXPECT().GetRealmIdentityProviderInstancesAliasMappersWithResponse(mock.Anything, Realm, BrokerAlias).
+		Return(&api.GetRealmIdentityProviderInstancesAliasMappersResponse{
+			HTTPResponse: ptr.To(http.Response{StatusCode: http.StatusOK}),
+			JSON2XX:      &mappers,
+		}, nil)
+}
+
+func TestGetRealmIdentityBrokerReturnsClientError(t *testing.T) {
+	realmClient := NewRealmClient(nil)
+	result, err := realmClient.GetRealmIdentityBroker(context.Background(), Realm, BrokerAlias)
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+}
+
+func TestGetRealmIdentityBrokerReturnsNilForMissingBroker(t *testing.T) {
+	mockedClient := mocks.NewMockKeycloakClient(t)
+	mockedClient.EXPECT().GetRealmIdentityProviderInstancesAliasWithResponse(mock.Anything, Realm, BrokerAlias).
+		Return(&api.GetRealmIdentityProviderInstancesAliasResponse{
+			HTTPResponse: ptr.To(http.Response{StatusCode: http.StatusNotFound}),
+		}, nil)
+
+	result, err := NewRealmClient(mockedClient).GetRealmIdentityBroker(context.Background(), Realm, BrokerAlias)
+
+	assert.NoError(t, err)
+	assert.Nil(t, result)
+}
+
+func TestGetRealmIdentityBrokerReturnsError(t *testing.T) {
+	mockedClient := mocks.NewMockKeycloakClient(t)
+	mockedClient.EXPECT().GetRealmIdentityProviderInstancesAliasWithResponse(mock.Anything, Realm, BrokerAlias).
+		Return(nil, fmt.Errorf("error getting identity provider"))
+
+	result, err := NewRealmClient(mockedClient).GetRealmIdentityBroker(context.Background(), Realm, BrokerAlias)
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+}
+
+func TestCreateOrUpdateRealmIdentityBrokerCreatesBrokerAndMappers(t *testing.T) {
+	mockedClient := mocks.NewMockKeycloakClient(t)
+	mockedClient.EXPECT().GetRealmIdentityProviderInstancesAliasWithResponse(mock.Anything, Realm, BrokerAlias).
+		Return(&api.GetRealmIdentityProviderInstancesAliasResponse{
+			HTTPResponse: ptr.To(http.Response{StatusCode: http.StatusNotFound}),
+		}, nil)
+	mockedClient.EXPECT().PostRealmIdentityProviderInstancesWithResponse(mock.Anything, Realm,
+		mock.MatchedBy(func(body api.IdentityProviderRepresentation) bool {
+			config := *body.Config
+			return *body.Alias == BrokerAlias && *body.ProviderId == "oidc" && *body.Enabled &&
+				config["clientSecret"] == "upstream-secret" && config["defaultScope"] == "openid email"
+		})).
+		Return(&api.PostRealmIdentityProviderInstancesResponse{
+			HTTPResponse: ptr.To(http.Response{StatusCode: http.StatusCreated}),
+		}, nil)
+	mockGetRealmIdentityProviderMappers(mockedClient)
+	mockedClient.EXPECT().PostRealmIdentityProviderInstancesAliasMappersWithResponse(mock.Anything, Realm, BrokerAlias,
+		mock.MatchedBy(func(body api.IdentityProviderMapperRepresentation) bool {
+			return *body.Name == "email" && *body.IdentityProviderMapper == "oidc-user-attribute-idp-mapper"
+		})).
+		Return(&api.PostRealmIdentityProviderInstancesAliasMappersResponse{
+			HTTPResponse: ptr.To(http.Response{StatusCode: http.StatusCreated}),
+		}, nil)
+
+	err := NewRealmClient(mockedClient).
+		CreateOrUpdateRealmIdentityBroker(context.Background(), newTestRealm(Realm), newTestIdentityBroker())
+
+	assert.NoError(t, err)
+}
+
+func TestCreateOrUpdateRealmIdentityBrokerUpdatesBrokerAndMappers(t *testing.T) {
+	existingIdp := &api.IdentityProviderRepresentation{
+		Alias:      ptr.To(BrokerAlias),
+		InternalId: ptr.To("internal-id"),
+		ProviderId: ptr.To("oidc"),
+		Config:     &map[string]interface{}{"syncMode": "IMPORT"},
+	}
+
+	mockedClient := mocks.NewMockKeycloakClient(t)
+	mockedClient.EXPECT().GetRealmIdentityProviderInstancesAliasWithResponse(mock.Anything, Realm, BrokerAlias).
+		Return(&api.GetRealmIdentityProviderInstancesAliasResponse{
+			HTTPResponse: ptr.To(http.Response{StatusCode: http.StatusOK}),
+			JSON2XX:      existingIdp,
+		}, nil)
+	mockedClient.EXPECT().PutRealmIdentityProviderInstancesAliasWithResponse(mock.Anything, Realm, BrokerAlias,
+		mock.MatchedBy(func(body api.IdentityProviderRepresentation) bool {
+			config := *body.Config
+			// Unmanaged config is kept
+			return *body.InternalId == "internal-id" && *body.DisplayName == "Test Broker" &&
+				config["syncMode"] == "IMPORT" && config["tokenUrl"] == "https://upstream.example.com/token"
+		})).
+		Return(&api.PutRealmIdentityProviderInstancesAliasResponse{
+			HTTPResponse: ptr.To(http.Response{StatusCode: http.StatusNoContent}),
+		}, nil)
+	mockGetRealmIdentityProviderMappers(mockedClient,
+		api.IdentityProviderMapperRepresentation{
+			Id:                     ptr.To("email-id"),
+			Name:                   ptr.To("email"),
+			IdentityProviderMapper: ptr.To("oidc-user-attribute-idp-mapper"),
+			Config:                 &map[string]interface{}{"claim": "mail"},
+		},
+		api.IdentityProviderMapperRepresentation{
+			Id:   ptr.To("obsolete-id"),
+			Name: ptr.To("obsolete"),
+		})
+	mockedClient.EXPECT().PutRealmIdentityProviderInstancesAliasMappersIdWithResponse(mock.Anything, Realm,
+		BrokerAlias, "email-id",
+		mock.MatchedBy(func(body api.IdentityProviderMapperRepresentation) bool {
+			return (*body.Config)["claim"] == "email"
+		})).
+		Return(&api.PutRealmIdentityProviderInstancesAliasMappersIdResponse{
+			HTTPResponse: ptr.To(http.Response{StatusCode: http.StatusNoContent}),
+		}, nil)
+	mockedClient.EXPECT().DeleteRealmIdentityProviderInstancesAliasMappersIdWithResponse(mock.Anything, Realm,
+		BrokerAlias, "obsolete-id").
+		Return(&api.DeleteRealmIdentityProviderInstancesAliasMappersIdResponse{
+			HTTPResponse: ptr.To(http.Response{StatusCode: http.StatusNoContent}),
+		}, nil)
+
+	err := NewRealmClient(mockedClient).
+		CreateOrUpdateRealmIdentityBroker(context.Background(), newTestRealm(Realm), newTestIdentityBroker())
+
+	assert.NoError(t, err)
+}
+
+func TestCreateOrUpdateRealmIdentityBrokerSkipsUnchangedMapper(t *testing.T) {
+	mockedClient := mocks.NewMockKeycloakClient(t)
+	mockedClient.EXPECT().GetRealmIdentityProviderInstancesAliasWithResponse(mock.Anything, Realm, BrokerAlias).
+		Return(&api.GetRealmIdentityProviderInstancesAliasResponse{
+			HTTPResponse: ptr.To(http.Response{StatusCode: http.StatusNotFound}),
+		}, nil)
+	mockedClient.EXPECT().PostRealmIdentityProviderInstancesWithResponse(mock.Anything, Realm,
+		mock.AnythingOfType("api.IdentityProviderRepresentation")).
+		Return(&api.PostRealmIdentityProviderInstancesResponse{
+			HTTPResponse: ptr.To(http.Response{StatusCode: http.StatusCreated}),
+		}, nil)
+	mockGetRealmIdentityProviderMappers(mockedClient, api.IdentityProviderMapperRepresentation{
+		Id:                     ptr.To("email-id"),
+		Name:                   ptr.To("email"),
+		IdentityProviderMapper: ptr.To("oidc-user-attribute-idp-mapper"),
+		Config:                 &map[string]interface{}{"claim": "email", "user.attribute": "email"},
+	})
+
+	err := NewRealmClient(mockedClient).
+		CreateOrUpdateRealmIdentityBroker(context.Background(), newTestRealm(Realm), newTestIdentityBroker())
+
+	assert.NoError(t, err)
+}
+
+func TestCreateOrUpdateRealmIdentityBrokerReturnsStatusCodeError(t *testing.T) {
+	mockedClient := mocks.NewMockKeycloakClient(t)
+	mockedClient.EXPECT().GetRealmIdentityProviderInstancesAliasWithResponse(mock.Anything, Realm, BrokerAlias).
+		Return(&api.GetRealmIdentityProviderInstancesAliasResponse{
+			HTTPResponse: ptr.To(http.Response{StatusCode: http.StatusNotFound}),
+		}, nil)
+	mockedClient.EXPECT().PostRealmIdentityProviderInstancesWithResponse(mock.Anything, Realm,
+		mock.AnythingOfType("api.IdentityProviderRepresentation")).
+		Return(&api.PostRealmIdentityProviderInstancesResponse{
+			HTTPResponse: ptr.To(http.Response{StatusCode: http.StatusConflict}),
+		}, nil)
+
+	err := NewRealmClient(mockedClient).
+		CreateOrUpdateRealmIdentityBroker(context.Background(), newTestRealm(Realm), newTestIdentityBroker())
+
+	assert.Error(t, err)
+}
+
+func TestDeleteRealmIdentityBrokerIgnoresMissingBroker(t *testing.T) {
+	mockedClient := mocks.NewMockKeycloakClient(t)
+	mockedClient.EXPECT().DeleteRealmIdentityProviderInstancesAliasWithResponse(mock.Anything, Realm, BrokerAlias).
+		Return(&api.DeleteRealmIdentityProviderInstancesAliasResponse{
+			HTTPResponse: ptr.To(http.Response{StatusCode: http.StatusNotFound}),
+		}, nil)
+
+	err := NewRealmClient(mockedClient).
+		DeleteRealmIdentityBroker(context.Background(), newTestRealm(Realm), newTestIdentityBroker())
+
+	assert.NoError(t, err)
+}
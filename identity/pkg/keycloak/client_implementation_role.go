@@ -0,0 +1,451 @@
+package keycloak
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-logr/logr"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	identityv1 "github.com/telekom/controlplane-mono/identity/api/v1"
+	"github.com/telekom/controlplane-mono/identity/pkg/api"
+	"github.com/telekom/controlplane-mono/identity/pkg/keycloak/mapper"
+)
+
+// realmRoleContainer is used as container ID for realm roles, client roles use the ID of their client
+const realmRoleContainer = ""
+
+func (k *realmClient) GetRealmRole(ctx context.Context, realmName string,
+	role *identityv1.Role) (*api.RoleRepresentation, error) {
+	if k.clientWithResponses == nil {
+		return nil, fmt.Errorf("keycloak client is required")
+	}
+
+	containerId, err := k.getRoleContainerId(ctx, realmName, role.Spec.ClientId)
+	if err != nil {
+		return nil, err
+	}
+	return k.getRole(ctx, realmName, containerId, role.Spec.Name)
+}
+
+// getRoleContainerId resolves the ID of the client owning a role.
+// For realm roles the clientId is empty and realmRoleContainer is returned.
+func (k *realmClient) getRoleContainerId(ctx context.Context, realmName, clientId string) (string, error) {
+	if clientId == "" {
+		return realmRoleContainer, nil
+	}
+
+	existingClient, err := k.getRealmClientByClientId(ctx, realmName, clientId)
+	if err != nil {
+		return "", err
+	}
+	if existingClient == nil || existingClient.Id == nil {
+		return "", fmt.Errorf("❌ client %s owning the role does not exist", clientId)
+	}
+	return *existingClient.Id, nil
+}
+
+func (k *realmClient) getRole(ctx context.Context, realmName, containerId,
+	roleName string) (*api.RoleRepresentation, error) {
+	logger := log.FromContext(ctx)
+
+	logger.V(1).Info("GetRealmRole", "ℹ️ request realm", realmName)
+	logger.V(1).Info("GetRealmRole", "ℹ️ request role", roleName)
+
+	var response ApiResponse
+	var role *api.RoleRepresentation
+	var body []byte
+	var err error
+
+	start := time.Now()
+	if containerId == realmRoleContainer {
+		var get *api.GetRealmRolesRoleNameResponse
+		get, err = k.clientWithResponses.GetRealmRolesRoleNameWithResponse(ctx, realmName, roleName)
+		if get != nil {
+			response, role, body = get, get.JSON2XX, get.Body
+		}
+	} else {
+		var get *api.GetRealmClientsIdRolesRoleNameResponse
+		get, err = k.clientWithResponses.GetRealmClientsIdRolesRoleNameWithResponse(ctx, realmName, containerId, roleName)
+		if get != nil {
+			response, role, body = get, get.JSON2XX, get.Body
+		}
+	}
+	IncreaseDurationMetrics(start, "GET", "GetRealmRole")
+	if err != nil {
+		IncreaseErrorMetrics()
+		return nil, err
+	}
+
+	if responseErr := CheckStatusCode(response, http.StatusOK, http.StatusNotFound); responseErr != nil {
+		IncreaseErrorMetrics()
+		return nil, fmt.Errorf("❌ failed to get role: %d -- Response for GET is: %s",
+			response.StatusCode(), string(body))
+	}
+
+	IncreaseStatusMetrics(strconv.Itoa(response.StatusCode()), "GET", "GetRealmRole")
+	if response.StatusCode() == http.StatusNotFound {
+		return nil, nil
+	}
+	logger.V(1).Info("GetRealmRole", "ℹ️ response", role)
+	return role, nil
+}
+
+func CheckForRoleChanges(role *identityv1.Role,
+	existingRole *api.RoleRepresentation,
+	logger logr.Logger) *api.RoleRepresentation {
+
+	roleRepresentation := mapper.MapToRoleRepresentation(role)
+	if mapper.CompareRoleRepresentation(existingRole, &roleRepresentation) {
+		var message = fmt.Sprintf("ℹ️ No changes detected for role %s", role.Spec.Name)
+		logger.V(1).Info(message)
+	} else {
+		var message = fmt.Sprintf("🧹 Changes found for role %s in keycloak", role.Spec.Name)
+		logger.V(1).Info(message)
+	}
+	// Merge existing role with new role and update it in keycloak
+	return mapper.MergeRoleRepresentation(existingRole, &roleRepresentation)
+}
+
+func (k *realmClient) putRole(ctx context.Context, realmName, containerId, roleName string,
+	body api.RoleRepresentation) error {
+	logger := log.FromContext(ctx)
+
+	logger.V(1).Info("PutRealmRole", "ℹ️ request realm", realmName)
+	logger.V(1).Info("PutRealmRole", "ℹ️ request body", body)
+
+	var response ApiResponse
+	var responseBody []byte
+	var err error
+
+	start := time.Now()
+	if containerId == realmRoleContainer {
+		var put *api.PutRealmRolesRoleNameResponse
+		put, err = k.clientWithResponses.PutRealmRolesRoleNameWithResponse(ctx, realmName, roleName, body)
+		if put != nil {
+			response, responseBody = put, put.Body
+		}
+	} else {
+		var put *api.PutRealmClientsIdRolesRoleNameResponse
+		put, err = k.clientWithResponses.PutRealmClientsIdRolesRoleNameWithResponse(ctx, realmName,
+			containerId, roleName, body)
+		if put != nil {
+			response, responseBody = put, put.Body
+		}
+	}
+	IncreaseDurationMetrics(start, "PUT", "PutRealmRole")
+	if err != nil {
+		IncreaseErrorMetrics()
+		return err
+	}
+
+	if responseErr := CheckStatusCode(response, http.StatusNoContent); responseErr != nil {
+		IncreaseErrorMetrics()
+		return fmt.Errorf("❌ failed to update role: %d -- Response for PUT is: %s",
+			response.StatusCode(), string(responseBody))
+	}
+
+	IncreaseStatusMetrics(strconv.Itoa(response.StatusCode()), "PUT", "PutRealmRole")
+	return nil
+}
+
+func (k *realmClient) postRole(ctx context.Context, realmName, containerId string,
+	body api.RoleRepresentation) error {
+	logger := log.FromContext(ctx)
+
+	logger.V(1).Info("PostRealmRole", "ℹ️ request realm", realmName)
+	logger.V(1).Info("PostRealmRole", "ℹ️ request body", body)
+
+	var response ApiResponse
+	var responseBody []byte
+	var err error
+
+	start := time.Now()
+	if containerId == realmRoleContainer {
+		var post *api.PostRealmRolesResponse
+		post, err = k.clientWithResponses.PostRealmRolesWithResponse(ctx, realmName, body)
+		if post != nil {
+			response, responseBody = post, post.Body
+		}
+	} else {
+		var post *api.PostRealmClientsIdRolesResponse
+		post, err = k.clientWithResponses.PostRealmClientsIdRolesWithResponse(ctx, realmName, containerId, body)
+		if post != nil {
+			response, responseBody = post, post.Body
+		}
+	}
+	IncreaseDurationMetrics(start, "POST", "PostRealmRole")
+	if err != nil {
+		IncreaseErrorMetrics()
+		return err
+	}
+
+	if responseErr := CheckStatusCode(response, http.StatusCreated); responseErr != nil {
+		IncreaseErrorMetrics()
+		return fmt.Errorf("❌ failed to create role: %d -- Response for POST is: %s",
+			response.StatusCode(), string(responseBody))
+	}
+
+	IncreaseStatusMetrics(strconv.Itoa(response.StatusCode()), "POST", "PostRealmRole")
+	return nil
+}
+
+func (k *realmClient) CreateOrUpdateRealmRole(ctx context.Context, realm *identityv1.Realm,
+	role *identityv1.Role) error {
+	logger := log.FromContext(ctx)
+	if k.clientWithResponses == nil {
+		return fmt.Errorf("keycloak client is required")
+	}
+
+	containerId, err := k.getRoleContainerId(ctx, realm.Name, role.Spec.ClientId)
+	if err != nil {
+		return err
+	}
+	existingRole, err := k.getRole(ctx, realm.Name, containerId, role.Spec.Name)
+	if err != nil {
+		return err
+	}
+
+	if existingRole != nil {
+		var message = fmt.Sprintf("🔍 found existing role %s in keycloak", role.Spec.Name)
+		logger.V(1).Info(message)
+		body := CheckForRoleChanges(role, existingRole, logger)
+		if err := k.putRole(ctx, realm.Name, containerId, role.Spec.Name, *body); err != nil {
+			return err
+		}
+		var successMessage = fmt.Sprintf("✅ updated existing role %s in realm %s", role.Spec.Name, realm.Name)
+		logger.V(1).Info(successMessage)
+	} else {
+		var message = fmt.Sprintf("role %s not found in keycloak", role.Spec.Name)
+		logger.V(1).Info(message)
+		if err := k.postRole(ctx, realm.Name, containerId, mapper.MapToRoleRepresentation(role)); err != nil {
+			return err
+		}
+		var successMessage = fmt.Sprintf("✅ created role %s in realm %s", role.Spec.Name, realm.Name)
+		logger.V(1).Info(successMessage)
+	}
+
+	return nil
+}
+
+func (k *realmClient) DeleteRealmRole(ctx context.Context, realm *identityv1.Realm, role *identityv1.Role) error {
+	logger := log.FromContext(ctx)
+	if k.clientWithResponses == nil {
+		return fmt.Errorf("keycloak client is required")
+	}
+
+	containerId, err := k.getRoleContainerId(ctx, realm.Name, role.Spec.ClientId)
+	if err != nil {
+		return err
+	}
+
+	var response ApiResponse
+	var responseBody []byte
+
+	start := time.Now()
+	if containerId == realmRoleContainer {
+		var del *api.DeleteRealmRolesRoleNameResponse
+		del, err = k.clientWithResponses.DeleteRealmRolesRoleNameWithResponse(ctx, realm.Name, role.Spec.Name)
+		if del != nil {
+			response, responseBody = del, del.Body
+		}
+	} else {
+		var del *api.DeleteRealmClientsIdRolesRoleNameResponse
+		del, err = k.clientWithResponses.DeleteRealmClientsIdRolesRoleNameWithResponse(ctx, realm.Name,
+			containerId, role.Spec.Name)
+		if del != nil {
+			response, responseBody = del, del.Body
+		}
+	}
+	IncreaseDurationMetrics(start, "DELETE", "DeleteRealmRole")
+	if err != nil {
+		IncreaseErrorMetrics()
+		return err
+	}
+
+	if responseErr := CheckStatusCode(response, http.StatusNoContent, http.StatusNotFound); responseErr != nil {
+		IncreaseErrorMetrics()
+		return fmt.Errorf("❌ failed to delete role: %d -- Response for DELETE is: %s",
+			response.StatusCode(), string(responseBody))
+	}
+
+	IncreaseStatusMetrics(strconv.Itoa(response.StatusCode()), "DELETE", "DeleteRealmRole")
+	var successMessage = fmt.Sprintf("✅ deleted role %s in realm %s", role.Spec.Name, realm.Name)
+	logger.V(1).Info(successMessage)
+	return nil
+}
+
+func (k *realmClient) getServiceAccountUserId(ctx context.Context, realmName, id string) (string, error) {
+	start := time.Now()
+	get, err := k.clientWithResponses.GetRealmClientsIdServiceAccountUserWithResponse(ctx, realmName, id)
+	IncreaseDurationMetrics(start, "GET", "GetRealmClientsServiceAccountUser")
+	if err != nil {
+		IncreaseErrorMetrics()
+		return "", err
+	}
+
+	if responseErr := CheckStatusCode(get, http.StatusOK); responseErr != nil {
+		IncreaseErrorMetrics()
+		return "", fmt.Errorf("❌ failed to get service-account user: %d -- Response for GET is: %s",
+			get.StatusCode(), string(get.Body))
+	}
+
+	IncreaseStatusMetrics(strconv.Itoa(get.StatusCode()), "GET", "GetRealmClientsServiceAccountUser")
+	if get.JSON2XX == nil || get.JSON2XX.Id == nil {
+		return "", fmt.Errorf("❌ service-account user of client with ID %s has no ID", id)
+	}
+	return *get.JSON2XX.Id, nil
+}
+
+func (k *realmClient) getUserRoleMappings(ctx context.Context, realmName, userId,
+	containerId string) (*[]api.RoleRepresentation, error) {
+	var response ApiResponse
+	var roles *[]api.RoleRepresentation
+	var body []byte
+	var err error
+
+	start := time.Now()
+	if containerId == realmRoleContainer {
+		var get *api.GetRealmUsersIdRoleMappingsRealmResponse
+		get, err = k.clientWithResponses.GetRealmUsersIdRoleMappingsRealmWithResponse(ctx, realmName, userId)
+		if get != nil {
+			response, roles, body = get, get.JSON2XX, get.Body
+		}
+	} else {
+		var get *api.GetRealmUsersIdRoleMappingsClientsClientResponse
+		get, err = k.clientWithResponses.GetRealmUsersIdRoleMappingsClientsClientWithResponse(ctx, realmName,
+			userId, containerId)
+		if get != nil {
+			response, roles, body = get, get.JSON2XX, get.Body
+		}
+	}
+	IncreaseDurationMetrics(start, "GET", "GetRealmUsersRoleMappings")
+	if err != nil {
+		IncreaseErrorMetrics()
+		return nil, err
+	}
+
+	if responseErr := CheckStatusCode(response, http.StatusOK); responseErr != nil {
+		IncreaseErrorMetrics()
+		return nil, fmt.Errorf("❌ failed to get role mappings: %d -- Response for GET is: %s",
+			response.StatusCode(), string(body))
+	}
+
+	IncreaseStatusMetrics(strconv.Itoa(response.StatusCode()), "GET", "GetRealmUsersRoleMappings")
+	return roles, nil
+}
+
+func (k *realmClient) postUserRoleMappings(ctx context.Context, realmName, userId, containerId string,
+	roles []api.RoleRepresentation) error {
+	var response ApiResponse
+	var body []byte
+	var err error
+
+	start := time.Now()
+	if containerId == realmRoleContainer {
+		var post *api.PostRealmUsersIdRoleMappingsRealmResponse
+		post, err = k.clientWithResponses.PostRealmUsersIdRoleMappingsRealmWithResponse(ctx, realmName, userId, roles)
+		if post != nil {
+			response, body = post, post.Body
+		}
+	} else {
+		var post *api.PostRealmUsersIdRoleMappingsClientsClientResponse
+		post, err = k.clientWithResponses.PostRealmUsersIdRoleMappingsClientsClientWithResponse(ctx, realmName,
+			userId, containerId, roles)
+		if post != nil {
+			response, body = post, post.Body
+		}
+	}
+	IncreaseDurationMetrics(start, "POST", "PostRealmUsersRoleMappings")
+	if err != nil {
+		IncreaseErrorMetrics()
+		return err
+	}
+
+	if responseErr := CheckStatusCode(response, http.StatusNoContent); responseErr != nil {
+		IncreaseErrorMetrics()
+		return fmt.Errorf("❌ failed to assign roles: %d -- Response for POST is: %s",
+			response.StatusCode(), string(body))
+	}
+
+	IncreaseStatusMetrics(strconv.Itoa(response.StatusCode()), "POST", "PostRealmUsersRoleMappings")
+	return nil
+}
+
+// AssignServiceAccountRoles makes sure that all roles of the client spec are assigned to
+// the service-account user of the client. Like the protocol mappers, roles are only merged,
+// roles that were assigned outside of the operator are kept.
+func (k *realmClient) AssignServiceAccountRoles(ctx context.Context, realm *identityv1.Realm,
+	client *identityv1.Client) error {
+	logger := log.FromContext(ctx)
+	if k.clientWithResponses == nil {
+		return fmt.Errorf("keycloak client is required")
+	}
+	if len(client.Spec.Roles) == 0 {
+		return nil
+	}
+
+	existingClient, err := k.getRealmClient(ctx, realm.Name, client)
+	if err != nil {
+		return err
+	}
+	if existingClient == nil || existingClient.Id == nil {
+		return fmt.Errorf("❌ client %s does not exist", client.Spec.ClientId)
+	}
+	userId, err := k.getServiceAccountUserId(ctx, realm.Name, *existingClient.Id)
+	if err != nil {
+		return err
+	}
+
+	// Group the requested roles by the client owning them while keeping the order of the spec
+	clientIds := make([]string, 0)
+	rolesByClientId := map[string][]string{}
+	for _, assignment := range client.Spec.Roles {
+		if _, found := rolesByClientId[assignment.ClientId]; !found {
+			clientIds = append(clientIds, assignment.ClientId)
+		}
+		rolesByClientId[assignment.ClientId] = append(rolesByClientId[assignment.ClientId], assignment.Name)
+	}
+
+	for _, clientId := range clientIds {
+		containerId, err := k.getRoleContainerId(ctx, realm.Name, clientId)
+		if err != nil {
+			return err
+		}
+
+		requestedRoles := make([]api.RoleRepresentation, 0, len(rolesByClientId[clientId]))
+		for _, roleName := range rolesByClientId[clientId] {
+			role, err := k.getRole(ctx, realm.Name, containerId, roleName)
+			if err != nil {
+				return err
+			}
+			if role == nil {
+				return fmt.Errorf("❌ role %s does not exist", roleName)
+			}
+			requestedRoles = append(requestedRoles, *role)
+		}
+
+		existingRoles, err := k.getUserRoleMappings(ctx, realm.Name, userId, containerId)
+		if err != nil {
+			return err
+		}
+
+		missingRoles := mapper.FindMissingRoles(existingRoles, &requestedRoles)
+		if len(missingRoles) == 0 {
+			var message = fmt.Sprintf("ℹ️ No missing roles for client %s", client.Spec.ClientId)
+			logger.V(1).Info(message, "roleClientId", clientId)
+			continue
+		}
+
+		if err := k.postUserRoleMappings(ctx, realm.Name, userId, containerId, missingRoles); err != nil {
+			return err
+		}
+		var successMessage = fmt.Sprintf("✅ assigned %d roles to client %s", len(missingRoles), client.Spec.ClientId)
+		logger.V(1).Info(successMessage, "roleClientId", clientId)
+	}
+
+	return nil
+}
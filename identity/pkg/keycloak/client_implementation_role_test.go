@@ -0,0 +1,277 @@
+package keycloak
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"k8s.io/utils/ptr"
+
+	identityv1 "github.com/telekom/controlplane-mono/identity/api/v1"
+	"github.com/telekom/controlplane-mono/identity/pkg/api"
+	"github.com/telekom/controlplane-mono/identity/test/mocks"
+)
+
+const (
+	RoleName             = "test-role"
+	ClientRoleName       = "test-client-role"
+	RoleOwnerClientId    = "role-owner"
+	RoleOwnerId          = "role-owner-id"
+	ServiceAccountClient = "service-account-client-id"
+	ServiceAccountUserId = "service-account-user-id"
+)
+
+func newTestRole(clientId string) *identityv1.Role {
+	return &identityv1.Role{
+		Spec: identityv1.RoleSpec{
+			Name:        RoleName,
+			ClientId:    clientId,
+			Description: "test-description",
+		},
+	}
+}
+
+func mockGetRealmRolesRoleNameResponse(statusCode int, role *api.RoleRepresentation) *api.GetRealmRolesRoleNameResponse {
+	return &api.GetRealmRolesRoleNameResponse{
+		HTTPResponse: ptr.To(http.Response{StatusCode: statusCode}),
+		JSON2XX:      role,
+	}
+}
+
+func mockGetRealmClientsByClientId(mockedClient *mocks.MockKeycloakClient, clientId, id string) {
+	mockedClient.EXPECT().GetRealmClientsWithResponse(mock.Anything, Realm,
+		mock.MatchedBy(func(params *api.GetRealmClientsParams) bool {
+			return *params.ClientId == clientId
+		})).
+		Return(&api.GetRealmClientsResponse{
+			HTTPResponse: ptr.To(http.Response{StatusCode: http.StatusOK}),
+			JSON2XX: &[]api.ClientRepresentation{{
+				Id:       ptr.To(id),
+				ClientId: ptr.To(clientId),
+				Secret:   ptr.To(""),
+			}},
+		}, nil)
+}
+
+func TestGetRealmRoleReturnsClientError(t *testing.T) {
+	realmClient := NewRealmClient(nil)
+	result, err := realmClient.GetRealmRole(context.Background(), Realm, newTestRole(""))
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+}
+
+func TestGetRealmRoleReturnsNilForMissingRole(t *testing.T) {
+	mockedClient := mocks.NewMockKeycloakClient(t)
+	mockedClient.EXPECT().GetRealmRolesRoleNameWithResponse(mock.Anything, Realm, RoleName).
+		Return(mockGetRealmRolesRoleNameResponse(http.StatusNotFound, nil), nil)
+
+	result, err := NewRealmClient(mockedClient).GetRealmRole(context.Background(), Realm, newTestRole(""))
+
+	assert.NoError(t, err)
+	assert.Nil(t, result)
+}
+
+func TestGetRealmRoleReturnsError(t *testing.T) {
+	mockedClient := mocks.NewMockKeycloakClient(t)
+	mockedClient.EXPECT().GetRealmRolesRoleNameWithResponse(mock.Anything, Realm, RoleName).
+		Return(nil, fmt.Errorf("error getting role"))
+
+	result, err := NewRealmClient(mockedClient).GetRealmRole(context.Background(), Realm, newTestRole(""))
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+}
+
+func TestCreateOrUpdateRealmRoleCreatesRealmRole(t *testing.T) {
+	mockedClient := mocks.NewMockKeycloakClient(t)
+	mockedClient.EXPECT().GetRealmRolesRoleNameWithResponse(mock.Anything, Realm, RoleName).
+		Return(mockGetRealmRolesRoleNameResponse(http.StatusNotFound, nil), nil)
+	mockedClient.EXPECT().PostRealmRolesWithResponse(mock.Anything, Realm,
+		mock.MatchedBy(func(body api.RoleRepresentation) bool {
+			return *body.Name == RoleName && !*body.ClientRole
+		})).
+		Return(&api.PostRealmRolesResponse{
+			HTTPResponse: ptr.To(http.Response{StatusCode: http.StatusCreated}),
+		}, nil)
+
+	err := NewRealmClient(mockedClient).
+		CreateOrUpdateRealmRole(context.Background(), newTestRealm(Realm), newTestRole(""))
+
+	assert.NoError(t, err)
+}
+
+func TestCreateOrUpdateRealmRoleUpdatesRealmRole(t *testing.T) {
+	existingRole := &api.RoleRepresentation{
+		Id:          ptr.To("role-id"),
+		Name:        ptr.To(RoleName),
+		Description: ptr.To("outdated"),
+	}
+
+	mockedClient := mocks.NewMockKeycloakClient(t)
+	mockedClient.EXPECT().GetRealmRolesRoleNameWithResponse(mock.Anything, Realm, RoleName).
+		Return(mockGetRealmRolesRoleNameResponse(http.StatusOK, existingRole), nil)
+	mockedClient.EXPECT().PutRealmRolesRoleNameWithResponse(mock.Anything, Realm, RoleName,
+		mock.MatchedBy(func(body api.RoleRepresentation) bool {
+			return *body.Id == "role-id" && *body.Description == "test-description"
+		})).
+		Return(&api.PutRealmRolesRoleNameResponse{
+			HTTPResponse: ptr.To(http.Response{StatusCode: http.StatusNoContent}),
+		}, nil)
+
+	err := NewRealmClient(mockedClient).
+		CreateOrUpdateRealmRole(context.Background(), newTestRealm(Realm), newTestRole(""))
+
+	assert.NoError(t, err)
+}
+
+func TestCreateOrUpdateRealmRoleCreatesClientRole(t *testing.T) {
+	mockedClient := mocks.NewMockKeycloakClient(t)
+	mockGetRealmClientsByClientId(mockedClient, RoleOwnerClientId, RoleOwnerId)
+	mockedClient.EXPECT().GetRealmClientsIdRolesRoleNameWithResponse(mock.Anything, Realm, RoleOwnerId, RoleName).
+		Return(&api.GetRealmClientsIdRolesRoleNameResponse{
+			HTTPResponse: ptr.To(http.Response{StatusCode: http.StatusNotFound}),
+		}, nil)
+	mockedClient.EXPECT().PostRealmClientsIdRolesWithResponse(mock.Anything, Realm, RoleOwnerId,
+		mock.MatchedBy(func(body api.RoleRepresentation) bool {
+			return *body.Name == RoleName && *body.ClientRole
+		})).
+		Return(&api.PostRealmClientsIdRolesResponse{
+			HTTPResponse: ptr.To(http.Response{StatusCode: http.StatusCreated}),
+		}, nil)
+
+	err := NewRealmClient(mockedClient).
+		CreateOrUpdateRealmRole(context.Background(), newTestRealm(Realm), newTestRole(RoleOwnerClientId))
+
+	assert.NoError(t, err)
+}
+
+func TestCreateOrUpdateRealmRoleReturnsErrorForMissingClient(t *testing.T) {
+	mockedClient := mocks.NewMockKeycloakClient(t)
+	mockedClient.EXPECT().GetRealmClientsWithResponse(mock.Anything, Realm,
+		mock.AnythingOfType("*api.GetRealmClientsParams")).
+		Return(&api.GetRealmClientsResponse{
+			HTTPResponse: ptr.To(http.Response{StatusCode: http.StatusOK}),
+			JSON2XX:      &[]api.ClientRepresentation{},
+		}, nil)
+
+	err := NewRealmClient(mockedClient).
+		CreateOrUpdateRealmRole(context.Background(), newTestRealm(Realm), newTestRole(RoleOwnerClientId))
+
+	assert.Error(t, err)
+}
+
+func TestDeleteRealmRoleDeletesClientRole(t *testing.T) {
+	mockedClient := mocks.NewMockKeycloakClient(t)
+	mockGetRealmClientsByClientId(mockedClient, RoleOwnerClientId, RoleOwnerId)
+	mockedClient.EXPECT().DeleteRealmClientsIdRolesRoleNameWithResponse(mock.Anything, Realm, RoleOwnerId, RoleName).
+		Return(&api.DeleteRealmClientsIdRolesRoleNameResponse{
+			HTTPResponse: ptr.To(http.Response{StatusCode: http.StatusNoContent}),
+		}, nil)
+
+	err := NewRealmClient(mockedClient).
+		DeleteRealmRole(context.Background(), newTestRealm(Realm), newTestRole(RoleOwnerClientId))
+
+	assert.NoError(t, err)
+}
+
+func TestAssignServiceAccountRolesSkipsClientWithoutRoles(t *testing.T) {
+	mockedClient := mocks.NewMockKeycloakClient(t)
+
+	err := NewRealmClient(mockedClient).
+		AssignServiceAccountRoles(context.Background(), newTestRealm(Realm), &identityv1.Client{})
+
+	assert.NoError(t, err)
+}
+
+func TestAssignServiceAccountRolesAssignsOnlyMissingRoles(t *testing.T) {
+	client := &identityv1.Client{
+		Spec: identityv1.ClientSpec{
+			ClientId: ClientId,
+			Roles: []identityv1.RoleAssignment{
+				{Name: RoleName},
+				{Name: "already-assigned"},
+				{Name: ClientRoleName, ClientId: RoleOwnerClientId},
+			},
+		},
+	}
+
+	mockedClient := mocks.NewMockKeycloakClient(t)
+	mockGetRealmClientsByClientId(mockedClient, ClientId, ServiceAccountClient)
+	mockGetRealmClientsByClientId(mockedClient, RoleOwnerClientId, RoleOwnerId)
+	mockedClient.EXPECT().GetRealmClientsIdServiceAccountUserWithResponse(mock.Anything, Realm, ServiceAccountClient).
+		Return(&api.GetRealmClientsIdServiceAccountUserResponse{
+			HTTPResponse: ptr.To(http.Response{StatusCode: http.StatusOK}),
+			JSON2XX:      &api.UserRepresentation{Id: ptr.To(ServiceAccountUserId)},
+		}, nil)
+
+	// Realm roles
+	mockedClient.EXPECT().GetRealmRolesRoleNameWithResponse(mock.Anything, Realm, RoleName).
+		Return(mockGetRealmRolesRoleNameResponse(http.StatusOK, &api.RoleRepresentation{Name: ptr.To(RoleName)}), nil)
+	mockedClient.EXPECT().GetRealmRolesRoleNameWithResponse(mock.Anything, Realm, "already-assigned").
+		Return(mockGetRealmRolesRoleNameResponse(http.StatusOK,
+			&api.RoleRepresentation{Name: ptr.To("already-assigned")}), nil)
+	mockedClient.EXPECT().GetRealmUsersIdRoleMappingsRealmWithResponse(mock.Anything, Realm, ServiceAccountUserId).
+		Return(&api.GetRealmUsersIdRoleMappingsRealmResponse{
+			HTTPResponse: ptr.To(http.Response{StatusCode: http.StatusOK}),
+			JSON2XX:      &[]api.RoleRepresentation{{Name: ptr.To("already-assigned")}},
+		}, nil)
+	mockedClient.EXPECT().PostRealmUsersIdRoleMappingsRealmWithResponse(mock.Anything, Realm, ServiceAccountUserId,
+		mock.MatchedBy(func(roles []api.RoleRepresentation) bool {
+			return len(roles) == 1 && *roles[0].Name == RoleName
+		})).
+		Return(&api.PostRealmUsersIdRoleMappingsRealmResponse{
+			HTTPResponse: ptr.To(http.Response{StatusCode: http.StatusNoContent}),
+		}, nil)
+
+	// Client roles
+	mockedClient.EXPECT().GetRealmClientsIdRolesRoleNameWithResponse(mock.Anything, Realm, RoleOwnerId, ClientRoleName).
+		Return(&api.GetRealmClientsIdRolesRoleNameResponse{
+			HTTPResponse: ptr.To(http.Response{StatusCode: http.StatusOK}),
+			JSON2XX:      &api.RoleRepresentation{Name: ptr.To(ClientRoleName)},
+		}, nil)
+	mockedClient.EXPECT().GetRealmUsersIdRoleMappingsClientsClientWithResponse(mock.Anything, Realm,
+		ServiceAccountUserId, RoleOwnerId).
+		Return(&api.GetRealmUsersIdRoleMappingsClientsClientResponse{
+			HTTPResponse: ptr.To(http.Response{StatusCode: http.StatusOK}),
+			JSON2XX:      &[]api.RoleRepresentation{},
+		}, nil)
+	mockedClient.EXPECT().PostRealmUsersIdRoleMappingsClientsClientWithResponse(mock.Anything, Realm,
+		ServiceAccountUserId, RoleOwnerId,
+		mock.MatchedBy(func(roles []api.RoleRepresentation) bool {
+			return len(roles) == 1 && *roles[0].Name == ClientRoleName
+		})).
+		Return(&api.PostRealmUsersIdRoleMappingsClientsClientResponse{
+			HTTPResponse: ptr.To(http.Response{StatusCode: http.StatusNoContent}),
+		}, nil)
+
+	err := NewRealmClient(mockedClient).AssignServiceAccountRoles(context.Background(), newTestRealm(Realm), client)
+
+	assert.NoError(t, err)
+}
+
+func TestAssignServiceAccountRolesReturnsErrorForMissingRole(t *testing.T) {
+	client := &identityv1.Client{
+		Spec: identityv1.ClientSpec{
+			ClientId: ClientId,
+			Roles:    []identityv1.RoleAssignment{{Name: RoleName}},
+		},
+	}
+
+	mockedClient := mocks.NewMockKeycloakClient(t)
+	mockGetRealmClientsByClientId(mockedClient, ClientId, ServiceAccountClient)
+	mockedClient.EXPECT().GetRealmClientsIdServiceAccountUserWithResponse(mock.Anything, Realm, ServiceAccountClient).
+		Return(&api.GetRealmClientsIdServiceAccountUserResponse{
+			HTTPResponse: ptr.To(http.Response{StatusCode: http.StatusOK}),
+			JSON2XX:      &api.UserRepresentation{Id: ptr.To(ServiceAccountUserId)},
+		}, nil)
+	mockedClient.EXPECT().GetRealmRolesRoleNameWithResponse(mock.Anything, Realm, RoleName).
+		Return(mockGetRealmRolesRoleNameResponse(http.StatusNotFound, nil), nil)
+
+	err := NewRealmClient(mockedClient).AssignServiceAccountRoles(context.Background(), newTestRealm(Realm), client)
+
+	assert.Error(t, err)
+}
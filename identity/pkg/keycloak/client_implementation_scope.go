@@ -0,0 +1,213 @@
+package keycloak
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-logr/logr"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	identityv1 "github.com/telekom/controlplane-mono/identity/api/v1"
+	"github.com/telekom/controlplane-mono/identity/pkg/api"
+	"github.com/telekom/controlplane-mono/identity/pkg/keycloak/mapper"
+)
+
+func (k *realmClient) GetRealmClientScopes(ctx context.Context,
+	realm string) (*api.GetRealmClientScopesResponse, error) {
+	logger := log.FromContext(ctx)
+	if k.clientWithResponses == nil {
+		return nil, fmt.Errorf("keycloak client is required")
+	}
+
+	logger.V(1).Info("GetRealmClientScopes", "ℹ️ request realm", realm)
+	start := time.Now()
+	get, err := k.clientWithResponses.GetRealmClientScopesWithResponse(ctx, realm)
+	IncreaseDurationMetrics(start, "GET", "GetRealmClientScopes")
+	if err != nil {
+		IncreaseErrorMetrics()
+		return nil, err
+	}
+
+	if responseErr := CheckStatusCode(get, http.StatusOK); responseErr != nil {
+		IncreaseErrorMetrics()
+		return nil, fmt.Errorf("❌ failed to list client scopes: %d -- Response for GET is: %s",
+			get.StatusCode(), string(get.Body))
+	}
+
+	IncreaseStatusMetrics(strconv.Itoa(get.StatusCode()), "GET", "GetRealmClientScopes")
+	logger.V(1).Info("GetRealmClientScopes", "ℹ️ response", get.JSON2XX)
+	return get, nil
+}
+
+func (k *realmClient) getRealmClientScope(ctx context.Context, realmName string,
+	clientScope *identityv1.ClientScope) (*api.ClientScopeRepresentation, error) {
+	getRealmClientScopes, err := k.GetRealmClientScopes(ctx, realmName)
+	if err != nil {
+		return nil, err
+	}
+	return mapper.GetClientScope(*getRealmClientScopes, clientScope.Spec.Name), nil
+}
+
+func CheckForClientScopeChanges(clientScope *identityv1.ClientScope,
+	existingClientScope *api.ClientScopeRepresentation,
+	logger logr.Logger) *api.ClientScopeRepresentation {
+
+	clientScopeRepresentation := mapper.MapToClientScopeRepresentation(clientScope)
+	if mapper.CompareClientScopeRepresentation(existingClientScope, &clientScopeRepresentation) {
+		var message = fmt.Sprintf("ℹ️ No changes detected for client scope %s", clientScope.Spec.Name)
+		logger.V(1).Info(message)
+	} else {
+		var message = fmt.Sprintf("🧹 Changes found for client scope %s in keycloak", clientScope.Spec.Name)
+		logger.V(1).Info(message)
+	}
+	// Merge existing client scope with new client scope and update it in keycloak
+	return mapper.MergeClientScopeRepresentation(existingClientScope, &clientScopeRepresentation)
+}
+
+func (k *realmClient) PutRealmClientScope(ctx context.Context, realmName, id string,
+	clientScope *identityv1.ClientScope) (*api.PutRealmClientScopesIdResponse, error) {
+	logger := log.FromContext(ctx)
+	if k.clientWithResponses == nil {
+		return nil, fmt.Errorf("keycloak client is required")
+	}
+
+	// Get existing client scope
+	existingClientScope, err := k.getRealmClientScope(ctx, realmName, clientScope)
+	if err != nil {
+		return nil, err
+	}
+	if existingClientScope == nil {
+		var message = fmt.Sprintf("🔍 ClientScope with ID %s not found", id)
+		logger.V(1).Info(message)
+		return nil, fmt.Errorf("client scope to update does not exist")
+	}
+
+	// Check if there are any changes to the client scope
+	body := CheckForClientScopeChanges(clientScope, existingClientScope, logger)
+	logger.V(1).Info("PutRealmClientScope", "ℹ️ request realm", realmName)
+	logger.V(1).Info("PutRealmClientScope", "ℹ️ request ID", id)
+	logger.V(1).Info("PutRealmClientScope", "ℹ️ request body", body)
+
+	start := time.Now()
+	put, err := k.clientWithResponses.PutRealmClientScopesIdWithResponse(ctx, realmName, id, *body)
+	IncreaseDurationMetrics(start, "PUT", "PutRealmClientScopes")
+	if err != nil {
+		IncreaseErrorMetrics()
+		return nil, err
+	}
+
+	if responseErr := CheckStatusCode(put, http.StatusNoContent); responseErr != nil {
+		IncreaseErrorMetrics()
+		return nil, fmt.Errorf("❌ failed to update client scope: %d -- Response for PUT is: %s",
+			put.StatusCode(), string(put.Body))
+	}
+
+	IncreaseStatusMetrics(strconv.Itoa(put.StatusCode()), "PUT", "PutRealmClientScopes")
+	logger.V(1).Info("PutRealmClientScope", "response", put.HTTPResponse.Body)
+	return put, nil
+}
+
+func (k *realmClient) PostRealmClientScope(ctx context.Context, realmName string,
+	clientScope *identityv1.ClientScope) (*api.PostRealmClientScopesResponse, error) {
+	logger := log.FromContext(ctx)
+	if k.clientWithResponses == nil {
+		return nil, fmt.Errorf("keycloak client is required")
+	}
+
+	body := mapper.MapToClientScopeRepresentation(clientScope)
+
+	logger.V(1).Info("PostRealmClientScope", "ℹ️ request realm", realmName)
+	logger.V(1).Info("PostRealmClientScope", "ℹ️ request body", body)
+
+	start := time.Now()
+	post, err := k.clientWithResponses.PostRealmClientScopesWithResponse(ctx, realmName, body)
+	IncreaseDurationMetrics(start, "POST", "PostRealmClientScopes")
+	if err != nil {
+		IncreaseErrorMetrics()
+		return nil, err
+	}
+
+	if responseErr := CheckStatusCode(post, http.StatusCreated); responseErr != nil {
+		IncreaseErrorMetrics()
+		return nil, fmt.Errorf("❌ failed to create client scope: %d -- Response for POST is: %s",
+			post.StatusCode(), string(post.Body))
+	}
+
+	IncreaseStatusMetrics(strconv.Itoa(post.StatusCode()), "POST", "PostRealmClientScopes")
+	logger.V(1).Info("PostRealmClientScope", "response", post.HTTPResponse.Body)
+	return post, nil
+}
+
+func (k *realmClient) CreateOrUpdateRealmClientScope(ctx context.Context, realm *identityv1.Realm,
+	clientScope *identityv1.ClientScope) error {
+	logger := log.FromContext(ctx)
+
+	existingClientScope, err := k.getRealmClientScope(ctx, realm.Name, clientScope)
+	if err != nil {
+		return err
+	}
+
+	if existingClientScope != nil && existingClientScope.Id != nil && *existingClientScope.Id != "" {
+		var message = fmt.Sprintf("🔍 found existing client scope %s in keycloak with ID %s",
+			clientScope.Spec.Name, *existingClientScope.Id)
+		logger.V(1).Info(message)
+		putRealmClientScope, err := k.PutRealmClientScope(ctx, realm.Name, *existingClientScope.Id, clientScope)
+		if err != nil {
+			return err
+		}
+		var successMessage = fmt.Sprintf("✅ updated existing client scope %s in realm %s",
+			clientScope.Spec.Name, realm.Name)
+		logger.V(1).Info(successMessage, "clientScope", putRealmClientScope.Body)
+	} else {
+		var message = fmt.Sprintf("client scope %s not found in keycloak", clientScope.Spec.Name)
+		logger.V(1).Info(message)
+		postRealmClientScope, err := k.PostRealmClientScope(ctx, realm.Name, clientScope)
+		if err != nil {
+			return err
+		}
+		var successMessage = fmt.Sprintf("✅ created client scope %s in realm %s", clientScope.Spec.Name, realm.Name)
+		logger.V(1).Info(successMessage, "clientScope", postRealmClientScope.Body)
+	}
+
+	return nil
+}
+
+func (k *realmClient) DeleteRealmClientScope(ctx context.Context, realm *identityv1.Realm,
+	clientScope *identityv1.ClientScope) error {
+	logger := log.FromContext(ctx)
+	if k.clientWithResponses == nil {
+		return fmt.Errorf("keycloak client is required")
+	}
+
+	existingClientScope, err := k.getRealmClientScope(ctx, realm.Name, clientScope)
+	if err != nil {
+		return err
+	}
+	if existingClientScope == nil || existingClientScope.Id == nil {
+		var message = fmt.Sprintf("client scope %s not found in keycloak, nothing to delete", clientScope.Spec.Name)
+		logger.V(1).Info(message)
+		return nil
+	}
+
+	start := time.Now()
+	del, err := k.clientWithResponses.DeleteRealmClientScopesIdWithResponse(ctx, realm.Name, *existingClientScope.Id)
+	IncreaseDurationMetrics(start, "DELETE", "DeleteRealmClientScopes")
+	if err != nil {
+		IncreaseErrorMetrics()
+		return err
+	}
+
+	if responseErr := CheckStatusCode(del, http.StatusNoContent, http.StatusNotFound); responseErr != nil {
+		IncreaseErrorMetrics()
+		return fmt.Errorf("❌ failed to delete client scope: %d -- Response for DELETE is: %s",
+			del.StatusCode(), string(del.Body))
+	}
+
+	IncreaseStatusMetrics(strconv.Itoa(del.StatusCode()), "DELETE", "DeleteRealmClientScopes")
+	var successMessage = fmt.Sprintf("✅ deleted client scope %s in realm %s", clientScope.Spec.Name, realm.Name)
+	logger.V(1).Info(successMessage)
+	return nil
+}
@@ -0,0 +1,169 @@
+package keycloak
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"k8s.io/utils/ptr"
+
+	identityv1 "github.com/telekom/controlplane-mono/identity/api/v1"
+	"github.com/telekom/controlplane-mono/identity/pkg/api"
+	"github.com/telekom/controlplane-mono/identity/test/mocks"
+)
+
+const (
+	ClientScopeName = "test-scope"
+	ClientScopeId   = "test-scope-id"
+)
+
+func newTestClientScope() *identityv1.ClientScope {
+	return &identityv1.ClientScope{
+		Spec: identityv1.ClientScopeSpec{
+			Name:        ClientScopeName,
+			Description: "test-description",
+			Attributes:  map[string]string{"include.in.token.scope": "true"},
+		},
+	}
+}
+
+func newTestRealm(name string) *identityv1.Realm {
+	realm := &identityv1.Realm{}
+	realm.Name = name
+	return realm
+}
+
+func mockGetRealmClientScopesResponse(statusCode int,
+	clientScopes ...api.ClientScopeRepresentation) *api.GetRealmClientScopesResponse {
+	return &api.GetRealmClientScopesResponse{
+		HTTPResponse: ptr.To(http.Response{StatusCode: statusCode}),
+		JSON2XX:      &clientScopes,
+	}
+}
+
+func TestGetRealmClientScopesReturnsClientError(t *testing.T) {
+	realmClient := NewRealmClient(nil)
+	result, err := realmClient.GetRealmClientScopes(context.Background(), Realm)
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+}
+
+func TestGetRealmClientScopesReturnsError(t *testing.T) {
+	mockedClient := mocks.NewMockKeycloakClient(t)
+	mockedClient.EXPECT().GetRealmClientScopesWithResponse(mock.Anything, Realm).
+		Return(nil, fmt.Errorf("error getting client scopes"))
+
+	result, err := NewRealmClient(mockedClient).GetRealmClientScopes(context.Background(), Realm)
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+}
+
+func TestGetRealmClientScopesReturnsStatusCodeError(t *testing.T) {
+	mockedClient := mocks.NewMockKeycloakClient(t)
+	mockedClient.EXPECT().GetRealmClientScopesWithResponse(mock.Anything, Realm).
+		Return(mockGetRealmClientScopesResponse(http.StatusBadRequest), nil)
+
+	result, err := NewRealmClient(mockedClient).GetRealmClientScopes(context.Background(), Realm)
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+}
+
+func TestCreateOrUpdateRealmClientScopeCreatesMissingScope(t *testing.T) {
+	mockedClient := mocks.NewMockKeycloakClient(t)
+	mockedClient.EXPECT().GetRealmClientScopesWithResponse(mock.Anything, Realm).
+		Return(mockGetRealmClientScopesResponse(http.StatusOK), nil)
+	mockedClient.EXPECT().PostRealmClientScopesWithResponse(mock.Anything, Realm,
+		mock.MatchedBy(func(body api.ClientScopeRepresentation) bool {
+			return *body.Name == ClientScopeName && *body.Protocol == "openid-connect"
+		})).
+		Return(&api.PostRealmClientScopesResponse{
+			HTTPResponse: ptr.To(http.Response{StatusCode: http.StatusCreated}),
+		}, nil)
+
+	err := NewRealmClient(mockedClient).
+		CreateOrUpdateRealmClientScope(context.Background(), newTestRealm(Realm), newTestClientScope())
+
+	assert.NoError(t, err)
+}
+
+func TestCreateOrUpdateRealmClientScopeUpdatesExistingScope(t *testing.T) {
+	existingClientScope := api.ClientScopeRepresentation{
+		Id:         ptr.To(ClientScopeId),
+		Name:       ptr.To(ClientScopeName),
+		Protocol:   ptr.To("openid-connect"),
+		Attributes: &map[string]interface{}{"display.on.consent.screen": "false"},
+	}
+
+	mockedClient := mocks.NewMockKeycloakClient(t)
+	mockedClient.EXPECT().GetRealmClientScopesWithResponse(mock.Anything, Realm).
+		Return(mockGetRealmClientScopesResponse(http.StatusOK, existingClientScope), nil)
+	mockedClient.EXPECT().PutRealmClientScopesIdWithResponse(mock.Anything, Realm, ClientScopeId,
+		mock.MatchedBy(func(body api.ClientScopeRepresentation) bool {
+			// Attributes are merged, so unmanaged attributes must be kept
+			return *body.Id == ClientScopeId &&
+				*body.Description == "test-description" &&
+				(*body.Attributes)["display.on.consent.screen"] == "false" &&
+				(*body.Attributes)["include.in.token.scope"] == "true"
+		})).
+		Return(&api.PutRealmClientScopesIdResponse{
+			HTTPResponse: ptr.To(http.Response{StatusCode: http.StatusNoContent}),
+		}, nil)
+
+	err := NewRealmClient(mockedClient).
+		CreateOrUpdateRealmClientScope(context.Background(), newTestRealm(Realm), newTestClientScope())
+
+	assert.NoError(t, err)
+}
+
+func TestCreateOrUpdateRealmClientScopeReturnsStatusCodeError(t *testing.T) {
+	mockedClient := mocks.NewMockKeycloakClient(t)
+	mockedClient.EXPECT().GetRealmClientScopesWithResponse(mock.Anything, Realm).
+		Return(mockGetRealmClientScopesResponse(http.StatusOK), nil)
+	mockedClient.EXPECT().PostRealmClientScopesWithResponse(mock.Anything, Realm,
+		mock.AnythingOfType("api.ClientScopeRepresentation")).
+		Return(&api.PostRealmClientScopesResponse{
+			HTTPResponse: ptr.To(http.Response{StatusCode: http.StatusConflict}),
+		}, nil)
+
+	err := NewRealmClient(mockedClient).
+		CreateOrUpdateRealmClientScope(context.Background(), newTestRealm(Realm), newTestClientScope())
+
+	assert.Error(t, err)
+}
+
+func TestDeleteRealmClientScopeIgnoresMissingScope(t *testing.T) {
+	mockedClient := mocks.NewMockKeycloakClient(t)
+	mockedClient.EXPECT().GetRealmClientScopesWithResponse(mock.Anything, Realm).
+		Return(mockGetRealmClientScopesResponse(http.StatusOK), nil)
+
+	err := NewRealmClient(mockedClient).
+		DeleteRealmClientScope(context.Background(), newTestRealm(Realm), newTestClientScope())
+
+	assert.NoError(t, err)
+}
+
+func TestDeleteRealmClientScopeDeletesExistingScope(t *testing.T) {
+	existingClientScope := api.ClientScopeRepresentation{
+		Id:   ptr.To(ClientScopeId),
+		Name: ptr.To(ClientScopeName),
+	}
+
+	mockedClient := mocks.NewMockKeycloakClient(t)
+	mockedClient.EXPECT().GetRealmClientScopesWithResponse(mock.Anything, Realm).
+		Return(mockGetRealmClientScopesResponse(http.StatusOK, existingClientScope), nil)
+	mockedClient.EXPECT().DeleteRealmClientScopesIdWithResponse(mock.Anything, Realm, ClientScopeId).
+		Return(&api.DeleteRealmClientScopesIdResponse{
+			HTTPResponse: ptr.To(http.Response{StatusCode: http.StatusNoContent}),
+		}, nil)
+
+	err := NewRealmClient(mockedClient).
+		DeleteRealmClientScope(context.Background(), newTestRealm(Realm), newTestClientScope())
+
+	assert.NoError(t, err)
+}
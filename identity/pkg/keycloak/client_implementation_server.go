@@ -0,0 +1,61 @@
+package keycloak
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/telekom/controlplane-mono/identity/pkg/api"
+)
+
+// serverInfoPath is relative to the admin URL, which points to the realms endpoint
+const serverInfoPath = "../serverinfo"
+
+// withServerInfoPath redirects the request of the generated root endpoint to the server-info endpoint.
+// The generated API is rooted at the admin realms endpoint, the server-info endpoint is a sibling of it.
+func withServerInfoPath(_ context.Context, req *http.Request) error {
+	req.URL = req.URL.ResolveReference(&url.URL{Path: serverInfoPath})
+	return nil
+}
+
+func (k *realmClient) GetServerInfo(ctx context.Context) (*api.ServerInfoRepresentation, error) {
+	logger := log.FromContext(ctx)
+	if k.clientWithResponses == nil {
+		return nil, fmt.Errorf("keycloak client is required")
+	}
+
+	start := time.Now()
+	get, err := k.clientWithResponses.GetWithResponse(ctx, withServerInfoPath)
+	IncreaseDurationMetrics(start, "GET", "GetServerInfo")
+	if err != nil {
+		IncreaseErrorMetrics()
+		return nil, err
+	}
+
+	if responseErr := CheckStatusCode(get, http.StatusOK); responseErr != nil {
+		IncreaseErrorMetrics()
+		return nil, fmt.Errorf("❌ failed to get server info: %d -- Response for GET is: %s",
+			get.StatusCode(), string(get.Body))
+	}
+
+	IncreaseStatusMetrics(strconv.Itoa(get.StatusCode()), "GET", "GetServerInfo")
+	if get.JSON2XX == nil {
+		return nil, fmt.Errorf("❌ server info response is empty")
+	}
+	logger.V(1).Info("GetServerInfo", "ℹ️ version", GetServerVersion(get.JSON2XX))
+	return get.JSON2XX, nil
+}
+
+// GetServerVersion returns the Keycloak version reported in the server info or an empty string if it is unknown
+func GetServerVersion(serverInfo *api.ServerInfoRepresentation) string {
+	if serverInfo == nil || serverInfo.SystemInfo == nil {
+		return ""
+	}
+	return ptr.Deref(serverInfo.SystemInfo.Version, "")
+}
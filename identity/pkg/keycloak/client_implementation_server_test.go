@@ -0,0 +1,78 @@
+package keycloak
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"k8s.io/utils/ptr"
+
+	"github.com/telekom/controlplane-mono/identity/pkg/api"
+	"github.com/telekom/controlplane-mono/identity/test/mocks"
+)
+
+func TestWithServerInfoPathResolvesSiblingOfRealms(t *testing.T) {
+	req := &http.Request{URL: &url.URL{Scheme: "https", Host: "keycloak.example.com", Path: "/auth/admin/realms/"}}
+
+	err := withServerInfoPath(context.Background(), req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "https://keycloak.example.com/auth/admin/serverinfo", req.URL.String())
+}
+
+func TestGetServerInfoReturnsClientError(t *testing.T) {
+	realmClient := NewRealmClient(nil)
+	result, err := realmClient.GetServerInfo(context.Background())
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+}
+
+func TestGetServerInfoReturnsServerInfo(t *testing.T) {
+	mockedClient := mocks.NewMockKeycloakClient(t)
+	mockedClient.EXPECT().GetWithResponse(mock.Anything, mock.Anything).
+		Return(&api.GetResponse{
+			HTTPResponse: ptr.To(http.Response{StatusCode: http.StatusOK}),
+			JSON2XX: &api.ServerInfoRepresentation{
+				SystemInfo: &api.SystemInfoRepresentation{Version: ptr.To("24.0.5")},
+			},
+		}, nil)
+
+	result, err := NewRealmClient(mockedClient).GetServerInfo(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, "24.0.5", GetServerVersion(result))
+}
+
+func TestGetServerInfoReturnsError(t *testing.T) {
+	mockedClient := mocks.NewMockKeycloakClient(t)
+	mockedClient.EXPECT().GetWithResponse(mock.Anything, mock.Anything).
+		Return(nil, fmt.Errorf("connection refused"))
+
+	result, err := NewRealmClient(mockedClient).GetServerInfo(context.Background())
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+}
+
+func TestGetServerInfoReturnsStatusCodeError(t *testing.T) {
+	mockedClient := mocks.NewMockKeycloakClient(t)
+	mockedClient.EXPECT().GetWithResponse(mock.Anything, mock.Anything).
+		Return(&api.GetResponse{
+			HTTPResponse: ptr.To(http.Response{StatusCode: http.StatusForbidden}),
+		}, nil)
+
+	result, err := NewRealmClient(mockedClient).GetServerInfo(context.Background())
+
+	assert.Error(t, err)
+	assert.Nil(t, result)
+}
+
+func TestGetServerVersionHandlesMissingSystemInfo(t *testing.T) {
+	assert.Equal(t, "", GetServerVersion(nil))
+	assert.Equal(t, "", GetServerVersion(&api.ServerInfoRepresentation{}))
+}
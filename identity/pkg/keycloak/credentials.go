@@ -0,0 +1,88 @@
+package keycloak
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	identityv1 "github.com/telekom/controlplane-mono/identity/api/v1"
+	secrets "github.com/telekom/controlplane-mono/secret-manager/pkg/api"
+)
+
+// SecretResolver resolves a secret reference to its actual value
+type SecretResolver func(ctx context.Context, secretRef string) (string, error)
+
+// CredentialProvider resolves the admin credentials of an IdentityProvider at use time,
+// so that they never have to be stored anywhere else
+type CredentialProvider interface {
+	AdminConfigFor(ctx context.Context, idp *identityv1.IdentityProvider) (AdminConfig, error)
+}
+
+var _ CredentialProvider = &credentialProvider{}
+
+type credentialProvider struct {
+	resolve SecretResolver
+}
+
+func NewCredentialProvider(resolve SecretResolver) CredentialProvider {
+	return &credentialProvider{
+		resolve: resolve,
+	}
+}
+
+// Credentials is the shared CredentialProvider which resolves secrets using the secret-manager
+var Credentials = NewCredentialProvider(func(ctx context.Context, secretRef string) (string, error) {
+	return secrets.Get(ctx, secretRef)
+})
+
+func (c *credentialProvider) AdminConfigFor(ctx context.Context,
+	idp *identityv1.IdentityProvider) (AdminConfig, error) {
+	if idp == nil {
+		return nil, fmt.Errorf("identityProvider is nil")
+	}
+	if err := ValidateIdentityProviderSpec(&idp.Spec); err != nil {
+		return nil, err
+	}
+
+	password, err := c.resolve(ctx, idp.Spec.AdminPassword)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to retrieve password from secret manager")
+	}
+
+	return NewKeycloakClientConfig(
+		idp.Spec.AdminUrl,
+		DetermineIssuerUrlFrom(idp.Spec.AdminUrl, MasterRealm),
+		DetermineAdminTokenUrlFrom(idp.Spec.AdminUrl, MasterRealm),
+		idp.Spec.AdminClientId,
+		EmptyString, // client secret is not needed for the admin client, because PasswordCredentialsFlow must be used
+		idp.Spec.AdminUserName,
+		password,
+	), nil
+}
+
+func ValidateIdentityProviderSpec(spec *identityv1.IdentityProviderSpec) error {
+	if spec.AdminUrl == "" {
+		return fmt.Errorf("identityProvider.AdminUrl is empty")
+	}
+	if spec.AdminClientId == "" {
+		return fmt.Errorf("identityProvider.AdminClientId is empty")
+	}
+	if spec.AdminUserName == "" {
+		return fmt.Errorf("identityProvider.AdminUserName is empty")
+	}
+	if spec.AdminPassword == "" {
+		return fmt.Errorf("identityProvider.AdminPassword is empty")
+	}
+	return nil
+}
+
+// GetClientForIdentityProvider resolves the admin credentials of the IdentityProvider
+// and returns a keycloak client that is authenticated with them
+func GetClientForIdentityProvider(ctx context.Context, idp *identityv1.IdentityProvider) (RealmClient, error) {
+	config, err := Credentials.AdminConfigFor(ctx, idp)
+	if err != nil {
+		return nil, err
+	}
+	return GetClientFor(config)
+}
@@ -0,0 +1,88 @@
+package keycloak
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	identityv1 "github.com/telekom/controlplane-mono/identity/api/v1"
+)
+
+func newTestIdentityProvider() *identityv1.IdentityProvider {
+	return &identityv1.IdentityProvider{
+		Spec: identityv1.IdentityProviderSpec{
+			AdminUrl:      "https://example.com/auth/admin/realms/",
+			AdminClientId: "admin-cli",
+			AdminUserName: "admin",
+			AdminPassword: "$<secret-ref>",
+		},
+	}
+}
+
+func TestAdminConfigForResolvesPassword(t *testing.T) {
+	provider := NewCredentialProvider(func(ctx context.Context, secretRef string) (string, error) {
+		assert.Equal(t, "$<secret-ref>", secretRef)
+		return "resolved-password", nil
+	})
+	idp := newTestIdentityProvider()
+
+	config, err := provider.AdminConfigFor(context.Background(), idp)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "https://example.com/auth/admin/realms/", config.EndpointUrl())
+	assert.Equal(t, "https://example.com/auth/realms/master", config.IssuerUrl())
+	assert.Equal(t, "https://example.com/auth/realms/master/protocol/openid-connect/token", config.TokenUrl())
+	assert.Equal(t, "admin-cli", config.ClientId())
+	assert.Equal(t, "", config.ClientSecret())
+	assert.Equal(t, "admin", config.Username())
+	assert.Equal(t, "resolved-password", config.Password())
+	// The identity provider itself must never be modified
+	assert.Equal(t, "$<secret-ref>", idp.Spec.AdminPassword)
+}
+
+func TestAdminConfigForFailsWhenSecretCanNotBeResolved(t *testing.T) {
+	provider := NewCredentialProvider(func(ctx context.Context, secretRef string) (string, error) {
+		return "", fmt.Errorf("secret-manager unavailable")
+	})
+
+	config, err := provider.AdminConfigFor(context.Background(), newTestIdentityProvider())
+
+	assert.Error(t, err)
+	assert.Nil(t, config)
+	assert.Contains(t, err.Error(), "failed to retrieve password from secret manager")
+}
+
+func TestAdminConfigForFailsWhenIdentityProviderIsInvalid(t *testing.T) {
+	provider := NewCredentialProvider(func(ctx context.Context, secretRef string) (string, error) {
+		return secretRef, nil
+	})
+
+	config, err := provider.AdminConfigFor(context.Background(), nil)
+	assert.Error(t, err)
+	assert.Nil(t, config)
+
+	idp := newTestIdentityProvider()
+	idp.Spec.AdminUserName = ""
+	config, err = provider.AdminConfigFor(context.Background(), idp)
+	assert.Error(t, err)
+	assert.Nil(t, config)
+	assert.Equal(t, "identityProvider.AdminUserName is empty", err.Error())
+}
+
+func TestValidateIdentityProviderSpec(t *testing.T) {
+	spec := newTestIdentityProvider().Spec
+	assert.NoError(t, ValidateIdentityProviderSpec(&spec))
+
+	spec.AdminUrl = ""
+	assert.EqualError(t, ValidateIdentityProviderSpec(&spec), "identityProvider.AdminUrl is empty")
+
+	spec = newTestIdentityProvider().Spec
+	spec.AdminClientId = ""
+	assert.EqualError(t, ValidateIdentityProviderSpec(&spec), "identityProvider.AdminClientId is empty")
+
+	spec = newTestIdentityProvider().Spec
+	spec.AdminPassword = ""
+	assert.EqualError(t, ValidateIdentityProviderSpec(&spec), "identityProvider.AdminPassword is empty")
+}
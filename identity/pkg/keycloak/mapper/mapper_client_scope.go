@@ -0,0 +1,92 @@
+package mapper
+
+import (
+	"reflect"
+
+	"k8s.io/utils/ptr"
+
+	identityv1 "github.com/telekom/controlplane-mono/identity/api/v1"
+	"github.com/telekom/controlplane-mono/identity/pkg/api"
+)
+
+const DefaultProtocol = "openid-connect"
+
+func GetClientScope(getRealmClientScopes api.GetRealmClientScopesResponse,
+	name string) *api.ClientScopeRepresentation {
+	if getRealmClientScopes.JSON2XX == nil {
+		return nil
+	}
+	for _, clientScope := range *getRealmClientScopes.JSON2XX {
+		if clientScope.Name != nil && *clientScope.Name == name {
+			return &clientScope
+		}
+	}
+	return nil
+}
+
+func MapToClientScopeRepresentation(clientScope *identityv1.ClientScope) api.ClientScopeRepresentation {
+	protocol := clientScope.Spec.Protocol
+	if protocol == "" {
+		protocol = DefaultProtocol
+	}
+
+	attributes := map[string]interface{}{}
+	for key, value := range clientScope.Spec.Attributes {
+		attributes[key] = value
+	}
+
+	return api.ClientScopeRepresentation{
+		Name:        ptr.To(clientScope.Spec.Name),
+		Description: ptr.To(clientScope.Spec.Description),
+		Protocol:    ptr.To(protocol),
+		Attributes:  &attributes,
+	}
+}
+
+func CompareClientScopeRepresentation(existingClientScope, newClientScope *api.ClientScopeRepresentation) bool {
+	return ptr.Deref(existingClientScope.Name, "") == *newClientScope.Name &&
+		ptr.Deref(existingClientScope.Description, "") == *newClientScope.Description &&
+		ptr.Deref(existingClientScope.Protocol, "") == *newClientScope.Protocol &&
+		containsAllAttributes(existingClientScope.Attributes, newClientScope.Attributes)
+}
+
+func MergeClientScopeRepresentation(existingClientScope,
+	newClientScope *api.ClientScopeRepresentation) *api.ClientScopeRepresentation {
+	// ID stays the same
+	existingClientScope.Name = newClientScope.Name
+	existingClientScope.Description = newClientScope.Description
+	existingClientScope.Protocol = newClientScope.Protocol
+	existingClientScope.Attributes = MergeAttributes(existingClientScope.Attributes, newClientScope.Attributes)
+
+	return existingClientScope
+}
+
+func containsAllAttributes(existingAttributes, newAttributes *map[string]interface{}) bool {
+	if newAttributes == nil {
+		return true
+	}
+	if existingAttributes == nil {
+		return len(*newAttributes) == 0
+	}
+	for key, value := range *newAttributes {
+		existingValue, found := (*existingAttributes)[key]
+		if !found || !reflect.DeepEqual(existingValue, value) {
+			return false
+		}
+	}
+	return true
+}
+
+func MergeAttributes(existingAttributes, newAttributes *map[string]interface{}) *map[string]interface{} {
+	if existingAttributes == nil {
+		return newAttributes
+	}
+	if newAttributes == nil {
+		return existingAttributes
+	}
+	for key, value := range *newAttributes {
+		(*existingAttributes)[key] = value
+	}
+
+	return existingAttributes
+}
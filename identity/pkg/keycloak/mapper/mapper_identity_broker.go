@@ -0,0 +1,170 @@
+package mapper
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+
+	"k8s.io/utils/ptr"
+
+	identityv1 "github.com/telekom/controlplane-mono/identity/api/v1"
+	"github.com/telekom/controlplane-mono/identity/pkg/api"
+)
+
+func MapToIdentityProviderRepresentation(broker *identityv1.IdentityBroker) api.IdentityProviderRepresentation {
+	config := map[string]interface{}{}
+	switch broker.Spec.ProviderType {
+	case identityv1.IdentityBrokerTypeOIDC:
+		mapOIDCConfig(broker.Spec.OIDC, config)
+	case identityv1.IdentityBrokerTypeSAML:
+		mapSAMLConfig(broker.Spec.SAML, config)
+	}
+
+	return api.IdentityProviderRepresentation{
+		Alias:                     ptr.To(broker.Spec.Alias),
+		DisplayName:               ptr.To(broker.Spec.DisplayName),
+		ProviderId:                ptr.To(broker.Spec.ProviderType),
+		Enabled:                   ptr.To(ptr.Deref(broker.Spec.Enabled, true)),
+		TrustEmail:                ptr.To(broker.Spec.TrustEmail),
+		FirstBrokerLoginFlowAlias: optionalString(broker.Spec.FirstBrokerLoginFlowAlias),
+		Config:                    &config,
+	}
+}
+
+func mapOIDCConfig(oidc *identityv1.OIDCBrokerConfig, config map[string]interface{}) {
+	if oidc == nil {
+		return
+	}
+	config["authorizationUrl"] = oidc.AuthorizationUrl
+	config["tokenUrl"] = oidc.TokenUrl
+	config["clientId"] = oidc.ClientId
+	config["clientSecret"] = oidc.ClientSecret
+	config["validateSignature"] = strconv.FormatBool(oidc.ValidateSignature)
+	config["pkceEnabled"] = strconv.FormatBool(oidc.PkceEnabled)
+	if oidc.PkceEnabled {
+		config["pkceMethod"] = "S256"
+	}
+	config["useJwksUrl"] = strconv.FormatBool(oidc.JwksUrl != "")
+	setIfNotEmpty(config, "jwksUrl", oidc.JwksUrl)
+	setIfNotEmpty(config, "userInfoUrl", oidc.UserInfoUrl)
+	setIfNotEmpty(config, "logoutUrl", oidc.LogoutUrl)
+	setIfNotEmpty(config, "issuer", oidc.Issuer)
+	setIfNotEmpty(config, "clientAuthMethod", oidc.ClientAuthMethod)
+	setIfNotEmpty(config, "defaultScope", strings.Join(oidc.DefaultScopes, " "))
+}
+
+func mapSAMLConfig(saml *identityv1.SAMLBrokerConfig, config map[string]interface{}) {
+	if saml == nil {
+		return
+	}
+	config["singleSignOnServiceUrl"] = saml.SingleSignOnServiceUrl
+	config["validateSignature"] = strconv.FormatBool(saml.ValidateSignature)
+	config["wantAssertionsSigned"] = strconv.FormatBool(saml.WantAssertionsSigned)
+	config["postBindingResponse"] = strconv.FormatBool(saml.PostBindingResponse)
+	setIfNotEmpty(config, "singleLogoutServiceUrl", saml.SingleLogoutServiceUrl)
+	setIfNotEmpty(config, "idpEntityId", saml.IdpEntityId)
+	setIfNotEmpty(config, "nameIDPolicyFormat", saml.NameIDPolicyFormat)
+	setIfNotEmpty(config, "principalType", saml.PrincipalType)
+	setIfNotEmpty(config, "signingCertificate", saml.SigningCertificate)
+}
+
+func setIfNotEmpty(config map[string]interface{}, key, value string) {
+	if value != "" {
+		config[key] = value
+	}
+}
+
+func optionalString(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return ptr.To(value)
+}
+
+// CompareIdentityProviderRepresentation compares the managed fields of both identity providers.
+// Keycloak never returns the client secret in plain text, hence a configured secret is always
+// detected as a change.
+func CompareIdentityProviderRepresentation(existingIdp, newIdp *api.IdentityProviderRepresentation) bool {
+	return ptr.Deref(existingIdp.Alias, "") == *newIdp.Alias &&
+		ptr.Deref(existingIdp.DisplayName, "") == *newIdp.DisplayName &&
+		ptr.Deref(existingIdp.ProviderId, "") == *newIdp.ProviderId &&
+		ptr.Deref(existingIdp.Enabled, false) == *newIdp.Enabled &&
+		ptr.Deref(existingIdp.TrustEmail, false) == *newIdp.TrustEmail &&
+		(newIdp.FirstBrokerLoginFlowAlias == nil ||
+			ptr.Deref(existingIdp.FirstBrokerLoginFlowAlias, "") == *newIdp.FirstBrokerLoginFlowAlias) &&
+		containsAllAttributes(existingIdp.Config, newIdp.Config)
+}
+
+func MergeIdentityProviderRepresentation(existingIdp,
+	newIdp *api.IdentityProviderRepresentation) *api.IdentityProviderRepresentation {
+	// Alias and internal ID stay the same
+	existingIdp.DisplayName = newIdp.DisplayName
+	existingIdp.ProviderId = newIdp.ProviderId
+	existingIdp.Enabled = newIdp.Enabled
+	existingIdp.TrustEmail = newIdp.TrustEmail
+	if newIdp.FirstBrokerLoginFlowAlias != nil {
+		existingIdp.FirstBrokerLoginFlowAlias = newIdp.FirstBrokerLoginFlowAlias
+	}
+	existingIdp.Config = MergeAttributes(existingIdp.Config, newIdp.Config)
+
+	return existingIdp
+}
+
+func MapToIdentityProviderMapperRepresentation(alias string,
+	brokerMapper identityv1.IdentityBrokerMapper) api.IdentityProviderMapperRepresentation {
+	config := map[string]interface{}{}
+	for key, value := range brokerMapper.Config {
+		config[key] = value
+	}
+
+	return api.IdentityProviderMapperRepresentation{
+		Name:                   ptr.To(brokerMapper.Name),
+		IdentityProviderAlias:  ptr.To(alias),
+		IdentityProviderMapper: ptr.To(brokerMapper.Type),
+		Config:                 &config,
+	}
+}
+
+func GetIdentityProviderMapper(mappers *[]api.IdentityProviderMapperRepresentation,
+	name string) *api.IdentityProviderMapperRepresentation {
+	if mappers == nil {
+		return nil
+	}
+	for _, idpMapper := range *mappers {
+		if idpMapper.Name != nil && *idpMapper.Name == name {
+			return &idpMapper
+		}
+	}
+	return nil
+}
+
+// CompareIdentityProviderMapperRepresentation returns true if the existing mapper has the same type
+// and exactly the same config as the new mapper.
+func CompareIdentityProviderMapperRepresentation(existingMapper,
+	newMapper *api.IdentityProviderMapperRepresentation) bool {
+	return ptr.Deref(existingMapper.IdentityProviderMapper, "") == *newMapper.IdentityProviderMapper &&
+		reflect.DeepEqual(ptr.Deref(existingMapper.Config, map[string]interface{}{}),
+			ptr.Deref(newMapper.Config, map[string]interface{}{}))
+}
+
+// FindObsoleteIdentityProviderMappers returns all existing mappers that are not part of the broker spec
+func FindObsoleteIdentityProviderMappers(existingMappers *[]api.IdentityProviderMapperRepresentation,
+	brokerMappers []identityv1.IdentityBrokerMapper) []api.IdentityProviderMapperRepresentation {
+	obsoleteMappers := make([]api.IdentityProviderMapperRepresentation, 0)
+	if existingMappers == nil {
+		return obsoleteMappers
+	}
+	for _, existingMapper := range *existingMappers {
+		found := false
+		for _, brokerMapper := range brokerMappers {
+			if ptr.Deref(existingMapper.Name, "") == brokerMapper.Name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			obsoleteMappers = append(obsoleteMappers, existingMapper)
+		}
+	}
+	return obsoleteMappers
+}
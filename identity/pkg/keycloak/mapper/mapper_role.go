@@ -0,0 +1,54 @@
+package mapper
+
+import (
+	"k8s.io/utils/ptr"
+
+	identityv1 "github.com/telekom/controlplane-mono/identity/api/v1"
+	"github.com/telekom/controlplane-mono/identity/pkg/api"
+)
+
+func MapToRoleRepresentation(role *identityv1.Role) api.RoleRepresentation {
+	return api.RoleRepresentation{
+		Name:        ptr.To(role.Spec.Name),
+		Description: ptr.To(role.Spec.Description),
+		ClientRole:  ptr.To(role.IsClientRole()),
+	}
+}
+
+func CompareRoleRepresentation(existingRole, newRole *api.RoleRepresentation) bool {
+	return ptr.Deref(existingRole.Name, "") == *newRole.Name &&
+		ptr.Deref(existingRole.Description, "") == *newRole.Description
+}
+
+func MergeRoleRepresentation(existingRole, newRole *api.RoleRepresentation) *api.RoleRepresentation {
+	// ID, container and composites stay the same
+	existingRole.Name = newRole.Name
+	existingRole.Description = newRole.Description
+
+	return existingRole
+}
+
+// FindMissingRoles returns all roles of newRoles that are not part of existingRoles.
+// Roles are compared by name, because the role mappings of a user are always scoped
+// to either the realm or a single client.
+func FindMissingRoles(existingRoles, newRoles *[]api.RoleRepresentation) []api.RoleRepresentation {
+	missingRoles := make([]api.RoleRepresentation, 0)
+	if newRoles == nil {
+		return missingRoles
+	}
+	for _, newRole := range *newRoles {
+		found := false
+		if existingRoles != nil {
+			for _, existingRole := range *existingRoles {
+				if ptr.Deref(existingRole.Name, "") == ptr.Deref(newRole.Name, "") {
+					found = true
+					break
+				}
+			}
+		}
+		if !found {
+			missingRoles = append(missingRoles, newRole)
+		}
+	}
+	return missingRoles
+}
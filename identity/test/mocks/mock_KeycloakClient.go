@@ -23,36 +23,36 @@ func (_m *MockKeycloakClient) EXPECT() *MockKeycloakClient_Expecter {
 	return &MockKeycloakClient_Expecter{mock: &_m.Mock}
 }
 
-// GetRealmClientsWithResponse provides a mock function with given fields: ctx, realm, params, reqEditors
-func (_m *MockKeycloakClient) GetRealmClientsWithResponse(ctx context.Context, realm string, params *api.GetRealmClientsParams, reqEditors ...api.RequestEditorFn) (*api.GetRealmClientsResponse, error) {
+// DeleteRealmClientScopesIdWithResponse provides a mock function with given fields: ctx, realm, id, reqEditors
+func (_m *MockKeycloakClient) DeleteRealmClientScopesIdWithResponse(ctx context.Context, realm string, id string, reqEditors ...api.RequestEditorFn) (*api.DeleteRealmClientScopesIdResponse, error) {
 	_va := make([]interface{}, len(reqEditors))
 	for _i := range reqEditors {
 		_va[_i] = reqEditors[_i]
 	}
 	var _ca []interface{}
-	_ca = append(_ca, ctx, realm, params)
+	_ca = append(_ca, ctx, realm, id)
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetRealmClientsWithResponse")
+		panic("no return value specified for DeleteRealmClientScopesIdWithResponse")
 	}
 
-	var r0 *api.GetRealmClientsResponse
+	var r0 *api.DeleteRealmClientScopesIdResponse
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, *api.GetRealmClientsParams, ...api.RequestEditorFn) (*api.GetRealmClientsResponse, error)); ok {
-		return rf(ctx, realm, params, reqEditors...)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, ...api.RequestEditorFn) (*api.DeleteRealmClientScopesIdResponse, error)); ok {
+		return rf(ctx, realm, id, reqEditors...)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, *api.GetRealmClientsParams, ...api.RequestEditorFn) *api.GetRealmClientsResponse); ok {
-		r0 = rf(ctx, realm, params, reqEditors...)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, ...api.RequestEditorFn) *api.DeleteRealmClientScopesIdResponse); ok {
+		r0 = rf(ctx, realm, id, reqEditors...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*api.GetRealmClientsResponse)
+			r0 = ret.Get(0).(*api.DeleteRealmClientScopesIdResponse)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, *api.GetRealmClientsParams, ...api.RequestEditorFn) error); ok {
-		r1 = rf(ctx, realm, params, reqEditors...)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, ...api.RequestEditorFn) error); ok {
+		r1 = rf(ctx, realm, id, reqEditors...)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -60,22 +60,22 @@ func (_m *MockKeycloakClient) GetRealmClientsWithResponse(ctx context.Context, r
 	return r0, r1
 }
 
-// MockKeycloakClient_GetRealmClientsWithResponse_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRealmClientsWithResponse'
-type MockKeycloakClient_GetRealmClientsWithResponse_Call struct {
+// MockKeycloakClient_DeleteRealmClientScopesIdWithResponse_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteRealmClientScopesIdWithResponse'
+type MockKeycloakClient_DeleteRealmClientScopesIdWithResponse_Call struct {
 	*mock.Call
 }
 
-// GetRealmClientsWithResponse is a helper method to define mock.On call
+// DeleteRealmClientScopesIdWithResponse is a helper method to define mock.On call
 //   - ctx context.Context
 //   - realm string
-//   - params *api.GetRealmClientsParams
+//   - id string
 //   - reqEditors ...api.RequestEditorFn
-func (_e *MockKeycloakClient_Expecter) GetRealmClientsWithResponse(ctx interface{}, realm interface{}, params interface{}, reqEditors ...interface{}) *MockKeycloakClient_GetRealmClientsWithResponse_Call {
-	return &MockKeycloakClient_GetRealmClientsWithResponse_Call{Call: _e.mock.On("GetRealmClientsWithResponse",
-		append([]interface{}{ctx, realm, params}, reqEditors...)...)}
+func (_e *MockKeycloakClient_Expecter) DeleteRealmClientScopesIdWithResponse(ctx interface{}, realm interface{}, id interface{}, reqEditors ...interface{}) *MockKeycloakClient_DeleteRealmClientScopesIdWithResponse_Call {
+	return &MockKeycloakClient_DeleteRealmClientScopesIdWithResponse_Call{Call: _e.mock.On("DeleteRealmClientScopesIdWithResponse",
+		append([]interface{}{ctx, realm, id}, reqEditors...)...)}
 }
 
-func (_c *MockKeycloakClient_GetRealmClientsWithResponse_Call) Run(run func(ctx context.Context, realm string, params *api.GetRealmClientsParams, reqEditors ...api.RequestEditorFn)) *MockKeycloakClient_GetRealmClientsWithResponse_Call {
+func (_c *MockKeycloakClient_DeleteRealmClientScopesIdWithResponse_Call) Run(run func(ctx context.Context, realm string, id string, reqEditors ...api.RequestEditorFn)) *MockKeycloakClient_DeleteRealmClientScopesIdWithResponse_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		variadicArgs := make([]api.RequestEditorFn, len(args)-3)
 		for i, a := range args[3:] {
@@ -83,51 +83,51 @@ func (_c *MockKeycloakClient_GetRealmClientsWithResponse_Call) Run(run func(ctx
 				variadicArgs[i] = a.(api.RequestEditorFn)
 			}
 		}
-		run(args[0].(context.Context), args[1].(string), args[2].(*api.GetRealmClientsParams), variadicArgs...)
+		run(args[0].(context.Context), args[1].(string), args[2].(string), variadicArgs...)
 	})
 	return _c
 }
 
-func (_c *MockKeycloakClient_GetRealmClientsWithResponse_Call) Return(_a0 *api.GetRealmClientsResponse, _a1 error) *MockKeycloakClient_GetRealmClientsWithResponse_Call {
+func (_c *MockKeycloakClient_DeleteRealmClientScopesIdWithResponse_Call) Return(_a0 *api.DeleteRealmClientScopesIdResponse, _a1 error) *MockKeycloakClient_DeleteRealmClientScopesIdWithResponse_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockKeycloakClient_GetRealmClientsWithResponse_Call) RunAndReturn(run func(context.Context, string, *api.GetRealmClientsParams, ...api.RequestEditorFn) (*api.GetRealmClientsResponse, error)) *MockKeycloakClient_GetRealmClientsWithResponse_Call {
+func (_c *MockKeycloakClient_DeleteRealmClientScopesIdWithResponse_Call) RunAndReturn(run func(context.Context, string, string, ...api.RequestEditorFn) (*api.DeleteRealmClientScopesIdResponse, error)) *MockKeycloakClient_DeleteRealmClientScopesIdWithResponse_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetRealmWithResponse provides a mock function with given fields: ctx, realm, reqEditors
-func (_m *MockKeycloakClient) GetRealmWithResponse(ctx context.Context, realm string, reqEditors ...api.RequestEditorFn) (*api.GetRealmResponse, error) {
+// DeleteRealmClientsIdRolesRoleNameWithResponse provides a mock function with given fields: ctx, realm, id, roleName, reqEditors
+func (_m *MockKeycloakClient) DeleteRealmClientsIdRolesRoleNameWithResponse(ctx context.Context, realm string, id string, roleName string, reqEditors ...api.RequestEditorFn) (*api.DeleteRealmClientsIdRolesRoleNameResponse, error) {
 	_va := make([]interface{}, len(reqEditors))
 	for _i := range reqEditors {
 		_va[_i] = reqEditors[_i]
 	}
 	var _ca []interface{}
-	_ca = append(_ca, ctx, realm)
+	_ca = append(_ca, ctx, realm, id, roleName)
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetRealmWithResponse")
+		panic("no return value specified for DeleteRealmClientsIdRolesRoleNameWithResponse")
 	}
 
-	var r0 *api.GetRealmResponse
+	var r0 *api.DeleteRealmClientsIdRolesRoleNameResponse
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, ...api.RequestEditorFn) (*api.GetRealmResponse, error)); ok {
-		return rf(ctx, realm, reqEditors...)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, ...api.RequestEditorFn) (*api.DeleteRealmClientsIdRolesRoleNameResponse, error)); ok {
+		return rf(ctx, realm, id, roleName, reqEditors...)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, ...api.RequestEditorFn) *api.GetRealmResponse); ok {
-		r0 = rf(ctx, realm, reqEditors...)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, ...api.RequestEditorFn) *api.DeleteRealmClientsIdRolesRoleNameResponse); ok {
+		r0 = rf(ctx, realm, id, roleName, reqEditors...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*api.GetRealmResponse)
+			r0 = ret.Get(0).(*api.DeleteRealmClientsIdRolesRoleNameResponse)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, ...api.RequestEditorFn) error); ok {
-		r1 = rf(ctx, realm, reqEditors...)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, ...api.RequestEditorFn) error); ok {
+		r1 = rf(ctx, realm, id, roleName, reqEditors...)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -135,73 +135,75 @@ func (_m *MockKeycloakClient) GetRealmWithResponse(ctx context.Context, realm st
 	return r0, r1
 }
 
-// MockKeycloakClient_GetRealmWithResponse_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRealmWithResponse'
-type MockKeycloakClient_GetRealmWithResponse_Call struct {
+// MockKeycloakClient_DeleteRealmClientsIdRolesRoleNameWithResponse_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteRealmClientsIdRolesRoleNameWithResponse'
+type MockKeycloakClient_DeleteRealmClientsIdRolesRoleNameWithResponse_Call struct {
 	*mock.Call
 }
 
-// GetRealmWithResponse is a helper method to define mock.On call
+// DeleteRealmClientsIdRolesRoleNameWithResponse is a helper method to define mock.On call
 //   - ctx context.Context
 //   - realm string
+//   - id string
+//   - roleName string
 //   - reqEditors ...api.RequestEditorFn
-func (_e *MockKeycloakClient_Expecter) GetRealmWithResponse(ctx interface{}, realm interface{}, reqEditors ...interface{}) *MockKeycloakClient_GetRealmWithResponse_Call {
-	return &MockKeycloakClient_GetRealmWithResponse_Call{Call: _e.mock.On("GetRealmWithResponse",
-		append([]interface{}{ctx, realm}, reqEditors...)...)}
+func (_e *MockKeycloakClient_Expecter) DeleteRealmClientsIdRolesRoleNameWithResponse(ctx interface{}, realm interface{}, id interface{}, roleName interface{}, reqEditors ...interface{}) *MockKeycloakClient_DeleteRealmClientsIdRolesRoleNameWithResponse_Call {
+	return &MockKeycloakClient_DeleteRealmClientsIdRolesRoleNameWithResponse_Call{Call: _e.mock.On("DeleteRealmClientsIdRolesRoleNameWithResponse",
+		append([]interface{}{ctx, realm, id, roleName}, reqEditors...)...)}
 }
 
-func (_c *MockKeycloakClient_GetRealmWithResponse_Call) Run(run func(ctx context.Context, realm string, reqEditors ...api.RequestEditorFn)) *MockKeycloakClient_GetRealmWithResponse_Call {
+func (_c *MockKeycloakClient_DeleteRealmClientsIdRolesRoleNameWithResponse_Call) Run(run func(ctx context.Context, realm string, id string, roleName string, reqEditors ...api.RequestEditorFn)) *MockKeycloakClient_DeleteRealmClientsIdRolesRoleNameWithResponse_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		variadicArgs := make([]api.RequestEditorFn, len(args)-2)
-		for i, a := range args[2:] {
+		variadicArgs := make([]api.RequestEditorFn, len(args)-4)
+		for i, a := range args[4:] {
 			if a != nil {
 				variadicArgs[i] = a.(api.RequestEditorFn)
 			}
 		}
-		run(args[0].(context.Context), args[1].(string), variadicArgs...)
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string), variadicArgs...)
 	})
 	return _c
 }
 
-func (_c *MockKeycloakClient_GetRealmWithResponse_Call) Return(_a0 *api.GetRealmResponse, _a1 error) *MockKeycloakClient_GetRealmWithResponse_Call {
+func (_c *MockKeycloakClient_DeleteRealmClientsIdRolesRoleNameWithResponse_Call) Return(_a0 *api.DeleteRealmClientsIdRolesRoleNameResponse, _a1 error) *MockKeycloakClient_DeleteRealmClientsIdRolesRoleNameWithResponse_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockKeycloakClient_GetRealmWithResponse_Call) RunAndReturn(run func(context.Context, string, ...api.RequestEditorFn) (*api.GetRealmResponse, error)) *MockKeycloakClient_GetRealmWithResponse_Call {
+func (_c *MockKeycloakClient_DeleteRealmClientsIdRolesRoleNameWithResponse_Call) RunAndReturn(run func(context.Context, string, string, string, ...api.RequestEditorFn) (*api.DeleteRealmClientsIdRolesRoleNameResponse, error)) *MockKeycloakClient_DeleteRealmClientsIdRolesRoleNameWithResponse_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// PostRealmClientsWithResponse provides a mock function with given fields: ctx, realm, body, reqEditors
-func (_m *MockKeycloakClient) PostRealmClientsWithResponse(ctx context.Context, realm string, body api.ClientRepresentation, reqEditors ...api.RequestEditorFn) (*api.PostRealmClientsResponse, error) {
+// DeleteRealmIdentityProviderInstancesAliasMappersIdWithResponse provides a mock function with given fields: ctx, realm, alias, id, reqEditors
+func (_m *MockKeycloakClient) DeleteRealmIdentityProviderInstancesAliasMappersIdWithResponse(ctx context.Context, realm string, alias string, id string, reqEditors ...api.RequestEditorFn) (*api.DeleteRealmIdentityProviderInstancesAliasMappersIdResponse, error) {
 	_va := make([]interface{}, len(reqEditors))
 	for _i := range reqEditors {
 		_va[_i] = reqEditors[_i]
 	}
 	var _ca []interface{}
-	_ca = append(_ca, ctx, realm, body)
+	_ca = append(_ca, ctx, realm, alias, id)
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
 	if len(ret) == 0 {
-		panic("no return value specified for PostRealmClientsWithResponse")
+		panic("no return value specified for DeleteRealmIdentityProviderInstancesAliasMappersIdWithResponse")
 	}
 
-	var r0 *api.PostRealmClientsResponse
+	var r0 *api.DeleteRealmIdentityProviderInstancesAliasMappersIdResponse
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, api.ClientRepresentation, ...api.RequestEditorFn) (*api.PostRealmClientsResponse, error)); ok {
-		return rf(ctx, realm, body, reqEditors...)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, ...api.RequestEditorFn) (*api.DeleteRealmIdentityProviderInstancesAliasMappersIdResponse, error)); ok {
+		return rf(ctx, realm, alias, id, reqEditors...)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, api.ClientRepresentation, ...api.RequestEditorFn) *api.PostRealmClientsResponse); ok {
-		r0 = rf(ctx, realm, body, reqEditors...)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, ...api.RequestEditorFn) *api.DeleteRealmIdentityProviderInstancesAliasMappersIdResponse); ok {
+		r0 = rf(ctx, realm, alias, id, reqEditors...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*api.PostRealmClientsResponse)
+			r0 = ret.Get(0).(*api.DeleteRealmIdentityProviderInstancesAliasMappersIdResponse)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, api.ClientRepresentation, ...api.RequestEditorFn) error); ok {
-		r1 = rf(ctx, realm, body, reqEditors...)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, ...api.RequestEditorFn) error); ok {
+		r1 = rf(ctx, realm, alias, id, reqEditors...)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -209,22 +211,98 @@ func (_m *MockKeycloakClient) PostRealmClientsWithResponse(ctx context.Context,
 	return r0, r1
 }
 
-// MockKeycloakClient_PostRealmClientsWithResponse_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PostRealmClientsWithResponse'
-type MockKeycloakClient_PostRealmClientsWithResponse_Call struct {
+// MockKeycloakClient_DeleteRealmIdentityProviderInstancesAliasMappersIdWithResponse_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteRealmIdentityProviderInstancesAliasMappersIdWithResponse'
+type MockKeycloakClient_DeleteRealmIdentityProviderInstancesAliasMappersIdWithResponse_Call struct {
 	*mock.Call
 }
 
-// PostRealmClientsWithResponse is a helper method to define mock.On call
+// DeleteRealmIdentityProviderInstancesAliasMappersIdWithResponse is a helper method to define mock.On call
 //   - ctx context.Context
 //   - realm string
-//   - body api.ClientRepresentation
+//   - alias string
+//   - id string
 //   - reqEditors ...api.RequestEditorFn
-func (_e *MockKeycloakClient_Expecter) PostRealmClientsWithResponse(ctx interface{}, realm interface{}, body interface{}, reqEditors ...interface{}) *MockKeycloakClient_PostRealmClientsWithResponse_Call {
-	return &MockKeycloakClient_PostRealmClientsWithResponse_Call{Call: _e.mock.On("PostRealmClientsWithResponse",
-		append([]interface{}{ctx, realm, body}, reqEditors...)...)}
+func (_e *MockKeycloakClient_Expecter) DeleteRealmIdentityProviderInstancesAliasMappersIdWithResponse(ctx interface{}, realm interface{}, alias interface{}, id interface{}, reqEditors ...interface{}) *MockKeycloakClient_DeleteRealmIdentityProviderInstancesAliasMappersIdWithResponse_Call {
+	return &MockKeycloakClient_DeleteRealmIdentityProviderInstancesAliasMappersIdWithResponse_Call{Call: _e.mock.On("DeleteRealmIdentityProviderInstancesAliasMappersIdWithResponse",
+		append([]interface{}{ctx, realm, alias, id}, reqEditors...)...)}
 }
 
-func (_c *MockKeycloakClient_PostRealmClientsWithResponse_Call) Run(run func(ctx context.Context, realm string, body api.ClientRepresentation, reqEditors ...api.RequestEditorFn)) *MockKeycloakClient_PostRealmClientsWithResponse_Call {
+func (_c *MockKeycloakClient_DeleteRealmIdentityProviderInstancesAliasMappersIdWithResponse_Call) Run(run func(ctx context.Context, realm string, alias string, id string, reqEditors ...api.RequestEditorFn)) *MockKeycloakClient_DeleteRealmIdentityProviderInstancesAliasMappersIdWithResponse_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]api.RequestEditorFn, len(args)-4)
+		for i, a := range args[4:] {
+			if a != nil {
+				variadicArgs[i] = a.(api.RequestEditorFn)
+			}
+		}
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockKeycloakClient_DeleteRealmIdentityProviderInstancesAliasMappersIdWithResponse_Call) Return(_a0 *api.DeleteRealmIdentityProviderInstancesAliasMappersIdResponse, _a1 error) *MockKeycloakClient_DeleteRealmIdentityProviderInstancesAliasMappersIdWithResponse_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockKeycloakClient_DeleteRealmIdentityProviderInstancesAliasMappersIdWithResponse_Call) RunAndReturn(run func(context.Context, string, string, string, ...api.RequestEditorFn) (*api.DeleteRealmIdentityProviderInstancesAliasMappersIdResponse, error)) *MockKeycloakClient_DeleteRealmIdentityProviderInstancesAliasMappersIdWithResponse_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteRealmIdentityProviderInstancesAliasWithResponse provides a mock function with given fields: ctx, realm, alias, reqEditors
+func (_m *MockKeycloakClient) DeleteRealmIdentityProviderInstancesAliasWithResponse(ctx context.Context, realm string, alias string, reqEditors ...api.RequestEditorFn) (*api.DeleteRealmIdentityProviderInstancesAliasResponse, error) {
+	_va := make([]interface{}, len(reqEditors))
+	for _i := range reqEditors {
+		_va[_i] = reqEditors[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, realm, alias)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteRealmIdentityProviderInstancesAliasWithResponse")
+	}
+
+	var r0 *api.DeleteRealmIdentityProviderInstancesAliasResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, ...api.RequestEditorFn) (*api.DeleteRealmIdentityProviderInstancesAliasResponse, error)); ok {
+		return rf(ctx, realm, alias, reqEditors...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, ...api.RequestEditorFn) *api.DeleteRealmIdentityProviderInstancesAliasResponse); ok {
+		r0 = rf(ctx, realm, alias, reqEditors...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.DeleteRealmIdentityProviderInstancesAliasResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, ...api.RequestEditorFn) error); ok {
+		r1 = rf(ctx, realm, alias, reqEditors...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockKeycloakClient_DeleteRealmIdentityProviderInstancesAliasWithResponse_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteRealmIdentityProviderInstancesAliasWithResponse'
+type MockKeycloakClient_DeleteRealmIdentityProviderInstancesAliasWithResponse_Call struct {
+	*mock.Call
+}
+
+// DeleteRealmIdentityProviderInstancesAliasWithResponse is a helper method to define mock.On call
+//   - ctx context.Context
+//   - realm string
+//   - alias string
+//   - reqEditors ...api.RequestEditorFn
+func (_e *MockKeycloakClient_Expecter) DeleteRealmIdentityProviderInstancesAliasWithResponse(ctx interface{}, realm interface{}, alias interface{}, reqEditors ...interface{}) *MockKeycloakClient_DeleteRealmIdentityProviderInstancesAliasWithResponse_Call {
+	return &MockKeycloakClient_DeleteRealmIdentityProviderInstancesAliasWithResponse_Call{Call: _e.mock.On("DeleteRealmIdentityProviderInstancesAliasWithResponse",
+		append([]interface{}{ctx, realm, alias}, reqEditors...)...)}
+}
+
+func (_c *MockKeycloakClient_DeleteRealmIdentityProviderInstancesAliasWithResponse_Call) Run(run func(ctx context.Context, realm string, alias string, reqEditors ...api.RequestEditorFn)) *MockKeycloakClient_DeleteRealmIdentityProviderInstancesAliasWithResponse_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		variadicArgs := make([]api.RequestEditorFn, len(args)-3)
 		for i, a := range args[3:] {
@@ -232,51 +310,51 @@ func (_c *MockKeycloakClient_PostRealmClientsWithResponse_Call) Run(run func(ctx
 				variadicArgs[i] = a.(api.RequestEditorFn)
 			}
 		}
-		run(args[0].(context.Context), args[1].(string), args[2].(api.ClientRepresentation), variadicArgs...)
+		run(args[0].(context.Context), args[1].(string), args[2].(string), variadicArgs...)
 	})
 	return _c
 }
 
-func (_c *MockKeycloakClient_PostRealmClientsWithResponse_Call) Return(_a0 *api.PostRealmClientsResponse, _a1 error) *MockKeycloakClient_PostRealmClientsWithResponse_Call {
+func (_c *MockKeycloakClient_DeleteRealmIdentityProviderInstancesAliasWithResponse_Call) Return(_a0 *api.DeleteRealmIdentityProviderInstancesAliasResponse, _a1 error) *MockKeycloakClient_DeleteRealmIdentityProviderInstancesAliasWithResponse_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockKeycloakClient_PostRealmClientsWithResponse_Call) RunAndReturn(run func(context.Context, string, api.ClientRepresentation, ...api.RequestEditorFn) (*api.PostRealmClientsResponse, error)) *MockKeycloakClient_PostRealmClientsWithResponse_Call {
+func (_c *MockKeycloakClient_DeleteRealmIdentityProviderInstancesAliasWithResponse_Call) RunAndReturn(run func(context.Context, string, string, ...api.RequestEditorFn) (*api.DeleteRealmIdentityProviderInstancesAliasResponse, error)) *MockKeycloakClient_DeleteRealmIdentityProviderInstancesAliasWithResponse_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// PostWithResponse provides a mock function with given fields: ctx, body, reqEditors
-func (_m *MockKeycloakClient) PostWithResponse(ctx context.Context, body api.RealmRepresentation, reqEditors ...api.RequestEditorFn) (*api.PostResponse, error) {
+// DeleteRealmRolesRoleNameWithResponse provides a mock function with given fields: ctx, realm, roleName, reqEditors
+func (_m *MockKeycloakClient) DeleteRealmRolesRoleNameWithResponse(ctx context.Context, realm string, roleName string, reqEditors ...api.RequestEditorFn) (*api.DeleteRealmRolesRoleNameResponse, error) {
 	_va := make([]interface{}, len(reqEditors))
 	for _i := range reqEditors {
 		_va[_i] = reqEditors[_i]
 	}
 	var _ca []interface{}
-	_ca = append(_ca, ctx, body)
+	_ca = append(_ca, ctx, realm, roleName)
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
 	if len(ret) == 0 {
-		panic("no return value specified for PostWithResponse")
+		panic("no return value specified for DeleteRealmRolesRoleNameWithResponse")
 	}
 
-	var r0 *api.PostResponse
+	var r0 *api.DeleteRealmRolesRoleNameResponse
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, api.RealmRepresentation, ...api.RequestEditorFn) (*api.PostResponse, error)); ok {
-		return rf(ctx, body, reqEditors...)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, ...api.RequestEditorFn) (*api.DeleteRealmRolesRoleNameResponse, error)); ok {
+		return rf(ctx, realm, roleName, reqEditors...)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, api.RealmRepresentation, ...api.RequestEditorFn) *api.PostResponse); ok {
-		r0 = rf(ctx, body, reqEditors...)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, ...api.RequestEditorFn) *api.DeleteRealmRolesRoleNameResponse); ok {
+		r0 = rf(ctx, realm, roleName, reqEditors...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*api.PostResponse)
+			r0 = ret.Get(0).(*api.DeleteRealmRolesRoleNameResponse)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, api.RealmRepresentation, ...api.RequestEditorFn) error); ok {
-		r1 = rf(ctx, body, reqEditors...)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, ...api.RequestEditorFn) error); ok {
+		r1 = rf(ctx, realm, roleName, reqEditors...)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -284,21 +362,96 @@ func (_m *MockKeycloakClient) PostWithResponse(ctx context.Context, body api.Rea
 	return r0, r1
 }
 
-// MockKeycloakClient_PostWithResponse_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PostWithResponse'
-type MockKeycloakClient_PostWithResponse_Call struct {
+// MockKeycloakClient_DeleteRealmRolesRoleNameWithResponse_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteRealmRolesRoleNameWithResponse'
+type MockKeycloakClient_DeleteRealmRolesRoleNameWithResponse_Call struct {
 	*mock.Call
 }
 
-// PostWithResponse is a helper method to define mock.On call
+// DeleteRealmRolesRoleNameWithResponse is a helper method to define mock.On call
 //   - ctx context.Context
-//   - body api.RealmRepresentation
+//   - realm string
+//   - roleName string
 //   - reqEditors ...api.RequestEditorFn
-func (_e *MockKeycloakClient_Expecter) PostWithResponse(ctx interface{}, body interface{}, reqEditors ...interface{}) *MockKeycloakClient_PostWithResponse_Call {
-	return &MockKeycloakClient_PostWithResponse_Call{Call: _e.mock.On("PostWithResponse",
-		append([]interface{}{ctx, body}, reqEditors...)...)}
+func (_e *MockKeycloakClient_Expecter) DeleteRealmRolesRoleNameWithResponse(ctx interface{}, realm interface{}, roleName interface{}, reqEditors ...interface{}) *MockKeycloakClient_DeleteRealmRolesRoleNameWithResponse_Call {
+	return &MockKeycloakClient_DeleteRealmRolesRoleNameWithResponse_Call{Call: _e.mock.On("DeleteRealmRolesRoleNameWithResponse",
+		append([]interface{}{ctx, realm, roleName}, reqEditors...)...)}
 }
 
-func (_c *MockKeycloakClient_PostWithResponse_Call) Run(run func(ctx context.Context, body api.RealmRepresentation, reqEditors ...api.RequestEditorFn)) *MockKeycloakClient_PostWithResponse_Call {
+func (_c *MockKeycloakClient_DeleteRealmRolesRoleNameWithResponse_Call) Run(run func(ctx context.Context, realm string, roleName string, reqEditors ...api.RequestEditorFn)) *MockKeycloakClient_DeleteRealmRolesRoleNameWithResponse_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]api.RequestEditorFn, len(args)-3)
+		for i, a := range args[3:] {
+			if a != nil {
+				variadicArgs[i] = a.(api.RequestEditorFn)
+			}
+		}
+		run(args[0].(context.Context), args[1].(string), args[2].(string), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockKeycloakClient_DeleteRealmRolesRoleNameWithResponse_Call) Return(_a0 *api.DeleteRealmRolesRoleNameResponse, _a1 error) *MockKeycloakClient_DeleteRealmRolesRoleNameWithResponse_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockKeycloakClient_DeleteRealmRolesRoleNameWithResponse_Call) RunAndReturn(run func(context.Context, string, string, ...api.RequestEditorFn) (*api.DeleteRealmRolesRoleNameResponse, error)) *MockKeycloakClient_DeleteRealmRolesRoleNameWithResponse_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetRealmClientScopesWithResponse provides a mock function with given fields: ctx, realm, reqEditors
+func (_m *MockKeycloakClient) GetRealmClientScopesWithResponse(ctx context.Context, realm string, reqEditors ...api.RequestEditorFn) (*api.GetRealmClientScopesResponse, error) {
+	_va := make([]interface{}, len(reqEditors))
+	for _i := range reqEditors {
+		_va[_i] = reqEditors[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, realm)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRealmClientScopesWithResponse")
+	}
+
+	var r0 *api.GetRealmClientScopesResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...api.RequestEditorFn) (*api.GetRealmClientScopesResponse, error)); ok {
+		return rf(ctx, realm, reqEditors...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...api.RequestEditorFn) *api.GetRealmClientScopesResponse); ok {
+		r0 = rf(ctx, realm, reqEditors...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.GetRealmClientScopesResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, ...api.RequestEditorFn) error); ok {
+		r1 = rf(ctx, realm, reqEditors...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockKeycloakClient_GetRealmClientScopesWithResponse_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRealmClientScopesWithResponse'
+type MockKeycloakClient_GetRealmClientScopesWithResponse_Call struct {
+	*mock.Call
+}
+
+// GetRealmClientScopesWithResponse is a helper method to define mock.On call
+//   - ctx context.Context
+//   - realm string
+//   - reqEditors ...api.RequestEditorFn
+func (_e *MockKeycloakClient_Expecter) GetRealmClientScopesWithResponse(ctx interface{}, realm interface{}, reqEditors ...interface{}) *MockKeycloakClient_GetRealmClientScopesWithResponse_Call {
+	return &MockKeycloakClient_GetRealmClientScopesWithResponse_Call{Call: _e.mock.On("GetRealmClientScopesWithResponse",
+		append([]interface{}{ctx, realm}, reqEditors...)...)}
+}
+
+func (_c *MockKeycloakClient_GetRealmClientScopesWithResponse_Call) Run(run func(ctx context.Context, realm string, reqEditors ...api.RequestEditorFn)) *MockKeycloakClient_GetRealmClientScopesWithResponse_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		variadicArgs := make([]api.RequestEditorFn, len(args)-2)
 		for i, a := range args[2:] {
@@ -306,51 +459,51 @@ func (_c *MockKeycloakClient_PostWithResponse_Call) Run(run func(ctx context.Con
 				variadicArgs[i] = a.(api.RequestEditorFn)
 			}
 		}
-		run(args[0].(context.Context), args[1].(api.RealmRepresentation), variadicArgs...)
+		run(args[0].(context.Context), args[1].(string), variadicArgs...)
 	})
 	return _c
 }
 
-func (_c *MockKeycloakClient_PostWithResponse_Call) Return(_a0 *api.PostResponse, _a1 error) *MockKeycloakClient_PostWithResponse_Call {
+func (_c *MockKeycloakClient_GetRealmClientScopesWithResponse_Call) Return(_a0 *api.GetRealmClientScopesResponse, _a1 error) *MockKeycloakClient_GetRealmClientScopesWithResponse_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockKeycloakClient_PostWithResponse_Call) RunAndReturn(run func(context.Context, api.RealmRepresentation, ...api.RequestEditorFn) (*api.PostResponse, error)) *MockKeycloakClient_PostWithResponse_Call {
+func (_c *MockKeycloakClient_GetRealmClientScopesWithResponse_Call) RunAndReturn(run func(context.Context, string, ...api.RequestEditorFn) (*api.GetRealmClientScopesResponse, error)) *MockKeycloakClient_GetRealmClientScopesWithResponse_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// PutRealmClientsIdWithResponse provides a mock function with given fields: ctx, realm, id, body, reqEditors
-func (_m *MockKeycloakClient) PutRealmClientsIdWithResponse(ctx context.Context, realm string, id string, body api.ClientRepresentation, reqEditors ...api.RequestEditorFn) (*api.PutRealmClientsIdResponse, error) {
+// GetRealmClientsIdRolesRoleNameWithResponse provides a mock function with given fields: ctx, realm, id, roleName, reqEditors
+func (_m *MockKeycloakClient) GetRealmClientsIdRolesRoleNameWithResponse(ctx context.Context, realm string, id string, roleName string, reqEditors ...api.RequestEditorFn) (*api.GetRealmClientsIdRolesRoleNameResponse, error) {
 	_va := make([]interface{}, len(reqEditors))
 	for _i := range reqEditors {
 		_va[_i] = reqEditors[_i]
 	}
 	var _ca []interface{}
-	_ca = append(_ca, ctx, realm, id, body)
+	_ca = append(_ca, ctx, realm, id, roleName)
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
 	if len(ret) == 0 {
-		panic("no return value specified for PutRealmClientsIdWithResponse")
+		panic("no return value specified for GetRealmClientsIdRolesRoleNameWithResponse")
 	}
 
-	var r0 *api.PutRealmClientsIdResponse
+	var r0 *api.GetRealmClientsIdRolesRoleNameResponse
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, api.ClientRepresentation, ...api.RequestEditorFn) (*api.PutRealmClientsIdResponse, error)); ok {
-		return rf(ctx, realm, id, body, reqEditors...)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, ...api.RequestEditorFn) (*api.GetRealmClientsIdRolesRoleNameResponse, error)); ok {
+		return rf(ctx, realm, id, roleName, reqEditors...)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, string, api.ClientRepresentation, ...api.RequestEditorFn) *api.PutRealmClientsIdResponse); ok {
-		r0 = rf(ctx, realm, id, body, reqEditors...)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, ...api.RequestEditorFn) *api.GetRealmClientsIdRolesRoleNameResponse); ok {
+		r0 = rf(ctx, realm, id, roleName, reqEditors...)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*api.PutRealmClientsIdResponse)
+			r0 = ret.Get(0).(*api.GetRealmClientsIdRolesRoleNameResponse)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, string, api.ClientRepresentation, ...api.RequestEditorFn) error); ok {
-		r1 = rf(ctx, realm, id, body, reqEditors...)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, ...api.RequestEditorFn) error); ok {
+		r1 = rf(ctx, realm, id, roleName, reqEditors...)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -358,23 +511,23 @@ func (_m *MockKeycloakClient) PutRealmClientsIdWithResponse(ctx context.Context,
 	return r0, r1
 }
 
-// MockKeycloakClient_PutRealmClientsIdWithResponse_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PutRealmClientsIdWithResponse'
-type MockKeycloakClient_PutRealmClientsIdWithResponse_Call struct {
+// MockKeycloakClient_GetRealmClientsIdRolesRoleNameWithResponse_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRealmClientsIdRolesRoleNameWithResponse'
+type MockKeycloakClient_GetRealmClientsIdRolesRoleNameWithResponse_Call struct {
 	*mock.Call
 }
 
-// PutRealmClientsIdWithResponse is a helper method to define mock.On call
+// GetRealmClientsIdRolesRoleNameWithResponse is a helper method to define mock.On call
 //   - ctx context.Context
 //   - realm string
 //   - id string
-//   - body api.ClientRepresentation
+//   - roleName string
 //   - reqEditors ...api.RequestEditorFn
-func (_e *MockKeycloakClient_Expecter) PutRealmClientsIdWithResponse(ctx interface{}, realm interface{}, id interface{}, body interface{}, reqEditors ...interface{}) *MockKeycloakClient_PutRealmClientsIdWithResponse_Call {
-	return &MockKeycloakClient_PutRealmClientsIdWithResponse_Call{Call: _e.mock.On("PutRealmClientsIdWithResponse",
-		append([]interface{}{ctx, realm, id, body}, reqEditors...)...)}
+func (_e *MockKeycloakClient_Expecter) GetRealmClientsIdRolesRoleNameWithResponse(ctx interface{}, realm interface{}, id interface{}, roleName interface{}, reqEditors ...interface{}) *MockKeycloakClient_GetRealmClientsIdRolesRoleNameWithResponse_Call {
+	return &MockKeycloakClient_GetRealmClientsIdRolesRoleNameWithResponse_Call{Call: _e.mock.On("GetRealmClientsIdRolesRoleNameWithResponse",
+		append([]interface{}{ctx, realm, id, roleName}, reqEditors...)...)}
 }
 
-func (_c *MockKeycloakClient_PutRealmClientsIdWithResponse_Call) Run(run func(ctx context.Context, realm string, id string, body api.ClientRepresentation, reqEditors ...api.RequestEditorFn)) *MockKeycloakClient_PutRealmClientsIdWithResponse_Call {
+func (_c *MockKeycloakClient_GetRealmClientsIdRolesRoleNameWithResponse_Call) Run(run func(ctx context.Context, realm string, id string, roleName string, reqEditors ...api.RequestEditorFn)) *MockKeycloakClient_GetRealmClientsIdRolesRoleNameWithResponse_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		variadicArgs := make([]api.RequestEditorFn, len(args)-4)
 		for i, a := range args[4:] {
@@ -382,17 +535,1901 @@ func (_c *MockKeycloakClient_PutRealmClientsIdWithResponse_Call) Run(run func(ct
 				variadicArgs[i] = a.(api.RequestEditorFn)
 			}
 		}
-		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(api.ClientRepresentation), variadicArgs...)
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string), variadicArgs...)
 	})
 	return _c
 }
 
-func (_c *MockKeycloakClient_PutRealmClientsIdWithResponse_Call) Return(_a0 *api.PutRealmClientsIdResponse, _a1 error) *MockKeycloakClient_PutRealmClientsIdWithResponse_Call {
+func (_c *MockKeycloakClient_GetRealmClientsIdRolesRoleNameWithResponse_Call) Return(_a0 *api.GetRealmClientsIdRolesRoleNameResponse, _a1 error) *MockKeycloakClient_GetRealmClientsIdRolesRoleNameWithResponse_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockKeycloakClient_PutRealmClientsIdWithResponse_Call) RunAndReturn(run func(context.Context, string, string, api.ClientRepresentation, ...api.RequestEditorFn) (*api.PutRealmClientsIdResponse, error)) *MockKeycloakClient_PutRealmClientsIdWithResponse_Call {
+func (_c *MockKeycloakClient_GetRealmClientsIdRolesRoleNameWithResponse_Call) RunAndReturn(run func(context.Context, string, string, string, ...api.RequestEditorFn) (*api.GetRealmClientsIdRolesRoleNameResponse, error)) *MockKeycloakClient_GetRealmClientsIdRolesRoleNameWithResponse_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetRealmClientsIdServiceAccountUserWithResponse provides a mock function with given fields: ctx, realm, id, reqEditors
+func (_m *MockKeycloakClient) GetRealmClientsIdServiceAccountUserWithResponse(ctx context.Context, realm string, id string, reqEditors ...api.RequestEditorFn) (*api.GetRealmClientsIdServiceAccountUserResponse, error) {
+	_va := make([]interface{}, len(reqEditors))
+	for _i := range reqEditors {
+		_va[_i] = reqEditors[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, realm, id)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRealmClientsIdServiceAccountUserWithResponse")
+	}
+
+	var r0 *api.GetRealmClientsIdServiceAccountUserResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, ...api.RequestEditorFn) (*api.GetRealmClientsIdServiceAccountUserResponse, error)); ok {
+		return rf(ctx, realm, id, reqEditors...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, ...api.RequestEditorFn) *api.GetRealmClientsIdServiceAccountUserResponse); ok {
+		r0 = rf(ctx, realm, id, reqEditors...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.GetRealmClientsIdServiceAccountUserResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, ...api.RequestEditorFn) error); ok {
+		r1 = rf(ctx, realm, id, reqEditors...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockKeycloakClient_GetRealmClientsIdServiceAccountUserWithResponse_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRealmClientsIdServiceAccountUserWithResponse'
+type MockKeycloakClient_GetRealmClientsIdServiceAccountUserWithResponse_Call struct {
+	*mock.Call
+}
+
+// GetRealmClientsIdServiceAccountUserWithResponse is a helper method to define mock.On call
+//   - ctx context.Context
+//   - realm string
+//   - id string
+//   - reqEditors ...api.RequestEditorFn
+func (_e *MockKeycloakClient_Expecter) GetRealmClientsIdServiceAccountUserWithResponse(ctx interface{}, realm interface{}, id interface{}, reqEditors ...interface{}) *MockKeycloakClient_GetRealmClientsIdServiceAccountUserWithResponse_Call {
+	return &MockKeycloakClient_GetRealmClientsIdServiceAccountUserWithResponse_Call{Call: _e.mock.On("GetRealmClientsIdServiceAccountUserWithResponse",
+		append([]interface{}{ctx, realm, id}, reqEditors...)...)}
+}
+
+func (_c *MockKeycloakClient_GetRealmClientsIdServiceAccountUserWithResponse_Call) Run(run func(ctx context.Context, realm string, id string, reqEditors ...api.RequestEditorFn)) *MockKeycloakClient_GetRealmClientsIdServiceAccountUserWithResponse_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]api.RequestEditorFn, len(args)-3)
+		for i, a := range args[3:] {
+			if a != nil {
+				variadicArgs[i] = a.(api.RequestEditorFn)
+			}
+		}
+		run(args[0].(context.Context), args[1].(string), args[2].(string), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockKeycloakClient_GetRealmClientsIdServiceAccountUserWithResponse_Call) Return(_a0 *api.GetRealmClientsIdServiceAccountUserResponse, _a1 error) *MockKeycloakClient_GetRealmClientsIdServiceAccountUserWithResponse_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockKeycloakClient_GetRealmClientsIdServiceAccountUserWithResponse_Call) RunAndReturn(run func(context.Context, string, string, ...api.RequestEditorFn) (*api.GetRealmClientsIdServiceAccountUserResponse, error)) *MockKeycloakClient_GetRealmClientsIdServiceAccountUserWithResponse_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetRealmClientsWithResponse provides a mock function with given fields: ctx, realm, params, reqEditors
+func (_m *MockKeycloakClient) GetRealmClientsWithResponse(ctx context.Context, realm string, params *api.GetRealmClientsParams, reqEditors ...api.RequestEditorFn) (*api.GetRealmClientsResponse, error) {
+	_va := make([]interface{}, len(reqEditors))
+	for _i := range reqEditors {
+		_va[_i] = reqEditors[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, realm, params)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRealmClientsWithResponse")
+	}
+
+	var r0 *api.GetRealmClientsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *api.GetRealmClientsParams, ...api.RequestEditorFn) (*api.GetRealmClientsResponse, error)); ok {
+		return rf(ctx, realm, params, reqEditors...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *api.GetRealmClientsParams, ...api.RequestEditorFn) *api.GetRealmClientsResponse); ok {
+		r0 = rf(ctx, realm, params, reqEditors...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.GetRealmClientsResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *api.GetRealmClientsParams, ...api.RequestEditorFn) error); ok {
+		r1 = rf(ctx, realm, params, reqEditors...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockKeycloakClient_GetRealmClientsWithResponse_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRealmClientsWithResponse'
+type MockKeycloakClient_GetRealmClientsWithResponse_Call struct {
+	*mock.Call
+}
+
+// GetRealmClientsWithResponse is a helper method to define mock.On call
+//   - ctx context.Context
+//   - realm string
+//   - params *api.GetRealmClientsParams
+//   - reqEditors ...api.RequestEditorFn
+func (_e *MockKeycloakClient_Expecter) GetRealmClientsWithResponse(ctx interface{}, realm interface{}, params interface{}, reqEditors ...interface{}) *MockKeycloakClient_GetRealmClientsWithResponse_Call {
+	return &MockKeycloakClient_GetRealmClientsWithResponse_Call{Call: _e.mock.On("GetRealmClientsWithResponse",
+		append([]interface{}{ctx, realm, params}, reqEditors...)...)}
+}
+
+func (_c *MockKeycloakClient_GetRealmClientsWithResponse_Call) Run(run func(ctx context.Context, realm string, params *api.GetRealmClientsParams, reqEditors ...api.RequestEditorFn)) *MockKeycloakClient_GetRealmClientsWithResponse_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]api.RequestEditorFn, len(args)-3)
+		for i, a := range args[3:] {
+			if a != nil {
+				variadicArgs[i] = a.(api.RequestEditorFn)
+			}
+		}
+		run(args[0].(context.Context), args[1].(string), args[2].(*api.GetRealmClientsParams), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockKeycloakClient_GetRealmClientsWithResponse_Call) Return(_a0 *api.GetRealmClientsResponse, _a1 error) *MockKeycloakClient_GetRealmClientsWithResponse_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockKeycloakClient_GetRealmClientsWithResponse_Call) RunAndReturn(run func(context.Context, string, *api.GetRealmClientsParams, ...api.RequestEditorFn) (*api.GetRealmClientsResponse, error)) *MockKeycloakClient_GetRealmClientsWithResponse_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetRealmIdentityProviderInstancesAliasMappersWithResponse provides a mock function with given fields: ctx, realm, alias, reqEditors
+func (_m *MockKeycloakClient) GetRealmIdentityProviderInstancesAliasMappersWithResponse(ctx context.Context, realm string, alias string, reqEditors ...api.RequestEditorFn) (*api.GetRealmIdentityProviderInstancesAliasMappersResponse, error) {
+	_va := make([]interface{}, len(reqEditors))
+	for _i := range reqEditors {
+		_va[_i] = reqEditors[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, realm, alias)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRealmIdentityProviderInstancesAliasMappersWithResponse")
+	}
+
+	var r0 *api.GetRealmIdentityProviderInstancesAliasMappersResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, ...api.RequestEditorFn) (*api.GetRealmIdentityProviderInstancesAliasMappersResponse, error)); ok {
+		return rf(ctx, realm, alias, reqEditors...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, ...api.RequestEditorFn) *api.GetRealmIdentityProviderInstancesAliasMappersResponse); ok {
+		r0 = rf(ctx, realm, alias, reqEditors...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.GetRealmIdentityProviderInstancesAliasMappersResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, ...api.RequestEditorFn) error); ok {
+		r1 = rf(ctx, realm, alias, reqEditors...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockKeycloakClient_GetRealmIdentityProviderInstancesAliasMappersWithResponse_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRealmIdentityProviderInstancesAliasMappersWithResponse'
+type MockKeycloakClient_GetRealmIdentityProviderInstancesAliasMappersWithResponse_Call struct {
+	*mock.Call
+}
+
+// GetRealmIdentityProviderInstancesAliasMappersWithResponse is a helper method to define mock.On call
+//   - ctx context.Context
+//   - realm string
+//   - alias string
+//   - reqEditors ...api.RequestEditorFn
+func (_e *MockKeycloakClient_Expecter) GetRealmIdentityProviderInstancesAliasMappersWithResponse(ctx interface{}, realm interface{}, alias interface{}, reqEditors ...interface{}) *MockKeycloakClient_GetRealmIdentityProviderInstancesAliasMappersWithResponse_Call {
+	return &MockKeycloakClient_GetRealmIdentityProviderInstancesAliasMappersWithResponse_Call{Call: _e.mock.On("GetRealmIdentityProviderInstancesAliasMappersWithResponse",
+		append([]interface{}{ctx, realm, alias}, reqEditors...)...)}
+}
+
+func (_c *MockKeycloakClient_GetRealmIdentityProviderInstancesAliasMappersWithResponse_Call) Run(run func(ctx context.Context, realm string, alias string, reqEditors ...api.RequestEditorFn)) *MockKeycloakClient_GetRealmIdentityProviderInstancesAliasMappersWithResponse_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]api.RequestEditorFn, len(args)-3)
+		for i, a := range args[3:] {
+			if a != nil {
+				variadicArgs[i] = a.(api.RequestEditorFn)
+			}
+		}
+		run(args[0].(context.Context), args[1].(string), args[2].(string), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockKeycloakClient_GetRealmIdentityProviderInstancesAliasMappersWithResponse_Call) Return(_a0 *api.GetRealmIdentityProviderInstancesAliasMappersResponse, _a1 error) *MockKeycloakClient_GetRealmIdentityProviderInstancesAliasMappersWithResponse_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockKeycloakClient_GetRealmIdentityProviderInstancesAliasMappersWithResponse_Call) RunAndReturn(run func(context.Context, string, string, ...api.RequestEditorFn) (*api.GetRealmIdentityProviderInstancesAliasMappersResponse, error)) *MockKeycloakClient_GetRealmIdentityProviderInstancesAliasMappersWithResponse_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetRealmIdentityProviderInstancesAliasWithResponse provides a mock function with given fields: ctx, realm, alias, reqEditors
+func (_m *MockKeycloakClient) GetRealmIdentityProviderInstancesAliasWithResponse(ctx context.Context, realm string, alias string, reqEditors ...api.RequestEditorFn) (*api.GetRealmIdentityProviderInstancesAliasResponse, error) {
+	_va := make([]interface{}, len(reqEditors))
+	for _i := range reqEditors {
+		_va[_i] = reqEditors[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, realm, alias)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRealmIdentityProviderInstancesAliasWithResponse")
+	}
+
+	var r0 *api.GetRealmIdentityProviderInstancesAliasResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, ...api.RequestEditorFn) (*api.GetRealmIdentityProviderInstancesAliasResponse, error)); ok {
+		return rf(ctx, realm, alias, reqEditors...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, ...api.RequestEditorFn) *api.GetRealmIdentityProviderInstancesAliasResponse); ok {
+		r0 = rf(ctx, realm, alias, reqEditors...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.GetRealmIdentityProviderInstancesAliasResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, ...api.RequestEditorFn) error); ok {
+		r1 = rf(ctx, realm, alias, reqEditors...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockKeycloakClient_GetRealmIdentityProviderInstancesAliasWithResponse_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRealmIdentityProviderInstancesAliasWithResponse'
+type MockKeycloakClient_GetRealmIdentityProviderInstancesAliasWithResponse_Call struct {
+	*mock.Call
+}
+
+// GetRealmIdentityProviderInstancesAliasWithResponse is a helper method to define mock.On call
+//   - ctx context.Context
+//   - realm string
+//   - alias string
+//   - reqEditors ...api.RequestEditorFn
+func (_e *MockKeycloakClient_Expecter) GetRealmIdentityProviderInstancesAliasWithResponse(ctx interface{}, realm interface{}, alias interface{}, reqEditors ...interface{}) *MockKeycloakClient_GetRealmIdentityProviderInstancesAliasWithResponse_Call {
+	return &MockKeycloakClient_GetRealmIdentityProviderInstancesAliasWithResponse_Call{Call: _e.mock.On("GetRealmIdentityProviderInstancesAliasWithResponse",
+		append([]interface{}{ctx, realm, alias}, reqEditors...)...)}
+}
+
+func (_c *MockKeycloakClient_GetRealmIdentityProviderInstancesAliasWithResponse_Call) Run(run func(ctx context.Context, realm string, alias string, reqEditors ...api.RequestEditorFn)) *MockKeycloakClient_GetRealmIdentityProviderInstancesAliasWithResponse_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]api.RequestEditorFn, len(args)-3)
+		for i, a := range args[3:] {
+			if a != nil {
+				variadicArgs[i] = a.(api.RequestEditorFn)
+			}
+		}
+		run(args[0].(context.Context), args[1].(string), args[2].(string), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockKeycloakClient_GetRealmIdentityProviderInstancesAliasWithResponse_Call) Return(_a0 *api.GetRealmIdentityProviderInstancesAliasResponse, _a1 error) *MockKeycloakClient_GetRealmIdentityProviderInstancesAliasWithResponse_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockKeycloakClient_GetRealmIdentityProviderInstancesAliasWithResponse_Call) RunAndReturn(run func(context.Context, string, string, ...api.RequestEditorFn) (*api.GetRealmIdentityProviderInstancesAliasResponse, error)) *MockKeycloakClient_GetRealmIdentityProviderInstancesAliasWithResponse_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetRealmIdentityProviderInstancesWithResponse provides a mock function with given fields: ctx, realm, reqEditors
+func (_m *MockKeycloakClient) GetRealmIdentityProviderInstancesWithResponse(ctx context.Context, realm string, reqEditors ...api.RequestEditorFn) (*api.GetRealmIdentityProviderInstancesResponse, error) {
+	_va := make([]interface{}, len(reqEditors))
+	for _i := range reqEditors {
+		_va[_i] = reqEditors[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, realm)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRealmIdentityProviderInstancesWithResponse")
+	}
+
+	var r0 *api.GetRealmIdentityProviderInstancesResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...api.RequestEditorFn) (*api.GetRealmIdentityProviderInstancesResponse, error)); ok {
+		return rf(ctx, realm, reqEditors...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...api.RequestEditorFn) *api.GetRealmIdentityProviderInstancesResponse); ok {
+		r0 = rf(ctx, realm, reqEditors...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.GetRealmIdentityProviderInstancesResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, ...api.RequestEditorFn) error); ok {
+		r1 = rf(ctx, realm, reqEditors...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockKeycloakClient_GetRealmIdentityProviderInstancesWithResponse_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRealmIdentityProviderInstancesWithResponse'
+type MockKeycloakClient_GetRealmIdentityProviderInstancesWithResponse_Call struct {
+	*mock.Call
+}
+
+// GetRealmIdentityProviderInstancesWithResponse is a helper method to define mock.On call
+//   - ctx context.Context
+//   - realm string
+//   - reqEditors ...api.RequestEditorFn
+func (_e *MockKeycloakClient_Expecter) GetRealmIdentityProviderInstancesWithResponse(ctx interface{}, realm interface{}, reqEditors ...interface{}) *MockKeycloakClient_GetRealmIdentityProviderInstancesWithResponse_Call {
+	return &MockKeycloakClient_GetRealmIdentityProviderInstancesWithResponse_Call{Call: _e.mock.On("GetRealmIdentityProviderInstancesWithResponse",
+		append([]interface{}{ctx, realm}, reqEditors...)...)}
+}
+
+func (_c *MockKeycloakClient_GetRealmIdentityProviderInstancesWithResponse_Call) Run(run func(ctx context.Context, realm string, reqEditors ...api.RequestEditorFn)) *MockKeycloakClient_GetRealmIdentityProviderInstancesWithResponse_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]api.RequestEditorFn, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(api.RequestEditorFn)
+			}
+		}
+		run(args[0].(context.Context), args[1].(string), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockKeycloakClient_GetRealmIdentityProviderInstancesWithResponse_Call) Return(_a0 *api.GetRealmIdentityProviderInstancesResponse, _a1 error) *MockKeycloakClient_GetRealmIdentityProviderInstancesWithResponse_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockKeycloakClient_GetRealmIdentityProviderInstancesWithResponse_Call) RunAndReturn(run func(context.Context, string, ...api.RequestEditorFn) (*api.GetRealmIdentityProviderInstancesResponse, error)) *MockKeycloakClient_GetRealmIdentityProviderInstancesWithResponse_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetRealmRolesRoleNameWithResponse provides a mock function with given fields: ctx, realm, roleName, reqEditors
+func (_m *MockKeycloakClient) GetRealmRolesRoleNameWithResponse(ctx context.Context, realm string, roleName string, reqEditors ...api.RequestEditorFn) (*api.GetRealmRolesRoleNameResponse, error) {
+	_va := make([]interface{}, len(reqEditors))
+	for _i := range reqEditors {
+		_va[_i] = reqEditors[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, realm, roleName)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRealmRolesRoleNameWithResponse")
+	}
+
+	var r0 *api.GetRealmRolesRoleNameResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, ...api.RequestEditorFn) (*api.GetRealmRolesRoleNameResponse, error)); ok {
+		return rf(ctx, realm, roleName, reqEditors...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, ...api.RequestEditorFn) *api.GetRealmRolesRoleNameResponse); ok {
+		r0 = rf(ctx, realm, roleName, reqEditors...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.GetRealmRolesRoleNameResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, ...api.RequestEditorFn) error); ok {
+		r1 = rf(ctx, realm, roleName, reqEditors...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockKeycloakClient_GetRealmRolesRoleNameWithResponse_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRealmRolesRoleNameWithResponse'
+type MockKeycloakClient_GetRealmRolesRoleNameWithResponse_Call struct {
+	*mock.Call
+}
+
+// GetRealmRolesRoleNameWithResponse is a helper method to define mock.On call
+//   - ctx context.Context
+//   - realm string
+//   - roleName string
+//   - reqEditors ...api.RequestEditorFn
+func (_e *MockKeycloakClient_Expecter) GetRealmRolesRoleNameWithResponse(ctx interface{}, realm interface{}, roleName interface{}, reqEditors ...interface{}) *MockKeycloakClient_GetRealmRolesRoleNameWithResponse_Call {
+	return &MockKeycloakClient_GetRealmRolesRoleNameWithResponse_Call{Call: _e.mock.On("GetRealmRolesRoleNameWithResponse",
+		append([]interface{}{ctx, realm, roleName}, reqEditors...)...)}
+}
+
+func (_c *MockKeycloakClient_GetRealmRolesRoleNameWithResponse_Call) Run(run func(ctx context.Context, realm string, roleName string, reqEditors ...api.RequestEditorFn)) *MockKeycloakClient_GetRealmRolesRoleNameWithResponse_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]api.RequestEditorFn, len(args)-3)
+		for i, a := range args[3:] {
+			if a != nil {
+				variadicArgs[i] = a.(api.RequestEditorFn)
+			}
+		}
+		run(args[0].(context.Context), args[1].(string), args[2].(string), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockKeycloakClient_GetRealmRolesRoleNameWithResponse_Call) Return(_a0 *api.GetRealmRolesRoleNameResponse, _a1 error) *MockKeycloakClient_GetRealmRolesRoleNameWithResponse_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockKeycloakClient_GetRealmRolesRoleNameWithResponse_Call) RunAndReturn(run func(context.Context, string, string, ...api.RequestEditorFn) (*api.GetRealmRolesRoleNameResponse, error)) *MockKeycloakClient_GetRealmRolesRoleNameWithResponse_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetRealmUsersIdRoleMappingsClientsClientWithResponse provides a mock function with given fields: ctx, realm, id, client, reqEditors
+func (_m *MockKeycloakClient) GetRealmUsersIdRoleMappingsClientsClientWithResponse(ctx context.Context, realm string, id string, client string, reqEditors ...api.RequestEditorFn) (*api.GetRealmUsersIdRoleMappingsClientsClientResponse, error) {
+	_va := make([]interface{}, len(reqEditors))
+	for _i := range reqEditors {
+		_va[_i] = reqEditors[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, realm, id, client)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRealmUsersIdRoleMappingsClientsClientWithResponse")
+	}
+
+	var r0 *api.GetRealmUsersIdRoleMappingsClientsClientResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, ...api.RequestEditorFn) (*api.GetRealmUsersIdRoleMappingsClientsClientResponse, error)); ok {
+		return rf(ctx, realm, id, client, reqEditors...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, ...api.RequestEditorFn) *api.GetRealmUsersIdRoleMappingsClientsClientResponse); ok {
+		r0 = rf(ctx, realm, id, client, reqEditors...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.GetRealmUsersIdRoleMappingsClientsClientResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, ...api.RequestEditorFn) error); ok {
+		r1 = rf(ctx, realm, id, client, reqEditors...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockKeycloakClient_GetRealmUsersIdRoleMappingsClientsClientWithResponse_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRealmUsersIdRoleMappingsClientsClientWithResponse'
+type MockKeycloakClient_GetRealmUsersIdRoleMappingsClientsClientWithResponse_Call struct {
+	*mock.Call
+}
+
+// GetRealmUsersIdRoleMappingsClientsClientWithResponse is a helper method to define mock.On call
+//   - ctx context.Context
+//   - realm string
+//   - id string
+//   - client string
+//   - reqEditors ...api.RequestEditorFn
+func (_e *MockKeycloakClient_Expecter) GetRealmUsersIdRoleMappingsClientsClientWithResponse(ctx interface{}, realm interface{}, id interface{}, client interface{}, reqEditors ...interface{}) *MockKeycloakClient_GetRealmUsersIdRoleMappingsClientsClientWithResponse_Call {
+	return &MockKeycloakClient_GetRealmUsersIdRoleMappingsClientsClientWithResponse_Call{Call: _e.mock.On("GetRealmUsersIdRoleMappingsClientsClientWithResponse",
+		append([]interface{}{ctx, realm, id, client}, reqEditors...)...)}
+}
+
+func (_c *MockKeycloakClient_GetRealmUsersIdRoleMappingsClientsClientWithResponse_Call) Run(run func(ctx context.Context, realm string, id string, client string, reqEditors ...api.RequestEditorFn)) *MockKeycloakClient_GetRealmUsersIdRoleMappingsClientsClientWithResponse_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]api.RequestEditorFn, len(args)-4)
+		for i, a := range args[4:] {
+			if a != nil {
+				variadicArgs[i] = a.(api.RequestEditorFn)
+			}
+		}
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockKeycloakClient_GetRealmUsersIdRoleMappingsClientsClientWithResponse_Call) Return(_a0 *api.GetRealmUsersIdRoleMappingsClientsClientResponse, _a1 error) *MockKeycloakClient_GetRealmUsersIdRoleMappingsClientsClientWithResponse_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockKeycloakClient_GetRealmUsersIdRoleMappingsClientsClientWithResponse_Call) RunAndReturn(run func(context.Context, string, string, string, ...api.RequestEditorFn) (*api.GetRealmUsersIdRoleMappingsClientsClientResponse, error)) *MockKeycloakClient_GetRealmUsersIdRoleMappingsClientsClientWithResponse_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetRealmUsersIdRoleMappingsRealmWithResponse provides a mock function with given fields: ctx, realm, id, reqEditors
+func (_m *MockKeycloakClient) GetRealmUsersIdRoleMappingsRealmWithResponse(ctx context.Context, realm string, id string, reqEditors ...api.RequestEditorFn) (*api.GetRealmUsersIdRoleMappingsRealmResponse, error) {
+	_va := make([]interface{}, len(reqEditors))
+	for _i := range reqEditors {
+		_va[_i] = reqEditors[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, realm, id)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRealmUsersIdRoleMappingsRealmWithResponse")
+	}
+
+	var r0 *api.GetRealmUsersIdRoleMappingsRealmResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, ...api.RequestEditorFn) (*api.GetRealmUsersIdRoleMappingsRealmResponse, error)); ok {
+		return rf(ctx, realm, id, reqEditors...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, ...api.RequestEditorFn) *api.GetRealmUsersIdRoleMappingsRealmResponse); ok {
+		r0 = rf(ctx, realm, id, reqEditors...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.GetRealmUsersIdRoleMappingsRealmResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, ...api.RequestEditorFn) error); ok {
+		r1 = rf(ctx, realm, id, reqEditors...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockKeycloakClient_GetRealmUsersIdRoleMappingsRealmWithResponse_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRealmUsersIdRoleMappingsRealmWithResponse'
+type MockKeycloakClient_GetRealmUsersIdRoleMappingsRealmWithResponse_Call struct {
+	*mock.Call
+}
+
+// GetRealmUsersIdRoleMappingsRealmWithResponse is a helper method to define mock.On call
+//   - ctx context.Context
+//   - realm string
+//   - id string
+//   - reqEditors ...api.RequestEditorFn
+func (_e *MockKeycloakClient_Expecter) GetRealmUsersIdRoleMappingsRealmWithResponse(ctx interface{}, realm interface{}, id interface{}, reqEditors ...interface{}) *MockKeycloakClient_GetRealmUsersIdRoleMappingsRealmWithResponse_Call {
+	return &MockKeycloakClient_GetRealmUsersIdRoleMappingsRealmWithResponse_Call{Call: _e.mock.On("GetRealmUsersIdRoleMappingsRealmWithResponse",
+		append([]interface{}{ctx, realm, id}, reqEditors...)...)}
+}
+
+func (_c *MockKeycloakClient_GetRealmUsersIdRoleMappingsRealmWithResponse_Call) Run(run func(ctx context.Context, realm string, id string, reqEditors ...api.RequestEditorFn)) *MockKeycloakClient_GetRealmUsersIdRoleMappingsRealmWithResponse_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]api.RequestEditorFn, len(args)-3)
+		for i, a := range args[3:] {
+			if a != nil {
+				variadicArgs[i] = a.(api.RequestEditorFn)
+			}
+		}
+		run(args[0].(context.Context), args[1].(string), args[2].(string), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockKeycloakClient_GetRealmUsersIdRoleMappingsRealmWithResponse_Call) Return(_a0 *api.GetRealmUsersIdRoleMappingsRealmResponse, _a1 error) *MockKeycloakClient_GetRealmUsersIdRoleMappingsRealmWithResponse_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockKeycloakClient_GetRealmUsersIdRoleMappingsRealmWithResponse_Call) RunAndReturn(run func(context.Context, string, string, ...api.RequestEditorFn) (*api.GetRealmUsersIdRoleMappingsRealmResponse, error)) *MockKeycloakClient_GetRealmUsersIdRoleMappingsRealmWithResponse_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetRealmWithResponse provides a mock function with given fields: ctx, realm, reqEditors
+func (_m *MockKeycloakClient) GetRealmWithResponse(ctx context.Context, realm string, reqEditors ...api.RequestEditorFn) (*api.GetRealmResponse, error) {
+	_va := make([]interface{}, len(reqEditors))
+	for _i := range reqEditors {
+		_va[_i] = reqEditors[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, realm)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRealmWithResponse")
+	}
+
+	var r0 *api.GetRealmResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...api.RequestEditorFn) (*api.GetRealmResponse, error)); ok {
+		return rf(ctx, realm, reqEditors...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...api.RequestEditorFn) *api.GetRealmResponse); ok {
+		r0 = rf(ctx, realm, reqEditors...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.GetRealmResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, ...api.RequestEditorFn) error); ok {
+		r1 = rf(ctx, realm, reqEditors...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockKeycloakClient_GetRealmWithResponse_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRealmWithResponse'
+type MockKeycloakClient_GetRealmWithResponse_Call struct {
+	*mock.Call
+}
+
+// GetRealmWithResponse is a helper method to define mock.On call
+//   - ctx context.Context
+//   - realm string
+//   - reqEditors ...api.RequestEditorFn
+func (_e *MockKeycloakClient_Expecter) GetRealmWithResponse(ctx interface{}, realm interface{}, reqEditors ...interface{}) *MockKeycloakClient_GetRealmWithResponse_Call {
+	return &MockKeycloakClient_GetRealmWithResponse_Call{Call: _e.mock.On("GetRealmWithResponse",
+		append([]interface{}{ctx, realm}, reqEditors...)...)}
+}
+
+func (_c *MockKeycloakClient_GetRealmWithResponse_Call) Run(run func(ctx context.Context, realm string, reqEditors ...api.RequestEditorFn)) *MockKeycloakClient_GetRealmWithResponse_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]api.RequestEditorFn, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(api.RequestEditorFn)
+			}
+		}
+		run(args[0].(context.Context), args[1].(string), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockKeycloakClient_GetRealmWithResponse_Call) Return(_a0 *api.GetRealmResponse, _a1 error) *MockKeycloakClient_GetRealmWithResponse_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockKeycloakClient_GetRealmWithResponse_Call) RunAndReturn(run func(context.Context, string, ...api.RequestEditorFn) (*api.GetRealmResponse, error)) *MockKeycloakClient_GetRealmWithResponse_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWithResponse provides a mock function with given fields: ctx, reqEditors
+func (_m *MockKeycloakClient) GetWithResponse(ctx context.Context, reqEditors ...api.RequestEditorFn) (*api.GetResponse, error) {
+	_va := make([]interface{}, len(reqEditors))
+	for _i := range reqEditors {
+		_va[_i] = reqEditors[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWithResponse")
+	}
+
+	var r0 *api.GetResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...api.RequestEditorFn) (*api.GetResponse, error)); ok {
+		return rf(ctx, reqEditors...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ...api.RequestEditorFn) *api.GetResponse); ok {
+		r0 = rf(ctx, reqEditors...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.GetResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ...api.RequestEditorFn) error); ok {
+		r1 = rf(ctx, reqEditors...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockKeycloakClient_GetWithResponse_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWithResponse'
+type MockKeycloakClient_GetWithResponse_Call struct {
+	*mock.Call
+}
+
+// GetWithResponse is a helper method to define mock.On call
+//   - ctx context.Context
+//   - reqEditors ...api.RequestEditorFn
+func (_e *MockKeycloakClient_Expecter) GetWithResponse(ctx interface{}, reqEditors ...interface{}) *MockKeycloakClient_GetWithResponse_Call {
+	return &MockKeycloakClient_GetWithResponse_Call{Call: _e.mock.On("GetWithResponse",
+		append([]interface{}{ctx}, reqEditors...)...)}
+}
+
+func (_c *MockKeycloakClient_GetWithResponse_Call) Run(run func(ctx context.Context, reqEditors ...api.RequestEditorFn)) *MockKeycloakClient_GetWithResponse_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]api.RequestEditorFn, len(args)-1)
+		for i, a := range args[1:] {
+			if a != nil {
+				variadicArgs[i] = a.(api.RequestEditorFn)
+			}
+		}
+		run(args[0].(context.Context), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockKeycloakClient_GetWithResponse_Call) Return(_a0 *api.GetResponse, _a1 error) *MockKeycloakClient_GetWithResponse_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockKeycloakClient_GetWithResponse_Call) RunAndReturn(run func(context.Context, ...api.RequestEditorFn) (*api.GetResponse, error)) *MockKeycloakClient_GetWithResponse_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PostRealmClientScopesWithResponse provides a mock function with given fields: ctx, realm, body, reqEditors
+func (_m *MockKeycloakClient) PostRealmClientScopesWithResponse(ctx context.Context, realm string, body api.ClientScopeRepresentation, reqEditors ...api.RequestEditorFn) (*api.PostRealmClientScopesResponse, error) {
+	_va := make([]interface{}, len(reqEditors))
+	for _i := range reqEditors {
+		_va[_i] = reqEditors[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, realm, body)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PostRealmClientScopesWithResponse")
+	}
+
+	var r0 *api.PostRealmClientScopesResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, api.ClientScopeRepresentation, ...api.RequestEditorFn) (*api.PostRealmClientScopesResponse, error)); ok {
+		return rf(ctx, realm, body, reqEditors...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, api.ClientScopeRepresentation, ...api.RequestEditorFn) *api.PostRealmClientScopesResponse); ok {
+		r0 = rf(ctx, realm, body, reqEditors...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.PostRealmClientScopesResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, api.ClientScopeRepresentation, ...api.RequestEditorFn) error); ok {
+		r1 = rf(ctx, realm, body, reqEditors...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockKeycloakClient_PostRealmClientScopesWithResponse_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PostRealmClientScopesWithResponse'
+type MockKeycloakClient_PostRealmClientScopesWithResponse_Call struct {
+	*mock.Call
+}
+
+// PostRealmClientScopesWithResponse is a helper method to define mock.On call
+//   - ctx context.Context
+//   - realm string
+//   - body api.ClientScopeRepresentation
+//   - reqEditors ...api.RequestEditorFn
+func (_e *MockKeycloakClient_Expecter) PostRealmClientScopesWithResponse(ctx interface{}, realm interface{}, body interface{}, reqEditors ...interface{}) *MockKeycloakClient_PostRealmClientScopesWithResponse_Call {
+	return &MockKeycloakClient_PostRealmClientScopesWithResponse_Call{Call: _e.mock.On("PostRealmClientScopesWithResponse",
+		append([]interface{}{ctx, realm, body}, reqEditors...)...)}
+}
+
+func (_c *MockKeycloakClient_PostRealmClientScopesWithResponse_Call) Run(run func(ctx context.Context, realm string, body api.ClientScopeRepresentation, reqEditors ...api.RequestEditorFn)) *MockKeycloakClient_PostRealmClientScopesWithResponse_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]api.RequestEditorFn, len(args)-3)
+		for i, a := range args[3:] {
+			if a != nil {
+				variadicArgs[i] = a.(api.RequestEditorFn)
+			}
+		}
+		run(args[0].(context.Context), args[1].(string), args[2].(api.ClientScopeRepresentation), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockKeycloakClient_PostRealmClientScopesWithResponse_Call) Return(_a0 *api.PostRealmClientScopesResponse, _a1 error) *MockKeycloakClient_PostRealmClientScopesWithResponse_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockKeycloakClient_PostRealmClientScopesWithResponse_Call) RunAndReturn(run func(context.Context, string, api.ClientScopeRepresentation, ...api.RequestEditorFn) (*api.PostRealmClientScopesResponse, error)) *MockKeycloakClient_PostRealmClientScopesWithResponse_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PostRealmClientsIdRolesWithResponse provides a mock function with given fields: ctx, realm, id, body, reqEditors
+func (_m *MockKeycloakClient) PostRealmClientsIdRolesWithResponse(ctx context.Context, realm string, id string, body api.RoleRepresentation, reqEditors ...api.RequestEditorFn) (*api.PostRealmClientsIdRolesResponse, error) {
+	_va := make([]interface{}, len(reqEditors))
+	for _i := range reqEditors {
+		_va[_i] = reqEditors[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, realm, id, body)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PostRealmClientsIdRolesWithResponse")
+	}
+
+	var r0 *api.PostRealmClientsIdRolesResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, api.RoleRepresentation, ...api.RequestEditorFn) (*api.PostRealmClientsIdRolesResponse, error)); ok {
+		return rf(ctx, realm, id, body, reqEditors...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, api.RoleRepresentation, ...api.RequestEditorFn) *api.PostRealmClientsIdRolesResponse); ok {
+		r0 = rf(ctx, realm, id, body, reqEditors...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.PostRealmClientsIdRolesResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, api.RoleRepresentation, ...api.RequestEditorFn) error); ok {
+		r1 = rf(ctx, realm, id, body, reqEditors...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockKeycloakClient_PostRealmClientsIdRolesWithResponse_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PostRealmClientsIdRolesWithResponse'
+type MockKeycloakClient_PostRealmClientsIdRolesWithResponse_Call struct {
+	*mock.Call
+}
+
+// PostRealmClientsIdRolesWithResponse is a helper method to define mock.On call
+//   - ctx context.Context
+//   - realm string
+//   - id string
+//   - body api.RoleRepresentation
+//   - reqEditors ...api.RequestEditorFn
+func (_e *MockKeycloakClient_Expecter) PostRealmClientsIdRolesWithResponse(ctx interface{}, realm interface{}, id interface{}, body interface{}, reqEditors ...interface{}) *MockKeycloakClient_PostRealmClientsIdRolesWithResponse_Call {
+	return &MockKeycloakClient_PostRealmClientsIdRolesWithResponse_Call{Call: _e.mock.On("PostRealmClientsIdRolesWithResponse",
+		append([]interface{}{ctx, realm, id, body}, reqEditors...)...)}
+}
+
+func (_c *MockKeycloakClient_PostRealmClientsIdRolesWithResponse_Call) Run(run func(ctx context.Context, realm string, id string, body api.RoleRepresentation, reqEditors ...api.RequestEditorFn)) *MockKeycloakClient_PostRealmClientsIdRolesWithResponse_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]api.RequestEditorFn, len(args)-4)
+		for i, a := range args[4:] {
+			if a != nil {
+				variadicArgs[i] = a.(api.RequestEditorFn)
+			}
+		}
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(api.RoleRepresentation), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockKeycloakClient_PostRealmClientsIdRolesWithResponse_Call) Return(_a0 *api.PostRealmClientsIdRolesResponse, _a1 error) *MockKeycloakClient_PostRealmClientsIdRolesWithResponse_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockKeycloakClient_PostRealmClientsIdRolesWithResponse_Call) RunAndReturn(run func(context.Context, string, string, api.RoleRepresentation, ...api.RequestEditorFn) (*api.PostRealmClientsIdRolesResponse, error)) *MockKeycloakClient_PostRealmClientsIdRolesWithResponse_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PostRealmClientsWithResponse provides a mock function with given fields: ctx, realm, body, reqEditors
+func (_m *MockKeycloakClient) PostRealmClientsWithResponse(ctx context.Context, realm string, body api.ClientRepresentation, reqEditors ...api.RequestEditorFn) (*api.PostRealmClientsResponse, error) {
+	_va := make([]interface{}, len(reqEditors))
+	for _i := range reqEditors {
+		_va[_i] = reqEditors[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, realm, body)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PostRealmClientsWithResponse")
+	}
+
+	var r0 *api.PostRealmClientsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, api.ClientRepresentation, ...api.RequestEditorFn) (*api.PostRealmClientsResponse, error)); ok {
+		return rf(ctx, realm, body, reqEditors...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, api.ClientRepresentation, ...api.RequestEditorFn) *api.PostRealmClientsResponse); ok {
+		r0 = rf(ctx, realm, body, reqEditors...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.PostRealmClientsResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, api.ClientRepresentation, ...api.RequestEditorFn) error); ok {
+		r1 = rf(ctx, realm, body, reqEditors...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockKeycloakClient_PostRealmClientsWithResponse_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PostRealmClientsWithResponse'
+type MockKeycloakClient_PostRealmClientsWithResponse_Call struct {
+	*mock.Call
+}
+
+// PostRealmClientsWithResponse is a helper method to define mock.On call
+//   - ctx context.Context
+//   - realm string
+//   - body api.ClientRepresentation
+//   - reqEditors ...api.RequestEditorFn
+func (_e *MockKeycloakClient_Expecter) PostRealmClientsWithResponse(ctx interface{}, realm interface{}, body interface{}, reqEditors ...interface{}) *MockKeycloakClient_PostRealmClientsWithResponse_Call {
+	return &MockKeycloakClient_PostRealmClientsWithResponse_Call{Call: _e.mock.On("PostRealmClientsWithResponse",
+		append([]interface{}{ctx, realm, body}, reqEditors...)...)}
+}
+
+func (_c *MockKeycloakClient_PostRealmClientsWithResponse_Call) Run(run func(ctx context.Context, realm string, body api.ClientRepresentation, reqEditors ...api.RequestEditorFn)) *MockKeycloakClient_PostRealmClientsWithResponse_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]api.RequestEditorFn, len(args)-3)
+		for i, a := range args[3:] {
+			if a != nil {
+				variadicArgs[i] = a.(api.RequestEditorFn)
+			}
+		}
+		run(args[0].(context.Context), args[1].(string), args[2].(api.ClientRepresentation), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockKeycloakClient_PostRealmClientsWithResponse_Call) Return(_a0 *api.PostRealmClientsResponse, _a1 error) *MockKeycloakClient_PostRealmClientsWithResponse_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockKeycloakClient_PostRealmClientsWithResponse_Call) RunAndReturn(run func(context.Context, string, api.ClientRepresentation, ...api.RequestEditorFn) (*api.PostRealmClientsResponse, error)) *MockKeycloakClient_PostRealmClientsWithResponse_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PostRealmIdentityProviderInstancesAliasMappersWithResponse provides a mock function with given fields: ctx, realm, alias, body, reqEditors
+func (_m *MockKeycloakClient) PostRealmIdentityProviderInstancesAliasMappersWithResponse(ctx context.Context, realm string, alias string, body api.IdentityProviderMapperRepresentation, reqEditors ...api.RequestEditorFn) (*api.PostRealmIdentityProviderInstancesAliasMappersResponse, error) {
+	_va := make([]interface{}, len(reqEditors))
+	for _i := range reqEditors {
+		_va[_i] = reqEditors[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, realm, alias, body)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PostRealmIdentityProviderInstancesAliasMappersWithResponse")
+	}
+
+	var r0 *api.PostRealmIdentityProviderInstancesAliasMappersResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, api.IdentityProviderMapperRepresentation, ...api.RequestEditorFn) (*api.PostRealmIdentityProviderInstancesAliasMappersResponse, error)); ok {
+		return rf(ctx, realm, alias, body, reqEditors...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, api.IdentityProviderMapperRepresentation, ...api.RequestEditorFn) *api.PostRealmIdentityProviderInstancesAliasMappersResponse); ok {
+		r0 = rf(ctx, realm, alias, body, reqEditors...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.PostRealmIdentityProviderInstancesAliasMappersResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, api.IdentityProviderMapperRepresentation, ...api.RequestEditorFn) error); ok {
+		r1 = rf(ctx, realm, alias, body, reqEditors...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockKeycloakClient_PostRealmIdentityProviderInstancesAliasMappersWithResponse_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PostRealmIdentityProviderInstancesAliasMappersWithResponse'
+type MockKeycloakClient_PostRealmIdentityProviderInstancesAliasMappersWithResponse_Call struct {
+	*mock.Call
+}
+
+// PostRealmIdentityProviderInstancesAliasMappersWithResponse is a helper method to define mock.On call
+//   - ctx context.Context
+//   - realm string
+//   - alias string
+//   - body api.IdentityProviderMapperRepresentation
+//   - reqEditors ...api.RequestEditorFn
+func (_e *MockKeycloakClient_Expecter) PostRealmIdentityProviderInstancesAliasMappersWithResponse(ctx interface{}, realm interface{}, alias interface{}, body interface{}, reqEditors ...interface{}) *MockKeycloakClient_PostRealmIdentityProviderInstancesAliasMappersWithResponse_Call {
+	return &MockKeycloakClient_PostRealmIdentityProviderInstancesAliasMappersWithResponse_Call{Call: _e.mock.On("PostRealmIdentityProviderInstancesAliasMappersWithResponse",
+		append([]interface{}{ctx, realm, alias, body}, reqEditors...)...)}
+}
+
+func (_c *MockKeycloakClient_PostRealmIdentityProviderInstancesAliasMappersWithResponse_Call) Run(run func(ctx context.Context, realm string, alias string, body api.IdentityProviderMapperRepresentation, reqEditors ...api.RequestEditorFn)) *MockKeycloakClient_PostRealmIdentityProviderInstancesAliasMappersWithResponse_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]api.RequestEditorFn, len(args)-4)
+		for i, a := range args[4:] {
+			if a != nil {
+				variadicArgs[i] = a.(api.RequestEditorFn)
+			}
+		}
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(api.IdentityProviderMapperRepresentation), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockKeycloakClient_PostRealmIdentityProviderInstancesAliasMappersWithResponse_Call) Return(_a0 *api.PostRealmIdentityProviderInstancesAliasMappersResponse, _a1 error) *MockKeycloakClient_PostRealmIdentityProviderInstancesAliasMappersWithResponse_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockKeycloakClient_PostRealmIdentityProviderInstancesAliasMappersWithResponse_Call) RunAndReturn(run func(context.Context, string, string, api.IdentityProviderMapperRepresentation, ...api.RequestEditorFn) (*api.PostRealmIdentityProviderInstancesAliasMappersResponse, error)) *MockKeycloakClient_PostRealmIdentityProviderInstancesAliasMappersWithResponse_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PostRealmIdentityProviderInstancesWithResponse provides a mock function with given fields: ctx, realm, body, reqEditors
+func (_m *MockKeycloakClient) PostRealmIdentityProviderInstancesWithResponse(ctx context.Context, realm string, body api.IdentityProviderRepresentation, reqEditors ...api.RequestEditorFn) (*api.PostRealmIdentityProviderInstancesResponse, error) {
+	_va := make([]interface{}, len(reqEditors))
+	for _i := range reqEditors {
+		_va[_i] = reqEditors[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, realm, body)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PostRealmIdentityProviderInstancesWithResponse")
+	}
+
+	var r0 *api.PostRealmIdentityProviderInstancesResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, api.IdentityProviderRepresentation, ...api.RequestEditorFn) (*api.PostRealmIdentityProviderInstancesResponse, error)); ok {
+		return rf(ctx, realm, body, reqEditors...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, api.IdentityProviderRepresentation, ...api.RequestEditorFn) *api.PostRealmIdentityProviderInstancesResponse); ok {
+		r0 = rf(ctx, realm, body, reqEditors...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.PostRealmIdentityProviderInstancesResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, api.IdentityProviderRepresentation, ...api.RequestEditorFn) error); ok {
+		r1 = rf(ctx, realm, body, reqEditors...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockKeycloakClient_PostRealmIdentityProviderInstancesWithResponse_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PostRealmIdentityProviderInstancesWithResponse'
+type MockKeycloakClient_PostRealmIdentityProviderInstancesWithResponse_Call struct {
+	*mock.Call
+}
+
+// PostRealmIdentityProviderInstancesWithResponse is a helper method to define mock.On call
+//   - ctx context.Context
+//   - realm string
+//   - body api.IdentityProviderRepresentation
+//   - reqEditors ...api.RequestEditorFn
+func (_e *MockKeycloakClient_Expecter) PostRealmIdentityProviderInstancesWithResponse(ctx interface{}, realm interface{}, body interface{}, reqEditors ...interface{}) *MockKeycloakClient_PostRealmIdentityProviderInstancesWithResponse_Call {
+	return &MockKeycloakClient_PostRealmIdentityProviderInstancesWithResponse_Call{Call: _e.mock.On("PostRealmIdentityProviderInstancesWithResponse",
+		append([]interface{}{ctx, realm, body}, reqEditors...)...)}
+}
+
+func (_c *MockKeycloakClient_PostRealmIdentityProviderInstancesWithResponse_Call) Run(run func(ctx context.Context, realm string, body api.IdentityProviderRepresentation, reqEditors ...api.RequestEditorFn)) *MockKeycloakClient_PostRealmIdentityProviderInstancesWithResponse_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]api.RequestEditorFn, len(args)-3)
+		for i, a := range args[3:] {
+			if a != nil {
+				variadicArgs[i] = a.(api.RequestEditorFn)
+			}
+		}
+		run(args[0].(context.Context), args[1].(string), args[2].(api.IdentityProviderRepresentation), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockKeycloakClient_PostRealmIdentityProviderInstancesWithResponse_Call) Return(_a0 *api.PostRealmIdentityProviderInstancesResponse, _a1 error) *MockKeycloakClient_PostRealmIdentityProviderInstancesWithResponse_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockKeycloakClient_PostRealmIdentityProviderInstancesWithResponse_Call) RunAndReturn(run func(context.Context, string, api.IdentityProviderRepresentation, ...api.RequestEditorFn) (*api.PostRealmIdentityProviderInstancesResponse, error)) *MockKeycloakClient_PostRealmIdentityProviderInstancesWithResponse_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PostRealmRolesWithResponse provides a mock function with given fields: ctx, realm, body, reqEditors
+func (_m *MockKeycloakClient) PostRealmRolesWithResponse(ctx context.Context, realm string, body api.RoleRepresentation, reqEditors ...api.RequestEditorFn) (*api.PostRealmRolesResponse, error) {
+	_va := make([]interface{}, len(reqEditors))
+	for _i := range reqEditors {
+		_va[_i] = reqEditors[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, realm, body)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PostRealmRolesWithResponse")
+	}
+
+	var r0 *api.PostRealmRolesResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, api.RoleRepresentation, ...api.RequestEditorFn) (*api.PostRealmRolesResponse, error)); ok {
+		return rf(ctx, realm, body, reqEditors...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, api.RoleRepresentation, ...api.RequestEditorFn) *api.PostRealmRolesResponse); ok {
+		r0 = rf(ctx, realm, body, reqEditors...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.PostRealmRolesResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, api.RoleRepresentation, ...api.RequestEditorFn) error); ok {
+		r1 = rf(ctx, realm, body, reqEditors...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockKeycloakClient_PostRealmRolesWithResponse_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PostRealmRolesWithResponse'
+type MockKeycloakClient_PostRealmRolesWithResponse_Call struct {
+	*mock.Call
+}
+
+// PostRealmRolesWithResponse is a helper method to define mock.On call
+//   - ctx context.Context
+//   - realm string
+//   - body api.RoleRepresentation
+//   - reqEditors ...api.RequestEditorFn
+func (_e *MockKeycloakClient_Expecter) PostRealmRolesWithResponse(ctx interface{}, realm interface{}, body interface{}, reqEditors ...interface{}) *MockKeycloakClient_PostRealmRolesWithResponse_Call {
+	return &MockKeycloakClient_PostRealmRolesWithResponse_Call{Call: _e.mock.On("PostRealmRolesWithResponse",
+		append([]interface{}{ctx, realm, body}, reqEditors...)...)}
+}
+
+func (_c *MockKeycloakClient_PostRealmRolesWithResponse_Call) Run(run func(ctx context.Context, realm string, body api.RoleRepresentation, reqEditors ...api.RequestEditorFn)) *MockKeycloakClient_PostRealmRolesWithResponse_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]api.RequestEditorFn, len(args)-3)
+		for i, a := range args[3:] {
+			if a != nil {
+				variadicArgs[i] = a.(api.RequestEditorFn)
+			}
+		}
+		run(args[0].(context.Context), args[1].(string), args[2].(api.RoleRepresentation), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockKeycloakClient_PostRealmRolesWithResponse_Call) Return(_a0 *api.PostRealmRolesResponse, _a1 error) *MockKeycloakClient_PostRealmRolesWithResponse_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockKeycloakClient_PostRealmRolesWithResponse_Call) RunAndReturn(run func(context.Context, string, api.RoleRepresentation, ...api.RequestEditorFn) (*api.PostRealmRolesResponse, error)) *MockKeycloakClient_PostRealmRolesWithResponse_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PostRealmUsersIdRoleMappingsClientsClientWithResponse provides a mock function with given fields: ctx, realm, id, client, body, reqEditors
+func (_m *MockKeycloakClient) PostRealmUsersIdRoleMappingsClientsClientWithResponse(ctx context.Context, realm string, id string, client string, body []api.RoleRepresentation, reqEditors ...api.RequestEditorFn) (*api.PostRealmUsersIdRoleMappingsClientsClientResponse, error) {
+	_va := make([]interface{}, len(reqEditors))
+	for _i := range reqEditors {
+		_va[_i] = reqEditors[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, realm, id, client, body)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PostRealmUsersIdRoleMappingsClientsClientWithResponse")
+	}
+
+	var r0 *api.PostRealmUsersIdRoleMappingsClientsClientResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, []api.RoleRepresentation, ...api.RequestEditorFn) (*api.PostRealmUsersIdRoleMappingsClientsClientResponse, error)); ok {
+		return rf(ctx, realm, id, client, body, reqEditors...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, []api.RoleRepresentation, ...api.RequestEditorFn) *api.PostRealmUsersIdRoleMappingsClientsClientResponse); ok {
+		r0 = rf(ctx, realm, id, client, body, reqEditors...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.PostRealmUsersIdRoleMappingsClientsClientResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, []api.RoleRepresentation, ...api.RequestEditorFn) error); ok {
+		r1 = rf(ctx, realm, id, client, body, reqEditors...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockKeycloakClient_PostRealmUsersIdRoleMappingsClientsClientWithResponse_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PostRealmUsersIdRoleMappingsClientsClientWithResponse'
+type MockKeycloakClient_PostRealmUsersIdRoleMappingsClientsClientWithResponse_Call struct {
+	*mock.Call
+}
+
+// PostRealmUsersIdRoleMappingsClientsClientWithResponse is a helper method to define mock.On call
+//   - ctx context.Context
+//   - realm string
+//   - id string
+//   - client string
+//   - body []api.RoleRepresentation
+//   - reqEditors ...api.RequestEditorFn
+func (_e *MockKeycloakClient_Expecter) PostRealmUsersIdRoleMappingsClientsClientWithResponse(ctx interface{}, realm interface{}, id interface{}, client interface{}, body interface{}, reqEditors ...interface{}) *MockKeycloakClient_PostRealmUsersIdRoleMappingsClientsClientWithResponse_Call {
+	return &MockKeycloakClient_PostRealmUsersIdRoleMappingsClientsClientWithResponse_Call{Call: _e.mock.On("PostRealmUsersIdRoleMappingsClientsClientWithResponse",
+		append([]interface{}{ctx, realm, id, client, body}, reqEditors...)...)}
+}
+
+func (_c *MockKeycloakClient_PostRealmUsersIdRoleMappingsClientsClientWithResponse_Call) Run(run func(ctx context.Context, realm string, id string, client string, body []api.RoleRepresentation, reqEditors ...api.RequestEditorFn)) *MockKeycloakClient_PostRealmUsersIdRoleMappingsClientsClientWithResponse_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]api.RequestEditorFn, len(args)-5)
+		for i, a := range args[5:] {
+			if a != nil {
+				variadicArgs[i] = a.(api.RequestEditorFn)
+			}
+		}
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string), args[4].([]api.RoleRepresentation), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockKeycloakClient_PostRealmUsersIdRoleMappingsClientsClientWithResponse_Call) Return(_a0 *api.PostRealmUsersIdRoleMappingsClientsClientResponse, _a1 error) *MockKeycloakClient_PostRealmUsersIdRoleMappingsClientsClientWithResponse_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockKeycloakClient_PostRealmUsersIdRoleMappingsClientsClientWithResponse_Call) RunAndReturn(run func(context.Context, string, string, string, []api.RoleRepresentation, ...api.RequestEditorFn) (*api.PostRealmUsersIdRoleMappingsClientsClientResponse, error)) *MockKeycloakClient_PostRealmUsersIdRoleMappingsClientsClientWithResponse_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PostRealmUsersIdRoleMappingsRealmWithResponse provides a mock function with given fields: ctx, realm, id, body, reqEditors
+func (_m *MockKeycloakClient) PostRealmUsersIdRoleMappingsRealmWithResponse(ctx context.Context, realm string, id string, body []api.RoleRepresentation, reqEditors ...api.RequestEditorFn) (*api.PostRealmUsersIdRoleMappingsRealmResponse, error) {
+	_va := make([]interface{}, len(reqEditors))
+	for _i := range reqEditors {
+		_va[_i] = reqEditors[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, realm, id, body)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PostRealmUsersIdRoleMappingsRealmWithResponse")
+	}
+
+	var r0 *api.PostRealmUsersIdRoleMappingsRealmResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, []api.RoleRepresentation, ...api.RequestEditorFn) (*api.PostRealmUsersIdRoleMappingsRealmResponse, error)); ok {
+		return rf(ctx, realm, id, body, reqEditors...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, []api.RoleRepresentation, ...api.RequestEditorFn) *api.PostRealmUsersIdRoleMappingsRealmResponse); ok {
+		r0 = rf(ctx, realm, id, body, reqEditors...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.PostRealmUsersIdRoleMappingsRealmResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, []api.RoleRepresentation, ...api.RequestEditorFn) error); ok {
+		r1 = rf(ctx, realm, id, body, reqEditors...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockKeycloakClient_PostRealmUsersIdRoleMappingsRealmWithResponse_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PostRealmUsersIdRoleMappingsRealmWithResponse'
+type MockKeycloakClient_PostRealmUsersIdRoleMappingsRealmWithResponse_Call struct {
+	*mock.Call
+}
+
+// PostRealmUsersIdRoleMappingsRealmWithResponse is a helper method to define mock.On call
+//   - ctx context.Context
+//   - realm string
+//   - id string
+//   - body []api.RoleRepresentation
+//   - reqEditors ...api.RequestEditorFn
+func (_e *MockKeycloakClient_Expecter) PostRealmUsersIdRoleMappingsRealmWithResponse(ctx interface{}, realm interface{}, id interface{}, body interface{}, reqEditors ...interface{}) *MockKeycloakClient_PostRealmUsersIdRoleMappingsRealmWithResponse_Call {
+	return &MockKeycloakClient_PostRealmUsersIdRoleMappingsRealmWithResponse_Call{Call: _e.mock.On("PostRealmUsersIdRoleMappingsRealmWithResponse",
+		append([]interface{}{ctx, realm, id, body}, reqEditors...)...)}
+}
+
+func (_c *MockKeycloakClient_PostRealmUsersIdRoleMappingsRealmWithResponse_Call) Run(run func(ctx context.Context, realm string, id string, body []api.RoleRepresentation, reqEditors ...api.RequestEditorFn)) *MockKeycloakClient_PostRealmUsersIdRoleMappingsRealmWithResponse_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]api.RequestEditorFn, len(args)-4)
+		for i, a := range args[4:] {
+			if a != nil {
+				variadicArgs[i] = a.(api.RequestEditorFn)
+			}
+		}
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].([]api.RoleRepresentation), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockKeycloakClient_PostRealmUsersIdRoleMappingsRealmWithResponse_Call) Return(_a0 *api.PostRealmUsersIdRoleMappingsRealmResponse, _a1 error) *MockKeycloakClient_PostRealmUsersIdRoleMappingsRealmWithResponse_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockKeycloakClient_PostRealmUsersIdRoleMappingsRealmWithResponse_Call) RunAndReturn(run func(context.Context, string, string, []api.RoleRepresentation, ...api.RequestEditorFn) (*api.PostRealmUsersIdRoleMappingsRealmResponse, error)) *MockKeycloakClient_PostRealmUsersIdRoleMappingsRealmWithResponse_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PostWithResponse provides a mock function with given fields: ctx, body, reqEditors
+func (_m *MockKeycloakClient) PostWithResponse(ctx context.Context, body api.RealmRepresentation, reqEditors ...api.RequestEditorFn) (*api.PostResponse, error) {
+	_va := make([]interface{}, len(reqEditors))
+	for _i := range reqEditors {
+		_va[_i] = reqEditors[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, body)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PostWithResponse")
+	}
+
+	var r0 *api.PostResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, api.RealmRepresentation, ...api.RequestEditorFn) (*api.PostResponse, error)); ok {
+		return rf(ctx, body, reqEditors...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, api.RealmRepresentation, ...api.RequestEditorFn) *api.PostResponse); ok {
+		r0 = rf(ctx, body, reqEditors...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.PostResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, api.RealmRepresentation, ...api.RequestEditorFn) error); ok {
+		r1 = rf(ctx, body, reqEditors...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockKeycloakClient_PostWithResponse_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PostWithResponse'
+type MockKeycloakClient_PostWithResponse_Call struct {
+	*mock.Call
+}
+
+// PostWithResponse is a helper method to define mock.On call
+//   - ctx context.Context
+//   - body api.RealmRepresentation
+//   - reqEditors ...api.RequestEditorFn
+func (_e *MockKeycloakClient_Expecter) PostWithResponse(ctx interface{}, body interface{}, reqEditors ...interface{}) *MockKeycloakClient_PostWithResponse_Call {
+	return &MockKeycloakClient_PostWithResponse_Call{Call: _e.mock.On("PostWithResponse",
+		append([]interface{}{ctx, body}, reqEditors...)...)}
+}
+
+func (_c *MockKeycloakClient_PostWithResponse_Call) Run(run func(ctx context.Context, body api.RealmRepresentation, reqEditors ...api.RequestEditorFn)) *MockKeycloakClient_PostWithResponse_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]api.RequestEditorFn, len(args)-2)
+		for i, a := range args[2:] {
+			if a != nil {
+				variadicArgs[i] = a.(api.RequestEditorFn)
+			}
+		}
+		run(args[0].(context.Context), args[1].(api.RealmRepresentation), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockKeycloakClient_PostWithResponse_Call) Return(_a0 *api.PostResponse, _a1 error) *MockKeycloakClient_PostWithResponse_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockKeycloakClient_PostWithResponse_Call) RunAndReturn(run func(context.Context, api.RealmRepresentation, ...api.RequestEditorFn) (*api.PostResponse, error)) *MockKeycloakClient_PostWithResponse_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PutRealmClientScopesIdWithResponse provides a mock function with given fields: ctx, realm, id, body, reqEditors
+func (_m *MockKeycloakClient) PutRealmClientScopesIdWithResponse(ctx context.Context, realm string, id string, body api.ClientScopeRepresentation, reqEditors ...api.RequestEditorFn) (*api.PutRealmClientScopesIdResponse, error) {
+	_va := make([]interface{}, len(reqEditors))
+	for _i := range reqEditors {
+		_va[_i] = reqEditors[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, realm, id, body)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PutRealmClientScopesIdWithResponse")
+	}
+
+	var r0 *api.PutRealmClientScopesIdResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, api.ClientScopeRepresentation, ...api.RequestEditorFn) (*api.PutRealmClientScopesIdResponse, error)); ok {
+		return rf(ctx, realm, id, body, reqEditors...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, api.ClientScopeRepresentation, ...api.RequestEditorFn) *api.PutRealmClientScopesIdResponse); ok {
+		r0 = rf(ctx, realm, id, body, reqEditors...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.PutRealmClientScopesIdResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, api.ClientScopeRepresentation, ...api.RequestEditorFn) error); ok {
+		r1 = rf(ctx, realm, id, body, reqEditors...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockKeycloakClient_PutRealmClientScopesIdWithResponse_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PutRealmClientScopesIdWithResponse'
+type MockKeycloakClient_PutRealmClientScopesIdWithResponse_Call struct {
+	*mock.Call
+}
+
+// PutRealmClientScopesIdWithResponse is a helper method to define mock.On call
+//   - ctx context.Context
+//   - realm string
+//   - id string
+//   - body api.ClientScopeRepresentation
+//   - reqEditors ...api.RequestEditorFn
+func (_e *MockKeycloakClient_Expecter) PutRealmClientScopesIdWithResponse(ctx interface{}, realm interface{}, id interface{}, body interface{}, reqEditors ...interface{}) *MockKeycloakClient_PutRealmClientScopesIdWithResponse_Call {
+	return &MockKeycloakClient_PutRealmClientScopesIdWithResponse_Call{Call: _e.mock.On("PutRealmClientScopesIdWithResponse",
+		append([]interface{}{ctx, realm, id, body}, reqEditors...)...)}
+}
+
+func (_c *MockKeycloakClient_PutRealmClientScopesIdWithResponse_Call) Run(run func(ctx context.Context, realm string, id string, body api.ClientScopeRepresentation, reqEditors ...api.RequestEditorFn)) *MockKeycloakClient_PutRealmClientScopesIdWithResponse_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]api.RequestEditorFn, len(args)-4)
+		for i, a := range args[4:] {
+			if a != nil {
+				variadicArgs[i] = a.(api.RequestEditorFn)
+			}
+		}
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(api.ClientScopeRepresentation), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockKeycloakClient_PutRealmClientScopesIdWithResponse_Call) Return(_a0 *api.PutRealmClientScopesIdResponse, _a1 error) *MockKeycloakClient_PutRealmClientScopesIdWithResponse_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockKeycloakClient_PutRealmClientScopesIdWithResponse_Call) RunAndReturn(run func(context.Context, string, string, api.ClientScopeRepresentation, ...api.RequestEditorFn) (*api.PutRealmClientScopesIdResponse, error)) *MockKeycloakClient_PutRealmClientScopesIdWithResponse_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PutRealmClientsIdRolesRoleNameWithResponse provides a mock function with given fields: ctx, realm, id, roleName, body, reqEditors
+func (_m *MockKeycloakClient) PutRealmClientsIdRolesRoleNameWithResponse(ctx context.Context, realm string, id string, roleName string, body api.RoleRepresentation, reqEditors ...api.RequestEditorFn) (*api.PutRealmClientsIdRolesRoleNameResponse, error) {
+	_va := make([]interface{}, len(reqEditors))
+	for _i := range reqEditors {
+		_va[_i] = reqEditors[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, realm, id, roleName, body)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PutRealmClientsIdRolesRoleNameWithResponse")
+	}
+
+	var r0 *api.PutRealmClientsIdRolesRoleNameResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, api.RoleRepresentation, ...api.RequestEditorFn) (*api.PutRealmClientsIdRolesRoleNameResponse, error)); ok {
+		return rf(ctx, realm, id, roleName, body, reqEditors...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, api.RoleRepresentation, ...api.RequestEditorFn) *api.PutRealmClientsIdRolesRoleNameResponse); ok {
+		r0 = rf(ctx, realm, id, roleName, body, reqEditors...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.PutRealmClientsIdRolesRoleNameResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, api.RoleRepresentation, ...api.RequestEditorFn) error); ok {
+		r1 = rf(ctx, realm, id, roleName, body, reqEditors...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockKeycloakClient_PutRealmClientsIdRolesRoleNameWithResponse_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PutRealmClientsIdRolesRoleNameWithResponse'
+type MockKeycloakClient_PutRealmClientsIdRolesRoleNameWithResponse_Call struct {
+	*mock.Call
+}
+
+// PutRealmClientsIdRolesRoleNameWithResponse is a helper method to define mock.On call
+//   - ctx context.Context
+//   - realm string
+//   - id string
+//   - roleName string
+//   - body api.RoleRepresentation
+//   - reqEditors ...api.RequestEditorFn
+func (_e *MockKeycloakClient_Expecter) PutRealmClientsIdRolesRoleNameWithResponse(ctx interface{}, realm interface{}, id interface{}, roleName interface{}, body interface{}, reqEditors ...interface{}) *MockKeycloakClient_PutRealmClientsIdRolesRoleNameWithResponse_Call {
+	return &MockKeycloakClient_PutRealmClientsIdRolesRoleNameWithResponse_Call{Call: _e.mock.On("PutRealmClientsIdRolesRoleNameWithResponse",
+		append([]interface{}{ctx, realm, id, roleName, body}, reqEditors...)...)}
+}
+
+func (_c *MockKeycloakClient_PutRealmClientsIdRolesRoleNameWithResponse_Call) Run(run func(ctx context.Context, realm string, id string, roleName string, body api.RoleRepresentation, reqEditors ...api.RequestEditorFn)) *MockKeycloakClient_PutRealmClientsIdRolesRoleNameWithResponse_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]api.RequestEditorFn, len(args)-5)
+		for i, a := range args[5:] {
+			if a != nil {
+				variadicArgs[i] = a.(api.RequestEditorFn)
+			}
+		}
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string), args[4].(api.RoleRepresentation), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockKeycloakClient_PutRealmClientsIdRolesRoleNameWithResponse_Call) Return(_a0 *api.PutRealmClientsIdRolesRoleNameResponse, _a1 error) *MockKeycloakClient_PutRealmClientsIdRolesRoleNameWithResponse_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockKeycloakClient_PutRealmClientsIdRolesRoleNameWithResponse_Call) RunAndReturn(run func(context.Context, string, string, string, api.RoleRepresentation, ...api.RequestEditorFn) (*api.PutRealmClientsIdRolesRoleNameResponse, error)) *MockKeycloakClient_PutRealmClientsIdRolesRoleNameWithResponse_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PutRealmClientsIdWithResponse provides a mock function with given fields: ctx, realm, id, body, reqEditors
+func (_m *MockKeycloakClient) PutRealmClientsIdWithResponse(ctx context.Context, realm string, id string, body api.ClientRepresentation, reqEditors ...api.RequestEditorFn) (*api.PutRealmClientsIdResponse, error) {
+	_va := make([]interface{}, len(reqEditors))
+	for _i := range reqEditors {
+		_va[_i] = reqEditors[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, realm, id, body)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PutRealmClientsIdWithResponse")
+	}
+
+	var r0 *api.PutRealmClientsIdResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, api.ClientRepresentation, ...api.RequestEditorFn) (*api.PutRealmClientsIdResponse, error)); ok {
+		return rf(ctx, realm, id, body, reqEditors...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, api.ClientRepresentation, ...api.RequestEditorFn) *api.PutRealmClientsIdResponse); ok {
+		r0 = rf(ctx, realm, id, body, reqEditors...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.PutRealmClientsIdResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, api.ClientRepresentation, ...api.RequestEditorFn) error); ok {
+		r1 = rf(ctx, realm, id, body, reqEditors...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockKeycloakClient_PutRealmClientsIdWithResponse_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PutRealmClientsIdWithResponse'
+type MockKeycloakClient_PutRealmClientsIdWithResponse_Call struct {
+	*mock.Call
+}
+
+// PutRealmClientsIdWithResponse is a helper method to define mock.On call
+//   - ctx context.Context
+//   - realm string
+//   - id string
+//   - body api.ClientRepresentation
+//   - reqEditors ...api.RequestEditorFn
+func (_e *MockKeycloakClient_Expecter) PutRealmClientsIdWithResponse(ctx interface{}, realm interface{}, id interface{}, body interface{}, reqEditors ...interface{}) *MockKeycloakClient_PutRealmClientsIdWithResponse_Call {
+	return &MockKeycloakClient_PutRealmClientsIdWithResponse_Call{Call: _e.mock.On("PutRealmClientsIdWithResponse",
+		append([]interface{}{ctx, realm, id, body}, reqEditors...)...)}
+}
+
+func (_c *MockKeycloakClient_PutRealmClientsIdWithResponse_Call) Run(run func(ctx context.Context, realm string, id string, body api.ClientRepresentation, reqEditors ...api.RequestEditorFn)) *MockKeycloakClient_PutRealmClientsIdWithResponse_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]api.RequestEditorFn, len(args)-4)
+		for i, a := range args[4:] {
+			if a != nil {
+				variadicArgs[i] = a.(api.RequestEditorFn)
+			}
+		}
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(api.ClientRepresentation), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockKeycloakClient_PutRealmClientsIdWithResponse_Call) Return(_a0 *api.PutRealmClientsIdResponse, _a1 error) *MockKeycloakClient_PutRealmClientsIdWithResponse_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockKeycloakClient_PutRealmClientsIdWithResponse_Call) RunAndReturn(run func(context.Context, string, string, api.ClientRepresentation, ...api.RequestEditorFn) (*api.PutRealmClientsIdResponse, error)) *MockKeycloakClient_PutRealmClientsIdWithResponse_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PutRealmIdentityProviderInstancesAliasMappersIdWithResponse provides a mock function with given fields: ctx, realm, alias, id, body, reqEditors
+func (_m *MockKeycloakClient) PutRealmIdentityProviderInstancesAliasMappersIdWithResponse(ctx context.Context, realm string, alias string, id string, body api.IdentityProviderMapperRepresentation, reqEditors ...api.RequestEditorFn) (*api.PutRealmIdentityProviderInstancesAliasMappersIdResponse, error) {
+	_va := make([]interface{}, len(reqEditors))
+	for _i := range reqEditors {
+		_va[_i] = reqEditors[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, realm, alias, id, body)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PutRealmIdentityProviderInstancesAliasMappersIdWithResponse")
+	}
+
+	var r0 *api.PutRealmIdentityProviderInstancesAliasMappersIdResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, api.IdentityProviderMapperRepresentation, ...api.RequestEditorFn) (*api.PutRealmIdentityProviderInstancesAliasMappersIdResponse, error)); ok {
+		return rf(ctx, realm, alias, id, body, reqEditors...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, api.IdentityProviderMapperRepresentation, ...api.RequestEditorFn) *api.PutRealmIdentityProviderInstancesAliasMappersIdResponse); ok {
+		r0 = rf(ctx, realm, alias, id, body, reqEditors...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.PutRealmIdentityProviderInstancesAliasMappersIdResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, api.IdentityProviderMapperRepresentation, ...api.RequestEditorFn) error); ok {
+		r1 = rf(ctx, realm, alias, id, body, reqEditors...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockKeycloakClient_PutRealmIdentityProviderInstancesAliasMappersIdWithResponse_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PutRealmIdentityProviderInstancesAliasMappersIdWithResponse'
+type MockKeycloakClient_PutRealmIdentityProviderInstancesAliasMappersIdWithResponse_Call struct {
+	*mock.Call
+}
+
+// PutRealmIdentityProviderInstancesAliasMappersIdWithResponse is a helper method to define mock.On call
+//   - ctx context.Context
+//   - realm string
+//   - alias string
+//   - id string
+//   - body api.IdentityProviderMapperRepresentation
+//   - reqEditors ...api.RequestEditorFn
+func (_e *MockKeycloakClient_Expecter) PutRealmIdentityProviderInstancesAliasMappersIdWithResponse(ctx interface{}, realm interface{}, alias interface{}, id interface{}, body interface{}, reqEditors ...interface{}) *MockKeycloakClient_PutRealmIdentityProviderInstancesAliasMappersIdWithResponse_Call {
+	return &MockKeycloakClient_PutRealmIdentityProviderInstancesAliasMappersIdWithResponse_Call{Call: _e.mock.On("PutRealmIdentityProviderInstancesAliasMappersIdWithResponse",
+		append([]interface{}{ctx, realm, alias, id, body}, reqEditors...)...)}
+}
+
+func (_c *MockKeycloakClient_PutRealmIdentityProviderInstancesAliasMappersIdWithResponse_Call) Run(run func(ctx context.Context, realm string, alias string, id string, body api.IdentityProviderMapperRepresentation, reqEditors ...api.RequestEditorFn)) *MockKeycloakClient_PutRealmIdentityProviderInstancesAliasMappersIdWithResponse_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]api.RequestEditorFn, len(args)-5)
+		for i, a := range args[5:] {
+			if a != nil {
+				variadicArgs[i] = a.(api.RequestEditorFn)
+			}
+		}
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string), args[4].(api.IdentityProviderMapperRepresentation), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockKeycloakClient_PutRealmIdentityProviderInstancesAliasMappersIdWithResponse_Call) Return(_a0 *api.PutRealmIdentityProviderInstancesAliasMappersIdResponse, _a1 error) *MockKeycloakClient_PutRealmIdentityProviderInstancesAliasMappersIdWithResponse_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockKeycloakClient_PutRealmIdentityProviderInstancesAliasMappersIdWithResponse_Call) RunAndReturn(run func(context.Context, string, string, string, api.IdentityProviderMapperRepresentation, ...api.RequestEditorFn) (*api.PutRealmIdentityProviderInstancesAliasMappersIdResponse, error)) *MockKeycloakClient_PutRealmIdentityProviderInstancesAliasMappersIdWithResponse_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PutRealmIdentityProviderInstancesAliasWithResponse provides a mock function with given fields: ctx, realm, alias, body, reqEditors
+func (_m *MockKeycloakClient) PutRealmIdentityProviderInstancesAliasWithResponse(ctx context.Context, realm string, alias string, body api.IdentityProviderRepresentation, reqEditors ...api.RequestEditorFn) (*api.PutRealmIdentityProviderInstancesAliasResponse, error) {
+	_va := make([]interface{}, len(reqEditors))
+	for _i := range reqEditors {
+		_va[_i] = reqEditors[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, realm, alias, body)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PutRealmIdentityProviderInstancesAliasWithResponse")
+	}
+
+	var r0 *api.PutRealmIdentityProviderInstancesAliasResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, api.IdentityProviderRepresentation, ...api.RequestEditorFn) (*api.PutRealmIdentityProviderInstancesAliasResponse, error)); ok {
+		return rf(ctx, realm, alias, body, reqEditors...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, api.IdentityProviderRepresentation, ...api.RequestEditorFn) *api.PutRealmIdentityProviderInstancesAliasResponse); ok {
+		r0 = rf(ctx, realm, alias, body, reqEditors...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.PutRealmIdentityProviderInstancesAliasResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, api.IdentityProviderRepresentation, ...api.RequestEditorFn) error); ok {
+		r1 = rf(ctx, realm, alias, body, reqEditors...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockKeycloakClient_PutRealmIdentityProviderInstancesAliasWithResponse_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PutRealmIdentityProviderInstancesAliasWithResponse'
+type MockKeycloakClient_PutRealmIdentityProviderInstancesAliasWithResponse_Call struct {
+	*mock.Call
+}
+
+// PutRealmIdentityProviderInstancesAliasWithResponse is a helper method to define mock.On call
+//   - ctx context.Context
+//   - realm string
+//   - alias string
+//   - body api.IdentityProviderRepresentation
+//   - reqEditors ...api.RequestEditorFn
+func (_e *MockKeycloakClient_Expecter) PutRealmIdentityProviderInstancesAliasWithResponse(ctx interface{}, realm interface{}, alias interface{}, body interface{}, reqEditors ...interface{}) *MockKeycloakClient_PutRealmIdentityProviderInstancesAliasWithResponse_Call {
+	return &MockKeycloakClient_PutRealmIdentityProviderInstancesAliasWithResponse_Call{Call: _e.mock.On("PutRealmIdentityProviderInstancesAliasWithResponse",
+		append([]interface{}{ctx, realm, alias, body}, reqEditors...)...)}
+}
+
+func (_c *MockKeycloakClient_PutRealmIdentityProviderInstancesAliasWithResponse_Call) Run(run func(ctx context.Context, realm string, alias string, body api.IdentityProviderRepresentation, reqEditors ...api.RequestEditorFn)) *MockKeycloakClient_PutRealmIdentityProviderInstancesAliasWithResponse_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]api.RequestEditorFn, len(args)-4)
+		for i, a := range args[4:] {
+			if a != nil {
+				variadicArgs[i] = a.(api.RequestEditorFn)
+			}
+		}
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(api.IdentityProviderRepresentation), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockKeycloakClient_PutRealmIdentityProviderInstancesAliasWithResponse_Call) Return(_a0 *api.PutRealmIdentityProviderInstancesAliasResponse, _a1 error) *MockKeycloakClient_PutRealmIdentityProviderInstancesAliasWithResponse_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockKeycloakClient_PutRealmIdentityProviderInstancesAliasWithResponse_Call) RunAndReturn(run func(context.Context, string, string, api.IdentityProviderRepresentation, ...api.RequestEditorFn) (*api.PutRealmIdentityProviderInstancesAliasResponse, error)) *MockKeycloakClient_PutRealmIdentityProviderInstancesAliasWithResponse_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PutRealmRolesRoleNameWithResponse provides a mock function with given fields: ctx, realm, roleName, body, reqEditors
+func (_m *MockKeycloakClient) PutRealmRolesRoleNameWithResponse(ctx context.Context, realm string, roleName string, body api.RoleRepresentation, reqEditors ...api.RequestEditorFn) (*api.PutRealmRolesRoleNameResponse, error) {
+	_va := make([]interface{}, len(reqEditors))
+	for _i := range reqEditors {
+		_va[_i] = reqEditors[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, realm, roleName, body)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PutRealmRolesRoleNameWithResponse")
+	}
+
+	var r0 *api.PutRealmRolesRoleNameResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, api.RoleRepresentation, ...api.RequestEditorFn) (*api.PutRealmRolesRoleNameResponse, error)); ok {
+		return rf(ctx, realm, roleName, body, reqEditors...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, api.RoleRepresentation, ...api.RequestEditorFn) *api.PutRealmRolesRoleNameResponse); ok {
+		r0 = rf(ctx, realm, roleName, body, reqEditors...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.PutRealmRolesRoleNameResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, api.RoleRepresentation, ...api.RequestEditorFn) error); ok {
+		r1 = rf(ctx, realm, roleName, body, reqEditors...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockKeycloakClient_PutRealmRolesRoleNameWithResponse_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PutRealmRolesRoleNameWithResponse'
+type MockKeycloakClient_PutRealmRolesRoleNameWithResponse_Call struct {
+	*mock.Call
+}
+
+// PutRealmRolesRoleNameWithResponse is a helper method to define mock.On call
+//   - ctx context.Context
+//   - realm string
+//   - roleName string
+//   - body api.RoleRepresentation
+//   - reqEditors ...api.RequestEditorFn
+func (_e *MockKeycloakClient_Expecter) PutRealmRolesRoleNameWithResponse(ctx interface{}, realm interface{}, roleName interface{}, body interface{}, reqEditors ...interface{}) *MockKeycloakClient_PutRealmRolesRoleNameWithResponse_Call {
+	return &MockKeycloakClient_PutRealmRolesRoleNameWithResponse_Call{Call: _e.mock.On("PutRealmRolesRoleNameWithResponse",
+		append([]interface{}{ctx, realm, roleName, body}, reqEditors...)...)}
+}
+
+func (_c *MockKeycloakClient_PutRealmRolesRoleNameWithResponse_Call) Run(run func(ctx context.Context, realm string, roleName string, body api.RoleRepresentation, reqEditors ...api.RequestEditorFn)) *MockKeycloakClient_PutRealmRolesRoleNameWithResponse_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		variadicArgs := make([]api.RequestEditorFn, len(args)-4)
+		for i, a := range args[4:] {
+			if a != nil {
+				variadicArgs[i] = a.(api.RequestEditorFn)
+			}
+		}
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(api.RoleRepresentation), variadicArgs...)
+	})
+	return _c
+}
+
+func (_c *MockKeycloakClient_PutRealmRolesRoleNameWithResponse_Call) Return(_a0 *api.PutRealmRolesRoleNameResponse, _a1 error) *MockKeycloakClient_PutRealmRolesRoleNameWithResponse_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockKeycloakClient_PutRealmRolesRoleNameWithResponse_Call) RunAndReturn(run func(context.Context, string, string, api.RoleRepresentation, ...api.RequestEditorFn) (*api.PutRealmRolesRoleNameResponse, error)) *MockKeycloakClient_PutRealmRolesRoleNameWithResponse_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -25,6 +25,54 @@ func (_m *MockRealmClient) EXPECT() *MockRealmClient_Expecter {
 	return &MockRealmClient_Expecter{mock: &_m.Mock}
 }
 
+// AssignServiceAccountRoles provides a mock function with given fields: ctx, realm, client
+func (_m *MockRealmClient) AssignServiceAccountRoles(ctx context.Context, realm *v1.Realm, client *v1.Client) error {
+	ret := _m.Called(ctx, realm, client)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AssignServiceAccountRoles")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *v1.Realm, *v1.Client) error); ok {
+		r0 = rf(ctx, realm, client)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRealmClient_AssignServiceAccountRoles_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AssignServiceAccountRoles'
+type MockRealmClient_AssignServiceAccountRoles_Call struct {
+	*mock.Call
+}
+
+// AssignServiceAccountRoles is a helper method to define mock.On call
+//   - ctx context.Context
+//   - realm *v1.Realm
+//   - client *v1.Client
+func (_e *MockRealmClient_Expecter) AssignServiceAccountRoles(ctx interface{}, realm interface{}, client interface{}) *MockRealmClient_AssignServiceAccountRoles_Call {
+	return &MockRealmClient_AssignServiceAccountRoles_Call{Call: _e.mock.On("AssignServiceAccountRoles", ctx, realm, client)}
+}
+
+func (_c *MockRealmClient_AssignServiceAccountRoles_Call) Run(run func(ctx context.Context, realm *v1.Realm, client *v1.Client)) *MockRealmClient_AssignServiceAccountRoles_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*v1.Realm), args[2].(*v1.Client))
+	})
+	return _c
+}
+
+func (_c *MockRealmClient_AssignServiceAccountRoles_Call) Return(_a0 error) *MockRealmClient_AssignServiceAccountRoles_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRealmClient_AssignServiceAccountRoles_Call) RunAndReturn(run func(context.Context, *v1.Realm, *v1.Client) error) *MockRealmClient_AssignServiceAccountRoles_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateOrUpdateRealm provides a mock function with given fields: ctx, realm
 func (_m *MockRealmClient) CreateOrUpdateRealm(ctx context.Context, realm *v1.Realm) error {
 	ret := _m.Called(ctx, realm)
@@ -120,6 +168,294 @@ func (_c *MockRealmClient_CreateOrUpdateRealmClient_Call) RunAndReturn(run func(
 	return _c
 }
 
+// CreateOrUpdateRealmClientScope provides a mock function with given fields: ctx, realm, clientScope
+func (_m *MockRealmClient) CreateOrUpdateRealmClientScope(ctx context.Context, realm *v1.Realm, clientScope *v1.ClientScope) error {
+	ret := _m.Called(ctx, realm, clientScope)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateOrUpdateRealmClientScope")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *v1.Realm, *v1.ClientScope) error); ok {
+		r0 = rf(ctx, realm, clientScope)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRealmClient_CreateOrUpdateRealmClientScope_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateOrUpdateRealmClientScope'
+type MockRealmClient_CreateOrUpdateRealmClientScope_Call struct {
+	*mock.Call
+}
+
+// CreateOrUpdateRealmClientScope is a helper method to define mock.On call
+//   - ctx context.Context
+//   - realm *v1.Realm
+//   - clientScope *v1.ClientScope
+func (_e *MockRealmClient_Expecter) CreateOrUpdateRealmClientScope(ctx interface{}, realm interface{}, clientScope interface{}) *MockRealmClient_CreateOrUpdateRealmClientScope_Call {
+	return &MockRealmClient_CreateOrUpdateRealmClientScope_Call{Call: _e.mock.On("CreateOrUpdateRealmClientScope", ctx, realm, clientScope)}
+}
+
+func (_c *MockRealmClient_CreateOrUpdateRealmClientScope_Call) Run(run func(ctx context.Context, realm *v1.Realm, clientScope *v1.ClientScope)) *MockRealmClient_CreateOrUpdateRealmClientScope_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*v1.Realm), args[2].(*v1.ClientScope))
+	})
+	return _c
+}
+
+func (_c *MockRealmClient_CreateOrUpdateRealmClientScope_Call) Return(_a0 error) *MockRealmClient_CreateOrUpdateRealmClientScope_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRealmClient_CreateOrUpdateRealmClientScope_Call) RunAndReturn(run func(context.Context, *v1.Realm, *v1.ClientScope) error) *MockRealmClient_CreateOrUpdateRealmClientScope_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateOrUpdateRealmIdentityBroker provides a mock function with given fields: ctx, realm, broker
+func (_m *MockRealmClient) CreateOrUpdateRealmIdentityBroker(ctx context.Context, realm *v1.Realm, broker *v1.IdentityBroker) error {
+	ret := _m.Called(ctx, realm, broker)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateOrUpdateRealmIdentityBroker")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *v1.Realm, *v1.IdentityBroker) error); ok {
+		r0 = rf(ctx, realm, broker)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRealmClient_CreateOrUpdateRealmIdentityBroker_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateOrUpdateRealmIdentityBroker'
+type MockRealmClient_CreateOrUpdateRealmIdentityBroker_Call struct {
+	*mock.Call
+}
+
+// CreateOrUpdateRealmIdentityBroker is a helper method to define mock.On call
+//   - ctx context.Context
+//   - realm *v1.Realm
+//   - broker *v1.IdentityBroker
+func (_e *MockRealmClient_Expecter) CreateOrUpdateRealmIdentityBroker(ctx interface{}, realm interface{}, broker interface{}) *MockRealmClient_CreateOrUpdateRealmIdentityBroker_Call {
+	return &MockRealmClient_CreateOrUpdateRealmIdentityBroker_Call{Call: _e.mock.On("CreateOrUpdateRealmIdentityBroker", ctx, realm, broker)}
+}
+
+func (_c *MockRealmClient_CreateOrUpdateRealmIdentityBroker_Call) Run(run func(ctx context.Context, realm *v1.Realm, broker *v1.IdentityBroker)) *MockRealmClient_CreateOrUpdateRealmIdentityBroker_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*v1.Realm), args[2].(*v1.IdentityBroker))
+	})
+	return _c
+}
+
+func (_c *MockRealmClient_CreateOrUpdateRealmIdentityBroker_Call) Return(_a0 error) *MockRealmClient_CreateOrUpdateRealmIdentityBroker_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRealmClient_CreateOrUpdateRealmIdentityBroker_Call) RunAndReturn(run func(context.Context, *v1.Realm, *v1.IdentityBroker) error) *MockRealmClient_CreateOrUpdateRealmIdentityBroker_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateOrUpdateRealmRole provides a mock function with given fields: ctx, realm, role
+func (_m *MockRealmClient) CreateOrUpdateRealmRole(ctx context.Context, realm *v1.Realm, role *v1.Role) error {
+	ret := _m.Called(ctx, realm, role)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateOrUpdateRealmRole")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *v1.Realm, *v1.Role) error); ok {
+		r0 = rf(ctx, realm, role)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRealmClient_CreateOrUpdateRealmRole_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateOrUpdateRealmRole'
+type MockRealmClient_CreateOrUpdateRealmRole_Call struct {
+	*mock.Call
+}
+
+// CreateOrUpdateRealmRole is a helper method to define mock.On call
+//   - ctx context.Context
+//   - realm *v1.Realm
+//   - role *v1.Role
+func (_e *MockRealmClient_Expecter) CreateOrUpdateRealmRole(ctx interface{}, realm interface{}, role interface{}) *MockRealmClient_CreateOrUpdateRealmRole_Call {
+	return &MockRealmClient_CreateOrUpdateRealmRole_Call{Call: _e.mock.On("CreateOrUpdateRealmRole", ctx, realm, role)}
+}
+
+func (_c *MockRealmClient_CreateOrUpdateRealmRole_Call) Run(run func(ctx context.Context, realm *v1.Realm, role *v1.Role)) *MockRealmClient_CreateOrUpdateRealmRole_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*v1.Realm), args[2].(*v1.Role))
+	})
+	return _c
+}
+
+func (_c *MockRealmClient_CreateOrUpdateRealmRole_Call) Return(_a0 error) *MockRealmClient_CreateOrUpdateRealmRole_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRealmClient_CreateOrUpdateRealmRole_Call) RunAndReturn(run func(context.Context, *v1.Realm, *v1.Role) error) *MockRealmClient_CreateOrUpdateRealmRole_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteRealmClientScope provides a mock function with given fields: ctx, realm, clientScope
+func (_m *MockRealmClient) DeleteRealmClientScope(ctx context.Context, realm *v1.Realm, clientScope *v1.ClientScope) error {
+	ret := _m.Called(ctx, realm, clientScope)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteRealmClientScope")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *v1.Realm, *v1.ClientScope) error); ok {
+		r0 = rf(ctx, realm, clientScope)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRealmClient_DeleteRealmClientScope_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteRealmClientScope'
+type MockRealmClient_DeleteRealmClientScope_Call struct {
+	*mock.Call
+}
+
+// DeleteRealmClientScope is a helper method to define mock.On call
+//   - ctx context.Context
+//   - realm *v1.Realm
+//   - clientScope *v1.ClientScope
+func (_e *MockRealmClient_Expecter) DeleteRealmClientScope(ctx interface{}, realm interface{}, clientScope interface{}) *MockRealmClient_DeleteRealmClientScope_Call {
+	return &MockRealmClient_DeleteRealmClientScope_Call{Call: _e.mock.On("DeleteRealmClientScope", ctx, realm, clientScope)}
+}
+
+func (_c *MockRealmClient_DeleteRealmClientScope_Call) Run(run func(ctx context.Context, realm *v1.Realm, clientScope *v1.ClientScope)) *MockRealmClient_DeleteRealmClientScope_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*v1.Realm), args[2].(*v1.ClientScope))
+	})
+	return _c
+}
+
+func (_c *MockRealmClient_DeleteRealmClientScope_Call) Return(_a0 error) *MockRealmClient_DeleteRealmClientScope_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRealmClient_DeleteRealmClientScope_Call) RunAndReturn(run func(context.Context, *v1.Realm, *v1.ClientScope) error) *MockRealmClient_DeleteRealmClientScope_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteRealmIdentityBroker provides a mock function with given fields: ctx, realm, broker
+func (_m *MockRealmClient) DeleteRealmIdentityBroker(ctx context.Context, realm *v1.Realm, broker *v1.IdentityBroker) error {
+	ret := _m.Called(ctx, realm, broker)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteRealmIdentityBroker")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *v1.Realm, *v1.IdentityBroker) error); ok {
+		r0 = rf(ctx, realm, broker)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRealmClient_DeleteRealmIdentityBroker_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteRealmIdentityBroker'
+type MockRealmClient_DeleteRealmIdentityBroker_Call struct {
+	*mock.Call
+}
+
+// DeleteRealmIdentityBroker is a helper method to define mock.On call
+//   - ctx context.Context
+//   - realm *v1.Realm
+//   - broker *v1.IdentityBroker
+func (_e *MockRealmClient_Expecter) DeleteRealmIdentityBroker(ctx interface{}, realm interface{}, broker interface{}) *MockRealmClient_DeleteRealmIdentityBroker_Call {
+	return &MockRealmClient_DeleteRealmIdentityBroker_Call{Call: _e.mock.On("DeleteRealmIdentityBroker", ctx, realm, broker)}
+}
+
+func (_c *MockRealmClient_DeleteRealmIdentityBroker_Call) Run(run func(ctx context.Context, realm *v1.Realm, broker *v1.IdentityBroker)) *MockRealmClient_DeleteRealmIdentityBroker_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*v1.Realm), args[2].(*v1.IdentityBroker))
+	})
+	return _c
+}
+
+func (_c *MockRealmClient_DeleteRealmIdentityBroker_Call) Return(_a0 error) *MockRealmClient_DeleteRealmIdentityBroker_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRealmClient_DeleteRealmIdentityBroker_Call) RunAndReturn(run func(context.Context, *v1.Realm, *v1.IdentityBroker) error) *MockRealmClient_DeleteRealmIdentityBroker_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteRealmRole provides a mock function with given fields: ctx, realm, role
+func (_m *MockRealmClient) DeleteRealmRole(ctx context.Context, realm *v1.Realm, role *v1.Role) error {
+	ret := _m.Called(ctx, realm, role)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteRealmRole")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *v1.Realm, *v1.Role) error); ok {
+		r0 = rf(ctx, realm, role)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockRealmClient_DeleteRealmRole_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteRealmRole'
+type MockRealmClient_DeleteRealmRole_Call struct {
+	*mock.Call
+}
+
+// DeleteRealmRole is a helper method to define mock.On call
+//   - ctx context.Context
+//   - realm *v1.Realm
+//   - role *v1.Role
+func (_e *MockRealmClient_Expecter) DeleteRealmRole(ctx interface{}, realm interface{}, role interface{}) *MockRealmClient_DeleteRealmRole_Call {
+	return &MockRealmClient_DeleteRealmRole_Call{Call: _e.mock.On("DeleteRealmRole", ctx, realm, role)}
+}
+
+func (_c *MockRealmClient_DeleteRealmRole_Call) Run(run func(ctx context.Context, realm *v1.Realm, role *v1.Role)) *MockRealmClient_DeleteRealmRole_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*v1.Realm), args[2].(*v1.Role))
+	})
+	return _c
+}
+
+func (_c *MockRealmClient_DeleteRealmRole_Call) Return(_a0 error) *MockRealmClient_DeleteRealmRole_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockRealmClient_DeleteRealmRole_Call) RunAndReturn(run func(context.Context, *v1.Realm, *v1.Role) error) *MockRealmClient_DeleteRealmRole_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetRealm provides a mock function with given fields: ctx, realm
 func (_m *MockRealmClient) GetRealm(ctx context.Context, realm string) (*api.GetRealmResponse, error) {
 	ret := _m.Called(ctx, realm)
@@ -179,6 +515,65 @@ func (_c *MockRealmClient_GetRealm_Call) RunAndReturn(run func(context.Context,
 	return _c
 }
 
+// GetRealmClientScopes provides a mock function with given fields: ctx, realm
+func (_m *MockRealmClient) GetRealmClientScopes(ctx context.Context, realm string) (*api.GetRealmClientScopesResponse, error) {
+	ret := _m.Called(ctx, realm)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRealmClientScopes")
+	}
+
+	var r0 *api.GetRealmClientScopesResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*api.GetRealmClientScopesResponse, error)); ok {
+		return rf(ctx, realm)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *api.GetRealmClientScopesResponse); ok {
+		r0 = rf(ctx, realm)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.GetRealmClientScopesResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, realm)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRealmClient_GetRealmClientScopes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRealmClientScopes'
+type MockRealmClient_GetRealmClientScopes_Call struct {
+	*mock.Call
+}
+
+// GetRealmClientScopes is a helper method to define mock.On call
+//   - ctx context.Context
+//   - realm string
+func (_e *MockRealmClient_Expecter) GetRealmClientScopes(ctx interface{}, realm interface{}) *MockRealmClient_GetRealmClientScopes_Call {
+	return &MockRealmClient_GetRealmClientScopes_Call{Call: _e.mock.On("GetRealmClientScopes", ctx, realm)}
+}
+
+func (_c *MockRealmClient_GetRealmClientScopes_Call) Run(run func(ctx context.Context, realm string)) *MockRealmClient_GetRealmClientScopes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockRealmClient_GetRealmClientScopes_Call) Return(_a0 *api.GetRealmClientScopesResponse, _a1 error) *MockRealmClient_GetRealmClientScopes_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRealmClient_GetRealmClientScopes_Call) RunAndReturn(run func(context.Context, string) (*api.GetRealmClientScopesResponse, error)) *MockRealmClient_GetRealmClientScopes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetRealmClients provides a mock function with given fields: ctx, realm, client
 func (_m *MockRealmClient) GetRealmClients(ctx context.Context, realm string, client *v1.Client) (*api.GetRealmClientsResponse, error) {
 	ret := _m.Called(ctx, realm, client)
@@ -239,6 +634,184 @@ func (_c *MockRealmClient_GetRealmClients_Call) RunAndReturn(run func(context.Co
 	return _c
 }
 
+// GetRealmIdentityBroker provides a mock function with given fields: ctx, realmName, alias
+func (_m *MockRealmClient) GetRealmIdentityBroker(ctx context.Context, realmName string, alias string) (*api.IdentityProviderRepresentation, error) {
+	ret := _m.Called(ctx, realmName, alias)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRealmIdentityBroker")
+	}
+
+	var r0 *api.IdentityProviderRepresentation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*api.IdentityProviderRepresentation, error)); ok {
+		return rf(ctx, realmName, alias)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *api.IdentityProviderRepresentation); ok {
+		r0 = rf(ctx, realmName, alias)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.IdentityProviderRepresentation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, realmName, alias)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRealmClient_GetRealmIdentityBroker_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRealmIdentityBroker'
+type MockRealmClient_GetRealmIdentityBroker_Call struct {
+	*mock.Call
+}
+
+// GetRealmIdentityBroker is a helper method to define mock.On call
+//   - ctx context.Context
+//   - realmName string
+//   - alias string
+func (_e *MockRealmClient_Expecter) GetRealmIdentityBroker(ctx interface{}, realmName interface{}, alias interface{}) *MockRealmClient_GetRealmIdentityBroker_Call {
+	return &MockRealmClient_GetRealmIdentityBroker_Call{Call: _e.mock.On("GetRealmIdentityBroker", ctx, realmName, alias)}
+}
+
+func (_c *MockRealmClient_GetRealmIdentityBroker_Call) Run(run func(ctx context.Context, realmName string, alias string)) *MockRealmClient_GetRealmIdentityBroker_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockRealmClient_GetRealmIdentityBroker_Call) Return(_a0 *api.IdentityProviderRepresentation, _a1 error) *MockRealmClient_GetRealmIdentityBroker_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRealmClient_GetRealmIdentityBroker_Call) RunAndReturn(run func(context.Context, string, string) (*api.IdentityProviderRepresentation, error)) *MockRealmClient_GetRealmIdentityBroker_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetRealmRole provides a mock function with given fields: ctx, realmName, role
+func (_m *MockRealmClient) GetRealmRole(ctx context.Context, realmName string, role *v1.Role) (*api.RoleRepresentation, error) {
+	ret := _m.Called(ctx, realmName, role)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRealmRole")
+	}
+
+	var r0 *api.RoleRepresentation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *v1.Role) (*api.RoleRepresentation, error)); ok {
+		return rf(ctx, realmName, role)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *v1.Role) *api.RoleRepresentation); ok {
+		r0 = rf(ctx, realmName, role)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.RoleRepresentation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *v1.Role) error); ok {
+		r1 = rf(ctx, realmName, role)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRealmClient_GetRealmRole_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRealmRole'
+type MockRealmClient_GetRealmRole_Call struct {
+	*mock.Call
+}
+
+// GetRealmRole is a helper method to define mock.On call
+//   - ctx context.Context
+//   - realmName string
+//   - role *v1.Role
+func (_e *MockRealmClient_Expecter) GetRealmRole(ctx interface{}, realmName interface{}, role interface{}) *MockRealmClient_GetRealmRole_Call {
+	return &MockRealmClient_GetRealmRole_Call{Call: _e.mock.On("GetRealmRole", ctx, realmName, role)}
+}
+
+func (_c *MockRealmClient_GetRealmRole_Call) Run(run func(ctx context.Context, realmName string, role *v1.Role)) *MockRealmClient_GetRealmRole_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(*v1.Role))
+	})
+	return _c
+}
+
+func (_c *MockRealmClient_GetRealmRole_Call) Return(_a0 *api.RoleRepresentation, _a1 error) *MockRealmClient_GetRealmRole_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRealmClient_GetRealmRole_Call) RunAndReturn(run func(context.Context, string, *v1.Role) (*api.RoleRepresentation, error)) *MockRealmClient_GetRealmRole_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetServerInfo provides a mock function with given fields: ctx
+func (_m *MockRealmClient) GetServerInfo(ctx context.Context) (*api.ServerInfoRepresentation, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetServerInfo")
+	}
+
+	var r0 *api.ServerInfoRepresentation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*api.ServerInfoRepresentation, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *api.ServerInfoRepresentation); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.ServerInfoRepresentation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRealmClient_GetServerInfo_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetServerInfo'
+type MockRealmClient_GetServerInfo_Call struct {
+	*mock.Call
+}
+
+// GetServerInfo is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockRealmClient_Expecter) GetServerInfo(ctx interface{}) *MockRealmClient_GetServerInfo_Call {
+	return &MockRealmClient_GetServerInfo_Call{Call: _e.mock.On("GetServerInfo", ctx)}
+}
+
+func (_c *MockRealmClient_GetServerInfo_Call) Run(run func(ctx context.Context)) *MockRealmClient_GetServerInfo_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockRealmClient_GetServerInfo_Call) Return(_a0 *api.ServerInfoRepresentation, _a1 error) *MockRealmClient_GetServerInfo_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRealmClient_GetServerInfo_Call) RunAndReturn(run func(context.Context) (*api.ServerInfoRepresentation, error)) *MockRealmClient_GetServerInfo_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // PostRealm provides a mock function with given fields: ctx, realm
 func (_m *MockRealmClient) PostRealm(ctx context.Context, realm *v1.Realm) (*api.PostResponse, error) {
 	ret := _m.Called(ctx, realm)
@@ -358,6 +931,66 @@ func (_c *MockRealmClient_PostRealmClient_Call) RunAndReturn(run func(context.Co
 	return _c
 }
 
+// PostRealmClientScope provides a mock function with given fields: ctx, realmName, clientScope
+func (_m *MockRealmClient) PostRealmClientScope(ctx context.Context, realmName string, clientScope *v1.ClientScope) (*api.PostRealmClientScopesResponse, error) {
+	ret := _m.Called(ctx, realmName, clientScope)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PostRealmClientScope")
+	}
+
+	var r0 *api.PostRealmClientScopesResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *v1.ClientScope) (*api.PostRealmClientScopesResponse, error)); ok {
+		return rf(ctx, realmName, clientScope)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, *v1.ClientScope) *api.PostRealmClientScopesResponse); ok {
+		r0 = rf(ctx, realmName, clientScope)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.PostRealmClientScopesResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, *v1.ClientScope) error); ok {
+		r1 = rf(ctx, realmName, clientScope)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRealmClient_PostRealmClientScope_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PostRealmClientScope'
+type MockRealmClient_PostRealmClientScope_Call struct {
+	*mock.Call
+}
+
+// PostRealmClientScope is a helper method to define mock.On call
+//   - ctx context.Context
+//   - realmName string
+//   - clientScope *v1.ClientScope
+func (_e *MockRealmClient_Expecter) PostRealmClientScope(ctx interface{}, realmName interface{}, clientScope interface{}) *MockRealmClient_PostRealmClientScope_Call {
+	return &MockRealmClient_PostRealmClientScope_Call{Call: _e.mock.On("PostRealmClientScope", ctx, realmName, clientScope)}
+}
+
+func (_c *MockRealmClient_PostRealmClientScope_Call) Run(run func(ctx context.Context, realmName string, clientScope *v1.ClientScope)) *MockRealmClient_PostRealmClientScope_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(*v1.ClientScope))
+	})
+	return _c
+}
+
+func (_c *MockRealmClient_PostRealmClientScope_Call) Return(_a0 *api.PostRealmClientScopesResponse, _a1 error) *MockRealmClient_PostRealmClientScope_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRealmClient_PostRealmClientScope_Call) RunAndReturn(run func(context.Context, string, *v1.ClientScope) (*api.PostRealmClientScopesResponse, error)) *MockRealmClient_PostRealmClientScope_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // PutRealm provides a mock function with given fields: ctx, realmName, realm
 func (_m *MockRealmClient) PutRealm(ctx context.Context, realmName string, realm *v1.Realm) (*api.PutRealmResponse, error) {
 	ret := _m.Called(ctx, realmName, realm)
@@ -479,6 +1112,67 @@ func (_c *MockRealmClient_PutRealmClient_Call) RunAndReturn(run func(context.Con
 	return _c
 }
 
+// PutRealmClientScope provides a mock function with given fields: ctx, realmName, id, clientScope
+func (_m *MockRealmClient) PutRealmClientScope(ctx context.Context, realmName string, id string, clientScope *v1.ClientScope) (*api.PutRealmClientScopesIdResponse, error) {
+	ret := _m.Called(ctx, realmName, id, clientScope)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PutRealmClientScope")
+	}
+
+	var r0 *api.PutRealmClientScopesIdResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *v1.ClientScope) (*api.PutRealmClientScopesIdResponse, error)); ok {
+		return rf(ctx, realmName, id, clientScope)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *v1.ClientScope) *api.PutRealmClientScopesIdResponse); ok {
+		r0 = rf(ctx, realmName, id, clientScope)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.PutRealmClientScopesIdResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, *v1.ClientScope) error); ok {
+		r1 = rf(ctx, realmName, id, clientScope)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockRealmClient_PutRealmClientScope_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PutRealmClientScope'
+type MockRealmClient_PutRealmClientScope_Call struct {
+	*mock.Call
+}
+
+// PutRealmClientScope is a helper method to define mock.On call
+//   - ctx context.Context
+//   - realmName string
+//   - id string
+//   - clientScope *v1.ClientScope
+func (_e *MockRealmClient_Expecter) PutRealmClientScope(ctx interface{}, realmName interface{}, id interface{}, clientScope interface{}) *MockRealmClient_PutRealmClientScope_Call {
+	return &MockRealmClient_PutRealmClientScope_Call{Call: _e.mock.On("PutRealmClientScope", ctx, realmName, id, clientScope)}
+}
+
+func (_c *MockRealmClient_PutRealmClientScope_Call) Run(run func(ctx context.Context, realmName string, id string, clientScope *v1.ClientScope)) *MockRealmClient_PutRealmClientScope_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(*v1.ClientScope))
+	})
+	return _c
+}
+
+func (_c *MockRealmClient_PutRealmClientScope_Call) Return(_a0 *api.PutRealmClientScopesIdResponse, _a1 error) *MockRealmClient_PutRealmClientScope_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockRealmClient_PutRealmClientScope_Call) RunAndReturn(run func(context.Context, string, string, *v1.ClientScope) (*api.PutRealmClientScopesIdResponse, error)) *MockRealmClient_PutRealmClientScope_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockRealmClient creates a new instance of MockRealmClient. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockRealmClient(t interface {
@@ -28,6 +28,11 @@ func NewKeycloakClientMock(testing ginkgo.FullGinkgoTInterface) *mocks.MockKeycl
 }
 
 func ConfigureKeycloakClientMock(mockedClient *mocks.MockKeycloakClient) {
+	mockedClient.EXPECT().GetWithResponse(
+		mock.AnythingOfType("*context.valueCtx"),
+		mock.Anything).
+		Return(mockGetServerInfoResponse(), nil).Maybe()
+
 	var mockedBody, _ = io.ReadAll(io.NopCloser(strings.NewReader(fmt.Sprintf(`{"realm":"%s"}`, Realm))))
 
 	// The parameter "reqEditors ...RequestEditorFn" is not used in the implementation and therefore omitted
@@ -82,6 +87,85 @@ func ConfigureKeycloakClientMock(mockedClient *mocks.MockKeycloakClient) {
 		mock.AnythingOfType("api.ClientRepresentation")).
 		Return(mockPostRealmClientsResponse(mockedBody), nil).Maybe()
 
+	mockedClient.EXPECT().GetRealmClientScopesWithResponse(
+		mock.AnythingOfType("*context.valueCtx"),
+		mock.MatchedBy(func(s string) bool {
+			return s == Realm || s == RealmForClient
+		})).
+		Return(mockGetRealmClientScopesResponse(mockedBody), nil).Maybe()
+
+	mockedClient.EXPECT().PostRealmClientScopesWithResponse(
+		mock.AnythingOfType("*context.valueCtx"),
+		mock.MatchedBy(func(s string) bool {
+			return s == Realm || s == RealmForClient
+		}),
+		mock.AnythingOfType("api.ClientScopeRepresentation")).
+		Return(mockPostRealmClientScopesResponse(mockedBody), nil).Maybe()
+
+	mockedClient.EXPECT().GetRealmRolesRoleNameWithResponse(
+		mock.AnythingOfType("*context.valueCtx"),
+		mock.MatchedBy(func(s string) bool {
+			return s == Realm || s == RealmForClient
+		}),
+		mock.AnythingOfType("string")).
+		Return(mockGetRealmRolesRoleNameResponse(mockedBody), nil).Maybe()
+
+	mockedClient.EXPECT().PostRealmRolesWithResponse(
+		mock.AnythingOfType("*context.valueCtx"),
+		mock.MatchedBy(func(s string) bool {
+			return s == Realm || s == RealmForClient
+		}),
+		mock.AnythingOfType("api.RoleRepresentation")).
+		Return(mockPostRealmRolesResponse(mockedBody), nil).Maybe()
+
+	mockedClient.EXPECT().DeleteRealmRolesRoleNameWithResponse(
+		mock.AnythingOfType("*context.valueCtx"),
+		mock.MatchedBy(func(s string) bool {
+			return s == Realm || s == RealmForClient
+		}),
+		mock.AnythingOfType("string")).
+		Return(mockDeleteRealmRolesRoleNameResponse(mockedBody), nil).Maybe()
+
+	mockedClient.EXPECT().GetRealmIdentityProviderInstancesAliasWithResponse(
+		mock.AnythingOfType("*context.valueCtx"),
+		mock.MatchedBy(func(s string) bool {
+			return s == Realm || s == RealmForClient
+		}),
+		mock.AnythingOfType("string")).
+		Return(mockGetRealmIdentityProviderInstancesAliasResponse(mockedBody), nil).Maybe()
+
+	mockedClient.EXPECT().PostRealmIdentityProviderInstancesWithResponse(
+		mock.AnythingOfType("*context.valueCtx"),
+		mock.MatchedBy(func(s string) bool {
+			return s == Realm || s == RealmForClient
+		}),
+		mock.AnythingOfType("api.IdentityProviderRepresentation")).
+		Return(mockPostRealmIdentityProviderInstancesResponse(mockedBody), nil).Maybe()
+
+	mockedClient.EXPECT().DeleteRealmIdentityProviderInstancesAliasWithResponse(
+		mock.AnythingOfType("*context.valueCtx"),
+		mock.MatchedBy(func(s string) bool {
+			return s == Realm || s == RealmForClient
+		}),
+		mock.AnythingOfType("string")).
+		Return(mockDeleteRealmIdentityProviderInstancesAliasResponse(mockedBody), nil).Maybe()
+
+	mockedClient.EXPECT().GetRealmIdentityProviderInstancesAliasMappersWithResponse(
+		mock.AnythingOfType("*context.valueCtx"),
+		mock.MatchedBy(func(s string) bool {
+			return s == Realm || s == RealmForClient
+		}),
+		mock.AnythingOfType("string")).
+		Return(mockGetRealmIdentityProviderInstancesAliasMappersResponse(mockedBody), nil).Maybe()
+
+	mockedClient.EXPECT().PostRealmIdentityProviderInstancesAliasMappersWithResponse(
+		mock.AnythingOfType("*context.valueCtx"),
+		mock.MatchedBy(func(s string) bool {
+			return s == Realm || s == RealmForClient
+		}),
+		mock.AnythingOfType("string"),
+		mock.AnythingOfType("api.IdentityProviderMapperRepresentation")).
+		Return(mockPostRealmIdentityProviderInstancesAliasMappersResponse(mockedBody), nil).Maybe()
 }
 
 func NewRealmClientMock(testing ginkgo.FullGinkgoTInterface) keycloak.RealmClient {
@@ -159,3 +243,87 @@ func mockPostRealmClientsResponse(body []byte) *api.PostRealmClientsResponse {
 		HTTPResponse: ptr.To(http.Response{StatusCode: http.StatusCreated}),
 	}
 }
+
+func mockGetRealmClientScopesResponse(body []byte) *api.GetRealmClientScopesResponse {
+	return &api.GetRealmClientScopesResponse{
+		Body:         body,
+		HTTPResponse: ptr.To(http.Response{StatusCode: http.StatusOK}),
+		JSON2XX:      &[]api.ClientScopeRepresentation{},
+	}
+}
+
+func mockPostRealmClientScopesResponse(body []byte) *api.PostRealmClientScopesResponse {
+	return &api.PostRealmClientScopesResponse{
+		Body:         body,
+		HTTPResponse: ptr.To(http.Response{StatusCode: http.StatusCreated}),
+	}
+}
+
+func mockGetRealmRolesRoleNameResponse(body []byte) *api.GetRealmRolesRoleNameResponse {
+	return &api.GetRealmRolesRoleNameResponse{
+		Body:         body,
+		HTTPResponse: ptr.To(http.Response{StatusCode: http.StatusNotFound}),
+	}
+}
+
+func mockPostRealmRolesResponse(body []byte) *api.PostRealmRolesResponse {
+	return &api.PostRealmRolesResponse{
+		Body:         body,
+		HTTPResponse: ptr.To(http.Response{StatusCode: http.StatusCreated}),
+	}
+}
+
+func mockDeleteRealmRolesRoleNameResponse(body []byte) *api.DeleteRealmRolesRoleNameResponse {
+	return &api.DeleteRealmRolesRoleNameResponse{
+		Body:         body,
+		HTTPResponse: ptr.To(http.Response{StatusCode: http.StatusNoContent}),
+	}
+}
+
+func mockGetRealmIdentityProviderInstancesAliasResponse(body []byte) *api.GetRealmIdentityProviderInstancesAliasResponse {
+	return &api.GetRealmIdentityProviderInstancesAliasResponse{
+		Body:         body,
+		HTTPResponse: ptr.To(http.Response{StatusCode: http.StatusNotFound}),
+	}
+}
+
+func mockPostRealmIdentityProviderInstancesResponse(body []byte) *api.PostRealmIdentityProviderInstancesResponse {
+	return &api.PostRealmIdentityProviderInstancesResponse{
+		Body:         body,
+		HTTPResponse: ptr.To(http.Response{StatusCode: http.StatusCreated}),
+	}
+}
+
+func mockDeleteRealmIdentityProviderInstancesAliasResponse(
+	body []byte) *api.DeleteRealmIdentityProviderInstancesAliasResponse {
+	return &api.DeleteRealmIdentityProviderInstancesAliasResponse{
+		Body:         body,
+		HTTPResponse: ptr.To(http.Response{StatusCode: http.StatusNoContent}),
+	}
+}
+
+func mockGetRealmIdentityProviderInstancesAliasMappersResponse(
+	body []byte) *api.GetRealmIdentityProviderInstancesAliasMappersResponse {
+	return &api.GetRealmIdentityProviderInstancesAliasMappersResponse{
+		Body:         body,
+		HTTPResponse: ptr.To(http.Response{StatusCode: http.StatusOK}),
+		JSON2XX:      &[]api.IdentityProviderMapperRepresentation{},
+	}
+}
+
+func mockPostRealmIdentityProviderInstancesAliasMappersResponse(
+	body []byte) *api.PostRealmIdentityProviderInstancesAliasMappersResponse {
+	return &api.PostRealmIdentityProviderInstancesAliasMappersResponse{
+		Body:         body,
+		HTTPResponse: ptr.To(http.Response{StatusCode: http.StatusCreated}),
+	}
+}
+
+func mockGetServerInfoResponse() *api.GetResponse {
+	return &api.GetResponse{
+		HTTPResponse: ptr.To(http.Response{StatusCode: http.StatusOK}),
+		JSON2XX: &api.ServerInfoRepresentation{
+			SystemInfo: &api.SystemInfoRepresentation{Version: ptr.To("24.0.5")},
+		},
+	}
+}
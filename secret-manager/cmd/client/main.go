@@ -0,0 +1,17 @@
+package main
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/telekom/controlplane-mono/secret-manager/internal/cli"
+)
+
+func main() {
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	code := cli.New(cli.NewClient, os.Stdin, os.Stdout, os.Stderr).Execute(ctx, os.Args[1:])
+	cancel()
+	os.Exit(code)
+}
@@ -0,0 +1,240 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"github.com/go-logr/zapr"
+	"github.com/pkg/errors"
+	cs "github.com/telekom/controlplane-mono/common-server/pkg/server"
+	"github.com/telekom/controlplane-mono/secret-manager/cmd/server/config"
+	"github.com/telekom/controlplane-mono/secret-manager/internal/setup"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/migration"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	ctrlr "sigs.k8s.io/controller-runtime"
+)
+
+var (
+	logLevel      string
+	sourceConfig  string
+	targetConfig  string
+	dryRun        bool
+	verify        bool
+	output        string
+	mappingFile   string
+	rewrite       string
+	namespace     string
+	sourceBackend string
+	targetBackend string
+)
+
+func init() {
+	flag.StringVar(&logLevel, "loglevel", "info", "log level")
+	flag.StringVar(&sourceConfig, "source-config", "", "path to the server config file of the source backend")
+	flag.StringVar(&targetConfig, "target-config", "", "path to the server config file of the target backend")
+	flag.StringVar(&sourceBackend, "source-backend", "", "source backend type (kubernetes, conjur, encrypt, vault)")
+	flag.StringVar(&targetBackend, "target-backend", "", "target backend type (kubernetes, conjur, encrypt, vault)")
+	flag.BoolVar(&dryRun, "dry-run", false, "only report what would be migrated or rewritten")
+	flag.BoolVar(&verify, "verify", false, "compare the values of the source and the target without writing anything")
+	flag.StringVar(&output, "output", "", "path to the file the report is written to (default stdout)")
+	flag.StringVar(&mappingFile, "mapping", "", "path to the report of a previous migration. If set, only the references are rewritten")
+	flag.StringVar(&rewrite, "rewrite", "", "comma-separated resources whose secret references are rewritten, e.g. clients.v1.identity.cp.ei.telekom.de")
+	flag.StringVar(&namespace, "namespace", "", "only rewrite the resources of this namespace")
+}
+
+func setupLog(logLevel string) logr.Logger {
+	logCfg := zap.NewProductionConfig()
+	logCfg.DisableCaller = true
+	logCfg.DisableStacktrace = true
+	logCfg.EncoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
+	logCfg.EncoderConfig.TimeKey = "time"
+	zapLogLevel, err := zapcore.ParseLevel(logLevel)
+	if err != nil {
+		zapLogLevel = zapcore.InfoLevel
+	}
+
+	logCfg.Level.SetLevel(zapLogLevel)
+	logCfg.OutputPaths = []string{"stderr"}
+	zapLog := zap.Must(logCfg.Build())
+	return zapr.NewLogger(zapLog)
+}
+
+func main() {
+	flag.Parse()
+	log := setupLog(logLevel)
+	ctrlr.SetLogger(log)
+	ctx := logr.NewContext(cs.SignalHandler(context.Background()), log)
+
+	if err := run(ctx); err != nil {
+		log.Error(err, "migration failed")
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context) error {
+	if dryRun && verify {
+		return errors.New("dry-run and verify cannot be combined")
+	}
+
+	var report *migration.Report
+	var err error
+	if mappingFile != "" {
+		report, err = readReport(mappingFile)
+	} else {
+		report, err = migrate(ctx)
+	}
+	if err != nil {
+		return err
+	}
+
+	if rewrite != "" && !verify {
+		if err := rewriteResources(ctx, migration.NewRewriter(report.Mapping)); err != nil {
+			return err
+		}
+	}
+
+	if mappingFile == "" {
+		if err := writeReport(report); err != nil {
+			return err
+		}
+	}
+	if report.Failed() {
+		return errors.Errorf("%d secrets have failed and %d secrets differ, see the report", len(report.Errors), len(report.Mismatches))
+	}
+	return nil
+}
+
+func migrate(ctx context.Context) (*migration.Report, error) {
+	if sourceConfig == "" || targetConfig == "" {
+		return nil, errors.New("source-config and target-config must be set")
+	}
+	sourceCfg := config.GetConfigOrDie(sourceConfig)
+	if sourceBackend != "" {
+		sourceCfg.Backend.Type = sourceBackend
+	}
+	targetCfg := config.GetConfigOrDie(targetConfig)
+	if targetBackend != "" {
+		targetCfg.Backend.Type = targetBackend
+	}
+	// The clients of the backends are configured using the environment, so both must use a different storage
+	if storageOf(sourceCfg.Backend.Type) == storageOf(targetCfg.Backend.Type) {
+		return nil, errors.Errorf("cannot migrate from %s to %s as they use the same storage", sourceCfg.Backend.Type, targetCfg.Backend.Type)
+	}
+
+	// Every secret is read once and must reflect the backend, e.g. when verifying the target
+	disableCache(&sourceCfg.Backend)
+	disableCache(&targetCfg.Backend)
+
+	source, scopes, err := setup.NewController(ctx, sourceCfg.Backend, sourceCfg.Blueprint)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create source backend")
+	}
+	target, _, err := setup.NewController(ctx, targetCfg.Backend, targetCfg.Blueprint)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create target backend")
+	}
+
+	mode := migration.ModeMigrate
+	if dryRun {
+		mode = migration.ModeDryRun
+	} else if verify {
+		mode = migration.ModeVerify
+	}
+	return migration.NewMigrator(scopes, source, target).Run(ctx, mode)
+}
+
+// storageOf returns where the secrets of the backend type are stored
+func storageOf(backendType string) string {
+	switch backendType {
+	case "", "encrypt":
+		return "kubernetes"
+	default:
+		return backendType
+	}
+}
+
+// rewriteResources replaces the secret references in all objects of the resources
+func rewriteResources(ctx context.Context, rewriter *migration.Rewriter) error {
+	log := logr.FromContextOrDiscard(ctx)
+	client, err := dynamic.NewForConfig(ctrlr.GetConfigOrDie())
+	if err != nil {
+		return errors.Wrap(err, "failed to create kubernetes client")
+	}
+
+	for _, arg := range strings.Split(rewrite, ",") {
+		gvr, _ := schema.ParseResourceArg(strings.TrimSpace(arg))
+		if gvr == nil {
+			return errors.Errorf("invalid resource %q, expected <resource>.<version>.<group>", arg)
+		}
+		resource := client.Resource(*gvr).Namespace(namespace)
+		list, err := resource.List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return errors.Wrapf(err, "failed to list %s", arg)
+		}
+		for i := range list.Items {
+			obj := &list.Items[i]
+			content := obj.UnstructuredContent()
+			// The references are only contained in the spec of the resources
+			spec, ok := content["spec"].(map[string]any)
+			if !ok || !rewriter.RewriteObject(spec) {
+				continue
+			}
+			log.Info("Rewriting secret references", "resource", arg, "namespace", obj.GetNamespace(), "name", obj.GetName(), "dryRun", dryRun)
+			if dryRun {
+				continue
+			}
+			_, err := client.Resource(*gvr).Namespace(obj.GetNamespace()).Update(ctx, obj, metav1.UpdateOptions{})
+			if err != nil {
+				return errors.Wrapf(err, "failed to update %s %s/%s", arg, obj.GetNamespace(), obj.GetName())
+			}
+		}
+	}
+	return nil
+}
+
+func readReport(path string) (*migration.Report, error) {
+	file, err := os.Open(path) //nolint:gosec
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to open mapping")
+	}
+	defer file.Close() //nolint:errcheck
+	report := &migration.Report{}
+	if err := json.NewDecoder(file).Decode(report); err != nil {
+		return nil, errors.Wrap(err, "failed to read mapping")
+	}
+	// The mapping can be used to rewrite the references although some secrets have failed
+	report.Errors = nil
+	report.Mismatches = nil
+	return report, nil
+}
+
+func writeReport(report *migration.Report) error {
+	var w io.Writer = os.Stdout
+	if output != "" {
+		file, err := os.Create(output) //nolint:gosec
+		if err != nil {
+			return errors.Wrap(err, "failed to create output")
+		}
+		defer file.Close() //nolint:errcheck
+		w = file
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(report)
+}
+
+func disableCache(cfg *config.BackendConfig) {
+	if cfg.Config == nil {
+		cfg.Config = map[string]string{}
+	}
+	cfg.Config["disable_cache"] = "true"
+}
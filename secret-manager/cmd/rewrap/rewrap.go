@@ -0,0 +1,60 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"os"
+
+	"github.com/go-logr/logr"
+	"github.com/go-logr/zapr"
+	cs "github.com/telekom/controlplane-mono/common-server/pkg/server"
+	"github.com/telekom/controlplane-mono/secret-manager/cmd/server/config"
+	"github.com/telekom/controlplane-mono/secret-manager/internal/setup"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	ctrlr "sigs.k8s.io/controller-runtime"
+)
+
+var (
+	logLevel   string
+	configFile string
+)
+
+func init() {
+	flag.StringVar(&logLevel, "loglevel", "info", "log level")
+	flag.StringVar(&configFile, "configfile", "", "path to the server config file")
+}
+
+func setupLog(logLevel string) logr.Logger {
+	logCfg := zap.NewProductionConfig()
+	logCfg.DisableCaller = true
+	logCfg.DisableStacktrace = true
+	logCfg.EncoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
+	logCfg.EncoderConfig.TimeKey = "time"
+	zapLogLevel, err := zapcore.ParseLevel(logLevel)
+	if err != nil {
+		zapLogLevel = zapcore.InfoLevel
+	}
+
+	logCfg.Level.SetLevel(zapLogLevel)
+	logCfg.OutputPaths = []string{"stderr"}
+	zapLog := zap.Must(logCfg.Build())
+	return zapr.NewLogger(zapLog)
+}
+
+// rewrap re-wraps the data keys of all encrypted secrets with the current key after a key rotation.
+// It uses the same config file as the server.
+func main() {
+	flag.Parse()
+	log := setupLog(logLevel)
+	ctrlr.SetLogger(log)
+	ctx := logr.NewContext(cs.SignalHandler(context.Background()), log)
+
+	cfg := config.GetConfigOrDie(configFile)
+	count, err := setup.Rewrap(ctx, cfg.Backend, cfg.Blueprint)
+	if err != nil {
+		log.Error(err, "rewrap failed", "changed", count)
+		os.Exit(1)
+	}
+	log.Info("rewrap finished", "changed", count)
+}
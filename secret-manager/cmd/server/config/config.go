@@ -4,15 +4,31 @@ import (
 	"io"
 	"os"
 
+	"github.com/pkg/errors"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/backend"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/backend/routing"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/generator"
 	"github.com/telekom/controlplane-mono/secret-manager/pkg/middleware"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/policy"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/rotation"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/tombstone"
 	"gopkg.in/yaml.v3"
 )
 
 type BackendConfig struct {
-	Type   string            `yaml:"type"`
+	Type string `yaml:"type"`
+	// Routes are the backends of the routing backend, see RouteConfig
+	Routes []RouteConfig     `yaml:"routes"`
 	Config map[string]string `yaml:",inline"`
 }
 
+// RouteConfig stores the secrets that are selected by the rule in the backend.
+// The routes are matched in their order. A route without env and prefix is the default.
+type RouteConfig struct {
+	routing.Rule `yaml:",inline"`
+	Backend      BackendConfig `yaml:"backend"`
+}
+
 func (c BackendConfig) Get(key string) string {
 	if c.Config == nil {
 		return ""
@@ -35,11 +51,86 @@ type SecurityConfig struct {
 	TrustedIssuers []string                         `yaml:"trusted_issuers"`
 	JWKSetURLs     []string                         `yaml:"jwk_set_urls"`
 	AccessConfig   []middleware.ServiceAccessConfig `yaml:"access_config"`
+	// OIDC are the issuers of callers outside the cluster, e.g. CI pipelines
+	OIDC []middleware.OIDCConfig `yaml:"oidc"`
+	// MTLS authenticates callers by their client certificate
+	MTLS *middleware.MTLSConfig `yaml:"mtls"`
+	// Policy restricts the access of the callers to secrets
+	Policy *policy.Policy `yaml:"policy"`
+	// PolicyFile contains the policy. It is reloaded when the file changes, e.g. if it is mounted from a ConfigMap.
+	PolicyFile string `yaml:"policy_file"`
+}
+
+// Validate checks the authenticators, so a misconfigured issuer fails on startup
+func (c SecurityConfig) Validate() error {
+	for _, oidc := range c.OIDC {
+		if err := oidc.Validate(); err != nil {
+			return errors.Wrap(err, "invalid oidc config")
+		}
+	}
+	return nil
+}
+
+type RotationConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// CheckInterval is the interval in which due secrets are rotated
+	CheckInterval rotation.Interval `yaml:"check_interval"`
+	// WebhookURL is notified about the rotated secrets of policies with notify enabled
+	WebhookURL string            `yaml:"webhook_url"`
+	Policies   []rotation.Policy `yaml:"policies"`
+}
+
+type CAConfig struct {
+	// CertFile and KeyFile contain the PEM-encoded certificate and private key of the CA
+	CertFile string `yaml:"cert_file"`
+	KeyFile  string `yaml:"key_file"`
+	// Constraints restrict the names and the validity of the signed certificates. The allowed names are required.
+	generator.Constraints `yaml:",inline"`
+}
+
+type GeneratorsConfig struct {
+	// CA signs the generated certificates whose issuer is ca
+	CA *CAConfig `yaml:"ca"`
+}
+
+type DeletionConfig struct {
+	// Retention is the period in which deleted secrets can be restored. If it is 0, secrets are deleted immediately.
+	Retention rotation.Interval `yaml:"retention"`
+	// PurgeInterval is the interval in which the secrets whose retention has passed are deleted
+	PurgeInterval rotation.Interval `yaml:"purge_interval"`
+}
+
+type EventsConfig struct {
+	// Capacity is the number of change events that are kept for watchers
+	Capacity int `yaml:"capacity"`
+}
+
+type AuditSinkConfig struct {
+	// Type is one of stdout, file or webhook
+	Type string `yaml:"type"`
+	// Path is the file that the entries are appended to
+	Path string `yaml:"path"`
+	// URL is the webhook that the entries are posted to
+	URL string `yaml:"url"`
+}
+
+type AuditConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// Sinks receive the audit entries. If empty, the entries are written to stdout.
+	Sinks []AuditSinkConfig `yaml:"sinks"`
 }
 
 type ServerConfig struct {
 	Security SecurityConfig `yaml:"security"`
 	Backend  BackendConfig  `yaml:"backend"`
+	// Blueprint describes the secrets that are created when onboarding.
+	// Levels without secrets use the default secrets, see backend.DefaultBlueprint.
+	Blueprint  backend.Blueprint `yaml:"blueprint"`
+	Rotation   RotationConfig    `yaml:"rotation"`
+	Generators GeneratorsConfig  `yaml:"generators"`
+	Deletion   DeletionConfig    `yaml:"deletion"`
+	Events     EventsConfig      `yaml:"events"`
+	Audit      AuditConfig       `yaml:"audit"`
 }
 
 func ReadConfig(r io.Reader) (*ServerConfig, error) {
@@ -47,6 +138,9 @@ func ReadConfig(r io.Reader) (*ServerConfig, error) {
 	if err := yaml.NewDecoder(r).Decode(cfg); err != nil {
 		return nil, err
 	}
+	if err := cfg.Security.Validate(); err != nil {
+		return nil, err
+	}
 	return cfg, nil
 }
 
@@ -55,6 +149,9 @@ func DefaultConfig() *ServerConfig {
 		Security: SecurityConfig{
 			Enabled: true,
 		},
+		Deletion: DeletionConfig{
+			Retention: rotation.Interval(tombstone.DefaultRetention),
+		},
 	}
 }
 
@@ -5,7 +5,6 @@ import (
 	"flag"
 	"fmt"
 	"os"
-	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/go-logr/zapr"
@@ -15,19 +14,23 @@ import (
 	"github.com/telekom/controlplane-mono/secret-manager/cmd/server/config"
 	"github.com/telekom/controlplane-mono/secret-manager/internal/api"
 	"github.com/telekom/controlplane-mono/secret-manager/internal/handler"
+	"github.com/telekom/controlplane-mono/secret-manager/internal/setup"
 	"github.com/telekom/controlplane-mono/secret-manager/pkg/api/util"
-	"github.com/telekom/controlplane-mono/secret-manager/pkg/backend/cache"
-	"github.com/telekom/controlplane-mono/secret-manager/pkg/backend/conjur"
-	"github.com/telekom/controlplane-mono/secret-manager/pkg/backend/kubernetes"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/audit"
+	smbackend "github.com/telekom/controlplane-mono/secret-manager/pkg/backend"
 	"github.com/telekom/controlplane-mono/secret-manager/pkg/controller"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/events"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/generation"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/generator"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/metadata"
 	"github.com/telekom/controlplane-mono/secret-manager/pkg/middleware"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/policy"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/rotation"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/tombstone"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 	ctrlr "sigs.k8s.io/controller-runtime"
-)
-
-const (
-	trueStr = "true"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 var (
@@ -47,7 +50,7 @@ func init() {
 	flag.StringVar(&tlsKey, "tls-key", "/etc/tls/tls.key", "path to TLS key")
 	flag.StringVar(&address, "address", ":8443", "server address")
 	flag.StringVar(&configFile, "configfile", "", "path to config file")
-	flag.StringVar(&backendType, "backend", "", "backend type (kubernetes, conjur, encrypt)")
+	flag.StringVar(&backendType, "backend", "", "backend type (kubernetes, conjur, encrypt, vault, routing)")
 }
 
 func setupLog(logLevel string) logr.Logger {
@@ -66,50 +69,138 @@ func setupLog(logLevel string) logr.Logger {
 	return zapr.NewLogger(zapLog)
 }
 
-func newController(ctx context.Context, cfg *config.ServerConfig) (c controller.Controller, err error) {
+// newController creates the controller of the backend. The metadata store and the onboarded scopes
+// are returned as well, so the rotation scheduler can use them.
+func newController(ctx context.Context, cfg *config.ServerConfig) (controller.Controller, *metadata.Store, smbackend.ScopeLister, error) {
 	if backendType != "" {
 		cfg.Backend.Type = backendType
 	}
-	if cfg.Backend.Type == "" {
-		cfg.Backend.Type = "kubernetes"
+	ctrl, scopes, err := setup.NewController(ctx, cfg.Backend, cfg.Blueprint)
+	if err != nil {
+		return nil, nil, nil, err
 	}
-	cacheDuration, err := time.ParseDuration(cfg.Backend.GetDefault("cache_duration", "10s"))
+	// The metadata is written using the controller of the backend, so it is neither published nor soft-deleted.
+	// Scopes that have been onboarded before the metadata secret existed are onboarded again when it is first written.
+	meta := metadata.NewStore(ctrl).WithOnboarder(ctrl)
+	ctrl = metadata.NewController(ctrl)
+	ctrl, err = newGeneratingController(ctrl, meta, cfg.Generators)
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to parse cache duration")
+		return nil, nil, nil, err
 	}
+	return newSoftDeletingController(ctx, ctrl, scopes, cfg.Deletion), meta, scopes, nil
+}
 
-	switch cfg.Backend.Type {
-	case "conjur":
-		conjurWriteApi := conjur.NewWriteApiOrDie()
-		conjurReadApi := conjur.NewReadOnlyApiOrDie()
+// newSoftDeletingController wraps the controller to keep deleted secrets for the retention
+// and purges them in the background afterwards
+func newSoftDeletingController(ctx context.Context, ctrl controller.Controller, scopes smbackend.ScopeLister, cfg config.DeletionConfig) controller.Controller {
+	if cfg.Retention <= 0 {
+		return ctrl
+	}
+	purger := tombstone.NewPurger(ctrl, scopes, cfg.Retention.Duration())
+	if cfg.PurgeInterval > 0 {
+		purger.PurgeInterval = cfg.PurgeInterval.Duration()
+	}
+	go purger.Start(ctx)
+	return tombstone.NewController(ctrl, purger)
+}
 
-		backend := conjur.NewBackend(conjurWriteApi, conjurReadApi)
-		if cfg.Backend.GetDefault("disable_cache", "false") == trueStr {
-			backend = cache.NewCachedBackend(backend, cacheDuration)
+// newGeneratingController wraps the controller to keep the generators of the secrets
+// and registers the CA to sign certificates
+func newGeneratingController(ctrl controller.Controller, meta *metadata.Store, cfg config.GeneratorsConfig) (controller.Controller, error) {
+	if cfg.CA != nil {
+		if len(cfg.CA.AllowedNames) == 0 {
+			return nil, errors.New("allowed_names of the CA must be set")
 		}
-		onboarder := conjur.NewOnboarder(conjurWriteApi, backend)
-		bouncer := conjur.NewDefaultBouncer()
-		bouncer.StartN(ctx, 1)
-		onboarder.WithBouncer(bouncer)
-		c = controller.NewController(backend, onboarder)
-
-	case "kubernetes":
-		k8sClient, err := kubernetes.NewCachedClient(ctx, ctrlr.GetConfigOrDie())
+		if err := cfg.CA.Constraints.Validate(); err != nil {
+			return nil, errors.Wrap(err, "invalid constraints of the CA")
+		}
+		ca, err := generator.LoadCA(cfg.CA.CertFile, cfg.CA.KeyFile)
 		if err != nil {
-			return nil, errors.Wrap(err, "failed to create kubernetes client")
+			return nil, err
 		}
-		backend := kubernetes.NewBackend(k8sClient)
-		if cfg.Backend.GetDefault("disable_cache", "false") == trueStr {
-			backend = cache.NewCachedBackend(backend, cacheDuration)
+		generator.Register(generator.TypeCertificate, generator.NewCertificateFactory(ca.WithConstraints(cfg.CA.Constraints)))
+	}
+
+	return generation.NewController(ctrl, generation.NewMetadataStore(meta)), nil
+}
+
+// newScheduler creates the rotation scheduler. Its records are kept in the metadata of the secrets,
+// and the existing secrets of the onboarded scopes are tracked when it is started.
+func newScheduler(ctrl controller.Controller, meta *metadata.Store, scopes smbackend.ScopeLister, cfg config.RotationConfig) (*rotation.Scheduler, error) {
+	scheduler, err := rotation.NewScheduler(ctrl, rotation.NewMetadataStore(meta, scopes), cfg.Policies)
+	if err != nil {
+		return nil, err
+	}
+	scheduler.WithDiscovery(scopes, ctrl)
+	if cfg.CheckInterval > 0 {
+		scheduler.CheckInterval = cfg.CheckInterval.Duration()
+	}
+	scheduler.WithNotifier(rotation.LogNotifier)
+	if cfg.WebhookURL != "" {
+		scheduler.WithNotifier(rotation.NewWebhookNotifier(cfg.WebhookURL))
+	}
+	return scheduler, nil
+}
+
+// newAuthorizer creates the authorizer of the configured policy.
+// A policy file is reloaded in the background when it changes.
+func newAuthorizer(ctx context.Context, cfg config.SecurityConfig) (*policy.Authorizer, error) {
+	p := cfg.Policy
+	if p == nil {
+		p = &policy.Policy{}
+	}
+	store, err := policy.NewStore(p)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid policy")
+	}
+	if cfg.PolicyFile != "" {
+		if err := policy.NewFileReloader(store, cfg.PolicyFile).Start(ctx); err != nil {
+			return nil, err
 		}
-		onboarder := kubernetes.NewOnboarder(k8sClient)
-		c = controller.NewController(backend, onboarder)
+	}
 
-	default:
-		return nil, errors.Errorf("unknown backend type: %s", cfg.Backend.Type)
+	// The labels of the callers can only be resolved if the Kubernetes API is available
+	var labels policy.LabelResolver
+	if restConfig, err := ctrlr.GetConfig(); err == nil {
+		k8sClient, err := client.New(restConfig, client.Options{})
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create kubernetes client")
+		}
+		labels = policy.NewPodLabelResolver(k8sClient)
+	} else {
+		logr.FromContextOrDiscard(ctx).Info("Labels of the callers cannot be resolved, deny rules that depend on them apply to all callers", "reason", err.Error())
 	}
+	return policy.NewAuthorizer(store, labels), nil
+}
 
-	return c, nil
+// newAuditSink creates the configured sinks. Webhooks are called in the background.
+func newAuditSink(ctx context.Context, cfg config.AuditConfig, log logr.Logger) (audit.Sink, error) {
+	if len(cfg.Sinks) == 0 {
+		return audit.NewStdoutSink(), nil
+	}
+	sinks := make(audit.MultiSink, 0, len(cfg.Sinks))
+	for _, sinkCfg := range cfg.Sinks {
+		switch sinkCfg.Type {
+		case "stdout":
+			sinks = append(sinks, audit.NewStdoutSink())
+		case "file":
+			fileSink, err := audit.NewFileSink(sinkCfg.Path)
+			if err != nil {
+				return nil, err
+			}
+			sinks = append(sinks, fileSink)
+		case "webhook":
+			if sinkCfg.URL == "" {
+				return nil, errors.New("url of the audit webhook must be set")
+			}
+			asyncSink := audit.NewAsyncSink(audit.NewWebhookSink(sinkCfg.URL), audit.DefaultBufferSize)
+			go asyncSink.Start(logr.NewContext(ctx, log.WithName("audit")))
+			sinks = append(sinks, asyncSink)
+		default:
+			return nil, errors.Errorf("unknown audit sink type: %s", sinkCfg.Type)
+		}
+	}
+	return sinks, nil
 }
 
 func main() {
@@ -121,11 +212,42 @@ func main() {
 	ctrlr.SetLogger(log)
 	cfg := config.GetConfigOrDie(configFile)
 
-	ctrl, err := newController(ctx, cfg)
+	ctrl, meta, scopes, err := newController(logr.NewContext(ctx, log), cfg)
 	if err != nil {
 		log.Error(err, "failed to create controller")
 		return
 	}
+	// Rotated secrets must be published as well, so the scheduler uses the publishing controller
+	broker := events.NewBroker(cfg.Events.Capacity)
+	ctrl = events.NewController(ctrl, broker)
+	if cfg.Rotation.Enabled {
+		scheduler, err := newScheduler(ctrl, meta, scopes, cfg.Rotation)
+		if err != nil {
+			log.Error(err, "failed to create rotation scheduler")
+			return
+		}
+		ctrl = rotation.NewController(ctrl, scheduler)
+		go scheduler.Start(logr.NewContext(ctx, log))
+	}
+	var authorizer *policy.Authorizer
+	if cfg.Security.Enabled && (cfg.Security.Policy != nil || cfg.Security.PolicyFile != "") {
+		authorizer, err = newAuthorizer(logr.NewContext(ctx, log.WithName("policy")), cfg.Security)
+		if err != nil {
+			log.Error(err, "failed to create authorizer")
+			return
+		}
+		ctrl = policy.NewController(ctrl, authorizer)
+	}
+	// Requests denied by the policy are audited by the controller, those denied by the middleware by the audit middleware
+	var auditSink audit.Sink
+	if cfg.Audit.Enabled {
+		auditSink, err = newAuditSink(ctx, cfg.Audit, log)
+		if err != nil {
+			log.Error(err, "failed to create audit sink")
+			return
+		}
+		ctrl = audit.NewController(ctrl, auditSink)
+	}
 
 	appCfg := cs.NewAppConfig()
 	appCfg.CtxLog = &log
@@ -136,8 +258,10 @@ func main() {
 	probesCtrl.Register(app, cs.ControllerOpts{})
 
 	apiGroup := app.Group("/api")
-	handler := api.NewStrictHandler(handler.NewHandler(ctrl), nil)
+	router := handler.NewRouter(apiGroup)
+	handler := api.NewStrictHandler(handler.NewHandler(ctrl, broker).WithAuthorizer(authorizer), nil)
 
+	var tlsOpts []serve.Option
 	if cfg.Security.Enabled {
 		opts := []middleware.KubernetesAuthOption{
 			middleware.WithTrustedIssuers(cfg.Security.TrustedIssuers...),
@@ -148,10 +272,30 @@ func main() {
 			log.Info("🔑 Running in cluster")
 			opts = append(opts, middleware.WithInClusterIssuer())
 		}
+		for _, oidcCfg := range cfg.Security.OIDC {
+			authenticator, err := middleware.NewOIDCAuthenticator(oidcCfg)
+			if err != nil {
+				log.Error(err, "failed to create OIDC authenticator", "issuer", oidcCfg.Issuer)
+				return
+			}
+			opts = append(opts, middleware.WithAuthenticators(authenticator))
+		}
+		if cfg.Security.MTLS != nil {
+			authenticator, err := middleware.NewMTLSAuthenticator(*cfg.Security.MTLS)
+			if err != nil {
+				log.Error(err, "failed to create mTLS authenticator")
+				return
+			}
+			opts = append(opts, middleware.WithAuthenticators(authenticator))
+			tlsOpts = append(tlsOpts, serve.WithClientCAs(authenticator.ClientCAs()))
+		}
+		if auditSink != nil {
+			apiGroup.Use(audit.NewMiddleware(auditSink))
+		}
 		apiGroup.Use(middleware.NewKubernetesAuthz(opts...))
 	}
 
-	api.RegisterHandlersWithOptions(apiGroup, handler, api.FiberServerOptions{})
+	api.RegisterHandlersWithOptions(router, handler, api.FiberServerOptions{})
 
 	go func() {
 		if disableTls {
@@ -164,7 +308,7 @@ func main() {
 		}
 
 		ctx = logr.NewContext(ctx, log.WithName("server"))
-		if err := serve.ServeTLS(ctx, app, address, tlsCert, tlsKey); err != nil {
+		if err := serve.ServeTLS(ctx, app, address, tlsCert, tlsKey, tlsOpts...); err != nil {
 			log.Error(err, "failed to start server")
 			os.Exit(1)
 		}
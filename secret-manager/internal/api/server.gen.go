@@ -12,12 +12,48 @@ import (
 	"net/url"
 	"path"
 	"strings"
+	"time"
 
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/gofiber/fiber/v2"
 	"github.com/oapi-codegen/runtime"
 )
 
+// Defines values for SecretEventType.
+const (
+	Deleted SecretEventType = "deleted"
+	Updated SecretEventType = "updated"
+)
+
+// Defines values for SecretGeneratorCurve.
+const (
+	P256 SecretGeneratorCurve = "P-256"
+	P384 SecretGeneratorCurve = "P-384"
+	P521 SecretGeneratorCurve = "P-521"
+)
+
+// Defines values for SecretGeneratorIssuer.
+const (
+	Ca         SecretGeneratorIssuer = "ca"
+	SelfSigned SecretGeneratorIssuer = "self-signed"
+)
+
+// Defines values for SecretGeneratorKeyType.
+const (
+	SecretGeneratorKeyTypeEc  SecretGeneratorKeyType = "ec"
+	SecretGeneratorKeyTypeRsa SecretGeneratorKeyType = "rsa"
+)
+
+// Defines values for SecretGeneratorType.
+const (
+	SecretGeneratorTypeAlphanumeric SecretGeneratorType = "alphanumeric"
+	SecretGeneratorTypeCertificate  SecretGeneratorType = "certificate"
+	SecretGeneratorTypeEc           SecretGeneratorType = "ec"
+	SecretGeneratorTypePassword     SecretGeneratorType = "password"
+	SecretGeneratorTypeRsa          SecretGeneratorType = "rsa"
+	SecretGeneratorTypeUuid         SecretGeneratorType = "uuid"
+)
+
 // ApiProblem Based on https://www.rfc-editor.org/rfc/rfc9457.html
 type ApiProblem struct {
 	Detail   string  `json:"detail"`
@@ -27,6 +63,21 @@ type ApiProblem struct {
 	Type     string  `json:"type"`
 }
 
+// BatchGetItem defines model for BatchGetItem.
+type BatchGetItem struct {
+	// Error Based on https://www.rfc-editor.org/rfc/rfc9457.html
+	Error *ApiProblem `json:"error,omitempty"`
+
+	// Id A reference to a secret
+	Id SecretRef `json:"id"`
+
+	// Stale True if the value has been served from the cache because the backend is unavailable. It might be outdated.
+	Stale *bool `json:"stale,omitempty"`
+
+	// Value The value of the secret. It is not set if an error occurred.
+	Value *string `json:"value,omitempty"`
+}
+
 // ListSecretItem defines model for ListSecretItem.
 type ListSecretItem struct {
 	// Id A reference to a secret
@@ -41,19 +92,124 @@ type Secret struct {
 	// Id A reference to a secret
 	Id SecretRef `json:"id"`
 
+	// Stale True if the value has been served from the cache because the backend is unavailable. It might be outdated.
+	Stale *bool `json:"stale,omitempty"`
+
 	// Value If empty, a random secret will be generated
 	Value string `json:"value"`
 }
 
+// SecretEvent defines model for SecretEvent.
+type SecretEvent struct {
+	// Id A reference to a secret
+	Id *SecretRef `json:"id,omitempty"`
+
+	// Secret The ID of the secret without checksum. If an environment, team or application has been deleted, it is the prefix of the IDs of all of its secrets.
+	Secret string          `json:"secret"`
+	Time   time.Time       `json:"time"`
+	Type   SecretEventType `json:"type"`
+}
+
+// SecretEventType defines model for SecretEvent.Type.
+type SecretEventType string
+
+// SecretGenerator Generates the value of the secret. The value must be set to `rotate`.
+// The generator is kept, so later rotations produce the same kind of secret.
+type SecretGenerator struct {
+	// Bits The size of an RSA key
+	Bits *int `json:"bits,omitempty"`
+
+	// CommonName The common name of a certificate
+	CommonName *string `json:"commonName,omitempty"`
+
+	// Curve The curve of an EC key
+	Curve *SecretGeneratorCurve `json:"curve,omitempty"`
+
+	// DnsNames The DNS names of a certificate
+	DnsNames *[]string `json:"dnsNames,omitempty"`
+
+	// Issuer Whether a certificate is self-signed or signed by the CA of the server.
+	// The CA only signs the names that are allowed for the secret, up to its maximum validity.
+	Issuer *SecretGeneratorIssuer `json:"issuer,omitempty"`
+
+	// KeyType The type of the key of a certificate
+	KeyType *SecretGeneratorKeyType `json:"keyType,omitempty"`
+
+	// Length The length of an alphanumeric value or password
+	Length *int `json:"length,omitempty"`
+
+	// MinDigits The minimum number of digits of a password
+	MinDigits *int `json:"minDigits,omitempty"`
+
+	// MinLower The minimum number of lowercase letters of a password
+	MinLower *int `json:"minLower,omitempty"`
+
+	// MinSymbols The minimum number of symbols of a password
+	MinSymbols *int `json:"minSymbols,omitempty"`
+
+	// MinUpper The minimum number of uppercase letters of a password
+	MinUpper *int `json:"minUpper,omitempty"`
+
+	// NoSymbols Restricts a password to letters and digits
+	NoSymbols *bool `json:"noSymbols,omitempty"`
+
+	// Symbols The symbols that are used in a password
+	Symbols *string             `json:"symbols,omitempty"`
+	Type    SecretGeneratorType `json:"type"`
+
+	// ValidityDays The validity of a certificate in days
+	ValidityDays *int `json:"validityDays,omitempty"`
+}
+
+// SecretGeneratorCurve The curve of an EC key
+type SecretGeneratorCurve string
+
+// SecretGeneratorIssuer Whether a certificate is self-signed or signed by the CA of the server.
+// The CA only signs the names that are allowed for the secret, up to its maximum validity.
+type SecretGeneratorIssuer string
+
+// SecretGeneratorKeyType The type of the key of a certificate
+type SecretGeneratorKeyType string
+
+// SecretGeneratorType defines model for SecretGenerator.Type.
+type SecretGeneratorType string
+
 // SecretRef A reference to a secret
 type SecretRef = string
 
-// QuerySecretId defines model for QuerySecretId.
-type QuerySecretId = []SecretRef
+// SecretVersion defines model for SecretVersion.
+type SecretVersion struct {
+	// Id A reference to a secret
+	Id SecretRef `json:"id"`
+
+	// Version The version of the secret, e.g. v3
+	Version string `json:"version"`
+}
+
+// Cursor defines model for Cursor.
+type Cursor = string
+
+// Limit defines model for Limit.
+type Limit = int
+
+// QueryAppId defines model for QueryAppId.
+type QueryAppId = string
+
+// QueryEnvId defines model for QueryEnvId.
+type QueryEnvId = string
+
+// QueryTeamId defines model for QueryTeamId.
+type QueryTeamId = string
 
 // SecretId A reference to a secret
 type SecretId = SecretRef
 
+// BatchGetResponse defines model for BatchGetResponse.
+type BatchGetResponse struct {
+	// Items The results in the order of the requested IDs
+	Items []BatchGetItem `json:"items"`
+}
+
 // ErrorResponse Based on https://www.rfc-editor.org/rfc/rfc9457.html
 type ErrorResponse = ApiProblem
 
@@ -63,23 +219,63 @@ type OnboardingResponse struct {
 	Items []ListSecretItem `json:"items"`
 }
 
-// SecretListReponse defines model for SecretListReponse.
-type SecretListReponse struct {
-	// Items A list of secrets
-	Items []Secret `json:"items"`
+// PublicKeyResponse defines model for PublicKeyResponse.
+type PublicKeyResponse struct {
+	// Id A reference to a secret
+	Id SecretRef `json:"id"`
+
+	// PublicKey The PEM-encoded public key or certificates
+	PublicKey string `json:"publicKey"`
+}
+
+// SecretEventListResponse defines model for SecretEventListResponse.
+type SecretEventListResponse struct {
+	// Cursor The cursor of the next request
+	Cursor string `json:"cursor"`
+
+	// Items The events since the cursor, the oldest first
+	Items []SecretEvent `json:"items"`
+
+	// Reset Events might have been missed since the cursor
+	Reset bool `json:"reset"`
+}
+
+// SecretRefListResponse defines model for SecretRefListResponse.
+type SecretRefListResponse struct {
+	// Items A list of secret references without values
+	Items []ListSecretItem `json:"items"`
+
+	// Next The cursor of the next page. It is not set on the last page.
+	Next *string `json:"next,omitempty"`
 }
 
 // SecretResponse defines model for SecretResponse.
 type SecretResponse = Secret
 
+// SecretVersionListResponse defines model for SecretVersionListResponse.
+type SecretVersionListResponse struct {
+	// Items The versions of the secret, the latest first
+	Items []SecretVersion `json:"items"`
+}
+
 // SecretWriteResponse defines model for SecretWriteResponse.
 type SecretWriteResponse struct {
 	// Id A reference to a secret
 	Id SecretRef `json:"id"`
 }
 
+// BatchGetRequest defines model for BatchGetRequest.
+type BatchGetRequest struct {
+	// Ids The references or IDs of the secrets
+	Ids []SecretRef `json:"ids"`
+}
+
 // SecretWriteRequest defines model for SecretWriteRequest.
 type SecretWriteRequest struct {
+	// Generator Generates the value of the secret. The value must be set to `rotate`.
+	// The generator is kept, so later rotations produce the same kind of secret.
+	Generator *SecretGenerator `json:"generator,omitempty"`
+
 	// Value This is the value of the secret.
 	// If set to `{{rotate}}`, the secret will be randomly generated
 	// and rotated.
@@ -87,14 +283,42 @@ type SecretWriteRequest struct {
 	Value string `json:"value"`
 }
 
+// WatchSecretEventsParams defines parameters for WatchSecretEvents.
+type WatchSecretEventsParams struct {
+	// Prefix The prefix of the secret IDs, e.g. `my-env:my-team:` for all secrets of a team and its applications. If empty, all secrets are watched.
+	Prefix *string `form:"prefix,omitempty" json:"prefix,omitempty"`
+
+	// Cursor The cursor returned by the previous request
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Timeout The maximum number of seconds to wait for events
+	Timeout *int `form:"timeout,omitempty" json:"timeout,omitempty"`
+}
+
 // ListSecretsParams defines parameters for ListSecrets.
 type ListSecretsParams struct {
-	// SecretId The id or reference to a secret
-	SecretId *QuerySecretId `form:"secretId,omitempty" json:"secretId,omitempty"`
+	// Env The environment of the secrets
+	Env QueryEnvId `form:"env" json:"env"`
+
+	// Team The team of the secrets. If empty, the environment secrets are listed
+	Team *QueryTeamId `form:"team,omitempty" json:"team,omitempty"`
+
+	// App The application of the secrets. If empty, the team secrets are listed. Requires the team to be set.
+	App *QueryAppId `form:"app,omitempty" json:"app,omitempty"`
+
+	// Limit The maximum number of items that are returned
+	Limit *Limit `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor The cursor returned by the previous page
+	Cursor *Cursor `form:"cursor,omitempty" json:"cursor,omitempty"`
 }
 
 // PutSecretJSONBody defines parameters for PutSecret.
 type PutSecretJSONBody struct {
+	// Generator Generates the value of the secret. The value must be set to `rotate`.
+	// The generator is kept, so later rotations produce the same kind of secret.
+	Generator *SecretGenerator `json:"generator,omitempty"`
+
 	// Value This is the value of the secret.
 	// If set to `{{rotate}}`, the secret will be randomly generated
 	// and rotated.
@@ -102,11 +326,23 @@ type PutSecretJSONBody struct {
 	Value string `json:"value"`
 }
 
+// BatchGetSecretsJSONBody defines parameters for BatchGetSecrets.
+type BatchGetSecretsJSONBody struct {
+	// Ids The references or IDs of the secrets
+	Ids []SecretRef `json:"ids"`
+}
+
 // PutSecretJSONRequestBody defines body for PutSecret for application/json ContentType.
 type PutSecretJSONRequestBody PutSecretJSONBody
 
+// BatchGetSecretsJSONRequestBody defines body for BatchGetSecrets for application/json ContentType.
+type BatchGetSecretsJSONRequestBody BatchGetSecretsJSONBody
+
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
+	// Watch the changes of secrets
+	// (GET /v1/events)
+	WatchSecretEvents(c *fiber.Ctx, params WatchSecretEventsParams) error
 	// Delete an environment
 	// (DELETE /v1/onboarding/environments/{envId})
 	DeleteEnvironment(c *fiber.Ctx, envId string) error
@@ -125,15 +361,30 @@ type ServerInterface interface {
 	// Create or update an app
 	// (PUT /v1/onboarding/environments/{envId}/teams/{teamId}/apps/{appId})
 	UpsertApp(c *fiber.Ctx, envId string, teamId string, appId string) error
-	// Get one or multiple secrets
+	// List the secrets of an environment, team or application
 	// (GET /v1/secrets)
 	ListSecrets(c *fiber.Ctx, params ListSecretsParams) error
+	// Delete a secret
+	// (DELETE /v1/secrets/{secretId})
+	DeleteSecret(c *fiber.Ctx, secretId SecretId) error
 	// Get a specific secret
 	// (GET /v1/secrets/{secretId})
 	GetSecret(c *fiber.Ctx, secretId SecretId) error
 	// Create or update a secret
 	// (PUT /v1/secrets/{secretId})
 	PutSecret(c *fiber.Ctx, secretId SecretId) error
+	// Get the public key of a secret
+	// (GET /v1/secrets/{secretId}/public)
+	GetPublicKey(c *fiber.Ctx, secretId SecretId) error
+	// Restore a deleted secret
+	// (POST /v1/secrets/{secretId}/undelete)
+	UndeleteSecret(c *fiber.Ctx, secretId SecretId) error
+	// List the versions of a secret
+	// (GET /v1/secrets/{secretId}/versions)
+	ListSecretVersions(c *fiber.Ctx, secretId SecretId) error
+	// Get many secrets at once
+	// (POST /v1/secrets:batchGet)
+	BatchGetSecrets(c *fiber.Ctx) error
 }
 
 // ServerInterfaceWrapper converts contexts to parameters.
@@ -143,6 +394,44 @@ type ServerInterfaceWrapper struct {
 
 type MiddlewareFunc fiber.Handler
 
+// WatchSecretEvents operation middleware
+func (siw *ServerInterfaceWrapper) WatchSecretEvents(c *fiber.Ctx) error {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params WatchSecretEventsParams
+
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for query string: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "prefix" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "prefix", query, &params.Prefix)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter prefix: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", query, &params.Cursor)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter cursor: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "timeout" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "timeout", query, &params.Timeout)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter timeout: %w", err).Error())
+	}
+
+	return siw.Handler.WatchSecretEvents(c, params)
+}
+
 // DeleteEnvironment operation middleware
 func (siw *ServerInterfaceWrapper) DeleteEnvironment(c *fiber.Ctx) error {
 
@@ -301,16 +590,68 @@ func (siw *ServerInterfaceWrapper) ListSecrets(c *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for query string: %w", err).Error())
 	}
 
-	// ------------- Optional query parameter "secretId" -------------
+	// ------------- Required query parameter "env" -------------
+
+	if paramValue := c.Query("env"); paramValue != "" {
+
+	} else {
+		err = fmt.Errorf("Query argument env is required, but not found")
+		c.Status(fiber.StatusBadRequest).JSON(err)
+		return err
+	}
 
-	err = runtime.BindQueryParameter("form", true, false, "secretId", query, &params.SecretId)
+	err = runtime.BindQueryParameter("form", true, true, "env", query, &params.Env)
 	if err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter secretId: %w", err).Error())
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter env: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "team" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "team", query, &params.Team)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter team: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "app" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "app", query, &params.App)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter app: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", query, &params.Limit)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter limit: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", query, &params.Cursor)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter cursor: %w", err).Error())
 	}
 
 	return siw.Handler.ListSecrets(c, params)
 }
 
+// DeleteSecret operation middleware
+func (siw *ServerInterfaceWrapper) DeleteSecret(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "secretId" -------------
+	var secretId SecretId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "secretId", c.Params("secretId"), &secretId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter secretId: %w", err).Error())
+	}
+
+	return siw.Handler.DeleteSecret(c, secretId)
+}
+
 // GetSecret operation middleware
 func (siw *ServerInterfaceWrapper) GetSecret(c *fiber.Ctx) error {
 
@@ -343,6 +684,60 @@ func (siw *ServerInterfaceWrapper) PutSecret(c *fiber.Ctx) error {
 	return siw.Handler.PutSecret(c, secretId)
 }
 
+// GetPublicKey operation middleware
+func (siw *ServerInterfaceWrapper) GetPublicKey(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "secretId" -------------
+	var secretId SecretId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "secretId", c.Params("secretId"), &secretId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter secretId: %w", err).Error())
+	}
+
+	return siw.Handler.GetPublicKey(c, secretId)
+}
+
+// UndeleteSecret operation middleware
+func (siw *ServerInterfaceWrapper) UndeleteSecret(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "secretId" -------------
+	var secretId SecretId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "secretId", c.Params("secretId"), &secretId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter secretId: %w", err).Error())
+	}
+
+	return siw.Handler.UndeleteSecret(c, secretId)
+}
+
+// ListSecretVersions operation middleware
+func (siw *ServerInterfaceWrapper) ListSecretVersions(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "secretId" -------------
+	var secretId SecretId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "secretId", c.Params("secretId"), &secretId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter secretId: %w", err).Error())
+	}
+
+	return siw.Handler.ListSecretVersions(c, secretId)
+}
+
+// BatchGetSecrets operation middleware
+func (siw *ServerInterfaceWrapper) BatchGetSecrets(c *fiber.Ctx) error {
+
+	return siw.Handler.BatchGetSecrets(c)
+}
+
 // FiberServerOptions provides options for the Fiber server.
 type FiberServerOptions struct {
 	BaseURL     string
@@ -364,6 +759,8 @@ func RegisterHandlersWithOptions(router fiber.Router, si ServerInterface, option
 		router.Use(fiber.Handler(m))
 	}
 
+	router.Get(options.BaseURL+"/v1/events", wrapper.WatchSecretEvents)
+
 	router.Delete(options.BaseURL+"/v1/onboarding/environments/:envId", wrapper.DeleteEnvironment)
 
 	router.Put(options.BaseURL+"/v1/onboarding/environments/:envId", wrapper.UpsertEnvironment)
@@ -378,10 +775,25 @@ func RegisterHandlersWithOptions(router fiber.Router, si ServerInterface, option
 
 	router.Get(options.BaseURL+"/v1/secrets", wrapper.ListSecrets)
 
+	router.Delete(options.BaseURL+"/v1/secrets/:secretId", wrapper.DeleteSecret)
+
 	router.Get(options.BaseURL+"/v1/secrets/:secretId", wrapper.GetSecret)
 
 	router.Put(options.BaseURL+"/v1/secrets/:secretId", wrapper.PutSecret)
 
+	router.Get(options.BaseURL+"/v1/secrets/:secretId/public", wrapper.GetPublicKey)
+
+	router.Post(options.BaseURL+"/v1/secrets/:secretId/undelete", wrapper.UndeleteSecret)
+
+	router.Get(options.BaseURL+"/v1/secrets/:secretId/versions", wrapper.ListSecretVersions)
+
+	router.Post(options.BaseURL+"/v1/secrets:batchGet", wrapper.BatchGetSecrets)
+
+}
+
+type BatchGetResponseJSONResponse struct {
+	// Items The results in the order of the requested IDs
+	Items []BatchGetItem `json:"items"`
 }
 
 type ErrorResponseApplicationProblemPlusJSONResponse ApiProblem
@@ -394,18 +806,84 @@ type OnboardingResponseJSONResponse struct {
 	Items []ListSecretItem `json:"items"`
 }
 
-type SecretListReponseJSONResponse struct {
-	// Items A list of secrets
-	Items []Secret `json:"items"`
+type PublicKeyResponseJSONResponse struct {
+	// Id A reference to a secret
+	Id SecretRef `json:"id"`
+
+	// PublicKey The PEM-encoded public key or certificates
+	PublicKey string `json:"publicKey"`
+}
+
+type SecretEventListResponseJSONResponse struct {
+	// Cursor The cursor of the next request
+	Cursor string `json:"cursor"`
+
+	// Items The events since the cursor, the oldest first
+	Items []SecretEvent `json:"items"`
+
+	// Reset Events might have been missed since the cursor
+	Reset bool `json:"reset"`
+}
+
+type SecretRefListResponseJSONResponse struct {
+	// Items A list of secret references without values
+	Items []ListSecretItem `json:"items"`
+
+	// Next The cursor of the next page. It is not set on the last page.
+	Next *string `json:"next,omitempty"`
 }
 
 type SecretResponseJSONResponse Secret
 
+type SecretVersionListResponseJSONResponse struct {
+	// Items The versions of the secret, the latest first
+	Items []SecretVersion `json:"items"`
+}
+
 type SecretWriteResponseJSONResponse struct {
 	// Id A reference to a secret
 	Id SecretRef `json:"id"`
 }
 
+type WatchSecretEventsRequestObject struct {
+	Params WatchSecretEventsParams
+}
+
+type WatchSecretEventsResponseObject interface {
+	VisitWatchSecretEventsResponse(ctx *fiber.Ctx) error
+}
+
+type WatchSecretEvents200JSONResponse struct {
+	SecretEventListResponseJSONResponse
+}
+
+func (response WatchSecretEvents200JSONResponse) VisitWatchSecretEventsResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type WatchSecretEvents400ApplicationProblemPlusJSONResponse struct {
+	ErrorResponseApplicationProblemPlusJSONResponse
+}
+
+func (response WatchSecretEvents400ApplicationProblemPlusJSONResponse) VisitWatchSecretEventsResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/problem+json")
+	ctx.Status(400)
+
+	return ctx.JSON(&response)
+}
+
+type WatchSecretEvents500ApplicationProblemPlusJSONResponse ApiProblem
+
+func (response WatchSecretEvents500ApplicationProblemPlusJSONResponse) VisitWatchSecretEventsResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/problem+json")
+	ctx.Status(500)
+
+	return ctx.JSON(&response)
+}
+
 type DeleteEnvironmentRequestObject struct {
 	EnvId string `json:"envId"`
 }
@@ -636,7 +1114,9 @@ type ListSecretsResponseObject interface {
 	VisitListSecretsResponse(ctx *fiber.Ctx) error
 }
 
-type ListSecrets200JSONResponse struct{ SecretListReponseJSONResponse }
+type ListSecrets200JSONResponse struct {
+	SecretRefListResponseJSONResponse
+}
 
 func (response ListSecrets200JSONResponse) VisitListSecretsResponse(ctx *fiber.Ctx) error {
 	ctx.Response().Header.Set("Content-Type", "application/json")
@@ -665,46 +1145,90 @@ func (response ListSecrets500ApplicationProblemPlusJSONResponse) VisitListSecret
 	return ctx.JSON(&response)
 }
 
-type GetSecretRequestObject struct {
+type DeleteSecretRequestObject struct {
 	SecretId SecretId `json:"secretId"`
 }
 
-type GetSecretResponseObject interface {
-	VisitGetSecretResponse(ctx *fiber.Ctx) error
+type DeleteSecretResponseObject interface {
+	VisitDeleteSecretResponse(ctx *fiber.Ctx) error
 }
 
-type GetSecret200JSONResponse struct{ SecretResponseJSONResponse }
-
-func (response GetSecret200JSONResponse) VisitGetSecretResponse(ctx *fiber.Ctx) error {
-	ctx.Response().Header.Set("Content-Type", "application/json")
-	ctx.Status(200)
+type DeleteSecret204Response = NoContentResponse
 
-	return ctx.JSON(&response)
+func (response DeleteSecret204Response) VisitDeleteSecretResponse(ctx *fiber.Ctx) error {
+	ctx.Status(204)
+	return nil
 }
 
-type GetSecret400ApplicationProblemPlusJSONResponse struct {
+type DeleteSecret400ApplicationProblemPlusJSONResponse struct {
 	ErrorResponseApplicationProblemPlusJSONResponse
 }
 
-func (response GetSecret400ApplicationProblemPlusJSONResponse) VisitGetSecretResponse(ctx *fiber.Ctx) error {
+func (response DeleteSecret400ApplicationProblemPlusJSONResponse) VisitDeleteSecretResponse(ctx *fiber.Ctx) error {
 	ctx.Response().Header.Set("Content-Type", "application/problem+json")
 	ctx.Status(400)
 
 	return ctx.JSON(&response)
 }
 
-type GetSecret500ApplicationProblemPlusJSONResponse ApiProblem
+type DeleteSecret404ApplicationProblemPlusJSONResponse ApiProblem
 
-func (response GetSecret500ApplicationProblemPlusJSONResponse) VisitGetSecretResponse(ctx *fiber.Ctx) error {
+func (response DeleteSecret404ApplicationProblemPlusJSONResponse) VisitDeleteSecretResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/problem+json")
+	ctx.Status(404)
+
+	return ctx.JSON(&response)
+}
+
+type DeleteSecret500ApplicationProblemPlusJSONResponse ApiProblem
+
+func (response DeleteSecret500ApplicationProblemPlusJSONResponse) VisitDeleteSecretResponse(ctx *fiber.Ctx) error {
 	ctx.Response().Header.Set("Content-Type", "application/problem+json")
 	ctx.Status(500)
 
 	return ctx.JSON(&response)
 }
 
-type PutSecretRequestObject struct {
+type GetSecretRequestObject struct {
 	SecretId SecretId `json:"secretId"`
-	Body     *PutSecretJSONRequestBody
+}
+
+type GetSecretResponseObject interface {
+	VisitGetSecretResponse(ctx *fiber.Ctx) error
+}
+
+type GetSecret200JSONResponse struct{ SecretResponseJSONResponse }
+
+func (response GetSecret200JSONResponse) VisitGetSecretResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type GetSecret400ApplicationProblemPlusJSONResponse struct {
+	ErrorResponseApplicationProblemPlusJSONResponse
+}
+
+func (response GetSecret400ApplicationProblemPlusJSONResponse) VisitGetSecretResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/problem+json")
+	ctx.Status(400)
+
+	return ctx.JSON(&response)
+}
+
+type GetSecret500ApplicationProblemPlusJSONResponse ApiProblem
+
+func (response GetSecret500ApplicationProblemPlusJSONResponse) VisitGetSecretResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/problem+json")
+	ctx.Status(500)
+
+	return ctx.JSON(&response)
+}
+
+type PutSecretRequestObject struct {
+	SecretId SecretId `json:"secretId"`
+	Body     *PutSecretJSONRequestBody
 }
 
 type PutSecretResponseObject interface {
@@ -742,8 +1266,190 @@ func (response PutSecret500ApplicationProblemPlusJSONResponse) VisitPutSecretRes
 	return ctx.JSON(&response)
 }
 
+type GetPublicKeyRequestObject struct {
+	SecretId SecretId `json:"secretId"`
+}
+
+type GetPublicKeyResponseObject interface {
+	VisitGetPublicKeyResponse(ctx *fiber.Ctx) error
+}
+
+type GetPublicKey200JSONResponse struct{ PublicKeyResponseJSONResponse }
+
+func (response GetPublicKey200JSONResponse) VisitGetPublicKeyResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type GetPublicKey400ApplicationProblemPlusJSONResponse struct {
+	ErrorResponseApplicationProblemPlusJSONResponse
+}
+
+func (response GetPublicKey400ApplicationProblemPlusJSONResponse) VisitGetPublicKeyResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/problem+json")
+	ctx.Status(400)
+
+	return ctx.JSON(&response)
+}
+
+type GetPublicKey404ApplicationProblemPlusJSONResponse ApiProblem
+
+func (response GetPublicKey404ApplicationProblemPlusJSONResponse) VisitGetPublicKeyResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/problem+json")
+	ctx.Status(404)
+
+	return ctx.JSON(&response)
+}
+
+type GetPublicKey500ApplicationProblemPlusJSONResponse ApiProblem
+
+func (response GetPublicKey500ApplicationProblemPlusJSONResponse) VisitGetPublicKeyResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/problem+json")
+	ctx.Status(500)
+
+	return ctx.JSON(&response)
+}
+
+type UndeleteSecretRequestObject struct {
+	SecretId SecretId `json:"secretId"`
+}
+
+type UndeleteSecretResponseObject interface {
+	VisitUndeleteSecretResponse(ctx *fiber.Ctx) error
+}
+
+type UndeleteSecret200JSONResponse struct {
+	SecretWriteResponseJSONResponse
+}
+
+func (response UndeleteSecret200JSONResponse) VisitUndeleteSecretResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type UndeleteSecret400ApplicationProblemPlusJSONResponse struct {
+	ErrorResponseApplicationProblemPlusJSONResponse
+}
+
+func (response UndeleteSecret400ApplicationProblemPlusJSONResponse) VisitUndeleteSecretResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/problem+json")
+	ctx.Status(400)
+
+	return ctx.JSON(&response)
+}
+
+type UndeleteSecret404ApplicationProblemPlusJSONResponse ApiProblem
+
+func (response UndeleteSecret404ApplicationProblemPlusJSONResponse) VisitUndeleteSecretResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/problem+json")
+	ctx.Status(404)
+
+	return ctx.JSON(&response)
+}
+
+type UndeleteSecret500ApplicationProblemPlusJSONResponse ApiProblem
+
+func (response UndeleteSecret500ApplicationProblemPlusJSONResponse) VisitUndeleteSecretResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/problem+json")
+	ctx.Status(500)
+
+	return ctx.JSON(&response)
+}
+
+type ListSecretVersionsRequestObject struct {
+	SecretId SecretId `json:"secretId"`
+}
+
+type ListSecretVersionsResponseObject interface {
+	VisitListSecretVersionsResponse(ctx *fiber.Ctx) error
+}
+
+type ListSecretVersions200JSONResponse struct {
+	SecretVersionListResponseJSONResponse
+}
+
+func (response ListSecretVersions200JSONResponse) VisitListSecretVersionsResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type ListSecretVersions400ApplicationProblemPlusJSONResponse struct {
+	ErrorResponseApplicationProblemPlusJSONResponse
+}
+
+func (response ListSecretVersions400ApplicationProblemPlusJSONResponse) VisitListSecretVersionsResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/problem+json")
+	ctx.Status(400)
+
+	return ctx.JSON(&response)
+}
+
+type ListSecretVersions404ApplicationProblemPlusJSONResponse ApiProblem
+
+func (response ListSecretVersions404ApplicationProblemPlusJSONResponse) VisitListSecretVersionsResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/problem+json")
+	ctx.Status(404)
+
+	return ctx.JSON(&response)
+}
+
+type ListSecretVersions500ApplicationProblemPlusJSONResponse ApiProblem
+
+func (response ListSecretVersions500ApplicationProblemPlusJSONResponse) VisitListSecretVersionsResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/problem+json")
+	ctx.Status(500)
+
+	return ctx.JSON(&response)
+}
+
+type BatchGetSecretsRequestObject struct {
+	Body *BatchGetSecretsJSONRequestBody
+}
+
+type BatchGetSecretsResponseObject interface {
+	VisitBatchGetSecretsResponse(ctx *fiber.Ctx) error
+}
+
+type BatchGetSecrets200JSONResponse struct{ BatchGetResponseJSONResponse }
+
+func (response BatchGetSecrets200JSONResponse) VisitBatchGetSecretsResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type BatchGetSecrets400ApplicationProblemPlusJSONResponse struct {
+	ErrorResponseApplicationProblemPlusJSONResponse
+}
+
+func (response BatchGetSecrets400ApplicationProblemPlusJSONResponse) VisitBatchGetSecretsResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/problem+json")
+	ctx.Status(400)
+
+	return ctx.JSON(&response)
+}
+
+type BatchGetSecrets500ApplicationProblemPlusJSONResponse ApiProblem
+
+func (response BatchGetSecrets500ApplicationProblemPlusJSONResponse) VisitBatchGetSecretsResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/problem+json")
+	ctx.Status(500)
+
+	return ctx.JSON(&response)
+}
+
 // StrictServerInterface represents all server handlers.
 type StrictServerInterface interface {
+	// Watch the changes of secrets
+	// (GET /v1/events)
+	WatchSecretEvents(ctx context.Context, request WatchSecretEventsRequestObject) (WatchSecretEventsResponseObject, error)
 	// Delete an environment
 	// (DELETE /v1/onboarding/environments/{envId})
 	DeleteEnvironment(ctx context.Context, request DeleteEnvironmentRequestObject) (DeleteEnvironmentResponseObject, error)
@@ -762,15 +1468,30 @@ type StrictServerInterface interface {
 	// Create or update an app
 	// (PUT /v1/onboarding/environments/{envId}/teams/{teamId}/apps/{appId})
 	UpsertApp(ctx context.Context, request UpsertAppRequestObject) (UpsertAppResponseObject, error)
-	// Get one or multiple secrets
+	// List the secrets of an environment, team or application
 	// (GET /v1/secrets)
 	ListSecrets(ctx context.Context, request ListSecretsRequestObject) (ListSecretsResponseObject, error)
+	// Delete a secret
+	// (DELETE /v1/secrets/{secretId})
+	DeleteSecret(ctx context.Context, request DeleteSecretRequestObject) (DeleteSecretResponseObject, error)
 	// Get a specific secret
 	// (GET /v1/secrets/{secretId})
 	GetSecret(ctx context.Context, request GetSecretRequestObject) (GetSecretResponseObject, error)
 	// Create or update a secret
 	// (PUT /v1/secrets/{secretId})
 	PutSecret(ctx context.Context, request PutSecretRequestObject) (PutSecretResponseObject, error)
+	// Get the public key of a secret
+	// (GET /v1/secrets/{secretId}/public)
+	GetPublicKey(ctx context.Context, request GetPublicKeyRequestObject) (GetPublicKeyResponseObject, error)
+	// Restore a deleted secret
+	// (POST /v1/secrets/{secretId}/undelete)
+	UndeleteSecret(ctx context.Context, request UndeleteSecretRequestObject) (UndeleteSecretResponseObject, error)
+	// List the versions of a secret
+	// (GET /v1/secrets/{secretId}/versions)
+	ListSecretVersions(ctx context.Context, request ListSecretVersionsRequestObject) (ListSecretVersionsResponseObject, error)
+	// Get many secrets at once
+	// (POST /v1/secrets:batchGet)
+	BatchGetSecrets(ctx context.Context, request BatchGetSecretsRequestObject) (BatchGetSecretsResponseObject, error)
 }
 
 type StrictHandlerFunc func(ctx *fiber.Ctx, args interface{}) (interface{}, error)
@@ -786,6 +1507,33 @@ type strictHandler struct {
 	middlewares []StrictMiddlewareFunc
 }
 
+// WatchSecretEvents operation middleware
+func (sh *strictHandler) WatchSecretEvents(ctx *fiber.Ctx, params WatchSecretEventsParams) error {
+	var request WatchSecretEventsRequestObject
+
+	request.Params = params
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.WatchSecretEvents(ctx.UserContext(), request.(WatchSecretEventsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "WatchSecretEvents")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(WatchSecretEventsResponseObject); ok {
+		if err := validResponse.VisitWatchSecretEventsResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
 // DeleteEnvironment operation middleware
 func (sh *strictHandler) DeleteEnvironment(ctx *fiber.Ctx, envId string) error {
 	var request DeleteEnvironmentRequestObject
@@ -981,6 +1729,33 @@ func (sh *strictHandler) ListSecrets(ctx *fiber.Ctx, params ListSecretsParams) e
 	return nil
 }
 
+// DeleteSecret operation middleware
+func (sh *strictHandler) DeleteSecret(ctx *fiber.Ctx, secretId SecretId) error {
+	var request DeleteSecretRequestObject
+
+	request.SecretId = secretId
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteSecret(ctx.UserContext(), request.(DeleteSecretRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteSecret")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(DeleteSecretResponseObject); ok {
+		if err := validResponse.VisitDeleteSecretResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
 // GetSecret operation middleware
 func (sh *strictHandler) GetSecret(ctx *fiber.Ctx, secretId SecretId) error {
 	var request GetSecretRequestObject
@@ -1041,34 +1816,180 @@ func (sh *strictHandler) PutSecret(ctx *fiber.Ctx, secretId SecretId) error {
 	return nil
 }
 
+// GetPublicKey operation middleware
+func (sh *strictHandler) GetPublicKey(ctx *fiber.Ctx, secretId SecretId) error {
+	var request GetPublicKeyRequestObject
+
+	request.SecretId = secretId
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.GetPublicKey(ctx.UserContext(), request.(GetPublicKeyRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetPublicKey")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(GetPublicKeyResponseObject); ok {
+		if err := validResponse.VisitGetPublicKeyResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// UndeleteSecret operation middleware
+func (sh *strictHandler) UndeleteSecret(ctx *fiber.Ctx, secretId SecretId) error {
+	var request UndeleteSecretRequestObject
+
+	request.SecretId = secretId
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.UndeleteSecret(ctx.UserContext(), request.(UndeleteSecretRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "UndeleteSecret")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(UndeleteSecretResponseObject); ok {
+		if err := validResponse.VisitUndeleteSecretResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// ListSecretVersions operation middleware
+func (sh *strictHandler) ListSecretVersions(ctx *fiber.Ctx, secretId SecretId) error {
+	var request ListSecretVersionsRequestObject
+
+	request.SecretId = secretId
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.ListSecretVersions(ctx.UserContext(), request.(ListSecretVersionsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ListSecretVersions")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(ListSecretVersionsResponseObject); ok {
+		if err := validResponse.VisitListSecretVersionsResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// BatchGetSecrets operation middleware
+func (sh *strictHandler) BatchGetSecrets(ctx *fiber.Ctx) error {
+	var request BatchGetSecretsRequestObject
+
+	var body BatchGetSecretsJSONRequestBody
+	if err := ctx.BodyParser(&body); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	request.Body = &body
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.BatchGetSecrets(ctx.UserContext(), request.(BatchGetSecretsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "BatchGetSecrets")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(BatchGetSecretsResponseObject); ok {
+		if err := validResponse.VisitBatchGetSecretsResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+xaW4/bNhP9KwS/762K5dzQ1E/dXBoYaJt0k6IP2QUylkY2U4lkyJE3hqH/XpCULNnW",
-	"2t6102yTPASxJV5mzjlzMblLnqhCK4mSLB8tuQYDBRIa/+2PEs3iDSYGaZy6BynaxAhNQkk+4m9nyETK",
-	"lGEGMzQoE2SkGDDrp/CICzfso1uFR1xCgXzEbbNexG0ywwLcwoKw8Fv+32DGR/x/cWtXHIbZOFhyjhmv",
-	"Ik4L7VYDY2DBqyriR9upgWa9Zhr8WAqDKR+RKbFr9oHWOvPcImjpqUoFek/D+7+MIDwP79zTRElC6T+C",
-	"1rlIwDkRf7DOk2Vna22URkP1YnPIS+zzXFgmLKMZMj+Eqcx/Cd4NLuQ4YxbJwfF+uTSKgLCq3kedQexK",
-	"5DmbIDMgU1XkCzZFiQYI0wsJMmVhVjpgF/IVzdBcCYsRW6iSJSD96iAXzJIRchqsGFxIHnH8BIXOHdbt",
-	"znxFbBjPG+gC/u9qRy9Xw9TkAybkIA4jrVbSBkxeGKPMef1kB7baqEmOxQ/bGO+i90yL12Fi4Hcd+LFk",
-	"CVgPt3MenSkOVGFZsNixYpBKIzF1cv5dPWutW1/rTZkkaG1W5sxR7o1mV4JmTCrW+FRF/JWcKDCpkNMD",
-	"nN4nqFVArttyxnJhybs1B5HDJF/JZBVb1gXUIfH8q7BURy05GCNewKdxmHl/2BPiXSGEHfqEEF2Pn0Ey",
-	"AueQOw+C3Zavcoez5xw/O3LNvtFNst42OoWQzbd/H6pbKewQL4+wqU6lx0s/vUlq34A6vSnOicEQ0cqw",
-	"UqdAIWk0tcnNrbd1VnWyzpbCnoLFlCnJZkTajuL46upqYLLkHqaClBkoM41Nlrh/Pz16/ONgRkXOow3n",
-	"UyQQufu0kYddkbQEMsHel5aAStt5JSThFI0v1YLy/lnhwXJPzvdvV1s060WNrduAR3wjtRzHcdMT9PUV",
-	"7s16Ud1bwvxiUb9UGi0fbfE1DcE4Y1hoWkQM6nq+WeVXxX2vH8INua4et4ki68uF1zVimzt62WWqiWZI",
-	"PDJYeI1yglTYn6XSA8Ic/1bFIMW2g3t7dv58/IZHvDRucBMVKc4xd8h2JsV8K0R963T2euybmAmy0gUX",
-	"KWbQqnzekG1ZZlTRqX3Md0S+sFsmyDJIqITc1elMpChJQN5tgnKRYJ2uarPPNCQzZA8Gw07s1Giy30DC",
-	"FI0zzIGPxgZrh4Ph4D6P+Kd7oMW9BAinyixaEKqIK40StOAj/nAwHDx0kQ8089qK5/djteoeYpRzYZQs",
-	"vLqWKOfjtAok5kg9onrunzOQrDOT0Qx8lxO8zgSmbLLwkLTkC+kDp+6N2USl7ofCqstxzXy9+ot2ZW95",
-	"+zPl3aHt/pp5/U2/93Vnx78p0MuNpvPB8NF1cboaF7fNXhXxR8Ph/hnrvWwV8ce3mOXSdFkU4IXRS5qL",
-	"QJg6UHmrB35ZRVyXPZ3pM1e7sFO5PpME/tQWDf13JHAANT3N+pfTwh4er1NFFR2SOWJCKGy8dP8dmEiY",
-	"G3viBPIWobhTsokOPqagYHrPrgHTbythNXAcl6hOK7GQoL5L7OtNiDtVd6tEGIPWNl6C1ge3V6D1ibPi",
-	"mdbfFXuDbT0H/dt6Ir+13jGgcWzPeEpdh1T8Xdd3StdfQ0O8Q+p1/m9OJEdLPsUe6b9EYrB5DByEDwY3",
-	"lO+Ero2aixTT7vnCITHQnn7Z7SjoQ6UdEq/f+d2Oy+3j9C9HpQNdSc9lUeYk9OrGwnbobJ5schkvm3vA",
-	"ag+tVmMiMpE052m78pgybPx8i7WXWJN2Y85OQtcdCLteJHtZOrzPr+k4VXl5XZ6IpOY2eHE9ZJ0L47jn",
-	"tri6PdfrVyV3qs/eQbqfi2beX89/UYblKoGc1Se8df1eP/11I2bK0ujJ8MnQU1Hvs7ncizmaBc2EnDKD",
-	"05DqmSXlb7HDIa9V+dw/XaWTtb8dsLy6rP4JAAD//6c6n+lhIQAA",
+	"H4sIAAAAAAAC/+xcW3MbN7L+Kyic83ZGpHzLcfS0iq11qdZJvLYTP8SuEjjT5CCaASYAhjJXxf++1Q3M",
+	"hRwMSV0cxUkeUrEGt0b3h76hwWue6rLSCpSz/OSaV8KIEhwY+utFbaw2+K8MbGpk5aRW/IS/z4Gl1MYM",
+	"uNooyNhsxVwOrDKwlLq2rBIL4AmX2P+3GsyKJ1yJEvgJ90N5wm2aQylwfreqsMU6I9WCr9cJfy1L6eJL",
+	"l+KzLOuSqbqcgWF6zqSD0jKXC8eEgZaokfULmrq/fAZzUReOnzw7TniYHv/Av6Tyfz1KGiqlcrAAQ2T+",
+	"G6c+rarzLE6rqKpCpgK/IKHIIgupAWcn7HzOoKzcKqHPDkTZtNEuCmkdZBP2Fn6rpQHb9XKazXAeNxnZ",
+	"oaiqPewlus/UcoxuUEtptCpBuS26R5YEteQJN57WjJ84U8MBJLwHUY7RQHvdzbQ+mUPejZCK8+5hzzua",
+	"a4wwmTGC/hwMqBRQHiIs3yxZCZd3K9pmul0c+l8Dc37C/2faHcmpb7VTT89bmPP1eu0nAeu+05kEOqnf",
+	"CZfmr7AHNeCnVCsHiv7ZA+H0V4vbuO6tWxldgXFhJpnZ+Kbb7Vrc/PlLG8EFHsMbbIXO2rkf9CgctubP",
+	"9rQJY8SKr9d91v1CZH5q++jZr5A65E0ruw9GOrg7OxagwAinzWHbetV2Xyd8KYoaYsyUlkl/nKnLJiMn",
+	"H9X5nFlwCKuL62ujnXCwXl8kvU7sShYFKgEjVKbLYsUCoZB9VEJlzI/KJuyj+tHlYK6khYStdM1SoWh2",
+	"oVbMI95TMfmoeMLhsyirAvgJ71bmSeSE9IXhNxoVB/W0lVZ2G6j+412Q2qAthlVbF84yqYhn2mTeTjhq",
+	"I0xAhhg+FLQN2YhOvm43OoJNmjHGjmSL1nd1moK187pgBpyRsBQF0lmScMK5Wif8zBhtDmBZZfSsgPL/",
+	"hqzbtbnTSr7xA2M0niuWCksgRaoASUEoSsv8xhDLrcVdJ/wH/aKjbnS/KElvFq+ky5nSrNnTOuE/qpkW",
+	"JpNq8eVwckpGgra1FLIQs6I9XJ2qOxQfr6V1wWYEhPQ12xfHSw8qb+pZIdN/weo+OJfdSJlXzdLxQ/nm",
+	"7PsjUKnOIGO+K7uEFRqTFJecI1XE8d3KRqIV7Za6C9tEjw7eWo6zJSiHAr0HDqb7PeiglhR8do1uGvIg",
+	"2aXtAAm2zEryRdqZvb3QRQbWsbk0NO8NTDTxYajsSKFD5HCfeTJKucgdy8US2AxAsVJaC9mAum6PM60L",
+	"EGrkWCRduODXvaHAr1Bzd2ckMKuT9luY35Os9+qZgXYh1adr5w3wHZTNtoQQTAeDDiO1CTsnPa60I+dA",
+	"e8NZCBva9x/L2ygx5Aw6IDHm9EV0C9nsx/ctFawf/DMYK7X6stBBgS39QlvedhLE4255tgP5X8aVGUq1",
+	"2QTfds9/XxM1tCQ33FlqIITyhtVVJpx3i8I2aYGwLFLV86sGov1OoFLUiuXOVfZkOr26upqYeXoEmXTa",
+	"TLRZTM08xf++ffrs/ye5KwuebG0+AydkEYlgMQi1TqgUoo3WCVfbXlOb0Ui4k66Ij/IfrvfoAWptl2jm",
+	"SxpahwxP+IZzPZAwOZw3cWGTm/ot1okiFqeZGpic9+K0XFhv0yyYJWRsbnRJzalIczR3qaitN3IzkV6C",
+	"ylCn1qp1MEnNegM5A6Zrl1GQFrGFO6LHeNS4pcAleuveWWc6TWtjNtYZ961iAtoyOHf1E31SJLYxbNnc",
+	"116SabJkjHK/7p0p/qoQ0mXIREgPbCcN2lzBYc72WHi/4TDfncetqIa4OH+5iYrWcUpzSC9tXVJeUKh+",
+	"PjAJ+UOzkYNtBZRBAQ6yhEnXJGMqA3P5uVkpZLhEQQ6AdLbNQcbccyc9pufalMLxE45yO6KvybgqBYWZ",
+	"5V+4tyUZqUkii38ajBrRs+0pwaXGZfSqn8faZHBogvGMFOuUTllbF5LPlJ/yOaKLyUf1Pm+BpQ2y9BIq",
+	"lzCryU0xPiNFnkxldFaHYMDiib+UKuu8BZ+I2sTSTLoR/8jK/4TcBHv77pQiuV4K//mjbx/3cvjHScTi",
+	"pbostfphVCn59lY3iX64GhNuWpsljHrfy4basxeB2AYEb44eP/uGJ/zN0ZPnT+n/zx4/igAh4ZmySO4I",
+	"R17+8I6ItTFqWx9xBJNdDCGtrSEClw85uBzM5swobwvF/MjKhQJKj4d/hXuhF6cdpswSTAAMflbFijp7",
+	"/HnK26scURT6ChWpNhvub10h/PBUBlkjPmUm3crDp2Fqjyie8FRE+XkJq/fhRA7Zib0b2ilfMWRqs5ix",
+	"Av9Ko4sUoBYuj6/h2wIwRFHlQtUlGJk2x9GwSlh7pU3WR/fT42+/2YfuUqqXcjF6fMLg3l1aRr39NnuL",
+	"Rmd+ra/AHDoxCtJQKrEA58AcuMa7VTnTxcHkW9/9sLl/qqrD6a+r6sb0Kz1K/ltAbKTO9uZATDeTYxbf",
+	"yyJq/O0utoTG7hjVFjImVZTcHWapJvvfByRq5m6GFvAJ75+IGPyb4/lSrOyoV0s9BicMCc9wWPQGdmAU",
+	"x40gehqR/MzYRd5gD5ux810dnmU3z2jYvx31w2QxYcsn+29kwtzJeJAr1Vw3gbdIyfGCksJJ7kQm7T+U",
+	"riYOCrjU5SSD7jLz/enbl+fveMJrg52bADaDJRTIjt6gKV8nsduv0zfndA81C8h0mhmwulhCe4tLnnMv",
+	"VUaXWnTLYEnti9TVomCpVnOZgXJSFP17rEKmEDILgezTilzwx5PjXpgbRMq+F0oswCBhvCcZfjw5njzi",
+	"Cf98JCp5hGBcaLPqmLBOuK5AiUryE/5kcjx5QgfE5QSI6fLRNOQb6Sox4t2+1mpxVOmiIAuX5kItvOFu",
+	"+HCVa+udUeuE8Z5vz1udsA/BFRYbaV+K+JQL3/pXNEyWJWRSOChWfnRvKHq7I+nkjcoK8rddDgbos9IK",
+	"kv7tGrsSKKRaOVmQX45zIhWUcLe5dxFwAPqtSD465lCIyuLk7/NuqWb5xvNE9eMhs502J6IuDFhwF94h",
+	"cYlfeTQrjajCaShLDGERYW1dQlCeA/ffT+IlRYFZe5OF5QL8A87Ui4ssTzaqan6JnfbNyMMvhDIPB/6i",
+	"XB2BWp6UqyOMaU4uCCxIWkMTqUxsox3R+eiCno2qif4oYZqdZ2OVJJ6y3dUStykP6m46blshdFhlkIVU",
+	"q8wiXBCSxDho5BJbOuAxXh70ZKM86Nme6qBPW3ffj4+PxwxE2286dge1TvjTQ8ZvXhWvE/7sFqPQv6jL",
+	"UphVg2ivCAbqiSfciYX1nrb/8glHo+bT7SXutBeW2+k1YNnR2jO2ABfxu1/S962A3h9IaZnX93PpYYVg",
+	"7yx4uPFv1NBMZ6vBEfWzn3UzH3JEYzU/G+TFK39orzcqjBqi5ul++XV37g+Hk6jQegDp8MA/0W1xxBy+",
+	"MEBJ9Ta9/oUg8FNlwbivBwIHiCZSM/FwWNgjxzFUHKY5pmjo7PTaUe3gQYok1E3eqwJ578sI/ziwSQ5d",
+	"nYUKyMiqnqd/LYXVsONuiup+IeYV1N8Q+/MqxJ2ou5UinIqqstNrUVUHakWFUcI9a8XTqvobsTdYlmQQ",
+	"X5YE+VfzHT037uoz3ieuvSr+G9d/KFz/GRziHVAP+r+JqUezh9K6AODuccZ8I8cTEG5TXTU3j/suyXv3",
+	"vduPPHy2D5ZgeqnAruKesm7aOH/UUNyUjwqp3kospBLYWFusD+tyi8M0WldyEkmgxcTQdZn23jatk8N6",
+	"h2dIh3b3D74O6O1fsR3QMTy1u0vOaLuS9eHA3sKyn57cj7tdyaTwYXrdPKU6LOzzvXeaAHrM1OSzw+00",
+	"uwSobFMj0mxj4wFOwLQB67RpIV0rP8ZLJOS//XoOVFuB4nPYE/YTtbscVFOHkguVFZCxQl726CdTlmnw",
+	"9V3wWVo3GfG/3jW3Vzc7M+2DtwfzIZ4eP73FqPsLArtrvwEGk7j2fQUOx1WQ4kXlwVgbCO4VuC8gtRvo",
+	"jQdXGFFOjoji0HC8f3TuwQt8U9+TkJqXm6txlvUed04jrxnXt5f1Ztn1Hyoc3iH0URsw9e93Rv2jV+Dt",
+	"UCWMf+lFFTyVkGbr2ZGHSdDpNhdYLcv+qU3b34Ynb6FUMP6WyQ/pTUujwPpL0u1x/X5tQSNRa+QSaaIZ",
+	"6RE5FaA35C2MUN4qIcjwGrcrM210kMO6p0lM07Tvw35vZTN8mPa1mYoWTb3Ha/PbIrfxFJCkSlsXLxDS",
+	"ATe+DMzfSG+5JSwXGZvBHLtKNyhwRQR53FKhXaWtlfjUsW5cj55rEnz9wfytwzKMjTf8nYexX/ek0x4I",
+	"VI2Ut8V6Qzi1L2z2hoq9l/v9t0WbBlMYoPrd5t4+FKsPnx1N2JlIcyzQ3qrnWQA6qiJ1xcprzl0VTU4z",
+	"gzUwuAwTbCaytlx4V2j4c7PpB8Fd7AnY14a+FhUxKBwAwZNZeLszrsYardnlE/qv27f8MpeDNMOfmggo",
+	"kw7K7hm/3xADSeXIVMYm1WgRe1Nv5J/EEMibdzHsKpcFIGLp2ZoMBe+SflSmX/W06/cEhjhtnjV1aYwb",
+	"O3/bP+txK89v8JMLD+vnb0gfpaBSiCMNh1I4Hku2oqdV6FQULBQehuTqZlEi9si1dSfPj58f02kP60Qe",
+	"L5uVy1H+BhY+D8dQM+P/fe0h1ifS11aeG7/uYvn60/q/AwCypVbIUEkAAA==",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
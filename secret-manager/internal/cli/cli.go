@@ -0,0 +1,214 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/api"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/api/accesstoken"
+)
+
+// Exit codes that scripts can rely on
+const (
+	ExitOK = 0
+	// ExitError is used for all errors that have no specific exit code, e.g. if the server is unavailable
+	ExitError = 1
+	// ExitUsage is used for invalid arguments or flags
+	ExitUsage = 2
+	// ExitNotFound is used if a secret or scope does not exist
+	ExitNotFound = 3
+	// ExitDifferent is used by diff if the secrets differ
+	ExitDifferent = 4
+)
+
+const (
+	OutputTable = "table"
+	OutputJSON  = "json"
+)
+
+var errDifferent = errors.New("secrets differ")
+
+type usageError struct {
+	error
+}
+
+func usageErrorf(format string, args ...any) error {
+	return usageError{fmt.Errorf(format, args...)}
+}
+
+// usageArgs marks the errors of the argument validation as usage errors
+func usageArgs(validate cobra.PositionalArgs) cobra.PositionalArgs {
+	return func(cmd *cobra.Command, args []string) error {
+		if err := validate(cmd, args); err != nil {
+			return usageError{err}
+		}
+		return nil
+	}
+}
+
+// ExitCode returns the exit code for the error returned by a command
+func ExitCode(err error) int {
+	var usageErr usageError
+	switch {
+	case err == nil:
+		return ExitOK
+	case errors.As(err, &usageErr):
+		return ExitUsage
+	case errors.Is(err, errDifferent):
+		return ExitDifferent
+	case errors.Is(err, api.ErrNotFound):
+		return ExitNotFound
+	default:
+		return ExitError
+	}
+}
+
+// NewClientFunc creates the client that is used to call the server of the profile
+type NewClientFunc func(profile Profile) (api.SecretManager, error)
+
+// NewClient creates a client of the pkg/api for the profile.
+// If the profile has no URL, the defaults of the API are used.
+func NewClient(profile Profile) (api.SecretManager, error) {
+	opts := []api.Option{}
+	if profile.URL != "" {
+		opts = append(opts, api.WithURL(profile.URL))
+	}
+	if profile.TokenFile != "" {
+		opts = append(opts, api.WithAccessToken(accesstoken.NewAccessToken(profile.TokenFile)))
+	}
+	return api.New(opts...)
+}
+
+// CLI is the command line interface of the secret-manager
+type CLI struct {
+	newClient NewClientFunc
+	stdin     io.Reader
+	stdout    io.Writer
+	stderr    io.Writer
+
+	configFile  string
+	profileName string
+	url         string
+	tokenFile   string
+	output      string
+}
+
+func New(newClient NewClientFunc, stdin io.Reader, stdout, stderr io.Writer) *CLI {
+	return &CLI{
+		newClient: newClient,
+		stdin:     stdin,
+		stdout:    stdout,
+		stderr:    stderr,
+	}
+}
+
+// Execute runs the command with the arguments and returns its exit code
+func (c *CLI) Execute(ctx context.Context, args []string) int {
+	cmd := c.rootCommand()
+	cmd.SetArgs(args)
+	cmd.SetIn(c.stdin)
+	cmd.SetOut(c.stdout)
+	cmd.SetErr(c.stderr)
+
+	err := cmd.ExecuteContext(ctx)
+	if err != nil {
+		fmt.Fprintln(c.stderr, "Error:", err) //nolint:errcheck
+	}
+	return ExitCode(err)
+}
+
+func (c *CLI) rootCommand() *cobra.Command {
+	root := &cobra.Command{
+		Use:           "secret-manager",
+		Short:         "Manage the secrets of the Control Plane",
+		Args:          usageArgs(cobra.NoArgs),
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if c.output != OutputTable && c.output != OutputJSON {
+				return usageErrorf("unknown output format %q, must be %s or %s", c.output, OutputTable, OutputJSON)
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+	root.SetFlagErrorFunc(func(cmd *cobra.Command, err error) error {
+		return usageError{err}
+	})
+
+	flags := root.PersistentFlags()
+	flags.StringVar(&c.configFile, "config", DefaultConfigPath(), "path to the config file with the profiles (env "+EnvConfig+")")
+	flags.StringVarP(&c.profileName, "profile", "p", os.Getenv(EnvProfile), "profile to use instead of the current profile (env "+EnvProfile+")")
+	flags.StringVar(&c.url, "url", os.Getenv(EnvURL), "URL of the API, overrides the profile (env "+EnvURL+")")
+	flags.StringVar(&c.tokenFile, "token-file", "", "file that contains the access token, overrides the profile")
+	flags.StringVarP(&c.output, "output", "o", OutputTable, "output format: table or json")
+
+	root.AddCommand(
+		c.getCommand(),
+		c.setCommand(),
+		c.rotateCommand(),
+		c.listCommand(),
+		c.deleteCommand(),
+		c.undeleteCommand(),
+		c.onboardCommand(),
+		c.offboardCommand(),
+		c.exportCommand(),
+		c.diffCommand(),
+		c.profileCommand(),
+	)
+	return root
+}
+
+func (c *CLI) loadConfig() (*Config, error) {
+	return LoadConfig(c.configFile)
+}
+
+// profile returns the profile with the name, or the current profile if the name is empty
+func (c *CLI) profile(name string) (Profile, error) {
+	cfg, err := c.loadConfig()
+	if err != nil {
+		return Profile{}, err
+	}
+	return cfg.Profile(name)
+}
+
+// client creates the client for the selected profile with the overrides of the flags
+func (c *CLI) client() (api.SecretManager, error) {
+	profile, err := c.profile(c.profileName)
+	if err != nil {
+		return nil, err
+	}
+	if c.url != "" {
+		profile.URL = c.url
+	}
+	if c.tokenFile != "" {
+		profile.TokenFile = c.tokenFile
+	}
+	return c.newClient(profile)
+}
+
+// print writes the value as JSON or the rows as table, depending on the output format
+func (c *CLI) print(value any, header []string, rows [][]string) error {
+	if c.output == OutputJSON {
+		enc := json.NewEncoder(c.stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(value)
+	}
+	w := tabwriter.NewWriter(c.stdout, 0, 4, 2, ' ', 0)
+	if header != nil {
+		fmt.Fprintln(w, strings.Join(header, "\t")) //nolint:errcheck
+	}
+	for _, row := range rows {
+		fmt.Fprintln(w, strings.Join(row, "\t")) //nolint:errcheck
+	}
+	return w.Flush()
+}
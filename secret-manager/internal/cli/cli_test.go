@@ -0,0 +1,261 @@
+package cli_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+	"github.com/telekom/controlplane-mono/secret-manager/internal/cli"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/api"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/api/fake"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/api/gen"
+)
+
+var _ = Describe("CLI", func() {
+
+	var client *fake.MockSecretManager
+	var clients map[string]*fake.MockSecretManager
+	var stdin *bytes.Buffer
+	var stdout, stderr *bytes.Buffer
+	var configFile string
+
+	run := func(args ...string) int {
+		newClient := func(profile cli.Profile) (api.SecretManager, error) {
+			if c, ok := clients[profile.URL]; ok {
+				return c, nil
+			}
+			return client, nil
+		}
+		args = append([]string{"--config", configFile}, args...)
+		return cli.New(newClient, stdin, stdout, stderr).Execute(context.Background(), args)
+	}
+
+	BeforeEach(func() {
+		client = fake.NewMockSecretManager(GinkgoT())
+		clients = map[string]*fake.MockSecretManager{}
+		stdin = &bytes.Buffer{}
+		stdout = &bytes.Buffer{}
+		stderr = &bytes.Buffer{}
+		configFile = filepath.Join(GinkgoT().TempDir(), "config.yaml")
+	})
+
+	Context("Exit codes", func() {
+		It("should return the usage exit code for invalid arguments", func() {
+			Expect(run("get")).To(Equal(cli.ExitUsage))
+			Expect(run("list", "env:team:app:foo")).To(Equal(cli.ExitUsage))
+			Expect(run("list", "env", "--unknown")).To(Equal(cli.ExitUsage))
+			Expect(run("list", "env", "-o", "yaml")).To(Equal(cli.ExitUsage))
+			Expect(run("foo")).To(Equal(cli.ExitUsage))
+			Expect(run("--profile", "missing", "list", "env")).To(Equal(cli.ExitUsage))
+		})
+
+		It("should return the not found exit code", func() {
+			client.EXPECT().Get(mock.Anything, "env:team:app:clientSecret:").Return("", api.ErrNotFound)
+			Expect(run("get", "env:team:app:clientSecret:")).To(Equal(cli.ExitNotFound))
+			Expect(stderr.String()).To(ContainSubstring("resource not found"))
+		})
+	})
+
+	Context("Secrets", func() {
+		It("should get a secret", func() {
+			client.EXPECT().Get(mock.Anything, "env:team:app:clientSecret:").Return("topsecret", nil)
+			Expect(run("get", "env:team:app:clientSecret:")).To(Equal(cli.ExitOK))
+			Expect(stdout.String()).To(MatchRegexp(`ID\s+VALUE\nenv:team:app:clientSecret:\s+topsecret\n`))
+		})
+
+		It("should get many secrets and print the found ones", func() {
+			ids := []string{"env:team:app:clientSecret:", "env:team::teamToken:"}
+			client.EXPECT().GetMany(mock.Anything, ids).Return(
+				map[string]string{"env:team:app:clientSecret:": "topsecret"},
+				&api.BatchError{Errors: map[string]error{"env:team::teamToken:": api.ErrNotFound}},
+			)
+			Expect(run("get", "-q", ids[0], ids[1])).To(Equal(cli.ExitNotFound))
+			Expect(stdout.String()).To(Equal("topsecret\n"))
+		})
+
+		It("should print JSON", func() {
+			client.EXPECT().Get(mock.Anything, "env:team:app:clientSecret:").Return("topsecret", nil)
+			Expect(run("get", "-o", "json", "env:team:app:clientSecret:")).To(Equal(cli.ExitOK))
+
+			res := []map[string]string{}
+			Expect(json.Unmarshal(stdout.Bytes(), &res)).To(Succeed())
+			Expect(res).To(Equal([]map[string]string{{"id": "env:team:app:clientSecret:", "value": "topsecret"}}))
+		})
+
+		It("should set a secret from stdin", func() {
+			stdin.WriteString("topsecret\n")
+			client.EXPECT().Set(mock.Anything, "env:team:app:clientSecret:", "topsecret").Return("env:team:app:clientSecret:v2", nil)
+			Expect(run("set", "env:team:app:clientSecret:")).To(Equal(cli.ExitOK))
+			Expect(stdout.String()).To(ContainSubstring("env:team:app:clientSecret:v2"))
+		})
+
+		It("should set a secret from a file", func() {
+			file := filepath.Join(GinkgoT().TempDir(), "value")
+			Expect(os.WriteFile(file, []byte("line1\nline2\n"), 0o600)).To(Succeed())
+			client.EXPECT().Set(mock.Anything, "env:team:app:externalSecrets/cert", "line1\nline2\n").Return("env:team:app:externalSecrets/cert:v1", nil)
+			Expect(run("set", "env:team:app:externalSecrets/cert", "--from-file", file)).To(Equal(cli.ExitOK))
+		})
+
+		It("should not set an empty value", func() {
+			Expect(run("set", "env:team:app:clientSecret:")).To(Equal(cli.ExitUsage))
+		})
+
+		It("should rotate a secret", func() {
+			client.EXPECT().Rotate(mock.Anything, "env:team:app:clientSecret:").Return("env:team:app:clientSecret:v3", nil)
+			Expect(run("rotate", "env:team:app:clientSecret:")).To(Equal(cli.ExitOK))
+			Expect(stdout.String()).To(ContainSubstring("env:team:app:clientSecret:v3"))
+		})
+
+		It("should list the secrets sorted by name", func() {
+			client.EXPECT().List(mock.Anything, "env", "team", "").Return([]gen.ListSecretItem{
+				{Name: "teamToken", Id: "env:team::teamToken:v1"},
+				{Name: "clientSecret", Id: "env:team::clientSecret:v1"},
+			}, nil)
+			Expect(run("list", "env:team")).To(Equal(cli.ExitOK))
+			lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+			Expect(lines).To(HaveLen(3))
+			Expect(lines[1]).To(HavePrefix("clientSecret"))
+			Expect(lines[2]).To(HavePrefix("teamToken"))
+		})
+
+		It("should stop deleting at the first error", func() {
+			client.EXPECT().Delete(mock.Anything, "env:team::a:").Return(nil)
+			client.EXPECT().Delete(mock.Anything, "env:team::b:").Return(api.ErrNotFound)
+			Expect(run("delete", "env:team::a:", "env:team::b:", "env:team::c:")).To(Equal(cli.ExitNotFound))
+			Expect(stdout.String()).To(ContainSubstring("env:team::a:"))
+		})
+
+		It("should restore a deleted secret", func() {
+			client.EXPECT().Undelete(mock.Anything, "env:team::a:").Return("env:team::a:v3", nil)
+			Expect(run("undelete", "env:team::a:")).To(Equal(cli.ExitOK))
+			Expect(stdout.String()).To(ContainSubstring("env:team::a:v3"))
+		})
+	})
+
+	Context("Onboarding", func() {
+		It("should onboard the scope by its depth", func() {
+			client.EXPECT().UpsertEnvironment(mock.Anything, "env").Return(nil, nil)
+			client.EXPECT().UpsertTeam(mock.Anything, "env", "team").Return([]gen.ListSecretItem{{Name: "teamToken", Id: "env:team::teamToken:v1"}}, nil)
+			client.EXPECT().UpsertApplication(mock.Anything, "env", "team", "app").Return(nil, nil)
+
+			Expect(run("onboard", "env")).To(Equal(cli.ExitOK))
+			Expect(run("onboard", "env:team")).To(Equal(cli.ExitOK))
+			Expect(stdout.String()).To(ContainSubstring("env:team::teamToken:v1"))
+			Expect(run("onboard", "env:team:app")).To(Equal(cli.ExitOK))
+		})
+
+		It("should offboard the scope by its depth", func() {
+			client.EXPECT().DeleteApplication(mock.Anything, "env", "team", "app").Return(nil)
+			Expect(run("offboard", "env:team:app")).To(Equal(cli.ExitOK))
+		})
+	})
+
+	Context("Export and Diff", func() {
+		expectScope := func(c *fake.MockSecretManager, team string, values map[string]string) {
+			items := []gen.ListSecretItem{}
+			ids := []string{}
+			byId := map[string]string{}
+			for name, value := range values {
+				id := "env:" + team + "::" + name + ":"
+				items = append(items, gen.ListSecretItem{Name: name, Id: id})
+				ids = append(ids, id)
+				byId[id] = value
+			}
+			c.EXPECT().List(mock.Anything, "env", team, "").Return(items, nil)
+			c.EXPECT().GetMany(mock.Anything, mock.MatchedBy(func(requested []string) bool {
+				return len(requested) == len(ids) && strings.HasPrefix(requested[0], "env:"+team+":")
+			})).Return(byId, nil)
+		}
+
+		It("should export the values by name", func() {
+			expectScope(client, "team", map[string]string{"teamToken": "token", "clientSecret": "secret"})
+			file := filepath.Join(GinkgoT().TempDir(), "export.json")
+			Expect(run("export", "env:team", "--file", file)).To(Equal(cli.ExitOK))
+
+			info, err := os.Stat(file)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(info.Mode().Perm()).To(Equal(os.FileMode(0o600)))
+
+			data, err := os.ReadFile(file)
+			Expect(err).ToNot(HaveOccurred())
+			export := cli.Export{}
+			Expect(json.Unmarshal(data, &export)).To(Succeed())
+			Expect(export).To(Equal(cli.Export{Scope: "env:team", Secrets: map[string]string{"teamToken": "token", "clientSecret": "secret"}}))
+		})
+
+		It("should compare two scopes without printing the values", func() {
+			expectScope(client, "a", map[string]string{"same": "1", "changed": "2", "removed": "3"})
+			expectScope(client, "b", map[string]string{"same": "1", "changed": "x", "added": "4"})
+
+			Expect(run("diff", "env:a", "env:b", "-o", "json")).To(Equal(cli.ExitDifferent))
+			entries := []cli.DiffEntry{}
+			Expect(json.Unmarshal(stdout.Bytes(), &entries)).To(Succeed())
+			Expect(entries).To(Equal([]cli.DiffEntry{
+				{Name: "added", Status: cli.DiffAdded},
+				{Name: "changed", Status: cli.DiffChanged},
+				{Name: "removed", Status: cli.DiffRemoved},
+			}))
+			Expect(stdout.String()).ToNot(ContainSubstring(`"x"`))
+		})
+
+		It("should compare with an export", func() {
+			expectScope(client, "team", map[string]string{"teamToken": "token"})
+			file := filepath.Join(GinkgoT().TempDir(), "export.json")
+			Expect(os.WriteFile(file, []byte(`{"scope":"env:team","secrets":{"teamToken":"token"}}`), 0o600)).To(Succeed())
+
+			Expect(run("diff", "env:team", "--file", file)).To(Equal(cli.ExitOK))
+			Expect(stdout.String()).To(BeEmpty())
+		})
+
+		It("should compare with the server of another profile", func() {
+			Expect(run("profile", "set", "dev", "--server", "https://dev")).To(Equal(cli.ExitOK))
+			Expect(run("profile", "set", "prod", "--server", "https://prod")).To(Equal(cli.ExitOK))
+			prod := fake.NewMockSecretManager(GinkgoT())
+			clients["https://prod"] = prod
+
+			expectScope(client, "team", map[string]string{"teamToken": "token"})
+			expectScope(prod, "team", map[string]string{"teamToken": "other"})
+			Expect(run("diff", "env:team", "--other-profile", "prod")).To(Equal(cli.ExitDifferent))
+		})
+
+		It("should require something to compare with", func() {
+			Expect(run("diff", "env:team")).To(Equal(cli.ExitUsage))
+		})
+	})
+
+	Context("Profiles", func() {
+		It("should use the current profile unless another one is selected", func() {
+			dev := fake.NewMockSecretManager(GinkgoT())
+			prod := fake.NewMockSecretManager(GinkgoT())
+			clients["https://dev"] = dev
+			clients["https://prod"] = prod
+
+			Expect(run("profile", "set", "dev", "--server", "https://dev", "--token", "/tmp/token")).To(Equal(cli.ExitOK))
+			Expect(run("profile", "set", "prod", "--server", "https://prod")).To(Equal(cli.ExitOK))
+
+			dev.EXPECT().Rotate(mock.Anything, "env:team::teamToken:").Return("env:team::teamToken:v2", nil)
+			Expect(run("rotate", "env:team::teamToken:")).To(Equal(cli.ExitOK))
+
+			prod.EXPECT().Rotate(mock.Anything, "env:team::teamToken:").Return("env:team::teamToken:v2", nil)
+			Expect(run("--profile", "prod", "rotate", "env:team::teamToken:")).To(Equal(cli.ExitOK))
+
+			Expect(run("profile", "use", "prod")).To(Equal(cli.ExitOK))
+			Expect(run("profile", "use", "missing")).To(Equal(cli.ExitUsage))
+
+			stdout.Reset()
+			Expect(run("profile", "list", "-o", "json")).To(Equal(cli.ExitOK))
+			res := []map[string]any{}
+			Expect(json.Unmarshal(stdout.Bytes(), &res)).To(Succeed())
+			Expect(res).To(HaveLen(2))
+			Expect(res[0]).To(HaveKeyWithValue("name", "dev"))
+			Expect(res[0]).To(HaveKeyWithValue("token_file", "/tmp/token"))
+			Expect(res[1]).To(HaveKeyWithValue("current", true))
+		})
+	})
+})
@@ -0,0 +1,210 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/api"
+)
+
+const (
+	DiffAdded   = "added"
+	DiffRemoved = "removed"
+	DiffChanged = "changed"
+)
+
+// Export contains the values of all secrets of a scope by their names
+type Export struct {
+	Scope   string            `json:"scope"`
+	Secrets map[string]string `json:"secrets"`
+}
+
+// DiffEntry is a secret that differs. Its values are never printed.
+type DiffEntry struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+}
+
+// exportScope returns the values of all secrets of the scope
+func exportScope(ctx context.Context, client api.SecretManager, scope Scope) (*Export, error) {
+	items, err := client.List(ctx, scope.Env, scope.Team, scope.App)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list secrets of %s", scope)
+	}
+	export := &Export{Scope: scope.String(), Secrets: make(map[string]string, len(items))}
+	if len(items) == 0 {
+		return export, nil
+	}
+
+	ids := make([]string, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, item.Id)
+	}
+	values, err := client.GetMany(ctx, ids)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get secrets of %s", scope)
+	}
+	for _, item := range items {
+		export.Secrets[item.Name] = values[item.Id]
+	}
+	return export, nil
+}
+
+func readExport(path string) (*Export, error) {
+	data, err := os.ReadFile(path) //nolint:gosec
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read export")
+	}
+	export := &Export{}
+	if err := json.Unmarshal(data, export); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse export %s", path)
+	}
+	return export, nil
+}
+
+// Diff returns the secrets that are added, removed or changed in the other secrets, sorted by name
+func Diff(secrets, other map[string]string) []DiffEntry {
+	entries := []DiffEntry{}
+	for name, value := range secrets {
+		otherValue, ok := other[name]
+		switch {
+		case !ok:
+			entries = append(entries, DiffEntry{Name: name, Status: DiffRemoved})
+		case otherValue != value:
+			entries = append(entries, DiffEntry{Name: name, Status: DiffChanged})
+		}
+	}
+	for name := range other {
+		if _, ok := secrets[name]; !ok {
+			entries = append(entries, DiffEntry{Name: name, Status: DiffAdded})
+		}
+	}
+	slices.SortFunc(entries, func(a, b DiffEntry) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return entries
+}
+
+func (c *CLI) exportCommand() *cobra.Command {
+	var file string
+	cmd := &cobra.Command{
+		Use:   "export SCOPE",
+		Short: "Export the values of all secrets of a scope as JSON",
+		Long: "Export the values of all secrets of a scope like env, env:team or env:team:app as JSON, e.g. to compare them using diff.\n" +
+			"The export contains the plain values. If it is written to a file, the file is only readable by the user.",
+		Args: usageArgs(cobra.ExactArgs(1)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			scope, err := ParseScope(args[0])
+			if err != nil {
+				return err
+			}
+			client, err := c.client()
+			if err != nil {
+				return err
+			}
+			export, err := exportScope(cmd.Context(), client, scope)
+			if err != nil {
+				return err
+			}
+
+			data, err := json.MarshalIndent(export, "", "  ")
+			if err != nil {
+				return err
+			}
+			data = append(data, '\n')
+			if file == "" {
+				_, err = c.stdout.Write(data)
+				return err
+			}
+			return errors.Wrap(os.WriteFile(file, data, 0o600), "failed to write export")
+		},
+	}
+	cmd.Flags().StringVarP(&file, "file", "f", "", "write the export to the file instead of stdout")
+	return cmd
+}
+
+func (c *CLI) diffCommand() *cobra.Command {
+	var file, otherProfile string
+	cmd := &cobra.Command{
+		Use:   "diff SCOPE [OTHER_SCOPE]",
+		Short: "Compare the secrets of a scope with another scope, server or export",
+		Long: "Compare the secrets of a scope with another scope, the same scope on the server of another profile, or an export.\n" +
+			"Only the names of the secrets that differ are printed, never their values. " +
+			"The exit code is 4 if the secrets differ.",
+		Example: "  secret-manager diff my-env:my-team my-env:other-team\n" +
+			"  secret-manager diff my-env:my-team --other-profile prod\n" +
+			"  secret-manager diff my-env:my-team --file backup.json",
+		Args: usageArgs(cobra.RangeArgs(1, 2)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			scope, err := ParseScope(args[0])
+			if err != nil {
+				return err
+			}
+			otherScope := scope
+			if len(args) == 2 {
+				if otherScope, err = ParseScope(args[1]); err != nil {
+					return err
+				}
+			}
+			if file != "" && (len(args) == 2 || otherProfile != "") {
+				return usageErrorf("--file cannot be combined with another scope or profile")
+			}
+			if file == "" && len(args) == 1 && otherProfile == "" {
+				return usageErrorf("either another scope, --other-profile or --file must be set")
+			}
+
+			client, err := c.client()
+			if err != nil {
+				return err
+			}
+			export, err := exportScope(cmd.Context(), client, scope)
+			if err != nil {
+				return err
+			}
+
+			var other *Export
+			if file != "" {
+				other, err = readExport(file)
+			} else {
+				other, err = c.exportOther(cmd.Context(), client, otherProfile, otherScope)
+			}
+			if err != nil {
+				return err
+			}
+
+			entries := Diff(export.Secrets, other.Secrets)
+			if len(entries) == 0 {
+				return nil
+			}
+			rows := make([][]string, 0, len(entries))
+			for _, entry := range entries {
+				rows = append(rows, []string{entry.Name, entry.Status})
+			}
+			if err := c.print(entries, []string{"NAME", "STATUS"}, rows); err != nil {
+				return err
+			}
+			return errDifferent
+		},
+	}
+	cmd.Flags().StringVarP(&file, "file", "f", "", "compare with an export")
+	cmd.Flags().StringVar(&otherProfile, "other-profile", "", "compare with the server of another profile")
+	return cmd
+}
+
+func (c *CLI) exportOther(ctx context.Context, client api.SecretManager, otherProfile string, scope Scope) (*Export, error) {
+	if otherProfile != "" {
+		profile, err := c.profile(otherProfile)
+		if err != nil {
+			return nil, err
+		}
+		if client, err = c.newClient(profile); err != nil {
+			return nil, err
+		}
+	}
+	return exportScope(ctx, client, scope)
+}
@@ -0,0 +1,74 @@
+package cli
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/api/gen"
+)
+
+func (c *CLI) onboardCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "onboard SCOPE",
+		Short: "Onboard an environment, team or application",
+		Long:  "Onboard a scope like env, env:team or env:team:app and list its secrets. The values are not returned.",
+		Args:  usageArgs(cobra.ExactArgs(1)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			scope, err := ParseScope(args[0])
+			if err != nil {
+				return err
+			}
+			client, err := c.client()
+			if err != nil {
+				return err
+			}
+
+			var items []gen.ListSecretItem
+			switch {
+			case scope.App != "":
+				items, err = client.UpsertApplication(cmd.Context(), scope.Env, scope.Team, scope.App)
+			case scope.Team != "":
+				items, err = client.UpsertTeam(cmd.Context(), scope.Env, scope.Team)
+			default:
+				items, err = client.UpsertEnvironment(cmd.Context(), scope.Env)
+			}
+			if err != nil {
+				return err
+			}
+			if items == nil {
+				items = []gen.ListSecretItem{}
+			}
+			items = sortItems(items)
+			return c.print(items, []string{"NAME", "ID"}, itemRows(items))
+		},
+	}
+}
+
+func (c *CLI) offboardCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "offboard SCOPE",
+		Short: "Delete an environment, team or application with all of its secrets",
+		Args:  usageArgs(cobra.ExactArgs(1)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			scope, err := ParseScope(args[0])
+			if err != nil {
+				return err
+			}
+			client, err := c.client()
+			if err != nil {
+				return err
+			}
+
+			switch {
+			case scope.App != "":
+				err = client.DeleteApplication(cmd.Context(), scope.Env, scope.Team, scope.App)
+			case scope.Team != "":
+				err = client.DeleteTeam(cmd.Context(), scope.Env, scope.Team)
+			default:
+				err = client.DeleteEnvironment(cmd.Context(), scope.Env)
+			}
+			if err != nil {
+				return err
+			}
+			return c.print(scope, []string{"OFFBOARDED"}, [][]string{{scope.String()}})
+		},
+	}
+}
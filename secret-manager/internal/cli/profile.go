@@ -0,0 +1,108 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// EnvConfig overrides the path of the config file
+	EnvConfig = "SECRET_MANAGER_CONFIG"
+	// EnvProfile selects the profile instead of the current profile of the config file
+	EnvProfile = "SECRET_MANAGER_PROFILE"
+	// EnvURL overrides the URL of the selected profile
+	EnvURL = "SECRET_MANAGER_URL"
+)
+
+// Profile contains the connection details of a secret-manager server
+type Profile struct {
+	// URL of the API, e.g. https://secret-manager.example.com/api
+	URL string `yaml:"url" json:"url"`
+	// TokenFile contains the access token that is sent to the server
+	TokenFile string `yaml:"token_file,omitempty" json:"token_file,omitempty"`
+}
+
+// Config is the config file of the CLI
+type Config struct {
+	CurrentProfile string             `yaml:"current_profile,omitempty"`
+	Profiles       map[string]Profile `yaml:"profiles"`
+}
+
+// DefaultConfigPath returns the path of the config file in the config directory of the user
+func DefaultConfigPath() string {
+	if path := os.Getenv(EnvConfig); path != "" {
+		return path
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "secret-manager", "config.yaml")
+}
+
+// LoadConfig reads the config file. If it does not exist, an empty config is returned.
+func LoadConfig(path string) (*Config, error) {
+	cfg := &Config{Profiles: map[string]Profile{}}
+	if path == "" {
+		return cfg, nil
+	}
+	data, err := os.ReadFile(path) //nolint:gosec
+	if err != nil {
+		if os.IsNotExist(err) {
+			return cfg, nil
+		}
+		return nil, errors.Wrap(err, "failed to read config")
+	}
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse config %s", path)
+	}
+	if cfg.Profiles == nil {
+		cfg.Profiles = map[string]Profile{}
+	}
+	return cfg, nil
+}
+
+// Save writes the config file. It is only readable by the user as it references the token files.
+func (c *Config) Save(path string) error {
+	if path == "" {
+		return errors.New("path of the config file is unknown")
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return errors.Wrap(err, "failed to create config directory")
+	}
+	data, err := yaml.Marshal(c)
+	if err != nil {
+		return err
+	}
+	return errors.Wrap(os.WriteFile(path, data, 0o600), "failed to write config")
+}
+
+// ProfileNames returns the names of all profiles sorted by name
+func (c *Config) ProfileNames() []string {
+	names := make([]string, 0, len(c.Profiles))
+	for name := range c.Profiles {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Profile returns the profile with the name, or the current profile if the name is empty.
+// If no profile is selected at all, the empty profile is returned and the defaults of the API are used.
+func (c *Config) Profile(name string) (Profile, error) {
+	if name == "" {
+		name = c.CurrentProfile
+	}
+	if name == "" {
+		return Profile{}, nil
+	}
+	profile, ok := c.Profiles[name]
+	if !ok {
+		return Profile{}, usageErrorf("profile %q does not exist", name)
+	}
+	return profile, nil
+}
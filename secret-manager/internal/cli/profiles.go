@@ -0,0 +1,97 @@
+package cli
+
+import (
+	"github.com/spf13/cobra"
+)
+
+type profileItem struct {
+	Name    string `json:"name"`
+	Current bool   `json:"current"`
+	Profile
+}
+
+func (c *CLI) profileCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "profile",
+		Short: "Manage the profiles of the servers",
+		Args:  usageArgs(cobra.NoArgs),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return cmd.Help()
+		},
+	}
+	cmd.AddCommand(c.profileListCommand(), c.profileSetCommand(), c.profileUseCommand())
+	return cmd
+}
+
+func (c *CLI) profileListCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List the profiles",
+		Args:  usageArgs(cobra.NoArgs),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := c.loadConfig()
+			if err != nil {
+				return err
+			}
+			items := make([]profileItem, 0, len(cfg.Profiles))
+			rows := make([][]string, 0, len(cfg.Profiles))
+			for _, name := range cfg.ProfileNames() {
+				item := profileItem{Name: name, Current: name == cfg.CurrentProfile, Profile: cfg.Profiles[name]}
+				items = append(items, item)
+				current := ""
+				if item.Current {
+					current = "*"
+				}
+				rows = append(rows, []string{current, name, item.URL, item.TokenFile})
+			}
+			return c.print(items, []string{"CURRENT", "NAME", "URL", "TOKEN FILE"}, rows)
+		},
+	}
+}
+
+func (c *CLI) profileSetCommand() *cobra.Command {
+	var profile Profile
+	cmd := &cobra.Command{
+		Use:     "set NAME",
+		Short:   "Create or update a profile",
+		Example: "  secret-manager profile set prod --server https://secret-manager.example.com/api --token /path/to/token",
+		Args:    usageArgs(cobra.ExactArgs(1)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if profile.URL == "" {
+				return usageErrorf("--server must be set")
+			}
+			cfg, err := c.loadConfig()
+			if err != nil {
+				return err
+			}
+			cfg.Profiles[args[0]] = profile
+			// The first profile is used by default
+			if len(cfg.Profiles) == 1 {
+				cfg.CurrentProfile = args[0]
+			}
+			return cfg.Save(c.configFile)
+		},
+	}
+	cmd.Flags().StringVar(&profile.URL, "server", "", "URL of the API")
+	cmd.Flags().StringVar(&profile.TokenFile, "token", "", "file that contains the access token")
+	return cmd
+}
+
+func (c *CLI) profileUseCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "use NAME",
+		Short: "Set the current profile",
+		Args:  usageArgs(cobra.ExactArgs(1)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := c.loadConfig()
+			if err != nil {
+				return err
+			}
+			if _, err := cfg.Profile(args[0]); err != nil {
+				return err
+			}
+			cfg.CurrentProfile = args[0]
+			return cfg.Save(c.configFile)
+		},
+	}
+}
@@ -0,0 +1,260 @@
+package cli
+
+import (
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/api"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/api/gen"
+)
+
+// Scope is an environment, team or application, e.g. `env:team:app`
+type Scope struct {
+	Env  string `json:"env"`
+	Team string `json:"team,omitempty"`
+	App  string `json:"app,omitempty"`
+}
+
+// ParseScope parses `env`, `env:team` or `env:team:app`
+func ParseScope(raw string) (Scope, error) {
+	parts := strings.Split(raw, ":")
+	if len(parts) > 3 || slices.Contains(parts, "") {
+		return Scope{}, usageErrorf("invalid scope %q, must be env, env:team or env:team:app", raw)
+	}
+	parts = append(parts, "", "")
+	return Scope{Env: parts[0], Team: parts[1], App: parts[2]}, nil
+}
+
+func (s Scope) String() string {
+	return strings.TrimRight(s.Env+":"+s.Team+":"+s.App, ":")
+}
+
+type secretValue struct {
+	Id    string `json:"id"`
+	Value string `json:"value"`
+}
+
+type secretRef struct {
+	Id string `json:"id"`
+}
+
+func sortItems(items []gen.ListSecretItem) []gen.ListSecretItem {
+	slices.SortFunc(items, func(a, b gen.ListSecretItem) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return items
+}
+
+func itemRows(items []gen.ListSecretItem) [][]string {
+	rows := make([][]string, 0, len(items))
+	for _, item := range items {
+		rows = append(rows, []string{item.Name, item.Id})
+	}
+	return rows
+}
+
+func (c *CLI) getCommand() *cobra.Command {
+	var quiet bool
+	cmd := &cobra.Command{
+		Use:   "get ID...",
+		Short: "Get the values of secrets",
+		Long:  "Get the values of secrets by their IDs or references. Many secrets are retrieved using a single request.",
+		Args:  usageArgs(cobra.MinimumNArgs(1)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := c.client()
+			if err != nil {
+				return err
+			}
+
+			values := map[string]string{}
+			if len(args) == 1 {
+				value, getErr := client.Get(cmd.Context(), args[0])
+				if getErr == nil {
+					values[args[0]] = value
+				}
+				err = getErr
+			} else {
+				// The values that could be retrieved are printed even if others are missing
+				values, err = client.GetMany(cmd.Context(), args)
+				var batchErr *api.BatchError
+				if err != nil && !errors.As(err, &batchErr) {
+					return err
+				}
+			}
+
+			result := make([]secretValue, 0, len(values))
+			rows := make([][]string, 0, len(values))
+			for _, id := range args {
+				value, ok := values[id]
+				if !ok {
+					continue
+				}
+				result = append(result, secretValue{Id: id, Value: value})
+				if quiet {
+					rows = append(rows, []string{value})
+				} else {
+					rows = append(rows, []string{id, value})
+				}
+			}
+			if len(result) > 0 {
+				header := []string{"ID", "VALUE"}
+				if quiet {
+					header = nil
+				}
+				if printErr := c.print(result, header, rows); printErr != nil {
+					return printErr
+				}
+			}
+			return err
+		},
+	}
+	cmd.Flags().BoolVarP(&quiet, "quiet", "q", false, "only print the values, one per line")
+	return cmd
+}
+
+func (c *CLI) setCommand() *cobra.Command {
+	var fromFile string
+	cmd := &cobra.Command{
+		Use:   "set ID",
+		Short: "Set the value of a secret",
+		Long: "Set the value of a secret. The value is read from a file or from stdin, so it does not end up in the shell history.\n" +
+			"A single trailing newline is removed from the value read from stdin.",
+		Example: "  secret-manager set my-env:my-team:my-app:externalSecrets/db < password.txt\n" +
+			"  secret-manager set my-env:my-team:my-app:externalSecrets/cert --from-file tls.crt",
+		Args: usageArgs(cobra.ExactArgs(1)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			value, err := c.readValue(fromFile)
+			if err != nil {
+				return err
+			}
+			client, err := c.client()
+			if err != nil {
+				return err
+			}
+			newId, err := client.Set(cmd.Context(), args[0], value)
+			if err != nil {
+				return err
+			}
+			return c.print(secretRef{Id: newId}, []string{"ID"}, [][]string{{newId}})
+		},
+	}
+	cmd.Flags().StringVarP(&fromFile, "from-file", "f", "", "read the value from the file instead of stdin")
+	return cmd
+}
+
+func (c *CLI) readValue(fromFile string) (string, error) {
+	if fromFile != "" {
+		data, err := os.ReadFile(fromFile) //nolint:gosec
+		if err != nil {
+			return "", errors.Wrap(err, "failed to read value")
+		}
+		return string(data), nil
+	}
+	data, err := io.ReadAll(c.stdin)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to read value from stdin")
+	}
+	value := strings.TrimSuffix(strings.TrimSuffix(string(data), "\n"), "\r")
+	if value == "" {
+		return "", usageErrorf("no value provided, pass it using stdin or --from-file")
+	}
+	return value, nil
+}
+
+func (c *CLI) rotateCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "rotate ID",
+		Short: "Rotate a secret to a new generated value",
+		Args:  usageArgs(cobra.ExactArgs(1)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := c.client()
+			if err != nil {
+				return err
+			}
+			newId, err := client.Rotate(cmd.Context(), args[0])
+			if err != nil {
+				return err
+			}
+			return c.print(secretRef{Id: newId}, []string{"ID"}, [][]string{{newId}})
+		},
+	}
+}
+
+func (c *CLI) listCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list SCOPE",
+		Short: "List the secrets of an environment, team or application",
+		Long:  "List the names and IDs of the secrets of a scope like env, env:team or env:team:app. The values are not returned.",
+		Args:  usageArgs(cobra.ExactArgs(1)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			scope, err := ParseScope(args[0])
+			if err != nil {
+				return err
+			}
+			client, err := c.client()
+			if err != nil {
+				return err
+			}
+			items, err := client.List(cmd.Context(), scope.Env, scope.Team, scope.App)
+			if err != nil {
+				return err
+			}
+			items = sortItems(items)
+			return c.print(items, []string{"NAME", "ID"}, itemRows(items))
+		},
+	}
+}
+
+func (c *CLI) deleteCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "delete ID...",
+		Short: "Delete secrets",
+		Args:  usageArgs(cobra.MinimumNArgs(1)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := c.client()
+			if err != nil {
+				return err
+			}
+			deleted := make([]secretRef, 0, len(args))
+			rows := make([][]string, 0, len(args))
+			for _, id := range args {
+				if err = client.Delete(cmd.Context(), id); err != nil {
+					err = errors.Wrapf(err, "failed to delete %s", id)
+					break
+				}
+				deleted = append(deleted, secretRef{Id: id})
+				rows = append(rows, []string{id})
+			}
+			if len(deleted) > 0 {
+				if printErr := c.print(deleted, []string{"DELETED"}, rows); printErr != nil {
+					return printErr
+				}
+			}
+			return err
+		},
+	}
+}
+
+func (c *CLI) undeleteCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "undelete ID",
+		Short: "Restore a deleted secret",
+		Long:  "Restore the value of a deleted secret. This is only possible until the retention of deleted secrets of the server has passed.",
+		Args:  usageArgs(cobra.ExactArgs(1)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := c.client()
+			if err != nil {
+				return err
+			}
+			newId, err := client.Undelete(cmd.Context(), args[0])
+			if err != nil {
+				return err
+			}
+			return c.print(secretRef{Id: newId}, []string{"ID"}, [][]string{{newId}})
+		},
+	}
+}
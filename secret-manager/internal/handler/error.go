@@ -1,10 +1,12 @@
 package handler
 
 import (
+	"encoding/json"
 	"errors"
 
 	"github.com/go-logr/logr"
 	"github.com/gofiber/fiber/v2"
+	"github.com/telekom/controlplane-mono/common-server/pkg/problems"
 	"github.com/telekom/controlplane-mono/common-server/pkg/server"
 	"github.com/telekom/controlplane-mono/secret-manager/internal/api"
 	"github.com/telekom/controlplane-mono/secret-manager/pkg/backend"
@@ -15,33 +17,70 @@ func ErrorHandler(c *fiber.Ctx, err error) error {
 	log.Info("Error handler", "error", err.Error())
 	var backendErr *backend.BackendError
 	if errors.As(err, &backendErr) {
-		switch backendErr.Type {
-		case backend.TypeErrNotFound:
-			return c.Status(fiber.StatusNotFound).JSON(api.ErrorResponse{
-				Status: fiber.StatusNotFound,
-				Title:  "Not Found",
-				Detail: backendErr.Err.Error(),
-			})
-		case backend.TypeErrBadChecksum:
-			return c.Status(fiber.StatusConflict).JSON(api.ErrorResponse{
-				Status: fiber.StatusBadRequest,
-				Title:  "Bad Checksum",
-				Detail: backendErr.Err.Error(),
-			})
-		case backend.TypeErrTooManyRequests:
-			return c.Status(fiber.StatusTooManyRequests).JSON(api.ErrorResponse{
-				Status: fiber.StatusTooManyRequests,
-				Title:  "Too Many Requests",
-				Detail: backendErr.Err.Error(),
-			})
-		default:
-			return c.Status(fiber.StatusInternalServerError).JSON(api.ErrorResponse{
-				Status: fiber.StatusInternalServerError,
-				Title:  "Internal Server Error",
-				Detail: backendErr.Err.Error(),
-			})
-		}
+		status, problem := backendProblem(backendErr)
+		return c.Status(status).JSON(problem)
 	}
 
 	return server.ReturnWithError(c, err)
 }
+
+// backendProblem returns the status code and the problem of the backend error
+func backendProblem(backendErr *backend.BackendError) (int, api.ErrorResponse) {
+	switch backendErr.Type {
+	case backend.TypeErrNotFound:
+		return fiber.StatusNotFound, api.ErrorResponse{
+			Status: fiber.StatusNotFound,
+			Title:  "Not Found",
+			Detail: backendErr.Err.Error(),
+		}
+	case backend.TypeErrVersionExpired:
+		return fiber.StatusGone, api.ErrorResponse{
+			Status: fiber.StatusGone,
+			Title:  "Version Expired",
+			Detail: backendErr.Err.Error(),
+		}
+	case backend.TypeErrInvalidSecretId:
+		return fiber.StatusBadRequest, api.ErrorResponse{
+			Status: fiber.StatusBadRequest,
+			Title:  "Bad Request",
+			Detail: backendErr.Err.Error(),
+		}
+	case backend.TypeErrBadChecksum:
+		return fiber.StatusConflict, api.ErrorResponse{
+			Status: fiber.StatusBadRequest,
+			Title:  "Bad Checksum",
+			Detail: backendErr.Err.Error(),
+		}
+	case backend.TypeErrTooManyRequests:
+		return fiber.StatusTooManyRequests, api.ErrorResponse{
+			Status: fiber.StatusTooManyRequests,
+			Title:  "Too Many Requests",
+			Detail: backendErr.Err.Error(),
+		}
+	default:
+		return fiber.StatusInternalServerError, api.ErrorResponse{
+			Status: fiber.StatusInternalServerError,
+			Title:  "Internal Server Error",
+			Detail: backendErr.Err.Error(),
+		}
+	}
+}
+
+// toProblem returns the problem of any error, e.g. for the items of a batch
+func toProblem(err error) api.ErrorResponse {
+	var backendErr *backend.BackendError
+	if errors.As(err, &backendErr) {
+		_, problem := backendProblem(backendErr)
+		problem.Type = backendErr.Type
+		return problem
+	}
+	var p problems.Problem
+	if !errors.As(err, &p) {
+		p = problems.NewProblemOfError(err)
+	}
+	problem := api.ErrorResponse{}
+	if b, err := json.Marshal(p); err == nil {
+		_ = json.Unmarshal(b, &problem)
+	}
+	return problem
+}
@@ -2,24 +2,54 @@ package handler
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
 
-	"github.com/go-logr/logr"
+	"github.com/telekom/controlplane-mono/common-server/pkg/problems"
 	"github.com/telekom/controlplane-mono/secret-manager/internal/api"
+	secretsapi "github.com/telekom/controlplane-mono/secret-manager/pkg/api"
 	"github.com/telekom/controlplane-mono/secret-manager/pkg/controller"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/events"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/generator"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/middleware"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/policy"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/tombstone"
+	"k8s.io/utils/ptr"
 )
 
 var _ api.StrictServerInterface = &Handler{}
 
+const (
+	DefaultWatchTimeout = 30
+	MaxWatchTimeout     = 55
+
+	// MaxBatchSize is the maximum number of secrets that can be requested at once
+	MaxBatchSize = 100
+	// batchConcurrency limits the number of secrets of a batch that are requested from the backend in parallel
+	batchConcurrency = 10
+)
+
 type Handler struct {
-	ctrl controller.Controller
+	ctrl       controller.Controller
+	broker     *events.Broker
+	authorizer *policy.Authorizer
 }
 
-func NewHandler(ctrl controller.Controller) *Handler {
+func NewHandler(ctrl controller.Controller, broker *events.Broker) *Handler {
 	return &Handler{
-		ctrl: ctrl,
+		ctrl:   ctrl,
+		broker: broker,
 	}
 }
 
+// WithAuthorizer enforces the access policy for requests that are not passed to the controller, e.g. watching secrets
+func (h *Handler) WithAuthorizer(a *policy.Authorizer) *Handler {
+	h.authorizer = a
+	return h
+}
+
 func (h *Handler) GetSecret(ctx context.Context, req api.GetSecretRequestObject) (res api.GetSecretResponseObject, err error) {
 	secret, err := h.ctrl.GetSecret(ctx, req.SecretId)
 	if err != nil {
@@ -31,26 +61,144 @@ func (h *Handler) GetSecret(ctx context.Context, req api.GetSecretRequestObject)
 			Value: secret.Value,
 		},
 	}
+	if secret.Stale {
+		okRes.Stale = &secret.Stale
+	}
 
 	return okRes, nil
 }
 
+func (h *Handler) BatchGetSecrets(ctx context.Context, req api.BatchGetSecretsRequestObject) (api.BatchGetSecretsResponseObject, error) {
+	if req.Body == nil || len(req.Body.Ids) == 0 {
+		return nil, problems.ValidationError("ids", "must not be empty")
+	}
+	if len(req.Body.Ids) > MaxBatchSize {
+		return nil, problems.ValidationError("ids", fmt.Sprintf("must not contain more than %d items", MaxBatchSize))
+	}
+
+	items := make([]api.BatchGetItem, len(req.Body.Ids))
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, batchConcurrency)
+	for i, id := range req.Body.Ids {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			items[i] = api.BatchGetItem{Id: id}
+			secret, err := h.ctrl.GetSecret(ctx, id)
+			if err != nil {
+				problem := toProblem(err)
+				items[i].Error = &problem
+				return
+			}
+			items[i].Value = &secret.Value
+			if secret.Stale {
+				items[i].Stale = &secret.Stale
+			}
+		}()
+	}
+	wg.Wait()
+
+	okRes := api.BatchGetSecrets200JSONResponse{
+		BatchGetResponseJSONResponse: api.BatchGetResponseJSONResponse{
+			Items: items,
+		},
+	}
+	return okRes, nil
+}
+
 func (h *Handler) ListSecrets(ctx context.Context, req api.ListSecretsRequestObject) (api.ListSecretsResponseObject, error) {
-	logr.FromContextOrDiscard(ctx).Info("ListSecrets", "request", req)
-	return api.ListSecrets200JSONResponse{
-		SecretListReponseJSONResponse: api.SecretListReponseJSONResponse{
-			Items: []api.Secret{
-				{
-					Id:    "123",
-					Value: "123",
-				},
-			},
+	listReq := controller.ListSecretsRequest{
+		Env:    req.Params.Env,
+		Team:   ptr.Deref(req.Params.Team, ""),
+		App:    ptr.Deref(req.Params.App, ""),
+		Limit:  ptr.Deref(req.Params.Limit, controller.DefaultListLimit),
+		Cursor: ptr.Deref(req.Params.Cursor, ""),
+	}
+	if accessConfig, ok := middleware.ServiceAccessConfigFromContext(ctx); ok {
+		listReq.Filter = accessConfig.IsSecretAllowed
+	}
+
+	res, err := h.ctrl.ListSecrets(ctx, listReq)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]api.ListSecretItem, 0, len(res.Items))
+	for _, item := range res.Items {
+		items = append(items, api.ListSecretItem{
+			Name: item.Name,
+			Id:   item.Id,
+		})
+	}
+	okRes := api.ListSecrets200JSONResponse{
+		SecretRefListResponseJSONResponse: api.SecretRefListResponseJSONResponse{
+			Items: items,
+		},
+	}
+	if res.Next != "" {
+		okRes.Next = &res.Next
+	}
+	return okRes, nil
+}
+
+func (h *Handler) ListSecretVersions(ctx context.Context, req api.ListSecretVersionsRequestObject) (api.ListSecretVersionsResponseObject, error) {
+	versions, err := h.ctrl.ListSecretVersions(ctx, req.SecretId)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]api.SecretVersion, 0, len(versions))
+	for _, version := range versions {
+		items = append(items, api.SecretVersion{
+			Version: version.Version,
+			Id:      version.Id,
+		})
+	}
+	okRes := api.ListSecretVersions200JSONResponse{
+		SecretVersionListResponseJSONResponse: api.SecretVersionListResponseJSONResponse{
+			Items: items,
+		},
+	}
+	return okRes, nil
+}
+
+func (h *Handler) GetPublicKey(ctx context.Context, req api.GetPublicKeyRequestObject) (api.GetPublicKeyResponseObject, error) {
+	res, err := h.ctrl.GetPublicKey(ctx, req.SecretId)
+	if err != nil {
+		if errors.Is(err, generator.ErrNoPublicKey) {
+			return nil, problems.BadRequest("secret has no public key")
+		}
+		return nil, err
+	}
+	return api.GetPublicKey200JSONResponse{
+		PublicKeyResponseJSONResponse: api.PublicKeyResponseJSONResponse{
+			Id:        res.Id,
+			PublicKey: res.PublicKey,
 		},
 	}, nil
 }
 
 func (h *Handler) PutSecret(ctx context.Context, req api.PutSecretRequestObject) (api.PutSecretResponseObject, error) {
-	secret, err := h.ctrl.SetSecret(ctx, req.SecretId, req.Body.Value)
+	var secret controller.SecretResponse
+	var err error
+	if req.Body.Generator != nil {
+		if req.Body.Value != secretsapi.KeywordRotate {
+			return nil, problems.ValidationError("value", "must be "+secretsapi.KeywordRotate+" if a generator is set")
+		}
+		cfg := generatorConfig(req.Body.Generator)
+		if _, err := generator.New(cfg); err != nil {
+			return nil, problems.ValidationError("generator", err.Error())
+		}
+		secret, err = h.ctrl.GenerateSecret(ctx, req.SecretId, cfg)
+	} else {
+		if tombstone.IsTombstone(req.Body.Value) {
+			return nil, problems.ValidationError("value", "is reserved for deleted secrets")
+		}
+		secret, err = h.ctrl.SetSecret(ctx, req.SecretId, req.Body.Value)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -62,6 +210,74 @@ func (h *Handler) PutSecret(ctx context.Context, req api.PutSecretRequestObject)
 	return okRes, nil
 }
 
+func (h *Handler) DeleteSecret(ctx context.Context, req api.DeleteSecretRequestObject) (api.DeleteSecretResponseObject, error) {
+	if err := h.ctrl.DeleteSecret(ctx, req.SecretId); err != nil {
+		return nil, err
+	}
+	return api.DeleteSecret204Response{}, nil
+}
+
+func (h *Handler) UndeleteSecret(ctx context.Context, req api.UndeleteSecretRequestObject) (api.UndeleteSecretResponseObject, error) {
+	secret, err := h.ctrl.UndeleteSecret(ctx, req.SecretId)
+	if err != nil {
+		return nil, err
+	}
+	return api.UndeleteSecret200JSONResponse{
+		SecretWriteResponseJSONResponse: api.SecretWriteResponseJSONResponse{
+			Id: secret.Id,
+		},
+	}, nil
+}
+
+func (h *Handler) WatchSecretEvents(ctx context.Context, req api.WatchSecretEventsRequestObject) (api.WatchSecretEventsResponseObject, error) {
+	timeout := min(max(ptr.Deref(req.Params.Timeout, DefaultWatchTimeout), 1), MaxWatchTimeout)
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(timeout)*time.Second)
+	defer cancel()
+
+	accessConfig, hasAccessConfig := middleware.ServiceAccessConfigFromContext(ctx)
+	allowed := func(string) bool { return true }
+	if h.authorizer != nil {
+		allowed = h.authorizer.Filter(ctx, policy.OperationRead)
+	}
+	filter := func(e events.Event) bool {
+		// The deletion of a whole scope affects all of its secrets,
+		// so it is only visible to callers that may read the whole scope
+		if scope, ok := e.Scope(); ok {
+			if hasAccessConfig && len(accessConfig.AllowedSecrets) > 0 {
+				return false
+			}
+			return allowed(policy.ScopeId(scope.Env, scope.Team, scope.App))
+		}
+		if hasAccessConfig && !accessConfig.IsSecretAllowed(e.Name()) {
+			return false
+		}
+		return allowed(e.Secret)
+	}
+
+	res := h.broker.Watch(ctx, ptr.Deref(req.Params.Cursor, ""), ptr.Deref(req.Params.Prefix, ""), filter)
+
+	items := make([]api.SecretEvent, 0, len(res.Events))
+	for _, e := range res.Events {
+		item := api.SecretEvent{
+			Type:   api.SecretEventType(e.Type),
+			Secret: e.Secret,
+			Time:   e.Time,
+		}
+		if e.Id != "" {
+			item.Id = &e.Id
+		}
+		items = append(items, item)
+	}
+	okRes := api.WatchSecretEvents200JSONResponse{
+		SecretEventListResponseJSONResponse: api.SecretEventListResponseJSONResponse{
+			Items:  items,
+			Cursor: res.Cursor,
+			Reset:  res.Reset,
+		},
+	}
+	return okRes, nil
+}
+
 func (h *Handler) UpsertEnvironment(ctx context.Context, request api.UpsertEnvironmentRequestObject) (api.UpsertEnvironmentResponseObject, error) {
 	res, err := h.ctrl.OnboardEnvironment(ctx, request.EnvId)
 	if err != nil {
@@ -139,3 +355,24 @@ func mapOnboardingResponseItems(items map[string]string) []api.ListSecretItem {
 	}
 	return list
 }
+
+// generatorConfig converts the generator of the request
+func generatorConfig(g *api.SecretGenerator) generator.Config {
+	return generator.Config{
+		Type:         string(g.Type),
+		Length:       ptr.Deref(g.Length, 0),
+		MinLower:     ptr.Deref(g.MinLower, 0),
+		MinUpper:     ptr.Deref(g.MinUpper, 0),
+		MinDigits:    ptr.Deref(g.MinDigits, 0),
+		MinSymbols:   ptr.Deref(g.MinSymbols, 0),
+		Symbols:      ptr.Deref(g.Symbols, ""),
+		NoSymbols:    ptr.Deref(g.NoSymbols, false),
+		Bits:         ptr.Deref(g.Bits, 0),
+		Curve:        string(ptr.Deref(g.Curve, "")),
+		KeyType:      string(ptr.Deref(g.KeyType, "")),
+		CommonName:   ptr.Deref(g.CommonName, ""),
+		DNSNames:     ptr.Deref(g.DnsNames, nil),
+		ValidityDays: ptr.Deref(g.ValidityDays, 0),
+		Issuer:       string(ptr.Deref(g.Issuer, "")),
+	}
+}
@@ -0,0 +1,47 @@
+package handler
+
+import (
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+var _ fiber.Router = &customMethodRouter{}
+
+// customMethodRouter escapes custom methods like `/v1/secrets:batchGet` in the paths of the generated routes.
+// Otherwise, fiber would register `:batchGet` as path parameter.
+type customMethodRouter struct {
+	fiber.Router
+}
+
+// NewRouter wraps the router to register the generated routes
+func NewRouter(r fiber.Router) fiber.Router {
+	return &customMethodRouter{Router: r}
+}
+
+func (r *customMethodRouter) Get(path string, handlers ...fiber.Handler) fiber.Router {
+	return r.Router.Get(escapeCustomMethod(path), handlers...)
+}
+
+func (r *customMethodRouter) Put(path string, handlers ...fiber.Handler) fiber.Router {
+	return r.Router.Put(escapeCustomMethod(path), handlers...)
+}
+
+func (r *customMethodRouter) Post(path string, handlers ...fiber.Handler) fiber.Router {
+	return r.Router.Post(escapeCustomMethod(path), handlers...)
+}
+
+func (r *customMethodRouter) Delete(path string, handlers ...fiber.Handler) fiber.Router {
+	return r.Router.Delete(escapeCustomMethod(path), handlers...)
+}
+
+// escapeCustomMethod escapes all colons that do not start a path parameter
+func escapeCustomMethod(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if idx := strings.Index(segment, ":"); idx > 0 {
+			segments[i] = segment[:idx] + `\` + segment[idx:]
+		}
+	}
+	return strings.Join(segments, "/")
+}
@@ -0,0 +1,319 @@
+package setup
+
+import (
+	"context"
+	"encoding/base64"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	"github.com/telekom/controlplane-mono/secret-manager/cmd/server/config"
+	smbackend "github.com/telekom/controlplane-mono/secret-manager/pkg/backend"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/backend/cache"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/backend/conjur"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/backend/encrypt"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/backend/kubernetes"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/backend/routing"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/backend/vault"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/controller"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/metadata"
+	ctrlr "sigs.k8s.io/controller-runtime"
+	ctrlcache "sigs.k8s.io/controller-runtime/pkg/cache"
+)
+
+const (
+	trueStr     = "true"
+	typeRouting = "routing"
+	typeEncrypt = "encrypt"
+)
+
+// NewController creates the controller of the configured backend.
+// The onboarder of the backend is returned as ScopeLister to discover what has been onboarded.
+// The onboarders also create the secrets of package metadata.
+func NewController(ctx context.Context, cfg config.BackendConfig, blueprint smbackend.Blueprint) (c controller.Controller, scopes smbackend.ScopeLister, err error) {
+	blueprint = blueprint.WithDefaults()
+	if err := blueprint.Validate(); err != nil {
+		return nil, nil, errors.Wrap(err, "invalid blueprint")
+	}
+	blueprint = metadata.WithBlueprint(blueprint)
+
+	if cfg.Type == typeRouting {
+		router, err := newRoutingBackend(ctx, cfg.Routes, blueprint)
+		if err != nil {
+			return nil, nil, err
+		}
+		return controller.NewController(router, router), router, nil
+	}
+	route, err := newRoute(ctx, cfg, blueprint, routing.Rule{})
+	if err != nil {
+		return nil, nil, err
+	}
+	return controller.NewController(route.Backend, route.Onboarder), route.Onboarder, nil
+}
+
+// newRoutingBackend creates the backends of the routes. Each route has its own cache settings.
+func newRoutingBackend(ctx context.Context, cfgs []config.RouteConfig, blueprint smbackend.Blueprint) (*routing.RoutingBackend, error) {
+	routes := make([]routing.Route, 0, len(cfgs))
+	for _, cfg := range cfgs {
+		if cfg.Backend.Type == typeRouting {
+			return nil, errors.Errorf("route %s must not be a routing backend", cfg.Rule)
+		}
+		route, err := newRoute(ctx, cfg.Backend, blueprint, cfg.Rule)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to create backend of route %s", cfg.Rule)
+		}
+		routes = append(routes, route)
+	}
+	return routing.NewRoutingBackend(routes...)
+}
+
+// newRoute creates the backend and onboarder of the configured type
+func newRoute(ctx context.Context, cfg config.BackendConfig, blueprint smbackend.Blueprint, rule routing.Rule) (routing.Route, error) {
+	if cfg.Type == "" {
+		cfg.Type = "kubernetes"
+	}
+	cacheEnabled := cfg.GetDefault("disable_cache", "false") != trueStr
+	maxVersions, err := maxVersionsOf(cfg)
+	if err != nil {
+		return routing.Route{}, err
+	}
+
+	switch cfg.Type {
+	case "conjur":
+		conjurWriteApi := conjur.NewWriteApiOrDie()
+		conjurReadApi := conjur.NewReadOnlyApiOrDie()
+
+		bouncer, err := newBouncer(ctx, cfg)
+		if err != nil {
+			return routing.Route{}, err
+		}
+		backend := conjur.NewBackend(conjur.NewBouncedApi(conjurWriteApi, bouncer), conjur.NewBouncedApi(conjurReadApi, bouncer))
+		backend.(*conjur.ConjurBackend).MaxVersions = maxVersions
+		if cacheEnabled {
+			maxStale, err := time.ParseDuration(cfg.GetDefault("max_stale", "5m"))
+			if err != nil {
+				return routing.Route{}, errors.Wrap(err, "failed to parse max stale")
+			}
+			cached, err := newCachedBackend(backend, cfg)
+			if err != nil {
+				return routing.Route{}, err
+			}
+			backend = cached.WithStaleReads(maxStale)
+		}
+		// Policy loads are run using the bouncer by the onboarder itself
+		onboarder := conjur.NewOnboarder(conjurWriteApi, backend).WithBlueprint(blueprint)
+		onboarder.WithBouncer(bouncer)
+		return routing.NewRoute(rule, backend, onboarder), nil
+
+	case "kubernetes":
+		k8sClient, informers, err := kubernetes.NewInformerClient(ctx, ctrlr.GetConfigOrDie())
+		if err != nil {
+			return routing.Route{}, errors.Wrap(err, "failed to create kubernetes client")
+		}
+		backend := kubernetes.NewBackend(k8sClient)
+		backend.(*kubernetes.KubernetesBackend).MaxVersions = maxVersions
+		if cacheEnabled {
+			cached, err := newInvalidatedCachedBackend(ctx, backend, cfg, informers)
+			if err != nil {
+				return routing.Route{}, err
+			}
+			backend = cached
+		}
+		onboarder := kubernetes.NewOnboarder(k8sClient).WithBlueprint(blueprint)
+		return routing.NewRoute(rule, backend, onboarder), nil
+
+	case typeEncrypt:
+		encrypted, onboarder, informers, err := newEncryptedBackend(ctx, cfg, blueprint, maxVersions)
+		if err != nil {
+			return routing.Route{}, err
+		}
+		var backend smbackend.Backend[kubernetes.Id, smbackend.DefaultSecret[kubernetes.Id]] = encrypted
+		if cacheEnabled {
+			cached, err := newInvalidatedCachedBackend(ctx, backend, cfg, informers)
+			if err != nil {
+				return routing.Route{}, err
+			}
+			backend = cached
+		}
+		return routing.NewRoute(rule, backend, onboarder), nil
+
+	case "vault":
+		vaultClient := vault.NewClientOrDie()
+		backend := vault.NewBackend(vaultClient)
+		backend.(*vault.VaultBackend).MaxVersions = maxVersions
+		if cacheEnabled {
+			cached, err := newCachedBackend(backend, cfg)
+			if err != nil {
+				return routing.Route{}, err
+			}
+			backend = cached
+		}
+		onboarder := vault.NewOnboarder(vaultClient).WithBlueprint(blueprint)
+		return routing.NewRoute(rule, backend, onboarder), nil
+
+	default:
+		return routing.Route{}, errors.Errorf("unknown backend type: %s", cfg.Type)
+	}
+}
+
+// maxVersionsOf returns the number of versions that are kept, which is configured using `max_versions`
+func maxVersionsOf(cfg config.BackendConfig) (int, error) {
+	maxVersions, err := strconv.Atoi(cfg.GetDefault("max_versions", strconv.Itoa(smbackend.DefaultMaxVersions)))
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to parse max versions")
+	}
+	return maxVersions, nil
+}
+
+// newEncryptedBackend creates the Kubernetes backend that encrypts the secrets
+// and its onboarder, which encrypts the generated secrets using the same keys.
+func newEncryptedBackend(ctx context.Context, cfg config.BackendConfig, blueprint smbackend.Blueprint, maxVersions int) (*encrypt.EncryptedBackend[kubernetes.Id, smbackend.DefaultSecret[kubernetes.Id]], *kubernetes.KubernetesOnboarder, ctrlcache.Informers, error) {
+	keys, err := newKeyProvider(cfg)
+	if err != nil {
+		return nil, nil, nil, errors.Wrap(err, "failed to create key provider")
+	}
+	encrypter := encrypt.NewEnvelopeEncrypter(keys)
+	k8sClient, informers, err := kubernetes.NewInformerClient(ctx, ctrlr.GetConfigOrDie())
+	if err != nil {
+		return nil, nil, nil, errors.Wrap(err, "failed to create kubernetes client")
+	}
+	k8sBackend := kubernetes.NewBackend(k8sClient)
+	k8sBackend.(*kubernetes.KubernetesBackend).MaxVersions = maxVersions
+	onboarder := kubernetes.NewOnboarder(k8sClient).WithEncrypter(encrypter).WithBlueprint(blueprint)
+	return encrypt.NewEncryptedBackend(k8sBackend, encrypter), onboarder, informers, nil
+}
+
+// Rewrap re-wraps the data keys of the secrets of all encrypted backends with their current key, including the kept versions.
+// Each scope is re-wrapped by the backend of its secrets, like the routing backend selects it. Other backends are skipped.
+// It returns the number of secrets that have been changed.
+func Rewrap(ctx context.Context, cfg config.BackendConfig, blueprint smbackend.Blueprint) (int, error) {
+	log := logr.FromContextOrDiscard(ctx)
+	blueprint = blueprint.WithDefaults()
+	routes := []config.RouteConfig{{Backend: cfg}}
+	if cfg.Type == typeRouting {
+		routes = cfg.Routes
+	}
+
+	count := 0
+	for i, route := range routes {
+		if route.Backend.Type != typeEncrypt {
+			continue
+		}
+		maxVersions, err := maxVersionsOf(route.Backend)
+		if err != nil {
+			return count, err
+		}
+		encrypted, onboarder, _, err := newEncryptedBackend(ctx, route.Backend, blueprint, maxVersions)
+		if err != nil {
+			return count, errors.Wrapf(err, "failed to create backend of route %s", route.Rule)
+		}
+		scopes, err := onboarder.ListScopes(ctx)
+		if err != nil {
+			return count, errors.Wrapf(err, "failed to list scopes of route %s", route.Rule)
+		}
+		for _, scope := range scopes {
+			if ownerOf(routes, scope) != i {
+				continue
+			}
+			changed, err := encrypted.RewrapAll(ctx, scope.Env, scope.Team, scope.App)
+			count += changed
+			if err != nil && !smbackend.IsNotFoundErr(err) {
+				return count, errors.Wrapf(err, "failed to rewrap the secrets of %s", scope)
+			}
+			log.Info("Rewrapped secrets", "scope", scope.String(), "changed", changed)
+		}
+	}
+	return count, nil
+}
+
+// ownerOf returns the index of the first route that matches the scope, or -1 if none matches
+func ownerOf(routes []config.RouteConfig, scope smbackend.Scope) int {
+	for i, route := range routes {
+		if route.Rule.Matches(scope.String() + smbackend.Separator) {
+			return i
+		}
+	}
+	return -1
+}
+
+// newCachedBackend wraps the backend with a cache that is configured using
+// `cache_duration`, `negative_cache_duration` and `cache_max_size`
+func newCachedBackend[T smbackend.SecretId, S smbackend.Secret[T]](backend smbackend.Backend[T, S], cfg config.BackendConfig) (*cache.CachedBackend[T, S], error) {
+	cacheDuration, err := time.ParseDuration(cfg.GetDefault("cache_duration", "10s"))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse cache duration")
+	}
+	negativeDuration, err := time.ParseDuration(cfg.GetDefault("negative_cache_duration", "2s"))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse negative cache duration")
+	}
+	maxSize, err := strconv.Atoi(cfg.GetDefault("cache_max_size", "10000"))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse cache max size")
+	}
+
+	cached := cache.NewCachedBackend(backend, cacheDuration).WithMaxSize(maxSize)
+	if negativeDuration > 0 {
+		cached = cached.WithNegativeCaching(negativeDuration)
+	}
+	return cached, nil
+}
+
+// newInvalidatedCachedBackend is like newCachedBackend but also invalidates the cached secrets
+// if their Secret is changed, unless `cache_informer_invalidation` is disabled
+func newInvalidatedCachedBackend[T smbackend.SecretId, S smbackend.Secret[T]](ctx context.Context, backend smbackend.Backend[T, S], cfg config.BackendConfig, informers ctrlcache.Informers) (*cache.CachedBackend[T, S], error) {
+	cached, err := newCachedBackend(backend, cfg)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.GetDefault("cache_informer_invalidation", trueStr) == trueStr {
+		if err := kubernetes.InvalidateOnChange(ctx, informers, cached); err != nil {
+			return nil, errors.Wrap(err, "failed to setup cache invalidation")
+		}
+	}
+	return cached, nil
+}
+
+// newBouncer creates and starts the bouncer that is used for all calls to Conjur
+func newBouncer(ctx context.Context, cfg config.BackendConfig) (conjur.Bouncer, error) {
+	opts := conjur.DefaultBouncerOptions()
+	maxConcurrency, err := strconv.Atoi(cfg.GetDefault("max_concurrency", strconv.Itoa(conjur.DefaultMaxConcurrency)))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse max concurrency")
+	}
+	if opts.QueueSize, err = strconv.Atoi(cfg.GetDefault("queue_size", strconv.Itoa(opts.QueueSize))); err != nil {
+		return nil, errors.Wrap(err, "failed to parse queue size")
+	}
+	if opts.LatencyTarget, err = time.ParseDuration(cfg.GetDefault("latency_target", opts.LatencyTarget.String())); err != nil {
+		return nil, errors.Wrap(err, "failed to parse latency target")
+	}
+	if opts.FailureThreshold, err = strconv.Atoi(cfg.GetDefault("breaker_threshold", strconv.Itoa(opts.FailureThreshold))); err != nil {
+		return nil, errors.Wrap(err, "failed to parse breaker threshold")
+	}
+	if opts.OpenDuration, err = time.ParseDuration(cfg.GetDefault("breaker_open_duration", opts.OpenDuration.String())); err != nil {
+		return nil, errors.Wrap(err, "failed to parse breaker open duration")
+	}
+
+	bouncer := conjur.NewBouncerWithOptions(opts)
+	bouncer.StartN(ctx, maxConcurrency)
+	return bouncer, nil
+}
+
+// newKeyProvider reads the keys from the files in `key_dir` if configured.
+// Otherwise, a single base64-encoded key is read from the environment variable ENCRYPTION_KEY.
+func newKeyProvider(cfg config.BackendConfig) (encrypt.KeyProvider, error) {
+	if keyDir := cfg.Get("key_dir"); keyDir != "" {
+		return encrypt.NewFileKeyProvider(keyDir, cfg.Get("current_key_id"))
+	}
+	rawKey := os.Getenv("ENCRYPTION_KEY")
+	if rawKey == "" {
+		return nil, errors.New("either key_dir or ENCRYPTION_KEY must be set")
+	}
+	key, err := base64.StdEncoding.DecodeString(rawKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode ENCRYPTION_KEY")
+	}
+	return encrypt.NewStaticKeyProvider("default", map[string][]byte{"default": key})
+}
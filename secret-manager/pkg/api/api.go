@@ -2,16 +2,19 @@ package api
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
 	"os"
 	"strings"
+	"sync"
+	"time"
 
+	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
 	"github.com/telekom/controlplane-mono/secret-manager/pkg/api/accesstoken"
 	"github.com/telekom/controlplane-mono/secret-manager/pkg/api/gen"
 	"github.com/telekom/controlplane-mono/secret-manager/pkg/api/util"
+	ctrllog "sigs.k8s.io/controller-runtime/pkg/log"
 )
 
 const (
@@ -26,14 +29,35 @@ const (
 	KeywordRotate = "rotate"
 )
 
+// MaxBatchSize is the maximum number of secrets that are requested at once
+const MaxBatchSize = 100
+
 var (
-	ErrNotFound = errors.New("resource not found")
+	// ClientMaxAttempts overrides the number of attempts of idempotent requests, see RetryOptions
+	ClientMaxAttempts = os.Getenv("CLIENT_MAX_ATTEMPTS")
+	// ClientFallbackTTL enables the fallback cache with the maximum age of its values, see WithFallbackCache
+	ClientFallbackTTL = os.Getenv("CLIENT_FALLBACK_TTL")
 )
 
 type SecretsApi interface {
 	Get(ctx context.Context, secretID string) (value string, err error)
+	// GetMany returns the values of the secrets by their IDs as passed.
+	// If some secrets could not be retrieved, the others are returned together with a *BatchError.
+	GetMany(ctx context.Context, secretIDs []string) (values map[string]string, err error)
 	Set(ctx context.Context, secretID string, secretValue string) (newID string, err error)
 	Rotate(ctx context.Context, secretID string) (newID string, err error)
+	// Generate sets the secret to a new value of the generator.
+	// Later rotations of the secret use the same generator.
+	Generate(ctx context.Context, secretID string, generator gen.SecretGenerator) (newID string, err error)
+	// GetPublicKey returns the PEM-encoded public key of a key pair or the certificates of a certificate
+	GetPublicKey(ctx context.Context, secretID string) (publicKey string, err error)
+	// List returns the references of all secrets of the environment, team or application.
+	// The team secrets are listed if appID is empty and the environment secrets if teamID is empty as well.
+	List(ctx context.Context, envID, teamID, appID string) (items []gen.ListSecretItem, err error)
+	// Delete deletes the secret. If the server keeps deleted secrets, it can be restored using Undelete.
+	Delete(ctx context.Context, secretID string) (err error)
+	// Undelete restores a deleted secret until its retention has passed
+	Undelete(ctx context.Context, secretID string) (newID string, err error)
 }
 
 type OnboardingApi interface {
@@ -49,17 +73,39 @@ type OnboardingApi interface {
 type SecretManager interface {
 	SecretsApi
 	OnboardingApi
+	WatchApi
 }
 
 var _ SecretManager = (*secretManagerAPI)(nil)
 
 type secretManagerAPI struct {
 	client gen.ClientWithResponsesInterface
+
+	options       *Options
+	skipTlsVerify bool
+	caFilepath    string
+	// cache is nil if the fallback cache is disabled
+	cache *fallbackCache
+	// watchClient is only created when needed as long-polling requires a longer timeout
+	watchClient gen.ClientWithResponsesInterface
+	watchErr    error
+	watchOnce   sync.Once
 }
 
 type Options struct {
 	URL   string
 	Token accesstoken.AccessToken
+	// Timeout limits each attempt of a request. It defaults to CLIENT_TIMEOUT or util.DefaultClientTimeout.
+	Timeout time.Duration
+	// Retry configures how idempotent requests are retried while the secret-manager is unavailable
+	Retry RetryOptions
+	// FallbackTTL is the maximum age of the cached values that are returned while the secret-manager is unavailable.
+	// If it is 0, no values are cached.
+	FallbackTTL time.Duration
+	// FallbackSize is the maximum number of cached values. It defaults to DefaultFallbackSize.
+	FallbackSize int
+	// Log is used for warnings, e.g. about insecure connections
+	Log logr.Logger
 }
 
 func (o *Options) accessTokenReqEditor(ctx context.Context, req *http.Request) error {
@@ -74,18 +120,33 @@ func (o *Options) accessTokenReqEditor(ctx context.Context, req *http.Request) e
 	return nil
 }
 
-func defaultOptions() *Options {
+func defaultOptions() (*Options, error) {
+	options := &Options{
+		URL:   localhost,
+		Token: nil,
+		Retry: defaultRetryOptions(),
+		Log:   ctrllog.Log.WithName("secret-manager-client"),
+	}
 	if util.IsRunningInCluster() {
-		return &Options{
-			URL:   inCluster,
-			Token: accesstoken.NewAccessToken(accesstoken.TokenFilePath),
+		options.URL = inCluster
+		options.Token = accesstoken.NewAccessToken(accesstoken.TokenFilePath)
+	}
+
+	var err error
+	if options.Timeout, err = util.DefaultTimeout(); err != nil {
+		return nil, err
+	}
+	if ClientMaxAttempts != "" {
+		if _, err := fmt.Sscan(ClientMaxAttempts, &options.Retry.MaxAttempts); err != nil {
+			return nil, errors.Wrap(err, "failed to parse CLIENT_MAX_ATTEMPTS")
 		}
-	} else {
-		return &Options{
-			URL:   localhost,
-			Token: nil,
+	}
+	if ClientFallbackTTL != "" {
+		if options.FallbackTTL, err = time.ParseDuration(ClientFallbackTTL); err != nil {
+			return nil, errors.Wrap(err, "failed to parse CLIENT_FALLBACK_TTL")
 		}
 	}
+	return options, nil
 }
 
 type Option func(*Options)
@@ -102,206 +163,422 @@ func WithAccessToken(token accesstoken.AccessToken) Option {
 	}
 }
 
-func NewOnboarding(opts ...Option) OnboardingApi {
+// WithTimeout limits each attempt of a request
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *Options) {
+		o.Timeout = timeout
+	}
+}
+
+// WithRetry configures how idempotent requests are retried while the secret-manager is unavailable
+func WithRetry(retry RetryOptions) Option {
+	return func(o *Options) {
+		o.Retry = retry
+	}
+}
+
+// WithFallbackCache keeps the recently resolved values for the TTL.
+// They are returned by Get and GetMany while the secret-manager is unavailable.
+func WithFallbackCache(ttl time.Duration, size int) Option {
+	return func(o *Options) {
+		o.FallbackTTL = ttl
+		o.FallbackSize = size
+	}
+}
+
+func WithLogger(log logr.Logger) Option {
+	return func(o *Options) {
+		o.Log = log
+	}
+}
+
+func NewOnboarding(opts ...Option) (OnboardingApi, error) {
+	return New(opts...)
+}
+
+func NewSecrets(opts ...Option) (SecretsApi, error) {
 	return New(opts...)
 }
 
-func NewSecrets(opts ...Option) SecretsApi {
+func NewWatch(opts ...Option) (WatchApi, error) {
 	return New(opts...)
 }
 
-func New(opts ...Option) SecretManager {
-	options := defaultOptions()
+func New(opts ...Option) (SecretManager, error) {
+	options, err := defaultOptions()
+	if err != nil {
+		return nil, err
+	}
 	for _, opt := range opts {
 		opt(options)
 	}
 
+	// The trust bundle is only needed to verify the server
+	caFilepath := CaFilePath
 	if !strings.HasPrefix(options.URL, "https://") {
-		fmt.Println("⚠️\tWarning: Using HTTP instead of HTTPS. This is not secure.")
+		options.Log.Info("Warning: Using HTTP instead of HTTPS. This is not secure.", "url", options.URL)
+		caFilepath = ""
 	}
 	skipTlsVerify := os.Getenv("SKIP_TLS_VERIFY") == "true"
-	httpClient, err := gen.NewClientWithResponses(options.URL, gen.WithHTTPClient(util.NewHttpClientOrDie(skipTlsVerify, CaFilePath)), gen.WithRequestEditorFn(options.accessTokenReqEditor))
+	if skipTlsVerify {
+		options.Log.Info("Warning: Using InsecureSkipVerify. This is not secure.")
+	}
+	httpClient, err := util.NewHttpClient(skipTlsVerify, caFilepath, options.Timeout)
 	if err != nil {
-		panic(fmt.Sprintf("Failed to create client: %v", err))
+		return nil, errors.Wrap(err, "failed to create HTTP client")
+	}
+	client, err := gen.NewClientWithResponses(options.URL, gen.WithHTTPClient(httpClient), gen.WithRequestEditorFn(options.accessTokenReqEditor))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create client")
+	}
+
+	s := &secretManagerAPI{
+		client:        client,
+		options:       options,
+		skipTlsVerify: skipTlsVerify,
+		caFilepath:    caFilepath,
 	}
-	return &secretManagerAPI{
-		client: httpClient,
+	if options.FallbackTTL > 0 {
+		s.cache = newFallbackCache(options.FallbackTTL, options.FallbackSize)
 	}
+	return s, nil
+}
+
+// fallback returns the cached value of the secret if the request failed because the secret-manager is unavailable
+func (s *secretManagerAPI) fallback(ctx context.Context, secretID string, err error) (string, bool) {
+	if !errors.Is(err, ErrUnavailable) {
+		return "", false
+	}
+	value, ok := s.cache.Get(secretID)
+	if ok {
+		logr.FromContextOrDiscard(ctx).Info("Secret-manager is unavailable, using cached value", "id", secretID, "error", err.Error())
+	}
+	return value, ok
 }
 
 func (s *secretManagerAPI) Get(ctx context.Context, secretID string) (value string, err error) {
 	// Remove the tags from the secret ID if it is a placeholder.
 	// If it is not a placeholder, we just assume that it is a valid secret ID.
 	secretID, _ = FromRef(secretID)
-	res, err := s.client.GetSecretWithResponse(ctx, secretID)
+	err = s.do(ctx, true, func(ctx context.Context) error {
+		res, err := s.client.GetSecretWithResponse(ctx, secretID)
+		if err != nil {
+			return requestError(err)
+		}
+		if res.StatusCode() != http.StatusOK {
+			return responseError(res.StatusCode(), res.Body)
+		}
+		value = res.JSON200.Value
+		return nil
+	})
 	if err != nil {
+		if cached, ok := s.fallback(ctx, secretID, err); ok {
+			return cached, nil
+		}
 		return "", err
 	}
-	switch res.StatusCode() {
-	case 200:
-		return res.JSON200.Value, nil
-	case 404:
-		return "", ErrNotFound
-	default:
-		var err gen.ErrorResponse
-		if err := json.Unmarshal(res.Body, &err); err != nil {
-			return "", err
+	s.cache.Put(secretID, value)
+	return value, nil
+}
+
+func (s *secretManagerAPI) GetMany(ctx context.Context, secretIDs []string) (values map[string]string, err error) {
+	// The same secret might be passed as placeholder and as ID
+	requested := make(map[string][]string, len(secretIDs))
+	ids := make([]string, 0, len(secretIDs))
+	for _, ref := range secretIDs {
+		id, _ := FromRef(ref)
+		if _, ok := requested[id]; !ok {
+			ids = append(ids, id)
+		}
+		requested[id] = append(requested[id], ref)
+	}
+
+	values = make(map[string]string, len(secretIDs))
+	batchErr := &BatchError{Errors: map[string]error{}}
+	for start := 0; start < len(ids); start += MaxBatchSize {
+		chunk := ids[start:min(start+MaxBatchSize, len(ids))]
+		items, err := s.batchGet(ctx, chunk)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range items {
+			var value string
+			var itemErr error
+			switch {
+			case item.Error != nil:
+				itemErr = &Error{StatusCode: item.Error.Status, Type: item.Error.Type, Detail: item.Error.Detail}
+				cached, ok := s.fallback(ctx, item.Id, itemErr)
+				if !ok {
+					break
+				}
+				value, itemErr = cached, nil
+			case item.Value != nil:
+				value = *item.Value
+				s.cache.Put(item.Id, value)
+			default:
+				continue
+			}
+			for _, ref := range requested[item.Id] {
+				if itemErr != nil {
+					batchErr.Errors[ref] = itemErr
+				} else {
+					values[ref] = value
+				}
+			}
+		}
+	}
+
+	if len(batchErr.Errors) > 0 {
+		return values, batchErr
+	}
+	return values, nil
+}
+
+// batchGet returns the items of the secrets. If the secret-manager is unavailable,
+// the cached values are returned if all of the secrets are cached.
+func (s *secretManagerAPI) batchGet(ctx context.Context, ids []string) (items []gen.BatchGetItem, err error) {
+	err = s.do(ctx, true, func(ctx context.Context) error {
+		res, err := s.client.BatchGetSecretsWithResponse(ctx, gen.BatchGetSecretsJSONRequestBody{Ids: ids})
+		if err != nil {
+			return requestError(err)
+		}
+		if res.StatusCode() != http.StatusOK {
+			return responseError(res.StatusCode(), res.Body)
 		}
-		return "", fmt.Errorf("Error %s: %s", err.Type, err.Detail)
+		items = res.JSON200.Items
+		return nil
+	})
+	if err == nil {
+		return items, nil
 	}
+
+	items = make([]gen.BatchGetItem, 0, len(ids))
+	for _, id := range ids {
+		cached, ok := s.fallback(ctx, id, err)
+		if !ok {
+			return nil, err
+		}
+		items = append(items, gen.BatchGetItem{Id: id, Value: &cached})
+	}
+	return items, nil
 }
+
 func (s *secretManagerAPI) Set(ctx context.Context, secretID string, secretValue string) (newID string, err error) {
+	// Setting the same value again does not change the secret
+	newID, err = s.put(ctx, secretID, gen.PutSecretJSONRequestBody{Value: secretValue}, secretValue != KeywordRotate)
+	if err == nil && secretValue != KeywordRotate {
+		id, _ := FromRef(newID)
+		s.cache.Put(id, secretValue)
+	}
+	return newID, err
+}
+
+func (s *secretManagerAPI) put(ctx context.Context, secretID string, body gen.PutSecretJSONRequestBody, idempotent bool) (newID string, err error) {
 	// Remove the tags from the secret ID if it is a placeholder.
 	// If it is not a placeholder, we just assume that it is a valid secret ID.
 	secretID, _ = FromRef(secretID)
-	res, err := s.client.PutSecretWithResponse(ctx, secretID, gen.PutSecretJSONRequestBody{Value: secretValue})
-	if err != nil {
-		return "", err
-	}
-	switch res.StatusCode() {
-	case 200:
-		return ToRef(res.JSON200.Id), nil
-	case 204:
-		return secretID, nil
-	case 404:
-		return "", ErrNotFound
-	default:
-		var err gen.ErrorResponse
-		if err := json.Unmarshal(res.Body, &err); err != nil {
-			return "", err
+	err = s.do(ctx, idempotent, func(ctx context.Context) error {
+		res, err := s.client.PutSecretWithResponse(ctx, secretID, body)
+		if err != nil {
+			return requestError(err)
 		}
-		return "", fmt.Errorf("Error %s: %s", err.Type, err.Detail)
-	}
+		switch res.StatusCode() {
+		case http.StatusOK:
+			newID = ToRef(res.JSON200.Id)
+		case http.StatusNoContent:
+			newID = secretID
+		default:
+			return responseError(res.StatusCode(), res.Body)
+		}
+		return nil
+	})
+	return newID, err
 }
 
 func (s *secretManagerAPI) Rotate(ctx context.Context, secretID string) (newID string, err error) {
 	return s.Set(ctx, secretID, KeywordRotate)
 }
 
-func (s *secretManagerAPI) UpsertEnvironment(ctx context.Context, envID string) (availableSecrets []gen.ListSecretItem, err error) {
-	res, err := s.client.UpsertEnvironmentWithResponse(ctx, envID)
-	if err != nil {
-		return nil, err
+func (s *secretManagerAPI) Generate(ctx context.Context, secretID string, generator gen.SecretGenerator) (newID string, err error) {
+	return s.put(ctx, secretID, gen.PutSecretJSONRequestBody{Value: KeywordRotate, Generator: &generator}, false)
+}
+
+func (s *secretManagerAPI) GetPublicKey(ctx context.Context, secretID string) (publicKey string, err error) {
+	secretID, _ = FromRef(secretID)
+	err = s.do(ctx, true, func(ctx context.Context) error {
+		res, err := s.client.GetPublicKeyWithResponse(ctx, secretID)
+		if err != nil {
+			return requestError(err)
+		}
+		if res.StatusCode() != http.StatusOK {
+			return responseError(res.StatusCode(), res.Body)
+		}
+		publicKey = res.JSON200.PublicKey
+		return nil
+	})
+	return publicKey, err
+}
+
+func (s *secretManagerAPI) List(ctx context.Context, envID, teamID, appID string) (items []gen.ListSecretItem, err error) {
+	params := &gen.ListSecretsParams{Env: envID}
+	if teamID != "" {
+		params.Team = &teamID
+	}
+	if appID != "" {
+		params.App = &appID
 	}
-	switch res.StatusCode() {
-	case 200:
-		return res.JSON200.Items, nil
-	case 204:
-		return nil, nil
-	case 404:
-		return nil, ErrNotFound
-	default:
-		var err gen.ErrorResponse
-		if err := json.Unmarshal(res.Body, &err); err != nil {
+	items = []gen.ListSecretItem{}
+	for {
+		var page *gen.SecretRefListResponse
+		err = s.do(ctx, true, func(ctx context.Context) error {
+			res, err := s.client.ListSecretsWithResponse(ctx, params)
+			if err != nil {
+				return requestError(err)
+			}
+			if res.StatusCode() != http.StatusOK {
+				return responseError(res.StatusCode(), res.Body)
+			}
+			page = res.JSON200
+			return nil
+		})
+		if err != nil {
 			return nil, err
 		}
-		return nil, fmt.Errorf("Error %s: %s", err.Type, err.Detail)
+		items = append(items, page.Items...)
+		if page.Next == nil || *page.Next == "" {
+			return items, nil
+		}
+		params.Cursor = page.Next
 	}
 }
 
+func (s *secretManagerAPI) Delete(ctx context.Context, secretID string) (err error) {
+	secretID, _ = FromRef(secretID)
+	// A retry would fail with not found if the secret has been deleted by the first attempt
+	return s.do(ctx, false, func(ctx context.Context) error {
+		res, err := s.client.DeleteSecretWithResponse(ctx, secretID)
+		if err != nil {
+			return requestError(err)
+		}
+		switch res.StatusCode() {
+		case http.StatusOK, http.StatusNoContent:
+			return nil
+		default:
+			return responseError(res.StatusCode(), res.Body)
+		}
+	})
+}
+
+func (s *secretManagerAPI) Undelete(ctx context.Context, secretID string) (newID string, err error) {
+	secretID, _ = FromRef(secretID)
+	err = s.do(ctx, false, func(ctx context.Context) error {
+		res, err := s.client.UndeleteSecretWithResponse(ctx, secretID)
+		if err != nil {
+			return requestError(err)
+		}
+		if res.StatusCode() != http.StatusOK {
+			return responseError(res.StatusCode(), res.Body)
+		}
+		newID = ToRef(res.JSON200.Id)
+		return nil
+	})
+	return newID, err
+}
+
+// upsert calls the onboarding request, which can be retried as onboarding the same scope again does not change it
+func (s *secretManagerAPI) upsert(ctx context.Context, request func(ctx context.Context) (*http.Response, *gen.OnboardingResponse, []byte, error)) (availableSecrets []gen.ListSecretItem, err error) {
+	err = s.do(ctx, true, func(ctx context.Context) error {
+		res, body, raw, err := request(ctx)
+		if err != nil {
+			return requestError(err)
+		}
+		switch res.StatusCode {
+		case http.StatusOK:
+			availableSecrets = body.Items
+		case http.StatusNoContent:
+			availableSecrets = nil
+		default:
+			return responseError(res.StatusCode, raw)
+		}
+		return nil
+	})
+	return availableSecrets, err
+}
+
+func (s *secretManagerAPI) UpsertEnvironment(ctx context.Context, envID string) (availableSecrets []gen.ListSecretItem, err error) {
+	return s.upsert(ctx, func(ctx context.Context) (*http.Response, *gen.OnboardingResponse, []byte, error) {
+		res, err := s.client.UpsertEnvironmentWithResponse(ctx, envID)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		return res.HTTPResponse, res.JSON200, res.Body, nil
+	})
+}
+
 func (s *secretManagerAPI) UpsertTeam(ctx context.Context, envID, teamID string) (availableSecrets []gen.ListSecretItem, err error) {
-	res, err := s.client.UpsertTeamWithResponse(ctx, envID, teamID)
-	if err != nil {
-		return nil, err
-	}
-	switch res.StatusCode() {
-	case 200:
-		return res.JSON200.Items, nil
-	case 204:
-		return nil, nil
-	case 404:
-		return nil, ErrNotFound
-	default:
-		var err gen.ErrorResponse
-		if err := json.Unmarshal(res.Body, &err); err != nil {
-			return nil, err
+	return s.upsert(ctx, func(ctx context.Context) (*http.Response, *gen.OnboardingResponse, []byte, error) {
+		res, err := s.client.UpsertTeamWithResponse(ctx, envID, teamID)
+		if err != nil {
+			return nil, nil, nil, err
 		}
-		return nil, fmt.Errorf("Error %s: %s", err.Type, err.Detail)
-	}
+		return res.HTTPResponse, res.JSON200, res.Body, nil
+	})
 }
 
 func (s *secretManagerAPI) UpsertApplication(ctx context.Context, envID, teamID, appID string) (availableSecrets []gen.ListSecretItem, err error) {
-	res, err := s.client.UpsertAppWithResponse(ctx, envID, teamID, appID)
-	if err != nil {
-		return nil, err
-	}
-	switch res.StatusCode() {
-	case 200:
-		return res.JSON200.Items, nil
-	case 204:
-		return nil, nil
-	case 404:
-		return nil, ErrNotFound
-	default:
-		var err gen.ErrorResponse
-		if err := json.Unmarshal(res.Body, &err); err != nil {
-			return nil, err
+	return s.upsert(ctx, func(ctx context.Context) (*http.Response, *gen.OnboardingResponse, []byte, error) {
+		res, err := s.client.UpsertAppWithResponse(ctx, envID, teamID, appID)
+		if err != nil {
+			return nil, nil, nil, err
 		}
-		return nil, fmt.Errorf("Error %s: %s", err.Type, err.Detail)
-	}
+		return res.HTTPResponse, res.JSON200, res.Body, nil
+	})
+}
+
+// deleteScope calls the offboarding request. Scopes that do not exist are already deleted.
+func (s *secretManagerAPI) deleteScope(ctx context.Context, request func(ctx context.Context) (statusCode int, body []byte, err error)) error {
+	return s.do(ctx, false, func(ctx context.Context) error {
+		statusCode, body, err := request(ctx)
+		if err != nil {
+			return requestError(err)
+		}
+		switch statusCode {
+		case http.StatusOK, http.StatusNoContent, http.StatusNotFound:
+			return nil
+		default:
+			return responseError(statusCode, body)
+		}
+	})
 }
 
 func (s *secretManagerAPI) DeleteEnvironment(ctx context.Context, envID string) (err error) {
-	res, err := s.client.DeleteEnvironmentWithResponse(ctx, envID)
-	if err != nil {
-		return err
-	}
-	switch res.StatusCode() {
-	case 200:
-		return nil
-	case 204:
-		return nil
-	case 404:
-		return nil
-	default:
-		var err gen.ErrorResponse
-		if err := json.Unmarshal(res.Body, &err); err != nil {
-			return err
+	return s.deleteScope(ctx, func(ctx context.Context) (int, []byte, error) {
+		res, err := s.client.DeleteEnvironmentWithResponse(ctx, envID)
+		if err != nil {
+			return 0, nil, err
 		}
-		return fmt.Errorf("Error %s: %s", err.Type, err.Detail)
-	}
+		return res.StatusCode(), res.Body, nil
+	})
 }
 
 func (s *secretManagerAPI) DeleteTeam(ctx context.Context, envID, teamID string) (err error) {
-	res, err := s.client.DeleteTeamWithResponse(ctx, envID, teamID)
-	if err != nil {
-		return err
-	}
-	switch res.StatusCode() {
-	case 200:
-		return nil
-	case 204:
-		return nil
-	case 404:
-		return nil
-	default:
-		var err gen.ErrorResponse
-		if err := json.Unmarshal(res.Body, &err); err != nil {
-			return err
+	return s.deleteScope(ctx, func(ctx context.Context) (int, []byte, error) {
+		res, err := s.client.DeleteTeamWithResponse(ctx, envID, teamID)
+		if err != nil {
+			return 0, nil, err
 		}
-		return fmt.Errorf("Error %s: %s", err.Type, err.Detail)
-	}
+		return res.StatusCode(), res.Body, nil
+	})
 }
 
 func (s *secretManagerAPI) DeleteApplication(ctx context.Context, envID, teamID, appID string) (err error) {
-	res, err := s.client.DeleteAppWithResponse(ctx, envID, teamID, appID)
-	if err != nil {
-		return err
-	}
-	switch res.StatusCode() {
-	case 200:
-		return nil
-	case 204:
-		return nil
-	case 404:
-		return nil
-	default:
-		var err gen.ErrorResponse
-		if err := json.Unmarshal(res.Body, &err); err != nil {
-			return err
+	return s.deleteScope(ctx, func(ctx context.Context) (int, []byte, error) {
+		res, err := s.client.DeleteAppWithResponse(ctx, envID, teamID, appID)
+		if err != nil {
+			return 0, nil, err
 		}
-		return fmt.Errorf("Error %s: %s", err.Type, err.Detail)
-	}
+		return res.StatusCode(), res.Body, nil
+	})
 }
 
 // FindSecretId will find the secret ID for the given name in the list of secrets.
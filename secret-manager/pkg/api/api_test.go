@@ -0,0 +1,228 @@
+package api_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/api"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/api/gen"
+)
+
+// retriable is implemented by the OperatorError of the common module
+type retriable interface {
+	error
+	Retriable() bool
+}
+
+// fakeServer answers the requests with the queued statuses, followed by successful responses
+type fakeServer struct {
+	mu       sync.Mutex
+	statuses []int
+	delay    time.Duration
+	requests map[string]int
+}
+
+func (s *fakeServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	s.requests[r.Method+" "+r.URL.Path]++
+	status := http.StatusOK
+	if len(s.statuses) > 0 {
+		status, s.statuses = s.statuses[0], s.statuses[1:]
+	}
+	s.mu.Unlock()
+
+	time.Sleep(s.delay)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if status != http.StatusOK {
+		_ = json.NewEncoder(w).Encode(gen.ErrorResponse{Type: "Error", Status: status, Title: http.StatusText(status), Detail: "failed"})
+		return
+	}
+
+	switch {
+	case strings.HasSuffix(r.URL.Path, ":batchGet"):
+		var req gen.BatchGetRequest
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		items := make([]gen.BatchGetItem, 0, len(req.Ids))
+		for _, id := range req.Ids {
+			value := "value-of-" + id
+			items = append(items, gen.BatchGetItem{Id: id, Value: &value})
+		}
+		_ = json.NewEncoder(w).Encode(gen.BatchGetResponse{Items: items})
+	case r.Method == http.MethodPut:
+		_ = json.NewEncoder(w).Encode(gen.SecretWriteResponse{Id: "env:team:app:secret:v2"})
+	default:
+		_ = json.NewEncoder(w).Encode(gen.Secret{Id: "env:team:app:secret:v1", Value: "topsecret"})
+	}
+}
+
+func (s *fakeServer) count(method, path string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests[method+" "+path]
+}
+
+var _ = Describe("Client", func() {
+
+	const secretID = "env:team:app:secret:v1"
+	const secretPath = "/v1/secrets/" + secretID
+
+	var ctx context.Context
+	var fake *fakeServer
+	var server *httptest.Server
+	var opts []api.Option
+
+	newClient := func(extra ...api.Option) api.SecretManager {
+		client, err := api.New(append(opts, extra...)...)
+		Expect(err).ToNot(HaveOccurred())
+		return client
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		fake = &fakeServer{requests: map[string]int{}}
+		server = httptest.NewServer(fake)
+		DeferCleanup(server.Close)
+		opts = []api.Option{
+			api.WithURL(server.URL),
+			api.WithRetry(api.RetryOptions{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond}),
+		}
+	})
+
+	Context("Typed errors", func() {
+
+		It("should return not found errors that are not retriable", func() {
+			fake.statuses = []int{http.StatusNotFound}
+
+			_, err := newClient().Get(ctx, secretID)
+			Expect(err).To(MatchError(api.ErrNotFound))
+			Expect(err).To(MatchError("Error Error: failed"))
+
+			var retriableErr retriable
+			Expect(errors.As(err, &retriableErr)).To(BeTrue())
+			Expect(retriableErr.Retriable()).To(BeFalse())
+			Expect(fake.count(http.MethodGet, secretPath)).To(Equal(1))
+		})
+
+		It("should return forbidden errors", func() {
+			fake.statuses = []int{http.StatusForbidden}
+
+			_, err := newClient().Get(ctx, secretID)
+			Expect(err).To(MatchError(api.ErrForbidden))
+			Expect(err).ToNot(MatchError(api.ErrUnavailable))
+		})
+
+		It("should return retriable unavailable errors if the server can not be reached", func() {
+			server.Close()
+
+			_, err := newClient().Get(ctx, secretID)
+			Expect(err).To(MatchError(api.ErrUnavailable))
+
+			var retriableErr retriable
+			Expect(errors.As(err, &retriableErr)).To(BeTrue())
+			Expect(retriableErr.Retriable()).To(BeTrue())
+		})
+	})
+
+	Context("Retries", func() {
+
+		It("should retry idempotent requests while the server is unavailable", func() {
+			fake.statuses = []int{http.StatusServiceUnavailable, http.StatusBadGateway}
+
+			value, err := newClient().Get(ctx, secretID)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(Equal("topsecret"))
+			Expect(fake.count(http.MethodGet, secretPath)).To(Equal(3))
+		})
+
+		It("should give up after the maximum attempts", func() {
+			fake.statuses = []int{503, 503, 503, 503}
+
+			_, err := newClient().Get(ctx, secretID)
+			Expect(err).To(MatchError(api.ErrUnavailable))
+			Expect(fake.count(http.MethodGet, secretPath)).To(Equal(3))
+		})
+
+		It("should retry setting a value", func() {
+			fake.statuses = []int{http.StatusServiceUnavailable}
+
+			newID, err := newClient().Set(ctx, secretID, "new-value")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(newID).To(Equal("$<env:team:app:secret:v2>"))
+			Expect(fake.count(http.MethodPut, secretPath)).To(Equal(2))
+		})
+
+		It("should not retry rotations", func() {
+			fake.statuses = []int{http.StatusServiceUnavailable}
+
+			_, err := newClient().Rotate(ctx, secretID)
+			Expect(err).To(MatchError(api.ErrUnavailable))
+			Expect(fake.count(http.MethodPut, secretPath)).To(Equal(1))
+		})
+
+		It("should limit each attempt by the timeout", func() {
+			fake.delay = 200 * time.Millisecond
+
+			_, err := newClient(api.WithTimeout(20*time.Millisecond), api.WithRetry(api.RetryOptions{MaxAttempts: 1})).Get(ctx, secretID)
+			Expect(err).To(MatchError(api.ErrUnavailable))
+			Expect(err).To(MatchError(context.DeadlineExceeded))
+		})
+	})
+
+	Context("Fallback cache", func() {
+
+		It("should return the cached value while the server is unavailable", func() {
+			client := newClient(api.WithFallbackCache(time.Minute, 10))
+			_, err := client.Get(ctx, secretID)
+			Expect(err).ToNot(HaveOccurred())
+
+			fake.statuses = []int{503, 503, 503}
+			value, err := client.Get(ctx, secretID)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(value).To(Equal("topsecret"))
+		})
+
+		It("should not return cached values for other errors", func() {
+			client := newClient(api.WithFallbackCache(time.Minute, 10))
+			_, err := client.Get(ctx, secretID)
+			Expect(err).ToNot(HaveOccurred())
+
+			fake.statuses = []int{http.StatusNotFound}
+			_, err = client.Get(ctx, secretID)
+			Expect(err).To(MatchError(api.ErrNotFound))
+		})
+
+		It("should not cache values if it is disabled", func() {
+			client := newClient()
+			_, err := client.Get(ctx, secretID)
+			Expect(err).ToNot(HaveOccurred())
+
+			fake.statuses = []int{503, 503, 503}
+			_, err = client.Get(ctx, secretID)
+			Expect(err).To(MatchError(api.ErrUnavailable))
+		})
+
+		It("should return the cached values of a batch if all are cached", func() {
+			client := newClient(api.WithFallbackCache(time.Minute, 10))
+			_, err := client.GetMany(ctx, []string{"a", "b"})
+			Expect(err).ToNot(HaveOccurred())
+
+			fake.statuses = []int{503, 503, 503}
+			values, err := client.GetMany(ctx, []string{"a", "$<b>"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(values).To(Equal(map[string]string{"a": "value-of-a", "$<b>": "value-of-b"}))
+
+			fake.statuses = []int{503, 503, 503}
+			_, err = client.GetMany(ctx, []string{"a", "c"})
+			Expect(err).To(MatchError(api.ErrUnavailable))
+		})
+	})
+})
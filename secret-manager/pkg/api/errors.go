@@ -0,0 +1,128 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/api/gen"
+)
+
+var (
+	// ErrNotFound is matched by errors.Is if the secret or scope does not exist, or the version of the secret has expired
+	ErrNotFound = errors.New("resource not found")
+	// ErrForbidden is matched by errors.Is if the caller is not authenticated or not allowed to access the resource
+	ErrForbidden = errors.New("access denied")
+	// ErrUnavailable is matched by errors.Is if the secret-manager could not be reached or is temporarily unable to handle the request.
+	// Such requests can be retried later.
+	ErrUnavailable = errors.New("secret-manager unavailable")
+)
+
+// Error is returned if a request to the secret-manager failed.
+// Use errors.Is with ErrNotFound, ErrForbidden or ErrUnavailable to check the kind of the error.
+//
+// It implements Retriable, so controllers handle it like an OperatorError of the common module.
+type Error struct {
+	// StatusCode is the HTTP status of the response. It is 0 if no response has been received.
+	StatusCode int
+	Type       string
+	Detail     string
+	// Err is the cause if no response has been received
+	Err error
+}
+
+func (e *Error) Error() string {
+	switch {
+	case e.Err != nil:
+		return fmt.Sprintf("request to secret-manager failed: %v", e.Err)
+	case e.Type != "" || e.Detail != "":
+		return fmt.Sprintf("Error %s: %s", e.Type, e.Detail)
+	default:
+		return fmt.Sprintf("unexpected status %d", e.StatusCode)
+	}
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Is matches the error against ErrNotFound, ErrForbidden and ErrUnavailable
+func (e *Error) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound || e.StatusCode == http.StatusGone
+	case ErrForbidden:
+		return e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden
+	case ErrUnavailable:
+		return e.unavailable()
+	default:
+		return false
+	}
+}
+
+// Retriable returns true if the request might succeed later
+func (e *Error) Retriable() bool {
+	return e.unavailable()
+}
+
+func (e *Error) unavailable() bool {
+	switch e.StatusCode {
+	case 0:
+		return true
+	case http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// requestError is returned if no response has been received
+func requestError(err error) error {
+	return &Error{Err: err}
+}
+
+// responseError returns the error of an unexpected response using the problem in its body
+func responseError(statusCode int, body []byte) error {
+	e := &Error{StatusCode: statusCode}
+	var problem gen.ErrorResponse
+	if err := json.Unmarshal(body, &problem); err == nil {
+		e.Type, e.Detail = problem.Type, problem.Detail
+	}
+	return e
+}
+
+// BatchError contains the errors of the secrets that could not be retrieved by their IDs
+type BatchError struct {
+	Errors map[string]error
+}
+
+func (e *BatchError) Error() string {
+	ids := make([]string, 0, len(e.Errors))
+	for id := range e.Errors {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	msgs := make([]string, 0, len(ids))
+	for _, id := range ids {
+		msgs = append(msgs, fmt.Sprintf("%s: %v", id, e.Errors[id]))
+	}
+	return fmt.Sprintf("failed to get %d secrets: %s", len(ids), strings.Join(msgs, "; "))
+}
+
+// Unwrap allows to check the errors of the items, e.g. using errors.Is(err, ErrNotFound)
+func (e *BatchError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Errors))
+	for _, err := range e.Errors {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+// Retriable returns true if any of the secrets might be retrieved later
+func (e *BatchError) Retriable() bool {
+	return errors.Is(e, ErrUnavailable)
+}
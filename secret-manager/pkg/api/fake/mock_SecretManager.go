@@ -5,8 +5,11 @@ package fake
 import (
 	context "context"
 
-	mock "github.com/stretchr/testify/mock"
+	api "github.com/telekom/controlplane-mono/secret-manager/pkg/api"
+
 	gen "github.com/telekom/controlplane-mono/secret-manager/pkg/api/gen"
+
+	mock "github.com/stretchr/testify/mock"
 )
 
 // MockSecretManager is an autogenerated mock type for the SecretManager type
@@ -22,6 +25,53 @@ func (_m *MockSecretManager) EXPECT() *MockSecretManager_Expecter {
 	return &MockSecretManager_Expecter{mock: &_m.Mock}
 }
 
+// Delete provides a mock function with given fields: ctx, secretID
+func (_m *MockSecretManager) Delete(ctx context.Context, secretID string) error {
+	ret := _m.Called(ctx, secretID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, secretID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockSecretManager_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockSecretManager_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - secretID string
+func (_e *MockSecretManager_Expecter) Delete(ctx interface{}, secretID interface{}) *MockSecretManager_Delete_Call {
+	return &MockSecretManager_Delete_Call{Call: _e.mock.On("Delete", ctx, secretID)}
+}
+
+func (_c *MockSecretManager_Delete_Call) Run(run func(ctx context.Context, secretID string)) *MockSecretManager_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockSecretManager_Delete_Call) Return(err error) *MockSecretManager_Delete_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockSecretManager_Delete_Call) RunAndReturn(run func(context.Context, string) error) *MockSecretManager_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // DeleteApplication provides a mock function with given fields: ctx, envID, teamID, appID
 func (_m *MockSecretManager) DeleteApplication(ctx context.Context, envID string, teamID string, appID string) error {
 	ret := _m.Called(ctx, envID, teamID, appID)
@@ -166,6 +216,64 @@ func (_c *MockSecretManager_DeleteTeam_Call) RunAndReturn(run func(context.Conte
 	return _c
 }
 
+// Generate provides a mock function with given fields: ctx, secretID, generator
+func (_m *MockSecretManager) Generate(ctx context.Context, secretID string, generator gen.SecretGenerator) (string, error) {
+	ret := _m.Called(ctx, secretID, generator)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Generate")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, gen.SecretGenerator) (string, error)); ok {
+		return rf(ctx, secretID, generator)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, gen.SecretGenerator) string); ok {
+		r0 = rf(ctx, secretID, generator)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, gen.SecretGenerator) error); ok {
+		r1 = rf(ctx, secretID, generator)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSecretManager_Generate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Generate'
+type MockSecretManager_Generate_Call struct {
+	*mock.Call
+}
+
+// Generate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - secretID string
+//   - generator gen.SecretGenerator
+func (_e *MockSecretManager_Expecter) Generate(ctx interface{}, secretID interface{}, generator interface{}) *MockSecretManager_Generate_Call {
+	return &MockSecretManager_Generate_Call{Call: _e.mock.On("Generate", ctx, secretID, generator)}
+}
+
+func (_c *MockSecretManager_Generate_Call) Run(run func(ctx context.Context, secretID string, generator gen.SecretGenerator)) *MockSecretManager_Generate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(gen.SecretGenerator))
+	})
+	return _c
+}
+
+func (_c *MockSecretManager_Generate_Call) Return(newID string, err error) *MockSecretManager_Generate_Call {
+	_c.Call.Return(newID, err)
+	return _c
+}
+
+func (_c *MockSecretManager_Generate_Call) RunAndReturn(run func(context.Context, string, gen.SecretGenerator) (string, error)) *MockSecretManager_Generate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Get provides a mock function with given fields: ctx, secretID
 func (_m *MockSecretManager) Get(ctx context.Context, secretID string) (string, error) {
 	ret := _m.Called(ctx, secretID)
@@ -223,6 +331,183 @@ func (_c *MockSecretManager_Get_Call) RunAndReturn(run func(context.Context, str
 	return _c
 }
 
+// GetMany provides a mock function with given fields: ctx, secretIDs
+func (_m *MockSecretManager) GetMany(ctx context.Context, secretIDs []string) (map[string]string, error) {
+	ret := _m.Called(ctx, secretIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMany")
+	}
+
+	var r0 map[string]string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) (map[string]string, error)); ok {
+		return rf(ctx, secretIDs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) map[string]string); ok {
+		r0 = rf(ctx, secretIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, secretIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSecretManager_GetMany_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMany'
+type MockSecretManager_GetMany_Call struct {
+	*mock.Call
+}
+
+// GetMany is a helper method to define mock.On call
+//   - ctx context.Context
+//   - secretIDs []string
+func (_e *MockSecretManager_Expecter) GetMany(ctx interface{}, secretIDs interface{}) *MockSecretManager_GetMany_Call {
+	return &MockSecretManager_GetMany_Call{Call: _e.mock.On("GetMany", ctx, secretIDs)}
+}
+
+func (_c *MockSecretManager_GetMany_Call) Run(run func(ctx context.Context, secretIDs []string)) *MockSecretManager_GetMany_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]string))
+	})
+	return _c
+}
+
+func (_c *MockSecretManager_GetMany_Call) Return(values map[string]string, err error) *MockSecretManager_GetMany_Call {
+	_c.Call.Return(values, err)
+	return _c
+}
+
+func (_c *MockSecretManager_GetMany_Call) RunAndReturn(run func(context.Context, []string) (map[string]string, error)) *MockSecretManager_GetMany_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetPublicKey provides a mock function with given fields: ctx, secretID
+func (_m *MockSecretManager) GetPublicKey(ctx context.Context, secretID string) (string, error) {
+	ret := _m.Called(ctx, secretID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetPublicKey")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
+		return rf(ctx, secretID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = rf(ctx, secretID)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, secretID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSecretManager_GetPublicKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetPublicKey'
+type MockSecretManager_GetPublicKey_Call struct {
+	*mock.Call
+}
+
+// GetPublicKey is a helper method to define mock.On call
+//   - ctx context.Context
+//   - secretID string
+func (_e *MockSecretManager_Expecter) GetPublicKey(ctx interface{}, secretID interface{}) *MockSecretManager_GetPublicKey_Call {
+	return &MockSecretManager_GetPublicKey_Call{Call: _e.mock.On("GetPublicKey", ctx, secretID)}
+}
+
+func (_c *MockSecretManager_GetPublicKey_Call) Run(run func(ctx context.Context, secretID string)) *MockSecretManager_GetPublicKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockSecretManager_GetPublicKey_Call) Return(publicKey string, err error) *MockSecretManager_GetPublicKey_Call {
+	_c.Call.Return(publicKey, err)
+	return _c
+}
+
+func (_c *MockSecretManager_GetPublicKey_Call) RunAndReturn(run func(context.Context, string) (string, error)) *MockSecretManager_GetPublicKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// List provides a mock function with given fields: ctx, envID, teamID, appID
+func (_m *MockSecretManager) List(ctx context.Context, envID string, teamID string, appID string) ([]gen.ListSecretItem, error) {
+	ret := _m.Called(ctx, envID, teamID, appID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 []gen.ListSecretItem
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) ([]gen.ListSecretItem, error)); ok {
+		return rf(ctx, envID, teamID, appID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) []gen.ListSecretItem); ok {
+		r0 = rf(ctx, envID, teamID, appID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]gen.ListSecretItem)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, envID, teamID, appID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSecretManager_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type MockSecretManager_List_Call struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+//   - envID string
+//   - teamID string
+//   - appID string
+func (_e *MockSecretManager_Expecter) List(ctx interface{}, envID interface{}, teamID interface{}, appID interface{}) *MockSecretManager_List_Call {
+	return &MockSecretManager_List_Call{Call: _e.mock.On("List", ctx, envID, teamID, appID)}
+}
+
+func (_c *MockSecretManager_List_Call) Run(run func(ctx context.Context, envID string, teamID string, appID string)) *MockSecretManager_List_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *MockSecretManager_List_Call) Return(items []gen.ListSecretItem, err error) *MockSecretManager_List_Call {
+	_c.Call.Return(items, err)
+	return _c
+}
+
+func (_c *MockSecretManager_List_Call) RunAndReturn(run func(context.Context, string, string, string) ([]gen.ListSecretItem, error)) *MockSecretManager_List_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Rotate provides a mock function with given fields: ctx, secretID
 func (_m *MockSecretManager) Rotate(ctx context.Context, secretID string) (string, error) {
 	ret := _m.Called(ctx, secretID)
@@ -338,6 +623,63 @@ func (_c *MockSecretManager_Set_Call) RunAndReturn(run func(context.Context, str
 	return _c
 }
 
+// Undelete provides a mock function with given fields: ctx, secretID
+func (_m *MockSecretManager) Undelete(ctx context.Context, secretID string) (string, error) {
+	ret := _m.Called(ctx, secretID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Undelete")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
+		return rf(ctx, secretID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = rf(ctx, secretID)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, secretID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSecretManager_Undelete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Undelete'
+type MockSecretManager_Undelete_Call struct {
+	*mock.Call
+}
+
+// Undelete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - secretID string
+func (_e *MockSecretManager_Expecter) Undelete(ctx interface{}, secretID interface{}) *MockSecretManager_Undelete_Call {
+	return &MockSecretManager_Undelete_Call{Call: _e.mock.On("Undelete", ctx, secretID)}
+}
+
+func (_c *MockSecretManager_Undelete_Call) Run(run func(ctx context.Context, secretID string)) *MockSecretManager_Undelete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockSecretManager_Undelete_Call) Return(newID string, err error) *MockSecretManager_Undelete_Call {
+	_c.Call.Return(newID, err)
+	return _c
+}
+
+func (_c *MockSecretManager_Undelete_Call) RunAndReturn(run func(context.Context, string) (string, error)) *MockSecretManager_Undelete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UpsertApplication provides a mock function with given fields: ctx, envID, teamID, appID
 func (_m *MockSecretManager) UpsertApplication(ctx context.Context, envID string, teamID string, appID string) ([]gen.ListSecretItem, error) {
 	ret := _m.Called(ctx, envID, teamID, appID)
@@ -518,6 +860,65 @@ func (_c *MockSecretManager_UpsertTeam_Call) RunAndReturn(run func(context.Conte
 	return _c
 }
 
+// Watch provides a mock function with given fields: ctx, prefix
+func (_m *MockSecretManager) Watch(ctx context.Context, prefix string) (<-chan api.ChangeEvent, error) {
+	ret := _m.Called(ctx, prefix)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Watch")
+	}
+
+	var r0 <-chan api.ChangeEvent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (<-chan api.ChangeEvent, error)); ok {
+		return rf(ctx, prefix)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) <-chan api.ChangeEvent); ok {
+		r0 = rf(ctx, prefix)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan api.ChangeEvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, prefix)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockSecretManager_Watch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Watch'
+type MockSecretManager_Watch_Call struct {
+	*mock.Call
+}
+
+// Watch is a helper method to define mock.On call
+//   - ctx context.Context
+//   - prefix string
+func (_e *MockSecretManager_Expecter) Watch(ctx interface{}, prefix interface{}) *MockSecretManager_Watch_Call {
+	return &MockSecretManager_Watch_Call{Call: _e.mock.On("Watch", ctx, prefix)}
+}
+
+func (_c *MockSecretManager_Watch_Call) Run(run func(ctx context.Context, prefix string)) *MockSecretManager_Watch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockSecretManager_Watch_Call) Return(_a0 <-chan api.ChangeEvent, _a1 error) *MockSecretManager_Watch_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockSecretManager_Watch_Call) RunAndReturn(run func(context.Context, string) (<-chan api.ChangeEvent, error)) *MockSecretManager_Watch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockSecretManager creates a new instance of MockSecretManager. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockSecretManager(t interface {
@@ -0,0 +1,78 @@
+package api
+
+import (
+	"sync"
+	"time"
+)
+
+// DefaultFallbackSize is the default number of values that are kept by the fallback cache
+const DefaultFallbackSize = 1000
+
+type fallbackEntry struct {
+	value    string
+	storedAt time.Time
+}
+
+// fallbackCache keeps the recently resolved values of the secrets by their IDs,
+// so they can be returned while the secret-manager is unavailable.
+// As the IDs contain the checksum of the value, a cached value is only returned for the same version of the secret.
+type fallbackCache struct {
+	mu      sync.Mutex
+	entries map[string]fallbackEntry
+	ttl     time.Duration
+	size    int
+	now     func() time.Time
+}
+
+func newFallbackCache(ttl time.Duration, size int) *fallbackCache {
+	if size <= 0 {
+		size = DefaultFallbackSize
+	}
+	return &fallbackCache{
+		entries: make(map[string]fallbackEntry, size),
+		ttl:     ttl,
+		size:    size,
+		now:     time.Now,
+	}
+}
+
+func (c *fallbackCache) Put(id, value string) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.entries[id]; !ok && len(c.entries) >= c.size {
+		c.evictOldest()
+	}
+	c.entries[id] = fallbackEntry{value: value, storedAt: c.now()}
+}
+
+// Get returns the cached value if it is not older than the TTL
+func (c *fallbackCache) Get(id string) (string, bool) {
+	if c == nil {
+		return "", false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[id]
+	if !ok {
+		return "", false
+	}
+	if c.now().Sub(entry.storedAt) > c.ttl {
+		delete(c.entries, id)
+		return "", false
+	}
+	return entry.value, true
+}
+
+func (c *fallbackCache) evictOldest() {
+	var oldest string
+	var oldestAt time.Time
+	for id, entry := range c.entries {
+		if oldest == "" || entry.storedAt.Before(oldestAt) {
+			oldest, oldestAt = id, entry.storedAt
+		}
+	}
+	delete(c.entries, oldest)
+}
@@ -12,10 +12,46 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/oapi-codegen/runtime"
 )
 
+// Defines values for SecretEventType.
+const (
+	Deleted SecretEventType = "deleted"
+	Updated SecretEventType = "updated"
+)
+
+// Defines values for SecretGeneratorCurve.
+const (
+	P256 SecretGeneratorCurve = "P-256"
+	P384 SecretGeneratorCurve = "P-384"
+	P521 SecretGeneratorCurve = "P-521"
+)
+
+// Defines values for SecretGeneratorIssuer.
+const (
+	Ca         SecretGeneratorIssuer = "ca"
+	SelfSigned SecretGeneratorIssuer = "self-signed"
+)
+
+// Defines values for SecretGeneratorKeyType.
+const (
+	SecretGeneratorKeyTypeEc  SecretGeneratorKeyType = "ec"
+	SecretGeneratorKeyTypeRsa SecretGeneratorKeyType = "rsa"
+)
+
+// Defines values for SecretGeneratorType.
+const (
+	SecretGeneratorTypeAlphanumeric SecretGeneratorType = "alphanumeric"
+	SecretGeneratorTypeCertificate  SecretGeneratorType = "certificate"
+	SecretGeneratorTypeEc           SecretGeneratorType = "ec"
+	SecretGeneratorTypePassword     SecretGeneratorType = "password"
+	SecretGeneratorTypeRsa          SecretGeneratorType = "rsa"
+	SecretGeneratorTypeUuid         SecretGeneratorType = "uuid"
+)
+
 // ApiProblem Based on https://www.rfc-editor.org/rfc/rfc9457.html
 type ApiProblem struct {
 	Detail   string  `json:"detail"`
@@ -25,6 +61,21 @@ type ApiProblem struct {
 	Type     string  `json:"type"`
 }
 
+// BatchGetItem defines model for BatchGetItem.
+type BatchGetItem struct {
+	// Error Based on https://www.rfc-editor.org/rfc/rfc9457.html
+	Error *ApiProblem `json:"error,omitempty"`
+
+	// Id A reference to a secret
+	Id SecretRef `json:"id"`
+
+	// Stale True if the value has been served from the cache because the backend is unavailable. It might be outdated.
+	Stale *bool `json:"stale,omitempty"`
+
+	// Value The value of the secret. It is not set if an error occurred.
+	Value *string `json:"value,omitempty"`
+}
+
 // ListSecretItem defines model for ListSecretItem.
 type ListSecretItem struct {
 	// Id A reference to a secret
@@ -39,19 +90,124 @@ type Secret struct {
 	// Id A reference to a secret
 	Id SecretRef `json:"id"`
 
+	// Stale True if the value has been served from the cache because the backend is unavailable. It might be outdated.
+	Stale *bool `json:"stale,omitempty"`
+
 	// Value If empty, a random secret will be generated
 	Value string `json:"value"`
 }
 
+// SecretEvent defines model for SecretEvent.
+type SecretEvent struct {
+	// Id A reference to a secret
+	Id *SecretRef `json:"id,omitempty"`
+
+	// Secret The ID of the secret without checksum. If an environment, team or application has been deleted, it is the prefix of the IDs of all of its secrets.
+	Secret string          `json:"secret"`
+	Time   time.Time       `json:"time"`
+	Type   SecretEventType `json:"type"`
+}
+
+// SecretEventType defines model for SecretEvent.Type.
+type SecretEventType string
+
+// SecretGenerator Generates the value of the secret. The value must be set to `rotate`.
+// The generator is kept, so later rotations produce the same kind of secret.
+type SecretGenerator struct {
+	// Bits The size of an RSA key
+	Bits *int `json:"bits,omitempty"`
+
+	// CommonName The common name of a certificate
+	CommonName *string `json:"commonName,omitempty"`
+
+	// Curve The curve of an EC key
+	Curve *SecretGeneratorCurve `json:"curve,omitempty"`
+
+	// DnsNames The DNS names of a certificate
+	DnsNames *[]string `json:"dnsNames,omitempty"`
+
+	// Issuer Whether a certificate is self-signed or signed by the CA of the server.
+	// The CA only signs the names that are allowed for the secret, up to its maximum validity.
+	Issuer *SecretGeneratorIssuer `json:"issuer,omitempty"`
+
+	// KeyType The type of the key of a certificate
+	KeyType *SecretGeneratorKeyType `json:"keyType,omitempty"`
+
+	// Length The length of an alphanumeric value or password
+	Length *int `json:"length,omitempty"`
+
+	// MinDigits The minimum number of digits of a password
+	MinDigits *int `json:"minDigits,omitempty"`
+
+	// MinLower The minimum number of lowercase letters of a password
+	MinLower *int `json:"minLower,omitempty"`
+
+	// MinSymbols The minimum number of symbols of a password
+	MinSymbols *int `json:"minSymbols,omitempty"`
+
+	// MinUpper The minimum number of uppercase letters of a password
+	MinUpper *int `json:"minUpper,omitempty"`
+
+	// NoSymbols Restricts a password to letters and digits
+	NoSymbols *bool `json:"noSymbols,omitempty"`
+
+	// Symbols The symbols that are used in a password
+	Symbols *string             `json:"symbols,omitempty"`
+	Type    SecretGeneratorType `json:"type"`
+
+	// ValidityDays The validity of a certificate in days
+	ValidityDays *int `json:"validityDays,omitempty"`
+}
+
+// SecretGeneratorCurve The curve of an EC key
+type SecretGeneratorCurve string
+
+// SecretGeneratorIssuer Whether a certificate is self-signed or signed by the CA of the server.
+// The CA only signs the names that are allowed for the secret, up to its maximum validity.
+type SecretGeneratorIssuer string
+
+// SecretGeneratorKeyType The type of the key of a certificate
+type SecretGeneratorKeyType string
+
+// SecretGeneratorType defines model for SecretGenerator.Type.
+type SecretGeneratorType string
+
 // SecretRef A reference to a secret
 type SecretRef = string
 
-// QuerySecretId defines model for QuerySecretId.
-type QuerySecretId = []SecretRef
+// SecretVersion defines model for SecretVersion.
+type SecretVersion struct {
+	// Id A reference to a secret
+	Id SecretRef `json:"id"`
+
+	// Version The version of the secret, e.g. v3
+	Version string `json:"version"`
+}
+
+// Cursor defines model for Cursor.
+type Cursor = string
+
+// Limit defines model for Limit.
+type Limit = int
+
+// QueryAppId defines model for QueryAppId.
+type QueryAppId = string
+
+// QueryEnvId defines model for QueryEnvId.
+type QueryEnvId = string
+
+// QueryTeamId defines model for QueryTeamId.
+type QueryTeamId = string
 
 // SecretId A reference to a secret
 type SecretId = SecretRef
 
+// BatchGetResponse defines model for BatchGetResponse.
+type BatchGetResponse struct {
+	// Items The results in the order of the requested IDs
+	Items []BatchGetItem `json:"items"`
+}
+
 // ErrorResponse Based on https://www.rfc-editor.org/rfc/rfc9457.html
 type ErrorResponse = ApiProblem
 
@@ -61,23 +217,63 @@ type OnboardingResponse struct {
 	Items []ListSecretItem `json:"items"`
 }
 
-// SecretListReponse defines model for SecretListReponse.
-type SecretListReponse struct {
-	// Items A list of secrets
-	Items []Secret `json:"items"`
+// PublicKeyResponse defines model for PublicKeyResponse.
+type PublicKeyResponse struct {
+	// Id A reference to a secret
+	Id SecretRef `json:"id"`
+
+	// PublicKey The PEM-encoded public key or certificates
+	PublicKey string `json:"publicKey"`
+}
+
+// SecretEventListResponse defines model for SecretEventListResponse.
+type SecretEventListResponse struct {
+	// Cursor The cursor of the next request
+	Cursor string `json:"cursor"`
+
+	// Items The events since the cursor, the oldest first
+	Items []SecretEvent `json:"items"`
+
+	// Reset Events might have been missed since the cursor
+	Reset bool `json:"reset"`
+}
+
+// SecretRefListResponse defines model for SecretRefListResponse.
+type SecretRefListResponse struct {
+	// Items A list of secret references without values
+	Items []ListSecretItem `json:"items"`
+
+	// Next The cursor of the next page. It is not set on the last page.
+	Next *string `json:"next,omitempty"`
 }
 
 // SecretResponse defines model for SecretResponse.
 type SecretResponse = Secret
 
+// SecretVersionListResponse defines model for SecretVersionListResponse.
+type SecretVersionListResponse struct {
+	// Items The versions of the secret, the latest first
+	Items []SecretVersion `json:"items"`
+}
+
 // SecretWriteResponse defines model for SecretWriteResponse.
 type SecretWriteResponse struct {
 	// Id A reference to a secret
 	Id SecretRef `json:"id"`
 }
 
+// BatchGetRequest defines model for BatchGetRequest.
+type BatchGetRequest struct {
+	// Ids The references or IDs of the secrets
+	Ids []SecretRef `json:"ids"`
+}
+
 // SecretWriteRequest defines model for SecretWriteRequest.
 type SecretWriteRequest struct {
+	// Generator Generates the value of the secret. The value must be set to `rotate`.
+	// The generator is kept, so later rotations produce the same kind of secret.
+	Generator *SecretGenerator `json:"generator,omitempty"`
+
 	// Value This is the value of the secret.
 	// If set to `{{rotate}}`, the secret will be randomly generated
 	// and rotated.
@@ -85,14 +281,42 @@ type SecretWriteRequest struct {
 	Value string `json:"value"`
 }
 
+// WatchSecretEventsParams defines parameters for WatchSecretEvents.
+type WatchSecretEventsParams struct {
+	// Prefix The prefix of the secret IDs, e.g. `my-env:my-team:` for all secrets of a team and its applications. If empty, all secrets are watched.
+	Prefix *string `form:"prefix,omitempty" json:"prefix,omitempty"`
+
+	// Cursor The cursor returned by the previous request
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Timeout The maximum number of seconds to wait for events
+	Timeout *int `form:"timeout,omitempty" json:"timeout,omitempty"`
+}
+
 // ListSecretsParams defines parameters for ListSecrets.
 type ListSecretsParams struct {
-	// SecretId The id or reference to a secret
-	SecretId *QuerySecretId `form:"secretId,omitempty" json:"secretId,omitempty"`
+	// Env The environment of the secrets
+	Env QueryEnvId `form:"env" json:"env"`
+
+	// Team The team of the secrets. If empty, the environment secrets are listed
+	Team *QueryTeamId `form:"team,omitempty" json:"team,omitempty"`
+
+	// App The application of the secrets. If empty, the team secrets are listed. Requires the team to be set.
+	App *QueryAppId `form:"app,omitempty" json:"app,omitempty"`
+
+	// Limit The maximum number of items that are returned
+	Limit *Limit `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor The cursor returned by the previous page
+	Cursor *Cursor `form:"cursor,omitempty" json:"cursor,omitempty"`
 }
 
 // PutSecretJSONBody defines parameters for PutSecret.
 type PutSecretJSONBody struct {
+	// Generator Generates the value of the secret. The value must be set to `rotate`.
+	// The generator is kept, so later rotations produce the same kind of secret.
+	Generator *SecretGenerator `json:"generator,omitempty"`
+
 	// Value This is the value of the secret.
 	// If set to `{{rotate}}`, the secret will be randomly generated
 	// and rotated.
@@ -100,9 +324,18 @@ type PutSecretJSONBody struct {
 	Value string `json:"value"`
 }
 
+// BatchGetSecretsJSONBody defines parameters for BatchGetSecrets.
+type BatchGetSecretsJSONBody struct {
+	// Ids The references or IDs of the secrets
+	Ids []SecretRef `json:"ids"`
+}
+
 // PutSecretJSONRequestBody defines body for PutSecret for application/json ContentType.
 type PutSecretJSONRequestBody PutSecretJSONBody
 
+// BatchGetSecretsJSONRequestBody defines body for BatchGetSecrets for application/json ContentType.
+type BatchGetSecretsJSONRequestBody BatchGetSecretsJSONBody
+
 // RequestEditorFn  is the function signature for the RequestEditor callback function
 type RequestEditorFn func(ctx context.Context, req *http.Request) error
 
@@ -176,6 +409,9 @@ func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
 
 // The interface specification for the client above.
 type ClientInterface interface {
+	// WatchSecretEvents request
+	WatchSecretEvents(ctx context.Context, params *WatchSecretEventsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// DeleteEnvironment request
 	DeleteEnvironment(ctx context.Context, envId string, reqEditors ...RequestEditorFn) (*http.Response, error)
 
@@ -197,6 +433,9 @@ type ClientInterface interface {
 	// ListSecrets request
 	ListSecrets(ctx context.Context, params *ListSecretsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
 
+	// DeleteSecret request
+	DeleteSecret(ctx context.Context, secretId SecretId, reqEditors ...RequestEditorFn) (*http.Response, error)
+
 	// GetSecret request
 	GetSecret(ctx context.Context, secretId SecretId, reqEditors ...RequestEditorFn) (*http.Response, error)
 
@@ -204,6 +443,32 @@ type ClientInterface interface {
 	PutSecretWithBody(ctx context.Context, secretId SecretId, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
 
 	PutSecret(ctx context.Context, secretId SecretId, body PutSecretJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetPublicKey request
+	GetPublicKey(ctx context.Context, secretId SecretId, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// UndeleteSecret request
+	UndeleteSecret(ctx context.Context, secretId SecretId, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ListSecretVersions request
+	ListSecretVersions(ctx context.Context, secretId SecretId, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// BatchGetSecretsWithBody request with any body
+	BatchGetSecretsWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	BatchGetSecrets(ctx context.Context, body BatchGetSecretsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+}
+
+func (c *Client) WatchSecretEvents(ctx context.Context, params *WatchSecretEventsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewWatchSecretEventsRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
 }
 
 func (c *Client) DeleteEnvironment(ctx context.Context, envId string, reqEditors ...RequestEditorFn) (*http.Response, error) {
@@ -290,6 +555,18 @@ func (c *Client) ListSecrets(ctx context.Context, params *ListSecretsParams, req
 	return c.Client.Do(req)
 }
 
+func (c *Client) DeleteSecret(ctx context.Context, secretId SecretId, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteSecretRequest(c.Server, secretId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
 func (c *Client) GetSecret(ctx context.Context, secretId SecretId, reqEditors ...RequestEditorFn) (*http.Response, error) {
 	req, err := NewGetSecretRequest(c.Server, secretId)
 	if err != nil {
@@ -326,6 +603,147 @@ func (c *Client) PutSecret(ctx context.Context, secretId SecretId, body PutSecre
 	return c.Client.Do(req)
 }
 
+func (c *Client) GetPublicKey(ctx context.Context, secretId SecretId, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetPublicKeyRequest(c.Server, secretId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) UndeleteSecret(ctx context.Context, secretId SecretId, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewUndeleteSecretRequest(c.Server, secretId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ListSecretVersions(ctx context.Context, secretId SecretId, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewListSecretVersionsRequest(c.Server, secretId)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) BatchGetSecretsWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewBatchGetSecretsRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) BatchGetSecrets(ctx context.Context, body BatchGetSecretsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewBatchGetSecretsRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// NewWatchSecretEventsRequest generates requests for WatchSecretEvents
+func NewWatchSecretEventsRequest(server string, params *WatchSecretEventsParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/events")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Prefix != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "prefix", runtime.ParamLocationQuery, *params.Prefix); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Cursor != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "cursor", runtime.ParamLocationQuery, *params.Cursor); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Timeout != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "timeout", runtime.ParamLocationQuery, *params.Timeout); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
 // NewDeleteEnvironmentRequest generates requests for DeleteEnvironment
 func NewDeleteEnvironmentRequest(server string, envId string) (*http.Request, error) {
 	var err error
@@ -594,9 +1012,21 @@ func NewListSecretsRequest(server string, params *ListSecretsParams) (*http.Requ
 	if params != nil {
 		queryValues := queryURL.Query()
 
-		if params.SecretId != nil {
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "env", runtime.ParamLocationQuery, params.Env); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+		if params.Team != nil {
 
-			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "secretId", runtime.ParamLocationQuery, *params.SecretId); err != nil {
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "team", runtime.ParamLocationQuery, *params.Team); err != nil {
 				return nil, err
 			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
 				return nil, err
@@ -610,18 +1040,100 @@ func NewListSecretsRequest(server string, params *ListSecretsParams) (*http.Requ
 
 		}
 
-		queryURL.RawQuery = queryValues.Encode()
-	}
+		if params.App != nil {
 
-	req, err := http.NewRequest("GET", queryURL.String(), nil)
-	if err != nil {
-		return nil, err
-	}
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "app", runtime.ParamLocationQuery, *params.App); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
 
-	return req, nil
-}
+		}
 
-// NewGetSecretRequest generates requests for GetSecret
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Cursor != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "cursor", runtime.ParamLocationQuery, *params.Cursor); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewDeleteSecretRequest generates requests for DeleteSecret
+func NewDeleteSecretRequest(server string, secretId SecretId) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "secretId", runtime.ParamLocationPath, secretId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/secrets/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetSecretRequest generates requests for GetSecret
 func NewGetSecretRequest(server string, secretId SecretId) (*http.Request, error) {
 	var err error
 
@@ -702,6 +1214,148 @@ func NewPutSecretRequestWithBody(server string, secretId SecretId, contentType s
 	return req, nil
 }
 
+// NewGetPublicKeyRequest generates requests for GetPublicKey
+func NewGetPublicKeyRequest(server string, secretId SecretId) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "secretId", runtime.ParamLocationPath, secretId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/secrets/%s/public", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewUndeleteSecretRequest generates requests for UndeleteSecret
+func NewUndeleteSecretRequest(server string, secretId SecretId) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "secretId", runtime.ParamLocationPath, secretId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/secrets/%s/undelete", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewListSecretVersionsRequest generates requests for ListSecretVersions
+func NewListSecretVersionsRequest(server string, secretId SecretId) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "secretId", runtime.ParamLocationPath, secretId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/secrets/%s/versions", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewBatchGetSecretsRequest calls the generic BatchGetSecrets builder with application/json body
+func NewBatchGetSecretsRequest(server string, body BatchGetSecretsJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewBatchGetSecretsRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewBatchGetSecretsRequestWithBody generates requests for BatchGetSecrets with any type of body
+func NewBatchGetSecretsRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/v1/secrets:batchGet")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
 func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
 	for _, r := range c.RequestEditors {
 		if err := r(ctx, req); err != nil {
@@ -745,6 +1399,9 @@ func WithBaseURL(baseURL string) ClientOption {
 
 // ClientWithResponsesInterface is the interface specification for the client with responses above.
 type ClientWithResponsesInterface interface {
+	// WatchSecretEventsWithResponse request
+	WatchSecretEventsWithResponse(ctx context.Context, params *WatchSecretEventsParams, reqEditors ...RequestEditorFn) (*WatchSecretEventsResponse, error)
+
 	// DeleteEnvironmentWithResponse request
 	DeleteEnvironmentWithResponse(ctx context.Context, envId string, reqEditors ...RequestEditorFn) (*DeleteEnvironmentResponse, error)
 
@@ -766,6 +1423,9 @@ type ClientWithResponsesInterface interface {
 	// ListSecretsWithResponse request
 	ListSecretsWithResponse(ctx context.Context, params *ListSecretsParams, reqEditors ...RequestEditorFn) (*ListSecretsResponse, error)
 
+	// DeleteSecretWithResponse request
+	DeleteSecretWithResponse(ctx context.Context, secretId SecretId, reqEditors ...RequestEditorFn) (*DeleteSecretResponse, error)
+
 	// GetSecretWithResponse request
 	GetSecretWithResponse(ctx context.Context, secretId SecretId, reqEditors ...RequestEditorFn) (*GetSecretResponse, error)
 
@@ -773,6 +1433,44 @@ type ClientWithResponsesInterface interface {
 	PutSecretWithBodyWithResponse(ctx context.Context, secretId SecretId, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PutSecretResponse, error)
 
 	PutSecretWithResponse(ctx context.Context, secretId SecretId, body PutSecretJSONRequestBody, reqEditors ...RequestEditorFn) (*PutSecretResponse, error)
+
+	// GetPublicKeyWithResponse request
+	GetPublicKeyWithResponse(ctx context.Context, secretId SecretId, reqEditors ...RequestEditorFn) (*GetPublicKeyResponse, error)
+
+	// UndeleteSecretWithResponse request
+	UndeleteSecretWithResponse(ctx context.Context, secretId SecretId, reqEditors ...RequestEditorFn) (*UndeleteSecretResponse, error)
+
+	// ListSecretVersionsWithResponse request
+	ListSecretVersionsWithResponse(ctx context.Context, secretId SecretId, reqEditors ...RequestEditorFn) (*ListSecretVersionsResponse, error)
+
+	// BatchGetSecretsWithBodyWithResponse request with any body
+	BatchGetSecretsWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*BatchGetSecretsResponse, error)
+
+	BatchGetSecretsWithResponse(ctx context.Context, body BatchGetSecretsJSONRequestBody, reqEditors ...RequestEditorFn) (*BatchGetSecretsResponse, error)
+}
+
+type WatchSecretEventsResponse struct {
+	Body                      []byte
+	HTTPResponse              *http.Response
+	JSON200                   *SecretEventListResponse
+	ApplicationproblemJSON400 *ErrorResponse
+	ApplicationproblemJSON500 *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r WatchSecretEventsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r WatchSecretEventsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
 }
 
 type DeleteEnvironmentResponse struct {
@@ -919,7 +1617,7 @@ func (r UpsertAppResponse) StatusCode() int {
 type ListSecretsResponse struct {
 	Body                      []byte
 	HTTPResponse              *http.Response
-	JSON200                   *SecretListReponse
+	JSON200                   *SecretRefListResponse
 	ApplicationproblemJSON400 *ErrorResponse
 	ApplicationproblemJSON500 *ErrorResponse
 }
@@ -940,6 +1638,30 @@ func (r ListSecretsResponse) StatusCode() int {
 	return 0
 }
 
+type DeleteSecretResponse struct {
+	Body                      []byte
+	HTTPResponse              *http.Response
+	ApplicationproblemJSON400 *ErrorResponse
+	ApplicationproblemJSON404 *ErrorResponse
+	ApplicationproblemJSON500 *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteSecretResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteSecretResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
 type GetSecretResponse struct {
 	Body                      []byte
 	HTTPResponse              *http.Response
@@ -988,16 +1710,124 @@ func (r PutSecretResponse) StatusCode() int {
 	return 0
 }
 
-// DeleteEnvironmentWithResponse request returning *DeleteEnvironmentResponse
-func (c *ClientWithResponses) DeleteEnvironmentWithResponse(ctx context.Context, envId string, reqEditors ...RequestEditorFn) (*DeleteEnvironmentResponse, error) {
-	rsp, err := c.DeleteEnvironment(ctx, envId, reqEditors...)
-	if err != nil {
-		return nil, err
-	}
-	return ParseDeleteEnvironmentResponse(rsp)
+type GetPublicKeyResponse struct {
+	Body                      []byte
+	HTTPResponse              *http.Response
+	JSON200                   *PublicKeyResponse
+	ApplicationproblemJSON400 *ErrorResponse
+	ApplicationproblemJSON404 *ErrorResponse
+	ApplicationproblemJSON500 *ErrorResponse
 }
 
-// UpsertEnvironmentWithResponse request returning *UpsertEnvironmentResponse
+// Status returns HTTPResponse.Status
+func (r GetPublicKeyResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetPublicKeyResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type UndeleteSecretResponse struct {
+	Body                      []byte
+	HTTPResponse              *http.Response
+	JSON200                   *SecretWriteResponse
+	ApplicationproblemJSON400 *ErrorResponse
+	ApplicationproblemJSON404 *ErrorResponse
+	ApplicationproblemJSON500 *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r UndeleteSecretResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r UndeleteSecretResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ListSecretVersionsResponse struct {
+	Body                      []byte
+	HTTPResponse              *http.Response
+	JSON200                   *SecretVersionListResponse
+	ApplicationproblemJSON400 *ErrorResponse
+	ApplicationproblemJSON404 *ErrorResponse
+	ApplicationproblemJSON500 *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r ListSecretVersionsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ListSecretVersionsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type BatchGetSecretsResponse struct {
+	Body                      []byte
+	HTTPResponse              *http.Response
+	JSON200                   *BatchGetResponse
+	ApplicationproblemJSON400 *ErrorResponse
+	ApplicationproblemJSON500 *ErrorResponse
+}
+
+// Status returns HTTPResponse.Status
+func (r BatchGetSecretsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r BatchGetSecretsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// WatchSecretEventsWithResponse request returning *WatchSecretEventsResponse
+func (c *ClientWithResponses) WatchSecretEventsWithResponse(ctx context.Context, params *WatchSecretEventsParams, reqEditors ...RequestEditorFn) (*WatchSecretEventsResponse, error) {
+	rsp, err := c.WatchSecretEvents(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseWatchSecretEventsResponse(rsp)
+}
+
+// DeleteEnvironmentWithResponse request returning *DeleteEnvironmentResponse
+func (c *ClientWithResponses) DeleteEnvironmentWithResponse(ctx context.Context, envId string, reqEditors ...RequestEditorFn) (*DeleteEnvironmentResponse, error) {
+	rsp, err := c.DeleteEnvironment(ctx, envId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteEnvironmentResponse(rsp)
+}
+
+// UpsertEnvironmentWithResponse request returning *UpsertEnvironmentResponse
 func (c *ClientWithResponses) UpsertEnvironmentWithResponse(ctx context.Context, envId string, reqEditors ...RequestEditorFn) (*UpsertEnvironmentResponse, error) {
 	rsp, err := c.UpsertEnvironment(ctx, envId, reqEditors...)
 	if err != nil {
@@ -1051,6 +1881,15 @@ func (c *ClientWithResponses) ListSecretsWithResponse(ctx context.Context, param
 	return ParseListSecretsResponse(rsp)
 }
 
+// DeleteSecretWithResponse request returning *DeleteSecretResponse
+func (c *ClientWithResponses) DeleteSecretWithResponse(ctx context.Context, secretId SecretId, reqEditors ...RequestEditorFn) (*DeleteSecretResponse, error) {
+	rsp, err := c.DeleteSecret(ctx, secretId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteSecretResponse(rsp)
+}
+
 // GetSecretWithResponse request returning *GetSecretResponse
 func (c *ClientWithResponses) GetSecretWithResponse(ctx context.Context, secretId SecretId, reqEditors ...RequestEditorFn) (*GetSecretResponse, error) {
 	rsp, err := c.GetSecret(ctx, secretId, reqEditors...)
@@ -1077,6 +1916,90 @@ func (c *ClientWithResponses) PutSecretWithResponse(ctx context.Context, secretI
 	return ParsePutSecretResponse(rsp)
 }
 
+// GetPublicKeyWithResponse request returning *GetPublicKeyResponse
+func (c *ClientWithResponses) GetPublicKeyWithResponse(ctx context.Context, secretId SecretId, reqEditors ...RequestEditorFn) (*GetPublicKeyResponse, error) {
+	rsp, err := c.GetPublicKey(ctx, secretId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetPublicKeyResponse(rsp)
+}
+
+// UndeleteSecretWithResponse request returning *UndeleteSecretResponse
+func (c *ClientWithResponses) UndeleteSecretWithResponse(ctx context.Context, secretId SecretId, reqEditors ...RequestEditorFn) (*UndeleteSecretResponse, error) {
+	rsp, err := c.UndeleteSecret(ctx, secretId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUndeleteSecretResponse(rsp)
+}
+
+// ListSecretVersionsWithResponse request returning *ListSecretVersionsResponse
+func (c *ClientWithResponses) ListSecretVersionsWithResponse(ctx context.Context, secretId SecretId, reqEditors ...RequestEditorFn) (*ListSecretVersionsResponse, error) {
+	rsp, err := c.ListSecretVersions(ctx, secretId, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseListSecretVersionsResponse(rsp)
+}
+
+// BatchGetSecretsWithBodyWithResponse request with arbitrary body returning *BatchGetSecretsResponse
+func (c *ClientWithResponses) BatchGetSecretsWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*BatchGetSecretsResponse, error) {
+	rsp, err := c.BatchGetSecretsWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseBatchGetSecretsResponse(rsp)
+}
+
+func (c *ClientWithResponses) BatchGetSecretsWithResponse(ctx context.Context, body BatchGetSecretsJSONRequestBody, reqEditors ...RequestEditorFn) (*BatchGetSecretsResponse, error) {
+	rsp, err := c.BatchGetSecrets(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseBatchGetSecretsResponse(rsp)
+}
+
+// ParseWatchSecretEventsResponse parses an HTTP response from a WatchSecretEventsWithResponse call
+func ParseWatchSecretEventsResponse(rsp *http.Response) (*WatchSecretEventsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &WatchSecretEventsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest SecretEventListResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
 // ParseDeleteEnvironmentResponse parses an HTTP response from a DeleteEnvironmentWithResponse call
 func ParseDeleteEnvironmentResponse(rsp *http.Response) (*DeleteEnvironmentResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
@@ -1311,7 +2234,7 @@ func ParseListSecretsResponse(rsp *http.Response) (*ListSecretsResponse, error)
 
 	switch {
 	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
-		var dest SecretListReponse
+		var dest SecretRefListResponse
 		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
 			return nil, err
 		}
@@ -1336,6 +2259,46 @@ func ParseListSecretsResponse(rsp *http.Response) (*ListSecretsResponse, error)
 	return response, nil
 }
 
+// ParseDeleteSecretResponse parses an HTTP response from a DeleteSecretWithResponse call
+func ParseDeleteSecretResponse(rsp *http.Response) (*DeleteSecretResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteSecretResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
 // ParseGetSecretResponse parses an HTTP response from a GetSecretWithResponse call
 func ParseGetSecretResponse(rsp *http.Response) (*GetSecretResponse, error) {
 	bodyBytes, err := io.ReadAll(rsp.Body)
@@ -1415,3 +2378,184 @@ func ParsePutSecretResponse(rsp *http.Response) (*PutSecretResponse, error) {
 
 	return response, nil
 }
+
+// ParseGetPublicKeyResponse parses an HTTP response from a GetPublicKeyWithResponse call
+func ParseGetPublicKeyResponse(rsp *http.Response) (*GetPublicKeyResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetPublicKeyResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest PublicKeyResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseUndeleteSecretResponse parses an HTTP response from a UndeleteSecretWithResponse call
+func ParseUndeleteSecretResponse(rsp *http.Response) (*UndeleteSecretResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &UndeleteSecretResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest SecretWriteResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseListSecretVersionsResponse parses an HTTP response from a ListSecretVersionsWithResponse call
+func ParseListSecretVersionsResponse(rsp *http.Response) (*ListSecretVersionsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ListSecretVersionsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest SecretVersionListResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 404:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON404 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON500 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseBatchGetSecretsResponse parses an HTTP response from a BatchGetSecretsWithResponse call
+func ParseBatchGetSecretsResponse(rsp *http.Response) (*BatchGetSecretsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &BatchGetSecretsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest BatchGetResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 400:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON400 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.ApplicationproblemJSON500 = &dest
+
+	}
+
+	return response, nil
+}
@@ -9,6 +9,7 @@ import (
 
 var once sync.Once
 var api SecretManager
+var apiErr error
 
 // Get retrieves the secret value from the secret manager.
 // The difference to the Get function in the api package is that this function
@@ -22,7 +23,11 @@ var Get = func(ctx context.Context, secretRef string) (value string, err error)
 		return secretRef, nil
 	}
 
-	value, err = API().Get(ctx, secretId)
+	sm, err := API()
+	if err != nil {
+		return "", err
+	}
+	value, err = sm.Get(ctx, secretId)
 	if err != nil {
 		return "", err
 	}
@@ -30,6 +35,43 @@ var Get = func(ctx context.Context, secretRef string) (value string, err error)
 	return value, nil
 }
 
+// GetMany retrieves the values of multiple secrets from the secret manager using a single request.
+// Like Get, it will return all secretRefs that are not placeholders as is.
+// The values are returned in the same order as the secretRefs.
+var GetMany = func(ctx context.Context, secretRefs ...string) (values []string, err error) {
+	log := logr.FromContextOrDiscard(ctx)
+	values = make([]string, len(secretRefs))
+	secretIds := make([]string, 0, len(secretRefs))
+	for i, secretRef := range secretRefs {
+		secretId, ok := FromRef(secretRef)
+		if !ok {
+			values[i] = secretRef
+			continue
+		}
+		secretIds = append(secretIds, secretId)
+	}
+	if len(secretIds) == 0 {
+		log.V(1).Info("Secrets are no placeholders, skipping ...")
+		return values, nil
+	}
+
+	sm, err := API()
+	if err != nil {
+		return nil, err
+	}
+	resolved, err := sm.GetMany(ctx, secretIds)
+	if err != nil {
+		return nil, err
+	}
+	for i, secretRef := range secretRefs {
+		if secretId, ok := FromRef(secretRef); ok {
+			values[i] = resolved[secretId]
+		}
+	}
+	log.V(1).Info("Secrets resolved successfully", "count", len(secretIds))
+	return values, nil
+}
+
 // Set sets the secret value in the secret manager.
 // The difference to the Set function in the api package is that this function
 // will check if this is a secret placeholder and if so, it will call the secret manager API.
@@ -42,7 +84,11 @@ var Set = func(ctx context.Context, secretRef string, value string) (newRef stri
 		return secretId, nil
 	}
 
-	newID, err := API().Set(ctx, secretId, value)
+	sm, err := API()
+	if err != nil {
+		return secretRef, err
+	}
+	newID, err := sm.Set(ctx, secretId, value)
 	if err != nil {
 		return secretRef, err
 	}
@@ -51,11 +97,10 @@ var Set = func(ctx context.Context, secretRef string, value string) (newRef stri
 	return ToRef(newID), nil
 }
 
-var API = func() SecretManager {
-	if api == nil {
-		once.Do(func() {
-			api = New()
-		})
-	}
-	return api
+// API returns the shared client, which is created on the first call
+var API = func() (SecretManager, error) {
+	once.Do(func() {
+		api, apiErr = New()
+	})
+	return api, apiErr
 }
@@ -0,0 +1,71 @@
+package api
+
+import (
+	"context"
+	"math/rand/v2"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+)
+
+const (
+	DefaultMaxAttempts    = 3
+	DefaultInitialBackoff = 200 * time.Millisecond
+	DefaultMaxBackoff     = 2 * time.Second
+)
+
+// RetryOptions configure how idempotent requests are retried while the secret-manager is unavailable.
+// Requests that change secrets to new values, e.g. rotations, are never retried.
+type RetryOptions struct {
+	// MaxAttempts is the number of attempts including the first one. 1 disables retries.
+	MaxAttempts int
+	// InitialBackoff is the delay before the first retry. It is doubled for each further retry.
+	InitialBackoff time.Duration
+	// MaxBackoff is the maximum delay between two attempts
+	MaxBackoff time.Duration
+}
+
+func defaultRetryOptions() RetryOptions {
+	return RetryOptions{
+		MaxAttempts:    DefaultMaxAttempts,
+		InitialBackoff: DefaultInitialBackoff,
+		MaxBackoff:     DefaultMaxBackoff,
+	}
+}
+
+// do calls the request until it succeeds, fails with an error that is not retriable,
+// or the attempts are exhausted. Each attempt is limited by the timeout of the options.
+func (s *secretManagerAPI) do(ctx context.Context, idempotent bool, request func(ctx context.Context) error) error {
+	attempts := 1
+	if idempotent {
+		attempts = max(attempts, s.options.Retry.MaxAttempts)
+	}
+	backoff := s.options.Retry.InitialBackoff
+
+	for attempt := 1; ; attempt++ {
+		err := s.attempt(ctx, request)
+		if err == nil || attempt >= attempts || ctx.Err() != nil || !errors.Is(err, ErrUnavailable) {
+			return err
+		}
+
+		// Jitter spreads the retries of many callers after an outage
+		delay := backoff/2 + rand.N(backoff/2+1) //nolint:gosec
+		logr.FromContextOrDiscard(ctx).V(1).Info("Retrying request to secret-manager", "attempt", attempt, "retryAfter", delay, "error", err.Error())
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(delay):
+		}
+		backoff = min(2*backoff, s.options.Retry.MaxBackoff)
+	}
+}
+
+func (s *secretManagerAPI) attempt(ctx context.Context, request func(ctx context.Context) error) error {
+	if s.options.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.options.Timeout)
+		defer cancel()
+	}
+	return request(ctx)
+}
@@ -5,7 +5,6 @@ import (
 	"context"
 	"crypto/tls"
 	"crypto/x509"
-	"fmt"
 	"net/http"
 	"os"
 	"time"
@@ -30,18 +29,28 @@ func IsRunningInCluster() bool {
 	return ok
 }
 
-func NewHttpClientOrDie(skipTlsVerify bool, caFilepath string) client.HttpRequestDoer {
-	var caPool *x509.CertPool
-
-	if skipTlsVerify {
-		fmt.Println("⚠️\tWarning: Using InsecureSkipVerify. This is not secure.")
+// DefaultTimeout returns the timeout of the requests that is configured using CLIENT_TIMEOUT or DefaultClientTimeout
+func DefaultTimeout() (time.Duration, error) {
+	if ClientTimeout == "" {
+		return DefaultClientTimeout, nil
+	}
+	timeout, err := time.ParseDuration(ClientTimeout)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to parse CLIENT_TIMEOUT")
 	}
+	return timeout, nil
+}
+
+// NewHttpClient creates a client that trusts the CA of the file, unless TLS verification is skipped.
+// The CA file is reloaded when it changes.
+func NewHttpClient(skipTlsVerify bool, caFilepath string, timeout time.Duration) (client.HttpRequestDoer, error) {
+	var caPool *x509.CertPool
 
 	if !skipTlsVerify && caFilepath != "" {
 		certRefresher := NewCertRefresher(caFilepath)
 		err := certRefresher.Start(context.Background())
 		if err != nil {
-			panic(err)
+			return nil, err
 		}
 		caPool = certRefresher.Pool
 	}
@@ -53,21 +62,12 @@ func NewHttpClientOrDie(skipTlsVerify bool, caFilepath string) client.HttpReques
 			RootCAs:            caPool,
 		},
 	}
-	timeout := DefaultClientTimeout
-	if ClientTimeout != "" {
-		var err error
-		timeout, err = time.ParseDuration(ClientTimeout)
-		if err != nil {
-			panic(errors.Wrap(err, "failed to parse CLIENT_TIMEOUT"))
-		}
-	}
-
 	httpClient := &http.Client{
 		Transport: transport,
 		Timeout:   timeout,
 	}
 
-	return client.WithMetrics(httpClient, ClientName, ReplacePattern)
+	return client.WithMetrics(httpClient, ClientName, ReplacePattern), nil
 }
 
 func GetCert(filepath string) (*x509.CertPool, error) {
@@ -0,0 +1,162 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/api/gen"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/api/util"
+)
+
+const (
+	// WatchTimeout is the time that the server waits for events before it returns an empty result
+	WatchTimeout = 30 * time.Second
+
+	separator         = ":"
+	minWatchBackoff   = time.Second
+	maxWatchBackoff   = 30 * time.Second
+	watchBufferLength = 100
+)
+
+type EventType string
+
+const (
+	// EventTypeUpdated is sent when a secret has been set or rotated
+	EventTypeUpdated EventType = "updated"
+	// EventTypeDeleted is sent when a secret or a whole environment, team or application has been deleted
+	EventTypeDeleted EventType = "deleted"
+	// EventTypeReset is sent when events might have been missed, e.g. because the server has been restarted.
+	// The watcher must assume that all of its secrets have changed.
+	EventTypeReset EventType = "reset"
+)
+
+// ChangeEvent describes the change of a secret. It never contains the value of the secret.
+type ChangeEvent struct {
+	Type EventType
+	// Secret is the ID of the secret without checksum.
+	// If an environment, team or application has been deleted, it is the prefix of the IDs of all its secrets.
+	Secret string
+	// Id is the ID of the new version of the secret. It is only set for updates.
+	Id   string
+	Time time.Time
+}
+
+// Affects checks if the secret reference is affected by the event.
+// The reference can be a placeholder or a plain secret ID.
+func (e ChangeEvent) Affects(secretRef string) bool {
+	if e.Type == EventTypeReset {
+		return true
+	}
+	secretId, _ := FromRef(secretRef)
+	if i := strings.LastIndex(secretId, separator); i >= 0 {
+		// Ignore the checksum, as every version of the secret is affected
+		secretId = secretId[:i+1]
+	}
+	if strings.Count(e.Secret, separator) == 4 {
+		return secretId == e.Secret
+	}
+	return strings.HasPrefix(secretId, e.Secret)
+}
+
+type WatchApi interface {
+	// Watch sends the changes of all secrets whose IDs start with the prefix, e.g. `my-env:my-team:`.
+	// The channel is closed when the context is done. Failed requests are retried until then.
+	Watch(ctx context.Context, prefix string) (<-chan ChangeEvent, error)
+}
+
+func (s *secretManagerAPI) Watch(ctx context.Context, prefix string) (<-chan ChangeEvent, error) {
+	s.watchOnce.Do(func() {
+		// The client must wait longer than the server
+		httpClient, err := util.NewHttpClient(s.skipTlsVerify, s.caFilepath, WatchTimeout+15*time.Second)
+		if err != nil {
+			s.watchErr = errors.Wrap(err, "failed to create HTTP client")
+			return
+		}
+		s.watchClient, s.watchErr = gen.NewClientWithResponses(s.options.URL, gen.WithHTTPClient(httpClient), gen.WithRequestEditorFn(s.options.accessTokenReqEditor))
+	})
+	if s.watchErr != nil {
+		return nil, errors.Wrap(s.watchErr, "failed to create watch client")
+	}
+
+	// The initial request returns the current cursor, so no events are missed after Watch has returned
+	res, err := s.watch(ctx, prefix, "")
+	if err != nil {
+		return nil, err
+	}
+
+	ch := make(chan ChangeEvent, watchBufferLength)
+	go s.watchLoop(ctx, prefix, res.Cursor, ch)
+	return ch, nil
+}
+
+func (s *secretManagerAPI) watchLoop(ctx context.Context, prefix, cursor string, ch chan<- ChangeEvent) {
+	defer close(ch)
+	log := logr.FromContextOrDiscard(ctx).WithValues("prefix", prefix)
+
+	backoff := minWatchBackoff
+	for ctx.Err() == nil {
+		res, err := s.watch(ctx, prefix, cursor)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			log.Error(err, "Failed to watch secrets", "retryAfter", backoff)
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(backoff):
+			}
+			backoff = min(2*backoff, maxWatchBackoff)
+			continue
+		}
+		backoff = minWatchBackoff
+		cursor = res.Cursor
+
+		if res.Reset {
+			if !send(ctx, ch, ChangeEvent{Type: EventTypeReset, Time: time.Now()}) {
+				return
+			}
+		}
+		for _, item := range res.Items {
+			e := ChangeEvent{Type: EventType(item.Type), Secret: item.Secret, Time: item.Time}
+			if item.Id != nil {
+				e.Id = ToRef(*item.Id)
+			}
+			if !send(ctx, ch, e) {
+				return
+			}
+		}
+	}
+}
+
+func send(ctx context.Context, ch chan<- ChangeEvent, e ChangeEvent) bool {
+	select {
+	case <-ctx.Done():
+		return false
+	case ch <- e:
+		return true
+	}
+}
+
+func (s *secretManagerAPI) watch(ctx context.Context, prefix, cursor string) (*gen.SecretEventListResponse, error) {
+	timeout := int(WatchTimeout.Seconds())
+	params := &gen.WatchSecretEventsParams{Timeout: &timeout}
+	if prefix != "" {
+		params.Prefix = &prefix
+	}
+	if cursor != "" {
+		params.Cursor = &cursor
+	}
+	res, err := s.watchClient.WatchSecretEventsWithResponse(ctx, params)
+	if err != nil {
+		return nil, requestError(err)
+	}
+	if res.StatusCode() != http.StatusOK {
+		return nil, responseError(res.StatusCode(), res.Body)
+	}
+	return res.JSON200, nil
+}
@@ -0,0 +1,147 @@
+package audit
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/api"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/controller"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/generator"
+)
+
+var _ controller.Controller = &auditingController{}
+
+// auditingController records an audit entry for each call of the controller
+type auditingController struct {
+	controller.Controller
+	sink Sink
+	now  func() time.Time
+}
+
+// NewController wraps the controller to record each call to the sink.
+// A failing sink never fails the call itself.
+func NewController(c controller.Controller, sink Sink) controller.Controller {
+	return &auditingController{Controller: c, sink: sink, now: time.Now}
+}
+
+// record starts a new entry. The returned function completes the entry with the result and writes it.
+func (c *auditingController) record(ctx context.Context, entry *Entry) func(err error) {
+	start := c.now()
+	entry.Time = start
+	entry.Caller = CallerFromContext(ctx)
+	markRecorded(ctx)
+	return func(err error) {
+		entry.setResult(err, c.now().Sub(start))
+		if err := c.sink.Write(ctx, *entry); err != nil {
+			logr.FromContextOrDiscard(ctx).Error(err, "Failed to write audit entry", "operation", entry.Operation)
+		}
+	}
+}
+
+func (c *auditingController) GetSecret(ctx context.Context, rawId string) (res controller.SecretResponse, err error) {
+	done := c.record(ctx, &Entry{Operation: OperationGet, SecretId: rawId})
+	res, err = c.Controller.GetSecret(ctx, rawId)
+	done(err)
+	return res, err
+}
+
+func (c *auditingController) GetPublicKey(ctx context.Context, rawId string) (res controller.PublicKeyResponse, err error) {
+	done := c.record(ctx, &Entry{Operation: OperationGetPublicKey, SecretId: rawId})
+	res, err = c.Controller.GetPublicKey(ctx, rawId)
+	done(err)
+	return res, err
+}
+
+func (c *auditingController) SetSecret(ctx context.Context, rawId, value string) (res controller.SecretResponse, err error) {
+	entry := Entry{Operation: OperationSet, SecretId: rawId}
+	if value == api.KeywordRotate {
+		entry.Operation = OperationRotate
+	}
+	done := c.record(ctx, &entry)
+	res, err = c.Controller.SetSecret(ctx, rawId, value)
+	entry.NewSecretId = res.Id
+	done(err)
+	return res, err
+}
+
+func (c *auditingController) GenerateSecret(ctx context.Context, rawId string, cfg generator.Config) (res controller.SecretResponse, err error) {
+	entry := Entry{Operation: OperationGenerate, SecretId: rawId, Generator: cfg.Type}
+	done := c.record(ctx, &entry)
+	res, err = c.Controller.GenerateSecret(ctx, rawId, cfg)
+	entry.NewSecretId = res.Id
+	done(err)
+	return res, err
+}
+
+func (c *auditingController) DeleteSecret(ctx context.Context, rawId string) (err error) {
+	done := c.record(ctx, &Entry{Operation: OperationDelete, SecretId: rawId})
+	err = c.Controller.DeleteSecret(ctx, rawId)
+	done(err)
+	return err
+}
+
+func (c *auditingController) UndeleteSecret(ctx context.Context, rawId string) (res controller.SecretResponse, err error) {
+	entry := Entry{Operation: OperationUndelete, SecretId: rawId}
+	done := c.record(ctx, &entry)
+	res, err = c.Controller.UndeleteSecret(ctx, rawId)
+	entry.NewSecretId = res.Id
+	done(err)
+	return res, err
+}
+
+func (c *auditingController) ListSecrets(ctx context.Context, req controller.ListSecretsRequest) (res controller.ListSecretsResponse, err error) {
+	done := c.record(ctx, &Entry{Operation: OperationList, Env: req.Env, Team: req.Team, App: req.App})
+	res, err = c.Controller.ListSecrets(ctx, req)
+	done(err)
+	return res, err
+}
+
+func (c *auditingController) ListSecretVersions(ctx context.Context, rawId string) (res []controller.SecretVersionResponse, err error) {
+	done := c.record(ctx, &Entry{Operation: OperationListVersions, SecretId: rawId})
+	res, err = c.Controller.ListSecretVersions(ctx, rawId)
+	done(err)
+	return res, err
+}
+
+func (c *auditingController) OnboardEnvironment(ctx context.Context, envId string) (res controller.OnboardResponse, err error) {
+	done := c.record(ctx, &Entry{Operation: OperationOnboard, Env: envId})
+	res, err = c.Controller.OnboardEnvironment(ctx, envId)
+	done(err)
+	return res, err
+}
+
+func (c *auditingController) OnboardTeam(ctx context.Context, envId, teamId string) (res controller.OnboardResponse, err error) {
+	done := c.record(ctx, &Entry{Operation: OperationOnboard, Env: envId, Team: teamId})
+	res, err = c.Controller.OnboardTeam(ctx, envId, teamId)
+	done(err)
+	return res, err
+}
+
+func (c *auditingController) OnboardApplication(ctx context.Context, envId, teamId, appId string) (res controller.OnboardResponse, err error) {
+	done := c.record(ctx, &Entry{Operation: OperationOnboard, Env: envId, Team: teamId, App: appId})
+	res, err = c.Controller.OnboardApplication(ctx, envId, teamId, appId)
+	done(err)
+	return res, err
+}
+
+func (c *auditingController) DeleteEnvironment(ctx context.Context, envId string) (err error) {
+	done := c.record(ctx, &Entry{Operation: OperationOffboard, Env: envId})
+	err = c.Controller.DeleteEnvironment(ctx, envId)
+	done(err)
+	return err
+}
+
+func (c *auditingController) DeleteTeam(ctx context.Context, envId, teamId string) (err error) {
+	done := c.record(ctx, &Entry{Operation: OperationOffboard, Env: envId, Team: teamId})
+	err = c.Controller.DeleteTeam(ctx, envId, teamId)
+	done(err)
+	return err
+}
+
+func (c *auditingController) DeleteApplication(ctx context.Context, envId, teamId, appId string) (err error) {
+	done := c.record(ctx, &Entry{Operation: OperationOffboard, Env: envId, Team: teamId, App: appId})
+	err = c.Controller.DeleteApplication(ctx, envId, teamId, appId)
+	done(err)
+	return err
+}
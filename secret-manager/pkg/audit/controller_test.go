@@ -0,0 +1,179 @@
+package audit_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/telekom/controlplane-mono/common-server/pkg/problems"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/api"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/audit"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/backend"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/controller"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/middleware"
+)
+
+const confidential = "super-secret-value"
+
+// fakeController echoes the values and leaks them in its errors
+type fakeController struct {
+	controller.Controller
+	err error
+}
+
+func (c *fakeController) GetSecret(_ context.Context, rawId string) (controller.SecretResponse, error) {
+	if c.err != nil {
+		return controller.SecretResponse{}, c.err
+	}
+	return controller.SecretResponse{Id: rawId, Value: confidential}, nil
+}
+
+func (c *fakeController) SetSecret(_ context.Context, rawId, value string) (controller.SecretResponse, error) {
+	if c.err != nil {
+		return controller.SecretResponse{}, errors.Wrapf(c.err, "failed to set %q", value)
+	}
+	return controller.SecretResponse{Id: "env:team::teamToken:v2"}, nil
+}
+
+func (c *fakeController) OnboardTeam(_ context.Context, envId, teamId string) (controller.OnboardResponse, error) {
+	return controller.OnboardResponse{SecretRefs: map[string]string{"teamToken": envId + ":" + teamId + "::teamToken:v1"}}, nil
+}
+
+func (c *fakeController) DeleteApplication(_ context.Context, _, _, _ string) error {
+	return c.err
+}
+
+var _ = Describe("Controller", func() {
+
+	var ctx context.Context
+	var fake *fakeController
+	var out *bytes.Buffer
+	var ctrl controller.Controller
+
+	entries := func() []audit.Entry {
+		var res []audit.Entry
+		decoder := json.NewDecoder(bytes.NewReader(out.Bytes()))
+		for decoder.More() {
+			var entry audit.Entry
+			Expect(decoder.Decode(&entry)).To(Succeed())
+			res = append(res, entry)
+		}
+		return res
+	}
+
+	BeforeEach(func() {
+		claims := &middleware.ServiceAccountTokenClaims{}
+		claims.Kubernetes.Namespace = "rover-operator-system"
+		claims.Kubernetes.ServiceAccount.Name = "rover-controller-manager"
+		claims.Kubernetes.Pod.Name = "rover-controller-manager-abc"
+		ctx = middleware.NewContextWithClaims(context.Background(), claims)
+
+		fake = &fakeController{}
+		out = &bytes.Buffer{}
+		ctrl = audit.NewController(fake, audit.NewJSONSink(out))
+	})
+
+	It("should record the caller, the secret and the result", func() {
+		_, err := ctrl.GetSecret(ctx, "env:team::teamToken:v1")
+		Expect(err).ToNot(HaveOccurred())
+
+		res := entries()
+		Expect(res).To(HaveLen(1))
+		Expect(res[0].Operation).To(Equal(audit.OperationGet))
+		Expect(res[0].SecretId).To(Equal("env:team::teamToken:v1"))
+		Expect(res[0].Result).To(Equal(audit.ResultSuccess))
+		Expect(res[0].Caller).To(Equal(&audit.Caller{
+			ServiceAccount: "rover-controller-manager",
+			Namespace:      "rover-operator-system",
+			Pod:            "rover-controller-manager-abc",
+			Method:         "kubernetes",
+		}))
+		Expect(res[0].Time).ToNot(BeZero())
+		Expect(res[0].LatencyMs).To(BeNumerically(">=", 0))
+	})
+
+	It("should record unauthenticated calls without caller", func() {
+		_, err := ctrl.GetSecret(context.Background(), "env:team::teamToken:v1")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(entries()[0].Caller).To(BeNil())
+	})
+
+	It("should record writes, rotations and onboarding", func() {
+		_, err := ctrl.SetSecret(ctx, "env:team::teamToken:v1", "new-value")
+		Expect(err).ToNot(HaveOccurred())
+		_, err = ctrl.SetSecret(ctx, "env:team::teamToken:v2", api.KeywordRotate)
+		Expect(err).ToNot(HaveOccurred())
+		_, err = ctrl.OnboardTeam(ctx, "env", "team")
+		Expect(err).ToNot(HaveOccurred())
+
+		res := entries()
+		Expect(res).To(HaveLen(3))
+		Expect(res[0].Operation).To(Equal(audit.OperationSet))
+		Expect(res[0].NewSecretId).To(Equal("env:team::teamToken:v2"))
+		Expect(res[1].Operation).To(Equal(audit.OperationRotate))
+		Expect(res[2].Operation).To(Equal(audit.OperationOnboard))
+		Expect(res[2].Env).To(Equal("env"))
+		Expect(res[2].Team).To(Equal("team"))
+	})
+
+	It("should record failures with their type", func() {
+		fake.err = backend.ErrNotFound()
+		Expect(ctrl.DeleteApplication(ctx, "env", "team", "app")).ToNot(Succeed())
+
+		fake.err = errors.New("connection refused")
+		_, err := ctrl.GetSecret(ctx, "env:team::teamToken:v1")
+		Expect(err).To(HaveOccurred())
+
+		fake.err = problems.Forbidden("Access denied", "not allowed")
+		_, err = ctrl.GetSecret(ctx, "env:team::teamToken:v1")
+		Expect(err).To(HaveOccurred())
+
+		res := entries()
+		Expect(res[2].Error).To(Equal("Forbidden"))
+		Expect(res[0].Operation).To(Equal(audit.OperationOffboard))
+		Expect(res[0].Result).To(Equal(audit.ResultFailure))
+		Expect(res[0].Error).To(Equal(backend.TypeErrNotFound))
+		Expect(res[1].Error).To(Equal("InternalError"))
+	})
+
+	It("should not fail the call if the sink fails", func() {
+		ctrl = audit.NewController(fake, audit.SinkFunc(func(_ context.Context, _ audit.Entry) error {
+			return errors.New("sink is broken")
+		}))
+		_, err := ctrl.GetSecret(ctx, "env:team::teamToken:v1")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	Context("Redaction", func() {
+
+		It("should never record the value of a read secret", func() {
+			res, err := ctrl.GetSecret(ctx, "env:team::teamToken:v1")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(res.Value).To(Equal(confidential))
+
+			Expect(out.String()).ToNot(BeEmpty())
+			Expect(out.String()).ToNot(ContainSubstring(confidential))
+		})
+
+		It("should never record the value of a written secret", func() {
+			_, err := ctrl.SetSecret(ctx, "env:team::teamToken:v1", confidential)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(out.String()).ToNot(BeEmpty())
+			Expect(out.String()).ToNot(ContainSubstring(confidential))
+		})
+
+		It("should never record error messages that contain the value", func() {
+			fake.err = backend.NewBackendError(nil, errors.New("invalid value "+confidential), backend.TypeErrInvalidSecretId)
+			_, err := ctrl.SetSecret(ctx, "env:team::teamToken:v1", confidential)
+			Expect(err).To(MatchError(ContainSubstring(confidential)))
+
+			Expect(entries()[0].Error).To(Equal(backend.TypeErrInvalidSecretId))
+			Expect(out.String()).ToNot(ContainSubstring(confidential))
+		})
+	})
+})
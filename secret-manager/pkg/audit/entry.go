@@ -0,0 +1,116 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/telekom/controlplane-mono/common-server/pkg/problems"
+
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/backend"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/middleware"
+)
+
+type Operation string
+
+const (
+	OperationGet          Operation = "get"
+	OperationGetPublicKey Operation = "getPublicKey"
+	OperationSet          Operation = "set"
+	OperationRotate       Operation = "rotate"
+	OperationGenerate     Operation = "generate"
+	OperationDelete       Operation = "delete"
+	OperationUndelete     Operation = "undelete"
+	OperationList         Operation = "list"
+	OperationListVersions Operation = "listVersions"
+	OperationOnboard      Operation = "onboard"
+	OperationOffboard     Operation = "offboard"
+	// The following operations are only recorded if the request is rejected by the middleware,
+	// as they are not handled by the controller as a whole
+	OperationBatchGet Operation = "batchGet"
+	OperationWatch    Operation = "watch"
+	OperationUnknown  Operation = "unknown"
+)
+
+type Result string
+
+const (
+	ResultSuccess Result = "success"
+	ResultFailure Result = "failure"
+)
+
+// Caller identifies the service account that called the API
+type Caller struct {
+	ServiceAccount string `json:"serviceAccount"`
+	Namespace      string `json:"namespace"`
+	Pod            string `json:"pod,omitempty"`
+	// Method is the way the caller has been authenticated, e.g. kubernetes, oidc or mtls
+	Method string `json:"method,omitempty"`
+}
+
+// Entry is a single audit record. It never contains the value of a secret.
+type Entry struct {
+	Time      time.Time `json:"time"`
+	Operation Operation `json:"operation"`
+	// Caller is not set if the caller is not authenticated, e.g. if security is disabled
+	Caller *Caller `json:"caller,omitempty"`
+
+	// SecretId is the ID of the secret that has been requested
+	SecretId string `json:"secretId,omitempty"`
+	// NewSecretId is the ID of the secret after it has been set
+	NewSecretId string `json:"newSecretId,omitempty"`
+	// Generator is the type of the generator of a generated secret
+	Generator string `json:"generator,omitempty"`
+	// Env, Team and App identify the scope of onboarding and list operations
+	Env  string `json:"env,omitempty"`
+	Team string `json:"team,omitempty"`
+	App  string `json:"app,omitempty"`
+
+	Result Result `json:"result"`
+	// Error is the type of the error, e.g. NotFound.
+	// The message of the error is never recorded as it might contain confidential data.
+	Error     string  `json:"error,omitempty"`
+	LatencyMs float64 `json:"latencyMs"`
+}
+
+// CallerFromContext returns the caller identity of the authenticated request
+func CallerFromContext(ctx context.Context) *Caller {
+	identity, ok := middleware.IdentityFromContext(ctx)
+	if !ok {
+		return nil
+	}
+	return &Caller{
+		ServiceAccount: identity.ServiceAccountName,
+		Namespace:      identity.Namespace,
+		Pod:            identity.Pod,
+		Method:         identity.Method,
+	}
+}
+
+func (e *Entry) setResult(err error, latency time.Duration) {
+	e.LatencyMs = float64(latency.Microseconds()) / 1000
+	if err == nil {
+		e.Result = ResultSuccess
+		return
+	}
+	e.Result = ResultFailure
+	e.Error = errorType(err)
+}
+
+func errorType(err error) string {
+	var backendErr *backend.BackendError
+	if errors.As(err, &backendErr) {
+		return backendErr.Type
+	}
+	var problem problems.Problem
+	if errors.As(err, &problem) {
+		// e.g. Forbidden if the access has been denied by the policy
+		return strings.ReplaceAll(http.StatusText(problem.Code()), " ", "")
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return "Canceled"
+	}
+	return "InternalError"
+}
@@ -0,0 +1,121 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/gofiber/fiber/v2"
+	"github.com/telekom/controlplane-mono/common-server/pkg/problems"
+)
+
+type recordedKey struct{}
+
+// markRecorded notes that the request has been audited by the controller
+func markRecorded(ctx context.Context) {
+	if recorded, ok := ctx.Value(recordedKey{}).(*atomic.Bool); ok {
+		recorded.Store(true)
+	}
+}
+
+// NewMiddleware records an audit entry for each request that is rejected before it reaches the controller,
+// e.g. if the caller can not be authenticated or is denied by the access config.
+// It must be used before the authentication and authorization middleware.
+func NewMiddleware(sink Sink) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		start := time.Now()
+		recorded := &atomic.Bool{}
+		c.SetUserContext(context.WithValue(c.UserContext(), recordedKey{}, recorded))
+
+		err := c.Next()
+		if err == nil || recorded.Load() || !isDenied(err) {
+			return err
+		}
+
+		ctx := c.UserContext()
+		entry := entryOf(c)
+		entry.Time = start
+		entry.Caller = CallerFromContext(ctx)
+		entry.setResult(err, time.Since(start))
+		if err := sink.Write(ctx, entry); err != nil {
+			logr.FromContextOrDiscard(ctx).Error(err, "Failed to write audit entry", "operation", entry.Operation)
+		}
+		return err
+	}
+}
+
+func isDenied(err error) bool {
+	var problem problems.Problem
+	if !errors.As(err, &problem) {
+		return false
+	}
+	return problem.Code() == http.StatusUnauthorized || problem.Code() == http.StatusForbidden
+}
+
+// entryOf maps the request to the operation and the secret or scope it targets
+func entryOf(c *fiber.Ctx) Entry {
+	method := c.Method()
+	path := strings.TrimPrefix(c.Path(), "/api/v1/")
+
+	switch {
+	case path == "events":
+		return Entry{Operation: OperationWatch}
+
+	case path == "secrets":
+		return Entry{Operation: OperationList, Env: c.Query("env"), Team: c.Query("team"), App: c.Query("app")}
+
+	case path == "secrets:batchGet":
+		return Entry{Operation: OperationBatchGet}
+
+	case strings.HasPrefix(path, "secrets/"):
+		rawId := strings.TrimPrefix(path, "secrets/")
+		operation := OperationUnknown
+		switch {
+		case strings.HasSuffix(rawId, "/public"):
+			rawId, operation = strings.TrimSuffix(rawId, "/public"), OperationGetPublicKey
+		case strings.HasSuffix(rawId, "/undelete"):
+			rawId, operation = strings.TrimSuffix(rawId, "/undelete"), OperationUndelete
+		case strings.HasSuffix(rawId, "/versions"):
+			rawId, operation = strings.TrimSuffix(rawId, "/versions"), OperationListVersions
+		case method == fiber.MethodGet:
+			operation = OperationGet
+		case method == fiber.MethodPut:
+			operation = OperationSet
+		case method == fiber.MethodDelete:
+			operation = OperationDelete
+		}
+		if unescaped, err := url.PathUnescape(rawId); err == nil {
+			rawId = unescaped
+		}
+		return Entry{Operation: operation, SecretId: rawId}
+
+	case strings.HasPrefix(path, "onboarding/"):
+		entry := Entry{Operation: OperationUnknown}
+		switch method {
+		case fiber.MethodPut:
+			entry.Operation = OperationOnboard
+		case fiber.MethodDelete:
+			entry.Operation = OperationOffboard
+		}
+		// environments/:envId/teams/:teamId/apps/:appId
+		segments := strings.Split(strings.TrimPrefix(path, "onboarding/"), "/")
+		for i := 0; i+1 < len(segments); i += 2 {
+			switch segments[i] {
+			case "environments":
+				entry.Env = segments[i+1]
+			case "teams":
+				entry.Team = segments[i+1]
+			case "apps":
+				entry.App = segments[i+1]
+			}
+		}
+		return entry
+	}
+
+	return Entry{Operation: OperationUnknown}
+}
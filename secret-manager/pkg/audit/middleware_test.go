@@ -0,0 +1,134 @@
+package audit_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/pkg/errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/telekom/controlplane-mono/common-server/pkg/problems"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/audit"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/middleware"
+)
+
+// staticAuthenticator authenticates every request as the same caller
+type staticAuthenticator struct {
+	identity middleware.Identity
+	err      error
+}
+
+func (a *staticAuthenticator) Authenticate(_ *fiber.Ctx) (middleware.Identity, bool, error) {
+	return a.identity, true, a.err
+}
+
+var _ = Describe("Middleware", func() {
+
+	var fake *fakeController
+	var authenticator *staticAuthenticator
+	var out *bytes.Buffer
+	var app *fiber.App
+
+	entries := func() []audit.Entry {
+		var res []audit.Entry
+		decoder := json.NewDecoder(bytes.NewReader(out.Bytes()))
+		for decoder.More() {
+			var entry audit.Entry
+			Expect(decoder.Decode(&entry)).To(Succeed())
+			res = append(res, entry)
+		}
+		return res
+	}
+
+	doRequest := func(method, path string) int {
+		res, err := app.Test(httptest.NewRequest(method, path, nil))
+		Expect(err).ToNot(HaveOccurred())
+		return res.StatusCode
+	}
+
+	BeforeEach(func() {
+		fake = &fakeController{}
+		authenticator = &staticAuthenticator{identity: middleware.Identity{
+			ServiceAccountName: "rover-controller-manager",
+			Namespace:          "rover-operator-system",
+			Method:             middleware.MethodMTLS,
+		}}
+		out = &bytes.Buffer{}
+		sink := audit.NewJSONSink(out)
+		ctrl := audit.NewController(fake, sink)
+
+		app = fiber.New(fiber.Config{
+			ErrorHandler: func(c *fiber.Ctx, err error) error {
+				var p problems.Problem
+				if errors.As(err, &p) {
+					return c.SendStatus(p.Code())
+				}
+				return c.SendStatus(http.StatusInternalServerError)
+			},
+		})
+		app.Use(audit.NewMiddleware(sink))
+		app.Use(middleware.NewKubernetesAuthz(
+			middleware.WithAuthenticators(authenticator),
+			middleware.WithAccessConfig(middleware.ServiceAccessConfig{
+				ServiceAccountName: "rover-controller-manager",
+				Namespace:          "rover-operator-system",
+				AllowedAccess:      []middleware.AccessType{middleware.AccessTypeSecretsRead},
+			}),
+		))
+		app.Get("/api/v1/secrets/:secretId", func(c *fiber.Ctx) error {
+			_, err := ctrl.GetSecret(c.UserContext(), c.Params("secretId"))
+			return err
+		})
+	})
+
+	It("should record requests denied by the access config", func() {
+		Expect(doRequest(http.MethodPut, "/api/v1/secrets/env:team::teamToken:v1")).To(Equal(http.StatusForbidden))
+		Expect(doRequest(http.MethodDelete, "/api/v1/onboarding/environments/env/teams/team")).To(Equal(http.StatusForbidden))
+
+		res := entries()
+		Expect(res).To(HaveLen(2))
+		Expect(res[0].Operation).To(Equal(audit.OperationSet))
+		Expect(res[0].SecretId).To(Equal("env:team::teamToken:v1"))
+		Expect(res[0].Result).To(Equal(audit.ResultFailure))
+		Expect(res[0].Error).To(Equal("Forbidden"))
+		Expect(res[0].Caller).To(Equal(&audit.Caller{
+			ServiceAccount: "rover-controller-manager",
+			Namespace:      "rover-operator-system",
+			Method:         middleware.MethodMTLS,
+		}))
+		Expect(res[1].Operation).To(Equal(audit.OperationOffboard))
+		Expect(res[1].Env).To(Equal("env"))
+		Expect(res[1].Team).To(Equal("team"))
+	})
+
+	It("should record requests that fail to authenticate", func() {
+		authenticator.err = errors.New("certificate expired")
+		Expect(doRequest(http.MethodGet, "/api/v1/secrets/env:team::teamToken:v1/versions")).To(Equal(http.StatusUnauthorized))
+
+		res := entries()
+		Expect(res).To(HaveLen(1))
+		Expect(res[0].Operation).To(Equal(audit.OperationListVersions))
+		Expect(res[0].SecretId).To(Equal("env:team::teamToken:v1"))
+		Expect(res[0].Error).To(Equal("Unauthorized"))
+		Expect(res[0].Caller).To(BeNil())
+	})
+
+	It("should not record requests twice that have been audited by the controller", func() {
+		fake.err = problems.Forbidden("Access denied", "not allowed")
+		Expect(doRequest(http.MethodGet, "/api/v1/secrets/env:team::teamToken:v1")).To(Equal(http.StatusForbidden))
+
+		res := entries()
+		Expect(res).To(HaveLen(1))
+		Expect(res[0].Operation).To(Equal(audit.OperationGet))
+		Expect(res[0].Error).To(Equal("Forbidden"))
+	})
+
+	It("should not record allowed requests", func() {
+		Expect(doRequest(http.MethodGet, "/api/v1/secrets/env:team::teamToken:v1")).To(Equal(http.StatusOK))
+		Expect(entries()).To(HaveLen(1))
+	})
+})
@@ -0,0 +1,171 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	pkgerrors "github.com/pkg/errors"
+)
+
+// DefaultBufferSize is the default number of entries that are buffered by an AsyncSink
+const DefaultBufferSize = 1000
+
+// Sink stores or forwards the audit entries
+type Sink interface {
+	Write(ctx context.Context, entry Entry) error
+}
+
+// SinkFunc is an adapter to use a function as Sink
+type SinkFunc func(ctx context.Context, entry Entry) error
+
+func (f SinkFunc) Write(ctx context.Context, entry Entry) error {
+	return f(ctx, entry)
+}
+
+var _ Sink = &JSONSink{}
+
+// JSONSink writes each entry as a single line of JSON
+type JSONSink struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func NewJSONSink(w io.Writer) *JSONSink {
+	return &JSONSink{w: w}
+}
+
+// NewStdoutSink writes the entries to stdout
+func NewStdoutSink() *JSONSink {
+	return NewJSONSink(os.Stdout)
+}
+
+func (s *JSONSink) Write(_ context.Context, entry Entry) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return pkgerrors.Wrap(err, "failed to encode audit entry")
+	}
+	line = append(line, '\n')
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, err = s.w.Write(line)
+	return err
+}
+
+var _ Sink = &FileSink{}
+
+// FileSink appends the entries as JSON lines to a file
+type FileSink struct {
+	*JSONSink
+	file *os.File
+}
+
+func NewFileSink(path string) (*FileSink, error) {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600) //nolint:gosec
+	if err != nil {
+		return nil, pkgerrors.Wrap(err, "failed to open audit file")
+	}
+	return &FileSink{JSONSink: NewJSONSink(file), file: file}, nil
+}
+
+func (s *FileSink) Close() error {
+	return s.file.Close()
+}
+
+var _ Sink = &WebhookSink{}
+
+// WebhookSink posts each entry as JSON to the URL
+type WebhookSink struct {
+	URL    string
+	Client *http.Client
+}
+
+func NewWebhookSink(url string) *WebhookSink {
+	return &WebhookSink{
+		URL:    url,
+		Client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (s *WebhookSink) Write(ctx context.Context, entry Entry) error {
+	body, err := json.Marshal(entry)
+	if err != nil {
+		return pkgerrors.Wrap(err, "failed to encode audit entry")
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.URL, bytes.NewReader(body))
+	if err != nil {
+		return pkgerrors.Wrap(err, "failed to create request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := s.Client.Do(req)
+	if err != nil {
+		return pkgerrors.Wrap(err, "failed to send audit entry")
+	}
+	defer res.Body.Close() //nolint:errcheck
+	if res.StatusCode >= 300 {
+		return pkgerrors.Errorf("failed to send audit entry: unexpected status %d", res.StatusCode)
+	}
+	return nil
+}
+
+// MultiSink writes the entries to all sinks
+type MultiSink []Sink
+
+func (m MultiSink) Write(ctx context.Context, entry Entry) error {
+	var errs []error
+	for _, s := range m {
+		if err := s.Write(ctx, entry); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+var _ Sink = &AsyncSink{}
+
+// AsyncSink writes the entries in the background, so slow sinks like webhooks do not delay the requests.
+// If the buffer is full, the entry is dropped and an error is returned.
+type AsyncSink struct {
+	sink    Sink
+	entries chan Entry
+}
+
+func NewAsyncSink(sink Sink, bufferSize int) *AsyncSink {
+	if bufferSize <= 0 {
+		bufferSize = DefaultBufferSize
+	}
+	return &AsyncSink{sink: sink, entries: make(chan Entry, bufferSize)}
+}
+
+func (s *AsyncSink) Write(_ context.Context, entry Entry) error {
+	select {
+	case s.entries <- entry:
+		return nil
+	default:
+		return pkgerrors.New("audit buffer is full, entry has been dropped")
+	}
+}
+
+// Start writes the buffered entries until the context is done
+func (s *AsyncSink) Start(ctx context.Context) {
+	log := logr.FromContextOrDiscard(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case entry := <-s.entries:
+			if err := s.sink.Write(ctx, entry); err != nil {
+				log.Error(err, "Failed to write audit entry", "operation", entry.Operation, "secretId", entry.SecretId)
+			}
+		}
+	}
+}
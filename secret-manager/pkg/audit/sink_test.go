@@ -0,0 +1,107 @@
+package audit_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/audit"
+)
+
+var _ = Describe("Sinks", func() {
+
+	var ctx context.Context
+	var entry audit.Entry
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		entry = audit.Entry{Operation: audit.OperationGet, SecretId: "env:team::teamToken:v1", Result: audit.ResultSuccess}
+	})
+
+	Context("File Sink", func() {
+		It("should append the entries as JSON lines", func() {
+			path := filepath.Join(GinkgoT().TempDir(), "audit.log")
+			sink, err := audit.NewFileSink(path)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(sink.Write(ctx, entry)).To(Succeed())
+			Expect(sink.Close()).To(Succeed())
+
+			// Reopening the file must not truncate it
+			sink, err = audit.NewFileSink(path)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(sink.Write(ctx, entry)).To(Succeed())
+			Expect(sink.Close()).To(Succeed())
+
+			content, err := os.ReadFile(path)
+			Expect(err).ToNot(HaveOccurred())
+			lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+			Expect(lines).To(HaveLen(2))
+
+			var res audit.Entry
+			Expect(json.Unmarshal([]byte(lines[1]), &res)).To(Succeed())
+			Expect(res.SecretId).To(Equal(entry.SecretId))
+		})
+	})
+
+	Context("Webhook Sink", func() {
+		It("should post the entries", func() {
+			received := make(chan audit.Entry, 1)
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var res audit.Entry
+				Expect(json.NewDecoder(r.Body).Decode(&res)).To(Succeed())
+				received <- res
+				w.WriteHeader(http.StatusAccepted)
+			}))
+			defer server.Close()
+
+			Expect(audit.NewWebhookSink(server.URL).Write(ctx, entry)).To(Succeed())
+			Expect(<-received).To(Equal(entry))
+		})
+
+		It("should fail on unexpected status codes", func() {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusInternalServerError)
+			}))
+			defer server.Close()
+
+			Expect(audit.NewWebhookSink(server.URL).Write(ctx, entry)).To(MatchError(ContainSubstring("unexpected status 500")))
+		})
+	})
+
+	Context("Async Sink", func() {
+		It("should write the entries in the background", func() {
+			received := make(chan audit.Entry, 1)
+			sink := audit.NewAsyncSink(audit.SinkFunc(func(_ context.Context, e audit.Entry) error {
+				received <- e
+				return nil
+			}), 1)
+
+			Expect(sink.Write(ctx, entry)).To(Succeed())
+			// The buffer is full as the sink has not been started yet
+			Expect(sink.Write(ctx, entry)).ToNot(Succeed())
+
+			ctx, cancel := context.WithCancel(ctx)
+			defer cancel()
+			go sink.Start(ctx)
+			Eventually(received).Should(Receive(Equal(entry)))
+		})
+	})
+
+	Context("Multi Sink", func() {
+		It("should write to all sinks", func() {
+			count := 0
+			counter := audit.SinkFunc(func(_ context.Context, _ audit.Entry) error {
+				count++
+				return nil
+			})
+			Expect(audit.MultiSink{counter, counter}.Write(ctx, entry)).To(Succeed())
+			Expect(count).To(Equal(2))
+		})
+	})
+})
@@ -0,0 +1,139 @@
+package backend
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/generator"
+)
+
+// SecretBlueprint describes a secret that is created when onboarding an environment, team or application.
+type SecretBlueprint struct {
+	Name string `yaml:"name" json:"name"`
+	// Value is the static initial value, e.g. "{}". It is only used if no generator is set.
+	Value string `yaml:"value,omitempty" json:"value,omitempty"`
+	// Generator generates a random initial value
+	Generator *generator.Config `yaml:"generator,omitempty" json:"generator,omitempty"`
+}
+
+// InitialValue returns the initial value of the secret in the scope and if it has been generated
+func (s SecretBlueprint) InitialValue(scope Scope) (value string, generated bool, err error) {
+	if s.Generator == nil {
+		return s.Value, false, nil
+	}
+	cfg := *s.Generator
+	cfg.Scope = scope.Generator()
+	g, err := generator.New(cfg)
+	if err != nil {
+		return "", false, err
+	}
+	value, err = g.Generate()
+	if err != nil {
+		return "", false, errors.Wrapf(err, "failed to generate initial value of secret %s", s.Name)
+	}
+	return value, true, nil
+}
+
+// LevelBlueprint describes what is created when onboarding an environment, team or application.
+type LevelBlueprint struct {
+	Secrets []SecretBlueprint `yaml:"secrets" json:"secrets"`
+	// ConjurPolicy is added to the body of the Conjur policy, e.g. to grant permissions.
+	// It may contain the variables {{Environment}}, {{TeamId}} and {{AppId}}.
+	ConjurPolicy string `yaml:"conjur_policy,omitempty" json:"conjur_policy,omitempty"`
+}
+
+// Names returns the names of the secrets in the order of the blueprint
+func (l LevelBlueprint) Names() []string {
+	names := make([]string, 0, len(l.Secrets))
+	for _, secret := range l.Secrets {
+		names = append(names, secret.Name)
+	}
+	return names
+}
+
+func (l LevelBlueprint) validate() error {
+	seen := make(map[string]bool, len(l.Secrets))
+	for _, secret := range l.Secrets {
+		if secret.Name == "" {
+			return errors.New("secret name must not be empty")
+		}
+		if strings.ContainsAny(secret.Name, Separator+"/") {
+			return errors.Errorf("secret name %q must not contain %q or %q", secret.Name, Separator, "/")
+		}
+		if seen[secret.Name] {
+			return errors.Errorf("duplicate secret %q", secret.Name)
+		}
+		seen[secret.Name] = true
+		if secret.Generator != nil {
+			if _, err := generator.New(*secret.Generator); err != nil {
+				return errors.Wrapf(err, "invalid generator of secret %s", secret.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// Blueprint describes the secrets that are created by the onboarders for each level.
+// All onboarders render the same blueprint, so a new secret only needs to be added here.
+type Blueprint struct {
+	Environment LevelBlueprint `yaml:"environment" json:"environment"`
+	Team        LevelBlueprint `yaml:"team" json:"team"`
+	Application LevelBlueprint `yaml:"application" json:"application"`
+}
+
+// DefaultBlueprint returns the secrets that are created if nothing else is configured
+func DefaultBlueprint() Blueprint {
+	uuid := &generator.Config{Type: generator.TypeUUID}
+	return Blueprint{
+		Environment: LevelBlueprint{
+			Secrets: []SecretBlueprint{
+				{Name: "zones"},
+			},
+		},
+		Team: LevelBlueprint{
+			Secrets: []SecretBlueprint{
+				// Used to authenticate the IDP-client of the team
+				{Name: "clientSecret", Generator: uuid},
+				// Used to authenticate the team using the CLIs
+				{Name: "teamToken", Generator: uuid},
+			},
+		},
+		Application: LevelBlueprint{
+			Secrets: []SecretBlueprint{
+				// Used to authenticate the IDP-client of the application
+				{Name: "clientSecret", Generator: uuid},
+				// Contains the secrets that are provided by the user as JSON-object
+				{Name: "externalSecrets", Value: "{}"},
+			},
+		},
+	}
+}
+
+// WithDefaults returns the blueprint where the secrets of all levels
+// that are not configured, i.e. nil, are taken from the DefaultBlueprint.
+func (b Blueprint) WithDefaults() Blueprint {
+	defaults := DefaultBlueprint()
+	if b.Environment.Secrets == nil {
+		b.Environment.Secrets = defaults.Environment.Secrets
+	}
+	if b.Team.Secrets == nil {
+		b.Team.Secrets = defaults.Team.Secrets
+	}
+	if b.Application.Secrets == nil {
+		b.Application.Secrets = defaults.Application.Secrets
+	}
+	return b
+}
+
+func (b Blueprint) Validate() error {
+	if err := b.Environment.validate(); err != nil {
+		return errors.Wrap(err, "invalid environment blueprint")
+	}
+	if err := b.Team.validate(); err != nil {
+		return errors.Wrap(err, "invalid team blueprint")
+	}
+	if err := b.Application.validate(); err != nil {
+		return errors.Wrap(err, "invalid application blueprint")
+	}
+	return nil
+}
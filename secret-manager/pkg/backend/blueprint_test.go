@@ -0,0 +1,64 @@
+package backend_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/backend"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/generator"
+)
+
+var _ = Describe("Blueprint", func() {
+
+	It("should contain the default secrets", func() {
+		blueprint := backend.DefaultBlueprint()
+		Expect(blueprint.Validate()).To(Succeed())
+		Expect(blueprint.Environment.Names()).To(Equal([]string{"zones"}))
+		Expect(blueprint.Team.Names()).To(Equal([]string{"clientSecret", "teamToken"}))
+		Expect(blueprint.Application.Names()).To(Equal([]string{"clientSecret", "externalSecrets"}))
+	})
+
+	It("should only use the defaults for levels that are not configured", func() {
+		blueprint := backend.Blueprint{
+			Team: backend.LevelBlueprint{
+				Secrets: []backend.SecretBlueprint{{Name: "teamToken"}, {Name: "webhookSecret"}},
+			},
+			Application: backend.LevelBlueprint{
+				Secrets: []backend.SecretBlueprint{},
+			},
+		}.WithDefaults()
+
+		Expect(blueprint.Environment.Names()).To(Equal([]string{"zones"}))
+		Expect(blueprint.Team.Names()).To(Equal([]string{"teamToken", "webhookSecret"}))
+		Expect(blueprint.Application.Names()).To(BeEmpty())
+	})
+
+	It("should return the initial values", func() {
+		value, generated, err := backend.SecretBlueprint{Name: "externalSecrets", Value: "{}"}.InitialValue(backend.Scope{Env: "env", Team: "team"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(generated).To(BeFalse())
+		Expect(value).To(Equal("{}"))
+
+		value, generated, err = backend.SecretBlueprint{
+			Name:      "token",
+			Generator: &generator.Config{Type: generator.TypeAlphanumeric, Length: 12},
+		}.InitialValue(backend.Scope{Env: "env", Team: "team"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(generated).To(BeTrue())
+		Expect(value).To(HaveLen(12))
+	})
+
+	DescribeTable("should reject invalid blueprints",
+		func(secrets []backend.SecretBlueprint, msg string) {
+			blueprint := backend.DefaultBlueprint()
+			blueprint.Team.Secrets = secrets
+			err := blueprint.Validate()
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("invalid team blueprint"))
+			Expect(err.Error()).To(ContainSubstring(msg))
+		},
+		Entry("empty name", []backend.SecretBlueprint{{Name: ""}}, "must not be empty"),
+		Entry("name with separator", []backend.SecretBlueprint{{Name: "foo:bar"}}, "must not contain"),
+		Entry("duplicate name", []backend.SecretBlueprint{{Name: "foo"}, {Name: "foo"}}, "duplicate secret"),
+		Entry("unknown generator", []backend.SecretBlueprint{{Name: "foo", Generator: &generator.Config{Type: "unknown"}}}, "invalid generator"),
+	)
+})
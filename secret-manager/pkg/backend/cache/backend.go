@@ -2,56 +2,160 @@ package cache
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/telekom/controlplane-mono/secret-manager/pkg/backend"
 )
 
+const defaultShardCount = 16
+
 type Cache[T backend.SecretId, S backend.Secret[T]] interface {
 	Get(id string) (CacheItem[T, S], bool)
 	Set(id string, item CacheItem[T, S])
 	Delete(id string)
+	// DeletePrefix deletes all items whose IDs start with the prefix and returns their number
+	DeletePrefix(prefix string) int
 }
 
 var _ backend.Backend[backend.SecretId, backend.Secret[backend.SecretId]] = (*CachedBackend[backend.SecretId, backend.Secret[backend.SecretId]])(nil)
 
+// CachedBackend caches the values of the secrets by their ID without checksum.
+// If a different checksum than the cached one is requested, the secret has changed and the cached value is invalidated.
+// Versions of a secret are immutable and are cached by their full ID.
 type CachedBackend[T backend.SecretId, S backend.Secret[T]] struct {
 	Backend backend.Backend[T, S]
 	Cache   Cache[T, S]
 	ttl     int64
+	maxSize int
+
+	// Negative keeps the IDs of the secrets that have not been found to protect the backend from repeated misses.
+	// It is only used if enabled using WithNegativeCaching.
+	Negative    Cache[T, S]
+	negativeTtl int64
+
+	// Stale keeps the last known values to serve them if the backend is unavailable.
+	// It is only used if enabled using WithStaleReads.
+	Stale    Cache[T, S]
+	staleTtl int64
 }
 
 func NewCachedBackend[T backend.SecretId, S backend.Secret[T]](backend backend.Backend[T, S], ttl time.Duration) *CachedBackend[T, S] {
+	RegisterMetrics(prometheus.DefaultRegisterer)
 	return &CachedBackend[T, S]{
 		Backend: backend,
-		Cache:   NewShardedCache[T, S](16),
+		Cache:   NewShardedCache[T, S](defaultShardCount),
 		ttl:     int64(ttl.Seconds()),
 	}
 }
 
+// WithMaxSize limits the number of items of each cache.
+// If a cache is full, its least recently used items are evicted.
+func (c *CachedBackend[T, S]) WithMaxSize(maxSize int) *CachedBackend[T, S] {
+	c.maxSize = maxSize
+	c.Cache = c.newCache()
+	if c.Negative != nil {
+		c.Negative = c.newCache()
+	}
+	if c.Stale != nil {
+		c.Stale = c.newCache()
+	}
+	return c
+}
+
+// WithNegativeCaching caches for ttl that a secret has not been found.
+// A secret that is set or invalidated is removed from the negative cache immediately.
+func (c *CachedBackend[T, S]) WithNegativeCaching(ttl time.Duration) *CachedBackend[T, S] {
+	if ttl <= 0 {
+		c.Negative = nil
+		return c
+	}
+	c.Negative = c.newCache()
+	c.negativeTtl = int64(ttl.Seconds())
+	return c
+}
+
+// WithStaleReads keeps the last known values for maxStale.
+// If the backend is unavailable, i.e. it returns a TooManyRequests error,
+// these values are returned instead and marked as stale, see backend.IsStale.
+func (c *CachedBackend[T, S]) WithStaleReads(maxStale time.Duration) *CachedBackend[T, S] {
+	if maxStale <= 0 {
+		c.Stale = nil
+		return c
+	}
+	c.Stale = c.newCache()
+	c.staleTtl = int64(maxStale.Seconds())
+	return c
+}
+
+func (c *CachedBackend[T, S]) newCache() Cache[T, S] {
+	return NewBoundedShardedCache[T, S](defaultShardCount, c.maxSize)
+}
+
 func (c *CachedBackend[T, S]) ParseSecretId(raw string) (T, error) {
 	return c.Backend.ParseSecretId(raw)
 }
 
 func (c *CachedBackend[T, S]) Get(ctx context.Context, id T) (res S, err error) {
 	log := logr.FromContextOrDiscard(ctx)
-	if item, ok := c.Cache.Get(id.String()); ok && !item.Expired() {
-		return item.Value(), nil
+	key, checksum := cacheKey(id.String())
+	if item, ok := c.Cache.Get(key); ok && !item.Expired() {
+		if checksum == "" || !checksumChanged(item.Value().Id(), checksum) {
+			hits.WithLabelValues(ResultPositive).Inc()
+			return item.Value(), nil
+		}
+		// The backend decides whether the requested checksum is outdated or the cached one
+		c.Cache.Delete(key)
+		evictions.WithLabelValues(ReasonChecksum).Inc()
+	}
+	if c.Negative != nil {
+		if _, ok := c.Negative.Get(key); ok {
+			hits.WithLabelValues(ResultNegative).Inc()
+			return res, backend.ErrSecretNotFound(id)
+		}
 	}
 
+	misses.Inc()
 	log.Info("Cache miss", "id", id.String())
 	item, err := c.Backend.Get(ctx, id)
 	if err != nil {
+		if c.Negative != nil && backend.IsNotFoundErr(err) {
+			c.Negative.Set(key, NewDefaultCacheItem(id, res, c.negativeTtl))
+		}
+		if stale, ok := c.getStale(key); ok && backend.IsTooManyRequestsErr(err) {
+			log.Info("Backend is unavailable. Returning stale secret", "id", id.String(), "error", err.Error())
+			return backend.MarkStale(stale), nil
+		}
 		return res, err
 	}
 
-	c.Cache.Set(id.String(), NewDefaultCacheItem(id, item, c.ttl))
+	c.set(key, id, item)
 	return item, nil
 }
 
+func (c *CachedBackend[T, S]) set(key string, id T, item S) {
+	c.Cache.Set(key, NewDefaultCacheItem(id, item, c.ttl))
+	if c.Stale != nil {
+		c.Stale.Set(key, NewDefaultCacheItem(id, item, c.staleTtl))
+	}
+}
+
+func (c *CachedBackend[T, S]) getStale(key string) (res S, ok bool) {
+	if c.Stale == nil {
+		return res, false
+	}
+	item, ok := c.Stale.Get(key)
+	if !ok {
+		return res, false
+	}
+	return item.Value(), true
+}
+
 func (c *CachedBackend[T, S]) Set(ctx context.Context, id T, value backend.SecretValue) (res S, err error) {
-	if item, ok := c.Cache.Get(id.String()); ok {
+	key, _ := cacheKey(id.String())
+	if item, ok := c.Cache.Get(key); ok {
 		if value.EqualString(item.Value().Value()) {
 			return item.Value(), nil
 		}
@@ -61,13 +165,74 @@ func (c *CachedBackend[T, S]) Set(ctx context.Context, id T, value backend.Secre
 		return res, err
 	}
 
+	// The cached values must never be older than a successful write.
+	// This includes the sub-paths and versions of the secret.
+	c.Invalidate(secretPrefix(key))
 	if item.Value() != "" {
-		c.Cache.Set(id.String(), NewDefaultCacheItem(id, item, c.ttl))
+		c.set(key, id, item)
 	}
 	return item, nil
 }
 
 func (c *CachedBackend[T, S]) Delete(ctx context.Context, id T) error {
-	c.Cache.Delete(id.String())
+	key, _ := cacheKey(id.String())
+	c.Invalidate(secretPrefix(key))
 	return c.Backend.Delete(ctx, id)
 }
+
+// Invalidate removes all secrets whose IDs start with the prefix from all caches,
+// e.g. `env:team:app:` if the secrets of an application have been changed by someone else.
+func (c *CachedBackend[T, S]) Invalidate(prefix string) {
+	deleted := c.Cache.DeletePrefix(prefix)
+	if c.Negative != nil {
+		deleted += c.Negative.DeletePrefix(prefix)
+	}
+	if c.Stale != nil {
+		c.Stale.DeletePrefix(prefix)
+	}
+	if deleted > 0 {
+		evictions.WithLabelValues(ReasonInvalidated).Add(float64(deleted))
+	}
+}
+
+// List is never cached as it does not contain any values
+// and must reflect newly created secrets immediately.
+func (c *CachedBackend[T, S]) List(ctx context.Context, env, team, app string) (map[string]T, error) {
+	return c.Backend.List(ctx, env, team, app)
+}
+
+// Versions is never cached as a new version must be visible immediately.
+// The versions themselves are immutable and are cached by Get.
+func (c *CachedBackend[T, S]) Versions(ctx context.Context, id T) ([]T, error) {
+	return c.Backend.Versions(ctx, id)
+}
+
+// cacheKey returns the key of the secret in the cache and the requested checksum.
+// The checksum is not part of the key unless it references an immutable version of the secret.
+func cacheKey(id string) (key, checksum string) {
+	i := strings.LastIndex(id, backend.Separator)
+	if i < 0 {
+		return id, ""
+	}
+	if _, ok := backend.ParseVersion(id[i+1:]); ok {
+		return id, ""
+	}
+	return id[:i+1], id[i+1:]
+}
+
+// checksumChanged returns true if the cached ID has a different checksum than the requested one
+func checksumChanged(cached backend.SecretId, checksum string) bool {
+	_, cachedChecksum := cacheKey(cached.String())
+	return cachedChecksum != "" && cachedChecksum != checksum
+}
+
+// secretPrefix returns the prefix of all keys of the secret, i.e. its versions and sub-paths,
+// e.g. `env:team:app:externalSecrets` for `env:team:app:externalSecrets/foo:`.
+func secretPrefix(key string) string {
+	parts := strings.Split(key, backend.Separator)
+	if len(parts) != 5 {
+		return key
+	}
+	name, _, _ := strings.Cut(parts[3], "/")
+	return strings.Join(append(parts[:3], name), backend.Separator)
+}
@@ -2,10 +2,13 @@ package cache_test
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/telekom/controlplane-mono/secret-manager/pkg/backend"
 	"github.com/telekom/controlplane-mono/secret-manager/pkg/backend/cache"
 	"github.com/telekom/controlplane-mono/secret-manager/test/mocks"
@@ -146,5 +149,257 @@ var _ = Describe("Cached Backend", func() {
 			Expect(cachedBackend.Cache.Get(secretId.String())).To(BeNil())
 
 		})
+
+		It("should always list the secrets from the backend", func() {
+			ctx := context.Background()
+			secretId := mocks.NewMockSecretId(GinkgoT())
+			ids := map[string]*mocks.MockSecretId{"clientSecret": secretId}
+
+			mockBackend.EXPECT().List(ctx, "env", "team", "app").Return(ids, nil).Twice()
+
+			for range 2 {
+				res, err := cachedBackend.List(ctx, "env", "team", "app")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(res).To(Equal(ids))
+			}
+		})
+
+		It("should invalidate the cached value if a different checksum is requested", func() {
+			ctx := context.Background()
+			oldId := mocks.NewMockSecretId(GinkgoT())
+			oldId.EXPECT().String().Return("env:team:app:clientSecret:old")
+			newId := mocks.NewMockSecretId(GinkgoT())
+			newId.EXPECT().String().Return("env:team:app:clientSecret:new")
+
+			mockBackend.EXPECT().Get(ctx, oldId).Return(backend.NewDefaultSecret(oldId, "old-value"), nil).Once()
+			mockBackend.EXPECT().Get(ctx, newId).Return(backend.NewDefaultSecret(newId, "new-value"), nil).Once()
+
+			for _, expected := range []string{"old-value", "old-value"} {
+				secret, err := cachedBackend.Get(ctx, oldId)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(secret.Value()).To(Equal(expected))
+			}
+			for _, expected := range []string{"new-value", "new-value"} {
+				secret, err := cachedBackend.Get(ctx, newId)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(secret.Value()).To(Equal(expected))
+			}
+		})
+
+		It("should serve requests without checksum from the cache", func() {
+			ctx := context.Background()
+			secretId := mocks.NewMockSecretId(GinkgoT())
+			secretId.EXPECT().String().Return("env:team:app:clientSecret:abc")
+			latestId := mocks.NewMockSecretId(GinkgoT())
+			latestId.EXPECT().String().Return("env:team:app:clientSecret:")
+
+			mockBackend.EXPECT().Get(ctx, secretId).Return(backend.NewDefaultSecret(secretId, "my-value"), nil).Once()
+
+			_, err := cachedBackend.Get(ctx, secretId)
+			Expect(err).NotTo(HaveOccurred())
+			secret, err := cachedBackend.Get(ctx, latestId)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(secret.Value()).To(Equal("my-value"))
+		})
+
+		It("should cache versions by their full ID", func() {
+			ctx := context.Background()
+			v1 := mocks.NewMockSecretId(GinkgoT())
+			v1.EXPECT().String().Return("env:team:app:clientSecret:v1")
+			v2 := mocks.NewMockSecretId(GinkgoT())
+			v2.EXPECT().String().Return("env:team:app:clientSecret:v2")
+
+			mockBackend.EXPECT().Get(ctx, v1).Return(backend.NewDefaultSecret(v1, "first"), nil).Once()
+			mockBackend.EXPECT().Get(ctx, v2).Return(backend.NewDefaultSecret(v2, "second"), nil).Once()
+
+			for range 2 {
+				secret, err := cachedBackend.Get(ctx, v1)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(secret.Value()).To(Equal("first"))
+				secret, err = cachedBackend.Get(ctx, v2)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(secret.Value()).To(Equal("second"))
+			}
+		})
+
+		It("should invalidate the secret and its sub-paths when it is set", func() {
+			ctx := context.Background()
+			secretId := mocks.NewMockSecretId(GinkgoT())
+			secretId.EXPECT().String().Return("env:team:app:externalSecrets:")
+			subPathId := mocks.NewMockSecretId(GinkgoT())
+
+			cachedBackend.Cache.Set("env:team:app:externalSecrets:", cache.NewDefaultCacheItem(secretId, backend.NewDefaultSecret(secretId, `{"foo":"old"}`), 10))
+			cachedBackend.Cache.Set("env:team:app:externalSecrets/foo:", cache.NewDefaultCacheItem(subPathId, backend.NewDefaultSecret(subPathId, "old"), 10))
+			// The backend does not return the written value
+			mockBackend.EXPECT().Set(ctx, secretId, backend.String(`{"foo":"new"}`)).Return(backend.NewDefaultSecret(secretId, ""), nil).Once()
+
+			_, err := cachedBackend.Set(ctx, secretId, backend.String(`{"foo":"new"}`))
+			Expect(err).NotTo(HaveOccurred())
+
+			_, ok := cachedBackend.Cache.Get("env:team:app:externalSecrets:")
+			Expect(ok).To(BeFalse())
+			_, ok = cachedBackend.Cache.Get("env:team:app:externalSecrets/foo:")
+			Expect(ok).To(BeFalse())
+		})
+
+		It("should invalidate all secrets with the prefix", func() {
+			secretId := mocks.NewMockSecretId(GinkgoT())
+			for _, id := range []string{"env:team:app:clientSecret:", "env:team:app:externalSecrets:", "env:team:other:clientSecret:"} {
+				cachedBackend.Cache.Set(id, cache.NewDefaultCacheItem(secretId, backend.NewDefaultSecret(secretId, "value"), 10))
+			}
+
+			cachedBackend.Invalidate("env:team:app:")
+
+			_, ok := cachedBackend.Cache.Get("env:team:app:clientSecret:")
+			Expect(ok).To(BeFalse())
+			_, ok = cachedBackend.Cache.Get("env:team:app:externalSecrets:")
+			Expect(ok).To(BeFalse())
+			_, ok = cachedBackend.Cache.Get("env:team:other:clientSecret:")
+			Expect(ok).To(BeTrue())
+		})
+
+		It("should provide metrics", func() {
+			ctx := context.Background()
+			secretId := mocks.NewMockSecretId(GinkgoT())
+			secretId.EXPECT().String().Return("env:team:app:clientSecret:")
+			mockBackend.EXPECT().Get(ctx, secretId).Return(backend.NewDefaultSecret(secretId, "my-value"), nil).Once()
+
+			for range 2 {
+				_, err := cachedBackend.Get(ctx, secretId)
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			families, err := prometheus.DefaultGatherer.Gather()
+			Expect(err).NotTo(HaveOccurred())
+			names := []string{}
+			for _, family := range families {
+				names = append(names, family.GetName())
+			}
+			Expect(names).To(ContainElements("secret_cache_hits_total", "secret_cache_misses_total"))
+		})
+
+		Context("Negative caching", func() {
+
+			BeforeEach(func() {
+				cachedBackend.WithNegativeCaching(time.Minute)
+			})
+
+			It("should cache that a secret has not been found", func() {
+				ctx := context.Background()
+				secretId := mocks.NewMockSecretId(GinkgoT())
+				secretId.EXPECT().String().Return("env:team:app:unknown:")
+
+				mockBackend.EXPECT().Get(ctx, secretId).Return(backend.DefaultSecret[*mocks.MockSecretId]{}, backend.ErrSecretNotFound(secretId)).Once()
+
+				for range 2 {
+					_, err := cachedBackend.Get(ctx, secretId)
+					Expect(backend.IsNotFoundErr(err)).To(BeTrue())
+				}
+			})
+
+			It("should not cache other errors", func() {
+				ctx := context.Background()
+				secretId := mocks.NewMockSecretId(GinkgoT())
+				secretId.EXPECT().String().Return("env:team:app:clientSecret:")
+
+				mockBackend.EXPECT().Get(ctx, secretId).Return(backend.DefaultSecret[*mocks.MockSecretId]{}, backend.NewBackendError(secretId, errors.New("timeout"), "InternalError")).Twice()
+
+				for range 2 {
+					_, err := cachedBackend.Get(ctx, secretId)
+					Expect(err).To(HaveOccurred())
+				}
+			})
+
+			It("should forget the miss when the secret is set", func() {
+				ctx := context.Background()
+				secretId := mocks.NewMockSecretId(GinkgoT())
+				secretId.EXPECT().String().Return("env:team:app:clientSecret:")
+
+				mockBackend.EXPECT().Get(ctx, secretId).Return(backend.DefaultSecret[*mocks.MockSecretId]{}, backend.ErrSecretNotFound(secretId)).Once()
+				_, err := cachedBackend.Get(ctx, secretId)
+				Expect(backend.IsNotFoundErr(err)).To(BeTrue())
+
+				mockBackend.EXPECT().Set(ctx, secretId, backend.String("my-value")).Return(backend.NewDefaultSecret(secretId, "my-value"), nil).Once()
+				_, err = cachedBackend.Set(ctx, secretId, backend.String("my-value"))
+				Expect(err).NotTo(HaveOccurred())
+
+				secret, err := cachedBackend.Get(ctx, secretId)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(secret.Value()).To(Equal("my-value"))
+			})
+		})
+
+		Context("Bounded size", func() {
+
+			It("should evict the least recently used secrets", func() {
+				cachedBackend.WithMaxSize(1)
+				secretId := mocks.NewMockSecretId(GinkgoT())
+				item := cache.NewDefaultCacheItem(secretId, backend.NewDefaultSecret(secretId, "value"), 10)
+
+				// With a single item per shard, a second item in the same shard evicts the first one
+				for i := range 100 {
+					cachedBackend.Cache.Set(fmt.Sprintf("env:team:app:secret%d:", i), item)
+				}
+				count := 0
+				for i := range 100 {
+					if _, ok := cachedBackend.Cache.Get(fmt.Sprintf("env:team:app:secret%d:", i)); ok {
+						count++
+					}
+				}
+				Expect(count).To(BeNumerically("<=", 16))
+			})
+		})
+
+		Context("Stale reads", func() {
+
+			BeforeEach(func() {
+				cachedBackend.WithStaleReads(time.Minute)
+			})
+
+			It("should return the last known value if the backend is unavailable", func() {
+				ctx := context.Background()
+				secretId := mocks.NewMockSecretId(GinkgoT())
+				secretId.EXPECT().String().Return("my-secret-id")
+
+				mockBackend.EXPECT().Get(ctx, secretId).Return(backend.NewDefaultSecret[*mocks.MockSecretId](secretId, "my-value"), nil).Once()
+				secret, err := cachedBackend.Get(ctx, secretId)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(backend.IsStale(secret)).To(BeFalse())
+
+				// The cached item has expired
+				cachedBackend.Cache.Delete(secretId.String())
+				mockBackend.EXPECT().Get(ctx, secretId).Return(backend.DefaultSecret[*mocks.MockSecretId]{}, backend.NewBackendError(secretId, errors.New("circuit breaker is open"), backend.TypeErrTooManyRequests)).Once()
+
+				secret, err = cachedBackend.Get(ctx, secretId)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(secret.Value()).To(Equal("my-value"))
+				Expect(backend.IsStale(secret)).To(BeTrue())
+			})
+
+			It("should not hide other errors of the backend", func() {
+				ctx := context.Background()
+				secretId := mocks.NewMockSecretId(GinkgoT())
+				secretId.EXPECT().String().Return("my-secret-id")
+
+				cachedBackend.Stale.Set(secretId.String(), cache.NewDefaultCacheItem(secretId, backend.NewDefaultSecret[*mocks.MockSecretId](secretId, "my-value"), 60))
+				mockBackend.EXPECT().Get(ctx, secretId).Return(backend.DefaultSecret[*mocks.MockSecretId]{}, backend.ErrSecretNotFound(secretId)).Once()
+
+				_, err := cachedBackend.Get(ctx, secretId)
+				Expect(backend.IsNotFoundErr(err)).To(BeTrue())
+			})
+
+			It("should forget the last known value when the secret is deleted", func() {
+				ctx := context.Background()
+				secretId := mocks.NewMockSecretId(GinkgoT())
+				secretId.EXPECT().String().Return("my-secret-id")
+
+				cachedBackend.Stale.Set(secretId.String(), cache.NewDefaultCacheItem(secretId, backend.NewDefaultSecret[*mocks.MockSecretId](secretId, "my-value"), 60))
+				mockBackend.EXPECT().Delete(ctx, secretId).Return(nil).Once()
+
+				Expect(cachedBackend.Delete(ctx, secretId)).To(Succeed())
+				_, ok := cachedBackend.Stale.Get(secretId.String())
+				Expect(ok).To(BeFalse())
+			})
+		})
 	})
 })
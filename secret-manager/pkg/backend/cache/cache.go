@@ -1,6 +1,7 @@
 package cache
 
 import (
+	"strings"
 	"sync"
 	"time"
 
@@ -43,6 +44,19 @@ func (c *SimpleCache[T, S]) Delete(id string) {
 	c.lock.Unlock()
 }
 
+func (c *SimpleCache[T, S]) DeletePrefix(prefix string) int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	deleted := 0
+	for id := range c.m {
+		if strings.HasPrefix(id, prefix) {
+			delete(c.m, id)
+			deleted++
+		}
+	}
+	return deleted
+}
+
 func (c *SimpleCache[T, S]) Set(id string, item CacheItem[T, S]) {
 	c.lock.Lock()
 	c.m[id] = item
@@ -0,0 +1,104 @@
+package cache
+
+import (
+	"container/list"
+	"strings"
+	"sync"
+
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/backend"
+)
+
+var _ Cache[backend.SecretId, backend.Secret[backend.SecretId]] = (*LRUCache[backend.SecretId, backend.Secret[backend.SecretId]])(nil)
+
+// LRUCache keeps at most maxSize items.
+// If it is full, the least recently used item is evicted.
+type LRUCache[T backend.SecretId, S backend.Secret[T]] struct {
+	lock    sync.Mutex
+	maxSize int
+	order   *list.List
+	m       map[string]*list.Element
+}
+
+type lruEntry[T backend.SecretId, S backend.Secret[T]] struct {
+	id   string
+	item CacheItem[T, S]
+}
+
+func NewLRUCache[T backend.SecretId, S backend.Secret[T]](maxSize int) *LRUCache[T, S] {
+	if maxSize <= 0 {
+		panic("maxSize must be greater than 0")
+	}
+	return &LRUCache[T, S]{
+		maxSize: maxSize,
+		order:   list.New(),
+		m:       make(map[string]*list.Element, maxSize),
+	}
+}
+
+func (c *LRUCache[T, S]) Get(id string) (CacheItem[T, S], bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	elem, ok := c.m[id]
+	if !ok {
+		return nil, false
+	}
+	entry := elem.Value.(*lruEntry[T, S])
+	if entry.item.Expired() {
+		c.remove(elem)
+		return nil, false
+	}
+	c.order.MoveToFront(elem)
+	return entry.item, true
+}
+
+func (c *LRUCache[T, S]) Set(id string, item CacheItem[T, S]) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if elem, ok := c.m[id]; ok {
+		elem.Value.(*lruEntry[T, S]).item = item
+		c.order.MoveToFront(elem)
+		return
+	}
+	c.m[id] = c.order.PushFront(&lruEntry[T, S]{id: id, item: item})
+	for c.order.Len() > c.maxSize {
+		c.remove(c.order.Back())
+		evictions.WithLabelValues(ReasonSize).Inc()
+	}
+}
+
+func (c *LRUCache[T, S]) Delete(id string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if elem, ok := c.m[id]; ok {
+		c.remove(elem)
+	}
+}
+
+func (c *LRUCache[T, S]) DeletePrefix(prefix string) int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	deleted := 0
+	for id, elem := range c.m {
+		if strings.HasPrefix(id, prefix) {
+			c.remove(elem)
+			deleted++
+		}
+	}
+	return deleted
+}
+
+// Len returns the number of items including the expired ones that have not been accessed since
+func (c *LRUCache[T, S]) Len() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.order.Len()
+}
+
+func (c *LRUCache[T, S]) remove(elem *list.Element) {
+	c.order.Remove(elem)
+	delete(c.m, elem.Value.(*lruEntry[T, S]).id)
+}
@@ -0,0 +1,72 @@
+package cache_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/backend"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/backend/cache"
+	"github.com/telekom/controlplane-mono/secret-manager/test/mocks"
+)
+
+var _ = Describe("LRU Cache", func() {
+
+	var lruCache *cache.LRUCache[*mocks.MockSecretId, backend.DefaultSecret[*mocks.MockSecretId]]
+	var item cache.CacheItem[*mocks.MockSecretId, backend.DefaultSecret[*mocks.MockSecretId]]
+
+	BeforeEach(func() {
+		lruCache = cache.NewLRUCache[*mocks.MockSecretId, backend.DefaultSecret[*mocks.MockSecretId]](2)
+		secretId := mocks.NewMockSecretId(GinkgoT())
+		item = cache.NewDefaultCacheItem(secretId, backend.NewDefaultSecret(secretId, "my-value"), 10)
+	})
+
+	It("should panic if the size is 0", func() {
+		Expect(func() {
+			cache.NewLRUCache[*mocks.MockSecretId, backend.DefaultSecret[*mocks.MockSecretId]](0)
+		}).To(Panic())
+	})
+
+	It("should evict the least recently used item", func() {
+		lruCache.Set("a", item)
+		lruCache.Set("b", item)
+		_, ok := lruCache.Get("a")
+		Expect(ok).To(BeTrue())
+
+		lruCache.Set("c", item)
+		Expect(lruCache.Len()).To(Equal(2))
+
+		_, ok = lruCache.Get("b")
+		Expect(ok).To(BeFalse())
+		_, ok = lruCache.Get("a")
+		Expect(ok).To(BeTrue())
+		_, ok = lruCache.Get("c")
+		Expect(ok).To(BeTrue())
+	})
+
+	It("should replace an existing item without eviction", func() {
+		lruCache.Set("a", item)
+		lruCache.Set("b", item)
+		lruCache.Set("a", item)
+		Expect(lruCache.Len()).To(Equal(2))
+
+		_, ok := lruCache.Get("b")
+		Expect(ok).To(BeTrue())
+	})
+
+	It("should not return expired items", func() {
+		secretId := mocks.NewMockSecretId(GinkgoT())
+		lruCache.Set("a", cache.NewDefaultCacheItem(secretId, backend.NewDefaultSecret(secretId, "my-value"), -1))
+
+		_, ok := lruCache.Get("a")
+		Expect(ok).To(BeFalse())
+		Expect(lruCache.Len()).To(Equal(0))
+	})
+
+	It("should delete items by prefix", func() {
+		lruCache.Set("env:team:a:", item)
+		lruCache.Set("env:other:b:", item)
+
+		Expect(lruCache.DeletePrefix("env:team:")).To(Equal(1))
+		lruCache.Delete("env:other:b:")
+		Expect(lruCache.Len()).To(Equal(0))
+	})
+})
@@ -0,0 +1,55 @@
+package cache
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// ResultPositive is used for cached values
+	ResultPositive = "positive"
+	// ResultNegative is used for cached NotFound results
+	ResultNegative = "negative"
+
+	// ReasonSize is used if the least recently used item is evicted as the cache is full
+	ReasonSize = "size"
+	// ReasonChecksum is used if the item is evicted as a different checksum has been requested
+	ReasonChecksum = "checksum"
+	// ReasonInvalidated is used if the item is evicted as the secret has been changed
+	ReasonInvalidated = "invalidated"
+)
+
+var (
+	registerOnce = sync.Once{}
+	hits         = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "secret_cache_hits_total",
+			Help: "Number of reads that have been served from the cache",
+		},
+		[]string{"result"},
+	)
+
+	misses = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "secret_cache_misses_total",
+			Help: "Number of reads that have been forwarded to the backend",
+		},
+	)
+
+	evictions = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "secret_cache_evictions_total",
+			Help: "Number of items that have been removed from the cache before they expired",
+		},
+		[]string{"reason"},
+	)
+)
+
+func RegisterMetrics(reg prometheus.Registerer) {
+	registerOnce.Do(func() {
+		reg.MustRegister(hits)
+		reg.MustRegister(misses)
+		reg.MustRegister(evictions)
+	})
+}
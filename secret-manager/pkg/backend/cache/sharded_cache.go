@@ -25,6 +25,24 @@ func NewShardedCache[T backend.SecretId, S backend.Secret[T]](shardCount uint8)
 	return &ShardedCache[T, S]{shards: shards, shardCount: shardCount}
 }
 
+// NewBoundedShardedCache creates a sharded cache that keeps at most maxSize items.
+// Each shard evicts its least recently used items if it is full.
+// The items are distributed by their hash, so a shard might be full before maxSize items are cached in total.
+func NewBoundedShardedCache[T backend.SecretId, S backend.Secret[T]](shardCount uint8, maxSize int) *ShardedCache[T, S] {
+	if shardCount == 0 {
+		panic("shardCount must be greater than 0")
+	}
+	if maxSize <= 0 {
+		return NewShardedCache[T, S](shardCount)
+	}
+	shardSize := max((maxSize+int(shardCount)-1)/int(shardCount), 1)
+	shards := make([]Cache[T, S], shardCount)
+	for i := range shards {
+		shards[i] = NewLRUCache[T, S](shardSize)
+	}
+	return &ShardedCache[T, S]{shards: shards, shardCount: shardCount}
+}
+
 func (sc *ShardedCache[T, S]) getShard(key string) Cache[T, S] {
 	hash := fnv.New32a()
 	_, err := hash.Write([]byte(key))
@@ -46,3 +64,11 @@ func (sc *ShardedCache[T, S]) Set(id string, item CacheItem[T, S]) {
 func (sc *ShardedCache[T, S]) Delete(id string) {
 	sc.getShard(id).Delete(id)
 }
+
+func (sc *ShardedCache[T, S]) DeletePrefix(prefix string) int {
+	deleted := 0
+	for _, shard := range sc.shards {
+		deleted += shard.DeletePrefix(prefix)
+	}
+	return deleted
+}
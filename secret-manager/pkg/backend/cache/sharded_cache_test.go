@@ -65,5 +65,34 @@ var _ = Describe("Sharded Cache", func() {
 			Expect(ok).To(BeFalse())
 			Expect(cachedItem).To(BeNil())
 		})
+
+		It("should delete items by prefix from all shards", func() {
+			shardedCache := cache.NewShardedCache[*mocks.MockSecretId, backend.DefaultSecret[*mocks.MockSecretId]](4)
+			secretId := mocks.NewMockSecretId(GinkgoT())
+			item := cache.NewDefaultCacheItem(secretId, backend.NewDefaultSecret(secretId, "my-value"), 10)
+
+			for _, id := range []string{"env:team:app:a:", "env:team:app:b:", "env:team:app:c:", "env:other:app:a:"} {
+				shardedCache.Set(id, item)
+			}
+
+			Expect(shardedCache.DeletePrefix("env:team:")).To(Equal(3))
+			_, ok := shardedCache.Get("env:other:app:a:")
+			Expect(ok).To(BeTrue())
+		})
+
+		It("should limit the number of items of a bounded sharded cache", func() {
+			shardedCache := cache.NewBoundedShardedCache[*mocks.MockSecretId, backend.DefaultSecret[*mocks.MockSecretId]](1, 2)
+			secretId := mocks.NewMockSecretId(GinkgoT())
+			item := cache.NewDefaultCacheItem(secretId, backend.NewDefaultSecret(secretId, "my-value"), 10)
+
+			shardedCache.Set("a", item)
+			shardedCache.Set("b", item)
+			shardedCache.Set("c", item)
+
+			_, ok := shardedCache.Get("a")
+			Expect(ok).To(BeFalse())
+			_, ok = shardedCache.Get("c")
+			Expect(ok).To(BeTrue())
+		})
 	})
 })
@@ -2,7 +2,11 @@ package conjur
 
 import (
 	"context"
+	"errors"
+	"slices"
+	"strings"
 
+	"github.com/cyberark/conjur-api-go/conjurapi"
 	"github.com/go-logr/logr"
 	"github.com/telekom/controlplane-mono/secret-manager/pkg/backend"
 	"github.com/tidwall/gjson"
@@ -11,6 +15,9 @@ import (
 
 var _ backend.Backend[ConjurSecretId, backend.DefaultSecret[ConjurSecretId]] = &ConjurBackend{}
 
+// ListPageSize is the number of resources that are requested from Conjur at once
+var ListPageSize = 100
+
 type ConjurBackend struct {
 	writeAPI ConjurAPI
 	readAPI  ConjurAPI
@@ -18,6 +25,10 @@ type ConjurBackend struct {
 	// MustMatchChecksum is used to check if the checksum of the requested secret
 	// is actually the same as the one in the backend. If it is not, an error is returned.
 	MustMatchChecksum bool
+
+	// MaxVersions is the number of versions of a secret that can be read.
+	// Conjur itself keeps the last 20 versions of each variable.
+	MaxVersions int
 }
 
 func NewBackend(writeAPI, readAPI ConjurAPI) backend.Backend[ConjurSecretId, backend.DefaultSecret[ConjurSecretId]] {
@@ -25,6 +36,7 @@ func NewBackend(writeAPI, readAPI ConjurAPI) backend.Backend[ConjurSecretId, bac
 		writeAPI:          writeAPI,
 		readAPI:           readAPI,
 		MustMatchChecksum: false,
+		MaxVersions:       backend.DefaultMaxVersions,
 	}
 }
 
@@ -34,6 +46,10 @@ func (c *ConjurBackend) ParseSecretId(rawId string) (ConjurSecretId, error) {
 
 func (c *ConjurBackend) Get(ctx context.Context, id ConjurSecretId) (res backend.DefaultSecret[ConjurSecretId], err error) {
 	log := logr.FromContextOrDiscard(ctx)
+	if version, ok := backend.ParseVersion(id.checksum); ok {
+		return c.getVersion(ctx, id, version)
+	}
+
 	log.Info("Getting secret", "variableID", id.VariableId())
 	secret, err := c.readAPI.RetrieveSecret(id.VariableId())
 	if err != nil {
@@ -50,6 +66,10 @@ func (c *ConjurBackend) Get(ctx context.Context, id ConjurSecretId) (res backend
 		newId := id.CopyWithChecksum(backend.MakeChecksum(result.String()))
 		res = backend.NewDefaultSecret(newId, result.String())
 	} else {
+		// Conjur can not remove the value of a variable, so deleted variables are empty
+		if len(secret) == 0 {
+			return res, backend.ErrSecretNotFound(id)
+		}
 		newId := id.CopyWithChecksum(backend.MakeChecksum(string(secret)))
 		res = backend.NewDefaultSecret(newId, string(secret))
 	}
@@ -115,12 +135,118 @@ func (c *ConjurBackend) Set(ctx context.Context, id ConjurSecretId, secretValue
 	if err != nil {
 		return res, handleError(err, id)
 	}
+	// Like Get, the checksum of the value is returned, as a version expires after MaxVersions writes.
+	// The version that has been written can be read from Versions.
 	newId := id.CopyWithChecksum(backend.MakeChecksum(secretValue.Value()))
 	return backend.NewDefaultSecret(newId, ""), nil
 }
 
+// Versions returns the IDs of the versions of the variable that can be read, the latest first.
+// Like the values, the versions are read from the followers, so a version that has just been written might not be listed yet.
+func (c *ConjurBackend) Versions(ctx context.Context, id ConjurSecretId) ([]ConjurSecretId, error) {
+	log := logr.FromContextOrDiscard(ctx)
+	log.Info("Getting versions", "variableID", id.VariableId())
+	versions, err := c.versions(id)
+	if err != nil {
+		return nil, handleError(err, id)
+	}
+
+	ids := make([]ConjurSecretId, 0, len(versions))
+	for _, version := range versions {
+		ids = append(ids, id.CopyWithChecksum(backend.MakeVersion(version)))
+	}
+	return ids, nil
+}
+
+func (c *ConjurBackend) getVersion(ctx context.Context, id ConjurSecretId, version int) (res backend.DefaultSecret[ConjurSecretId], err error) {
+	log := logr.FromContextOrDiscard(ctx)
+	log.Info("Getting secret version", "variableID", id.VariableId(), "version", version)
+
+	versions, err := c.versions(id)
+	if err != nil {
+		return res, handleError(err, id)
+	}
+	if !slices.Contains(versions, version) {
+		if len(versions) > 0 && version < versions[len(versions)-1] {
+			return res, backend.ErrVersionExpired(id, c.MaxVersions)
+		}
+		return res, backend.ErrSecretNotFound(id)
+	}
+
+	secret, err := c.readAPI.RetrieveSecretWithVersion(id.VariableId(), version)
+	if err != nil {
+		return res, handleError(err, id)
+	}
+
+	subPath := id.SubPath()
+	if subPath == "" {
+		if len(secret) == 0 {
+			return res, backend.ErrSecretNotFound(id)
+		}
+		return backend.NewDefaultSecret(id, string(secret)), nil
+	}
+	result := gjson.GetBytes(secret, subPath)
+	if !result.Exists() {
+		return res, backend.ErrSecretNotFound(id)
+	}
+	return backend.NewDefaultSecret(id, result.String()), nil
+}
+
+// versions returns the versions of the variable that can be read, the latest first.
+// They are taken from the metadata of the resource, so a single request is needed for all versions.
+func (c *ConjurBackend) versions(id ConjurSecretId) ([]int, error) {
+	resource, err := c.readAPI.Resource("variable:" + id.VariableId())
+	if err != nil {
+		return nil, err
+	}
+
+	secrets, _ := resource["secrets"].([]interface{})
+	versions := make([]int, 0, len(secrets))
+	for _, secret := range secrets {
+		metadata, ok := secret.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		// JSON numbers are decoded as float64
+		if version, ok := metadata["version"].(float64); ok {
+			versions = append(versions, int(version))
+		}
+	}
+
+	slices.Sort(versions)
+	slices.Reverse(versions)
+	if len(versions) > c.MaxVersions {
+		versions = versions[:c.MaxVersions]
+	}
+	return versions, nil
+}
+
+// Delete empties the variable, as Conjur can not remove the value of a variable.
+// If the ID has a sub-path, only the sub-path is removed from the value.
 func (c *ConjurBackend) Delete(ctx context.Context, id ConjurSecretId) error {
-	err := c.writeAPI.AddSecret(id.VariableId(), "")
+	log := logr.FromContextOrDiscard(ctx)
+	log.Info("Deleting secret", "variableID", id.VariableId())
+	data, err := c.readAPI.RetrieveSecret(id.VariableId())
+	if err != nil {
+		return handleError(err, id)
+	}
+	if len(data) == 0 {
+		return backend.ErrSecretNotFound(id)
+	}
+
+	nextValue := ""
+	if subPath := id.SubPath(); subPath != "" {
+		if !gjson.GetBytes(data, subPath).Exists() {
+			return backend.ErrSecretNotFound(id)
+		}
+		newData, err := sjson.DeleteBytes(data, subPath)
+		if err != nil {
+			return handleError(err, id)
+		}
+		nextValue = string(newData)
+	}
+
+	err = c.writeAPI.AddSecret(id.VariableId(), nextValue)
 	if err != nil {
 		return handleError(err, id)
 	}
@@ -128,10 +254,57 @@ func (c *ConjurBackend) Delete(ctx context.Context, id ConjurSecretId) error {
 	return nil
 }
 
+// List returns the variables that are located directly in the policy of the scope.
+// As the values are not read, the returned IDs do not contain a checksum.
+func (c *ConjurBackend) List(ctx context.Context, env, team, app string) (map[string]ConjurSecretId, error) {
+	log := logr.FromContextOrDiscard(ctx)
+	scope := New(env, team, app, "", "")
+	prefix := scope.PolicyPath() + "/"
+	log.Info("Listing secrets", "policyPath", prefix)
+
+	filter := &conjurapi.ResourceFilter{
+		Kind:   "variable",
+		Search: scope.lastSegment(),
+		Limit:  ListPageSize,
+	}
+
+	ids := make(map[string]ConjurSecretId)
+	for {
+		resources, err := c.readAPI.Resources(filter)
+		if err != nil {
+			return nil, handleError(err, scope)
+		}
+		for _, resource := range resources {
+			variableId, ok := VariableIdFromResource(resource)
+			if !ok || !strings.HasPrefix(variableId, prefix) {
+				continue
+			}
+			name := strings.TrimPrefix(variableId, prefix)
+			// variables of nested policies belong to a different scope
+			if name == "" || strings.Contains(name, "/") {
+				continue
+			}
+			ids[name] = New(env, team, app, name, "")
+		}
+		if len(resources) < filter.Limit {
+			break
+		}
+		filter.Offset += filter.Limit
+	}
+
+	if len(ids) == 0 {
+		return nil, backend.ErrNotFound()
+	}
+	return ids, nil
+}
+
 func handleError(err error, id ConjurSecretId) error {
 	if backend.IsBackendError(err) {
 		return err
 	}
+	if errors.Is(err, ErrQueueFull) || errors.Is(err, ErrCircuitOpen) {
+		return backend.NewBackendError(id, err, backend.TypeErrTooManyRequests)
+	}
 	cErr, ok := AsError(err)
 	if ok {
 		if cErr.Code == 404 {
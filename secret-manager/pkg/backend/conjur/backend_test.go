@@ -4,9 +4,11 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/cyberark/conjur-api-go/conjurapi"
 	"github.com/cyberark/conjur-api-go/conjurapi/response"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
 	"github.com/telekom/controlplane-mono/secret-manager/pkg/backend"
 	"github.com/telekom/controlplane-mono/secret-manager/pkg/backend/conjur"
 	"github.com/telekom/controlplane-mono/secret-manager/test/mocks"
@@ -17,6 +19,14 @@ var ErrNotFound = &response.ConjurError{
 	Message: "Not Found",
 }
 
+func variableResource(versions ...int) map[string]interface{} {
+	secrets := make([]interface{}, 0, len(versions))
+	for _, version := range versions {
+		secrets = append(secrets, map[string]interface{}{"version": float64(version)})
+	}
+	return map[string]interface{}{"secrets": secrets}
+}
+
 var _ = Describe("Conjur Backend", func() {
 
 	var writeAPI *mocks.MockConjurAPI
@@ -148,6 +158,21 @@ var _ = Describe("Conjur Backend", func() {
 			res, err := conjurBackend.Set(ctx, secretId, secretValue)
 			Expect(err).ToNot(HaveOccurred())
 			Expect(res).ToNot(BeNil())
+			// the ID references the latest value instead of a version that expires
+			Expect(res.Id().String()).To(Equal("test:my-team:my-app:clientSecret:" + backend.MakeChecksum("my-new-value")))
+		})
+
+		It("should return the checksum of the sub-path if a nested secret is changed", func() {
+			ctx := context.Background()
+			conjurBackend := conjur.NewBackend(writeAPI, readAPI)
+
+			readAPI.EXPECT().RetrieveSecret("controlplane/test/my-team/my-app/externalSecrets").Return([]byte(`{"foo":"old"}`), nil).Times(1)
+			writeAPI.EXPECT().AddSecret("controlplane/test/my-team/my-app/externalSecrets", `{"foo":"new"}`).Return(nil).Times(1)
+
+			secretId := conjur.New("test", "my-team", "my-app", "externalSecrets/foo", "")
+			res, err := conjurBackend.Set(ctx, secretId, backend.String("new"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(res.Id().String()).To(Equal("test:my-team:my-app:externalSecrets/foo:" + backend.MakeChecksum("new")))
 		})
 
 		It("should create an initial secret if it does not exist", func() {
@@ -186,17 +211,185 @@ var _ = Describe("Conjur Backend", func() {
 
 	})
 
+	Context("Versions", func() {
+
+		It("should list the versions, the latest first", func() {
+			ctx := context.Background()
+			conjurBackend := conjur.NewBackend(writeAPI, readAPI).(*conjur.ConjurBackend)
+			conjurBackend.MaxVersions = 2
+
+			readAPI.EXPECT().Resource("variable:controlplane/test/my-team/my-app/externalSecrets").Return(variableResource(1, 2, 3), nil).Times(1)
+
+			secretId := conjur.New("test", "my-team", "my-app", "externalSecrets/foo", "")
+			ids, err := conjurBackend.Versions(ctx, secretId)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ids).To(HaveLen(2))
+			Expect(ids[0].String()).To(Equal("test:my-team:my-app:externalSecrets/foo:v3"))
+			Expect(ids[1].String()).To(Equal("test:my-team:my-app:externalSecrets/foo:v2"))
+		})
+
+		It("should return not-found if the variable does not exist", func() {
+			ctx := context.Background()
+			conjurBackend := conjur.NewBackend(writeAPI, readAPI)
+
+			readAPI.EXPECT().Resource("variable:controlplane/test/my-team/my-app/clientSecret").Return(nil, ErrNotFound).Times(1)
+
+			_, err := conjurBackend.Versions(ctx, conjur.New("test", "my-team", "my-app", "clientSecret", ""))
+			Expect(backend.IsNotFoundErr(err)).To(BeTrue())
+		})
+
+		It("should get a specific version", func() {
+			ctx := context.Background()
+			conjurBackend := conjur.NewBackend(writeAPI, readAPI)
+
+			readAPI.EXPECT().Resource("variable:controlplane/test/my-team/my-app/externalSecrets").Return(variableResource(1, 2), nil).Times(1)
+			readAPI.EXPECT().RetrieveSecretWithVersion("controlplane/test/my-team/my-app/externalSecrets", 1).Return([]byte(`{"foo": "old"}`), nil).Times(1)
+
+			secretId := conjur.New("test", "my-team", "my-app", "externalSecrets/foo", "v1")
+			res, err := conjurBackend.Get(ctx, secretId)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(res.Value()).To(Equal("old"))
+			Expect(res.Id().String()).To(Equal("test:my-team:my-app:externalSecrets/foo:v1"))
+		})
+
+		It("should not get a version that is no longer kept", func() {
+			ctx := context.Background()
+			conjurBackend := conjur.NewBackend(writeAPI, readAPI).(*conjur.ConjurBackend)
+			conjurBackend.MaxVersions = 1
+
+			readAPI.EXPECT().Resource("variable:controlplane/test/my-team/my-app/clientSecret").Return(variableResource(1, 2), nil).Times(1)
+
+			_, err := conjurBackend.Get(ctx, conjur.New("test", "my-team", "my-app", "clientSecret", "v1"))
+			Expect(backend.IsVersionExpiredErr(err)).To(BeTrue())
+		})
+	})
+
 	Context("Delete", func() {
 
 		It("should delete a secret", func() {
 			ctx := context.Background()
 			conjurBackend := conjur.NewBackend(writeAPI, readAPI)
 
+			readAPI.EXPECT().RetrieveSecret("controlplane/test/my-team/my-app/clientSecret").Return([]byte("my-value"), nil).Times(1)
 			writeAPI.EXPECT().AddSecret("controlplane/test/my-team/my-app/clientSecret", "").Return(nil).Times(1)
 
 			secretId := conjur.New("test", "my-team", "my-app", "clientSecret", "checksum")
 			err := conjurBackend.Delete(ctx, secretId)
 			Expect(err).ToNot(HaveOccurred())
 		})
+
+		It("should only delete the sub-path of a secret", func() {
+			ctx := context.Background()
+			conjurBackend := conjur.NewBackend(writeAPI, readAPI)
+
+			readAPI.EXPECT().RetrieveSecret("controlplane/test/my-team/my-app/externalSecrets").Return([]byte(`{"foo":"bar","baz":"qux"}`), nil).Times(1)
+			writeAPI.EXPECT().AddSecret("controlplane/test/my-team/my-app/externalSecrets", `{"baz":"qux"}`).Return(nil).Times(1)
+
+			secretId := conjur.New("test", "my-team", "my-app", "externalSecrets/foo", "")
+			err := conjurBackend.Delete(ctx, secretId)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should return not found if the secret has already been deleted", func() {
+			ctx := context.Background()
+			conjurBackend := conjur.NewBackend(writeAPI, readAPI)
+
+			readAPI.EXPECT().RetrieveSecret("controlplane/test/my-team/my-app/clientSecret").Return([]byte(""), nil).Times(1)
+
+			secretId := conjur.New("test", "my-team", "my-app", "clientSecret", "")
+			err := conjurBackend.Delete(ctx, secretId)
+			Expect(backend.IsNotFoundErr(err)).To(BeTrue())
+		})
+
+		It("should return not found if the sub-path does not exist", func() {
+			ctx := context.Background()
+			conjurBackend := conjur.NewBackend(writeAPI, readAPI)
+
+			readAPI.EXPECT().RetrieveSecret("controlplane/test/my-team/my-app/externalSecrets").Return([]byte(`{"baz":"qux"}`), nil).Times(1)
+
+			secretId := conjur.New("test", "my-team", "my-app", "externalSecrets/foo", "")
+			err := conjurBackend.Delete(ctx, secretId)
+			Expect(backend.IsNotFoundErr(err)).To(BeTrue())
+		})
+
+		It("should return not found when getting a deleted secret", func() {
+			ctx := context.Background()
+			conjurBackend := conjur.NewBackend(writeAPI, readAPI)
+
+			readAPI.EXPECT().RetrieveSecret("controlplane/test/my-team/my-app/clientSecret").Return([]byte(""), nil).Times(1)
+
+			secretId := conjur.New("test", "my-team", "my-app", "clientSecret", "")
+			_, err := conjurBackend.Get(ctx, secretId)
+			Expect(backend.IsNotFoundErr(err)).To(BeTrue())
+		})
+	})
+
+	Context("List", func() {
+
+		It("should list the variables of an application", func() {
+			ctx := context.Background()
+			conjurBackend := conjur.NewBackend(writeAPI, readAPI)
+
+			readAPI.EXPECT().Resources(mock.MatchedBy(func(filter *conjurapi.ResourceFilter) bool {
+				return filter.Kind == "variable" && filter.Search == "my-app" && filter.Offset == 0
+			})).Return([]map[string]interface{}{
+				{"id": "account:variable:controlplane/test/my-team/my-app/clientSecret"},
+				{"id": "account:variable:controlplane/test/my-team/my-app/externalSecrets"},
+				{"id": "account:variable:controlplane/test/my-team/my-app-2/clientSecret"},
+				{"id": "account:policy:controlplane/test/my-team/my-app"},
+			}, nil).Times(1)
+
+			ids, err := conjurBackend.List(ctx, "test", "my-team", "my-app")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ids).To(HaveLen(2))
+			Expect(ids["clientSecret"].String()).To(Equal("test:my-team:my-app:clientSecret:"))
+			Expect(ids["externalSecrets"].VariableId()).To(Equal("controlplane/test/my-team/my-app/externalSecrets"))
+		})
+
+		It("should not list the variables of nested policies", func() {
+			ctx := context.Background()
+			conjurBackend := conjur.NewBackend(writeAPI, readAPI)
+
+			readAPI.EXPECT().Resources(mock.Anything).Return([]map[string]interface{}{
+				{"id": "account:variable:controlplane/test/my-team/clientSecret"},
+				{"id": "account:variable:controlplane/test/my-team/my-app/clientSecret"},
+			}, nil).Times(1)
+
+			ids, err := conjurBackend.List(ctx, "test", "my-team", "")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ids).To(HaveLen(1))
+			Expect(ids).To(HaveKey("clientSecret"))
+		})
+
+		It("should request all pages", func() {
+			ctx := context.Background()
+			conjurBackend := conjur.NewBackend(writeAPI, readAPI)
+			defer func(size int) { conjur.ListPageSize = size }(conjur.ListPageSize)
+			conjur.ListPageSize = 1
+
+			readAPI.EXPECT().Resources(mock.MatchedBy(func(filter *conjurapi.ResourceFilter) bool {
+				return filter.Offset == 0
+			})).Return([]map[string]interface{}{
+				{"id": "account:variable:controlplane/test/zones"},
+			}, nil).Times(1)
+			readAPI.EXPECT().Resources(mock.MatchedBy(func(filter *conjurapi.ResourceFilter) bool {
+				return filter.Offset == 1
+			})).Return([]map[string]interface{}{}, nil).Times(1)
+
+			ids, err := conjurBackend.List(ctx, "test", "", "")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ids).To(HaveKey("zones"))
+		})
+
+		It("should return an error if the scope does not exist", func() {
+			ctx := context.Background()
+			conjurBackend := conjur.NewBackend(writeAPI, readAPI)
+
+			readAPI.EXPECT().Resources(mock.Anything).Return([]map[string]interface{}{}, nil).Times(1)
+
+			_, err := conjurBackend.List(ctx, "test", "my-team", "my-app")
+			Expect(err).To(HaveOccurred())
+			Expect(backend.IsNotFoundErr(err)).To(BeTrue())
+		})
 	})
 })
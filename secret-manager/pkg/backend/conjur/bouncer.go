@@ -2,6 +2,7 @@ package conjur
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
 	"time"
@@ -29,55 +30,216 @@ var (
 		[]string{"queue", "status"},
 	)
 
-	ErrQueueFull = fmt.Errorf("queue is full")
+	inFlight = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "bouncer_runnable_in_flight",
+			Help: "Number of runnables that are currently running",
+		},
+		[]string{"queue"},
+	)
+
+	concurrencyLimit = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "bouncer_concurrency_limit",
+			Help: "Current limit of runnables that may run concurrently",
+		},
+		[]string{"queue"},
+	)
+
+	rejected = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "bouncer_runnable_rejected_total",
+			Help: "Number of runnables that have been rejected",
+		},
+		[]string{"queue", "priority", "reason"},
+	)
+
+	circuitState = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "bouncer_circuit_state",
+			Help: "State of the circuit breaker (0 = closed, 1 = open, 2 = half-open)",
+		},
+		[]string{"queue"},
+	)
+
+	ErrQueueFull   = fmt.Errorf("queue is full")
+	ErrCircuitOpen = fmt.Errorf("circuit breaker is open")
 )
 
+// DefaultMaxConcurrency is the number of runnables that may run concurrently if not configured otherwise
+const DefaultMaxConcurrency = 10
+
 const (
 	defaultQueueSize        = 10
 	defaultBlockingDuration = 2 * time.Second
+	defaultLatencyTarget    = time.Second
+	defaultFailureThreshold = 5
+	defaultOpenDuration     = 30 * time.Second
 )
 
 func RegisterMetrics(reg prometheus.Registerer) {
 	registerOnce.Do(func() {
 		reg.MustRegister(queueLength)
 		reg.MustRegister(timeInQueue)
+		reg.MustRegister(inFlight)
+		reg.MustRegister(concurrencyLimit)
+		reg.MustRegister(rejected)
+		reg.MustRegister(circuitState)
 	})
 }
 
-type contextKey string
+// Priority is the lane of a runnable. Runnables of a higher priority are always started first.
+type Priority int
 
-const startKey contextKey = "start"
+const (
+	// PriorityHigh is used for reads
+	PriorityHigh Priority = iota
+	// PriorityNormal is used for writes
+	PriorityNormal
+	// PriorityLow is used for policy loads
+	PriorityLow
+
+	priorityCount = 3
+)
+
+func (p Priority) String() string {
+	switch p {
+	case PriorityHigh:
+		return "high"
+	case PriorityNormal:
+		return "normal"
+	case PriorityLow:
+		return "low"
+	default:
+		return "unknown"
+	}
+}
 
 type Runnable func(ctx context.Context) error
 
 type Bouncer interface {
+	// Run enqueues the runnable with PriorityNormal
 	Run(ctx context.Context, runnable Runnable) <-chan error
+	RunWithPriority(ctx context.Context, priority Priority, runnable Runnable) <-chan error
+	// StartN starts to run the enqueued runnables with at most workerCount of them running concurrently
 	StartN(ctx context.Context, workerCount int)
 }
 
+type BouncerOptions struct {
+	// QueueSize is the size of the queue of each priority
+	QueueSize int
+	// MaxBlockingDuration is the time a runnable may wait for a free slot in the queue
+	// before it is rejected with ErrQueueFull
+	MaxBlockingDuration time.Duration
+	// MinConcurrency is the lower bound of the adaptive concurrency limit
+	MinConcurrency int
+	// LatencyTarget is the latency above which the concurrency limit is decreased.
+	// If it is zero, the concurrency limit is not adapted.
+	LatencyTarget time.Duration
+	// LaneConcurrency limits the runnables of a priority that may run concurrently. Zero means no limit.
+	LaneConcurrency [priorityCount]int
+	// FailureThreshold is the number of consecutive failures after which the circuit breaker opens.
+	// If it is zero, the circuit breaker is disabled.
+	FailureThreshold int
+	// OpenDuration is the time the circuit breaker stays open before it lets a single runnable through
+	OpenDuration time.Duration
+	// IsFailure decides if the error of a runnable counts as failure for the circuit breaker
+	// and the concurrency limit. Defaults to IsUnavailableErr.
+	IsFailure func(error) bool
+}
+
+// DefaultBouncerOptions serializes policy loads, adapts the concurrency to a latency of 1s
+// and opens the circuit breaker after 5 consecutive failures for 30s.
+func DefaultBouncerOptions() BouncerOptions {
+	return BouncerOptions{
+		QueueSize:           defaultQueueSize,
+		MaxBlockingDuration: defaultBlockingDuration,
+		MinConcurrency:      1,
+		LatencyTarget:       defaultLatencyTarget,
+		LaneConcurrency:     [priorityCount]int{PriorityLow: 1},
+		FailureThreshold:    defaultFailureThreshold,
+		OpenDuration:        defaultOpenDuration,
+	}
+}
+
+type task struct {
+	ctx      context.Context
+	priority Priority
+	runnable Runnable
+	done     chan error
+	enqueued time.Time
+}
+
 type bouncer struct {
 	Name                string
-	RunnableQueue       chan Runnable
 	MaxBlockingDuration time.Duration
+
+	lanes       [priorityCount]chan *task
+	laneLimits  [priorityCount]int
+	laneRunning [priorityCount]int
+	// released is signaled whenever a runnable has finished
+	released  chan struct{}
+	limiter   *limiter
+	breaker   *breaker
+	isFailure func(error) bool
+
+	mutex     sync.Mutex
+	startOnce sync.Once
 }
 
+// NewBouncer creates a bouncer without adaptive concurrency and circuit breaker
 func NewBouncer(queueSize int, blockingDur time.Duration) Bouncer {
-	RegisterMetrics(prometheus.DefaultRegisterer)
-	return &bouncer{
-		Name:                "default",
-		RunnableQueue:       make(chan Runnable, queueSize),
+	return NewBouncerWithOptions(BouncerOptions{
+		QueueSize:           queueSize,
 		MaxBlockingDuration: blockingDur,
-	}
+	})
 }
 
 func NewDefaultBouncer() Bouncer {
-	return NewBouncer(defaultQueueSize, defaultBlockingDuration)
+	return NewBouncerWithOptions(DefaultBouncerOptions())
+}
+
+func NewBouncerWithOptions(opts BouncerOptions) Bouncer {
+	RegisterMetrics(prometheus.DefaultRegisterer)
+	b := &bouncer{
+		Name:                "default",
+		MaxBlockingDuration: opts.MaxBlockingDuration,
+		laneLimits:          opts.LaneConcurrency,
+		released:            make(chan struct{}, 1),
+		limiter:             newLimiter(opts.MinConcurrency, opts.LatencyTarget),
+		breaker:             newBreaker(opts.FailureThreshold, opts.OpenDuration),
+		isFailure:           opts.IsFailure,
+	}
+	if b.isFailure == nil {
+		b.isFailure = IsUnavailableErr
+	}
+	for i := range b.lanes {
+		b.lanes[i] = make(chan *task, opts.QueueSize)
+	}
+	return b
 }
 
 func (b *bouncer) Run(ctx context.Context, runnable Runnable) <-chan error {
+	return b.RunWithPriority(ctx, PriorityNormal, runnable)
+}
+
+func (b *bouncer) RunWithPriority(ctx context.Context, priority Priority, runnable Runnable) <-chan error {
 	log := logr.FromContextOrDiscard(ctx)
 
 	done := make(chan error, 1)
+	if priority < 0 || priority >= priorityCount {
+		priority = PriorityNormal
+	}
+
+	allowed, halfOpen := b.breaker.allow()
+	if halfOpen {
+		log.Info("Circuit breaker lets a single runnable through", "queue", b.Name)
+		circuitState.WithLabelValues(b.Name).Set(float64(stateHalfOpen))
+	}
+	if !allowed {
+		b.reject(done, priority, ErrCircuitOpen)
+		return done
+	}
 
 	// timedCtx is used to set a timeout for enqueuing the runnable
 	var timedCtx context.Context
@@ -89,65 +251,330 @@ func (b *bouncer) Run(ctx context.Context, runnable Runnable) <-chan error {
 		timedCtx = ctx
 	}
 
-	ctx = context.WithValue(ctx, startKey, time.Now())
-
-	wrappedRunnable := func(_ context.Context) error {
-		start := ctx.Value(startKey).(time.Time)
-		duration := time.Since(start).Seconds()
-		timeInQueue.WithLabelValues(b.Name, "waiting").Observe(duration)
-		err := runnable(ctx)
-		if err != nil {
-			log.V(1).Info("runnable failed", "error", err)
-		}
-		done <- err
-		close(done)
-
-		duration = time.Since(start).Seconds()
-		status := "success"
-		if err != nil {
-			status = "error"
-		}
-		timeInQueue.WithLabelValues(b.Name, status).Observe(duration)
-		return nil
-	}
+	t := &task{ctx: ctx, priority: priority, runnable: runnable, done: done, enqueued: time.Now()}
 	select {
 	case <-timedCtx.Done():
-		done <- ErrQueueFull
-		close(done)
+		b.reject(done, priority, ErrQueueFull)
 
-	case b.RunnableQueue <- wrappedRunnable:
-		log.V(1).Info("runnable enqueued")
+	case b.lanes[priority] <- t:
+		log.V(1).Info("runnable enqueued", "priority", priority.String())
 		queueLength.WithLabelValues(b.Name).Inc()
 	}
 	return done
 }
 
+func (b *bouncer) reject(done chan error, priority Priority, err error) {
+	reason := "queue_full"
+	if errors.Is(err, ErrCircuitOpen) {
+		reason = "circuit_open"
+	}
+	rejected.WithLabelValues(b.Name, priority.String(), reason).Inc()
+	done <- err
+	close(done)
+}
+
+// StartN starts the dispatcher which runs at most n runnables concurrently.
+// If the concurrency is adaptive, the limit is adapted between the minimum and n.
 func (b *bouncer) StartN(ctx context.Context, n int) {
-	for range n {
-		b.startWorker(ctx)
+	b.limiter.setMax(n)
+	concurrencyLimit.WithLabelValues(b.Name).Set(float64(b.limiter.current()))
+	circuitState.WithLabelValues(b.Name).Set(float64(stateClosed))
+	b.startOnce.Do(func() {
+		go b.dispatch(ctx)
+	})
+}
+
+func (b *bouncer) dispatch(ctx context.Context) {
+	for {
+		for !b.limiter.tryAcquire() {
+			select {
+			case <-b.released:
+			case <-ctx.Done():
+				return
+			}
+		}
+		t, ok := b.next(ctx)
+		if !ok {
+			return
+		}
+		go b.execute(ctx, t)
+	}
+}
+
+// next returns the enqueued runnable with the highest priority whose lane is not saturated
+func (b *bouncer) next(ctx context.Context) (*task, bool) {
+	for {
+		var lanes [priorityCount]chan *task
+		b.mutex.Lock()
+		for i := range b.lanes {
+			if b.laneLimits[i] <= 0 || b.laneRunning[i] < b.laneLimits[i] {
+				lanes[i] = b.lanes[i]
+			}
+		}
+		b.mutex.Unlock()
+
+		// Prefer the lanes in order of their priority
+		for _, lane := range lanes {
+			if lane == nil {
+				continue
+			}
+			select {
+			case t := <-lane:
+				return b.take(t), true
+			default:
+			}
+		}
+
+		// Saturated lanes are nil and therefore never selected
+		select {
+		case t := <-lanes[PriorityHigh]:
+			return b.take(t), true
+		case t := <-lanes[PriorityNormal]:
+			return b.take(t), true
+		case t := <-lanes[PriorityLow]:
+			return b.take(t), true
+		case <-b.released:
+			// A lane might not be saturated anymore
+		case <-ctx.Done():
+			return nil, false
+		}
 	}
 }
 
-func (b *bouncer) startWorker(ctx context.Context) {
-	go func(ctx context.Context) {
-		log := logr.FromContextOrDiscard(ctx)
+func (b *bouncer) take(t *task) *task {
+	b.mutex.Lock()
+	b.laneRunning[t.priority]++
+	b.mutex.Unlock()
+	queueLength.WithLabelValues(b.Name).Dec()
+	inFlight.WithLabelValues(b.Name).Inc()
+	return t
+}
+
+func (b *bouncer) execute(ctx context.Context, t *task) {
+	log := logr.FromContextOrDiscard(t.ctx)
+	timeInQueue.WithLabelValues(b.Name, "waiting").Observe(time.Since(t.enqueued).Seconds())
+
+	start := time.Now()
+	var err error
+	skipped := false
+	func() {
 		defer func() {
 			if r := recover(); r != nil {
-				log.Error(fmt.Errorf("%v", r), "panic in bouncer worker")
+				err = fmt.Errorf("panic in runnable: %v", r)
+				logr.FromContextOrDiscard(ctx).Error(err, "panic in bouncer worker")
 			}
 		}()
-		for {
-			select {
-			case runnable := <-b.RunnableQueue:
-				queueLength.WithLabelValues(b.Name).Dec()
-				err := runnable(nil) // wrapped Runnable already has context
-				if err != nil {
-					log.Error(err, "failed to run runnable")
-				}
+		// The caller is no longer waiting for the result
+		if err = t.ctx.Err(); err != nil {
+			skipped = true
+			return
+		}
+		err = t.runnable(t.ctx)
+	}()
+	latency := time.Since(start)
 
-			case <-ctx.Done():
-				return
-			}
+	if err != nil {
+		log.V(1).Info("runnable failed", "error", err)
+	}
+	if !skipped {
+		failed := err != nil && b.isFailure(err)
+		if b.limiter.observe(latency, failed) {
+			concurrencyLimit.WithLabelValues(b.Name).Set(float64(b.limiter.current()))
+		}
+		if state, changed := b.breaker.record(failed); changed {
+			logr.FromContextOrDiscard(ctx).Info("Circuit breaker changed its state", "queue", b.Name, "state", state.String())
+			circuitState.WithLabelValues(b.Name).Set(float64(state))
+		}
+	}
+
+	b.mutex.Lock()
+	b.laneRunning[t.priority]--
+	b.mutex.Unlock()
+	b.limiter.release()
+	inFlight.WithLabelValues(b.Name).Dec()
+	select {
+	case b.released <- struct{}{}:
+	default:
+	}
+
+	t.done <- err
+	close(t.done)
+
+	status := "success"
+	if err != nil {
+		status = "error"
+	}
+	timeInQueue.WithLabelValues(b.Name, status).Observe(time.Since(t.enqueued).Seconds())
+}
+
+// limiter adapts the number of concurrent runnables to the observed latency.
+// The limit is increased by one after a window of fast runnables
+// and decreased by a quarter after a window with a slow or failed runnable.
+type limiter struct {
+	mutex     sync.Mutex
+	limit     int
+	min       int
+	max       int
+	running   int
+	target    time.Duration
+	completed int
+	overload  bool
+}
+
+func newLimiter(min int, target time.Duration) *limiter {
+	return &limiter{min: max(min, 1), max: max(min, 1), limit: max(min, 1), target: target}
+}
+
+func (l *limiter) setMax(n int) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.max = max(n, l.min)
+	l.limit = l.max
+}
+
+func (l *limiter) current() int {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return l.limit
+}
+
+func (l *limiter) tryAcquire() bool {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if l.running >= l.limit {
+		return false
+	}
+	l.running++
+	return true
+}
+
+func (l *limiter) release() {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.running--
+}
+
+// observe records the result of a runnable and returns true if the limit has changed
+func (l *limiter) observe(latency time.Duration, failed bool) bool {
+	if l.target <= 0 {
+		return false
+	}
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.completed++
+	l.overload = l.overload || failed || latency > l.target
+	if l.completed < l.limit {
+		return false
+	}
+
+	previous := l.limit
+	if l.overload {
+		l.limit = max(l.min, l.limit-max(l.limit/4, 1))
+	} else {
+		l.limit = min(l.max, l.limit+1)
+	}
+	l.completed = 0
+	l.overload = false
+	return l.limit != previous
+}
+
+type circuitBreakerState int
+
+const (
+	stateClosed circuitBreakerState = iota
+	stateOpen
+	stateHalfOpen
+)
+
+func (s circuitBreakerState) String() string {
+	switch s {
+	case stateOpen:
+		return "open"
+	case stateHalfOpen:
+		return "half-open"
+	default:
+		return "closed"
+	}
+}
+
+// breaker opens after a number of consecutive failures and rejects all runnables.
+// After the open duration, a single runnable is let through. If it succeeds, the breaker is closed again.
+type breaker struct {
+	mutex        sync.Mutex
+	threshold    int
+	openDuration time.Duration
+	state        circuitBreakerState
+	failures     int
+	openedAt     time.Time
+	probeAt      time.Time
+	now          func() time.Time
+}
+
+func newBreaker(threshold int, openDuration time.Duration) *breaker {
+	return &breaker{threshold: threshold, openDuration: openDuration, now: time.Now}
+}
+
+// allow returns if the runnable may be run and if the breaker has changed to half-open
+func (b *breaker) allow() (allowed bool, halfOpen bool) {
+	if b.threshold <= 0 {
+		return true, false
+	}
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	now := b.now()
+	switch b.state {
+	case stateOpen:
+		if now.Sub(b.openedAt) < b.openDuration {
+			return false, false
 		}
-	}(ctx)
+		b.state = stateHalfOpen
+		b.probeAt = now
+		return true, true
+	case stateHalfOpen:
+		// The probe might never have been run, e.g. because its caller has given up
+		if now.Sub(b.probeAt) < b.openDuration {
+			return false, false
+		}
+		b.probeAt = now
+		return true, false
+	default:
+		return true, false
+	}
+}
+
+// record records the result of a runnable and returns the new state if it has changed
+func (b *breaker) record(failed bool) (circuitBreakerState, bool) {
+	if b.threshold <= 0 {
+		return stateClosed, false
+	}
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	previous := b.state
+	if !failed {
+		b.failures = 0
+		b.state = stateClosed
+		return b.state, b.state != previous
+	}
+
+	b.failures++
+	if b.state == stateHalfOpen || b.failures >= b.threshold {
+		b.state = stateOpen
+		b.openedAt = b.now()
+	}
+	return b.state, b.state != previous
+}
+
+// IsUnavailableErr returns true if the error indicates that Conjur is unavailable or overloaded.
+// Client errors like a missing variable do not count.
+func IsUnavailableErr(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+	if cErr, ok := AsError(err); ok {
+		return cErr.Code >= 500 || cErr.Code == 429
+	}
+	return true
 }
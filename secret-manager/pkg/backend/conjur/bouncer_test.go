@@ -3,8 +3,12 @@ package conjur_test
 import (
 	"context"
 	"fmt"
+	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/cyberark/conjur-api-go/conjurapi/response"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/pkg/errors"
@@ -76,4 +80,138 @@ var _ = Describe("Bouncer Test", func() {
 			Expect(result).To(Equal("initial"))
 		})
 	})
+
+	Context("Priorities", func() {
+		It("should run the runnables with a higher priority first", func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+			defer cancel()
+
+			bouncer := conjur.NewBouncer(5, time.Second)
+			bouncer.StartN(ctx, 1)
+
+			// Occupy the only slot until all runnables are enqueued
+			started := make(chan struct{})
+			release := make(chan struct{})
+			blocker := bouncer.Run(ctx, func(ctx context.Context) error {
+				close(started)
+				<-release
+				return nil
+			})
+			<-started
+
+			mutex := sync.Mutex{}
+			order := []string{}
+			record := func(name string) conjur.Runnable {
+				return func(ctx context.Context) error {
+					mutex.Lock()
+					defer mutex.Unlock()
+					order = append(order, name)
+					return nil
+				}
+			}
+			low := bouncer.RunWithPriority(ctx, conjur.PriorityLow, record("low"))
+			normal := bouncer.RunWithPriority(ctx, conjur.PriorityNormal, record("normal"))
+			high := bouncer.RunWithPriority(ctx, conjur.PriorityHigh, record("high"))
+
+			close(release)
+			Expect(<-blocker).ToNot(HaveOccurred())
+			Expect(<-low).ToNot(HaveOccurred())
+			Expect(<-normal).ToNot(HaveOccurred())
+			Expect(<-high).ToNot(HaveOccurred())
+			Expect(order).To(Equal([]string{"high", "normal", "low"}))
+		})
+
+		It("should limit the concurrency of a lane", func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+			defer cancel()
+
+			opts := conjur.DefaultBouncerOptions()
+			opts.LatencyTarget = 0
+			bouncer := conjur.NewBouncerWithOptions(opts)
+			bouncer.StartN(ctx, 4)
+
+			var running, maxRunning atomic.Int32
+			policyLoad := func(ctx context.Context) error {
+				current := running.Add(1)
+				defer running.Add(-1)
+				for {
+					previous := maxRunning.Load()
+					if current <= previous || maxRunning.CompareAndSwap(previous, current) {
+						break
+					}
+				}
+				time.Sleep(50 * time.Millisecond)
+				return nil
+			}
+
+			results := []<-chan error{}
+			for range 3 {
+				results = append(results, bouncer.RunWithPriority(ctx, conjur.PriorityLow, policyLoad))
+			}
+			// Reads are not blocked by the policy loads
+			var runningDuringRead int32
+			read := bouncer.RunWithPriority(ctx, conjur.PriorityHigh, func(ctx context.Context) error {
+				runningDuringRead = running.Load()
+				return nil
+			})
+
+			Expect(<-read).ToNot(HaveOccurred())
+			Expect(runningDuringRead).To(BeNumerically("<=", 1))
+			for _, result := range results {
+				Expect(<-result).ToNot(HaveOccurred())
+			}
+			Expect(maxRunning.Load()).To(BeEquivalentTo(1))
+		})
+	})
+
+	Context("Circuit Breaker", func() {
+		var ctx context.Context
+		var bouncer conjur.Bouncer
+
+		BeforeEach(func() {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(context.Background(), 2*time.Second)
+			DeferCleanup(cancel)
+
+			opts := conjur.DefaultBouncerOptions()
+			opts.FailureThreshold = 2
+			opts.OpenDuration = 200 * time.Millisecond
+			bouncer = conjur.NewBouncerWithOptions(opts)
+			bouncer.StartN(ctx, 1)
+		})
+
+		It("should open after consecutive failures and reject runnables", func() {
+			unavailable := func(ctx context.Context) error {
+				return &response.ConjurError{Code: 503, Message: "unavailable"}
+			}
+			Expect(<-bouncer.Run(ctx, unavailable)).To(HaveOccurred())
+			Expect(<-bouncer.Run(ctx, unavailable)).To(HaveOccurred())
+
+			called := false
+			err := <-bouncer.RunWithPriority(ctx, conjur.PriorityHigh, func(ctx context.Context) error {
+				called = true
+				return nil
+			})
+			Expect(errors.Is(err, conjur.ErrCircuitOpen)).To(BeTrue())
+			Expect(called).To(BeFalse())
+
+			By("letting a single runnable through after the open duration")
+			time.Sleep(250 * time.Millisecond)
+			Expect(<-bouncer.Run(ctx, func(ctx context.Context) error { return nil })).ToNot(HaveOccurred())
+
+			By("closing again after the runnable succeeded")
+			Expect(<-bouncer.Run(ctx, func(ctx context.Context) error { return nil })).ToNot(HaveOccurred())
+		})
+
+		It("should not count client errors as failures", func() {
+			notFound := func(ctx context.Context) error {
+				return &response.ConjurError{Code: 404, Message: "not found"}
+			}
+			for range 3 {
+				err := <-bouncer.Run(ctx, notFound)
+				Expect(err).To(HaveOccurred())
+				Expect(errors.Is(err, conjur.ErrCircuitOpen)).To(BeFalse())
+			}
+		})
+	})
 })
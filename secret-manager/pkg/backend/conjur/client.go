@@ -1,6 +1,7 @@
 package conjur
 
 import (
+	"context"
 	"io"
 	"os"
 
@@ -16,6 +17,8 @@ type ConjurAPI interface {
 	AddSecret(variableID, value string) error
 	RetrieveBatchSecrets(variableIDs []string) (map[string][]byte, error)
 	RetrieveSecretWithVersion(variableID string, version int) ([]byte, error)
+	Resources(filter *conjurapi.ResourceFilter) ([]map[string]interface{}, error)
+	Resource(resourceID string) (map[string]interface{}, error)
 }
 
 func NewReadOnlyApiOrDie() ConjurAPI {
@@ -67,3 +70,79 @@ func NewApi(readyOnly bool) (ConjurAPI, error) {
 
 	return conjur, nil
 }
+
+var _ ConjurAPI = &bouncedAPI{}
+
+// bouncedAPI runs all calls using the bouncer.
+// Reads are run with PriorityHigh, writes with PriorityNormal and policy loads with PriorityLow.
+type bouncedAPI struct {
+	api     ConjurAPI
+	bouncer Bouncer
+}
+
+// NewBouncedApi returns an API that runs all calls of the passed API using the bouncer.
+// Rejected calls return ErrQueueFull or ErrCircuitOpen.
+func NewBouncedApi(api ConjurAPI, bouncer Bouncer) ConjurAPI {
+	return &bouncedAPI{api: api, bouncer: bouncer}
+}
+
+func (b *bouncedAPI) LoadPolicy(mode conjurapi.PolicyMode, path string, reader io.Reader) (res *conjurapi.PolicyResponse, err error) {
+	err = b.run(PriorityLow, func() error {
+		res, err = b.api.LoadPolicy(mode, path, reader)
+		return err
+	})
+	return res, err
+}
+
+func (b *bouncedAPI) RetrieveSecret(variableID string) (res []byte, err error) {
+	err = b.run(PriorityHigh, func() error {
+		res, err = b.api.RetrieveSecret(variableID)
+		return err
+	})
+	return res, err
+}
+
+func (b *bouncedAPI) AddSecret(variableID, value string) error {
+	return b.run(PriorityNormal, func() error {
+		return b.api.AddSecret(variableID, value)
+	})
+}
+
+func (b *bouncedAPI) RetrieveBatchSecrets(variableIDs []string) (res map[string][]byte, err error) {
+	err = b.run(PriorityHigh, func() error {
+		res, err = b.api.RetrieveBatchSecrets(variableIDs)
+		return err
+	})
+	return res, err
+}
+
+func (b *bouncedAPI) RetrieveSecretWithVersion(variableID string, version int) (res []byte, err error) {
+	err = b.run(PriorityHigh, func() error {
+		res, err = b.api.RetrieveSecretWithVersion(variableID, version)
+		return err
+	})
+	return res, err
+}
+
+func (b *bouncedAPI) Resources(filter *conjurapi.ResourceFilter) (res []map[string]interface{}, err error) {
+	err = b.run(PriorityHigh, func() error {
+		res, err = b.api.Resources(filter)
+		return err
+	})
+	return res, err
+}
+
+func (b *bouncedAPI) Resource(resourceID string) (res map[string]interface{}, err error) {
+	err = b.run(PriorityHigh, func() error {
+		res, err = b.api.Resource(resourceID)
+		return err
+	})
+	return res, err
+}
+
+// run waits for the result of the call. The API of Conjur does not support contexts.
+func (b *bouncedAPI) run(priority Priority, call func() error) error {
+	return <-b.bouncer.RunWithPriority(context.Background(), priority, func(_ context.Context) error {
+		return call()
+	})
+}
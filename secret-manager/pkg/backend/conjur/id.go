@@ -45,28 +45,19 @@ func New(env, team, app, path string, checksum string) ConjurSecretId {
 }
 
 func FromString(raw string) (id ConjurSecretId, err error) {
-	parts := strings.Split(raw, backend.Separator)
-	if len(parts) != 5 {
-		return id, backend.ErrInvalidSecretId(raw)
+	parsed, err := backend.ParseId(raw)
+	if err != nil {
+		return id, err
 	}
 
-	id = ConjurSecretId{
+	return ConjurSecretId{
 		Raw:      raw,
-		env:      parts[0],
-		team:     parts[1],
-		app:      parts[2],
-		path:     parts[3],
-		checksum: parts[4],
-	}
-
-	if id.env == "" {
-		return id, backend.ErrInvalidSecretId(raw)
-	}
-	if id.app != "" && id.team == "" {
-		return id, backend.ErrInvalidSecretId(raw)
-	}
-
-	return id, nil
+		env:      parsed.Env,
+		team:     parsed.Team,
+		app:      parsed.App,
+		path:     parsed.Path,
+		checksum: parsed.Checksum,
+	}, nil
 }
 
 func (c ConjurSecretId) Env() string {
@@ -89,6 +80,38 @@ func (c ConjurSecretId) VariableId() string {
 	return clean(str)
 }
 
+// PolicyPath returns the path of the policy that contains the variables of this scope
+func (c ConjurSecretId) PolicyPath() string {
+	if c.team == "" {
+		return clean(fmt.Sprintf("%s/%s", RootPolicyPath, c.env))
+	}
+	return strings.TrimSuffix(clean(fmt.Sprintf("%s/%s/%s/%s", RootPolicyPath, c.env, c.team, c.app)), "/")
+}
+
+func (c ConjurSecretId) lastSegment() string {
+	if c.app != "" {
+		return c.app
+	}
+	if c.team != "" {
+		return c.team
+	}
+	return c.env
+}
+
+// VariableIdFromResource extracts the variable ID from a resource returned by the Conjur API.
+// The ID of a resource has the format <account>:<kind>:<id>
+func VariableIdFromResource(resource map[string]interface{}) (string, bool) {
+	id, ok := resource["id"].(string)
+	if !ok {
+		return "", false
+	}
+	parts := strings.SplitN(id, ":", 3)
+	if len(parts) != 3 || parts[1] != "variable" {
+		return "", false
+	}
+	return parts[2], true
+}
+
 func (c ConjurSecretId) SubPath() string {
 	parts := strings.SplitN(c.path, "/", 2)
 	if len(parts) == 2 {
@@ -3,21 +3,23 @@ package conjur
 import (
 	"bytes"
 	"context"
+	"strings"
 
 	"github.com/cyberark/conjur-api-go/conjurapi"
 	"github.com/go-logr/logr"
-	"github.com/google/uuid"
 	"github.com/pkg/errors"
 	"github.com/telekom/controlplane-mono/secret-manager/pkg/backend"
 	"github.com/valyala/fasttemplate"
 )
 
 var _ backend.Onboarder = &ConjurOnboarder{}
+var _ backend.ScopeLister = &ConjurOnboarder{}
 
 type ConjurOnboarder struct {
 	conjur       ConjurAPI
 	secretWriter backend.Writer[ConjurSecretId, backend.DefaultSecret[ConjurSecretId]]
 	templates    map[string]*fasttemplate.Template
+	blueprint    backend.Blueprint
 
 	bouncer Bouncer
 }
@@ -26,15 +28,20 @@ func NewOnboarder(writeAPI ConjurAPI, secretWriter backend.Writer[ConjurSecretId
 	return &ConjurOnboarder{
 		conjur: writeAPI,
 		templates: map[string]*fasttemplate.Template{
-			"env":    fasttemplate.New(EnvironmentPolicyTemplate, startTag, endTag),
-			"team":   fasttemplate.New(TeamPolicyTemplate, startTag, endTag),
-			"app":    fasttemplate.New(ApplicationPolicyTemplate, startTag, endTag),
+			"policy": fasttemplate.New(PolicyTemplate, startTag, endTag),
 			"delete": fasttemplate.New(DeletePolicyTemplate, startTag, endTag),
 		},
+		blueprint:    backend.DefaultBlueprint(),
 		secretWriter: secretWriter,
 	}
 }
 
+// WithBlueprint sets the variables and additional policy that are created for each level
+func (c *ConjurOnboarder) WithBlueprint(blueprint backend.Blueprint) *ConjurOnboarder {
+	c.blueprint = blueprint
+	return c
+}
+
 func (c *ConjurOnboarder) WithBouncer(bouncer Bouncer) *ConjurOnboarder {
 	if bouncer == nil {
 		return c
@@ -49,9 +56,7 @@ func (c *ConjurOnboarder) OnboardEnvironment(ctx context.Context, env string) (b
 
 	policyPath := RootPolicyPath
 	buf := bytes.NewBuffer(nil)
-	_, err := c.templates["env"].Execute(buf, map[string]any{
-		"Environment": env,
-	})
+	err := c.renderPolicy(buf, env, c.blueprint.Environment, env, "", "")
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to execute template")
 	}
@@ -67,7 +72,7 @@ func (c *ConjurOnboarder) OnboardEnvironment(ctx context.Context, env string) (b
 		return nil, err
 	}
 
-	secretsIds, err := c.createSecrets(ctx, env, "", "", backend.EnvironmentSecrets...)
+	secretsIds, err := c.createSecrets(ctx, env, "", "", c.blueprint.Environment)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to create secrets for environment %s", env)
 	}
@@ -81,9 +86,7 @@ func (c *ConjurOnboarder) OnboardTeam(ctx context.Context, env, teamId string) (
 	policyPath := RootPolicyPath + "/" + env
 
 	buf := bytes.NewBuffer(nil)
-	_, err := c.templates["team"].Execute(buf, map[string]any{
-		"TeamId": teamId,
-	})
+	err := c.renderPolicy(buf, teamId, c.blueprint.Team, env, teamId, "")
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to execute template")
 	}
@@ -99,7 +102,7 @@ func (c *ConjurOnboarder) OnboardTeam(ctx context.Context, env, teamId string) (
 		return nil, err
 	}
 
-	secretsIds, err := c.createSecrets(ctx, env, teamId, "", backend.TeamSecrets...)
+	secretsIds, err := c.createSecrets(ctx, env, teamId, "", c.blueprint.Team)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to create secrets for team %s", teamId)
 	}
@@ -113,9 +116,7 @@ func (c *ConjurOnboarder) OnboardApplication(ctx context.Context, env, teamId, a
 	policyPath := RootPolicyPath + "/" + env + "/" + teamId
 
 	buf := bytes.NewBuffer(nil)
-	_, err := c.templates["app"].Execute(buf, map[string]any{
-		"AppId": appId,
-	})
+	err := c.renderPolicy(buf, appId, c.blueprint.Application, env, teamId, appId)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to execute template")
 	}
@@ -131,7 +132,7 @@ func (c *ConjurOnboarder) OnboardApplication(ctx context.Context, env, teamId, a
 		return nil, err
 	}
 
-	secretsIds, err := c.createSecrets(ctx, env, teamId, appId, backend.ApplicationSecrets...)
+	secretsIds, err := c.createSecrets(ctx, env, teamId, appId, c.blueprint.Application)
 	if err != nil {
 		return nil, errors.Wrapf(err, "failed to create secrets for application %s", appId)
 	}
@@ -168,8 +169,16 @@ func (c *ConjurOnboarder) deletePolicy(ctx context.Context, policyPath, policyKe
 	if err != nil {
 		return errors.Wrap(err, "failed to execute delete template")
 	}
-	log.Info("Deleting policy", "policyPath", policyPath, "policyKey", policyKey)
-	_, err = c.conjur.LoadPolicy(conjurapi.PolicyModePatch, policyPath, buf)
+	mutator := func(ctx context.Context) error {
+		log.Info("Deleting policy", "policyPath", policyPath, "policyKey", policyKey)
+		_, err := c.conjur.LoadPolicy(conjurapi.PolicyModePatch, policyPath, buf)
+		return err
+	}
+
+	err = c.MaybeRunWithBouncer(ctx, mutator)
+	if backend.IsBackendError(err) {
+		return err
+	}
 	if err != nil {
 		return errors.Wrap(err, "failed to load delete policy")
 	}
@@ -177,31 +186,97 @@ func (c *ConjurOnboarder) deletePolicy(ctx context.Context, policyPath, policyKe
 	return nil
 }
 
-func (c *ConjurOnboarder) createSecrets(ctx context.Context, env, teamId, appId string, secretNames ...string) (map[string]backend.SecretRef, error) {
+// renderPolicy renders the policy of the level with the variables of its blueprint
+func (c *ConjurOnboarder) renderPolicy(buf *bytes.Buffer, id string, blueprint backend.LevelBlueprint, env, teamId, appId string) error {
+	params := map[string]any{
+		"Environment": env,
+		"TeamId":      teamId,
+		"AppId":       appId,
+	}
+	_, err := c.templates["policy"].Execute(buf, map[string]any{
+		"Id":   id,
+		"Body": renderBody(blueprint, params),
+	})
+	return err
+}
+
+func (c *ConjurOnboarder) createSecrets(ctx context.Context, env, teamId, appId string, blueprint backend.LevelBlueprint) (map[string]backend.SecretRef, error) {
 	log := logr.FromContextOrDiscard(ctx)
 	secretsIds := make(map[string]backend.SecretRef)
 	if c.secretWriter == nil {
 		return secretsIds, nil
 	}
-	for _, secretName := range secretNames {
-		secretId := New(env, teamId, appId, secretName, "")
+	for _, secretBlueprint := range blueprint.Secrets {
+		secretId := New(env, teamId, appId, secretBlueprint.Name, "")
+		value, _, err := secretBlueprint.InitialValue(backend.Scope{Env: env, Team: teamId, App: appId})
+		if err != nil {
+			return nil, err
+		}
 		log.Info("Creating secret", "secretId", secretId.String())
-		secret, err := c.secretWriter.Set(ctx, secretId, backend.InitialString(uuid.NewString()))
+		secret, err := c.secretWriter.Set(ctx, secretId, backend.InitialString(value))
 		if err != nil {
 			return nil, errors.Wrapf(err, "failed to initialize secret %s", secretId.VariableId())
 		}
-		secretsIds[secretName] = secret.Id()
+		secretsIds[secretBlueprint.Name] = secret.Id()
 	}
 
 	return secretsIds, nil
 }
 
+// ListScopes returns the scopes of all variables below the RootPolicyPath.
+// The variables of the environments, teams and applications are located in the policies of the first three levels.
+// Scopes without any variable are not returned.
+func (c *ConjurOnboarder) ListScopes(ctx context.Context) ([]backend.Scope, error) {
+	log := logr.FromContextOrDiscard(ctx)
+	prefix := ""
+	if RootPolicyPath != "" {
+		prefix = RootPolicyPath + "/"
+	}
+	log.Info("Listing scopes", "policyPath", prefix)
+
+	filter := &conjurapi.ResourceFilter{
+		Kind:  "variable",
+		Limit: ListPageSize,
+	}
+	seen := make(map[backend.Scope]bool)
+	scopes := []backend.Scope{}
+	for {
+		resources, err := c.conjur.Resources(filter)
+		if err != nil {
+			return nil, handleError(err, New("", "", "", "", ""))
+		}
+		for _, resource := range resources {
+			variableId, ok := VariableIdFromResource(resource)
+			if !ok || !strings.HasPrefix(variableId, prefix) {
+				continue
+			}
+			segments := strings.Split(strings.TrimPrefix(variableId, prefix), "/")
+			// the last segment is the name of the variable
+			if len(segments) < 2 || len(segments) > 4 {
+				continue
+			}
+			segments = append(segments[:len(segments)-1], "", "")
+			scope := backend.Scope{Env: segments[0], Team: segments[1], App: segments[2]}
+			if !seen[scope] {
+				seen[scope] = true
+				scopes = append(scopes, scope)
+			}
+		}
+		if len(resources) < filter.Limit {
+			break
+		}
+		filter.Offset += filter.Limit
+	}
+	return scopes, nil
+}
+
+// MaybeRunWithBouncer runs the policy load using the bouncer with PriorityLow, so reads and writes are preferred.
 func (c *ConjurOnboarder) MaybeRunWithBouncer(ctx context.Context, runnable Runnable) error {
 	if c.bouncer == nil {
 		return runnable(ctx)
 	}
-	err := <-c.bouncer.Run(ctx, runnable)
-	if err != nil && errors.Is(err, ErrQueueFull) {
+	err := <-c.bouncer.RunWithPriority(ctx, PriorityLow, runnable)
+	if err != nil && (errors.Is(err, ErrQueueFull) || errors.Is(err, ErrCircuitOpen)) {
 		return backend.NewBackendError(nil, err, backend.TypeErrTooManyRequests)
 	}
 	return err
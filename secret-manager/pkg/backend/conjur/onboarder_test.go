@@ -168,5 +168,57 @@ var _ = Describe("Conjur Onboarder", func() {
 			Expect(err).ToNot(HaveOccurred())
 
 		})
+
+		It("should render the policy and secrets of the blueprint", func() {
+			ctx := context.Background()
+			blueprint := backend.DefaultBlueprint()
+			blueprint.Application = backend.LevelBlueprint{
+				Secrets:      []backend.SecretBlueprint{{Name: "externalSecrets", Value: "{}"}},
+				ConjurPolicy: "- !permit\n  role: !group {{Environment}}-{{TeamId}}\n  privilege: [ read ]\n  resource: !variable externalSecrets\n",
+			}
+			conjurOnboarder := conjur.NewOnboarder(writeAPI, writerBackend).WithBlueprint(blueprint)
+
+			runAndReturn := func(pm conjurapi.PolicyMode, s string, r io.Reader) (*conjurapi.PolicyResponse, error) {
+				buf, err := io.ReadAll(r)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(string(buf)).To(Equal("\n- !policy\n  id: test-app\n  body:\n  - !variable externalSecrets\n" +
+					"  - !permit\n    role: !group test-env-test-team\n    privilege: [ read ]\n    resource: !variable externalSecrets\n"))
+				return nil, nil
+			}
+			writeAPI.EXPECT().LoadPolicy(conjurapi.PolicyModePost, "controlplane/test-env/test-team", mock.Anything).RunAndReturn(runAndReturn)
+			writerBackend.EXPECT().Set(ctx, mock.Anything, backend.InitialString("{}")).Return(backend.DefaultSecret[conjur.ConjurSecretId]{}, nil).Once()
+
+			res, err := conjurOnboarder.OnboardApplication(ctx, "test-env", "test-team", "test-app")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(res.SecretRefs()).To(HaveKey("externalSecrets"))
+		})
+	})
+
+	Context("List Scopes", func() {
+
+		It("should list the scopes of the variables", func() {
+			ctx := context.Background()
+			conjurOnboarder := conjur.NewOnboarder(writeAPI, writerBackend)
+
+			writeAPI.EXPECT().Resources(mock.MatchedBy(func(filter *conjurapi.ResourceFilter) bool {
+				return filter.Kind == "variable" && filter.Offset == 0
+			})).Return([]map[string]interface{}{
+				{"id": "account:variable:controlplane/test/zones"},
+				{"id": "account:variable:controlplane/test/my-team/clientSecret"},
+				{"id": "account:variable:controlplane/test/my-team/teamToken"},
+				{"id": "account:variable:controlplane/test/my-team/my-app/clientSecret"},
+				{"id": "account:variable:controlplane/test/my-team/my-app/custom/nested"},
+				{"id": "account:variable:other/test/zones"},
+				{"id": "account:policy:controlplane/other"},
+			}, nil).Times(1)
+
+			scopes, err := conjurOnboarder.ListScopes(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(scopes).To(Equal([]backend.Scope{
+				{Env: "test"},
+				{Env: "test", Team: "my-team"},
+				{Env: "test", Team: "my-team", App: "my-app"},
+			}))
+		})
 	})
 })
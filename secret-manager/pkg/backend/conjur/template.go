@@ -1,32 +1,48 @@
 package conjur
 
-const startTag = "{{"
-const endTag = "}}"
+import (
+	"strings"
 
-const EnvironmentPolicyTemplate = `
-- !policy
-  id: {{Environment}}
-  body:
-  - !variable zones
-`
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/backend"
+	"github.com/valyala/fasttemplate"
+)
 
-const TeamPolicyTemplate = `
-- !policy
-  id: {{TeamId}}
-  body:
-  - !variable clientSecret
-  - !variable teamToken
-`
+const startTag = "{{"
+const endTag = "}}"
 
-const ApplicationPolicyTemplate = `
+// PolicyTemplate is used for the policies of environments, teams and applications.
+// The body is rendered from the blueprint of the level, see renderBody.
+const PolicyTemplate = `
 - !policy
-  id: {{AppId}}
+  id: {{Id}}
   body:
-  - !variable clientSecret
-  - !variable externalSecrets
-`
+{{Body}}`
 
 const DeletePolicyTemplate = `
 - !delete
   record: !policy {{PolicyPath}}
 `
+
+// renderBody renders the variables of the secrets and the additional policy of the blueprint
+func renderBody(blueprint backend.LevelBlueprint, params map[string]any) string {
+	body := strings.Builder{}
+	for _, name := range blueprint.Names() {
+		body.WriteString("  - !variable " + name + "\n")
+	}
+
+	if blueprint.ConjurPolicy != "" {
+		policy := fasttemplate.New(blueprint.ConjurPolicy, startTag, endTag).ExecuteString(params)
+		for _, line := range strings.Split(strings.TrimRight(policy, "\n"), "\n") {
+			if strings.TrimSpace(line) == "" {
+				body.WriteString("\n")
+				continue
+			}
+			body.WriteString("  " + line + "\n")
+		}
+	}
+
+	if body.Len() == 0 {
+		return "  []\n"
+	}
+	return body.String()
+}
@@ -7,6 +7,7 @@ var _ Secret[SecretId] = DefaultSecret[SecretId]{}
 type DefaultSecret[T SecretId] struct {
 	id    T
 	value string
+	stale bool
 }
 
 func NewDefaultSecret[T SecretId](id T, value string) DefaultSecret[T] {
@@ -21,6 +22,31 @@ func (d DefaultSecret[T]) Id() T {
 	return d.id
 }
 
+// Stale is true if the secret has been served from a cache because the backend is unavailable
+func (d DefaultSecret[T]) Stale() bool {
+	return d.stale
+}
+
+// AsStale returns a copy of the secret that is marked as stale
+func (d DefaultSecret[T]) AsStale() DefaultSecret[T] {
+	d.stale = true
+	return d
+}
+
+// IsStale returns true if the secret supports being marked as stale and is marked
+func IsStale(secret any) bool {
+	s, ok := secret.(interface{ Stale() bool })
+	return ok && s.Stale()
+}
+
+// MarkStale marks the secret as stale if it supports it, see DefaultSecret.AsStale
+func MarkStale[T SecretId, S Secret[T]](secret S) S {
+	if s, ok := any(secret).(interface{ AsStale() S }); ok {
+		return s.AsStale()
+	}
+	return secret
+}
+
 var _ OnboardResponse = DefaultOnboardResponse{}
 
 type DefaultOnboardResponse struct {
@@ -0,0 +1,330 @@
+package encrypt
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/backend"
+	"github.com/tidwall/gjson"
+	"github.com/tidwall/sjson"
+)
+
+var _ backend.Backend[backend.SecretId, backend.DefaultSecret[backend.SecretId]] = (*EncryptedBackend[backend.SecretId, backend.Secret[backend.SecretId]])(nil)
+
+// EncryptedBackend encrypts the values before they are written to the wrapped backend
+// and decrypts them after they are read.
+// Each value is encrypted as a whole and bound to its secret, so the wrapped backend only stores opaque values.
+// Nested secrets like `externalSecrets/foo` are therefore resolved here: the whole value is read and decrypted,
+// and written back after the nested secret has been changed.
+// Empty values and values that are not encrypted yet are returned as they are.
+type EncryptedBackend[T backend.SecretId, S backend.Secret[T]] struct {
+	Backend   backend.Backend[T, S]
+	Encrypter Encrypter
+}
+
+// Rewriter is implemented by backends that can change the stored values of a secret in place, including its versions.
+// It is used to re-wrap the versions, which can not be written using the Backend interface.
+type Rewriter[T backend.SecretId] interface {
+	// Rewrite applies the function to the stored value and to the kept versions of the secret.
+	// It returns true if any of them has been changed.
+	Rewrite(ctx context.Context, id T, fn func(value string) (string, error)) (bool, error)
+}
+
+func NewEncryptedBackend[T backend.SecretId, S backend.Secret[T]](b backend.Backend[T, S], e Encrypter) *EncryptedBackend[T, S] {
+	return &EncryptedBackend[T, S]{
+		Backend:   b,
+		Encrypter: e,
+	}
+}
+
+// AdditionalData returns the data that binds the encrypted value to the secret that stores it.
+// The checksum is not part of it, so all versions of the secret can be decrypted.
+func AdditionalData(scope backend.Scope, key string) string {
+	return scope.String() + backend.Separator + key
+}
+
+func (e *EncryptedBackend[T, S]) ParseSecretId(raw string) (T, error) {
+	return e.Backend.ParseSecretId(raw)
+}
+
+func (e *EncryptedBackend[T, S]) Get(ctx context.Context, id T) (res backend.DefaultSecret[T], err error) {
+	stored, err := e.storedSecretOf(id)
+	if err != nil {
+		return res, err
+	}
+	secret, err := e.Backend.Get(ctx, stored.id)
+	if err != nil {
+		return res, err
+	}
+	value, err := e.decrypt(secret.Value(), stored.additionalData)
+	if err != nil {
+		return res, backend.NewBackendError(id, err, "InternalError")
+	}
+	if stored.subPath == "" {
+		return backend.NewDefaultSecret(secret.Id(), value), nil
+	}
+
+	result := gjson.Get(value, stored.subPath)
+	if !result.Exists() {
+		return res, backend.ErrSecretNotFound(id)
+	}
+	nestedId, err := e.nestedIdOf(id, secret.Id())
+	if err != nil {
+		return res, err
+	}
+	return backend.NewDefaultSecret(nestedId, result.String()), nil
+}
+
+func (e *EncryptedBackend[T, S]) Set(ctx context.Context, id T, value backend.SecretValue) (res backend.DefaultSecret[T], err error) {
+	stored, err := e.storedSecretOf(id)
+	if err != nil {
+		return res, err
+	}
+
+	// The wrapped backend can only compare the encrypted values,
+	// so the checks of the value must be done here
+	plaintext, found, err := e.getPlaintext(ctx, id, stored)
+	if err != nil {
+		return res, err
+	}
+	current := plaintext
+	if stored.subPath != "" {
+		if !found {
+			return res, backend.ErrSecretNotFound(id)
+		}
+		current = gjson.Get(plaintext, stored.subPath).String()
+	}
+	if found {
+		if current != "" && !value.AllowChange() {
+			return backend.NewDefaultSecret(id, current), nil
+		}
+		if value.EqualString(current) {
+			return backend.NewDefaultSecret(id, current), nil
+		}
+	}
+
+	next := value.Value()
+	if stored.subPath != "" {
+		if next, err = sjson.Set(plaintext, stored.subPath, value.Value()); err != nil {
+			return res, backend.NewBackendError(id, err, "InternalError")
+		}
+	}
+	secret, err := e.setPlaintext(ctx, id, stored, next)
+	if err != nil {
+		return res, err
+	}
+	newId, err := e.nestedIdOf(id, secret.Id())
+	if err != nil {
+		return res, err
+	}
+	return backend.NewDefaultSecret(newId, ""), nil
+}
+
+// Delete deletes the secret. Nested secrets are removed from the value of their secret, which is written again.
+func (e *EncryptedBackend[T, S]) Delete(ctx context.Context, id T) error {
+	stored, err := e.storedSecretOf(id)
+	if err != nil {
+		return err
+	}
+	if stored.subPath == "" {
+		return e.Backend.Delete(ctx, id)
+	}
+
+	plaintext, found, err := e.getPlaintext(ctx, id, stored)
+	if err != nil {
+		return err
+	}
+	if !found || !gjson.Get(plaintext, stored.subPath).Exists() {
+		return backend.ErrSecretNotFound(id)
+	}
+	next, err := sjson.Delete(plaintext, stored.subPath)
+	if err != nil {
+		return backend.NewBackendError(id, err, "InternalError")
+	}
+	_, err = e.setPlaintext(ctx, id, stored, next)
+	return err
+}
+
+func (e *EncryptedBackend[T, S]) List(ctx context.Context, env, team, app string) (map[string]T, error) {
+	return e.Backend.List(ctx, env, team, app)
+}
+
+// Versions returns the versions of the secret. The versions of a nested secret are the versions of its secret.
+func (e *EncryptedBackend[T, S]) Versions(ctx context.Context, id T) ([]T, error) {
+	stored, err := e.storedSecretOf(id)
+	if err != nil {
+		return nil, err
+	}
+	ids, err := e.Backend.Versions(ctx, stored.id)
+	if err != nil || stored.subPath == "" {
+		return ids, err
+	}
+	for i := range ids {
+		if ids[i], err = e.nestedIdOf(id, ids[i]); err != nil {
+			return nil, err
+		}
+	}
+	return ids, nil
+}
+
+// Rewrap re-wraps the data keys of the secret with the current key.
+// Values that are not encrypted yet are encrypted.
+// If the wrapped backend is a Rewriter, the kept versions are re-wrapped as well and no new version is created.
+// Otherwise, only the latest value is re-wrapped by writing it again.
+// It returns the new ID of the secret and false if nothing had to be changed.
+func (e *EncryptedBackend[T, S]) Rewrap(ctx context.Context, id T) (T, bool, error) {
+	stored, err := e.storedSecretOf(id)
+	if err != nil {
+		return id, false, err
+	}
+	rewrap := func(value string) (string, error) {
+		return e.rewrap(value, stored.additionalData)
+	}
+
+	if rewriter, ok := any(e.Backend).(Rewriter[T]); ok {
+		changed, err := rewriter.Rewrite(ctx, stored.id, rewrap)
+		if err != nil {
+			return id, false, err
+		}
+		return stored.id, changed, nil
+	}
+
+	secret, err := e.Backend.Get(ctx, stored.id)
+	if err != nil {
+		return id, false, err
+	}
+	value, err := rewrap(secret.Value())
+	if err != nil {
+		return id, false, backend.NewBackendError(id, err, "InternalError")
+	}
+	if value == secret.Value() {
+		return stored.id, false, nil
+	}
+	newSecret, err := e.Backend.Set(ctx, stored.id, backend.String(value))
+	if err != nil {
+		return id, false, err
+	}
+	return newSecret.Id(), true, nil
+}
+
+// RewrapAll re-wraps the data keys of all secrets of an environment, team or application.
+// It returns the number of secrets that have been changed.
+func (e *EncryptedBackend[T, S]) RewrapAll(ctx context.Context, env, team, app string) (int, error) {
+	log := logr.FromContextOrDiscard(ctx)
+	ids, err := e.Backend.List(ctx, env, team, app)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for name, id := range ids {
+		_, changed, err := e.Rewrap(ctx, id)
+		if err != nil {
+			return count, errors.Wrapf(err, "failed to rewrap secret %s", name)
+		}
+		if changed {
+			log.Info("Rewrapped secret", "name", name)
+			count++
+		}
+	}
+	return count, nil
+}
+
+// storedSecret is the secret of the wrapped backend that contains the value of an ID
+type storedSecret[T backend.SecretId] struct {
+	id             T
+	subPath        string
+	additionalData string
+}
+
+// storedSecretOf returns the secret that contains the value of the ID.
+// For nested secrets, this is the secret that contains them, e.g. `externalSecrets` for `externalSecrets/foo`.
+func (e *EncryptedBackend[T, S]) storedSecretOf(id T) (res storedSecret[T], err error) {
+	parsed, err := backend.ParseId(id.String())
+	if err != nil {
+		return res, err
+	}
+	key, subPath := parsed.Key()
+	res = storedSecret[T]{id: id, subPath: subPath, additionalData: AdditionalData(parsed.Scope, key)}
+	if subPath == "" {
+		return res, nil
+	}
+	parsed.Path = key
+	res.id, err = e.Backend.ParseSecretId(parsed.String())
+	return res, err
+}
+
+// nestedIdOf returns the ID with the checksum of the ID of its stored secret
+func (e *EncryptedBackend[T, S]) nestedIdOf(id, storedId T) (T, error) {
+	parsed, err := backend.ParseId(id.String())
+	if err != nil {
+		return id, err
+	}
+	if _, subPath := parsed.Key(); subPath == "" {
+		return storedId, nil
+	}
+	stored, err := backend.ParseId(storedId.String())
+	if err != nil {
+		return id, err
+	}
+	parsed.Checksum = stored.Checksum
+	return e.Backend.ParseSecretId(parsed.String())
+}
+
+// getPlaintext returns the decrypted value of the stored secret and false if it does not exist
+func (e *EncryptedBackend[T, S]) getPlaintext(ctx context.Context, id T, stored storedSecret[T]) (string, bool, error) {
+	secret, err := e.Backend.Get(ctx, stored.id)
+	if err != nil {
+		if backend.IsNotFoundErr(err) {
+			return "", false, nil
+		}
+		return "", false, err
+	}
+	plaintext, err := e.decrypt(secret.Value(), stored.additionalData)
+	if err != nil {
+		return "", false, backend.NewBackendError(id, err, "InternalError")
+	}
+	return plaintext, true, nil
+}
+
+// setPlaintext encrypts the value and writes it to the stored secret
+func (e *EncryptedBackend[T, S]) setPlaintext(ctx context.Context, id T, stored storedSecret[T], plaintext string) (S, error) {
+	ciphertext, err := e.encrypt(plaintext, stored.additionalData)
+	if err != nil {
+		var res S
+		return res, backend.NewBackendError(id, err, "InternalError")
+	}
+	return e.Backend.Set(ctx, stored.id, backend.String(ciphertext))
+}
+
+// encrypt encrypts all values but empty ones.
+// Values that look like they are encrypted already are encrypted as well, so they are returned as they were set.
+func (e *EncryptedBackend[T, S]) encrypt(value, additionalData string) (string, error) {
+	if value == "" {
+		return value, nil
+	}
+	return e.Encrypter.Encrypt(value, additionalData)
+}
+
+func (e *EncryptedBackend[T, S]) decrypt(value, additionalData string) (string, error) {
+	if !IsEncrypted(value) {
+		return value, nil
+	}
+	return e.Encrypter.Decrypt(value, additionalData)
+}
+
+func (e *EncryptedBackend[T, S]) rewrap(value, additionalData string) (string, error) {
+	if !IsEncrypted(value) {
+		return e.encrypt(value, additionalData)
+	}
+	if rewrapper, ok := e.Encrypter.(Rewrapper); ok {
+		newValue, _, err := rewrapper.Rewrap(value)
+		return newValue, err
+	}
+	plaintext, err := e.Encrypter.Decrypt(value, additionalData)
+	if err != nil {
+		return "", err
+	}
+	return e.Encrypter.Encrypt(plaintext, additionalData)
+}
@@ -0,0 +1,348 @@
+package encrypt_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/backend"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/backend/encrypt"
+	"github.com/telekom/controlplane-mono/secret-manager/test/mocks"
+)
+
+const (
+	clientSecretAad    = "env:team:app:clientSecret"
+	externalSecretsAad = "env:team:app:externalSecrets"
+)
+
+// rewritingBackend keeps the stored value and the versions of a single secret to test the re-wrapping of the versions
+type rewritingBackend struct {
+	*mocks.MockBackend[*mocks.MockSecretId, backend.DefaultSecret[*mocks.MockSecretId]]
+	values []string
+}
+
+func (b *rewritingBackend) Rewrite(ctx context.Context, id *mocks.MockSecretId, fn func(value string) (string, error)) (bool, error) {
+	changed := false
+	for i, value := range b.values {
+		newValue, err := fn(value)
+		if err != nil {
+			return false, err
+		}
+		changed = changed || newValue != value
+		b.values[i] = newValue
+	}
+	return changed, nil
+}
+
+var _ = Describe("Encrypted Backend", func() {
+
+	var ctx context.Context
+	var keys *encrypt.StaticKeyProvider
+	var encrypter *encrypt.EnvelopeEncrypter
+	var mockBackend *mocks.MockBackend[*mocks.MockSecretId, backend.DefaultSecret[*mocks.MockSecretId]]
+	var encryptedBackend *encrypt.EncryptedBackend[*mocks.MockSecretId, backend.DefaultSecret[*mocks.MockSecretId]]
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		t := GinkgoT()
+		mockBackend = &mocks.MockBackend[*mocks.MockSecretId, backend.DefaultSecret[*mocks.MockSecretId]]{}
+		mockBackend.Mock.Test(t)
+		t.Cleanup(func() { mockBackend.AssertExpectations(t) })
+
+		var err error
+		keys, err = encrypt.NewStaticKeyProvider("key-1", map[string][]byte{"key-1": newKey(1)})
+		Expect(err).ToNot(HaveOccurred())
+		encrypter = encrypt.NewEnvelopeEncrypter(keys)
+		encryptedBackend = encrypt.NewEncryptedBackend(mockBackend, encrypter)
+	})
+
+	mustEncrypt := func(value, aad string) string {
+		return encryptWith(encrypter, value, aad)
+	}
+
+	mustDecrypt := func(value, aad string) string {
+		plaintext, err := encrypter.Decrypt(value, aad)
+		Expect(err).ToNot(HaveOccurred())
+		return plaintext
+	}
+
+	// expectNested expects the IDs of a nested secret of externalSecrets to be parsed
+	expectNested := func(nestedId *mocks.MockSecretId, checksum string) *mocks.MockSecretId {
+		storedId := newSecretId("env:team:app:externalSecrets:" + checksum)
+		mockBackend.EXPECT().ParseSecretId("env:team:app:externalSecrets:"+checksum).Return(storedId, nil).Once()
+		mockBackend.EXPECT().ParseSecretId(nestedId.String()).Return(nestedId, nil).Maybe()
+		return storedId
+	}
+
+	Context("Get", func() {
+		It("should decrypt the value", func() {
+			secretId := newSecretId("env:team:app:clientSecret:")
+			mockBackend.EXPECT().Get(ctx, secretId).Return(backend.NewDefaultSecret(secretId, mustEncrypt("my-value", clientSecretAad)), nil).Once()
+
+			secret, err := encryptedBackend.Get(ctx, secretId)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(secret.Value()).To(Equal("my-value"))
+			Expect(secret.Id()).To(Equal(secretId))
+		})
+
+		It("should return values that are not encrypted", func() {
+			secretId := newSecretId("env:team:app:clientSecret:")
+			mockBackend.EXPECT().Get(ctx, secretId).Return(backend.NewDefaultSecret(secretId, "my-value"), nil).Once()
+
+			secret, err := encryptedBackend.Get(ctx, secretId)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(secret.Value()).To(Equal("my-value"))
+		})
+
+		It("should not decrypt a value that was encrypted for another secret", func() {
+			secretId := newSecretId("env:team:app:clientSecret:")
+			value := mustEncrypt("my-value", "env:team:other-app:clientSecret")
+			mockBackend.EXPECT().Get(ctx, secretId).Return(backend.NewDefaultSecret(secretId, value), nil).Once()
+
+			_, err := encryptedBackend.Get(ctx, secretId)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should get a nested secret of the decrypted value", func() {
+			nestedId := newSecretId("env:team:app:externalSecrets/nested.key:v2")
+			storedId := expectNested(nestedId, "v2")
+			value := `{"foo":"bar","nested":{"count":1,"key":"<value>"}}`
+			mockBackend.EXPECT().Get(ctx, storedId).Return(backend.NewDefaultSecret(storedId, mustEncrypt(value, externalSecretsAad)), nil).Once()
+
+			secret, err := encryptedBackend.Get(ctx, nestedId)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(secret.Value()).To(Equal("<value>"))
+			Expect(secret.Id()).To(Equal(nestedId))
+		})
+
+		It("should return not-found if the nested secret does not exist", func() {
+			nestedId := newSecretId("env:team:app:externalSecrets/unknown:")
+			storedId := expectNested(nestedId, "")
+			mockBackend.EXPECT().Get(ctx, storedId).Return(backend.NewDefaultSecret(storedId, mustEncrypt(`{"foo":"bar"}`, externalSecretsAad)), nil).Once()
+
+			_, err := encryptedBackend.Get(ctx, nestedId)
+			Expect(backend.IsNotFoundErr(err)).To(BeTrue())
+		})
+
+		It("should fail if the value can not be decrypted", func() {
+			secretId := newSecretId("env:team:app:clientSecret:")
+			mockBackend.EXPECT().Get(ctx, secretId).Return(backend.NewDefaultSecret(secretId, "enc:v1:unknown:AA==:AA=="), nil).Once()
+
+			_, err := encryptedBackend.Get(ctx, secretId)
+			Expect(err).To(HaveOccurred())
+			var backendErr *backend.BackendError
+			Expect(err).To(BeAssignableToTypeOf(backendErr))
+		})
+	})
+
+	Context("Set", func() {
+		var stored string
+
+		expectSet := func(id *mocks.MockSecretId) {
+			mockBackend.EXPECT().Set(ctx, id, mock.Anything).RunAndReturn(
+				func(ctx context.Context, id *mocks.MockSecretId, value backend.SecretValue) (backend.DefaultSecret[*mocks.MockSecretId], error) {
+					stored = value.Value()
+					return backend.NewDefaultSecret(id, ""), nil
+				}).Once()
+		}
+
+		It("should encrypt the value", func() {
+			secretId := newSecretId("env:team:app:clientSecret:")
+			mockBackend.EXPECT().Get(ctx, secretId).Return(backend.NewDefaultSecret(secretId, ""), backend.ErrSecretNotFound(secretId)).Once()
+			expectSet(secretId)
+
+			secret, err := encryptedBackend.Set(ctx, secretId, backend.String("my-value"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(secret.Id()).To(Equal(secretId))
+			Expect(stored).To(HavePrefix(encrypt.Prefix))
+			Expect(mustDecrypt(stored, clientSecretAad)).To(Equal("my-value"))
+		})
+
+		It("should encrypt a JSON object as a whole", func() {
+			secretId := newSecretId("env:team:app:externalSecrets:")
+			mockBackend.EXPECT().Get(ctx, secretId).Return(backend.NewDefaultSecret(secretId, "{}"), nil).Once()
+			expectSet(secretId)
+
+			value := `{"foo":"bar","port":8443,"enabled":true}`
+			_, err := encryptedBackend.Set(ctx, secretId, backend.String(value))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(stored).To(HavePrefix(encrypt.Prefix))
+			for _, plaintext := range []string{"foo", "bar", "port", "8443", "enabled", "true"} {
+				Expect(stored).ToNot(ContainSubstring(plaintext))
+			}
+			Expect(mustDecrypt(stored, externalSecretsAad)).To(MatchJSON(value))
+		})
+
+		It("should encrypt values that look like they are encrypted", func() {
+			secretId := newSecretId("env:team:app:clientSecret:")
+			value := mustEncrypt("other-value", clientSecretAad)
+			mockBackend.EXPECT().Get(ctx, secretId).Return(backend.NewDefaultSecret(secretId, ""), backend.ErrSecretNotFound(secretId)).Once()
+			expectSet(secretId)
+
+			_, err := encryptedBackend.Set(ctx, secretId, backend.String(value))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(stored).ToNot(Equal(value))
+			Expect(mustDecrypt(stored, clientSecretAad)).To(Equal(value))
+
+			mockBackend.EXPECT().Get(ctx, secretId).Return(backend.NewDefaultSecret(secretId, stored), nil).Once()
+			secret, err := encryptedBackend.Get(ctx, secretId)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(secret.Value()).To(Equal(value))
+		})
+
+		It("should set a nested secret in the decrypted value", func() {
+			nestedId := newSecretId("env:team:app:externalSecrets/foo:")
+			storedId := expectNested(nestedId, "")
+			mockBackend.EXPECT().Get(ctx, storedId).Return(backend.NewDefaultSecret(storedId, mustEncrypt(`{"foo":"old","port":8443}`, externalSecretsAad)), nil).Once()
+			mockBackend.EXPECT().Set(ctx, storedId, mock.Anything).RunAndReturn(
+				func(ctx context.Context, id *mocks.MockSecretId, value backend.SecretValue) (backend.DefaultSecret[*mocks.MockSecretId], error) {
+					stored = value.Value()
+					return backend.NewDefaultSecret(newSecretId("env:team:app:externalSecrets:v3"), ""), nil
+				}).Once()
+			newNestedId := newSecretId("env:team:app:externalSecrets/foo:v3")
+			mockBackend.EXPECT().ParseSecretId("env:team:app:externalSecrets/foo:v3").Return(newNestedId, nil).Once()
+
+			secret, err := encryptedBackend.Set(ctx, nestedId, backend.String("new"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(secret.Id()).To(Equal(newNestedId))
+			Expect(mustDecrypt(stored, externalSecretsAad)).To(MatchJSON(`{"foo":"new","port":8443}`))
+		})
+
+		It("should not set an unchanged value", func() {
+			secretId := newSecretId("env:team:app:clientSecret:")
+			mockBackend.EXPECT().Get(ctx, secretId).Return(backend.NewDefaultSecret(secretId, mustEncrypt("my-value", clientSecretAad)), nil).Once()
+
+			secret, err := encryptedBackend.Set(ctx, secretId, backend.String("my-value"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(secret.Value()).To(Equal("my-value"))
+		})
+
+		It("should not change an existing value if it is not allowed", func() {
+			secretId := newSecretId("env:team:app:clientSecret:")
+			mockBackend.EXPECT().Get(ctx, secretId).Return(backend.NewDefaultSecret(secretId, mustEncrypt("my-value", clientSecretAad)), nil).Once()
+
+			secret, err := encryptedBackend.Set(ctx, secretId, backend.InitialString("other-value"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(secret.Value()).To(Equal("my-value"))
+		})
+	})
+
+	Context("Delete", func() {
+		It("should remove a nested secret from the decrypted value", func() {
+			nestedId := newSecretId("env:team:app:externalSecrets/foo:")
+			storedId := expectNested(nestedId, "")
+			mockBackend.EXPECT().Get(ctx, storedId).Return(backend.NewDefaultSecret(storedId, mustEncrypt(`{"foo":"bar","baz":"qux"}`, externalSecretsAad)), nil).Once()
+			var stored string
+			mockBackend.EXPECT().Set(ctx, storedId, mock.Anything).RunAndReturn(
+				func(ctx context.Context, id *mocks.MockSecretId, value backend.SecretValue) (backend.DefaultSecret[*mocks.MockSecretId], error) {
+					stored = value.Value()
+					return backend.NewDefaultSecret(id, ""), nil
+				}).Once()
+
+			err := encryptedBackend.Delete(ctx, nestedId)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(mustDecrypt(stored, externalSecretsAad)).To(MatchJSON(`{"baz":"qux"}`))
+		})
+	})
+
+	Context("Rewrap", func() {
+		var rotatedEncrypter *encrypt.EnvelopeEncrypter
+
+		BeforeEach(func() {
+			rotatedKeys, err := encrypt.NewStaticKeyProvider("key-2", map[string][]byte{"key-1": newKey(1), "key-2": newKey(2)})
+			Expect(err).ToNot(HaveOccurred())
+			rotatedEncrypter = encrypt.NewEnvelopeEncrypter(rotatedKeys)
+		})
+
+		It("should rewrap the data key with the current key", func() {
+			secretId := newSecretId("env:team:app:clientSecret:v1")
+			newSecretId := newSecretId("env:team:app:clientSecret:v2")
+			mockBackend.EXPECT().Get(ctx, secretId).Return(backend.NewDefaultSecret(secretId, mustEncrypt("my-value", clientSecretAad)), nil).Once()
+			var stored string
+			mockBackend.EXPECT().Set(ctx, secretId, mock.Anything).RunAndReturn(
+				func(ctx context.Context, id *mocks.MockSecretId, value backend.SecretValue) (backend.DefaultSecret[*mocks.MockSecretId], error) {
+					stored = value.Value()
+					return backend.NewDefaultSecret(newSecretId, ""), nil
+				}).Once()
+
+			encryptedBackend.Encrypter = rotatedEncrypter
+			id, changed, err := encryptedBackend.Rewrap(ctx, secretId)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(changed).To(BeTrue())
+			Expect(id).To(Equal(newSecretId))
+			Expect(stored).To(HavePrefix("enc:v1:key-2:"))
+			plaintext, err := rotatedEncrypter.Decrypt(stored, clientSecretAad)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(plaintext).To(Equal("my-value"))
+		})
+
+		It("should not change a value that uses the current key", func() {
+			secretId := newSecretId("env:team:app:clientSecret:")
+			mockBackend.EXPECT().Get(ctx, secretId).Return(backend.NewDefaultSecret(secretId, mustEncrypt("my-value", clientSecretAad)), nil).Once()
+
+			id, changed, err := encryptedBackend.Rewrap(ctx, secretId)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(changed).To(BeFalse())
+			Expect(id).To(Equal(secretId))
+		})
+
+		It("should rewrap the versions if the backend can rewrite them", func() {
+			secretId := newSecretId("env:team:app:clientSecret:v3")
+			rewriter := &rewritingBackend{
+				MockBackend: mockBackend,
+				values: []string{
+					mustEncrypt("latest", clientSecretAad),
+					mustEncrypt("previous", clientSecretAad),
+					"plain",
+				},
+			}
+			rewritingEncryptedBackend := encrypt.NewEncryptedBackend(rewriter, rotatedEncrypter)
+
+			id, changed, err := rewritingEncryptedBackend.Rewrap(ctx, secretId)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(changed).To(BeTrue())
+			Expect(id).To(Equal(secretId))
+			for i, plaintext := range []string{"latest", "previous", "plain"} {
+				Expect(rewriter.values[i]).To(HavePrefix("enc:v1:key-2:"))
+				value, err := rotatedEncrypter.Decrypt(rewriter.values[i], clientSecretAad)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(value).To(Equal(plaintext))
+			}
+		})
+
+		It("should rewrap all secrets of an application", func() {
+			currentId := newSecretId("env:team:app:current:")
+			outdatedId := newSecretId("env:team:app:outdated:")
+			plainId := newSecretId("env:team:app:plain:")
+			encryptedBackend.Encrypter = rotatedEncrypter
+
+			mockBackend.EXPECT().List(ctx, "env", "team", "app").Return(map[string]*mocks.MockSecretId{
+				"current":  currentId,
+				"outdated": outdatedId,
+				"plain":    plainId,
+			}, nil).Once()
+			mockBackend.EXPECT().Get(ctx, currentId).Return(backend.NewDefaultSecret(currentId, encryptWith(rotatedEncrypter, "a", "env:team:app:current")), nil).Once()
+			mockBackend.EXPECT().Get(ctx, outdatedId).Return(backend.NewDefaultSecret(outdatedId, mustEncrypt("b", "env:team:app:outdated")), nil).Once()
+			mockBackend.EXPECT().Get(ctx, plainId).Return(backend.NewDefaultSecret(plainId, "c"), nil).Once()
+			mockBackend.EXPECT().Set(ctx, outdatedId, mock.Anything).Return(backend.NewDefaultSecret(outdatedId, ""), nil).Once()
+			mockBackend.EXPECT().Set(ctx, plainId, mock.Anything).Return(backend.NewDefaultSecret(plainId, ""), nil).Once()
+
+			count, err := encryptedBackend.RewrapAll(ctx, "env", "team", "app")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(count).To(Equal(2))
+		})
+	})
+})
+
+func encryptWith(encrypter encrypt.Encrypter, value, aad string) string {
+	ciphertext, err := encrypter.Encrypt(value, aad)
+	Expect(err).ToNot(HaveOccurred())
+	return ciphertext
+}
+
+func newSecretId(raw string) *mocks.MockSecretId {
+	secretId := mocks.NewMockSecretId(GinkgoT())
+	secretId.EXPECT().String().Return(raw).Maybe()
+	return secretId
+}
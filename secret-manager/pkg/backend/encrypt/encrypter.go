@@ -0,0 +1,194 @@
+package encrypt
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// Prefix is used to identify values that are encrypted by the EnvelopeEncrypter
+	Prefix = "enc:v1:"
+
+	dataKeySize = 32
+)
+
+var ErrNotEncrypted = errors.New("value is not encrypted")
+
+// Encrypter is used to encrypt and decrypt the values of secrets.
+// The additional data is authenticated but not encrypted. A value can only be decrypted with the same additional data,
+// which binds it to its secret, see AdditionalData.
+type Encrypter interface {
+	Encrypt(plaintext, additionalData string) (string, error)
+	Decrypt(ciphertext, additionalData string) (string, error)
+}
+
+// Rewrapper is implemented by encrypters that can re-wrap the data key of a ciphertext
+// with the current key without decrypting the value itself.
+type Rewrapper interface {
+	// Rewrap returns the ciphertext with its data key wrapped by the current key.
+	// It returns false if the data key is already wrapped by the current key.
+	Rewrap(ciphertext string) (string, bool, error)
+}
+
+var _ Encrypter = &EnvelopeEncrypter{}
+var _ Rewrapper = &EnvelopeEncrypter{}
+
+// EnvelopeEncrypter encrypts each value with a new random AES-GCM data key.
+// The data key is wrapped by the current key of the KeyProvider and stored next to the value:
+//
+//	enc:v1:<keyId>:<base64(wrapped data key)>:<base64(ciphertext)>
+type EnvelopeEncrypter struct {
+	keys KeyProvider
+}
+
+func NewEnvelopeEncrypter(keys KeyProvider) *EnvelopeEncrypter {
+	return &EnvelopeEncrypter{keys: keys}
+}
+
+// IsEncrypted checks if the value has been encrypted by the EnvelopeEncrypter
+func IsEncrypted(value string) bool {
+	return strings.HasPrefix(value, Prefix)
+}
+
+func (e *EnvelopeEncrypter) Encrypt(plaintext, additionalData string) (string, error) {
+	dataKey := make([]byte, dataKeySize)
+	if _, err := rand.Read(dataKey); err != nil {
+		return "", errors.Wrap(err, "failed to generate data key")
+	}
+	ciphertext, err := seal(dataKey, []byte(plaintext), []byte(additionalData))
+	if err != nil {
+		return "", errors.Wrap(err, "failed to encrypt value")
+	}
+
+	keyId, wrappedKey, err := e.wrap(dataKey)
+	if err != nil {
+		return "", err
+	}
+	return format(keyId, wrappedKey, ciphertext), nil
+}
+
+func (e *EnvelopeEncrypter) Decrypt(value, additionalData string) (string, error) {
+	keyId, wrappedKey, ciphertext, err := parse(value)
+	if err != nil {
+		return "", err
+	}
+	dataKey, err := e.unwrap(keyId, wrappedKey)
+	if err != nil {
+		return "", err
+	}
+	plaintext, err := open(dataKey, ciphertext, []byte(additionalData))
+	if err != nil {
+		return "", errors.Wrap(err, "failed to decrypt value")
+	}
+	return string(plaintext), nil
+}
+
+func (e *EnvelopeEncrypter) Rewrap(value string) (string, bool, error) {
+	keyId, wrappedKey, ciphertext, err := parse(value)
+	if err != nil {
+		return "", false, err
+	}
+	currentKeyId, _, err := e.keys.CurrentKey()
+	if err != nil {
+		return "", false, errors.Wrap(err, "failed to get current key")
+	}
+	if keyId == currentKeyId {
+		return value, false, nil
+	}
+
+	dataKey, err := e.unwrap(keyId, wrappedKey)
+	if err != nil {
+		return "", false, err
+	}
+	newKeyId, newWrappedKey, err := e.wrap(dataKey)
+	if err != nil {
+		return "", false, err
+	}
+	return format(newKeyId, newWrappedKey, ciphertext), true, nil
+}
+
+func (e *EnvelopeEncrypter) wrap(dataKey []byte) (string, []byte, error) {
+	keyId, key, err := e.keys.CurrentKey()
+	if err != nil {
+		return "", nil, errors.Wrap(err, "failed to get current key")
+	}
+	// The key ID is authenticated to detect a manipulated envelope
+	wrappedKey, err := seal(key, dataKey, []byte(keyId))
+	if err != nil {
+		return "", nil, errors.Wrapf(err, "failed to wrap data key with key %s", keyId)
+	}
+	return keyId, wrappedKey, nil
+}
+
+func (e *EnvelopeEncrypter) unwrap(keyId string, wrappedKey []byte) ([]byte, error) {
+	key, err := e.keys.Key(keyId)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get key %s", keyId)
+	}
+	dataKey, err := open(key, wrappedKey, []byte(keyId))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to unwrap data key with key %s", keyId)
+	}
+	return dataKey, nil
+}
+
+func format(keyId string, wrappedKey, ciphertext []byte) string {
+	return Prefix + keyId + ":" + base64.StdEncoding.EncodeToString(wrappedKey) + ":" + base64.StdEncoding.EncodeToString(ciphertext)
+}
+
+func parse(value string) (keyId string, wrappedKey, ciphertext []byte, err error) {
+	if !IsEncrypted(value) {
+		return "", nil, nil, ErrNotEncrypted
+	}
+	parts := strings.Split(strings.TrimPrefix(value, Prefix), ":")
+	if len(parts) != 3 || parts[0] == "" {
+		return "", nil, nil, errors.New("invalid format of encrypted value")
+	}
+	wrappedKey, err = base64.StdEncoding.DecodeString(parts[1])
+	if err != nil {
+		return "", nil, nil, errors.Wrap(err, "invalid data key of encrypted value")
+	}
+	ciphertext, err = base64.StdEncoding.DecodeString(parts[2])
+	if err != nil {
+		return "", nil, nil, errors.Wrap(err, "invalid ciphertext of encrypted value")
+	}
+	return parts[0], wrappedKey, ciphertext, nil
+}
+
+// seal encrypts the plaintext using AES-GCM. The random nonce is prepended to the result.
+func seal(key, plaintext, additionalData []byte) ([]byte, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(plaintext)+aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, plaintext, additionalData), nil
+}
+
+func open(key, ciphertext, additionalData []byte) ([]byte, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(ciphertext) < aead.NonceSize() {
+		return nil, errors.New("ciphertext too short")
+	}
+	nonce, sealed := ciphertext[:aead.NonceSize()], ciphertext[aead.NonceSize():]
+	return aead.Open(nil, nonce, sealed, additionalData)
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,113 @@
+package encrypt_test
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/backend/encrypt"
+)
+
+var _ = Describe("Envelope Encrypter", func() {
+
+	const aad = "env:team:app:clientSecret"
+
+	var keys *encrypt.StaticKeyProvider
+	var encrypter *encrypt.EnvelopeEncrypter
+
+	BeforeEach(func() {
+		var err error
+		keys, err = encrypt.NewStaticKeyProvider("key-1", map[string][]byte{"key-1": newKey(1)})
+		Expect(err).ToNot(HaveOccurred())
+		encrypter = encrypt.NewEnvelopeEncrypter(keys)
+	})
+
+	It("should encrypt and decrypt a value", func() {
+		ciphertext, err := encrypter.Encrypt("my-secret", aad)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ciphertext).To(HavePrefix("enc:v1:key-1:"))
+		Expect(ciphertext).ToNot(ContainSubstring("my-secret"))
+		Expect(encrypt.IsEncrypted(ciphertext)).To(BeTrue())
+
+		plaintext, err := encrypter.Decrypt(ciphertext, aad)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(plaintext).To(Equal("my-secret"))
+	})
+
+	It("should only decrypt a value with the same additional data", func() {
+		ciphertext, err := encrypter.Encrypt("my-secret", aad)
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = encrypter.Decrypt(ciphertext, "env:team:other-app:clientSecret")
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(HavePrefix("failed to decrypt value"))
+	})
+
+	It("should use a new data key for each value", func() {
+		first, err := encrypter.Encrypt("my-secret", aad)
+		Expect(err).ToNot(HaveOccurred())
+		second, err := encrypter.Encrypt("my-secret", aad)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(first).ToNot(Equal(second))
+	})
+
+	It("should fail to decrypt a value that is not encrypted", func() {
+		_, err := encrypter.Decrypt("my-secret", aad)
+		Expect(err).To(MatchError(encrypt.ErrNotEncrypted))
+
+		_, err = encrypter.Decrypt("enc:v1:invalid", aad)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(Equal("invalid format of encrypted value"))
+	})
+
+	It("should fail to decrypt with an unknown key", func() {
+		otherKeys, err := encrypt.NewStaticKeyProvider("key-2", map[string][]byte{"key-2": newKey(2)})
+		Expect(err).ToNot(HaveOccurred())
+		ciphertext, err := encrypt.NewEnvelopeEncrypter(otherKeys).Encrypt("my-secret", aad)
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = encrypter.Decrypt(ciphertext, aad)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(HavePrefix("failed to get key key-2"))
+	})
+
+	It("should detect a manipulated key id", func() {
+		keys, err := encrypt.NewStaticKeyProvider("key-1", map[string][]byte{"key-1": newKey(1), "key-2": newKey(1)})
+		Expect(err).ToNot(HaveOccurred())
+		encrypter = encrypt.NewEnvelopeEncrypter(keys)
+
+		ciphertext, err := encrypter.Encrypt("my-secret", aad)
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = encrypter.Decrypt(strings.Replace(ciphertext, "key-1", "key-2", 1), aad)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(HavePrefix("failed to unwrap data key with key key-2"))
+	})
+
+	It("should rewrap the data key after a key rotation", func() {
+		ciphertext, err := encrypter.Encrypt("my-secret", aad)
+		Expect(err).ToNot(HaveOccurred())
+
+		_, changed, err := encrypter.Rewrap(ciphertext)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(changed).To(BeFalse())
+
+		rotatedKeys, err := encrypt.NewStaticKeyProvider("key-2", map[string][]byte{"key-1": newKey(1), "key-2": newKey(2)})
+		Expect(err).ToNot(HaveOccurred())
+		encrypter = encrypt.NewEnvelopeEncrypter(rotatedKeys)
+
+		rewrapped, changed, err := encrypter.Rewrap(ciphertext)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(changed).To(BeTrue())
+		Expect(rewrapped).To(HavePrefix("enc:v1:key-2:"))
+		// The value itself is not encrypted again
+		Expect(rewrapped[strings.LastIndex(rewrapped, ":"):]).To(Equal(ciphertext[strings.LastIndex(ciphertext, ":"):]))
+
+		// The old key is no longer needed
+		newKeys, err := encrypt.NewStaticKeyProvider("key-2", map[string][]byte{"key-2": newKey(2)})
+		Expect(err).ToNot(HaveOccurred())
+		plaintext, err := encrypt.NewEnvelopeEncrypter(newKeys).Decrypt(rewrapped, aad)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(plaintext).To(Equal("my-secret"))
+	})
+})
@@ -0,0 +1,105 @@
+package encrypt
+
+import (
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// KeyProvider provides the keys that are used to wrap the data keys.
+// To rotate a key, a new key is added and made the current one.
+// The previous keys must be kept until all data keys have been re-wrapped.
+type KeyProvider interface {
+	// CurrentKey returns the key that is used to wrap new data keys
+	CurrentKey() (keyId string, key []byte, err error)
+	// Key returns the key with the given ID to unwrap existing data keys
+	Key(keyId string) ([]byte, error)
+}
+
+var _ KeyProvider = &StaticKeyProvider{}
+
+// StaticKeyProvider provides a fixed set of keys.
+type StaticKeyProvider struct {
+	keys      map[string][]byte
+	currentId string
+}
+
+// NewStaticKeyProvider returns a KeyProvider for the given keys by their ID.
+// Each key must be a valid AES key with 16, 24 or 32 bytes.
+func NewStaticKeyProvider(currentId string, keys map[string][]byte) (*StaticKeyProvider, error) {
+	for keyId, key := range keys {
+		if err := validateKey(keyId, key); err != nil {
+			return nil, err
+		}
+	}
+	if _, ok := keys[currentId]; !ok {
+		return nil, errors.Errorf("current key %s does not exist", currentId)
+	}
+	return &StaticKeyProvider{keys: keys, currentId: currentId}, nil
+}
+
+func (p *StaticKeyProvider) CurrentKey() (string, []byte, error) {
+	return p.currentId, p.keys[p.currentId], nil
+}
+
+func (p *StaticKeyProvider) Key(keyId string) ([]byte, error) {
+	key, ok := p.keys[keyId]
+	if !ok {
+		return nil, errors.Errorf("key %s does not exist", keyId)
+	}
+	return key, nil
+}
+
+// NewFileKeyProvider reads all keys from the files in the directory, e.g. a mounted Kubernetes Secret.
+// The name of each file is the ID of the key. Its content is either the base64-encoded or the raw key.
+// Hidden files are ignored.
+func NewFileKeyProvider(dir, currentId string) (*StaticKeyProvider, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read key directory")
+	}
+
+	keys := make(map[string][]byte, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		// Kubernetes mounts the files as symlinks, so the type of the entry can not be used
+		info, err := os.Stat(filepath.Join(dir, entry.Name()))
+		if err != nil || info.IsDir() {
+			continue
+		}
+		content, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read key %s", entry.Name())
+		}
+		keys[entry.Name()] = decodeKey(content)
+	}
+
+	return NewStaticKeyProvider(currentId, keys)
+}
+
+func decodeKey(content []byte) []byte {
+	decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(content)))
+	if err == nil && isValidKeySize(len(decoded)) {
+		return decoded
+	}
+	return content
+}
+
+func validateKey(keyId string, key []byte) error {
+	if keyId == "" || strings.Contains(keyId, ":") {
+		return errors.Errorf("invalid key id %q", keyId)
+	}
+	if !isValidKeySize(len(key)) {
+		return errors.Errorf("invalid size of key %s, must be 16, 24 or 32 bytes", keyId)
+	}
+	return nil
+}
+
+func isValidKeySize(size int) bool {
+	return size == 16 || size == 24 || size == 32
+}
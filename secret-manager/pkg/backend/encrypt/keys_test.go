@@ -0,0 +1,112 @@
+package encrypt_test
+
+import (
+	"encoding/base64"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/backend/encrypt"
+)
+
+var _ = Describe("Key Provider", func() {
+
+	Context("Static Key Provider", func() {
+
+		It("should return the current key", func() {
+			keys, err := encrypt.NewStaticKeyProvider("key-2", map[string][]byte{
+				"key-1": newKey(1),
+				"key-2": newKey(2),
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			keyId, key, err := keys.CurrentKey()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(keyId).To(Equal("key-2"))
+			Expect(key).To(Equal(newKey(2)))
+
+			key, err = keys.Key("key-1")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(key).To(Equal(newKey(1)))
+		})
+
+		It("should fail if the key does not exist", func() {
+			keys, err := encrypt.NewStaticKeyProvider("key-1", map[string][]byte{"key-1": newKey(1)})
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = keys.Key("unknown")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("key unknown does not exist"))
+		})
+
+		It("should fail if the current key does not exist", func() {
+			_, err := encrypt.NewStaticKeyProvider("key-2", map[string][]byte{"key-1": newKey(1)})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("current key key-2 does not exist"))
+		})
+
+		It("should fail for invalid keys", func() {
+			_, err := encrypt.NewStaticKeyProvider("key-1", map[string][]byte{"key-1": []byte("too-short")})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("invalid size of key key-1, must be 16, 24 or 32 bytes"))
+
+			_, err = encrypt.NewStaticKeyProvider("key:1", map[string][]byte{"key:1": newKey(1)})
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal(`invalid key id "key:1"`))
+		})
+	})
+
+	Context("File Key Provider", func() {
+
+		var dir string
+
+		BeforeEach(func() {
+			dir = GinkgoT().TempDir()
+		})
+
+		It("should read the keys from the directory", func() {
+			Expect(os.WriteFile(filepath.Join(dir, "key-1"), newKey(1), 0600)).To(Succeed())
+			encoded := base64.StdEncoding.EncodeToString(newKey(2)) + "\n"
+			Expect(os.WriteFile(filepath.Join(dir, "key-2"), []byte(encoded), 0600)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(dir, ".hidden"), []byte("ignored"), 0600)).To(Succeed())
+			Expect(os.Mkdir(filepath.Join(dir, "nested"), 0700)).To(Succeed())
+
+			keys, err := encrypt.NewFileKeyProvider(dir, "key-2")
+			Expect(err).ToNot(HaveOccurred())
+
+			keyId, key, err := keys.CurrentKey()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(keyId).To(Equal("key-2"))
+			Expect(key).To(Equal(newKey(2)))
+
+			key, err = keys.Key("key-1")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(key).To(Equal(newKey(1)))
+
+			_, err = keys.Key(".hidden")
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should fail if the directory does not exist", func() {
+			_, err := encrypt.NewFileKeyProvider(filepath.Join(dir, "unknown"), "key-1")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("failed to read key directory"))
+		})
+
+		It("should fail if the current key does not exist", func() {
+			Expect(os.WriteFile(filepath.Join(dir, "key-1"), newKey(1), 0600)).To(Succeed())
+
+			_, err := encrypt.NewFileKeyProvider(dir, "key-2")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
+
+func newKey(b byte) []byte {
+	key := make([]byte, 32)
+	for i := range key {
+		key[i] = b
+	}
+	return key
+}
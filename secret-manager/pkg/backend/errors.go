@@ -10,6 +10,7 @@ const (
 	TypeErrBadChecksum     = "BadChecksum"
 	TypeErrInvalidSecretId = "InvalidSecretId"
 	TypeErrTooManyRequests = "TooManyRequests"
+	TypeErrVersionExpired  = "VersionExpired"
 )
 
 var _ error = &BackendError{}
@@ -63,6 +64,36 @@ func IsNotFoundErr(err error) bool {
 	return false
 }
 
+// IsTooManyRequestsErr returns true if the backend is overloaded or unavailable
+func IsTooManyRequestsErr(err error) bool {
+	if err == nil {
+		return false
+	}
+	var backendErr *BackendError
+	if errors.As(err, &backendErr) {
+		return backendErr.Type == TypeErrTooManyRequests
+	}
+	return false
+}
+
+// ErrVersionExpired is returned if a version of a secret has been removed, as only the last versions are kept
+func ErrVersionExpired(id SecretId, kept int) *BackendError {
+	err := fmt.Errorf("version of secret %s has expired, only the last %d versions are kept", id.String(), kept)
+	return NewBackendError(id, err, TypeErrVersionExpired)
+}
+
+// IsVersionExpiredErr returns true if the version of the secret has been removed
+func IsVersionExpiredErr(err error) bool {
+	if err == nil {
+		return false
+	}
+	var backendErr *BackendError
+	if errors.As(err, &backendErr) {
+		return backendErr.Type == TypeErrVersionExpired
+	}
+	return false
+}
+
 func ErrBadChecksum(id SecretId) *BackendError {
 	err := fmt.Errorf("bad checksum for secret %s", id.String())
 	bErr := NewBackendError(id, err, TypeErrBadChecksum)
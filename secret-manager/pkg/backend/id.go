@@ -0,0 +1,50 @@
+package backend
+
+import "strings"
+
+// ParsedId contains the segments of a secret ID <env>:<team>:<app>:<path>:<checksum>,
+// which is the format of the IDs of all backends.
+type ParsedId struct {
+	Scope
+	Path     string
+	Checksum string
+}
+
+// ParseId splits the secret ID into its segments.
+// The environment must be set and the team must be set if the application is set.
+func ParseId(raw string) (ParsedId, error) {
+	parts := strings.Split(raw, Separator)
+	if len(parts) != 5 {
+		return ParsedId{}, ErrInvalidSecretId(raw)
+	}
+
+	id := ParsedId{
+		Scope:    Scope{Env: parts[0], Team: parts[1], App: parts[2]},
+		Path:     parts[3],
+		Checksum: parts[4],
+	}
+	if id.Env == "" {
+		return ParsedId{}, ErrInvalidSecretId(raw)
+	}
+	if id.App != "" && id.Team == "" {
+		return ParsedId{}, ErrInvalidSecretId(raw)
+	}
+	return id, nil
+}
+
+func (id ParsedId) String() string {
+	return strings.Join([]string{id.Env, id.Team, id.App, id.Path, id.Checksum}, Separator)
+}
+
+// Ref returns the ID without its checksum, which references the latest version of the secret
+func (id ParsedId) Ref() string {
+	id.Checksum = ""
+	return id.String()
+}
+
+// Key returns the secret that is stored by the backend and the sub-path of the nested secret in its value, if any.
+// E.g. the path `externalSecrets/foo` references the nested secret `foo` of the secret `externalSecrets`.
+func (id ParsedId) Key() (key string, subPath string) {
+	key, subPath, _ = strings.Cut(id.Path, "/")
+	return key, subPath
+}
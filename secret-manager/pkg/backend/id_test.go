@@ -0,0 +1,34 @@
+package backend_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/backend"
+)
+
+var _ = Describe("Id Tests", func() {
+
+	It("should parse an ID and format it without checksum", func() {
+		id, err := backend.ParseId("env:team:app:externalSecrets/foo:v3")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(id.String()).To(Equal("env:team:app:externalSecrets/foo:v3"))
+		Expect(id.Ref()).To(Equal("env:team:app:externalSecrets/foo:"))
+
+		key, subPath := id.Key()
+		Expect(key).To(Equal("externalSecrets"))
+		Expect(subPath).To(Equal("foo"))
+	})
+
+	It("should reject invalid IDs", func() {
+		for _, raw := range []string{"invalid", ":team::clientSecret:", "env::app:clientSecret:"} {
+			_, err := backend.ParseId(raw)
+			Expect(err).To(HaveOccurred(), raw)
+		}
+	})
+
+	It("should return the prefix of the IDs of a scope", func() {
+		Expect(backend.Scope{Env: "env"}.Prefix()).To(Equal("env:"))
+		Expect(backend.Scope{Env: "env", Team: "team"}.Prefix()).To(Equal("env:team:"))
+		Expect(backend.Scope{Env: "env", Team: "team", App: "app"}.Prefix()).To(Equal("env:team:app:"))
+	})
+})
@@ -2,6 +2,8 @@ package backend
 
 import (
 	"context"
+
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/generator"
 )
 
 // IdParser is responsible for parsing a secret ID from a string
@@ -46,11 +48,29 @@ type Writer[T SecretId, S Secret[T]] interface {
 	Delete(context.Context, T) error
 }
 
+// Lister is used to list the secrets of an environment, team or application.
+// It returns the IDs of the secrets by their name, the values are never read.
+// If team is empty, the environment secrets are listed.
+// If app is empty, the team secrets are listed.
+type Lister[T SecretId] interface {
+	List(ctx context.Context, env, team, app string) (map[string]T, error)
+}
+
+// Versioner is used to read the history of a secret.
+// Each Set produces a new version whose ID can be passed to Get to read exactly this version.
+// Only a limited number of past versions is kept by the backend.
+type Versioner[T SecretId] interface {
+	// Versions returns the IDs of the kept versions of the secret, the latest first.
+	Versions(ctx context.Context, id T) ([]T, error)
+}
+
 // Backend is the interface that must be implemented by all backends.
 type Backend[T SecretId, S Secret[T]] interface {
 	IdParser[T]
 	Reader[T, S]
 	Writer[T, S]
+	Lister[T]
+	Versioner[T]
 }
 
 // SecretRef is a simpler version of SecretId
@@ -69,6 +89,7 @@ type OnboardResponse interface {
 // Each steps of this process depends on the previous one.
 // It is used to onboard a new environment, team or application.
 // It is also used to delete an environment, team or application.
+// The secrets that are created are described by a Blueprint.
 type Onboarder interface {
 	OnboardEnvironment(ctx context.Context, env string) (OnboardResponse, error)
 	OnboardTeam(ctx context.Context, env, id string) (OnboardResponse, error)
@@ -79,11 +100,39 @@ type Onboarder interface {
 	DeleteApplication(ctx context.Context, env, teamId, appId string) error
 }
 
-var (
-	// Secrets that are per-default created for each environment
-	EnvironmentSecrets = []string{"zones"}
-	// Secrets that are per-default created for each team
-	TeamSecrets = []string{"clientSecret", "teamToken"}
-	// Secrets that are per-default created for each application
-	ApplicationSecrets = []string{"clientSecret", "externalSecrets"}
-)
+// Scope identifies an onboarded environment, team or application.
+// Team is empty for an environment and App is empty for a team.
+type Scope struct {
+	Env  string `json:"env"`
+	Team string `json:"team,omitempty"`
+	App  string `json:"app,omitempty"`
+}
+
+// Generator returns the scope that is used by the generators, e.g. to check the names of certificates
+func (s Scope) Generator() *generator.Scope {
+	return &generator.Scope{Env: s.Env, Team: s.Team, App: s.App}
+}
+
+func (s Scope) String() string {
+	return s.Env + Separator + s.Team + Separator + s.App
+}
+
+// Prefix returns the prefix of the IDs of all secrets of the environment, team or application.
+// The prefix of an environment also matches the secrets of its teams and applications.
+func (s Scope) Prefix() string {
+	prefix := s.Env + Separator
+	if s.Team != "" {
+		prefix += s.Team + Separator
+		if s.App != "" {
+			prefix += s.App + Separator
+		}
+	}
+	return prefix
+}
+
+// ScopeLister is implemented by onboarders that can discover what has been onboarded,
+// e.g. to migrate all secrets to another backend.
+type ScopeLister interface {
+	// ListScopes returns all onboarded environments, teams and applications.
+	ListScopes(ctx context.Context) ([]Scope, error)
+}
@@ -2,29 +2,43 @@ package kubernetes
 
 import (
 	"context"
+	"slices"
+	"strings"
 
 	"github.com/go-logr/logr"
 	"github.com/telekom/controlplane-mono/secret-manager/pkg/backend"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/backend/encrypt"
 	"github.com/tidwall/gjson"
 	"github.com/tidwall/sjson"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
 var _ backend.Backend[Id, backend.DefaultSecret[Id]] = &KubernetesBackend{}
+var _ encrypt.Rewriter[Id] = &KubernetesBackend{}
+
+// HistoryPrefix is the prefix of the keys that contain the past versions of the keys of a Secret.
+// They are stored in the same Secret, so a value and its history are always updated together.
+const HistoryPrefix = ".history."
 
 type KubernetesBackend struct {
 	client               client.Client
 	MatchResourceVersion bool
+
+	// MaxVersions is the number of versions that are kept for each key.
+	// If it is 0, no history is kept and the resourceVersion is used as checksum.
+	MaxVersions int
 }
 
 func NewBackend(client client.Client) backend.Backend[Id, backend.DefaultSecret[Id]] {
 	return &KubernetesBackend{
 		client:               client,
 		MatchResourceVersion: false, // Cannot be set to true, as the resource version can change from different secret changes
+		MaxVersions:          backend.DefaultMaxVersions,
 	}
 }
 
@@ -34,6 +48,10 @@ func (k *KubernetesBackend) ParseSecretId(rawId string) (Id, error) {
 
 func (k *KubernetesBackend) Get(ctx context.Context, secretId Id) (res backend.DefaultSecret[Id], err error) {
 	log := logr.FromContextOrDiscard(ctx)
+	if version, ok := backend.ParseVersion(secretId.checksum); ok {
+		return k.getVersion(ctx, secretId, version)
+	}
+
 	obj := &corev1.Secret{}
 	err = k.client.Get(ctx, secretId.ObjectKey(), obj)
 	if err != nil {
@@ -41,7 +59,7 @@ func (k *KubernetesBackend) Get(ctx context.Context, secretId Id) (res backend.D
 	}
 
 	if k.MatchResourceVersion {
-		if secretId.checksum != "" && secretId.checksum != obj.GetResourceVersion() {
+		if secretId.ResourceVersion() != "" && secretId.ResourceVersion() != obj.GetResourceVersion() {
 			return res, backend.ErrBadChecksum(secretId)
 		}
 	}
@@ -99,12 +117,14 @@ func (k *KubernetesBackend) Set(ctx context.Context, secretId Id, secretValue ba
 	key, subPath := secretId.JsonPath()
 	log.Info("set secret", "key", key, "subPath", subPath)
 
+	var version int
 	mutate := func() error {
 		if k.MatchResourceVersion {
-			if secretId.checksum != "" && secretId.checksum != obj.GetResourceVersion() {
+			if secretId.ResourceVersion() != "" && secretId.ResourceVersion() != obj.GetResourceVersion() {
 				return backend.ErrBadChecksum(secretId)
 			}
 		}
+		previousData := slices.Clone(obj.Data[key])
 
 		if subPath != "" {
 			data, ok := obj.Data[key]
@@ -118,13 +138,16 @@ func (k *KubernetesBackend) Set(ctx context.Context, secretId Id, secretValue ba
 			}
 
 			obj.Data[key] = newData
-			return nil
+		} else {
+			if obj.Data == nil {
+				obj.Data = make(map[string][]byte)
+			}
+			obj.Data[key] = []byte(secretValue.Value())
 		}
 
-		if obj.Data == nil {
-			obj.Data = make(map[string][]byte)
+		if k.MaxVersions > 0 {
+			version = k.addVersion(obj, key, previousData)
 		}
-		obj.Data[key] = []byte(secretValue.Value())
 		return nil
 	}
 	_, err = controllerutil.CreateOrUpdate(ctx, k.client, obj, mutate)
@@ -132,17 +155,161 @@ func (k *KubernetesBackend) Set(ctx context.Context, secretId Id, secretValue ba
 		return res, handleError(err, secretId)
 	}
 
+	// The returned ID references the latest value, as a version expires after MaxVersions writes.
+	// The version that has been written can be read from Versions.
+	log.Info("secret set", "key", key, "version", version)
 	newId := secretId.CopyWithChecksum(obj.GetResourceVersion())
 	return backend.NewDefaultSecret(newId, ""), nil
 }
 
+// Versions returns the IDs of the kept versions of the secret, the latest first.
+// Secrets that have not been changed since the history was introduced have no versions.
+func (k *KubernetesBackend) Versions(ctx context.Context, secretId Id) ([]Id, error) {
+	key, _ := secretId.JsonPath()
+	obj := &corev1.Secret{}
+	err := k.client.Get(ctx, secretId.ObjectKey(), obj)
+	if err != nil {
+		return nil, handleError(err, secretId)
+	}
+
+	versions := versionsOf(obj, key)
+	if len(versions) == 0 {
+		// make sure that the secret itself exists
+		if _, err := k.Get(ctx, secretId.CopyWithChecksum("")); err != nil {
+			return nil, err
+		}
+	}
+
+	ids := make([]Id, 0, len(versions))
+	for _, version := range versions {
+		ids = append(ids, secretId.CopyWithChecksum(backend.MakeVersion(version)))
+	}
+	return ids, nil
+}
+
+// Rewrite applies the function to the value of the key and to all of its versions in a single update.
+// It is used to re-wrap the encrypted values without creating a new version, see encrypt.Rewriter.
+func (k *KubernetesBackend) Rewrite(ctx context.Context, secretId Id, fn func(value string) (string, error)) (changed bool, err error) {
+	key, _ := secretId.JsonPath()
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		changed = false
+		obj := &corev1.Secret{}
+		if err := k.client.Get(ctx, secretId.ObjectKey(), obj); err != nil {
+			return err
+		}
+
+		dataKeys := []string{}
+		if _, ok := obj.Data[key]; ok {
+			dataKeys = append(dataKeys, key)
+		}
+		for _, version := range versionsOf(obj, key) {
+			dataKeys = append(dataKeys, versionKey(key, version))
+		}
+		if len(dataKeys) == 0 {
+			return backend.ErrSecretNotFound(secretId)
+		}
+
+		for _, dataKey := range dataKeys {
+			value, err := fn(string(obj.Data[dataKey]))
+			if err != nil {
+				return err
+			}
+			if value != string(obj.Data[dataKey]) {
+				obj.Data[dataKey] = []byte(value)
+				changed = true
+			}
+		}
+		if !changed {
+			return nil
+		}
+		return k.client.Update(ctx, obj)
+	})
+	if err != nil {
+		return false, handleError(err, secretId)
+	}
+	return changed, nil
+}
+
+func (k *KubernetesBackend) getVersion(ctx context.Context, secretId Id, version int) (res backend.DefaultSecret[Id], err error) {
+	log := logr.FromContextOrDiscard(ctx)
+	key, subPath := secretId.JsonPath()
+	log.Info("get secret version", "key", key, "subPath", subPath, "version", version)
+
+	obj := &corev1.Secret{}
+	err = k.client.Get(ctx, secretId.ObjectKey(), obj)
+	if err != nil {
+		return res, handleError(err, secretId)
+	}
+
+	data, ok := obj.Data[versionKey(key, version)]
+	if !ok {
+		// versions that are older than the kept ones have been removed
+		if versions := versionsOf(obj, key); len(versions) > 0 && version < versions[len(versions)-1] {
+			return res, backend.ErrVersionExpired(secretId, k.MaxVersions)
+		}
+		return res, backend.ErrSecretNotFound(secretId)
+	}
+	if subPath == "" {
+		return backend.NewDefaultSecret(secretId, string(data)), nil
+	}
+	result := gjson.GetBytes(data, subPath)
+	if !result.Exists() {
+		return res, backend.ErrSecretNotFound(secretId)
+	}
+	return backend.NewDefaultSecret(secretId, result.String()), nil
+}
+
+// addVersion stores the new value of the key as a version in the Secret
+// and removes the versions that exceed MaxVersions.
+// If the key has no history yet, the previous value is stored as first version to allow a rollback.
+func (k *KubernetesBackend) addVersion(obj *corev1.Secret, key string, previousData []byte) int {
+	versions := versionsOf(obj, key)
+	if len(versions) == 0 && previousData != nil {
+		obj.Data[versionKey(key, 1)] = previousData
+		versions = []int{1}
+	}
+	version := 1
+	if len(versions) > 0 {
+		version = versions[0] + 1
+	}
+	obj.Data[versionKey(key, version)] = obj.Data[key]
+	for _, old := range versions {
+		if old <= version-k.MaxVersions {
+			delete(obj.Data, versionKey(key, old))
+		}
+	}
+	return version
+}
+
+// versionKey returns the key of a version in the Secret, e.g. .history.clientSecret.v3
+func versionKey(key string, version int) string {
+	return HistoryPrefix + key + "." + backend.MakeVersion(version)
+}
+
+// versionsOf returns the versions of the key that are contained in the Secret, the latest first
+func versionsOf(obj *corev1.Secret, key string) []int {
+	versions := []int{}
+	for historyKey := range obj.Data {
+		suffix, ok := strings.CutPrefix(historyKey, HistoryPrefix+key+".")
+		if !ok {
+			continue
+		}
+		if version, ok := backend.ParseVersion(suffix); ok {
+			versions = append(versions, version)
+		}
+	}
+	slices.Sort(versions)
+	slices.Reverse(versions)
+	return versions
+}
+
 func (k *KubernetesBackend) Delete(ctx context.Context, secretId Id) error {
 	log := logr.FromContextOrDiscard(ctx)
 	obj := NewSecretObj(secretId.env, secretId.team, secretId.app)
 
 	mutate := func() error {
 		if k.MatchResourceVersion {
-			if secretId.checksum != "" && secretId.checksum != obj.GetResourceVersion() {
+			if secretId.ResourceVersion() != "" && secretId.ResourceVersion() != obj.GetResourceVersion() {
 				return backend.ErrBadChecksum(secretId)
 			}
 		}
@@ -155,7 +322,7 @@ func (k *KubernetesBackend) Delete(ctx context.Context, secretId Id) error {
 		log.Info("delete secret", "key", key, "subPath", subPath)
 		if subPath != "" {
 			data, ok := obj.Data[key]
-			if !ok {
+			if !ok || !gjson.GetBytes(data, subPath).Exists() {
 				return backend.ErrSecretNotFound(secretId)
 			}
 			newData, err := sjson.DeleteBytes(data, subPath)
@@ -166,9 +333,10 @@ func (k *KubernetesBackend) Delete(ctx context.Context, secretId Id) error {
 			return nil
 		}
 
-		if obj.Data[key] != nil {
-			delete(obj.Data, key)
+		if _, ok := obj.Data[key]; !ok {
+			return backend.ErrSecretNotFound(secretId)
 		}
+		delete(obj.Data, key)
 		return nil
 	}
 
@@ -180,6 +348,29 @@ func (k *KubernetesBackend) Delete(ctx context.Context, secretId Id) error {
 	return err
 }
 
+func (k *KubernetesBackend) List(ctx context.Context, env, team, app string) (map[string]Id, error) {
+	log := logr.FromContextOrDiscard(ctx)
+	scope := New(env, team, app, "", "")
+	obj := &corev1.Secret{}
+	err := k.client.Get(ctx, scope.ObjectKey(), obj)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, backend.ErrNotFound()
+		}
+		return nil, handleError(err, scope)
+	}
+
+	log.Info("list secrets", "name", obj.Name, "namespace", obj.Namespace)
+	ids := make(map[string]Id, len(obj.Data))
+	for key := range obj.Data {
+		if strings.HasPrefix(key, HistoryPrefix) {
+			continue
+		}
+		ids[key] = New(env, team, app, key, obj.GetResourceVersion())
+	}
+	return ids, nil
+}
+
 func handleError(err error, id Id) error {
 	if backend.IsBackendError(err) {
 		return err
@@ -185,10 +185,10 @@ var _ = Describe("Kubernetes Backend", func() {
 			res, err := k8sBackend.Set(ctx, secretId, secretValue)
 			Expect(err).ToNot(HaveOccurred())
 			Expect(res).ToNot(BeNil())
-			Expect(res.Id().String()).To(Equal("poc:my-team::clientSecret:1000"))
 
 			err = client.Get(ctx, secretId.ObjectKey(), existingSecret)
 			Expect(err).NotTo(HaveOccurred())
+			Expect(res.Id().String()).To(Equal("poc:my-team::clientSecret:" + existingSecret.ResourceVersion))
 			Expect(existingSecret.Data["clientSecret"]).To(Equal([]byte("topsecret")))
 		})
 
@@ -301,5 +301,259 @@ var _ = Describe("Kubernetes Backend", func() {
 			Expect(err).ToNot(HaveOccurred())
 		})
 
+		It("should return not found when the key does not exist", func() {
+			existingSecret := NewSecret("my-app", "poc--my-team", map[string]string{
+				"externalSecrets": "{}",
+			})
+			k8sBackend := kubernetes.NewBackend(NewMockK8sClient(existingSecret))
+
+			err := k8sBackend.Delete(ctx, kubernetes.New("poc", "my-team", "my-app", "clientSecret", ""))
+			Expect(backend.IsNotFoundErr(err)).To(BeTrue())
+		})
+
+		It("should return not found when the sub-path does not exist", func() {
+			existingSecret := NewSecret("my-app", "poc--my-team", map[string]string{
+				"externalSecrets": `{"foo":"bar"}`,
+			})
+			k8sClient := NewMockK8sClient(existingSecret)
+			k8sBackend := kubernetes.NewBackend(k8sClient)
+
+			err := k8sBackend.Delete(ctx, kubernetes.New("poc", "my-team", "my-app", "externalSecrets/baz", ""))
+			Expect(backend.IsNotFoundErr(err)).To(BeTrue())
+
+			err = k8sBackend.Delete(ctx, kubernetes.New("poc", "my-team", "my-app", "externalSecrets/foo", ""))
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = k8sBackend.Get(ctx, kubernetes.New("poc", "my-team", "my-app", "externalSecrets/foo", ""))
+			Expect(backend.IsNotFoundErr(err)).To(BeTrue())
+		})
+
+	})
+
+	Context("Versions", func() {
+
+		It("should keep the previous value when a secret is changed for the first time", func() {
+			existingSecret := NewSecret("my-app", "poc--my-team", map[string]string{
+				"clientSecret": "topsecret",
+			})
+			k8sBackend := kubernetes.NewBackend(NewMockK8sClient(existingSecret))
+
+			res, err := k8sBackend.Set(ctx, kubernetes.New("poc", "my-team", "my-app", "clientSecret", ""), backend.String("new-topsecret"))
+			Expect(err).ToNot(HaveOccurred())
+
+			ids, err := k8sBackend.Versions(ctx, res.Id())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ids).To(HaveLen(2))
+			Expect(ids[0].String()).To(Equal("poc:my-team:my-app:clientSecret:v2"))
+			Expect(ids[1].String()).To(Equal("poc:my-team:my-app:clientSecret:v1"))
+
+			previous, err := k8sBackend.Get(ctx, ids[1])
+			Expect(err).ToNot(HaveOccurred())
+			Expect(previous.Value()).To(Equal("topsecret"))
+
+			latest, err := k8sBackend.Get(ctx, kubernetes.New("poc", "my-team", "my-app", "clientSecret", ""))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(latest.Value()).To(Equal("new-topsecret"))
+		})
+
+		It("should only keep the configured number of versions", func() {
+			existingSecret := NewSecret("my-app", "poc--my-team", map[string]string{
+				"externalSecrets": `{"foo": "v0"}`,
+			})
+			k8sClient := NewMockK8sClient(existingSecret)
+			k8sBackend := kubernetes.NewBackend(k8sClient).(*kubernetes.KubernetesBackend)
+			k8sBackend.MaxVersions = 2
+
+			secretId := kubernetes.New("poc", "my-team", "my-app", "externalSecrets/foo", "")
+			for _, value := range []string{"a", "b", "c"} {
+				_, err := k8sBackend.Set(ctx, secretId, backend.String(value))
+				Expect(err).ToNot(HaveOccurred())
+			}
+
+			ids, err := k8sBackend.Versions(ctx, secretId)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ids).To(HaveLen(2))
+			Expect(ids[0].String()).To(Equal("poc:my-team:my-app:externalSecrets/foo:v4"))
+
+			res, err := k8sBackend.Get(ctx, ids[1])
+			Expect(err).ToNot(HaveOccurred())
+			Expect(res.Value()).To(Equal("b"))
+
+			_, err = k8sBackend.Get(ctx, kubernetes.New("poc", "my-team", "my-app", "externalSecrets/foo", "v1"))
+			Expect(backend.IsVersionExpiredErr(err)).To(BeTrue())
+
+			_, err = k8sBackend.Get(ctx, kubernetes.New("poc", "my-team", "my-app", "externalSecrets/foo", "v5"))
+			Expect(backend.IsNotFoundErr(err)).To(BeTrue())
+		})
+
+		It("should store the versions in the same Secret as the value", func() {
+			existingSecret := NewSecret("my-app", "poc--my-team", map[string]string{
+				"clientSecret": "topsecret",
+			})
+			k8sClient := NewMockK8sClient(existingSecret)
+			k8sBackend := kubernetes.NewBackend(k8sClient)
+
+			_, err := k8sBackend.Set(ctx, kubernetes.New("poc", "my-team", "my-app", "clientSecret", ""), backend.String("new-topsecret"))
+			Expect(err).ToNot(HaveOccurred())
+
+			obj := &corev1.Secret{}
+			err = k8sClient.Get(ctx, client.ObjectKeyFromObject(existingSecret), obj)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(obj.Data).To(HaveKeyWithValue("clientSecret", []byte("new-topsecret")))
+			Expect(obj.Data).To(HaveKeyWithValue(kubernetes.HistoryPrefix+"clientSecret.v1", []byte("topsecret")))
+			Expect(obj.Data).To(HaveKeyWithValue(kubernetes.HistoryPrefix+"clientSecret.v2", []byte("new-topsecret")))
+
+			ids, err := k8sBackend.List(ctx, "poc", "my-team", "my-app")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ids).To(HaveLen(1))
+			Expect(ids).To(HaveKey("clientSecret"))
+
+			_, err = k8sBackend.ParseSecretId("poc:my-team:my-app:" + kubernetes.HistoryPrefix + "clientSecret.v1:")
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should rewrite the value and its versions without creating a new version", func() {
+			existingSecret := NewSecret("my-app", "poc--my-team", map[string]string{
+				"clientSecret": "a",
+				"other":        "a",
+			})
+			k8sClient := NewMockK8sClient(existingSecret)
+			k8sBackend := kubernetes.NewBackend(k8sClient).(*kubernetes.KubernetesBackend)
+
+			secretId := kubernetes.New("poc", "my-team", "my-app", "clientSecret", "")
+			_, err := k8sBackend.Set(ctx, secretId, backend.String("b"))
+			Expect(err).ToNot(HaveOccurred())
+
+			changed, err := k8sBackend.Rewrite(ctx, secretId, func(value string) (string, error) {
+				return value + "-rewritten", nil
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(changed).To(BeTrue())
+
+			obj := &corev1.Secret{}
+			err = k8sClient.Get(ctx, client.ObjectKeyFromObject(existingSecret), obj)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(obj.Data).To(HaveKeyWithValue("clientSecret", []byte("b-rewritten")))
+			Expect(obj.Data).To(HaveKeyWithValue(kubernetes.HistoryPrefix+"clientSecret.v1", []byte("a-rewritten")))
+			Expect(obj.Data).To(HaveKeyWithValue(kubernetes.HistoryPrefix+"clientSecret.v2", []byte("b-rewritten")))
+			Expect(obj.Data).To(HaveKeyWithValue("other", []byte("a")))
+
+			ids, err := k8sBackend.Versions(ctx, secretId)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ids).To(HaveLen(2))
+
+			_, err = k8sBackend.Rewrite(ctx, kubernetes.New("poc", "my-team", "my-app", "unknown", ""), func(value string) (string, error) {
+				return value, nil
+			})
+			Expect(backend.IsNotFoundErr(err)).To(BeTrue())
+		})
+
+		It("should return no versions for a secret without history", func() {
+			existingSecret := NewSecret("my-team", "poc", map[string]string{
+				"clientSecret": "topsecret",
+			})
+			k8sBackend := kubernetes.NewBackend(NewMockK8sClient(existingSecret))
+
+			ids, err := k8sBackend.Versions(ctx, kubernetes.New("poc", "my-team", "", "clientSecret", ""))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ids).To(BeEmpty())
+
+			_, err = k8sBackend.Versions(ctx, kubernetes.New("poc", "my-team", "", "unknown", ""))
+			Expect(backend.IsNotFoundErr(err)).To(BeTrue())
+		})
+
+		It("should use the resourceVersion if no history is kept", func() {
+			existingSecret := NewSecret("my-team", "poc", map[string]string{
+				"clientSecret": "topsecret",
+			})
+			k8sBackend := kubernetes.NewBackend(NewMockK8sClient(existingSecret)).(*kubernetes.KubernetesBackend)
+			k8sBackend.MaxVersions = 0
+
+			res, err := k8sBackend.Set(ctx, kubernetes.New("poc", "my-team", "", "clientSecret", ""), backend.String("new"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(res.Id().String()).To(Equal("poc:my-team::clientSecret:1000"))
+		})
+
+		It("should return an ID that references the latest value after the written version has expired", func() {
+			existingSecret := NewSecret("my-team", "poc", map[string]string{
+				"clientSecret": "topsecret",
+			})
+			k8sBackend := kubernetes.NewBackend(NewMockK8sClient(existingSecret)).(*kubernetes.KubernetesBackend)
+			k8sBackend.MaxVersions = 2
+
+			secretId := kubernetes.New("poc", "my-team", "", "clientSecret", "")
+			res, err := k8sBackend.Set(ctx, secretId, backend.String("a"))
+			Expect(err).ToNot(HaveOccurred())
+			for _, value := range []string{"b", "c"} {
+				_, err = k8sBackend.Set(ctx, secretId, backend.String(value))
+				Expect(err).ToNot(HaveOccurred())
+			}
+
+			secret, err := k8sBackend.Get(ctx, res.Id())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(secret.Value()).To(Equal("c"))
+
+			// the written version can still be pinned explicitly
+			ids, err := k8sBackend.Versions(ctx, secretId)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ids[0].String()).To(Equal("poc:my-team::clientSecret:v4"))
+		})
+	})
+
+	Context("List Secrets", func() {
+
+		It("should return an error when the scope does not exist", func() {
+			k8sBackend := kubernetes.NewBackend(NewMockK8sClient())
+
+			_, err := k8sBackend.List(ctx, "poc", "my-team", "my-app")
+			Expect(err).To(HaveOccurred())
+			Expect(backend.IsNotFoundErr(err)).To(BeTrue())
+		})
+
+		It("should list the app secrets without values", func() {
+			existingSecret := NewSecret("my-app", "poc--my-team", map[string]string{
+				"clientSecret":    "topsecret",
+				"externalSecrets": "{}",
+			})
+			k8sBackend := kubernetes.NewBackend(NewMockK8sClient(existingSecret))
+
+			ids, err := k8sBackend.List(ctx, "poc", "my-team", "my-app")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ids).To(HaveLen(2))
+			Expect(ids).To(HaveKey("clientSecret"))
+			Expect(ids).To(HaveKey("externalSecrets"))
+
+			id := ids["clientSecret"]
+			Expect(id.ObjectKey()).To(Equal(client.ObjectKeyFromObject(existingSecret)))
+			Expect(id.String()).To(Equal("poc:my-team:my-app:clientSecret:" + existingSecret.ResourceVersion))
+		})
+
+		It("should list the same version that was returned when the secret was set", func() {
+			existingSecret := NewSecret("my-app", "poc--my-team", map[string]string{
+				"clientSecret":    "topsecret",
+				"externalSecrets": "{}",
+			})
+			k8sBackend := kubernetes.NewBackend(NewMockK8sClient(existingSecret))
+
+			res, err := k8sBackend.Set(ctx, kubernetes.New("poc", "my-team", "my-app", "clientSecret", ""), backend.String("new-topsecret"))
+			Expect(err).ToNot(HaveOccurred())
+
+			ids, err := k8sBackend.List(ctx, "poc", "my-team", "my-app")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ids["clientSecret"].String()).To(Equal(res.Id().String()))
+			Expect(ids["externalSecrets"].String()).ToNot(HaveSuffix(":v1"))
+		})
+
+		It("should list the environment secrets", func() {
+			existingSecret := NewSecret("secrets", "poc", map[string]string{
+				"zones": "zone-a",
+			})
+			k8sBackend := kubernetes.NewBackend(NewMockK8sClient(existingSecret))
+
+			ids, err := k8sBackend.List(ctx, "poc", "", "")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ids).To(HaveLen(1))
+			Expect(ids["zones"].String()).To(HavePrefix("poc:::zones:"))
+		})
 	})
 })
@@ -14,7 +14,15 @@ import (
 	crscheme "sigs.k8s.io/controller-runtime/pkg/scheme"
 )
 
+// NewCachedClient creates a client that reads the Secrets managed by the secret-manager from an informer cache
 func NewCachedClient(ctx context.Context, restCfg *rest.Config) (client.Client, error) {
+	k8sClient, _, err := NewInformerClient(ctx, restCfg)
+	return k8sClient, err
+}
+
+// NewInformerClient is like NewCachedClient but also returns the informers of the cache,
+// e.g. to be notified about changes of the Secrets using InvalidateOnChange.
+func NewInformerClient(ctx context.Context, restCfg *rest.Config) (client.Client, cache.Informers, error) {
 	restCfg.UserAgent = "secret-manager"
 
 	scheme := runtime.NewScheme()
@@ -23,12 +31,12 @@ func NewCachedClient(ctx context.Context, restCfg *rest.Config) (client.Client,
 	}).Register(&corev1.Secret{}, &corev1.SecretList{}).AddToScheme(scheme)
 
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to register scheme")
+		return nil, nil, errors.Wrap(err, "failed to register scheme")
 	}
 
 	managedOnly, err := labels.NewRequirement("app.kubernetes.io/managed-by", selection.DoubleEquals, []string{"secret-manager"})
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to create label requirement")
+		return nil, nil, errors.Wrap(err, "failed to create label requirement")
 	}
 
 	k8sCache, err := cache.New(restCfg, cache.Options{
@@ -36,7 +44,7 @@ func NewCachedClient(ctx context.Context, restCfg *rest.Config) (client.Client,
 		DefaultLabelSelector: labels.NewSelector().Add(*managedOnly),
 	})
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to create cache")
+		return nil, nil, errors.Wrap(err, "failed to create cache")
 	}
 
 	go func() {
@@ -44,11 +52,11 @@ func NewCachedClient(ctx context.Context, restCfg *rest.Config) (client.Client,
 	}()
 
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to start cache")
+		return nil, nil, errors.Wrap(err, "failed to start cache")
 	}
 
 	if !k8sCache.WaitForCacheSync(ctx) {
-		return nil, errors.New("failed to sync cache")
+		return nil, nil, errors.New("failed to sync cache")
 	}
 
 	k8sClient, err := client.New(restCfg, client.Options{
@@ -59,8 +67,8 @@ func NewCachedClient(ctx context.Context, restCfg *rest.Config) (client.Client,
 		Scheme: scheme,
 	})
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to create Kubernetes client")
+		return nil, nil, errors.Wrap(err, "failed to create Kubernetes client")
 	}
 
-	return k8sClient, err
+	return k8sClient, k8sCache, nil
 }
@@ -36,28 +36,23 @@ func New(env, team, app, path string, checksum string) Id {
 }
 
 func FromString(raw string) (id Id, err error) {
-	parts := strings.Split(raw, backend.Separator)
-	if len(parts) != 5 {
-		return id, backend.ErrInvalidSecretId(raw)
-	}
-
-	id = Id{
-		Raw:      raw,
-		env:      parts[0],
-		team:     parts[1],
-		app:      parts[2],
-		path:     parts[3],
-		checksum: parts[4],
-	}
-
-	if id.env == "" {
-		return id, backend.ErrInvalidSecretId(raw)
+	parsed, err := backend.ParseId(raw)
+	if err != nil {
+		return id, err
 	}
-	if id.app != "" && id.team == "" {
+	if strings.HasPrefix(parsed.Path, HistoryPrefix) {
+		// these keys contain the versions of the secrets
 		return id, backend.ErrInvalidSecretId(raw)
 	}
 
-	return id, nil
+	return Id{
+		Raw:      raw,
+		env:      parsed.Env,
+		team:     parsed.Team,
+		app:      parsed.App,
+		path:     parsed.Path,
+		checksum: parsed.Checksum,
+	}, nil
 }
 
 func (id Id) Env() string {
@@ -68,6 +63,15 @@ func (id Id) String() string {
 	return fmt.Sprintf("%s:%s:%s:%s:%s", id.env, id.team, id.app, id.path, id.checksum)
 }
 
+// ResourceVersion returns the checksum if it is a resourceVersion of the Secret.
+// It is empty if the ID references a version of the secret.
+func (id Id) ResourceVersion() string {
+	if _, ok := backend.ParseVersion(id.checksum); ok {
+		return ""
+	}
+	return id.checksum
+}
+
 func (id Id) Namespace() string {
 	if id.app == "" {
 		// if app is empty, this must be an env or team secrets
@@ -0,0 +1,64 @@
+package kubernetes
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/backend"
+	corev1 "k8s.io/api/core/v1"
+	toolscache "k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+)
+
+// Invalidator removes cached secrets, e.g. the cache.CachedBackend
+type Invalidator interface {
+	// Invalidate removes all secrets whose IDs start with the prefix
+	Invalidate(prefix string)
+}
+
+// InvalidateOnChange invalidates the cached secrets of every Secret that is created, changed or deleted,
+// e.g. by another replica or manually, instead of waiting for the cached values to expire.
+func InvalidateOnChange(ctx context.Context, informers cache.Informers, invalidator Invalidator) error {
+	informer, err := informers.GetInformer(ctx, &corev1.Secret{})
+	if err != nil {
+		return errors.Wrap(err, "failed to get informer for secrets")
+	}
+
+	invalidate := func(obj any) {
+		if tombstone, ok := obj.(toolscache.DeletedFinalStateUnknown); ok {
+			obj = tombstone.Obj
+		}
+		if secret, ok := obj.(*corev1.Secret); ok {
+			if prefix, ok := SecretPrefix(secret); ok {
+				invalidator.Invalidate(prefix)
+			}
+		}
+	}
+	_, err = informer.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+		AddFunc: invalidate,
+		UpdateFunc: func(oldObj, newObj any) {
+			oldSecret, oldOk := oldObj.(*corev1.Secret)
+			newSecret, newOk := newObj.(*corev1.Secret)
+			// Periodic resyncs do not change the Secret
+			if oldOk && newOk && oldSecret.ResourceVersion == newSecret.ResourceVersion {
+				return
+			}
+			invalidate(newObj)
+		},
+		DeleteFunc: invalidate,
+	})
+	return errors.Wrap(err, "failed to add event handler for secrets")
+}
+
+// SecretPrefix returns the prefix of the IDs of all secrets that are stored in the Secret, e.g. `env:team:app:`.
+func SecretPrefix(obj *corev1.Secret) (string, bool) {
+	scope := backend.Scope{
+		Env:  obj.Labels["cp.ei.telekom.de/environment"],
+		Team: obj.Labels["cp.ei.telekom.de/team"],
+		App:  obj.Labels["cp.ei.telekom.de/application"],
+	}
+	if scope.Env == "" {
+		return "", false
+	}
+	return scope.String() + backend.Separator, true
+}
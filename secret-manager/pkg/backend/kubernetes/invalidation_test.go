@@ -0,0 +1,85 @@
+package kubernetes_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/backend/kubernetes"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	toolscache "k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// fakeInformers only provides the informer for Secrets and records its event handler
+type fakeInformers struct {
+	cache.Informers
+	informer *fakeInformer
+}
+
+func (f *fakeInformers) GetInformer(_ context.Context, _ client.Object, _ ...cache.InformerGetOption) (cache.Informer, error) {
+	return f.informer, nil
+}
+
+type fakeInformer struct {
+	cache.Informer
+	handler toolscache.ResourceEventHandler
+}
+
+func (f *fakeInformer) AddEventHandler(handler toolscache.ResourceEventHandler) (toolscache.ResourceEventHandlerRegistration, error) {
+	f.handler = handler
+	return nil, nil
+}
+
+type recordingInvalidator struct {
+	prefixes []string
+}
+
+func (r *recordingInvalidator) Invalidate(prefix string) {
+	r.prefixes = append(r.prefixes, prefix)
+}
+
+var _ = Describe("Cache Invalidation", func() {
+
+	var ctx context.Context
+	var informer *fakeInformer
+	var invalidator *recordingInvalidator
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		informer = &fakeInformer{}
+		invalidator = &recordingInvalidator{}
+		Expect(kubernetes.InvalidateOnChange(ctx, &fakeInformers{informer: informer}, invalidator)).To(Succeed())
+	})
+
+	It("should invalidate the secrets of a changed Secret", func() {
+		oldObj := kubernetes.NewSecretObj("env", "team", "app")
+		oldObj.ResourceVersion = "1"
+		newObj := oldObj.DeepCopy()
+		newObj.ResourceVersion = "2"
+
+		informer.handler.OnUpdate(oldObj, newObj)
+		Expect(invalidator.prefixes).To(Equal([]string{"env:team:app:"}))
+	})
+
+	It("should ignore resyncs", func() {
+		obj := kubernetes.NewSecretObj("env", "team", "app")
+		obj.ResourceVersion = "1"
+
+		informer.handler.OnUpdate(obj, obj.DeepCopy())
+		Expect(invalidator.prefixes).To(BeEmpty())
+	})
+
+	It("should invalidate the secrets of created and deleted Secrets", func() {
+		informer.handler.OnAdd(kubernetes.NewSecretObj("env", "", ""), false)
+		informer.handler.OnDelete(kubernetes.NewSecretObj("env", "team", ""))
+		Expect(invalidator.prefixes).To(Equal([]string{"env:::", "env:team::"}))
+	})
+
+	It("should ignore Secrets without environment", func() {
+		informer.handler.OnAdd(&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "bar"}}, false)
+		Expect(invalidator.prefixes).To(BeEmpty())
+	})
+})
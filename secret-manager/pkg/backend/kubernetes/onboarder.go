@@ -3,8 +3,8 @@ package kubernetes
 import (
 	"context"
 
-	"github.com/google/uuid"
 	"github.com/telekom/controlplane-mono/secret-manager/pkg/backend"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/backend/encrypt"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -15,17 +15,61 @@ import (
 const FinalizerName = "secret-manager/finalizer"
 
 var _ backend.Onboarder = &KubernetesOnboarder{}
+var _ backend.ScopeLister = &KubernetesOnboarder{}
 
 type KubernetesOnboarder struct {
-	client client.Client
+	client    client.Client
+	encrypter encrypt.Encrypter
+	blueprint backend.Blueprint
 }
 
 func NewOnboarder(client client.Client) *KubernetesOnboarder {
 	return &KubernetesOnboarder{
-		client: client,
+		client:    client,
+		blueprint: backend.DefaultBlueprint(),
 	}
 }
 
+// WithBlueprint sets the secrets that are created for each level
+func (k *KubernetesOnboarder) WithBlueprint(blueprint backend.Blueprint) *KubernetesOnboarder {
+	k.blueprint = blueprint
+	return k
+}
+
+// WithEncrypter is used to encrypt the generated secrets before they are stored.
+// It must be the same encrypter that is used by the backend.
+func (k *KubernetesOnboarder) WithEncrypter(encrypter encrypt.Encrypter) *KubernetesOnboarder {
+	k.encrypter = encrypter
+	return k
+}
+
+// initialData returns the initial values of the secrets of the blueprint.
+// The generated values are encrypted if an encrypter is configured.
+func (k *KubernetesOnboarder) initialData(blueprint backend.LevelBlueprint, scope backend.Scope) (map[string][]byte, error) {
+	data := make(map[string]string, len(blueprint.Secrets))
+	for _, secret := range blueprint.Secrets {
+		value, generated, err := secret.InitialValue(scope)
+		if err != nil {
+			return nil, err
+		}
+		if generated && k.encrypter != nil {
+			if value, err = k.encrypter.Encrypt(value, encrypt.AdditionalData(scope, secret.Name)); err != nil {
+				return nil, err
+			}
+		}
+		data[secret.Name] = value
+	}
+	return convertToDataFormat(data), nil
+}
+
+func secretRefs(blueprint backend.LevelBlueprint, env, teamId, appId, resourceVersion string) map[string]backend.SecretRef {
+	secretRefs := make(map[string]backend.SecretRef, len(blueprint.Secrets))
+	for _, secret := range blueprint.Names() {
+		secretRefs[secret] = New(env, teamId, appId, secret, resourceVersion)
+	}
+	return secretRefs
+}
+
 func (k *KubernetesOnboarder) OnboardEnvironment(ctx context.Context, env string) (backend.OnboardResponse, error) {
 	obj := NewSecretObj(env, "", "")
 
@@ -38,9 +82,11 @@ func (k *KubernetesOnboarder) OnboardEnvironment(ctx context.Context, env string
 		}
 		obj.Type = corev1.SecretTypeOpaque
 		if obj.Data == nil {
-			obj.Data = map[string][]byte{
-				"zones": []byte(""),
+			data, err := k.initialData(k.blueprint.Environment, backend.Scope{Env: env})
+			if err != nil {
+				return err
 			}
+			obj.Data = data
 		}
 
 		return nil
@@ -50,12 +96,7 @@ func (k *KubernetesOnboarder) OnboardEnvironment(ctx context.Context, env string
 		return backend.NewDefaultOnboardResponse(nil), backend.NewBackendError(nil, err, "failed to create or update environment")
 	}
 
-	secretRefs := make(map[string]backend.SecretRef, len(backend.EnvironmentSecrets))
-	for _, secret := range backend.EnvironmentSecrets {
-		secretRefs[secret] = New(env, "", "", secret, obj.GetResourceVersion())
-	}
-
-	return backend.NewDefaultOnboardResponse(secretRefs), nil
+	return backend.NewDefaultOnboardResponse(secretRefs(k.blueprint.Environment, env, "", "", obj.GetResourceVersion())), nil
 }
 
 func (k *KubernetesOnboarder) OnboardTeam(ctx context.Context, env string, teamId string) (backend.OnboardResponse, error) {
@@ -71,10 +112,11 @@ func (k *KubernetesOnboarder) OnboardTeam(ctx context.Context, env string, teamI
 		}
 		obj.Type = corev1.SecretTypeOpaque
 		if obj.Data == nil { // Only do the initial onboarding. After that, the data can only be changed using the secrets-API
-			obj.Data = map[string][]byte{
-				"clientSecret": []byte(uuid.NewString()),
-				"teamToken":    []byte(uuid.NewString()),
+			data, err := k.initialData(k.blueprint.Team, backend.Scope{Env: env, Team: teamId})
+			if err != nil {
+				return err
 			}
+			obj.Data = data
 		}
 		return nil
 	}
@@ -84,12 +126,7 @@ func (k *KubernetesOnboarder) OnboardTeam(ctx context.Context, env string, teamI
 		return backend.NewDefaultOnboardResponse(nil), backend.NewBackendError(nil, err, "failed to create or update team")
 	}
 
-	secretRefs := make(map[string]backend.SecretRef, len(backend.TeamSecrets))
-	for _, secret := range backend.TeamSecrets {
-		secretRefs[secret] = New(env, teamId, "", secret, obj.GetResourceVersion())
-	}
-
-	return backend.NewDefaultOnboardResponse(secretRefs), nil
+	return backend.NewDefaultOnboardResponse(secretRefs(k.blueprint.Team, env, teamId, "", obj.GetResourceVersion())), nil
 }
 
 func (k *KubernetesOnboarder) OnboardApplication(ctx context.Context, env string, teamId string, appId string) (backend.OnboardResponse, error) {
@@ -99,11 +136,11 @@ func (k *KubernetesOnboarder) OnboardApplication(ctx context.Context, env string
 		controllerutil.AddFinalizer(obj, FinalizerName)
 
 		if obj.Data == nil { // Only do the initial onboarding. After that, the data can only be changed using the secrets-API
-			appData := map[string]string{
-				"clientSecret":    uuid.NewString(),
-				"externalSecrets": "{}",
+			data, err := k.initialData(k.blueprint.Application, backend.Scope{Env: env, Team: teamId, App: appId})
+			if err != nil {
+				return err
 			}
-			obj.Data = convertToDataFormat(appData)
+			obj.Data = data
 		}
 		return nil
 	}
@@ -113,12 +150,7 @@ func (k *KubernetesOnboarder) OnboardApplication(ctx context.Context, env string
 		return backend.NewDefaultOnboardResponse(nil), backend.NewBackendError(nil, err, "failed to create or update application")
 	}
 
-	secretRefs := make(map[string]backend.SecretRef, len(backend.ApplicationSecrets))
-	for _, secret := range backend.ApplicationSecrets {
-		secretRefs[secret] = New(env, teamId, appId, secret, obj.GetResourceVersion())
-	}
-
-	return backend.NewDefaultOnboardResponse(secretRefs), nil
+	return backend.NewDefaultOnboardResponse(secretRefs(k.blueprint.Application, env, teamId, appId, obj.GetResourceVersion())), nil
 }
 
 func (k *KubernetesOnboarder) DeleteEnvironment(ctx context.Context, env string) error {
@@ -181,6 +213,33 @@ func (k *KubernetesOnboarder) DeleteApplication(ctx context.Context, env string,
 	return nil
 }
 
+// ListScopes returns the scopes of all Secrets that are managed by the secret-manager.
+// The scope is taken from the labels of the Secret.
+func (k *KubernetesOnboarder) ListScopes(ctx context.Context) ([]backend.Scope, error) {
+	list := &corev1.SecretList{}
+	err := k.client.List(ctx, list, client.MatchingLabels{"app.kubernetes.io/managed-by": "secret-manager"})
+	if err != nil {
+		return nil, backend.NewBackendError(nil, err, "failed to list secrets")
+	}
+
+	scopes := make([]backend.Scope, 0, len(list.Items))
+	for _, obj := range list.Items {
+		if !obj.DeletionTimestamp.IsZero() {
+			continue
+		}
+		scope := backend.Scope{
+			Env:  obj.Labels["cp.ei.telekom.de/environment"],
+			Team: obj.Labels["cp.ei.telekom.de/team"],
+			App:  obj.Labels["cp.ei.telekom.de/application"],
+		}
+		if scope.Env == "" {
+			continue
+		}
+		scopes = append(scopes, scope)
+	}
+	return scopes, nil
+}
+
 func NewSecretObj(env, teamId, appId string) *corev1.Secret {
 	id := New(env, teamId, appId, "", "")
 	ref := id.ObjectKey()
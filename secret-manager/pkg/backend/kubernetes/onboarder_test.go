@@ -6,7 +6,10 @@ import (
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/backend"
 	"github.com/telekom/controlplane-mono/secret-manager/pkg/backend/kubernetes"
+	"github.com/telekom/controlplane-mono/secret-manager/test/mocks"
 	corev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -123,5 +126,57 @@ var _ = Describe("Kubernetes Onboarder", func() {
 			err = mockK8sClient.Get(ctx, client.ObjectKey{Name: appId, Namespace: fmt.Sprintf("%s--%s", env, teamId)}, secret)
 			Expect(err).To(HaveOccurred())
 		})
+
+		It("should encrypt the generated secrets", func() {
+			encrypter := mocks.NewMockEncrypter(GinkgoT())
+			encrypter.EXPECT().Encrypt(mock.Anything, env+":"+teamId+":"+appId+":clientSecret").Return("encrypted", nil).Times(1)
+			onboarder := kubernetes.NewOnboarder(mockK8sClient).WithEncrypter(encrypter)
+
+			_, err := onboarder.OnboardApplication(ctx, env, teamId, appId)
+			Expect(err).ToNot(HaveOccurred())
+
+			secret := &corev1.Secret{}
+			err = mockK8sClient.Get(ctx, client.ObjectKey{Name: appId, Namespace: fmt.Sprintf("%s--%s", env, teamId)}, secret)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(secret.Data["clientSecret"])).To(Equal("encrypted"))
+			Expect(string(secret.Data["externalSecrets"])).To(Equal("{}"))
+		})
+
+		It("should create the secrets of the blueprint", func() {
+			blueprint := backend.DefaultBlueprint()
+			blueprint.Application.Secrets = append(blueprint.Application.Secrets, backend.SecretBlueprint{Name: "webhookSecret", Value: "initial"})
+			onboarder := kubernetes.NewOnboarder(mockK8sClient).WithBlueprint(blueprint)
+
+			res, err := onboarder.OnboardApplication(ctx, env, teamId, appId)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(res.SecretRefs()).To(HaveKey("webhookSecret"))
+
+			secret := &corev1.Secret{}
+			err = mockK8sClient.Get(ctx, client.ObjectKey{Name: appId, Namespace: fmt.Sprintf("%s--%s", env, teamId)}, secret)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(secret.Data).To(HaveKey("clientSecret"))
+			Expect(string(secret.Data["webhookSecret"])).To(Equal("initial"))
+		})
+	})
+
+	Context("List Scopes", func() {
+
+		It("should list the onboarded scopes", func() {
+			onboarder := kubernetes.NewOnboarder(mockK8sClient)
+			_, err := onboarder.OnboardEnvironment(ctx, env)
+			Expect(err).ToNot(HaveOccurred())
+			_, err = onboarder.OnboardTeam(ctx, env, teamId)
+			Expect(err).ToNot(HaveOccurred())
+			_, err = onboarder.OnboardApplication(ctx, env, teamId, appId)
+			Expect(err).ToNot(HaveOccurred())
+
+			scopes, err := onboarder.ListScopes(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(scopes).To(ConsistOf(
+				backend.Scope{Env: env},
+				backend.Scope{Env: env, Team: teamId},
+				backend.Scope{Env: env, Team: teamId, App: appId},
+			))
+		})
 	})
 })
@@ -0,0 +1,249 @@
+package routing
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/backend"
+)
+
+var _ AnyBackend = &RoutingBackend{}
+var _ Onboarder = &RoutingBackend{}
+
+// RoutingBackend stores each secret in the backend of the first route whose rule matches its ID.
+// Environments, teams and applications are onboarded in the backend of the route that stores their secrets.
+type RoutingBackend struct {
+	routes []Route
+}
+
+// routedId remembers the route of the ID
+type routedId struct {
+	backend.SecretId
+	route *Route
+}
+
+// NewRoutingBackend creates a backend that routes the secrets in the order of the routes
+func NewRoutingBackend(routes ...Route) (*RoutingBackend, error) {
+	if len(routes) == 0 {
+		return nil, errors.New("at least one route must be configured")
+	}
+	for i, route := range routes {
+		if route.Backend == nil || route.Onboarder == nil {
+			return nil, errors.Errorf("backend of route %s must be set", route.Rule)
+		}
+		for _, previous := range routes[:i] {
+			if previous.Rule.equal(route.Rule) {
+				return nil, errors.Errorf("route %s is configured twice", route.Rule)
+			}
+		}
+	}
+	return &RoutingBackend{routes: routes}, nil
+}
+
+// route returns the first route that matches the ID or the prefix of a scope
+func (b *RoutingBackend) route(id string) (*Route, error) {
+	for i := range b.routes {
+		if b.routes[i].Rule.Matches(id) {
+			return &b.routes[i], nil
+		}
+	}
+	return nil, backend.NewBackendError(nil, errors.Errorf("no backend is configured for '%s'", id), backend.TypeErrInvalidSecretId)
+}
+
+// routeOf returns the route of the ID. IDs that have not been parsed by this backend are routed again.
+func (b *RoutingBackend) routeOf(id backend.SecretId) (*Route, backend.SecretId, error) {
+	if routed, ok := id.(routedId); ok {
+		return routed.route, routed.SecretId, nil
+	}
+	route, err := b.route(id.String())
+	if err != nil {
+		return nil, nil, err
+	}
+	return route, id, nil
+}
+
+func (b *RoutingBackend) ParseSecretId(raw string) (backend.SecretId, error) {
+	if _, err := backend.ParseId(raw); err != nil {
+		return nil, err
+	}
+	route, err := b.route(raw)
+	if err != nil {
+		return nil, err
+	}
+	id, err := route.Backend.ParseSecretId(raw)
+	if err != nil {
+		return nil, err
+	}
+	return routedId{SecretId: id, route: route}, nil
+}
+
+func (b *RoutingBackend) Get(ctx context.Context, id backend.SecretId) (backend.Secret[backend.SecretId], error) {
+	route, id, err := b.routeOf(id)
+	if err != nil {
+		return nil, err
+	}
+	return route.Backend.Get(ctx, id)
+}
+
+func (b *RoutingBackend) Set(ctx context.Context, id backend.SecretId, value backend.SecretValue) (backend.Secret[backend.SecretId], error) {
+	route, id, err := b.routeOf(id)
+	if err != nil {
+		return nil, err
+	}
+	return route.Backend.Set(ctx, id, value)
+}
+
+func (b *RoutingBackend) Delete(ctx context.Context, id backend.SecretId) error {
+	route, id, err := b.routeOf(id)
+	if err != nil {
+		return err
+	}
+	return route.Backend.Delete(ctx, id)
+}
+
+func (b *RoutingBackend) List(ctx context.Context, env, team, app string) (map[string]backend.SecretId, error) {
+	route, err := b.route(scopePrefix(backend.Scope{Env: env, Team: team, App: app}))
+	if err != nil {
+		return nil, err
+	}
+	return route.Backend.List(ctx, env, team, app)
+}
+
+func (b *RoutingBackend) Versions(ctx context.Context, id backend.SecretId) ([]backend.SecretId, error) {
+	route, id, err := b.routeOf(id)
+	if err != nil {
+		return nil, err
+	}
+	return route.Backend.Versions(ctx, id)
+}
+
+// OnboardEnvironment onboards the environment in the backend of its secrets
+func (b *RoutingBackend) OnboardEnvironment(ctx context.Context, env string) (backend.OnboardResponse, error) {
+	route, err := b.route(scopePrefix(backend.Scope{Env: env}))
+	if err != nil {
+		return nil, err
+	}
+	return route.Onboarder.OnboardEnvironment(ctx, env)
+}
+
+// OnboardTeam onboards the team in the backend of its secrets.
+// If the environment is stored in another backend, it is onboarded there as well, as each step depends on the previous one.
+func (b *RoutingBackend) OnboardTeam(ctx context.Context, env, teamId string) (backend.OnboardResponse, error) {
+	route, err := b.route(scopePrefix(backend.Scope{Env: env, Team: teamId}))
+	if err != nil {
+		return nil, err
+	}
+	if err := b.onboardParents(ctx, route, backend.Scope{Env: env}); err != nil {
+		return nil, err
+	}
+	return route.Onboarder.OnboardTeam(ctx, env, teamId)
+}
+
+// OnboardApplication onboards the application in the backend of its secrets.
+// Like OnboardTeam, the environment and team are onboarded there as well if they are stored in another backend.
+func (b *RoutingBackend) OnboardApplication(ctx context.Context, env, teamId, appId string) (backend.OnboardResponse, error) {
+	route, err := b.route(scopePrefix(backend.Scope{Env: env, Team: teamId, App: appId}))
+	if err != nil {
+		return nil, err
+	}
+	if err := b.onboardParents(ctx, route, backend.Scope{Env: env, Team: teamId}); err != nil {
+		return nil, err
+	}
+	return route.Onboarder.OnboardApplication(ctx, env, teamId, appId)
+}
+
+// onboardParents onboards the parent and its parents in the backend of the route, unless they are stored there anyway
+func (b *RoutingBackend) onboardParents(ctx context.Context, route *Route, parent backend.Scope) error {
+	parentRoute, err := b.route(scopePrefix(parent))
+	if err == nil && parentRoute == route {
+		return nil
+	}
+
+	if parent.Team == "" {
+		_, err = route.Onboarder.OnboardEnvironment(ctx, parent.Env)
+		return err
+	}
+	if err := b.onboardParents(ctx, route, backend.Scope{Env: parent.Env}); err != nil {
+		return err
+	}
+	_, err = route.Onboarder.OnboardTeam(ctx, parent.Env, parent.Team)
+	return err
+}
+
+func (b *RoutingBackend) DeleteEnvironment(ctx context.Context, env string) error {
+	return b.deleteScope(ctx, backend.Scope{Env: env}, func(o Onboarder) error {
+		return o.DeleteEnvironment(ctx, env)
+	})
+}
+
+func (b *RoutingBackend) DeleteTeam(ctx context.Context, env, teamId string) error {
+	return b.deleteScope(ctx, backend.Scope{Env: env, Team: teamId}, func(o Onboarder) error {
+		return o.DeleteTeam(ctx, env, teamId)
+	})
+}
+
+func (b *RoutingBackend) DeleteApplication(ctx context.Context, env, teamId, appId string) error {
+	return b.deleteScope(ctx, backend.Scope{Env: env, Team: teamId, App: appId}, func(o Onboarder) error {
+		return o.DeleteApplication(ctx, env, teamId, appId)
+	})
+}
+
+// deleteScope deletes the scope in the backend of its secrets.
+// It is deleted in the other backends that might store its children as well, where it might have been onboarded as a parent.
+func (b *RoutingBackend) deleteScope(ctx context.Context, scope backend.Scope, deleteFn func(Onboarder) error) error {
+	route, err := b.route(scopePrefix(scope))
+	if err != nil {
+		return err
+	}
+	if err := deleteFn(route.Onboarder); err != nil {
+		return err
+	}
+
+	children := childPrefix(scope)
+	for i := range b.routes {
+		other := &b.routes[i]
+		if other == route || !other.Rule.overlaps(children) {
+			continue
+		}
+		if err := deleteFn(other.Onboarder); err != nil && !backend.IsNotFoundErr(err) {
+			return errors.Wrapf(err, "failed to delete %s in route %s", scope, other.Rule)
+		}
+	}
+	return nil
+}
+
+// ListScopes returns the scopes of all backends. Each scope is only listed once by the backend of its secrets.
+func (b *RoutingBackend) ListScopes(ctx context.Context) ([]backend.Scope, error) {
+	var scopes []backend.Scope
+	for i := range b.routes {
+		route := &b.routes[i]
+		routeScopes, err := route.Onboarder.ListScopes(ctx)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to list scopes of route %s", route.Rule)
+		}
+		for _, scope := range routeScopes {
+			// Parents that have only been onboarded for their children are stored elsewhere
+			if owner, err := b.route(scopePrefix(scope)); err == nil && owner == route {
+				scopes = append(scopes, scope)
+			}
+		}
+	}
+	return scopes, nil
+}
+
+// scopePrefix returns the prefix of the IDs of the secrets of the scope, e.g. `env:team::`
+func scopePrefix(scope backend.Scope) string {
+	return scope.String() + backend.Separator
+}
+
+// childPrefix returns the prefix of the IDs of the secrets of the scope and its children, e.g. `env:team:`
+func childPrefix(scope backend.Scope) string {
+	switch {
+	case scope.Team == "":
+		return scope.Env + backend.Separator
+	case scope.App == "":
+		return scope.Env + backend.Separator + scope.Team + backend.Separator
+	default:
+		return scopePrefix(scope)
+	}
+}
@@ -0,0 +1,301 @@
+package routing_test
+
+import (
+	"context"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/backend"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/backend/routing"
+)
+
+// fakeId is the ID type of the fake backend, which differs from the types of the real backends
+type fakeId struct {
+	raw string
+}
+
+func (id fakeId) Env() string {
+	return strings.Split(id.raw, backend.Separator)[0]
+}
+
+func (id fakeId) String() string {
+	return id.raw
+}
+
+// fakeBackend stores the values by their ID and records the onboarded scopes
+type fakeBackend struct {
+	values map[string]string
+	scopes []backend.Scope
+	stale  bool
+}
+
+func newFakeBackend() *fakeBackend {
+	return &fakeBackend{values: map[string]string{}}
+}
+
+func (b *fakeBackend) ParseSecretId(raw string) (fakeId, error) {
+	return fakeId{raw: raw}, nil
+}
+
+func (b *fakeBackend) Get(_ context.Context, id fakeId) (backend.DefaultSecret[fakeId], error) {
+	value, ok := b.values[id.raw]
+	if !ok {
+		return backend.DefaultSecret[fakeId]{}, backend.ErrSecretNotFound(id)
+	}
+	secret := backend.NewDefaultSecret(id, value)
+	if b.stale {
+		secret = secret.AsStale()
+	}
+	return secret, nil
+}
+
+func (b *fakeBackend) Set(_ context.Context, id fakeId, value backend.SecretValue) (backend.DefaultSecret[fakeId], error) {
+	b.values[id.raw] = value.Value()
+	return backend.NewDefaultSecret(id, value.Value()), nil
+}
+
+func (b *fakeBackend) Delete(_ context.Context, id fakeId) error {
+	delete(b.values, id.raw)
+	return nil
+}
+
+func (b *fakeBackend) List(_ context.Context, env, team, app string) (map[string]fakeId, error) {
+	prefix := strings.Join([]string{env, team, app}, backend.Separator) + backend.Separator
+	ids := map[string]fakeId{}
+	for raw := range b.values {
+		if strings.HasPrefix(raw, prefix) {
+			ids[strings.Split(raw, backend.Separator)[3]] = fakeId{raw: raw}
+		}
+	}
+	return ids, nil
+}
+
+func (b *fakeBackend) Versions(_ context.Context, id fakeId) ([]fakeId, error) {
+	return []fakeId{id}, nil
+}
+
+func (b *fakeBackend) onboard(scope backend.Scope) (backend.OnboardResponse, error) {
+	b.scopes = append(b.scopes, scope)
+	return backend.NewDefaultOnboardResponse(nil), nil
+}
+
+func (b *fakeBackend) OnboardEnvironment(_ context.Context, env string) (backend.OnboardResponse, error) {
+	return b.onboard(backend.Scope{Env: env})
+}
+
+func (b *fakeBackend) OnboardTeam(_ context.Context, env, teamId string) (backend.OnboardResponse, error) {
+	return b.onboard(backend.Scope{Env: env, Team: teamId})
+}
+
+func (b *fakeBackend) OnboardApplication(_ context.Context, env, teamId, appId string) (backend.OnboardResponse, error) {
+	return b.onboard(backend.Scope{Env: env, Team: teamId, App: appId})
+}
+
+func (b *fakeBackend) delete(scope backend.Scope) error {
+	for i, s := range b.scopes {
+		if s == scope {
+			b.scopes = append(b.scopes[:i], b.scopes[i+1:]...)
+			return nil
+		}
+	}
+	return backend.ErrNotFound()
+}
+
+func (b *fakeBackend) DeleteEnvironment(_ context.Context, env string) error {
+	return b.delete(backend.Scope{Env: env})
+}
+
+func (b *fakeBackend) DeleteTeam(_ context.Context, env, teamId string) error {
+	return b.delete(backend.Scope{Env: env, Team: teamId})
+}
+
+func (b *fakeBackend) DeleteApplication(_ context.Context, env, teamId, appId string) error {
+	return b.delete(backend.Scope{Env: env, Team: teamId, App: appId})
+}
+
+func (b *fakeBackend) ListScopes(_ context.Context) ([]backend.Scope, error) {
+	return b.scopes, nil
+}
+
+var _ = Describe("Routing Backend", func() {
+
+	var ctx context.Context
+	var prod, teamA, dev *fakeBackend
+	var router *routing.RoutingBackend
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		prod, teamA, dev = newFakeBackend(), newFakeBackend(), newFakeBackend()
+
+		var err error
+		router, err = routing.NewRoutingBackend(
+			routing.NewRoute(routing.Rule{Env: "prod"}, prod, prod),
+			routing.NewRoute(routing.Rule{Prefix: "dev:team-a:"}, teamA, teamA),
+			routing.NewRoute(routing.Rule{}, dev, dev),
+		)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	set := func(raw, value string) {
+		id, err := router.ParseSecretId(raw)
+		Expect(err).ToNot(HaveOccurred())
+		_, err = router.Set(ctx, id, backend.String(value))
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	Context("Rules", func() {
+
+		It("should match the environment and prefix", func() {
+			Expect(routing.Rule{Env: "prod"}.Matches("prod:team:app:secret:")).To(BeTrue())
+			Expect(routing.Rule{Env: "prod"}.Matches("production:team:app:secret:")).To(BeFalse())
+			Expect(routing.Rule{Prefix: "dev:team-a:"}.Matches("dev:team-a::secret:")).To(BeTrue())
+			Expect(routing.Rule{Prefix: "dev:team-a:"}.Matches("dev:::secret:")).To(BeFalse())
+			Expect(routing.Rule{Env: "dev", Prefix: "prod:"}.Matches("prod:::secret:")).To(BeFalse())
+			Expect(routing.Rule{}.Matches("any:::secret:")).To(BeTrue())
+		})
+
+		It("should only match whole segments of the prefix", func() {
+			Expect(routing.Rule{Prefix: "dev:team-a"}.Matches("dev:team-a::secret:")).To(BeTrue())
+			Expect(routing.Rule{Prefix: "dev:team-a"}.Matches("dev:team-a:app:secret:")).To(BeTrue())
+			Expect(routing.Rule{Prefix: "dev:team-a"}.Matches("dev:team-ab::secret:")).To(BeFalse())
+			Expect(routing.Rule{Prefix: "dev:team-a:"}.Matches("dev:team-ab::secret:")).To(BeFalse())
+			Expect(routing.Rule{Prefix: "dev"}.Matches("development:::secret:")).To(BeFalse())
+		})
+
+		It("should reject invalid routes", func() {
+			_, err := routing.NewRoutingBackend()
+			Expect(err).To(HaveOccurred())
+
+			_, err = routing.NewRoutingBackend(
+				routing.NewRoute(routing.Rule{Env: "prod"}, prod, prod),
+				routing.NewRoute(routing.Rule{Env: "prod"}, dev, dev),
+			)
+			Expect(err).To(MatchError("route env=prod is configured twice"))
+
+			_, err = routing.NewRoutingBackend(
+				routing.NewRoute(routing.Rule{Prefix: "dev:team-a:"}, prod, prod),
+				routing.NewRoute(routing.Rule{Prefix: "dev:team-a"}, dev, dev),
+			)
+			Expect(err).To(MatchError("route prefix=dev:team-a is configured twice"))
+		})
+	})
+
+	Context("Secrets", func() {
+
+		It("should store the secrets in the backend of the first matching route", func() {
+			set("prod:team-a:app:secret:", "prod-value")
+			set("dev:team-a:app:secret:", "team-a-value")
+			set("dev:team-b:app:secret:", "dev-value")
+
+			Expect(prod.values).To(Equal(map[string]string{"prod:team-a:app:secret:": "prod-value"}))
+			Expect(teamA.values).To(Equal(map[string]string{"dev:team-a:app:secret:": "team-a-value"}))
+			Expect(dev.values).To(Equal(map[string]string{"dev:team-b:app:secret:": "dev-value"}))
+
+			id, err := router.ParseSecretId("dev:team-a:app:secret:")
+			Expect(err).ToNot(HaveOccurred())
+			secret, err := router.Get(ctx, id)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(secret.Value()).To(Equal("team-a-value"))
+			Expect(secret.Id().String()).To(Equal("dev:team-a:app:secret:"))
+		})
+
+		It("should route IDs that have not been parsed by it", func() {
+			set("prod:team:app:secret:", "prod-value")
+
+			secret, err := router.Get(ctx, fakeId{raw: "prod:team:app:secret:"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(secret.Value()).To(Equal("prod-value"))
+		})
+
+		It("should keep whether a secret is stale", func() {
+			set("prod:team:app:secret:", "prod-value")
+			prod.stale = true
+
+			id, err := router.ParseSecretId("prod:team:app:secret:")
+			Expect(err).ToNot(HaveOccurred())
+			secret, err := router.Get(ctx, id)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(backend.IsStale(secret)).To(BeTrue())
+		})
+
+		It("should list the secrets of the route of the scope", func() {
+			set("dev:team-a::clientSecret:", "team-a-value")
+			set("dev:::envSecret:", "dev-value")
+
+			ids, err := router.List(ctx, "dev", "team-a", "")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ids).To(HaveLen(1))
+			Expect(ids["clientSecret"].String()).To(Equal("dev:team-a::clientSecret:"))
+
+			ids, err = router.List(ctx, "dev", "", "")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ids).To(HaveLen(1))
+			Expect(ids).To(HaveKey("envSecret"))
+		})
+
+		It("should reject invalid IDs", func() {
+			_, err := router.ParseSecretId("invalid")
+			Expect(err).To(HaveOccurred())
+			Expect(backend.IsBackendError(err)).To(BeTrue())
+		})
+
+		It("should reject IDs without route", func() {
+			router, err := routing.NewRoutingBackend(routing.NewRoute(routing.Rule{Env: "prod"}, prod, prod))
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = router.ParseSecretId("dev:team:app:secret:")
+			Expect(err).To(MatchError("InvalidSecretId: no backend is configured for 'dev:team:app:secret:'"))
+		})
+	})
+
+	Context("Onboarding", func() {
+
+		It("should onboard the scopes in the backend of their secrets", func() {
+			_, err := router.OnboardEnvironment(ctx, "prod")
+			Expect(err).ToNot(HaveOccurred())
+			_, err = router.OnboardTeam(ctx, "prod", "team-a")
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(prod.scopes).To(Equal([]backend.Scope{{Env: "prod"}, {Env: "prod", Team: "team-a"}}))
+			Expect(teamA.scopes).To(BeEmpty())
+			Expect(dev.scopes).To(BeEmpty())
+		})
+
+		It("should onboard the parents in the backend of the children", func() {
+			_, err := router.OnboardEnvironment(ctx, "dev")
+			Expect(err).ToNot(HaveOccurred())
+			_, err = router.OnboardTeam(ctx, "dev", "team-a")
+			Expect(err).ToNot(HaveOccurred())
+			_, err = router.OnboardApplication(ctx, "dev", "team-a", "app")
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(dev.scopes).To(Equal([]backend.Scope{{Env: "dev"}}))
+			Expect(teamA.scopes).To(Equal([]backend.Scope{
+				{Env: "dev"}, {Env: "dev", Team: "team-a"}, {Env: "dev", Team: "team-a", App: "app"},
+			}))
+
+			scopes, err := router.ListScopes(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(scopes).To(ConsistOf(
+				backend.Scope{Env: "dev"}, backend.Scope{Env: "dev", Team: "team-a"}, backend.Scope{Env: "dev", Team: "team-a", App: "app"},
+			))
+		})
+
+		It("should delete the scopes in all backends that might store their children", func() {
+			_, err := router.OnboardTeam(ctx, "dev", "team-a")
+			Expect(err).ToNot(HaveOccurred())
+			_, err = router.OnboardEnvironment(ctx, "dev")
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(router.DeleteEnvironment(ctx, "dev")).To(Succeed())
+			Expect(dev.scopes).To(BeEmpty())
+			Expect(teamA.scopes).To(Equal([]backend.Scope{{Env: "dev", Team: "team-a"}}))
+		})
+
+		It("should return not found if the scope is not onboarded in its backend", func() {
+			err := router.DeleteTeam(ctx, "prod", "team-a")
+			Expect(backend.IsNotFoundErr(err)).To(BeTrue())
+		})
+	})
+})
@@ -0,0 +1,184 @@
+package routing
+
+import (
+	"context"
+	"strings"
+
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/backend"
+)
+
+// AnyBackend is a backend whose secret ID type has been erased, so backends of different types can be routed
+type AnyBackend = backend.Backend[backend.SecretId, backend.Secret[backend.SecretId]]
+
+// Onboarder onboards the scopes of a route and lists them
+type Onboarder interface {
+	backend.Onboarder
+	backend.ScopeLister
+}
+
+// Rule selects the secrets of a route by their ID <env>:<team>:<app>:<path>:<checksum>.
+// If both Env and Prefix are set, both must match. A rule without both matches all secrets.
+type Rule struct {
+	// Env is the environment of the secrets
+	Env string `yaml:"env"`
+	// Prefix is the start of the IDs of the secrets, e.g. `dev:team-a:`.
+	// It matches whole segments, so `dev:team-a` does not select the secrets of `team-ab`.
+	Prefix string `yaml:"prefix"`
+}
+
+// Matches returns true if the ID or the prefix of the scope is selected by the rule
+func (r Rule) Matches(id string) bool {
+	if r.Env != "" && !strings.HasPrefix(id, r.Env+backend.Separator) {
+		return false
+	}
+	return strings.HasPrefix(id, r.prefix())
+}
+
+// overlaps returns true if the rule might select secrets whose IDs start with the prefix
+func (r Rule) overlaps(prefix string) bool {
+	if r.Env != "" && !strings.HasPrefix(prefix, r.Env+backend.Separator) {
+		return false
+	}
+	return strings.HasPrefix(prefix, r.prefix()) || strings.HasPrefix(r.prefix(), prefix)
+}
+
+// prefix returns the Prefix ending with a separator, so it only matches whole segments
+func (r Rule) prefix() string {
+	if r.Prefix == "" || strings.HasSuffix(r.Prefix, backend.Separator) {
+		return r.Prefix
+	}
+	return r.Prefix + backend.Separator
+}
+
+// equal returns true if both rules select the same secrets
+func (r Rule) equal(other Rule) bool {
+	return r.Env == other.Env && r.prefix() == other.prefix()
+}
+
+func (r Rule) String() string {
+	switch {
+	case r.Env == "" && r.Prefix == "":
+		return "default"
+	case r.Prefix == "":
+		return "env=" + r.Env
+	case r.Env == "":
+		return "prefix=" + r.Prefix
+	default:
+		return "env=" + r.Env + ",prefix=" + r.Prefix
+	}
+}
+
+// Route stores the secrets that are selected by its rule in its backend
+type Route struct {
+	Rule      Rule
+	Backend   AnyBackend
+	Onboarder Onboarder
+}
+
+// NewRoute creates a route to the backend, which can be of any type
+func NewRoute[T backend.SecretId, S backend.Secret[T]](rule Rule, b backend.Backend[T, S], o Onboarder) Route {
+	return Route{Rule: rule, Backend: Erase(b), Onboarder: o}
+}
+
+// Erase hides the secret ID type of the backend
+func Erase[T backend.SecretId, S backend.Secret[T]](b backend.Backend[T, S]) AnyBackend {
+	return &erasedBackend[T, S]{backend: b}
+}
+
+var _ AnyBackend = &erasedBackend[backend.SecretId, backend.Secret[backend.SecretId]]{}
+
+type erasedBackend[T backend.SecretId, S backend.Secret[T]] struct {
+	backend backend.Backend[T, S]
+}
+
+// erasedSecret keeps whether the secret is stale, see backend.IsStale
+type erasedSecret[T backend.SecretId, S backend.Secret[T]] struct {
+	secret S
+}
+
+func (s erasedSecret[T, S]) Id() backend.SecretId {
+	return s.secret.Id()
+}
+
+func (s erasedSecret[T, S]) Value() string {
+	return s.secret.Value()
+}
+
+func (s erasedSecret[T, S]) Stale() bool {
+	return backend.IsStale(s.secret)
+}
+
+func (b *erasedBackend[T, S]) id(id backend.SecretId) (T, error) {
+	if typed, ok := id.(T); ok {
+		return typed, nil
+	}
+	return b.backend.ParseSecretId(id.String())
+}
+
+func (b *erasedBackend[T, S]) ParseSecretId(raw string) (backend.SecretId, error) {
+	id, err := b.backend.ParseSecretId(raw)
+	if err != nil {
+		return nil, err
+	}
+	return id, nil
+}
+
+func (b *erasedBackend[T, S]) Get(ctx context.Context, id backend.SecretId) (backend.Secret[backend.SecretId], error) {
+	typed, err := b.id(id)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := b.backend.Get(ctx, typed)
+	if err != nil {
+		return nil, err
+	}
+	return erasedSecret[T, S]{secret: secret}, nil
+}
+
+func (b *erasedBackend[T, S]) Set(ctx context.Context, id backend.SecretId, value backend.SecretValue) (backend.Secret[backend.SecretId], error) {
+	typed, err := b.id(id)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := b.backend.Set(ctx, typed, value)
+	if err != nil {
+		return nil, err
+	}
+	return erasedSecret[T, S]{secret: secret}, nil
+}
+
+func (b *erasedBackend[T, S]) Delete(ctx context.Context, id backend.SecretId) error {
+	typed, err := b.id(id)
+	if err != nil {
+		return err
+	}
+	return b.backend.Delete(ctx, typed)
+}
+
+func (b *erasedBackend[T, S]) List(ctx context.Context, env, team, app string) (map[string]backend.SecretId, error) {
+	ids, err := b.backend.List(ctx, env, team, app)
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[string]backend.SecretId, len(ids))
+	for name, id := range ids {
+		res[name] = id
+	}
+	return res, nil
+}
+
+func (b *erasedBackend[T, S]) Versions(ctx context.Context, id backend.SecretId) ([]backend.SecretId, error) {
+	typed, err := b.id(id)
+	if err != nil {
+		return nil, err
+	}
+	ids, err := b.backend.Versions(ctx, typed)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]backend.SecretId, 0, len(ids))
+	for _, versionId := range ids {
+		res = append(res, versionId)
+	}
+	return res, nil
+}
@@ -3,6 +3,17 @@ package backend
 import (
 	"crypto/sha256"
 	"encoding/hex"
+	"strconv"
+	"strings"
+)
+
+const (
+	// VersionPrefix is used to distinguish a version from a checksum in the last segment of a secret ID.
+	// Checksums are hex-encoded or numeric and can never start with it.
+	VersionPrefix = "v"
+
+	// DefaultMaxVersions is the default number of versions that are kept for each secret
+	DefaultMaxVersions = 10
 )
 
 // MakeChecksum is used to generate a checksum for a given string.
@@ -13,3 +24,21 @@ func MakeChecksum(input string) string {
 	// Collisions are unlikely
 	return hex.EncodeToString(hash[:6])
 }
+
+// MakeVersion is used to encode a version in the last segment of a secret ID.
+func MakeVersion(version int) string {
+	return VersionPrefix + strconv.Itoa(version)
+}
+
+// ParseVersion returns the version that is encoded in the last segment of a secret ID.
+// It returns false if the segment is a checksum or empty.
+func ParseVersion(checksum string) (int, bool) {
+	if !strings.HasPrefix(checksum, VersionPrefix) {
+		return 0, false
+	}
+	version, err := strconv.Atoi(strings.TrimPrefix(checksum, VersionPrefix))
+	if err != nil || version <= 0 {
+		return 0, false
+	}
+	return version, true
+}
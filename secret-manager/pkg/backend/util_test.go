@@ -17,4 +17,21 @@ var _ = Describe("Util Tests", func() {
 			Expect(hash).To(Equal("9f86d081884c"))
 		})
 	})
+
+	Context("Versions", func() {
+		It("should encode and parse a version", func() {
+			Expect(backend.MakeVersion(3)).To(Equal("v3"))
+
+			version, ok := backend.ParseVersion("v3")
+			Expect(ok).To(BeTrue())
+			Expect(version).To(Equal(3))
+		})
+
+		It("should not parse a checksum as version", func() {
+			for _, checksum := range []string{"", "9f86d081884c", "12345", "v", "v0", "vx"} {
+				_, ok := backend.ParseVersion(checksum)
+				Expect(ok).To(BeFalse(), checksum)
+			}
+		})
+	})
 })
@@ -0,0 +1,232 @@
+package vault
+
+import (
+	"context"
+	"maps"
+	"slices"
+
+	"github.com/go-logr/logr"
+	"github.com/hashicorp/vault/api"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/backend"
+	"github.com/tidwall/sjson"
+)
+
+var _ backend.Backend[VaultSecretId, backend.DefaultSecret[VaultSecretId]] = &VaultBackend{}
+
+// maxWriteAttempts is the number of attempts to write a KV secret
+// if it has been changed concurrently by another request
+const maxWriteAttempts = 3
+
+// VaultBackend stores the secrets of an environment, team or application
+// as fields of a single secret in a KV v2 secrets engine.
+// The checksum of a secret is the version of the KV secret.
+type VaultBackend struct {
+	kv *api.KVv2
+
+	// MaxVersions is the maximum number of versions that are returned for each secret.
+	// The versions that are kept are configured in the KV secrets engine.
+	MaxVersions int
+}
+
+func NewBackend(client *api.Client) backend.Backend[VaultSecretId, backend.DefaultSecret[VaultSecretId]] {
+	return &VaultBackend{
+		kv:          client.KVv2(MountPath),
+		MaxVersions: backend.DefaultMaxVersions,
+	}
+}
+
+func (v *VaultBackend) ParseSecretId(rawId string) (VaultSecretId, error) {
+	return FromString(rawId)
+}
+
+func (v *VaultBackend) Get(ctx context.Context, secretId VaultSecretId) (res backend.DefaultSecret[VaultSecretId], err error) {
+	log := logr.FromContextOrDiscard(ctx)
+	key, subPath := secretId.JsonPath()
+	log.Info("get secret", "path", secretId.SecretPath(), "key", key, "subPath", subPath)
+
+	var kvSecret *api.KVSecret
+	if version, ok := secretId.Version(); ok {
+		kvSecret, err = v.kv.GetVersion(ctx, secretId.SecretPath(), version)
+	} else {
+		kvSecret, err = v.kv.Get(ctx, secretId.SecretPath())
+	}
+	if err != nil {
+		return res, handleError(err, secretId)
+	}
+
+	value, ok := lookup(kvSecret.Data, key, subPath)
+	if !ok {
+		return res, backend.ErrSecretNotFound(secretId)
+	}
+	return backend.NewDefaultSecret(secretId, value), nil
+}
+
+func (v *VaultBackend) Set(ctx context.Context, secretId VaultSecretId, secretValue backend.SecretValue) (res backend.DefaultSecret[VaultSecretId], err error) {
+	log := logr.FromContextOrDiscard(ctx)
+	key, subPath := secretId.JsonPath()
+	log.Info("set secret", "path", secretId.SecretPath(), "key", key, "subPath", subPath)
+
+	for attempt := 1; ; attempt++ {
+		data, cas, err := v.latest(ctx, secretId)
+		if err != nil && !backend.IsNotFoundErr(err) {
+			return res, err
+		}
+
+		current, _ := lookup(data, key, subPath)
+		if current != "" && !secretValue.AllowChange() {
+			return backend.NewDefaultSecret(secretId, current), nil
+		}
+		if secretValue.EqualString(current) {
+			return backend.NewDefaultSecret(secretId, current), nil
+		}
+
+		if subPath != "" {
+			raw, ok := lookup(data, key, "")
+			if !ok {
+				return res, backend.ErrSecretNotFound(secretId)
+			}
+			newValue, err := sjson.Set(raw, subPath, secretValue.Value())
+			if err != nil {
+				return res, handleError(err, secretId)
+			}
+			data[key] = newValue
+		} else {
+			data[key] = secretValue.Value()
+		}
+
+		written, err := v.kv.Put(ctx, secretId.SecretPath(), data, api.WithCheckAndSet(cas))
+		if isCheckAndSetErr(err) && attempt < maxWriteAttempts {
+			log.V(1).Info("secret has been changed concurrently, retrying", "attempt", attempt)
+			continue
+		}
+		if err != nil {
+			return res, handleError(err, secretId)
+		}
+		newId := secretId.CopyWithChecksum(backend.MakeVersion(written.VersionMetadata.Version))
+		return backend.NewDefaultSecret(newId, ""), nil
+	}
+}
+
+func (v *VaultBackend) Delete(ctx context.Context, secretId VaultSecretId) error {
+	log := logr.FromContextOrDiscard(ctx)
+	key, subPath := secretId.JsonPath()
+	log.Info("delete secret", "path", secretId.SecretPath(), "key", key, "subPath", subPath)
+
+	for attempt := 1; ; attempt++ {
+		data, cas, err := v.latest(ctx, secretId)
+		if err != nil {
+			return err
+		}
+
+		raw, ok := lookup(data, key, "")
+		if ok && subPath != "" {
+			_, ok = lookup(data, key, subPath)
+		}
+		if !ok {
+			return backend.ErrSecretNotFound(secretId)
+		}
+		if subPath != "" {
+			newValue, err := sjson.Delete(raw, subPath)
+			if err != nil {
+				return handleError(err, secretId)
+			}
+			data[key] = newValue
+		} else {
+			delete(data, key)
+		}
+
+		_, err = v.kv.Put(ctx, secretId.SecretPath(), data, api.WithCheckAndSet(cas))
+		if isCheckAndSetErr(err) && attempt < maxWriteAttempts {
+			continue
+		}
+		if err != nil {
+			return handleError(err, secretId)
+		}
+		return nil
+	}
+}
+
+func (v *VaultBackend) List(ctx context.Context, env, team, app string) (map[string]VaultSecretId, error) {
+	log := logr.FromContextOrDiscard(ctx)
+	scope := New(env, team, app, "", "")
+	log.Info("list secrets", "path", scope.SecretPath())
+
+	kvSecret, err := v.kv.Get(ctx, scope.SecretPath())
+	if err != nil {
+		if err = handleError(err, scope); backend.IsNotFoundErr(err) {
+			return nil, backend.ErrNotFound()
+		}
+		return nil, err
+	}
+	if kvSecret.Data == nil {
+		return nil, backend.ErrNotFound()
+	}
+
+	checksum := backend.MakeVersion(kvSecret.VersionMetadata.Version)
+	ids := make(map[string]VaultSecretId, len(kvSecret.Data))
+	for key := range kvSecret.Data {
+		ids[key] = New(env, team, app, key, checksum)
+	}
+	return ids, nil
+}
+
+// Versions returns the IDs of the versions of the KV secret in which the secret has been changed, the latest first.
+// As the KV secret contains all secrets of the scope, each of its versions must be read to find them.
+func (v *VaultBackend) Versions(ctx context.Context, secretId VaultSecretId) ([]VaultSecretId, error) {
+	key, subPath := secretId.JsonPath()
+	versions, err := v.kv.GetVersionsAsList(ctx, secretId.SecretPath())
+	if err != nil {
+		return nil, handleError(err, secretId)
+	}
+
+	ids := []VaultSecretId{}
+	previous, hasPrevious := "", false
+	for _, version := range versions {
+		if version.Destroyed || !version.DeletionTime.IsZero() {
+			continue
+		}
+		kvSecret, err := v.kv.GetVersion(ctx, secretId.SecretPath(), version.Version)
+		if err != nil {
+			if backend.IsNotFoundErr(handleError(err, secretId)) {
+				continue
+			}
+			return nil, handleError(err, secretId)
+		}
+		value, ok := lookup(kvSecret.Data, key, subPath)
+		if !ok {
+			hasPrevious = false
+			continue
+		}
+		if hasPrevious && value == previous {
+			continue
+		}
+		previous, hasPrevious = value, true
+		ids = append(ids, secretId.CopyWithChecksum(backend.MakeVersion(version.Version)))
+	}
+
+	if len(ids) == 0 {
+		return nil, backend.ErrSecretNotFound(secretId)
+	}
+	slices.Reverse(ids)
+	if v.MaxVersions > 0 && len(ids) > v.MaxVersions {
+		ids = ids[:v.MaxVersions]
+	}
+	return ids, nil
+}
+
+// latest returns a copy of the data of the latest version of the KV secret
+// and its version that must be used as check-and-set parameter to update it.
+func (v *VaultBackend) latest(ctx context.Context, secretId VaultSecretId) (map[string]any, int, error) {
+	kvSecret, err := v.kv.Get(ctx, secretId.SecretPath())
+	if err != nil {
+		return map[string]any{}, 0, handleError(err, secretId)
+	}
+	cas := 0
+	if kvSecret.VersionMetadata != nil {
+		cas = kvSecret.VersionMetadata.Version
+	}
+	if kvSecret.Data == nil {
+		return map[string]any{}, cas, backend.ErrSecretNotFound(secretId)
+	}
+	return maps.Clone(kvSecret.Data), cas, nil
+}
@@ -0,0 +1,229 @@
+package vault_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/backend"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/backend/vault"
+)
+
+var _ = Describe("Vault Backend", func() {
+
+	var ctx context.Context
+	var vaultBackend backend.Backend[vault.VaultSecretId, backend.DefaultSecret[vault.VaultSecretId]]
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		vaultBackend = vault.NewBackend(client)
+
+		_, err := vault.NewOnboarder(client).OnboardApplication(ctx, "test", "my-team", "my-app")
+		Expect(err).ToNot(HaveOccurred())
+		set(ctx, vaultBackend, "test:my-team:my-app:externalSecrets:", `{"foo":"bar"}`)
+	})
+
+	Context("Parse ID", func() {
+
+		It("should return an error on invalid secret id", func() {
+			_, err := vaultBackend.ParseSecretId("my-secret-id")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal("InvalidSecretId: invalid secret id 'my-secret-id'"))
+
+			_, err = vaultBackend.ParseSecretId("test::my-app:clientSecret:")
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should map the id to the path of the KV secret", func() {
+			secretId, err := vaultBackend.ParseSecretId("test:my-team:my-app:externalSecrets/foo:v2")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(secretId.SecretPath()).To(Equal("controlplane/test/my-team/my-app"))
+			Expect(secretId.Env()).To(Equal("test"))
+
+			key, subPath := secretId.JsonPath()
+			Expect(key).To(Equal("externalSecrets"))
+			Expect(subPath).To(Equal("foo"))
+
+			version, ok := secretId.Version()
+			Expect(ok).To(BeTrue())
+			Expect(version).To(Equal(2))
+
+			secretId, err = vaultBackend.ParseSecretId("test::::")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(secretId.SecretPath()).To(Equal("controlplane/test"))
+		})
+	})
+
+	Context("Get", func() {
+
+		It("should get a secret", func() {
+			secret, err := vaultBackend.Get(ctx, parse(vaultBackend, "test:my-team:my-app:externalSecrets:"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(secret.Value()).To(Equal(`{"foo":"bar"}`))
+		})
+
+		It("should get a nested secret", func() {
+			secret, err := vaultBackend.Get(ctx, parse(vaultBackend, "test:my-team:my-app:externalSecrets/foo:"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(secret.Value()).To(Equal("bar"))
+		})
+
+		It("should get a version of a secret", func() {
+			set(ctx, vaultBackend, "test:my-team:my-app:externalSecrets/foo:", "baz")
+
+			secret, err := vaultBackend.Get(ctx, parse(vaultBackend, "test:my-team:my-app:externalSecrets/foo:v2"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(secret.Value()).To(Equal("bar"))
+
+			secret, err = vaultBackend.Get(ctx, parse(vaultBackend, "test:my-team:my-app:externalSecrets/foo:v3"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(secret.Value()).To(Equal("baz"))
+		})
+
+		It("should return not found", func() {
+			_, err := vaultBackend.Get(ctx, parse(vaultBackend, "test:my-team:my-app:unknown:"))
+			Expect(backend.IsNotFoundErr(err)).To(BeTrue())
+
+			_, err = vaultBackend.Get(ctx, parse(vaultBackend, "test:my-team:my-app:externalSecrets/unknown:"))
+			Expect(backend.IsNotFoundErr(err)).To(BeTrue())
+
+			_, err = vaultBackend.Get(ctx, parse(vaultBackend, "test:my-team:other-app:clientSecret:"))
+			Expect(backend.IsNotFoundErr(err)).To(BeTrue())
+
+			_, err = vaultBackend.Get(ctx, parse(vaultBackend, "test:my-team:my-app:clientSecret:v9"))
+			Expect(backend.IsNotFoundErr(err)).To(BeTrue())
+		})
+	})
+
+	Context("Set", func() {
+
+		It("should set a secret and return the new version", func() {
+			secret, err := vaultBackend.Set(ctx, parse(vaultBackend, "test:my-team:my-app:clientSecret:"), backend.String("new-value"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(secret.Id().String()).To(Equal("test:my-team:my-app:clientSecret:v3"))
+
+			data, ok := server.Data("controlplane/test/my-team/my-app")
+			Expect(ok).To(BeTrue())
+			Expect(data).To(HaveKeyWithValue("clientSecret", "new-value"))
+			Expect(data).To(HaveKeyWithValue("externalSecrets", `{"foo":"bar"}`))
+		})
+
+		It("should set a nested secret", func() {
+			secret, err := vaultBackend.Set(ctx, parse(vaultBackend, "test:my-team:my-app:externalSecrets/other:"), backend.String("value"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(secret.Id().String()).To(Equal("test:my-team:my-app:externalSecrets/other:v3"))
+
+			data, _ := server.Data("controlplane/test/my-team/my-app")
+			Expect(data["externalSecrets"]).To(MatchJSON(`{"foo":"bar","other":"value"}`))
+		})
+
+		It("should not write an unchanged secret", func() {
+			secret, err := vaultBackend.Set(ctx, parse(vaultBackend, "test:my-team:my-app:externalSecrets/foo:"), backend.String("bar"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(secret.Value()).To(Equal("bar"))
+
+			versions, err := vaultBackend.Versions(ctx, parse(vaultBackend, "test:my-team:my-app:clientSecret:"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(versions).To(HaveLen(1))
+		})
+
+		It("should not change an initial value", func() {
+			secret, err := vaultBackend.Set(ctx, parse(vaultBackend, "test:my-team:my-app:externalSecrets/foo:"), backend.InitialString("other"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(secret.Value()).To(Equal("bar"))
+		})
+
+		It("should create the KV secret if it does not exist", func() {
+			secret, err := vaultBackend.Set(ctx, parse(vaultBackend, "other:::zones:"), backend.String("zone-a"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(secret.Id().String()).To(Equal("other:::zones:v1"))
+		})
+	})
+
+	Context("Delete", func() {
+
+		It("should delete a nested secret", func() {
+			err := vaultBackend.Delete(ctx, parse(vaultBackend, "test:my-team:my-app:externalSecrets/foo:"))
+			Expect(err).ToNot(HaveOccurred())
+
+			data, _ := server.Data("controlplane/test/my-team/my-app")
+			Expect(data["externalSecrets"]).To(MatchJSON(`{}`))
+
+			err = vaultBackend.Delete(ctx, parse(vaultBackend, "test:my-team:my-app:externalSecrets/foo:"))
+			Expect(backend.IsNotFoundErr(err)).To(BeTrue())
+		})
+
+		It("should delete a secret", func() {
+			err := vaultBackend.Delete(ctx, parse(vaultBackend, "test:my-team:my-app:clientSecret:"))
+			Expect(err).ToNot(HaveOccurred())
+
+			data, _ := server.Data("controlplane/test/my-team/my-app")
+			Expect(data).ToNot(HaveKey("clientSecret"))
+
+			err = vaultBackend.Delete(ctx, parse(vaultBackend, "test:my-team:my-app:clientSecret:"))
+			Expect(backend.IsNotFoundErr(err)).To(BeTrue())
+		})
+	})
+
+	Context("List", func() {
+
+		It("should list the secrets of an application", func() {
+			ids, err := vaultBackend.List(ctx, "test", "my-team", "my-app")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ids).To(HaveLen(2))
+			Expect(ids["clientSecret"].String()).To(Equal("test:my-team:my-app:clientSecret:v2"))
+			Expect(ids["externalSecrets"].String()).To(Equal("test:my-team:my-app:externalSecrets:v2"))
+		})
+
+		It("should return not found for an unknown application", func() {
+			_, err := vaultBackend.List(ctx, "test", "my-team", "other-app")
+			Expect(backend.IsNotFoundErr(err)).To(BeTrue())
+		})
+	})
+
+	Context("Versions", func() {
+
+		It("should only return the versions in which the secret has been changed", func() {
+			set(ctx, vaultBackend, "test:my-team:my-app:clientSecret:", "second")
+			set(ctx, vaultBackend, "test:my-team:my-app:externalSecrets/foo:", "baz")
+			set(ctx, vaultBackend, "test:my-team:my-app:clientSecret:", "third")
+
+			versions, err := vaultBackend.Versions(ctx, parse(vaultBackend, "test:my-team:my-app:clientSecret:"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(versions).To(HaveLen(3))
+			Expect(versions[0].String()).To(Equal("test:my-team:my-app:clientSecret:v5"))
+			Expect(versions[1].String()).To(Equal("test:my-team:my-app:clientSecret:v3"))
+			Expect(versions[2].String()).To(Equal("test:my-team:my-app:clientSecret:v1"))
+
+			secret, err := vaultBackend.Get(ctx, versions[1])
+			Expect(err).ToNot(HaveOccurred())
+			Expect(secret.Value()).To(Equal("second"))
+		})
+
+		It("should limit the number of versions", func() {
+			vaultBackend.(*vault.VaultBackend).MaxVersions = 1
+			set(ctx, vaultBackend, "test:my-team:my-app:clientSecret:", "second")
+
+			versions, err := vaultBackend.Versions(ctx, parse(vaultBackend, "test:my-team:my-app:clientSecret:"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(versions).To(HaveLen(1))
+			Expect(versions[0].String()).To(Equal("test:my-team:my-app:clientSecret:v3"))
+		})
+
+		It("should return not found for an unknown secret", func() {
+			_, err := vaultBackend.Versions(ctx, parse(vaultBackend, "test:my-team:my-app:unknown:"))
+			Expect(backend.IsNotFoundErr(err)).To(BeTrue())
+		})
+	})
+})
+
+func parse(b backend.Backend[vault.VaultSecretId, backend.DefaultSecret[vault.VaultSecretId]], raw string) vault.VaultSecretId {
+	secretId, err := b.ParseSecretId(raw)
+	Expect(err).ToNot(HaveOccurred())
+	return secretId
+}
+
+func set(ctx context.Context, b backend.Backend[vault.VaultSecretId, backend.DefaultSecret[vault.VaultSecretId]], raw, value string) {
+	_, err := b.Set(ctx, parse(b, raw), backend.String(value))
+	Expect(err).ToNot(HaveOccurred())
+}
@@ -0,0 +1,54 @@
+package vault
+
+import (
+	"os"
+	"strings"
+
+	"github.com/hashicorp/vault/api"
+	"github.com/pkg/errors"
+)
+
+// MountPath is the path where the KV v2 secrets engine is mounted
+var MountPath = "secret"
+
+func init() {
+	mp := os.Getenv("VAULT_KV_MOUNT")
+	if mp != "" {
+		MountPath = strings.Trim(mp, "/")
+	}
+}
+
+func NewClientOrDie() *api.Client {
+	client, err := NewClient()
+	if err != nil {
+		panic(errors.Wrap(err, "failed to create vault client"))
+	}
+	return client
+}
+
+// NewClient creates a client using the standard environment variables like VAULT_ADDR and VAULT_TOKEN.
+// If VAULT_TOKEN_FILE is set, the token is read from this file instead, e.g. the sink of a Vault agent.
+func NewClient() (*api.Client, error) {
+	config := api.DefaultConfig()
+	if config.Error != nil {
+		return nil, errors.Wrap(config.Error, "failed to load config")
+	}
+	client, err := api.NewClient(config)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create client")
+	}
+
+	tokenFile := os.Getenv("VAULT_TOKEN_FILE")
+	if tokenFile != "" {
+		token, err := os.ReadFile(tokenFile) //nolint:gosec
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read token file")
+		}
+		client.SetToken(strings.TrimSpace(string(token)))
+	}
+	if client.Token() == "" {
+		return nil, errors.New("either VAULT_TOKEN or VAULT_TOKEN_FILE must be set")
+	}
+
+	return client, nil
+}
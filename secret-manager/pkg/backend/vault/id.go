@@ -0,0 +1,103 @@
+package vault
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/backend"
+)
+
+// BasePath is prepended to the paths of all secrets in the KV engine
+var BasePath = ""
+
+func init() {
+	bp := os.Getenv("VAULT_BASE_PATH")
+	if bp != "" {
+		BasePath = strings.Trim(bp, "/")
+	}
+}
+
+var _ backend.SecretId = VaultSecretId{}
+
+type VaultSecretId struct {
+	Raw      string
+	env      string
+	team     string
+	app      string
+	path     string
+	checksum string
+}
+
+func Copy(id VaultSecretId) VaultSecretId {
+	return id
+}
+
+func New(env, team, app, path string, checksum string) VaultSecretId {
+	raw := strings.Join([]string{env, team, app, path, checksum}, backend.Separator)
+	return VaultSecretId{
+		Raw:      raw,
+		env:      env,
+		team:     team,
+		app:      app,
+		path:     path,
+		checksum: checksum,
+	}
+}
+
+func FromString(raw string) (id VaultSecretId, err error) {
+	parsed, err := backend.ParseId(raw)
+	if err != nil {
+		return id, err
+	}
+
+	return VaultSecretId{
+		Raw:      raw,
+		env:      parsed.Env,
+		team:     parsed.Team,
+		app:      parsed.App,
+		path:     parsed.Path,
+		checksum: parsed.Checksum,
+	}, nil
+}
+
+func (v VaultSecretId) Env() string {
+	return v.env
+}
+
+func (v VaultSecretId) String() string {
+	return fmt.Sprintf("%s:%s:%s:%s:%s", v.env, v.team, v.app, v.path, v.checksum)
+}
+
+// SecretPath returns the path of the KV secret that contains all secrets of the scope,
+// e.g. <env>/<team>/<app>
+func (v VaultSecretId) SecretPath() string {
+	parts := []string{}
+	for _, part := range []string{BasePath, v.env, v.team, v.app} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, "/")
+}
+
+// Version returns the version of the KV secret that is referenced by the checksum.
+// It returns false if the latest version is referenced.
+func (v VaultSecretId) Version() (int, bool) {
+	return backend.ParseVersion(v.checksum)
+}
+
+// JsonPath returns the field of the KV secret and the path to the secret in its JSON value
+func (v VaultSecretId) JsonPath() (key string, subPath string) {
+	parts := strings.SplitN(v.path, "/", 2)
+	if len(parts) == 1 {
+		return v.path, ""
+	}
+	return parts[0], parts[1]
+}
+
+func (v VaultSecretId) CopyWithChecksum(checksum string) VaultSecretId {
+	new := Copy(v)
+	new.checksum = checksum
+	return new
+}
@@ -0,0 +1,207 @@
+package vault
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"github.com/hashicorp/vault/api"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/backend"
+)
+
+var _ backend.Onboarder = &VaultOnboarder{}
+var _ backend.ScopeLister = &VaultOnboarder{}
+
+type VaultOnboarder struct {
+	client    *api.Client
+	kv        *api.KVv2
+	blueprint backend.Blueprint
+}
+
+func NewOnboarder(client *api.Client) *VaultOnboarder {
+	return &VaultOnboarder{
+		client:    client,
+		kv:        client.KVv2(MountPath),
+		blueprint: backend.DefaultBlueprint(),
+	}
+}
+
+// WithBlueprint sets the secrets that are created for each level
+func (v *VaultOnboarder) WithBlueprint(blueprint backend.Blueprint) *VaultOnboarder {
+	v.blueprint = blueprint
+	return v
+}
+
+func (v *VaultOnboarder) OnboardEnvironment(ctx context.Context, env string) (backend.OnboardResponse, error) {
+	return v.onboard(ctx, New(env, "", "", "", ""), v.blueprint.Environment)
+}
+
+func (v *VaultOnboarder) OnboardTeam(ctx context.Context, env string, teamId string) (backend.OnboardResponse, error) {
+	return v.onboard(ctx, New(env, teamId, "", "", ""), v.blueprint.Team)
+}
+
+func (v *VaultOnboarder) OnboardApplication(ctx context.Context, env string, teamId string, appId string) (backend.OnboardResponse, error) {
+	return v.onboard(ctx, New(env, teamId, appId, "", ""), v.blueprint.Application)
+}
+
+func (v *VaultOnboarder) DeleteEnvironment(ctx context.Context, env string) error {
+	return v.deleteTree(ctx, New(env, "", "", "", ""))
+}
+
+func (v *VaultOnboarder) DeleteTeam(ctx context.Context, env string, id string) error {
+	return v.deleteTree(ctx, New(env, id, "", "", ""))
+}
+
+func (v *VaultOnboarder) DeleteApplication(ctx context.Context, env string, teamId string, appId string) error {
+	return v.deleteTree(ctx, New(env, teamId, appId, "", ""))
+}
+
+// onboard creates the KV secret of the scope with its initial data.
+// Only the initial onboarding is done. After that, the data can only be changed using the secrets-API.
+func (v *VaultOnboarder) onboard(ctx context.Context, scope VaultSecretId, blueprint backend.LevelBlueprint) (backend.OnboardResponse, error) {
+	log := logr.FromContextOrDiscard(ctx)
+	path := scope.SecretPath()
+
+	kvSecret, err := v.kv.Get(ctx, path)
+	if err != nil && !backend.IsNotFoundErr(handleError(err, scope)) {
+		return backend.NewDefaultOnboardResponse(nil), handleError(err, scope)
+	}
+
+	var version int
+	if kvSecret != nil && kvSecret.Data != nil {
+		version = kvSecret.VersionMetadata.Version
+	} else {
+		cas := 0
+		if kvSecret != nil && kvSecret.VersionMetadata != nil {
+			cas = kvSecret.VersionMetadata.Version
+		}
+		data, err := initialData(blueprint, backend.Scope{Env: scope.env, Team: scope.team, App: scope.app})
+		if err != nil {
+			return backend.NewDefaultOnboardResponse(nil), backend.NewBackendError(scope, err, "InternalError")
+		}
+		log.Info("Creating secrets", "path", path)
+		written, err := v.kv.Put(ctx, path, data, api.WithCheckAndSet(cas))
+		if err != nil {
+			// The secret has been created concurrently
+			if isCheckAndSetErr(err) {
+				return v.onboard(ctx, scope, blueprint)
+			}
+			return backend.NewDefaultOnboardResponse(nil), handleError(err, scope)
+		}
+		version = written.VersionMetadata.Version
+	}
+
+	secretRefs := make(map[string]backend.SecretRef, len(blueprint.Secrets))
+	for _, secret := range blueprint.Names() {
+		secretRefs[secret] = New(scope.env, scope.team, scope.app, secret, backend.MakeVersion(version))
+	}
+	return backend.NewDefaultOnboardResponse(secretRefs), nil
+}
+
+func initialData(blueprint backend.LevelBlueprint, scope backend.Scope) (map[string]any, error) {
+	data := make(map[string]any, len(blueprint.Secrets))
+	for _, secret := range blueprint.Secrets {
+		value, _, err := secret.InitialValue(scope)
+		if err != nil {
+			return nil, err
+		}
+		data[secret.Name] = value
+	}
+	return data, nil
+}
+
+// deleteTree deletes the KV secret of the scope together with all secrets below its path,
+// e.g. the teams and applications of an environment
+func (v *VaultOnboarder) deleteTree(ctx context.Context, scope VaultSecretId) error {
+	log := logr.FromContextOrDiscard(ctx)
+	path := scope.SecretPath()
+
+	if _, err := v.kv.GetMetadata(ctx, path); err != nil {
+		if err = handleError(err, scope); backend.IsNotFoundErr(err) {
+			return backend.ErrNotFound()
+		}
+		return err
+	}
+
+	log.Info("Deleting secrets", "path", path)
+	if err := v.deleteChildren(ctx, path); err != nil {
+		return backend.NewBackendError(scope, err, "InternalError")
+	}
+	if err := v.kv.DeleteMetadata(ctx, path); err != nil {
+		return handleError(err, scope)
+	}
+	return nil
+}
+
+func (v *VaultOnboarder) deleteChildren(ctx context.Context, path string) error {
+	list, err := v.client.Logical().ListWithContext(ctx, MountPath+"/metadata/"+path)
+	if err != nil {
+		return err
+	}
+	if list == nil || list.Data == nil {
+		return nil
+	}
+	keys, _ := list.Data["keys"].([]any)
+	for _, key := range keys {
+		name, ok := key.(string)
+		if !ok {
+			continue
+		}
+		child := path + "/" + strings.TrimSuffix(name, "/")
+		if strings.HasSuffix(name, "/") {
+			if err := v.deleteChildren(ctx, child); err != nil {
+				return err
+			}
+		}
+		if err := v.kv.DeleteMetadata(ctx, child); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ListScopes returns the scopes of all KV secrets below the BasePath.
+// The KV secrets of the environments, teams and applications are located at the first three levels.
+func (v *VaultOnboarder) ListScopes(ctx context.Context) ([]backend.Scope, error) {
+	scopes := []backend.Scope{}
+	err := v.listScopes(ctx, New("", "", "", "", "").SecretPath(), nil, &scopes)
+	if err != nil {
+		return nil, backend.NewBackendError(nil, err, "InternalError")
+	}
+	return scopes, nil
+}
+
+func (v *VaultOnboarder) listScopes(ctx context.Context, path string, parents []string, scopes *[]backend.Scope) error {
+	listPath := MountPath + "/metadata"
+	if path != "" {
+		listPath += "/" + path
+	}
+	list, err := v.client.Logical().ListWithContext(ctx, listPath)
+	if err != nil {
+		return err
+	}
+	if list == nil || list.Data == nil {
+		return nil
+	}
+	keys, _ := list.Data["keys"].([]any)
+	for _, key := range keys {
+		name, ok := key.(string)
+		if !ok {
+			continue
+		}
+		segments := append(slices.Clone(parents), strings.TrimSuffix(name, "/"))
+		if !strings.HasSuffix(name, "/") {
+			segments = append(segments, "", "")
+			*scopes = append(*scopes, backend.Scope{Env: segments[0], Team: segments[1], App: segments[2]})
+			continue
+		}
+		if len(segments) < 3 {
+			child := strings.TrimPrefix(path+"/"+segments[len(segments)-1], "/")
+			if err := v.listScopes(ctx, child, segments, scopes); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
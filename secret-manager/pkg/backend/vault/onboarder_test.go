@@ -0,0 +1,124 @@
+package vault_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/backend"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/backend/vault"
+)
+
+var _ = Describe("Vault Onboarder", func() {
+
+	var ctx context.Context
+	var onboarder *vault.VaultOnboarder
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		onboarder = vault.NewOnboarder(client)
+	})
+
+	Context("Onboard", func() {
+
+		It("should onboard an environment", func() {
+			res, err := onboarder.OnboardEnvironment(ctx, "test")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(res.SecretRefs()).To(HaveLen(1))
+			Expect(res.SecretRefs()["zones"].String()).To(Equal("test:::zones:v1"))
+
+			data, ok := server.Data("controlplane/test")
+			Expect(ok).To(BeTrue())
+			Expect(data).To(HaveKeyWithValue("zones", ""))
+		})
+
+		It("should onboard a team", func() {
+			res, err := onboarder.OnboardTeam(ctx, "test", "my-team")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(res.SecretRefs()).To(HaveLen(2))
+			Expect(res.SecretRefs()["clientSecret"].String()).To(Equal("test:my-team::clientSecret:v1"))
+			Expect(res.SecretRefs()["teamToken"].String()).To(Equal("test:my-team::teamToken:v1"))
+
+			data, _ := server.Data("controlplane/test/my-team")
+			Expect(data["clientSecret"]).ToNot(BeEmpty())
+			Expect(data["teamToken"]).ToNot(BeEmpty())
+		})
+
+		It("should onboard an application only once", func() {
+			res, err := onboarder.OnboardApplication(ctx, "test", "my-team", "my-app")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(res.SecretRefs()["externalSecrets"].String()).To(Equal("test:my-team:my-app:externalSecrets:v1"))
+			data, _ := server.Data("controlplane/test/my-team/my-app")
+			clientSecret := data["clientSecret"]
+
+			_, err = vault.NewBackend(client).Set(ctx, vault.New("test", "my-team", "my-app", "externalSecrets/foo", ""), backend.String("bar"))
+			Expect(err).ToNot(HaveOccurred())
+
+			res, err = onboarder.OnboardApplication(ctx, "test", "my-team", "my-app")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(res.SecretRefs()["clientSecret"].String()).To(Equal("test:my-team:my-app:clientSecret:v2"))
+
+			data, _ = server.Data("controlplane/test/my-team/my-app")
+			Expect(data["clientSecret"]).To(Equal(clientSecret))
+			Expect(data["externalSecrets"]).To(MatchJSON(`{"foo":"bar"}`))
+		})
+	})
+
+	Context("Delete", func() {
+
+		BeforeEach(func() {
+			_, err := onboarder.OnboardEnvironment(ctx, "test")
+			Expect(err).ToNot(HaveOccurred())
+			_, err = onboarder.OnboardTeam(ctx, "test", "my-team")
+			Expect(err).ToNot(HaveOccurred())
+			_, err = onboarder.OnboardApplication(ctx, "test", "my-team", "my-app")
+			Expect(err).ToNot(HaveOccurred())
+			_, err = onboarder.OnboardApplication(ctx, "test", "my-team", "other-app")
+			Expect(err).ToNot(HaveOccurred())
+			_, err = onboarder.OnboardEnvironment(ctx, "other")
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should delete an application", func() {
+			Expect(onboarder.DeleteApplication(ctx, "test", "my-team", "my-app")).To(Succeed())
+			Expect(server.Paths()).To(Equal([]string{
+				"controlplane/other",
+				"controlplane/test",
+				"controlplane/test/my-team",
+				"controlplane/test/my-team/other-app",
+			}))
+		})
+
+		It("should delete a team with its applications", func() {
+			Expect(onboarder.DeleteTeam(ctx, "test", "my-team")).To(Succeed())
+			Expect(server.Paths()).To(Equal([]string{
+				"controlplane/other",
+				"controlplane/test",
+			}))
+		})
+
+		It("should delete an environment with its teams and applications", func() {
+			Expect(onboarder.DeleteEnvironment(ctx, "test")).To(Succeed())
+			Expect(server.Paths()).To(Equal([]string{
+				"controlplane/other",
+			}))
+		})
+
+		It("should list the onboarded scopes", func() {
+			scopes, err := onboarder.ListScopes(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(scopes).To(ConsistOf(
+				backend.Scope{Env: "test"},
+				backend.Scope{Env: "test", Team: "my-team"},
+				backend.Scope{Env: "test", Team: "my-team", App: "my-app"},
+				backend.Scope{Env: "test", Team: "my-team", App: "other-app"},
+				backend.Scope{Env: "other"},
+			))
+		})
+
+		It("should return not found for an unknown application", func() {
+			err := onboarder.DeleteApplication(ctx, "test", "my-team", "unknown")
+			Expect(backend.IsNotFoundErr(err)).To(BeTrue())
+		})
+	})
+})
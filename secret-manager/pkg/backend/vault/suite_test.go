@@ -0,0 +1,29 @@
+package vault_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/vault/api"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/backend/vault"
+	"github.com/telekom/controlplane-mono/secret-manager/test/vaulttest"
+)
+
+var server *vaulttest.Server
+var client *api.Client
+
+func TestVault(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Vault Suite")
+}
+
+var _ = BeforeEach(func() {
+	vault.BasePath = "controlplane"
+	server = vaulttest.NewServer(vault.MountPath)
+	DeferCleanup(server.Close)
+
+	var err error
+	client, err = server.NewClient()
+	Expect(err).ToNot(HaveOccurred())
+})
@@ -0,0 +1,74 @@
+package vault
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/vault/api"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/backend"
+	"github.com/tidwall/gjson"
+)
+
+// lookup returns the value of the field of the KV secret.
+// If a subPath is given, the field must contain a JSON object.
+func lookup(data map[string]any, key, subPath string) (string, bool) {
+	raw, ok := data[key]
+	if !ok || raw == nil {
+		return "", false
+	}
+	value, ok := raw.(string)
+	if !ok {
+		// Fields that have not been written by the secret-manager may contain any JSON value
+		b, err := json.Marshal(raw)
+		if err != nil {
+			return "", false
+		}
+		value = string(b)
+	}
+	if subPath == "" {
+		return value, true
+	}
+	result := gjson.Get(value, subPath)
+	if !result.Exists() {
+		return "", false
+	}
+	return result.String(), true
+}
+
+func isCheckAndSetErr(err error) bool {
+	var respErr *api.ResponseError
+	if !errors.As(err, &respErr) || respErr.StatusCode != http.StatusBadRequest {
+		return false
+	}
+	for _, msg := range respErr.Errors {
+		if strings.Contains(msg, "check-and-set") {
+			return true
+		}
+	}
+	return false
+}
+
+func handleError(err error, id VaultSecretId) error {
+	if backend.IsBackendError(err) {
+		return err
+	}
+	if errors.Is(err, api.ErrSecretNotFound) {
+		return backend.ErrSecretNotFound(id)
+	}
+	var respErr *api.ResponseError
+	if errors.As(err, &respErr) {
+		switch respErr.StatusCode {
+		case http.StatusNotFound:
+			return backend.ErrSecretNotFound(id)
+		case http.StatusTooManyRequests:
+			return backend.NewBackendError(id, err, backend.TypeErrTooManyRequests)
+		}
+	}
+	if isCheckAndSetErr(err) {
+		return backend.ErrIncorrectState(id, fmt.Errorf("secret has been changed concurrently: %w", err))
+	}
+	return backend.NewBackendError(id, err, "InternalError")
+}
@@ -1,6 +1,11 @@
 package controller
 
-import "github.com/telekom/controlplane-mono/secret-manager/pkg/backend"
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/backend"
+)
 
 type Controller interface {
 	SecretsController
@@ -18,3 +23,11 @@ func NewController[T backend.SecretId, S backend.Secret[T]](b backend.Backend[T,
 		OnboardController: NewOnboardController(o),
 	}
 }
+
+// LogError logs the error of the bookkeeping of a controller that wraps another one, e.g. to track the rotation of the secrets.
+// It must never fail the request, as the secret itself has already been changed.
+func LogError(ctx context.Context, err error, msg string, keysAndValues ...any) {
+	if err != nil {
+		logr.FromContextOrDiscard(ctx).Error(err, msg, keysAndValues...)
+	}
+}
@@ -5,8 +5,10 @@ import (
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
 	"github.com/telekom/controlplane-mono/secret-manager/pkg/backend"
 	"github.com/telekom/controlplane-mono/secret-manager/pkg/controller"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/generator"
 	"github.com/telekom/controlplane-mono/secret-manager/test/mocks"
 )
 
@@ -57,6 +59,48 @@ var _ = Describe("Controller", func() {
 			Expect(res.Value).To(Equal("my-value"))
 		})
 
+		It("should generate a secret", func() {
+			ctx := context.Background()
+			ctrl := controller.NewSecretsController(mockedBackend)
+
+			secretId := mocks.NewMockSecretId(GinkgoT())
+			secretId.EXPECT().String().Return("my-secret-id")
+
+			mockedBackend.EXPECT().ParseSecretId("my-secret-id").Return(secretId, nil).Times(1)
+			mockedBackend.EXPECT().Set(ctx, secretId, mock.Anything).RunAndReturn(func(_ context.Context, id *mocks.MockSecretId, value backend.SecretValue) (backend.DefaultSecret[*mocks.MockSecretId], error) {
+				return backend.NewDefaultSecret(id, value.Value()), nil
+			}).Times(1)
+
+			res, err := ctrl.GenerateSecret(ctx, "my-secret-id", generator.Config{Type: generator.TypePassword, Length: 20, MinSymbols: 20})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(res.Id).To(Equal("my-secret-id"))
+			Expect(res.Value).To(MatchRegexp(`^[^a-zA-Z0-9]{20}$`))
+		})
+
+		It("should get the public key of a secret", func() {
+			ctx := context.Background()
+			ctrl := controller.NewSecretsController(mockedBackend)
+			g, err := generator.New(generator.Config{Type: generator.TypeEC})
+			Expect(err).ToNot(HaveOccurred())
+			key, err := g.Generate()
+			Expect(err).ToNot(HaveOccurred())
+
+			secretId := mocks.NewMockSecretId(GinkgoT())
+			secretId.EXPECT().String().Return("my-secret-id")
+
+			mockedBackend.EXPECT().ParseSecretId("my-secret-id").Return(secretId, nil).Times(2)
+			mockedBackend.EXPECT().Get(ctx, secretId).Return(backend.NewDefaultSecret(secretId, key), nil).Times(1)
+
+			res, err := ctrl.GetPublicKey(ctx, "my-secret-id")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(res.Id).To(Equal("my-secret-id"))
+			Expect(res.PublicKey).To(HavePrefix("-----BEGIN PUBLIC KEY-----"))
+
+			mockedBackend.EXPECT().Get(ctx, secretId).Return(backend.NewDefaultSecret(secretId, "my-password"), nil).Times(1)
+			_, err = ctrl.GetPublicKey(ctx, "my-secret-id")
+			Expect(err).To(MatchError(generator.ErrNoPublicKey))
+		})
+
 		It("should delete a secret", func() {
 			ctx := context.Background()
 			ctrl := controller.NewSecretsController(mockedBackend)
@@ -71,6 +115,108 @@ var _ = Describe("Controller", func() {
 			Expect(err).ToNot(HaveOccurred())
 		})
 
+		It("should list the versions of a secret", func() {
+			ctx := context.Background()
+			ctrl := controller.NewSecretsController(mockedBackend)
+
+			secretId := mocks.NewMockSecretId(GinkgoT())
+			secretId.EXPECT().String().Return("env:team:app:clientSecret:")
+			v2 := mocks.NewMockSecretId(GinkgoT())
+			v2.EXPECT().String().Return("env:team:app:clientSecret:v2")
+			v1 := mocks.NewMockSecretId(GinkgoT())
+			v1.EXPECT().String().Return("env:team:app:clientSecret:v1")
+
+			mockedBackend.EXPECT().ParseSecretId("env:team:app:clientSecret:").Return(secretId, nil).Times(1)
+			mockedBackend.EXPECT().Versions(ctx, secretId).Return([]*mocks.MockSecretId{v2, v1}, nil).Times(1)
+
+			res, err := ctrl.ListSecretVersions(ctx, "env:team:app:clientSecret:")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(res).To(Equal([]controller.SecretVersionResponse{
+				{Version: "v2", Id: "env:team:app:clientSecret:v2"},
+				{Version: "v1", Id: "env:team:app:clientSecret:v1"},
+			}))
+		})
+
+		Context("List Secrets", func() {
+
+			newSecretId := func(raw string) *mocks.MockSecretId {
+				secretId := mocks.NewMockSecretId(GinkgoT())
+				secretId.EXPECT().String().Return(raw).Maybe()
+				return secretId
+			}
+
+			var ids map[string]*mocks.MockSecretId
+
+			BeforeEach(func() {
+				ids = map[string]*mocks.MockSecretId{
+					"clientSecret":    newSecretId("env:team:app:clientSecret:1"),
+					"externalSecrets": newSecretId("env:team:app:externalSecrets:1"),
+					"teamToken":       newSecretId("env:team:app:teamToken:1"),
+				}
+			})
+
+			It("should list the secrets sorted by name", func() {
+				ctx := context.Background()
+				ctrl := controller.NewSecretsController(mockedBackend)
+
+				mockedBackend.EXPECT().List(ctx, "env", "team", "app").Return(ids, nil).Times(1)
+
+				res, err := ctrl.ListSecrets(ctx, controller.ListSecretsRequest{Env: "env", Team: "team", App: "app"})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(res.Next).To(BeEmpty())
+				Expect(res.Items).To(Equal([]controller.SecretRefResponse{
+					{Name: "clientSecret", Id: "env:team:app:clientSecret:1"},
+					{Name: "externalSecrets", Id: "env:team:app:externalSecrets:1"},
+					{Name: "teamToken", Id: "env:team:app:teamToken:1"},
+				}))
+			})
+
+			It("should paginate the secrets", func() {
+				ctx := context.Background()
+				ctrl := controller.NewSecretsController(mockedBackend)
+
+				mockedBackend.EXPECT().List(ctx, "env", "team", "app").Return(ids, nil).Times(2)
+
+				req := controller.ListSecretsRequest{Env: "env", Team: "team", App: "app", Limit: 2}
+				res, err := ctrl.ListSecrets(ctx, req)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(res.Items).To(HaveLen(2))
+				Expect(res.Next).To(Equal("externalSecrets"))
+
+				req.Cursor = res.Next
+				res, err = ctrl.ListSecrets(ctx, req)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(res.Items).To(HaveLen(1))
+				Expect(res.Items[0].Name).To(Equal("teamToken"))
+				Expect(res.Next).To(BeEmpty())
+			})
+
+			It("should filter the secrets before paginating", func() {
+				ctx := context.Background()
+				ctrl := controller.NewSecretsController(mockedBackend)
+
+				mockedBackend.EXPECT().List(ctx, "env", "team", "app").Return(ids, nil).Times(1)
+
+				res, err := ctrl.ListSecrets(ctx, controller.ListSecretsRequest{
+					Env: "env", Team: "team", App: "app", Limit: 1,
+					Filter: func(name string) bool { return name != "clientSecret" },
+				})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(res.Items).To(HaveLen(1))
+				Expect(res.Items[0].Name).To(Equal("externalSecrets"))
+				Expect(res.Next).To(Equal("externalSecrets"))
+			})
+
+			It("should reject an app scope without team", func() {
+				ctx := context.Background()
+				ctrl := controller.NewSecretsController(mockedBackend)
+
+				_, err := ctrl.ListSecrets(ctx, controller.ListSecretsRequest{Env: "env", App: "app"})
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal("InvalidSecretId: team cannot be empty if app is set"))
+			})
+		})
+
 	})
 
 	Context("Onboard Controller", func() {
@@ -2,21 +2,74 @@ package controller
 
 import (
 	"context"
+	"slices"
+	"strings"
 
-	"github.com/google/uuid"
+	"github.com/pkg/errors"
 	"github.com/telekom/controlplane-mono/secret-manager/pkg/api"
 	"github.com/telekom/controlplane-mono/secret-manager/pkg/backend"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/generator"
 )
 
 type SecretResponse struct {
 	Id    string `json:"id"`
 	Value string `json:"value"`
+	// Stale is true if the value has been served from the cache because the backend is unavailable
+	Stale bool `json:"stale,omitempty"`
+}
+
+const (
+	DefaultListLimit = 50
+	MaxListLimit     = 500
+)
+
+type ListSecretsRequest struct {
+	Env  string
+	Team string
+	App  string
+	// Limit is the maximum number of items. It defaults to DefaultListLimit.
+	Limit int
+	// Cursor is the name of the last item of the previous page
+	Cursor string
+	// Filter is used to hide secrets, e.g. if the caller is not allowed to access them.
+	// It is applied before paginating.
+	Filter func(name string) bool
+}
+
+type SecretRefResponse struct {
+	Name string `json:"name"`
+	Id   string `json:"id"`
+}
+
+type ListSecretsResponse struct {
+	Items []SecretRefResponse `json:"items"`
+	// Next is the cursor of the next page. It is empty on the last page.
+	Next string `json:"next,omitempty"`
+}
+
+type SecretVersionResponse struct {
+	Version string `json:"version"`
+	Id      string `json:"id"`
+}
+
+// PublicKeyResponse contains the part of a key pair or certificate that can be shared
+type PublicKeyResponse struct {
+	Id        string `json:"id"`
+	PublicKey string `json:"publicKey"`
 }
 
 type SecretsController interface {
+	ListSecrets(ctx context.Context, req ListSecretsRequest) (ListSecretsResponse, error)
+	ListSecretVersions(ctx context.Context, rawId string) ([]SecretVersionResponse, error)
 	GetSecret(ctx context.Context, rawId string) (SecretResponse, error)
+	// GetPublicKey returns the public key of a key pair or the certificates of a certificate secret
+	GetPublicKey(ctx context.Context, rawId string) (PublicKeyResponse, error)
 	SetSecret(ctx context.Context, rawId, value string) (SecretResponse, error)
+	// GenerateSecret sets the secret to a new value of the generator
+	GenerateSecret(ctx context.Context, rawId string, cfg generator.Config) (SecretResponse, error)
 	DeleteSecret(ctx context.Context, rawId string) error
+	// UndeleteSecret restores a deleted secret. It requires soft-deletion, see package tombstone.
+	UndeleteSecret(ctx context.Context, rawId string) (SecretResponse, error)
 }
 
 type secretsController[T backend.SecretId, S backend.Secret[T]] struct {
@@ -27,6 +80,69 @@ func NewSecretsController[T backend.SecretId, S backend.Secret[T]](b backend.Bac
 	return &secretsController[T, S]{Backend: b}
 }
 
+func (c *secretsController[T, S]) ListSecrets(ctx context.Context, req ListSecretsRequest) (res ListSecretsResponse, err error) {
+	if req.Env == "" {
+		return res, backend.NewBackendError(nil, errors.New("env cannot be empty"), backend.TypeErrInvalidSecretId)
+	}
+	if req.App != "" && req.Team == "" {
+		return res, backend.NewBackendError(nil, errors.New("team cannot be empty if app is set"), backend.TypeErrInvalidSecretId)
+	}
+	limit := req.Limit
+	if limit <= 0 {
+		limit = DefaultListLimit
+	}
+	limit = min(limit, MaxListLimit)
+
+	ids, err := c.Backend.List(ctx, req.Env, req.Team, req.App)
+	if err != nil {
+		return res, err
+	}
+
+	names := make([]string, 0, len(ids))
+	for name := range ids {
+		if name <= req.Cursor {
+			continue
+		}
+		if req.Filter != nil && !req.Filter(name) {
+			continue
+		}
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	if len(names) > limit {
+		names = names[:limit]
+		res.Next = names[limit-1]
+	}
+
+	res.Items = make([]SecretRefResponse, 0, len(names))
+	for _, name := range names {
+		res.Items = append(res.Items, SecretRefResponse{Name: name, Id: ids[name].String()})
+	}
+	return res, nil
+}
+
+func (c *secretsController[T, S]) ListSecretVersions(ctx context.Context, rawId string) ([]SecretVersionResponse, error) {
+	id, err := c.Backend.ParseSecretId(rawId)
+	if err != nil {
+		return nil, err
+	}
+
+	ids, err := c.Backend.Versions(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]SecretVersionResponse, 0, len(ids))
+	for _, versionId := range ids {
+		raw := versionId.String()
+		// The version is always the last segment of the ID
+		version := raw[strings.LastIndex(raw, backend.Separator)+1:]
+		res = append(res, SecretVersionResponse{Version: version, Id: raw})
+	}
+	return res, nil
+}
+
 func (c *secretsController[T, S]) GetSecret(ctx context.Context, rawId string) (res SecretResponse, err error) {
 	id, err := c.Backend.ParseSecretId(rawId)
 	if err != nil {
@@ -38,7 +154,19 @@ func (c *secretsController[T, S]) GetSecret(ctx context.Context, rawId string) (
 		return res, err
 	}
 
-	return SecretResponse{Id: secret.Id().String(), Value: secret.Value()}, nil
+	return SecretResponse{Id: secret.Id().String(), Value: secret.Value(), Stale: backend.IsStale(secret)}, nil
+}
+
+func (c *secretsController[T, S]) GetPublicKey(ctx context.Context, rawId string) (res PublicKeyResponse, err error) {
+	secret, err := c.GetSecret(ctx, rawId)
+	if err != nil {
+		return res, err
+	}
+	publicKey, err := generator.PublicKey(secret.Value)
+	if err != nil {
+		return res, err
+	}
+	return PublicKeyResponse{Id: secret.Id, PublicKey: publicKey}, nil
 }
 
 func (c *secretsController[T, S]) SetSecret(ctx context.Context, rawId, value string) (res SecretResponse, err error) {
@@ -47,12 +175,38 @@ func (c *secretsController[T, S]) SetSecret(ctx context.Context, rawId, value st
 		return res, err
 	}
 
-	secretValue := backend.String(value)
 	if value == api.KeywordRotate {
-		secretValue = backend.String(uuid.NewString())
+		if value, err = generator.UUID.Generate(); err != nil {
+			return res, err
+		}
 	}
 
-	secret, err := c.Backend.Set(ctx, id, secretValue)
+	secret, err := c.Backend.Set(ctx, id, backend.String(value))
+	if err != nil {
+		return res, err
+	}
+	return SecretResponse{Id: secret.Id().String(), Value: secret.Value()}, nil
+}
+
+func (c *secretsController[T, S]) GenerateSecret(ctx context.Context, rawId string, cfg generator.Config) (res SecretResponse, err error) {
+	id, err := c.Backend.ParseSecretId(rawId)
+	if err != nil {
+		return res, err
+	}
+	// The scope is only known here, certificates are not signed by the CA without it
+	if parsed, err := backend.ParseId(rawId); err == nil {
+		cfg.Scope = parsed.Scope.Generator()
+	}
+	g, err := generator.New(cfg)
+	if err != nil {
+		return res, err
+	}
+	value, err := g.Generate()
+	if err != nil {
+		return res, err
+	}
+
+	secret, err := c.Backend.Set(ctx, id, backend.String(value))
 	if err != nil {
 		return res, err
 	}
@@ -67,3 +221,12 @@ func (c *secretsController[T, S]) DeleteSecret(ctx context.Context, rawId string
 
 	return c.Backend.Delete(ctx, id)
 }
+
+// UndeleteSecret always fails as the backend deletes secrets immediately
+func (c *secretsController[T, S]) UndeleteSecret(ctx context.Context, rawId string) (res SecretResponse, err error) {
+	id, err := c.Backend.ParseSecretId(rawId)
+	if err != nil {
+		return res, err
+	}
+	return res, backend.NewBackendError(id, errors.New("deleted secrets are not kept"), backend.TypeErrNotFound)
+}
@@ -0,0 +1,148 @@
+package events
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultCapacity is the default number of events that are kept by the broker
+const DefaultCapacity = 1000
+
+// Filter is used to hide events, e.g. if the caller is not allowed to access the secret
+type Filter func(e Event) bool
+
+// WatchResult is the result of a watch
+type WatchResult struct {
+	Events []Event
+	// Cursor must be passed to the next watch to receive the following events
+	Cursor string
+	// Reset is set if events might have been missed since the cursor,
+	// e.g. because they have already been dropped or the server has been restarted.
+	// The watcher must then assume that all of its secrets have changed.
+	Reset bool
+}
+
+// Broker keeps the latest events in memory and notifies the watchers about new events.
+// Events are only kept in memory, so a cursor is only valid for the broker that created it.
+type Broker struct {
+	mu     sync.Mutex
+	epoch  string
+	seq    uint64
+	events []Event
+	// changed is closed and replaced whenever an event is published
+	changed chan struct{}
+
+	capacity int
+	now      func() time.Time
+}
+
+func NewBroker(capacity int) *Broker {
+	if capacity <= 0 {
+		capacity = DefaultCapacity
+	}
+	return &Broker{
+		epoch:    strconv.FormatInt(time.Now().UnixNano(), 36),
+		events:   make([]Event, 0, capacity),
+		changed:  make(chan struct{}),
+		capacity: capacity,
+		now:      time.Now,
+	}
+}
+
+// Publish adds the event and wakes up all watchers
+func (b *Broker) Publish(e Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.seq++
+	e.seq = b.seq
+	if e.Time.IsZero() {
+		e.Time = b.now()
+	}
+	if len(b.events) == b.capacity {
+		copy(b.events, b.events[1:])
+		b.events = b.events[:len(b.events)-1]
+	}
+	b.events = append(b.events, e)
+
+	close(b.changed)
+	b.changed = make(chan struct{})
+}
+
+// Watch returns all events since the cursor whose secrets start with the prefix.
+// If there are none, it waits until a matching event is published or the context is done.
+// An empty cursor returns the current cursor immediately without any events.
+func (b *Broker) Watch(ctx context.Context, cursor, prefix string, filter Filter) WatchResult {
+	seq, reset := b.parseCursor(cursor)
+	if cursor == "" || reset {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		return WatchResult{Cursor: b.cursor(b.seq), Reset: reset}
+	}
+
+	for {
+		b.mu.Lock()
+		res := b.since(seq, prefix, filter)
+		changed := b.changed
+		b.mu.Unlock()
+
+		if len(res.Events) > 0 || res.Reset {
+			return res
+		}
+		// Skip the events that did not match
+		seq, _ = b.parseCursor(res.Cursor)
+
+		select {
+		case <-ctx.Done():
+			return res
+		case <-changed:
+		}
+	}
+}
+
+// since must be called with the lock held
+func (b *Broker) since(seq uint64, prefix string, filter Filter) WatchResult {
+	res := WatchResult{Cursor: b.cursor(b.seq)}
+	if seq > b.seq {
+		// The cursor is from the future, e.g. a broker with the same epoch on another replica
+		res.Reset = true
+		return res
+	}
+	if len(b.events) > 0 && seq+1 < b.events[0].seq {
+		res.Reset = true
+	}
+	for _, e := range b.events {
+		if e.seq <= seq || !e.Matches(prefix) {
+			continue
+		}
+		if filter != nil && !filter(e) {
+			continue
+		}
+		res.Events = append(res.Events, e)
+	}
+	return res
+}
+
+func (b *Broker) cursor(seq uint64) string {
+	return b.epoch + "-" + strconv.FormatUint(seq, 10)
+}
+
+// parseCursor returns the sequence of the cursor.
+// It returns true if the cursor is invalid or has been created by another broker.
+func (b *Broker) parseCursor(cursor string) (uint64, bool) {
+	if cursor == "" {
+		return 0, false
+	}
+	epoch, rawSeq, ok := strings.Cut(cursor, "-")
+	if !ok || epoch != b.epoch {
+		return 0, true
+	}
+	seq, err := strconv.ParseUint(rawSeq, 10, 64)
+	if err != nil {
+		return 0, true
+	}
+	return seq, false
+}
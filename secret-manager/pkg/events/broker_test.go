@@ -0,0 +1,144 @@
+package events_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/backend"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/events"
+)
+
+func updated(secret string) events.Event {
+	return events.Event{Type: events.EventTypeUpdated, Secret: secret, Id: secret + "v2"}
+}
+
+func secretsOf(res events.WatchResult) []string {
+	secrets := make([]string, 0, len(res.Events))
+	for _, e := range res.Events {
+		secrets = append(secrets, e.Secret)
+	}
+	return secrets
+}
+
+var _ = Describe("Event", func() {
+
+	It("should match secrets by prefix", func() {
+		e := updated("env:team:app:clientSecret:")
+		Expect(e.Name()).To(Equal("clientSecret"))
+		_, ok := e.Scope()
+		Expect(ok).To(BeFalse())
+		Expect(e.Matches("")).To(BeTrue())
+		Expect(e.Matches("env:team:")).To(BeTrue())
+		Expect(e.Matches("env:other:")).To(BeFalse())
+		Expect(e.Matches("env:team:app:clientSecret:")).To(BeTrue())
+	})
+
+	It("should match all secrets below a deleted scope", func() {
+		e := events.Event{Type: events.EventTypeDeleted, Secret: "env:team:"}
+		Expect(e.Name()).To(BeEmpty())
+		scope, ok := e.Scope()
+		Expect(ok).To(BeTrue())
+		Expect(scope).To(Equal(backend.Scope{Env: "env", Team: "team"}))
+		Expect(e.Matches("env:")).To(BeTrue())
+		Expect(e.Matches("env:team:app:")).To(BeTrue())
+		Expect(e.Matches("env:other:")).To(BeFalse())
+	})
+})
+
+var _ = Describe("Broker", func() {
+
+	var ctx context.Context
+	var broker *events.Broker
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		broker = events.NewBroker(3)
+	})
+
+	It("should return the current cursor without a cursor", func() {
+		broker.Publish(updated("env:team::teamToken:"))
+
+		res := broker.Watch(ctx, "", "", nil)
+		Expect(res.Events).To(BeEmpty())
+		Expect(res.Reset).To(BeFalse())
+		Expect(res.Cursor).ToNot(BeEmpty())
+
+		broker.Publish(updated("env:team::clientSecret:"))
+		res = broker.Watch(ctx, res.Cursor, "", nil)
+		Expect(secretsOf(res)).To(Equal([]string{"env:team::clientSecret:"}))
+		Expect(res.Events[0].Time).ToNot(BeZero())
+	})
+
+	It("should only return matching events", func() {
+		cursor := broker.Watch(ctx, "", "", nil).Cursor
+		broker.Publish(updated("env:other::teamToken:"))
+		broker.Publish(updated("env:team::teamToken:"))
+		broker.Publish(updated("env:team::clientSecret:"))
+
+		res := broker.Watch(ctx, cursor, "env:team:", func(e events.Event) bool {
+			return e.Name() != "clientSecret"
+		})
+		Expect(secretsOf(res)).To(Equal([]string{"env:team::teamToken:"}))
+	})
+
+	It("should wait for matching events", func() {
+		cursor := broker.Watch(ctx, "", "", nil).Cursor
+
+		done := make(chan events.WatchResult)
+		go func() {
+			defer GinkgoRecover()
+			done <- broker.Watch(ctx, cursor, "env:team:", nil)
+		}()
+
+		broker.Publish(updated("env:other::teamToken:"))
+		Consistently(done, 50*time.Millisecond).ShouldNot(Receive())
+
+		broker.Publish(updated("env:team::teamToken:"))
+		var res events.WatchResult
+		Eventually(done).Should(Receive(&res))
+		Expect(secretsOf(res)).To(Equal([]string{"env:team::teamToken:"}))
+	})
+
+	It("should return no events when the context is done", func() {
+		cursor := broker.Watch(ctx, "", "", nil).Cursor
+		broker.Publish(updated("env:other::teamToken:"))
+
+		ctx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+		defer cancel()
+		res := broker.Watch(ctx, cursor, "env:team:", nil)
+		Expect(res.Events).To(BeEmpty())
+		Expect(res.Reset).To(BeFalse())
+
+		// The cursor skips the events that did not match
+		broker.Publish(updated("env:team::teamToken:"))
+		res = broker.Watch(context.Background(), res.Cursor, "", nil)
+		Expect(secretsOf(res)).To(Equal([]string{"env:team::teamToken:"}))
+	})
+
+	It("should reset if events have been dropped", func() {
+		cursor := broker.Watch(ctx, "", "", nil).Cursor
+		for _, secret := range []string{"a", "b", "c", "d"} {
+			broker.Publish(updated("env:::" + secret + ":"))
+		}
+
+		res := broker.Watch(ctx, cursor, "", nil)
+		Expect(res.Reset).To(BeTrue())
+		Expect(secretsOf(res)).To(Equal([]string{"env:::b:", "env:::c:", "env:::d:"}))
+	})
+
+	It("should reset if the cursor is from another broker", func() {
+		other := events.NewBroker(3)
+		other.Publish(updated("env:team::teamToken:"))
+		cursor := other.Watch(ctx, "", "", nil).Cursor
+
+		res := broker.Watch(ctx, cursor, "", nil)
+		Expect(res.Reset).To(BeTrue())
+		Expect(res.Events).To(BeEmpty())
+		Expect(res.Cursor).ToNot(Equal(cursor))
+
+		res = broker.Watch(ctx, "invalid", "", nil)
+		Expect(res.Reset).To(BeTrue())
+	})
+})
@@ -0,0 +1,89 @@
+package events
+
+import (
+	"context"
+
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/controller"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/generator"
+)
+
+var _ controller.Controller = &publishingController{}
+
+// publishingController publishes an event for each secret that is changed using the controller
+type publishingController struct {
+	controller.Controller
+	broker *Broker
+}
+
+// NewController wraps the controller to publish the changes of secrets to the broker.
+// Onboarding does not publish any events as it never changes existing secrets.
+func NewController(c controller.Controller, b *Broker) controller.Controller {
+	return &publishingController{Controller: c, broker: b}
+}
+
+func (c *publishingController) SetSecret(ctx context.Context, rawId, value string) (controller.SecretResponse, error) {
+	res, err := c.Controller.SetSecret(ctx, rawId, value)
+	if err != nil {
+		return res, err
+	}
+	if e, ok := secretEvent(EventTypeUpdated, rawId, res.Id, c.broker.now()); ok {
+		c.broker.Publish(e)
+	}
+	return res, nil
+}
+
+func (c *publishingController) GenerateSecret(ctx context.Context, rawId string, cfg generator.Config) (controller.SecretResponse, error) {
+	res, err := c.Controller.GenerateSecret(ctx, rawId, cfg)
+	if err != nil {
+		return res, err
+	}
+	if e, ok := secretEvent(EventTypeUpdated, rawId, res.Id, c.broker.now()); ok {
+		c.broker.Publish(e)
+	}
+	return res, nil
+}
+
+func (c *publishingController) DeleteSecret(ctx context.Context, rawId string) error {
+	if err := c.Controller.DeleteSecret(ctx, rawId); err != nil {
+		return err
+	}
+	if e, ok := secretEvent(EventTypeDeleted, rawId, "", c.broker.now()); ok {
+		c.broker.Publish(e)
+	}
+	return nil
+}
+
+func (c *publishingController) UndeleteSecret(ctx context.Context, rawId string) (controller.SecretResponse, error) {
+	res, err := c.Controller.UndeleteSecret(ctx, rawId)
+	if err != nil {
+		return res, err
+	}
+	if e, ok := secretEvent(EventTypeUpdated, rawId, res.Id, c.broker.now()); ok {
+		c.broker.Publish(e)
+	}
+	return res, nil
+}
+
+func (c *publishingController) DeleteEnvironment(ctx context.Context, envId string) error {
+	if err := c.Controller.DeleteEnvironment(ctx, envId); err != nil {
+		return err
+	}
+	c.broker.Publish(scopeEvent(envId, "", "", c.broker.now()))
+	return nil
+}
+
+func (c *publishingController) DeleteTeam(ctx context.Context, envId, teamId string) error {
+	if err := c.Controller.DeleteTeam(ctx, envId, teamId); err != nil {
+		return err
+	}
+	c.broker.Publish(scopeEvent(envId, teamId, "", c.broker.now()))
+	return nil
+}
+
+func (c *publishingController) DeleteApplication(ctx context.Context, envId, teamId, appId string) error {
+	if err := c.Controller.DeleteApplication(ctx, envId, teamId, appId); err != nil {
+		return err
+	}
+	c.broker.Publish(scopeEvent(envId, teamId, appId, c.broker.now()))
+	return nil
+}
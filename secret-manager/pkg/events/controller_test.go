@@ -0,0 +1,92 @@
+package events_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/backend"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/controller"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/events"
+)
+
+type fakeController struct {
+	controller.Controller
+}
+
+func (c *fakeController) SetSecret(_ context.Context, rawId, _ string) (controller.SecretResponse, error) {
+	if rawId == "env:team::unknown:" {
+		return controller.SecretResponse{}, backend.ErrSecretNotFound(nil)
+	}
+	return controller.SecretResponse{Id: "env:team::teamToken:v2"}, nil
+}
+
+func (c *fakeController) DeleteSecret(_ context.Context, _ string) error {
+	return nil
+}
+
+func (c *fakeController) UndeleteSecret(_ context.Context, _ string) (controller.SecretResponse, error) {
+	return controller.SecretResponse{Id: "env:team::teamToken:v3"}, nil
+}
+
+func (c *fakeController) DeleteTeam(_ context.Context, _, _ string) error {
+	return nil
+}
+
+var _ = Describe("Controller", func() {
+
+	var ctx context.Context
+	var broker *events.Broker
+	var ctrl controller.Controller
+	var cursor string
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		broker = events.NewBroker(10)
+		ctrl = events.NewController(&fakeController{}, broker)
+		cursor = broker.Watch(ctx, "", "", nil).Cursor
+	})
+
+	It("should publish updated secrets", func() {
+		_, err := ctrl.SetSecret(ctx, "env:team::teamToken:v1", "value")
+		Expect(err).ToNot(HaveOccurred())
+
+		res := broker.Watch(ctx, cursor, "", nil)
+		Expect(res.Events).To(HaveLen(1))
+		Expect(res.Events[0].Type).To(Equal(events.EventTypeUpdated))
+		Expect(res.Events[0].Secret).To(Equal("env:team::teamToken:"))
+		Expect(res.Events[0].Id).To(Equal("env:team::teamToken:v2"))
+	})
+
+	It("should not publish failed updates", func() {
+		_, err := ctrl.SetSecret(ctx, "env:team::unknown:", "value")
+		Expect(err).To(HaveOccurred())
+
+		_, err = ctrl.SetSecret(ctx, "env:team::teamToken:v1", "value")
+		Expect(err).ToNot(HaveOccurred())
+		res := broker.Watch(ctx, cursor, "", nil)
+		Expect(secretsOf(res)).To(Equal([]string{"env:team::teamToken:"}))
+	})
+
+	It("should publish deleted secrets and scopes", func() {
+		Expect(ctrl.DeleteSecret(ctx, "env:team::teamToken:v1")).To(Succeed())
+		Expect(ctrl.DeleteTeam(ctx, "env", "team")).To(Succeed())
+
+		res := broker.Watch(ctx, cursor, "env:team:app:", nil)
+		Expect(secretsOf(res)).To(Equal([]string{"env:team:"}))
+		Expect(res.Events[0].Type).To(Equal(events.EventTypeDeleted))
+
+		res = broker.Watch(ctx, cursor, "env:team:", nil)
+		Expect(secretsOf(res)).To(Equal([]string{"env:team::teamToken:", "env:team:"}))
+	})
+
+	It("should publish restored secrets as updated", func() {
+		_, err := ctrl.UndeleteSecret(ctx, "env:team::teamToken:")
+		Expect(err).ToNot(HaveOccurred())
+
+		res := broker.Watch(ctx, cursor, "", nil)
+		Expect(res.Events).To(HaveLen(1))
+		Expect(res.Events[0].Type).To(Equal(events.EventTypeUpdated))
+		Expect(res.Events[0].Id).To(Equal("env:team::teamToken:v3"))
+	})
+})
@@ -0,0 +1,80 @@
+package events
+
+import (
+	"strings"
+	"time"
+
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/backend"
+)
+
+type EventType string
+
+const (
+	// EventTypeUpdated is published when a secret has been set or rotated
+	EventTypeUpdated EventType = "updated"
+	// EventTypeDeleted is published when a secret or a whole environment, team or application has been deleted
+	EventTypeDeleted EventType = "deleted"
+)
+
+// Event describes the change of a secret. It never contains the value of the secret.
+type Event struct {
+	Type EventType `json:"type"`
+	// Secret is the ID of the secret without checksum.
+	// If an environment, team or application has been deleted, it is the prefix of the IDs of all its secrets.
+	Secret string `json:"secret"`
+	// Id is the ID of the new version of the secret. It is only set for updates.
+	Id   string    `json:"id,omitempty"`
+	Time time.Time `json:"time"`
+
+	seq uint64
+}
+
+// Name returns the name of the secret, e.g. "clientSecret" or "externalSecrets/foo".
+// It is empty if the event affects a whole environment, team or application.
+func (e Event) Name() string {
+	id, err := backend.ParseId(e.Secret)
+	if err != nil {
+		return ""
+	}
+	return id.Path
+}
+
+// Scope returns the environment, team or application if the event is the deletion of a whole scope
+func (e Event) Scope() (backend.Scope, bool) {
+	if e.Name() != "" {
+		return backend.Scope{}, false
+	}
+	parts := strings.Split(strings.TrimSuffix(e.Secret, backend.Separator), backend.Separator)
+	scope := backend.Scope{Env: parts[0]}
+	if len(parts) > 1 {
+		scope.Team = parts[1]
+	}
+	if len(parts) > 2 {
+		scope.App = parts[2]
+	}
+	return scope, scope.Env != ""
+}
+
+// Matches checks if the event affects any secret whose ID starts with the prefix
+func (e Event) Matches(prefix string) bool {
+	if strings.HasPrefix(e.Secret, prefix) {
+		return true
+	}
+	// The deletion of a scope affects all secrets below it
+	return e.Name() == "" && strings.HasPrefix(prefix, e.Secret)
+}
+
+// secretEvent creates an event for the secret. The checksum of the ID is removed.
+func secretEvent(typ EventType, rawId, newId string, now time.Time) (Event, bool) {
+	id, err := backend.ParseId(rawId)
+	if err != nil || id.Path == "" {
+		return Event{}, false
+	}
+	return Event{Type: typ, Secret: id.Ref(), Id: newId, Time: now}, true
+}
+
+// scopeEvent creates a deletion event for an environment, team or application
+func scopeEvent(env, team, app string, now time.Time) Event {
+	scope := backend.Scope{Env: env, Team: team, App: app}
+	return Event{Type: EventTypeDeleted, Secret: scope.Prefix(), Time: now}
+}
@@ -0,0 +1,122 @@
+// Package generation keeps the generators of the secrets that are generated using the API,
+// so rotating them produces the same kind of secret again.
+package generation
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/api"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/backend"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/controller"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/generator"
+)
+
+var _ controller.Controller = &generatingController{}
+
+// generatingController records the generator of each generated secret
+// and uses it when the secret is rotated using the keyword rotate.
+type generatingController struct {
+	controller.Controller
+	store Store
+}
+
+// NewController wraps the controller to keep the generators of the secrets in the store.
+// It must wrap the controller of the backend directly, so the rotation scheduler uses it as well.
+func NewController(c controller.Controller, s Store) controller.Controller {
+	return &generatingController{Controller: c, store: s}
+}
+
+func (c *generatingController) SetSecret(ctx context.Context, rawId, value string) (controller.SecretResponse, error) {
+	id, err := backend.ParseId(rawId)
+	if err != nil {
+		return c.Controller.SetSecret(ctx, rawId, value)
+	}
+	ref := id.Ref()
+
+	if value == api.KeywordRotate {
+		cfg, found, err := c.store.Get(ctx, ref)
+		if err != nil {
+			return controller.SecretResponse{}, err
+		}
+		if found {
+			return c.Controller.GenerateSecret(ctx, rawId, cfg)
+		}
+		if err := c.checkNotGenerated(ctx, ref); err != nil {
+			return controller.SecretResponse{}, err
+		}
+		return c.Controller.SetSecret(ctx, rawId, value)
+	}
+
+	// The secret is no longer generated
+	res, err := c.Controller.SetSecret(ctx, rawId, value)
+	if err != nil {
+		return res, err
+	}
+	controller.LogError(ctx, c.store.Delete(ctx, ref), "Failed to delete generator", "secret", rawId)
+	return res, nil
+}
+
+func (c *generatingController) GenerateSecret(ctx context.Context, rawId string, cfg generator.Config) (controller.SecretResponse, error) {
+	res, err := c.Controller.GenerateSecret(ctx, rawId, cfg)
+	if err != nil {
+		return res, err
+	}
+	if id, err := backend.ParseId(rawId); err == nil {
+		// Without its generator, the secret can not be rotated using the keyword rotate, so the caller must know
+		if err := c.store.Put(ctx, id.Ref(), cfg); err != nil {
+			return res, errors.Wrapf(err, "secret %s has been generated, but its generator could not be recorded", rawId)
+		}
+	}
+	return res, nil
+}
+
+func (c *generatingController) DeleteSecret(ctx context.Context, rawId string) error {
+	if err := c.Controller.DeleteSecret(ctx, rawId); err != nil {
+		return err
+	}
+	if id, err := backend.ParseId(rawId); err == nil {
+		controller.LogError(ctx, c.store.Delete(ctx, id.Ref()), "Failed to delete generator", "secret", rawId)
+	}
+	return nil
+}
+
+func (c *generatingController) DeleteEnvironment(ctx context.Context, envId string) error {
+	if err := c.Controller.DeleteEnvironment(ctx, envId); err != nil {
+		return err
+	}
+	controller.LogError(ctx, c.store.DeletePrefix(ctx, backend.Scope{Env: envId}.Prefix()), "Failed to delete generators", "env", envId)
+	return nil
+}
+
+func (c *generatingController) DeleteTeam(ctx context.Context, envId, teamId string) error {
+	if err := c.Controller.DeleteTeam(ctx, envId, teamId); err != nil {
+		return err
+	}
+	controller.LogError(ctx, c.store.DeletePrefix(ctx, backend.Scope{Env: envId, Team: teamId}.Prefix()), "Failed to delete generators", "env", envId, "team", teamId)
+	return nil
+}
+
+func (c *generatingController) DeleteApplication(ctx context.Context, envId, teamId, appId string) error {
+	if err := c.Controller.DeleteApplication(ctx, envId, teamId, appId); err != nil {
+		return err
+	}
+	controller.LogError(ctx, c.store.DeletePrefix(ctx, backend.Scope{Env: envId, Team: teamId, App: appId}.Prefix()), "Failed to delete generators", "env", envId, "team", teamId, "app", appId)
+	return nil
+}
+
+// checkNotGenerated returns an error if the secret is a key pair or certificate, but its generator is unknown.
+// Such secrets must not be replaced by a UUID, but must be generated again.
+func (c *generatingController) checkNotGenerated(ctx context.Context, ref string) error {
+	current, err := c.Controller.GetSecret(ctx, ref)
+	if err != nil {
+		if backend.IsNotFoundErr(err) {
+			return nil
+		}
+		return err
+	}
+	if _, err := generator.PublicKey(current.Value); err != nil {
+		return nil
+	}
+	return backend.ErrIncorrectState(nil, errors.Errorf("generator of secret %s is unknown, it must be generated again to be rotated", ref))
+}
@@ -0,0 +1,142 @@
+package generation_test
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/api"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/backend"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/controller"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/generation"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/generator"
+)
+
+// fakeController records the generators that the secrets have been set with
+type fakeController struct {
+	controller.Controller
+	generators map[string]string
+	values     map[string]string
+}
+
+func (c *fakeController) GetSecret(_ context.Context, rawId string) (controller.SecretResponse, error) {
+	value, ok := c.values[rawId]
+	if !ok {
+		return controller.SecretResponse{}, backend.ErrSecretNotFound(nil)
+	}
+	return controller.SecretResponse{Id: rawId, Value: value}, nil
+}
+
+func (c *fakeController) SetSecret(_ context.Context, rawId, value string) (controller.SecretResponse, error) {
+	if value == api.KeywordRotate {
+		c.generators[rawId] = generator.TypeUUID
+	} else {
+		c.generators[rawId] = ""
+	}
+	return controller.SecretResponse{Id: rawId}, nil
+}
+
+func (c *fakeController) GenerateSecret(_ context.Context, rawId string, cfg generator.Config) (controller.SecretResponse, error) {
+	c.generators[rawId] = cfg.Type
+	return controller.SecretResponse{Id: rawId}, nil
+}
+
+func (c *fakeController) DeleteSecret(_ context.Context, _ string) error {
+	return nil
+}
+
+func (c *fakeController) DeleteTeam(_ context.Context, _, _ string) error {
+	return nil
+}
+
+// failingStore can not record generators, e.g. as the metadata secret of the scope can not be written
+type failingStore struct {
+	*generation.MemoryStore
+}
+
+func (s *failingStore) Put(_ context.Context, _ string, _ generator.Config) error {
+	return errors.New("metadata secret not found")
+}
+
+var _ = Describe("Controller", func() {
+
+	var ctx context.Context
+	var ctrl *fakeController
+	var store *generation.MemoryStore
+	var ctrlWithGeneration controller.Controller
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		ctrl = &fakeController{generators: map[string]string{}, values: map[string]string{}}
+		store = generation.NewMemoryStore()
+		ctrlWithGeneration = generation.NewController(ctrl, store)
+	})
+
+	It("should return the error if the generator can not be recorded", func() {
+		ctrlWithFailingStore := generation.NewController(ctrl, &failingStore{MemoryStore: store})
+
+		_, err := ctrlWithFailingStore.GenerateSecret(ctx, "env:team::key:", generator.Config{Type: generator.TypeEC})
+		Expect(err).To(MatchError(ContainSubstring("generator could not be recorded: metadata secret not found")))
+	})
+
+	It("should rotate a generated secret using its generator", func() {
+		_, err := ctrlWithGeneration.GenerateSecret(ctx, "env:team::key:v1", generator.Config{Type: generator.TypeRSA, Bits: 4096})
+		Expect(err).ToNot(HaveOccurred())
+
+		cfg, ok, err := store.Get(ctx, "env:team::key:")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ok).To(BeTrue())
+		Expect(cfg).To(Equal(generator.Config{Type: generator.TypeRSA, Bits: 4096}))
+
+		_, err = ctrlWithGeneration.SetSecret(ctx, "env:team::key:v2", api.KeywordRotate)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ctrl.generators["env:team::key:v2"]).To(Equal(generator.TypeRSA))
+	})
+
+	It("should rotate other secrets as before", func() {
+		_, err := ctrlWithGeneration.SetSecret(ctx, "env:team::teamToken:v1", api.KeywordRotate)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ctrl.generators["env:team::teamToken:v1"]).To(Equal(generator.TypeUUID))
+	})
+
+	It("should not rotate a key pair whose generator is unknown", func() {
+		key, err := generator.New(generator.Config{Type: generator.TypeEC})
+		Expect(err).ToNot(HaveOccurred())
+		ctrl.values["env:team::key:"], err = key.Generate()
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = ctrlWithGeneration.SetSecret(ctx, "env:team::key:v1", api.KeywordRotate)
+		Expect(backend.IsIncorrectStateErr(err)).To(BeTrue())
+		Expect(ctrl.generators).To(BeEmpty())
+	})
+
+	It("should forget the generator if a value is set", func() {
+		_, err := ctrlWithGeneration.GenerateSecret(ctx, "env:team::password:", generator.Config{Type: generator.TypePassword})
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = ctrlWithGeneration.SetSecret(ctx, "env:team::password:v1", "my-password")
+		Expect(err).ToNot(HaveOccurred())
+		_, ok, err := store.Get(ctx, "env:team::password:")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ok).To(BeFalse())
+	})
+
+	It("should forget the generators of deleted secrets", func() {
+		_, err := ctrlWithGeneration.GenerateSecret(ctx, "env:team::password:", generator.Config{Type: generator.TypePassword})
+		Expect(err).ToNot(HaveOccurred())
+		_, err = ctrlWithGeneration.GenerateSecret(ctx, "env:team:app:key:", generator.Config{Type: generator.TypeEC})
+		Expect(err).ToNot(HaveOccurred())
+		_, err = ctrlWithGeneration.GenerateSecret(ctx, "env:other::key:", generator.Config{Type: generator.TypeEC})
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(ctrlWithGeneration.DeleteSecret(ctx, "env:other::key:v1")).To(Succeed())
+		Expect(ctrlWithGeneration.DeleteTeam(ctx, "env", "team")).To(Succeed())
+
+		for _, ref := range []string{"env:team::password:", "env:team:app:key:", "env:other::key:"} {
+			_, ok, err := store.Get(ctx, ref)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ok).To(BeFalse(), ref)
+		}
+	})
+})
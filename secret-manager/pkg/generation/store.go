@@ -0,0 +1,99 @@
+package generation
+
+import (
+	"context"
+	"maps"
+	"strings"
+	"sync"
+
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/generator"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/metadata"
+)
+
+// Store persists the generators of the generated secrets by the IDs of the secrets without checksum
+type Store interface {
+	Get(ctx context.Context, ref string) (generator.Config, bool, error)
+	Put(ctx context.Context, ref string, cfg generator.Config) error
+	Delete(ctx context.Context, ref string) error
+	// DeletePrefix deletes the generators of all secrets whose IDs start with the prefix
+	DeletePrefix(ctx context.Context, prefix string) error
+}
+
+var _ Store = &MemoryStore{}
+
+// MemoryStore keeps the generators in memory. They are lost on restart.
+type MemoryStore struct {
+	mux     sync.RWMutex
+	configs map[string]generator.Config
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{configs: map[string]generator.Config{}}
+}
+
+func (s *MemoryStore) Get(_ context.Context, ref string) (generator.Config, bool, error) {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+	cfg, ok := s.configs[ref]
+	return cfg, ok, nil
+}
+
+func (s *MemoryStore) Put(_ context.Context, ref string, cfg generator.Config) error {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	s.configs[ref] = cfg
+	return nil
+}
+
+func (s *MemoryStore) Delete(_ context.Context, ref string) error {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	delete(s.configs, ref)
+	return nil
+}
+
+func (s *MemoryStore) DeletePrefix(_ context.Context, prefix string) error {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	maps.DeleteFunc(s.configs, func(ref string, _ generator.Config) bool {
+		return strings.HasPrefix(ref, prefix)
+	})
+	return nil
+}
+
+var _ Store = &MetadataStore{}
+
+// MetadataStore keeps the generators in the metadata of the secrets in the backend,
+// so they survive restarts and are shared by all replicas.
+type MetadataStore struct {
+	metadata *metadata.Store
+}
+
+func NewMetadataStore(m *metadata.Store) *MetadataStore {
+	return &MetadataStore{metadata: m}
+}
+
+func (s *MetadataStore) Get(ctx context.Context, ref string) (generator.Config, bool, error) {
+	m, _, err := s.metadata.Get(ctx, ref)
+	if err != nil || m.Generator == nil {
+		return generator.Config{}, false, err
+	}
+	return *m.Generator, true, nil
+}
+
+func (s *MetadataStore) Put(ctx context.Context, ref string, cfg generator.Config) error {
+	return s.metadata.Update(ctx, ref, func(m *metadata.Metadata) {
+		m.Generator = &cfg
+	})
+}
+
+func (s *MetadataStore) Delete(ctx context.Context, ref string) error {
+	return s.metadata.Update(ctx, ref, func(m *metadata.Metadata) {
+		m.Generator = nil
+	})
+}
+
+// DeletePrefix does nothing, as the metadata is deleted together with the secrets of the environment, team or application
+func (s *MetadataStore) DeletePrefix(_ context.Context, _ string) error {
+	return nil
+}
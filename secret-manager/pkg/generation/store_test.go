@@ -0,0 +1,58 @@
+package generation_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/backend"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/controller"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/generation"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/generator"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/metadata"
+)
+
+// backendController stores the values of the secrets by their ID, like a backend
+type backendController struct {
+	controller.Controller
+	values map[string]string
+}
+
+func (c *backendController) GetSecret(_ context.Context, rawId string) (controller.SecretResponse, error) {
+	value, ok := c.values[rawId]
+	if !ok {
+		return controller.SecretResponse{}, backend.ErrSecretNotFound(nil)
+	}
+	return controller.SecretResponse{Id: rawId, Value: value}, nil
+}
+
+func (c *backendController) SetSecret(_ context.Context, rawId, value string) (controller.SecretResponse, error) {
+	c.values[rawId] = value
+	return controller.SecretResponse{Id: rawId}, nil
+}
+
+var _ = Describe("Metadata Store", func() {
+
+	It("should store the generators in the backend", func() {
+		ctx := context.Background()
+		ctrl := &backendController{values: map[string]string{}}
+		password := generator.Config{Type: generator.TypePassword, Length: 24, MinSymbols: 2}
+
+		store := generation.NewMetadataStore(metadata.NewStore(ctrl))
+		Expect(store.Put(ctx, "env:team::password:", password)).To(Succeed())
+		Expect(store.Put(ctx, "env:other::key:", generator.Config{Type: generator.TypeRSA})).To(Succeed())
+		Expect(store.Delete(ctx, "env:other::key:")).To(Succeed())
+		Expect(ctrl.values).To(HaveKey("env:team::.metadata:"))
+
+		// A new store, e.g. of another replica, reads the same generators
+		store = generation.NewMetadataStore(metadata.NewStore(ctrl))
+		cfg, ok, err := store.Get(ctx, "env:team::password:")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ok).To(BeTrue())
+		Expect(cfg).To(Equal(password))
+
+		_, ok, err = store.Get(ctx, "env:other::key:")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ok).To(BeFalse())
+	})
+})
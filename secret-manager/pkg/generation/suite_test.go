@@ -0,0 +1,13 @@
+package generation_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestGeneration(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Generation Suite")
+}
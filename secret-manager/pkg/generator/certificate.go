@@ -0,0 +1,235 @@
+package generator
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// IssuerSelfSigned signs the certificate with its own key
+	IssuerSelfSigned = "self-signed"
+	// IssuerCA signs the certificate with the configured CA, see NewCertificateFactory
+	IssuerCA = "ca"
+
+	KeyTypeRSA = TypeRSA
+	KeyTypeEC  = TypeEC
+
+	// DefaultValidityDays is the default validity of certificates
+	DefaultValidityDays = 90
+)
+
+// CA is a local certificate authority that signs the generated certificates
+type CA struct {
+	cert        *x509.Certificate
+	certPEM     []byte
+	key         crypto.Signer
+	constraints Constraints
+}
+
+// WithConstraints restricts the certificates that the CA signs. Without allowed names, the CA does not sign any certificate.
+func (c *CA) WithConstraints(constraints Constraints) *CA {
+	c.constraints = constraints
+	return c
+}
+
+// LoadCA reads the PEM-encoded certificate and private key of the CA from the files
+func LoadCA(certFile, keyFile string) (*CA, error) {
+	certPEM, err := os.ReadFile(certFile) //nolint:gosec
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read CA certificate")
+	}
+	keyPEM, err := os.ReadFile(keyFile) //nolint:gosec
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read CA key")
+	}
+	return NewCA(certPEM, keyPEM)
+}
+
+// NewCA creates the CA from its PEM-encoded certificate and private key
+func NewCA(certPEM, keyPEM []byte) (*CA, error) {
+	block, _ := pem.Decode(certPEM)
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, errors.New("CA certificate must be PEM-encoded")
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse CA certificate")
+	}
+	if !cert.IsCA {
+		return nil, errors.Errorf("certificate %s is no CA", cert.Subject)
+	}
+
+	block, _ = pem.Decode(keyPEM)
+	if block == nil {
+		return nil, errors.New("CA key must be PEM-encoded")
+	}
+	key, err := parsePrivateKey(block)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse CA key")
+	}
+	if !key.Public().(interface{ Equal(crypto.PublicKey) bool }).Equal(cert.PublicKey) {
+		return nil, errors.New("CA key does not match the CA certificate")
+	}
+	return &CA{cert: cert, certPEM: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw}), key: key}, nil
+}
+
+func parsePrivateKey(block *pem.Block) (crypto.Signer, error) {
+	var key any
+	var err error
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	default:
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	}
+	if err != nil {
+		return nil, err
+	}
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, errors.Errorf("unsupported key %T", key)
+	}
+	return signer, nil
+}
+
+// Certificate generates a new private key and an X.509 certificate for it.
+// The value contains the PEM-encoded certificate, the certificate of the CA if it is signed by the CA,
+// and the PEM-encoded PKCS #8 private key. The certificates can be read using PublicKey.
+type Certificate struct {
+	generate func() (crypto.Signer, error)
+	template x509.Certificate
+	validity time.Duration
+	// ca is nil for self-signed certificates
+	ca *CA
+	// namesChecked is false if the names have not been checked against the constraints of the CA,
+	// e.g. if the config is only validated. Such certificates are never signed.
+	namesChecked bool
+}
+
+// NewCertificateFactory creates the factory of certificate generators.
+// Certificates can only be signed by the CA if it is set.
+// Use Register to replace the default factory that only supports self-signed certificates:
+//
+//	generator.Register(generator.TypeCertificate, generator.NewCertificateFactory(ca))
+func NewCertificateFactory(ca *CA) Factory {
+	return func(cfg Config) (Generator, error) {
+		return newCertificateGenerator(cfg, ca)
+	}
+}
+
+func newCertificateGenerator(cfg Config, ca *CA) (Generator, error) {
+	g := &Certificate{}
+	var err error
+	switch cfg.KeyType {
+	case "", KeyTypeEC:
+		g.generate, err = ecKey(cfg.Curve)
+	case KeyTypeRSA:
+		g.generate, err = rsaKey(cfg.Bits)
+	default:
+		err = errors.Errorf("unknown key type %q", cfg.KeyType)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	switch cfg.Issuer {
+	case "", IssuerSelfSigned:
+	case IssuerCA:
+		if ca == nil {
+			return nil, errors.New("no CA is configured to sign certificates")
+		}
+		g.ca = ca
+	default:
+		return nil, errors.Errorf("unknown issuer %q", cfg.Issuer)
+	}
+
+	if cfg.CommonName == "" && len(cfg.DNSNames) == 0 {
+		return nil, errors.New("common_name or dns_names must be set")
+	}
+	if cfg.ValidityDays < 0 {
+		return nil, errors.Errorf("invalid validity %d", cfg.ValidityDays)
+	}
+	if cfg.ValidityDays == 0 {
+		cfg.ValidityDays = DefaultValidityDays
+	}
+	if g.ca != nil {
+		maxValidityDays := g.ca.constraints.maxValidityDays()
+		if cfg.ValidityDays > maxValidityDays {
+			return nil, errors.Errorf("validity %d exceeds the maximum of %d days of the CA", cfg.ValidityDays, maxValidityDays)
+		}
+		if cfg.Scope != nil {
+			names := cfg.DNSNames
+			if cfg.CommonName != "" {
+				names = append([]string{cfg.CommonName}, names...)
+			}
+			if err := g.ca.constraints.checkNames(*cfg.Scope, names...); err != nil {
+				return nil, err
+			}
+			g.namesChecked = true
+		}
+	}
+	g.validity = time.Duration(cfg.ValidityDays) * 24 * time.Hour
+	g.template = x509.Certificate{
+		Subject:     pkix.Name{CommonName: cfg.CommonName},
+		DNSNames:    cfg.DNSNames,
+		KeyUsage:    x509.KeyUsageDigitalSignature,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	return g, nil
+}
+
+func (g *Certificate) Generate() (string, error) {
+	if g.ca != nil && !g.namesChecked {
+		return "", errors.New("certificates can only be signed by the CA for the scope of a secret")
+	}
+	key, err := g.generate()
+	if err != nil {
+		return "", errors.Wrap(err, "failed to generate key")
+	}
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return "", errors.Wrap(err, "failed to generate serial number")
+	}
+
+	template := g.template
+	template.SerialNumber = serial
+	// Tolerate clocks that are slightly behind
+	template.NotBefore = time.Now().Add(-5 * time.Minute)
+	template.NotAfter = template.NotBefore.Add(g.validity)
+	if _, ok := key.(*rsa.PrivateKey); ok {
+		template.KeyUsage |= x509.KeyUsageKeyEncipherment
+	}
+
+	parent, signer := &template, key
+	if g.ca != nil {
+		parent, signer = g.ca.cert, g.ca.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &template, parent, key.Public(), signer)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to create certificate")
+	}
+	keyBlock, err := encodePrivateKey(key)
+	if err != nil {
+		return "", err
+	}
+
+	value := &bytes.Buffer{}
+	_ = pem.Encode(value, &pem.Block{Type: "CERTIFICATE", Bytes: der})
+	if g.ca != nil {
+		value.Write(g.ca.certPEM)
+	}
+	_ = pem.Encode(value, keyBlock)
+	return value.String(), nil
+}
@@ -0,0 +1,111 @@
+package generator
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// DefaultMaxValidityDays is the default maximum validity of certificates that are signed by the CA
+const DefaultMaxValidityDays = 397
+
+var nameVariableRegex = regexp.MustCompile(`\$\{([^}]+)\}`)
+
+// Scope is the environment, team and application of the secret that is generated.
+// It is used to check the names of the certificates that are signed by the CA.
+type Scope struct {
+	Env  string
+	Team string
+	App  string
+}
+
+// Constraints restrict the certificates that the CA signs, as every caller that may generate secrets can request them
+type Constraints struct {
+	// AllowedNames are patterns of the common names and DNS names, e.g. `${app}.${team}.${env}.example.com`.
+	// `*` matches a single DNS label. The variables ${env}, ${team} and ${app} are replaced by the scope of the secret.
+	// If empty, the CA does not sign any certificate.
+	AllowedNames []string `yaml:"allowed_names" json:"allowed_names"`
+	// MaxValidityDays is the maximum validity of the certificates. It defaults to DefaultMaxValidityDays.
+	MaxValidityDays int `yaml:"max_validity_days" json:"max_validity_days"`
+}
+
+// Validate checks the patterns of the allowed names
+func (c Constraints) Validate() error {
+	if c.MaxValidityDays < 0 {
+		return errors.Errorf("invalid maximum validity %d", c.MaxValidityDays)
+	}
+	for _, pattern := range c.AllowedNames {
+		for _, match := range nameVariableRegex.FindAllStringSubmatch(pattern, -1) {
+			if match[1] != "env" && match[1] != "team" && match[1] != "app" {
+				return errors.Errorf("unknown variable %s in allowed name %q", match[0], pattern)
+			}
+		}
+	}
+	return nil
+}
+
+func (c Constraints) maxValidityDays() int {
+	if c.MaxValidityDays == 0 {
+		return DefaultMaxValidityDays
+	}
+	return c.MaxValidityDays
+}
+
+// checkNames returns an error if one of the names is not allowed for the scope
+func (c Constraints) checkNames(scope Scope, names ...string) error {
+	for _, name := range names {
+		if !c.isAllowed(scope, name) {
+			return errors.Errorf("name %q is not allowed to be signed by the CA for %s:%s:%s", name, scope.Env, scope.Team, scope.App)
+		}
+	}
+	return nil
+}
+
+func (c Constraints) isAllowed(scope Scope, name string) bool {
+	for _, pattern := range c.AllowedNames {
+		regex, ok := compileNamePattern(pattern, scope)
+		if ok && regex.MatchString(strings.ToLower(name)) {
+			return true
+		}
+	}
+	return false
+}
+
+// compileNamePattern replaces the variables of the pattern by the scope.
+// It returns false if a variable is empty, e.g. ${app} for the secrets of a team.
+func compileNamePattern(pattern string, scope Scope) (*regexp.Regexp, bool) {
+	values := map[string]string{"env": scope.Env, "team": scope.Team, "app": scope.App}
+	ok := true
+	var b strings.Builder
+	b.WriteString("^")
+	for _, part := range splitVariables(pattern) {
+		if match := nameVariableRegex.FindStringSubmatch(part); match != nil {
+			value := values[match[1]]
+			if value == "" {
+				ok = false
+			}
+			b.WriteString(regexp.QuoteMeta(strings.ToLower(value)))
+			continue
+		}
+		for i, literal := range strings.Split(strings.ToLower(part), "*") {
+			if i > 0 {
+				b.WriteString("[^.]+")
+			}
+			b.WriteString(regexp.QuoteMeta(literal))
+		}
+	}
+	b.WriteString("$")
+	return regexp.MustCompile(b.String()), ok
+}
+
+// splitVariables splits the pattern into the literal parts and the variables
+func splitVariables(pattern string) []string {
+	var parts []string
+	last := 0
+	for _, loc := range nameVariableRegex.FindAllStringIndex(pattern, -1) {
+		parts = append(parts, pattern[last:loc[0]], pattern[loc[0]:loc[1]])
+		last = loc[1]
+	}
+	return append(parts, pattern[last:])
+}
@@ -0,0 +1,119 @@
+// Package generator provides generators for the random values of secrets.
+package generator
+
+import (
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	TypeUUID         = "uuid"
+	TypeAlphanumeric = "alphanumeric"
+	TypePassword     = "password"
+	TypeRSA          = "rsa"
+	TypeEC           = "ec"
+	TypeCertificate  = "certificate"
+)
+
+const (
+	// MaxLength is the maximum length of alphanumeric values and passwords
+	MaxLength = 4096
+	// MaxRSABits is the maximum size of RSA keys, as larger keys take minutes to generate
+	MaxRSABits = 8192
+)
+
+// Generator generates a new random value for a secret.
+type Generator interface {
+	Generate() (string, error)
+}
+
+// GeneratorFunc is an adapter to use a function as Generator
+type GeneratorFunc func() (string, error)
+
+func (f GeneratorFunc) Generate() (string, error) {
+	return f()
+}
+
+// Config configures a generator.
+// The fields that are used depend on its type.
+type Config struct {
+	Type string `yaml:"type" json:"type"`
+	// Length of the alphanumeric value or password
+	Length int `yaml:"length,omitempty" json:"length,omitempty"`
+
+	// MinLower, MinUpper, MinDigits and MinSymbols are the minimum number of characters of each class of a password
+	MinLower   int `yaml:"min_lower,omitempty" json:"min_lower,omitempty"`
+	MinUpper   int `yaml:"min_upper,omitempty" json:"min_upper,omitempty"`
+	MinDigits  int `yaml:"min_digits,omitempty" json:"min_digits,omitempty"`
+	MinSymbols int `yaml:"min_symbols,omitempty" json:"min_symbols,omitempty"`
+	// Symbols that are used in a password. It defaults to DefaultSymbols.
+	Symbols string `yaml:"symbols,omitempty" json:"symbols,omitempty"`
+	// NoSymbols restricts a password to letters and digits
+	NoSymbols bool `yaml:"no_symbols,omitempty" json:"no_symbols,omitempty"`
+
+	// Bits of the RSA key
+	Bits int `yaml:"bits,omitempty" json:"bits,omitempty"`
+	// Curve of the EC key, e.g. P-256
+	Curve string `yaml:"curve,omitempty" json:"curve,omitempty"`
+
+	// KeyType of the certificate, rsa or ec. It defaults to ec.
+	KeyType string `yaml:"key_type,omitempty" json:"key_type,omitempty"`
+	// CommonName of the certificate
+	CommonName string `yaml:"common_name,omitempty" json:"common_name,omitempty"`
+	// DNSNames are the subject alternative names of the certificate
+	DNSNames []string `yaml:"dns_names,omitempty" json:"dns_names,omitempty"`
+	// ValidityDays of the certificate. It defaults to DefaultValidityDays.
+	ValidityDays int `yaml:"validity_days,omitempty" json:"validity_days,omitempty"`
+	// Issuer of the certificate, self-signed or ca. It defaults to self-signed.
+	Issuer string `yaml:"issuer,omitempty" json:"issuer,omitempty"`
+
+	// Scope of the secret that is generated. It is set for each secret and never stored with the config.
+	// Certificates can only be signed by the CA if it is set, see Constraints.
+	Scope *Scope `yaml:"-" json:"-"`
+}
+
+// Factory creates a generator from its config.
+type Factory func(cfg Config) (Generator, error)
+
+var (
+	mux       sync.RWMutex
+	factories = map[string]Factory{
+		TypeUUID:         newUUIDGenerator,
+		TypeAlphanumeric: newAlphanumericGenerator,
+		TypePassword:     newPasswordGenerator,
+		TypeRSA:          newRSAGenerator,
+		TypeEC:           newECGenerator,
+		TypeCertificate:  NewCertificateFactory(nil),
+	}
+)
+
+// Register registers a factory for a new type of generator.
+// An existing type is replaced.
+func Register(typ string, factory Factory) {
+	mux.Lock()
+	defer mux.Unlock()
+	factories[typ] = factory
+}
+
+// New creates the generator for the config.
+// If no type is set, a UUID generator is returned.
+func New(cfg Config) (Generator, error) {
+	if cfg.Type == "" {
+		cfg.Type = TypeUUID
+	}
+	mux.RLock()
+	factory, ok := factories[cfg.Type]
+	mux.RUnlock()
+	if !ok {
+		return nil, errors.Errorf("unknown generator type %q", cfg.Type)
+	}
+	// The limits apply to all factories, as the configs are passed by the callers of the API
+	if cfg.Length > MaxLength {
+		return nil, errors.Errorf("length %d exceeds the maximum of %d", cfg.Length, MaxLength)
+	}
+	if cfg.Bits > MaxRSABits {
+		return nil, errors.Errorf("bits %d exceed the maximum of %d", cfg.Bits, MaxRSABits)
+	}
+	return factory(cfg)
+}
@@ -0,0 +1,91 @@
+package generator
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	DefaultRSABits = 2048
+	DefaultCurve   = "P-256"
+)
+
+var curves = map[string]elliptic.Curve{
+	"P-256": elliptic.P256(),
+	"P-384": elliptic.P384(),
+	"P-521": elliptic.P521(),
+}
+
+// KeyPair generates a new private key.
+// The value is the PEM-encoded PKCS #8 private key, the public key can be derived from it, see PublicKey.
+type KeyPair struct {
+	generate func() (crypto.Signer, error)
+}
+
+func newRSAGenerator(cfg Config) (Generator, error) {
+	generate, err := rsaKey(cfg.Bits)
+	if err != nil {
+		return nil, err
+	}
+	return &KeyPair{generate: generate}, nil
+}
+
+func newECGenerator(cfg Config) (Generator, error) {
+	generate, err := ecKey(cfg.Curve)
+	if err != nil {
+		return nil, err
+	}
+	return &KeyPair{generate: generate}, nil
+}
+
+func rsaKey(bits int) (func() (crypto.Signer, error), error) {
+	if bits == 0 {
+		bits = DefaultRSABits
+	}
+	if bits < 2048 {
+		return nil, errors.Errorf("invalid size of RSA key %d, must be at least 2048 bits", bits)
+	}
+	return func() (crypto.Signer, error) {
+		return rsa.GenerateKey(rand.Reader, bits)
+	}, nil
+}
+
+func ecKey(name string) (func() (crypto.Signer, error), error) {
+	if name == "" {
+		name = DefaultCurve
+	}
+	curve, ok := curves[name]
+	if !ok {
+		return nil, errors.Errorf("unknown curve %q", name)
+	}
+	return func() (crypto.Signer, error) {
+		return ecdsa.GenerateKey(curve, rand.Reader)
+	}, nil
+}
+
+func (g *KeyPair) Generate() (string, error) {
+	key, err := g.generate()
+	if err != nil {
+		return "", errors.Wrap(err, "failed to generate key")
+	}
+	block, err := encodePrivateKey(key)
+	if err != nil {
+		return "", err
+	}
+	return string(pem.EncodeToMemory(block)), nil
+}
+
+func encodePrivateKey(key crypto.Signer) (*pem.Block, error) {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to encode key")
+	}
+	return &pem.Block{Type: "PRIVATE KEY", Bytes: der}, nil
+}
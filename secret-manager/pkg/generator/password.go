@@ -0,0 +1,121 @@
+package generator
+
+import (
+	"crypto/rand"
+	"math/big"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// DefaultSymbols are the symbols of passwords. They do not need to be quoted in shells, URLs or JSON.
+	DefaultSymbols = "!#%*+-.=?@^_~"
+
+	lowerChars = "abcdefghijklmnopqrstuvwxyz"
+	upperChars = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	digitChars = "0123456789"
+)
+
+// Password generates a random value of letters, digits and symbols
+// that contains at least the minimum number of characters of each class.
+type Password struct {
+	Length int
+	// Min is the minimum number of characters by class
+	Min map[string]int
+	// Chars are all characters that are used
+	Chars string
+}
+
+func newPasswordGenerator(cfg Config) (Generator, error) {
+	if cfg.Length < 0 {
+		return nil, errors.Errorf("invalid length %d", cfg.Length)
+	}
+	if cfg.Length == 0 {
+		cfg.Length = DefaultLength
+	}
+	symbols := cfg.Symbols
+	if symbols == "" {
+		symbols = DefaultSymbols
+	}
+	if cfg.NoSymbols {
+		if cfg.MinSymbols > 0 {
+			return nil, errors.New("min_symbols cannot be set if no_symbols is set")
+		}
+		symbols = ""
+	}
+	if strings.ContainsAny(symbols, lowerChars+upperChars+digitChars) {
+		return nil, errors.Errorf("symbols %q must not contain letters or digits", symbols)
+	}
+
+	g := &Password{
+		Length: cfg.Length,
+		Min: map[string]int{
+			lowerChars: cfg.MinLower,
+			upperChars: cfg.MinUpper,
+			digitChars: cfg.MinDigits,
+		},
+		Chars: lowerChars + upperChars + digitChars + symbols,
+	}
+	if symbols != "" {
+		g.Min[symbols] = cfg.MinSymbols
+	}
+	total := 0
+	for _, n := range g.Min {
+		if n < 0 {
+			return nil, errors.Errorf("invalid minimum number of characters %d", n)
+		}
+		total += n
+	}
+	if total > cfg.Length {
+		return nil, errors.Errorf("length %d is less than the sum of the minimum number of characters %d", cfg.Length, total)
+	}
+	return g, nil
+}
+
+func (g *Password) Generate() (string, error) {
+	value := make([]byte, 0, g.Length)
+	for chars, n := range g.Min {
+		for range n {
+			c, err := randomChar(chars)
+			if err != nil {
+				return "", err
+			}
+			value = append(value, c)
+		}
+	}
+	for len(value) < g.Length {
+		c, err := randomChar(g.Chars)
+		if err != nil {
+			return "", err
+		}
+		value = append(value, c)
+	}
+
+	// The required characters must not always be at the start
+	for i := len(value) - 1; i > 0; i-- {
+		j, err := randomInt(i + 1)
+		if err != nil {
+			return "", err
+		}
+		value[i], value[j] = value[j], value[i]
+	}
+	return string(value), nil
+}
+
+func randomChar(chars string) (byte, error) {
+	i, err := randomInt(len(chars))
+	if err != nil {
+		return 0, err
+	}
+	return chars[i], nil
+}
+
+// randomInt returns a uniform random number in [0, n)
+func randomInt(n int) (int, error) {
+	i, err := rand.Int(rand.Reader, big.NewInt(int64(n)))
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to generate random value")
+	}
+	return int(i.Int64()), nil
+}
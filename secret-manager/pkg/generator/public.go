@@ -0,0 +1,51 @@
+package generator
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/pem"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// ErrNoPublicKey is returned by PublicKey if the value contains neither certificates nor a private key
+var ErrNoPublicKey = errors.New("value contains no certificate or private key")
+
+// PublicKey returns the part of a generated value that can be shared.
+// For certificates, these are the PEM-encoded certificates of the value.
+// For key pairs, it is the PEM-encoded PKIX public key of the private key.
+func PublicKey(value string) (string, error) {
+	certs := &bytes.Buffer{}
+	var keyBlock *pem.Block
+	rest := []byte(value)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		switch {
+		case block.Type == "CERTIFICATE":
+			_ = pem.Encode(certs, block)
+		case keyBlock == nil && strings.HasSuffix(block.Type, "PRIVATE KEY"):
+			keyBlock = block
+		}
+	}
+	if certs.Len() > 0 {
+		return certs.String(), nil
+	}
+	if keyBlock == nil {
+		return "", ErrNoPublicKey
+	}
+
+	key, err := parsePrivateKey(keyBlock)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to parse private key")
+	}
+	der, err := x509.MarshalPKIXPublicKey(key.Public())
+	if err != nil {
+		return "", errors.Wrap(err, "failed to encode public key")
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})), nil
+}
@@ -0,0 +1,49 @@
+package generator
+
+import (
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+)
+
+const (
+	// DefaultLength is the default length of alphanumeric values
+	DefaultLength = 32
+
+	alphanumeric = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+)
+
+// UUID generates a random UUID. It is used by default to rotate secrets.
+var UUID Generator = GeneratorFunc(func() (string, error) {
+	return uuid.NewString(), nil
+})
+
+func newUUIDGenerator(_ Config) (Generator, error) {
+	return UUID, nil
+}
+
+// Alphanumeric generates a random value of the given length that only contains letters and digits
+type Alphanumeric struct {
+	Length int
+}
+
+func newAlphanumericGenerator(cfg Config) (Generator, error) {
+	if cfg.Length < 0 {
+		return nil, errors.Errorf("invalid length %d", cfg.Length)
+	}
+	if cfg.Length == 0 {
+		cfg.Length = DefaultLength
+	}
+	return &Alphanumeric{Length: cfg.Length}, nil
+}
+
+func (g *Alphanumeric) Generate() (string, error) {
+	value := make([]byte, g.Length)
+	for i := range value {
+		c, err := randomChar(alphanumeric)
+		if err != nil {
+			return "", err
+		}
+		value[i] = c
+	}
+	return string(value), nil
+}
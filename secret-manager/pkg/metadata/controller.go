@@ -0,0 +1,116 @@
+package metadata
+
+import (
+	"context"
+	"maps"
+
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/backend"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/controller"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/generator"
+)
+
+var _ controller.Controller = &hidingController{}
+
+// hidingController hides the metadata secrets, so they can only be changed using the Store
+type hidingController struct {
+	controller.Controller
+}
+
+// NewController wraps the controller of the backend to hide the metadata secrets.
+// The Store must use the controller c.
+func NewController(c controller.Controller) controller.Controller {
+	return &hidingController{Controller: c}
+}
+
+func (c *hidingController) ListSecrets(ctx context.Context, req controller.ListSecretsRequest) (controller.ListSecretsResponse, error) {
+	filter := req.Filter
+	req.Filter = func(name string) bool {
+		return !IsReserved(name) && (filter == nil || filter(name))
+	}
+	return c.Controller.ListSecrets(ctx, req)
+}
+
+func (c *hidingController) ListSecretVersions(ctx context.Context, rawId string) ([]controller.SecretVersionResponse, error) {
+	if err := checkNotReserved(rawId); err != nil {
+		return nil, err
+	}
+	return c.Controller.ListSecretVersions(ctx, rawId)
+}
+
+func (c *hidingController) GetSecret(ctx context.Context, rawId string) (controller.SecretResponse, error) {
+	if err := checkNotReserved(rawId); err != nil {
+		return controller.SecretResponse{}, err
+	}
+	return c.Controller.GetSecret(ctx, rawId)
+}
+
+func (c *hidingController) GetPublicKey(ctx context.Context, rawId string) (controller.PublicKeyResponse, error) {
+	if err := checkNotReserved(rawId); err != nil {
+		return controller.PublicKeyResponse{}, err
+	}
+	return c.Controller.GetPublicKey(ctx, rawId)
+}
+
+func (c *hidingController) SetSecret(ctx context.Context, rawId, value string) (controller.SecretResponse, error) {
+	if err := checkNotReserved(rawId); err != nil {
+		return controller.SecretResponse{}, err
+	}
+	return c.Controller.SetSecret(ctx, rawId, value)
+}
+
+func (c *hidingController) GenerateSecret(ctx context.Context, rawId string, cfg generator.Config) (controller.SecretResponse, error) {
+	if err := checkNotReserved(rawId); err != nil {
+		return controller.SecretResponse{}, err
+	}
+	return c.Controller.GenerateSecret(ctx, rawId, cfg)
+}
+
+func (c *hidingController) DeleteSecret(ctx context.Context, rawId string) error {
+	if err := checkNotReserved(rawId); err != nil {
+		return err
+	}
+	return c.Controller.DeleteSecret(ctx, rawId)
+}
+
+func (c *hidingController) UndeleteSecret(ctx context.Context, rawId string) (controller.SecretResponse, error) {
+	if err := checkNotReserved(rawId); err != nil {
+		return controller.SecretResponse{}, err
+	}
+	return c.Controller.UndeleteSecret(ctx, rawId)
+}
+
+func (c *hidingController) OnboardEnvironment(ctx context.Context, envId string) (controller.OnboardResponse, error) {
+	res, err := c.Controller.OnboardEnvironment(ctx, envId)
+	return withoutMetadata(res), err
+}
+
+func (c *hidingController) OnboardTeam(ctx context.Context, envId, teamId string) (controller.OnboardResponse, error) {
+	res, err := c.Controller.OnboardTeam(ctx, envId, teamId)
+	return withoutMetadata(res), err
+}
+
+func (c *hidingController) OnboardApplication(ctx context.Context, envId, teamId, appId string) (controller.OnboardResponse, error) {
+	res, err := c.Controller.OnboardApplication(ctx, envId, teamId, appId)
+	return withoutMetadata(res), err
+}
+
+func checkNotReserved(rawId string) error {
+	id, err := backend.ParseId(rawId)
+	if err != nil {
+		// The ID is validated by the backend
+		return nil
+	}
+	if IsReserved(id.Path) {
+		return backend.ErrInvalidSecretId(rawId)
+	}
+	return nil
+}
+
+func withoutMetadata(res controller.OnboardResponse) controller.OnboardResponse {
+	if _, ok := res.SecretRefs[SecretName]; !ok {
+		return res
+	}
+	res.SecretRefs = maps.Clone(res.SecretRefs)
+	delete(res.SecretRefs, SecretName)
+	return res
+}
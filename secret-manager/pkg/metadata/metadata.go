@@ -0,0 +1,57 @@
+// Package metadata keeps data about the secrets next to them in the backend,
+// e.g. the generator of a generated secret, so it survives restarts and is shared by all replicas.
+// The metadata of all secrets of an environment, team or application is stored as JSON in its reserved secret SecretName.
+package metadata
+
+import (
+	"slices"
+	"time"
+
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/backend"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/generator"
+)
+
+// SecretName is the name of the reserved secret that contains the metadata of the other secrets of its scope
+const SecretName = ".metadata"
+
+// Metadata of a secret. Empty metadata is removed.
+type Metadata struct {
+	// Generator is the generator that the secret has been generated with
+	Generator *generator.Config `json:"generator,omitempty"`
+	// Rotation is the state of the rotation policy that applies to the secret
+	Rotation *Rotation `json:"rotation,omitempty"`
+}
+
+type Rotation struct {
+	Policy string `json:"policy"`
+	// LastRotation is the time of the last rotation.
+	// For secrets that have never been rotated, it is the time when they have been tracked.
+	LastRotation time.Time `json:"lastRotation"`
+}
+
+func (m Metadata) IsZero() bool {
+	return m.Generator == nil && m.Rotation == nil
+}
+
+// IsReserved checks if the path references the metadata secret, which can not be accessed using the API
+func IsReserved(path string) bool {
+	key, _ := backend.ParsedId{Path: path}.Key()
+	return key == SecretName
+}
+
+// WithBlueprint adds the metadata secret to all levels of the blueprint, so the onboarders create it.
+// Backends like Conjur can only write secrets that have been onboarded.
+func WithBlueprint(b backend.Blueprint) backend.Blueprint {
+	b.Environment = withSecret(b.Environment)
+	b.Team = withSecret(b.Team)
+	b.Application = withSecret(b.Application)
+	return b
+}
+
+func withSecret(l backend.LevelBlueprint) backend.LevelBlueprint {
+	if slices.Contains(l.Names(), SecretName) {
+		return l
+	}
+	l.Secrets = append(slices.Clone(l.Secrets), backend.SecretBlueprint{Name: SecretName, Value: "{}"})
+	return l
+}
@@ -0,0 +1,151 @@
+package metadata_test
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/backend"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/controller"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/generator"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/metadata"
+)
+
+// fakeController stores the values of the secrets by their ID
+type fakeController struct {
+	controller.Controller
+	values map[string]string
+	// notOnboarded is true if the metadata secrets have not been onboarded, so they can not be written
+	notOnboarded bool
+}
+
+func (c *fakeController) GetSecret(_ context.Context, rawId string) (controller.SecretResponse, error) {
+	value, ok := c.values[rawId]
+	if !ok {
+		return controller.SecretResponse{}, backend.ErrSecretNotFound(nil)
+	}
+	return controller.SecretResponse{Id: rawId, Value: value}, nil
+}
+
+func (c *fakeController) SetSecret(_ context.Context, rawId, value string) (controller.SecretResponse, error) {
+	if c.notOnboarded && strings.Contains(rawId, metadata.SecretName) {
+		return controller.SecretResponse{}, backend.ErrSecretNotFound(nil)
+	}
+	c.values[rawId] = value
+	return controller.SecretResponse{Id: rawId}, nil
+}
+
+func (c *fakeController) ListSecrets(_ context.Context, req controller.ListSecretsRequest) (controller.ListSecretsResponse, error) {
+	res := controller.ListSecretsResponse{}
+	for _, name := range []string{".metadata", "clientSecret"} {
+		if req.Filter == nil || req.Filter(name) {
+			res.Items = append(res.Items, controller.SecretRefResponse{Name: name})
+		}
+	}
+	return res, nil
+}
+
+func (c *fakeController) OnboardTeam(_ context.Context, envId, teamId string) (controller.OnboardResponse, error) {
+	c.notOnboarded = false
+	return controller.OnboardResponse{SecretRefs: map[string]string{
+		".metadata":    envId + ":" + teamId + "::.metadata:v1",
+		"clientSecret": envId + ":" + teamId + "::clientSecret:v1",
+	}}, nil
+}
+
+var _ = Describe("Metadata", func() {
+
+	var ctx context.Context
+	var ctrl *fakeController
+	var store *metadata.Store
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		ctrl = &fakeController{values: map[string]string{}}
+		store = metadata.NewStore(ctrl)
+	})
+
+	It("should add the metadata secret to the blueprint", func() {
+		blueprint := metadata.WithBlueprint(backend.DefaultBlueprint())
+		Expect(blueprint.Team.Names()).To(Equal([]string{"clientSecret", "teamToken", ".metadata"}))
+		Expect(blueprint.Team.Secrets[2].Value).To(Equal("{}"))
+		Expect(metadata.WithBlueprint(blueprint)).To(Equal(blueprint))
+		Expect(backend.DefaultBlueprint().Team.Names()).ToNot(ContainElement(".metadata"))
+	})
+
+	It("should store the metadata of the secrets of a scope in its metadata secret", func() {
+		now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		Expect(store.Update(ctx, "env:team::teamToken:v1", func(m *metadata.Metadata) {
+			m.Rotation = &metadata.Rotation{Policy: "team-token", LastRotation: now}
+		})).To(Succeed())
+		Expect(store.Update(ctx, "env:team::externalSecrets/tls:", func(m *metadata.Metadata) {
+			m.Generator = &generator.Config{Type: generator.TypeCertificate, CommonName: "my-app"}
+		})).To(Succeed())
+		Expect(ctrl.values).To(HaveKey("env:team::.metadata:"))
+
+		m, ok, err := store.Get(ctx, "env:team::teamToken:")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ok).To(BeTrue())
+		Expect(m.Rotation).To(Equal(&metadata.Rotation{Policy: "team-token", LastRotation: now}))
+
+		entries, err := store.List(ctx, backend.Scope{Env: "env", Team: "team"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(entries).To(HaveLen(2))
+		Expect(entries["externalSecrets/tls"].Generator.CommonName).To(Equal("my-app"))
+
+		_, ok, err = store.Get(ctx, "env:other::teamToken:")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ok).To(BeFalse())
+	})
+
+	It("should remove empty metadata", func() {
+		Expect(store.Update(ctx, "env:team::key:", func(m *metadata.Metadata) {
+			m.Generator = &generator.Config{Type: generator.TypeEC}
+		})).To(Succeed())
+		Expect(store.Update(ctx, "env:team::key:", func(m *metadata.Metadata) {
+			m.Generator = nil
+		})).To(Succeed())
+		Expect(ctrl.values["env:team::.metadata:"]).To(Equal("{}"))
+	})
+
+	It("should onboard a scope again if its metadata secret does not exist", func() {
+		ctrl.notOnboarded = true
+		store.WithOnboarder(ctrl)
+
+		Expect(store.Update(ctx, "env:team::key:", func(m *metadata.Metadata) {
+			m.Generator = &generator.Config{Type: generator.TypeEC}
+		})).To(Succeed())
+		Expect(ctrl.notOnboarded).To(BeFalse())
+		Expect(ctrl.values).To(HaveKey("env:team::.metadata:"))
+	})
+
+	It("should return the error if the metadata secret can not be written", func() {
+		ctrl.notOnboarded = true
+
+		err := store.Update(ctx, "env:team::key:", func(m *metadata.Metadata) {
+			m.Generator = &generator.Config{Type: generator.TypeEC}
+		})
+		Expect(err).To(MatchError(ContainSubstring("failed to write metadata of env:team:")))
+	})
+
+	It("should hide the metadata secret", func() {
+		ctrlWithMetadata := metadata.NewController(ctrl)
+
+		_, err := ctrlWithMetadata.GetSecret(ctx, "env:team::.metadata:")
+		Expect(backend.IsBackendError(err)).To(BeTrue())
+		Expect(err.Error()).To(ContainSubstring("invalid secret id"))
+		_, err = ctrlWithMetadata.SetSecret(ctx, "env:team::.metadata/foo:", "{}")
+		Expect(err).To(HaveOccurred())
+		Expect(ctrl.values).To(BeEmpty())
+
+		list, err := ctrlWithMetadata.ListSecrets(ctx, controller.ListSecretsRequest{Env: "env", Team: "team"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(list.Items).To(Equal([]controller.SecretRefResponse{{Name: "clientSecret"}}))
+
+		res, err := ctrlWithMetadata.OnboardTeam(ctx, "env", "team")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(res.SecretRefs).To(Equal(map[string]string{"clientSecret": "env:team::clientSecret:v1"}))
+	})
+})
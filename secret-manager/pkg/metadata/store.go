@@ -0,0 +1,131 @@
+package metadata
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/backend"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/controller"
+)
+
+// Store reads and writes the metadata of the secrets using the controller of the backend.
+// The controller must not be wrapped, so writing the metadata neither publishes events nor is audited.
+type Store struct {
+	ctrl      controller.SecretsController
+	onboarder controller.OnboardController
+	// mux serializes the updates, as the metadata of a scope is written as a whole
+	mux sync.Mutex
+}
+
+func NewStore(c controller.SecretsController) *Store {
+	return &Store{ctrl: c}
+}
+
+// WithOnboarder onboards a scope again if its metadata secret does not exist,
+// e.g. as the scope has been onboarded before the metadata secret was added to the blueprint.
+// Backends like Conjur can only write secrets that have been onboarded.
+func (s *Store) WithOnboarder(o controller.OnboardController) *Store {
+	s.onboarder = o
+	return s
+}
+
+// Get returns the metadata of the secret and false if it has none
+func (s *Store) Get(ctx context.Context, rawId string) (Metadata, bool, error) {
+	id, err := backend.ParseId(rawId)
+	if err != nil {
+		return Metadata{}, false, err
+	}
+	entries, err := s.read(ctx, id.Scope)
+	if err != nil {
+		return Metadata{}, false, err
+	}
+	m, ok := entries[id.Path]
+	return m, ok, nil
+}
+
+// Update changes the metadata of the secret. The metadata is removed if fn leaves it empty.
+func (s *Store) Update(ctx context.Context, rawId string, fn func(m *Metadata)) error {
+	id, err := backend.ParseId(rawId)
+	if err != nil {
+		return err
+	}
+
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	entries, err := s.read(ctx, id.Scope)
+	if err != nil {
+		return err
+	}
+	m := entries[id.Path]
+	fn(&m)
+	if m.IsZero() {
+		if _, ok := entries[id.Path]; !ok {
+			return nil
+		}
+		delete(entries, id.Path)
+	} else {
+		entries[id.Path] = m
+	}
+	return s.write(ctx, id.Scope, entries)
+}
+
+// List returns the metadata of all secrets of the environment, team or application by their path
+func (s *Store) List(ctx context.Context, scope backend.Scope) (map[string]Metadata, error) {
+	return s.read(ctx, scope)
+}
+
+func (s *Store) read(ctx context.Context, scope backend.Scope) (map[string]Metadata, error) {
+	entries := map[string]Metadata{}
+	secret, err := s.ctrl.GetSecret(ctx, secretId(scope))
+	if err != nil {
+		if backend.IsNotFoundErr(err) {
+			return entries, nil
+		}
+		return nil, err
+	}
+	if secret.Value == "" {
+		return entries, nil
+	}
+	if err := json.Unmarshal([]byte(secret.Value), &entries); err != nil {
+		return nil, errors.Wrapf(err, "failed to decode metadata of %s", scope)
+	}
+	return entries, nil
+}
+
+func (s *Store) write(ctx context.Context, scope backend.Scope, entries map[string]Metadata) error {
+	// Maps are encoded sorted by key
+	value, err := json.Marshal(entries)
+	if err != nil {
+		return errors.Wrapf(err, "failed to encode metadata of %s", scope)
+	}
+	_, err = s.ctrl.SetSecret(ctx, secretId(scope), string(value))
+	if backend.IsNotFoundErr(err) && s.onboarder != nil {
+		logr.FromContextOrDiscard(ctx).Info("Metadata secret does not exist. Onboarding again...", "scope", scope.String())
+		if err := s.onboard(ctx, scope); err != nil {
+			return errors.Wrapf(err, "failed to onboard %s to create its metadata secret", scope)
+		}
+		_, err = s.ctrl.SetSecret(ctx, secretId(scope), string(value))
+	}
+	return errors.Wrapf(err, "failed to write metadata of %s", scope)
+}
+
+// onboard onboards the scope again, which creates the missing secrets of the blueprint and keeps the existing ones
+func (s *Store) onboard(ctx context.Context, scope backend.Scope) (err error) {
+	switch {
+	case scope.App != "":
+		_, err = s.onboarder.OnboardApplication(ctx, scope.Env, scope.Team, scope.App)
+	case scope.Team != "":
+		_, err = s.onboarder.OnboardTeam(ctx, scope.Env, scope.Team)
+	default:
+		_, err = s.onboarder.OnboardEnvironment(ctx, scope.Env)
+	}
+	return err
+}
+
+// secretId returns the ID of the metadata secret of the scope
+func secretId(scope backend.Scope) string {
+	return backend.ParsedId{Scope: scope, Path: SecretName}.String()
+}
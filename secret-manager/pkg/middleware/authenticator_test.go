@@ -0,0 +1,306 @@
+package middleware_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/telekom/controlplane-mono/common-server/pkg/problems"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/middleware"
+)
+
+type staticAuthenticator struct {
+	identity middleware.Identity
+	ok       bool
+	err      error
+}
+
+func (a *staticAuthenticator) Authenticate(_ *fiber.Ctx) (middleware.Identity, bool, error) {
+	return a.identity, a.ok, a.err
+}
+
+func newAuthzApp(opts ...middleware.KubernetesAuthOption) (*fiber.App, *middleware.Identity) {
+	identity := &middleware.Identity{}
+	app := fiber.New(fiber.Config{
+		ErrorHandler: func(c *fiber.Ctx, err error) error {
+			var p problems.Problem
+			if errors.As(err, &p) {
+				return c.SendStatus(p.Code())
+			}
+			return c.SendStatus(http.StatusInternalServerError)
+		},
+	})
+	app.Use(middleware.NewKubernetesAuthz(opts...))
+	app.All("/*", func(c *fiber.Ctx) error {
+		*identity, _ = middleware.IdentityFromContext(c.UserContext())
+		return c.SendStatus(http.StatusOK)
+	})
+	return app, identity
+}
+
+func doRequest(app *fiber.App, method, path, token string) int {
+	req := httptest.NewRequest(method, path, nil)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	res, err := app.Test(req)
+	Expect(err).ToNot(HaveOccurred())
+	return res.StatusCode
+}
+
+var _ = Describe("Authenticators", func() {
+
+	Context("Identity", func() {
+		It("should derive the identity from the claims", func() {
+			claims := &middleware.ServiceAccountTokenClaims{}
+			claims.Kubernetes.ServiceAccount.Name = "my-sa"
+			claims.Kubernetes.Namespace = "my-ns"
+			identity, ok := middleware.IdentityFromContext(middleware.NewContextWithClaims(context.Background(), claims))
+			Expect(ok).To(BeTrue())
+			Expect(identity).To(Equal(middleware.Identity{ServiceAccountName: "my-sa", Namespace: "my-ns", Method: middleware.MethodKubernetes}))
+		})
+
+		It("should prefer the stored identity", func() {
+			_, ok := middleware.IdentityFromContext(context.Background())
+			Expect(ok).To(BeFalse())
+
+			expected := middleware.Identity{ServiceAccountName: "ci", Method: middleware.MethodOIDC}
+			identity, ok := middleware.IdentityFromContext(middleware.NewContextWithIdentity(context.Background(), expected))
+			Expect(ok).To(BeTrue())
+			Expect(identity).To(Equal(expected))
+		})
+	})
+
+	Context("Access Config", func() {
+		var authenticator *staticAuthenticator
+
+		BeforeEach(func() {
+			authenticator = &staticAuthenticator{
+				identity: middleware.Identity{ServiceAccountName: "pipeline", Namespace: "ci", Method: middleware.MethodOIDC},
+				ok:       true,
+			}
+		})
+
+		It("should check the identity against the access config", func() {
+			app, identity := newAuthzApp(
+				middleware.WithAuthenticators(authenticator),
+				middleware.WithAccessConfig(middleware.ServiceAccessConfig{
+					ServiceAccountName: "pipeline",
+					Namespace:          "ci",
+					AllowedAccess:      []middleware.AccessType{middleware.AccessTypeSecretsRead},
+				}),
+			)
+
+			Expect(doRequest(app, http.MethodGet, "/api/v1/secrets/foo", "")).To(Equal(http.StatusOK))
+			Expect(*identity).To(Equal(authenticator.identity))
+			Expect(doRequest(app, http.MethodPut, "/api/v1/secrets/foo", "")).To(Equal(http.StatusForbidden))
+		})
+
+		It("should reject unknown identities", func() {
+			app, _ := newAuthzApp(
+				middleware.WithAuthenticators(authenticator),
+				middleware.WithAccessConfig(middleware.ServiceAccessConfig{ServiceAccountName: "other", Namespace: "ci"}),
+			)
+			Expect(doRequest(app, http.MethodGet, "/api/v1/secrets/foo", "")).To(Equal(http.StatusForbidden))
+		})
+
+		It("should reject invalid credentials", func() {
+			authenticator.err = jwt.ErrTokenExpired
+			app, _ := newAuthzApp(middleware.WithAuthenticators(authenticator))
+			Expect(doRequest(app, http.MethodGet, "/api/v1/secrets/foo", "")).To(Equal(http.StatusUnauthorized))
+		})
+
+		It("should reject requests without credentials", func() {
+			authenticator.ok = false
+			app, _ := newAuthzApp(middleware.WithAuthenticators(authenticator))
+			Expect(doRequest(app, http.MethodGet, "/api/v1/secrets/foo", "")).To(Equal(http.StatusUnauthorized))
+		})
+	})
+
+	Context("OIDC", func() {
+		var key *rsa.PrivateKey
+		var jwksServer *httptest.Server
+		var authenticator *middleware.OIDCAuthenticator
+
+		const issuer = "https://gitlab.example.com"
+
+		sign := func(claims jwt.MapClaims) string {
+			token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+			token.Header["kid"] = "test"
+			signed, err := token.SignedString(key)
+			Expect(err).ToNot(HaveOccurred())
+			return signed
+		}
+
+		BeforeEach(func() {
+			var err error
+			key, err = rsa.GenerateKey(rand.Reader, 2048)
+			Expect(err).ToNot(HaveOccurred())
+
+			jwks, err := json.Marshal(map[string]any{"keys": []map[string]string{{
+				"kty": "RSA",
+				"kid": "test",
+				"alg": "RS256",
+				"use": "sig",
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			}}})
+			Expect(err).ToNot(HaveOccurred())
+			jwksServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = w.Write(jwks)
+			}))
+			DeferCleanup(jwksServer.Close)
+
+			authenticator, err = middleware.NewOIDCAuthenticator(middleware.OIDCConfig{
+				Issuer:    issuer,
+				JWKSetURL: jwksServer.URL,
+				Audience:  "secret-manager",
+				ClaimMapping: middleware.ClaimMapping{
+					ServiceAccountName: "project_path",
+					Namespace:          "namespace.path",
+				},
+			})
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should map the claims to the identity", func() {
+			app, identity := newAuthzApp(middleware.WithAuthenticators(authenticator))
+			token := sign(jwt.MapClaims{
+				"iss":          issuer,
+				"aud":          "secret-manager",
+				"exp":          time.Now().Add(time.Minute).Unix(),
+				"project_path": "my-group/my-project",
+				"namespace":    map[string]any{"path": "my-group"},
+			})
+
+			Expect(doRequest(app, http.MethodGet, "/api/v1/secrets/foo", token)).To(Equal(http.StatusOK))
+			Expect(*identity).To(Equal(middleware.Identity{
+				ServiceAccountName: "my-group/my-project",
+				Namespace:          "my-group",
+				Method:             middleware.MethodOIDC,
+			}))
+		})
+
+		It("should reject invalid tokens of the issuer", func() {
+			app, _ := newAuthzApp(middleware.WithAuthenticators(authenticator))
+			expired := sign(jwt.MapClaims{
+				"iss":          issuer,
+				"aud":          "secret-manager",
+				"exp":          time.Now().Add(-time.Minute).Unix(),
+				"project_path": "my-group/my-project",
+			})
+			Expect(doRequest(app, http.MethodGet, "/api/v1/secrets/foo", expired)).To(Equal(http.StatusUnauthorized))
+
+			wrongAudience := sign(jwt.MapClaims{
+				"iss":          issuer,
+				"aud":          "other",
+				"exp":          time.Now().Add(time.Minute).Unix(),
+				"project_path": "my-group/my-project",
+			})
+			Expect(doRequest(app, http.MethodGet, "/api/v1/secrets/foo", wrongAudience)).To(Equal(http.StatusUnauthorized))
+
+			missingAudience := sign(jwt.MapClaims{
+				"iss":          issuer,
+				"exp":          time.Now().Add(time.Minute).Unix(),
+				"project_path": "my-group/my-project",
+			})
+			Expect(doRequest(app, http.MethodGet, "/api/v1/secrets/foo", missingAudience)).To(Equal(http.StatusUnauthorized))
+
+			missingClaim := sign(jwt.MapClaims{
+				"iss": issuer,
+				"aud": "secret-manager",
+				"exp": time.Now().Add(time.Minute).Unix(),
+			})
+			Expect(doRequest(app, http.MethodGet, "/api/v1/secrets/foo", missingClaim)).To(Equal(http.StatusUnauthorized))
+		})
+
+		It("should require the audience", func() {
+			_, err := middleware.NewOIDCAuthenticator(middleware.OIDCConfig{
+				Issuer:    issuer,
+				JWKSetURL: jwksServer.URL,
+			})
+			Expect(err).To(MatchError(ContainSubstring("audience of issuer https://gitlab.example.com must be set")))
+		})
+
+		It("should ignore tokens of other issuers", func() {
+			app := fiber.New()
+			app.Get("/", func(c *fiber.Ctx) error {
+				_, ok, err := authenticator.Authenticate(c)
+				Expect(ok).To(BeFalse())
+				Expect(err).ToNot(HaveOccurred())
+				return nil
+			})
+			token := sign(jwt.MapClaims{"iss": "https://kubernetes.default.svc"})
+			Expect(doRequest(app, http.MethodGet, "/", token)).To(Equal(http.StatusOK))
+			Expect(doRequest(app, http.MethodGet, "/", "")).To(Equal(http.StatusOK))
+		})
+	})
+
+	Context("mTLS", func() {
+		var authenticator *middleware.MTLSAuthenticator
+
+		newCertificate := func(subject pkix.Name) *x509.Certificate {
+			return &x509.Certificate{Subject: subject}
+		}
+
+		BeforeEach(func() {
+			key, err := rsa.GenerateKey(rand.Reader, 2048)
+			Expect(err).ToNot(HaveOccurred())
+			ca := &x509.Certificate{
+				SerialNumber:          big.NewInt(1),
+				Subject:               pkix.Name{CommonName: "test-ca"},
+				NotBefore:             time.Now(),
+				NotAfter:              time.Now().Add(time.Hour),
+				IsCA:                  true,
+				BasicConstraintsValid: true,
+				KeyUsage:              x509.KeyUsageCertSign,
+			}
+			der, err := x509.CreateCertificate(rand.Reader, ca, ca, &key.PublicKey, key)
+			Expect(err).ToNot(HaveOccurred())
+
+			caFile := filepath.Join(GinkgoT().TempDir(), "ca.crt")
+			pemBlock := "-----BEGIN CERTIFICATE-----\n" + base64.StdEncoding.EncodeToString(der) + "\n-----END CERTIFICATE-----\n"
+			Expect(os.WriteFile(caFile, []byte(pemBlock), 0o600)).To(Succeed())
+
+			authenticator, err = middleware.NewMTLSAuthenticator(middleware.MTLSConfig{CAFile: caFile})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(authenticator.ClientCAs()).ToNot(BeNil())
+		})
+
+		It("should map the subject to the identity", func() {
+			identity, err := authenticator.IdentityOf(newCertificate(pkix.Name{CommonName: "common-server", Organization: []string{"controlplane"}}))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(identity).To(Equal(middleware.Identity{ServiceAccountName: "common-server", Namespace: "controlplane", Method: middleware.MethodMTLS}))
+
+			_, err = authenticator.IdentityOf(newCertificate(pkix.Name{Organization: []string{"controlplane"}}))
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should fail for a CA file without certificates", func() {
+			caFile := filepath.Join(GinkgoT().TempDir(), "ca.crt")
+			Expect(os.WriteFile(caFile, []byte("foo"), 0o600)).To(Succeed())
+			_, err := middleware.NewMTLSAuthenticator(middleware.MTLSConfig{CAFile: caFile})
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should ignore requests without client certificate", func() {
+			app, _ := newAuthzApp(middleware.WithAuthenticators(authenticator))
+			Expect(doRequest(app, http.MethodGet, "/api/v1/secrets/foo", "")).To(Equal(http.StatusUnauthorized))
+		})
+	})
+})
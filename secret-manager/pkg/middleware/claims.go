@@ -1,6 +1,10 @@
 package middleware
 
-import "github.com/golang-jwt/jwt/v5"
+import (
+	"context"
+
+	"github.com/golang-jwt/jwt/v5"
+)
 
 var _ jwt.Claims = (*ServiceAccountTokenClaims)(nil)
 
@@ -20,3 +24,16 @@ type ServiceAccountTokenClaims struct {
 	jwt.RegisteredClaims
 	Kubernetes Kubernetes `json:"kubernetes.io"`
 }
+
+type claimsKey struct{}
+
+// ClaimsFromContext returns the claims of the authenticated caller
+func ClaimsFromContext(ctx context.Context) (*ServiceAccountTokenClaims, bool) {
+	claims, ok := ctx.Value(claimsKey{}).(*ServiceAccountTokenClaims)
+	return claims, ok
+}
+
+// NewContextWithClaims returns a copy of the context that contains the claims
+func NewContextWithClaims(ctx context.Context, claims *ServiceAccountTokenClaims) context.Context {
+	return context.WithValue(ctx, claimsKey{}, claims)
+}
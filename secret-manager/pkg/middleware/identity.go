@@ -0,0 +1,61 @@
+package middleware
+
+import (
+	"context"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	MethodKubernetes = "kubernetes"
+	MethodOIDC       = "oidc"
+	MethodMTLS       = "mtls"
+)
+
+// Identity is the authenticated caller.
+// Its service account name and namespace are checked against the access config, regardless of how the caller has been authenticated.
+type Identity struct {
+	ServiceAccountName string
+	Namespace          string
+	// Pod is only known for callers with a Kubernetes service-account token
+	Pod string
+	// Method is the authenticator that has identified the caller, e.g. oidc
+	Method string
+}
+
+// Authenticator identifies the caller of a request by other means than a Kubernetes service-account token
+type Authenticator interface {
+	// Authenticate returns the identity of the caller.
+	// ok is false if the request does not contain credentials this authenticator is responsible for.
+	// If ok is true and err is not nil, the credentials are invalid.
+	Authenticate(c *fiber.Ctx) (identity Identity, ok bool, err error)
+}
+
+type identityKey struct{}
+
+// IdentityFromContext returns the identity of the authenticated caller.
+// If only the claims of a service-account token are set, the identity is derived from them.
+func IdentityFromContext(ctx context.Context) (Identity, bool) {
+	if identity, ok := ctx.Value(identityKey{}).(Identity); ok {
+		return identity, true
+	}
+	claims, ok := ClaimsFromContext(ctx)
+	if !ok || claims == nil {
+		return Identity{}, false
+	}
+	return identityOf(claims), true
+}
+
+// NewContextWithIdentity returns a copy of the context that contains the identity
+func NewContextWithIdentity(ctx context.Context, identity Identity) context.Context {
+	return context.WithValue(ctx, identityKey{}, identity)
+}
+
+func identityOf(claims *ServiceAccountTokenClaims) Identity {
+	return Identity{
+		ServiceAccountName: claims.Kubernetes.ServiceAccount.Name,
+		Namespace:          claims.Kubernetes.Namespace,
+		Pod:                claims.Kubernetes.Pod.Name,
+		Method:             MethodKubernetes,
+	}
+}
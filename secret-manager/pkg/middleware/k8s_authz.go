@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"slices"
@@ -38,11 +39,38 @@ type ServiceAccessConfig struct {
 	allowedAccessSet   AccessTypeSet
 }
 
+// IsSecretAllowed checks if the secret with the given name may be accessed.
+// A nested secret like "externalSecrets/foo" is allowed if its parent is allowed.
+// If no secrets are configured, all secrets are allowed.
+func (s ServiceAccessConfig) IsSecretAllowed(name string) bool {
+	if len(s.AllowedSecrets) == 0 {
+		return true
+	}
+	parent := strings.SplitN(name, "/", 2)[0]
+	return slices.Contains(s.AllowedSecrets, name) || slices.Contains(s.AllowedSecrets, parent)
+}
+
+type serviceAccessConfigKey struct{}
+
+// ServiceAccessConfigFromContext returns the access config of the authenticated caller.
+// It is only set if an access config is defined for the caller.
+func ServiceAccessConfigFromContext(ctx context.Context) (ServiceAccessConfig, bool) {
+	config, ok := ctx.Value(serviceAccessConfigKey{}).(ServiceAccessConfig)
+	return config, ok
+}
+
+// NewContextWithServiceAccessConfig returns a copy of the context that contains the access config
+func NewContextWithServiceAccessConfig(ctx context.Context, config ServiceAccessConfig) context.Context {
+	return context.WithValue(ctx, serviceAccessConfigKey{}, config)
+}
+
 type KubernetesAuthzOptions struct {
 	JWKSetURLs     []string
 	TrustedIssuers []string
 	Audience       string
 	AccessConfig   []ServiceAccessConfig
+	// Authenticators are tried before the service-account token, e.g. for callers outside the cluster
+	Authenticators []Authenticator
 }
 
 func (o *KubernetesAuthzOptions) ServiceAccessConfig() map[string]ServiceAccessConfig {
@@ -91,6 +119,14 @@ func WithAccessConfig(configs ...ServiceAccessConfig) KubernetesAuthOption {
 	}
 }
 
+// WithAuthenticators adds authenticators for callers without a Kubernetes service-account token.
+// Their identities are checked against the same access config.
+func WithAuthenticators(authenticators ...Authenticator) KubernetesAuthOption {
+	return func(o *KubernetesAuthzOptions) {
+		o.Authenticators = append(o.Authenticators, authenticators...)
+	}
+}
+
 type KubernetesAuthOption func(*KubernetesAuthzOptions)
 
 func NewKubernetesAuthz(opts ...KubernetesAuthOption) fiber.Handler {
@@ -99,26 +135,50 @@ func NewKubernetesAuthz(opts ...KubernetesAuthOption) fiber.Handler {
 		opt(options)
 	}
 
-	if len(options.TrustedIssuers) == 0 {
+	if len(options.TrustedIssuers) == 0 && len(options.Authenticators) == 0 {
 		fmt.Println("⚠️\tDisabling Kubernetes Authz middleware, no trusted issuers provided")
 		return func(c *fiber.Ctx) error {
 			return c.Next()
 		}
 	}
 
-	return jwtware.New(jwtware.Config{
-		ContextKey:     "user",
-		JWKSetURLs:     options.JWKSetURLs,
-		SuccessHandler: newSuccessHandler(options),
-		Claims:         &ServiceAccountTokenClaims{},
-		ErrorHandler: func(c *fiber.Ctx, err error) error {
-			var pErr problems.Problem
-			if errors.As(err, &pErr) {
-				return pErr
+	tokenHandler := func(c *fiber.Ctx) error {
+		return problems.Unauthorized("Failed to authenticate", "Missing credentials")
+	}
+	if len(options.TrustedIssuers) > 0 {
+		tokenHandler = jwtware.New(jwtware.Config{
+			ContextKey:     "user",
+			JWKSetURLs:     options.JWKSetURLs,
+			SuccessHandler: newSuccessHandler(options),
+			Claims:         &ServiceAccountTokenClaims{},
+			ErrorHandler: func(c *fiber.Ctx, err error) error {
+				var pErr problems.Problem
+				if errors.As(err, &pErr) {
+					return pErr
+				}
+				return problems.Unauthorized("Failed to authenticate", err.Error())
+			},
+		})
+	}
+	if len(options.Authenticators) == 0 {
+		return tokenHandler
+	}
+
+	checkAccess := newAccessHandler(options)
+	return func(c *fiber.Ctx) error {
+		for _, authenticator := range options.Authenticators {
+			identity, ok, err := authenticator.Authenticate(c)
+			if !ok {
+				continue
 			}
-			return problems.Unauthorized("Failed to authenticate", err.Error())
-		},
-	})
+			if err != nil {
+				logr.FromContextOrDiscard(c.UserContext()).Info("Unauthorized", "method", identity.Method, "error", err.Error())
+				return problems.Unauthorized("Failed to authenticate", err.Error())
+			}
+			return checkAccess(c, c.UserContext(), identity)
+		}
+		return tokenHandler(c)
+	}
 }
 
 func defaultOpts() *KubernetesAuthzOptions {
@@ -134,7 +194,8 @@ func isReadOnly(c *fiber.Ctx) bool {
 	if c.Method() == fiber.MethodGet || c.Method() == fiber.MethodHead || c.Method() == fiber.MethodOptions {
 		return true
 	}
-	return false
+	// Getting many secrets at once needs a request body
+	return c.Method() == fiber.MethodPost && strings.HasSuffix(c.Path(), ":batchGet")
 }
 
 func isOnboardingRequest(c *fiber.Ctx) bool {
@@ -159,7 +220,7 @@ func isAccessAllowed(c *fiber.Ctx, accessTypesSet AccessTypeSet) bool {
 }
 
 func newSuccessHandler(options *KubernetesAuthzOptions) fiber.Handler {
-	cfg := options.ServiceAccessConfig()
+	checkAccess := newAccessHandler(options)
 	return func(c *fiber.Ctx) error {
 		user := c.Locals("user").(*jwt.Token)
 		claims := user.Claims.(*ServiceAccountTokenClaims)
@@ -168,16 +229,29 @@ func newSuccessHandler(options *KubernetesAuthzOptions) fiber.Handler {
 			return problems.Unauthorized("Failed to authenticate", "Invalid token structure")
 		}
 
-		log := logr.FromContextOrDiscard(c.UserContext())
-		log = log.WithValues("san", claims.Kubernetes.ServiceAccount.Name, "ns", claims.Kubernetes.Namespace)
-
 		if slices.Contains(claims.Audience, options.Audience) {
 			return problems.Forbidden("Access denied", "Invalid audience")
 		}
 
-		serviceAccountName := claims.Kubernetes.ServiceAccount.Name
-		namespace := claims.Kubernetes.Namespace
-		podName := claims.Kubernetes.Pod.Name
+		return checkAccess(c, NewContextWithClaims(c.UserContext(), claims), identityOf(claims))
+	}
+}
+
+// newAccessHandler returns a function that checks the authenticated caller against the access config.
+// The identity is stored in the user context. If the access is allowed, the next handler is called.
+func newAccessHandler(options *KubernetesAuthzOptions) func(c *fiber.Ctx, ctx context.Context, identity Identity) error {
+	cfg := options.ServiceAccessConfig()
+	return func(c *fiber.Ctx, ctx context.Context, identity Identity) error {
+		serviceAccountName := identity.ServiceAccountName
+		namespace := identity.Namespace
+		podName := identity.Pod
+
+		log := logr.FromContextOrDiscard(ctx)
+		log = log.WithValues("san", serviceAccountName, "ns", namespace, "method", identity.Method)
+
+		// The identity is known before the access is checked, so denied requests can be audited with it
+		ctx = NewContextWithIdentity(ctx, identity)
+		c.SetUserContext(ctx)
 
 		key := serviceAccountName + namespace
 		if len(cfg) > 0 {
@@ -196,13 +270,14 @@ func newSuccessHandler(options *KubernetesAuthzOptions) fiber.Handler {
 				log.Info("Forbidden", "allowed_access", config.AllowedAccess)
 				return problems.Forbidden("Access denied", "Invalid access")
 			}
+			ctx = NewContextWithServiceAccessConfig(ctx, config)
 		} else {
 			log.Info("No access config defined. Assuming all access is allowed")
 		}
 
 		log.Info("Authorized", "service_account_name", serviceAccountName, "namespace", namespace)
 
-		c.SetUserContext(logr.NewContext(c.UserContext(), log))
+		c.SetUserContext(logr.NewContext(ctx, log))
 		return c.Next()
 	}
 }
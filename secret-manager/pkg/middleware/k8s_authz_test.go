@@ -1,8 +1,11 @@
 package middleware_test
 
 import (
+	"context"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/middleware"
 )
 
 var _ = Describe("Kubernetes Authentication Middleware", func() {
@@ -12,4 +15,43 @@ var _ = Describe("Kubernetes Authentication Middleware", func() {
 			Expect(true).To(BeTrue())
 		})
 	})
+
+	Context("Service Access Config", func() {
+		It("should allow all secrets if none are configured", func() {
+			config := middleware.ServiceAccessConfig{}
+			Expect(config.IsSecretAllowed("clientSecret")).To(BeTrue())
+		})
+
+		It("should only allow the configured secrets", func() {
+			config := middleware.ServiceAccessConfig{AllowedSecrets: []string{"clientSecret", "externalSecrets"}}
+			Expect(config.IsSecretAllowed("clientSecret")).To(BeTrue())
+			Expect(config.IsSecretAllowed("externalSecrets/foo")).To(BeTrue())
+			Expect(config.IsSecretAllowed("teamToken")).To(BeFalse())
+			Expect(config.IsSecretAllowed("clientSecret2")).To(BeFalse())
+		})
+
+		It("should store the config in the context", func() {
+			_, ok := middleware.ServiceAccessConfigFromContext(context.Background())
+			Expect(ok).To(BeFalse())
+
+			config := middleware.ServiceAccessConfig{ServiceAccountName: "my-sa"}
+			ctx := middleware.NewContextWithServiceAccessConfig(context.Background(), config)
+			res, ok := middleware.ServiceAccessConfigFromContext(ctx)
+			Expect(ok).To(BeTrue())
+			Expect(res.ServiceAccountName).To(Equal("my-sa"))
+		})
+	})
+
+	Context("Claims", func() {
+		It("should store the claims in the context", func() {
+			_, ok := middleware.ClaimsFromContext(context.Background())
+			Expect(ok).To(BeFalse())
+
+			claims := &middleware.ServiceAccountTokenClaims{}
+			claims.Kubernetes.ServiceAccount.Name = "my-sa"
+			res, ok := middleware.ClaimsFromContext(middleware.NewContextWithClaims(context.Background(), claims))
+			Expect(ok).To(BeTrue())
+			Expect(res.Kubernetes.ServiceAccount.Name).To(Equal("my-sa"))
+		})
+	})
 })
@@ -0,0 +1,69 @@
+package middleware
+
+import (
+	"crypto/x509"
+	"os"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/pkg/errors"
+)
+
+type MTLSConfig struct {
+	// CAFile contains the PEM-encoded CAs that issue the client certificates
+	CAFile string `yaml:"ca_file" json:"ca_file"`
+	// Namespace is the namespace of all callers that authenticate by client certificate.
+	// If empty, the organization of the subject of the certificate is used.
+	Namespace string `yaml:"namespace" json:"namespace"`
+}
+
+var _ Authenticator = &MTLSAuthenticator{}
+
+// MTLSAuthenticator authenticates callers by their client certificate.
+// The common name of the subject is used as service account name.
+// The certificate is verified by the TLS listener, which must request client certificates issued by ClientCAs.
+type MTLSAuthenticator struct {
+	config    MTLSConfig
+	clientCAs *x509.CertPool
+}
+
+func NewMTLSAuthenticator(config MTLSConfig) (*MTLSAuthenticator, error) {
+	pem, err := os.ReadFile(config.CAFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read CA file")
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, errors.Errorf("no certificates found in %s", config.CAFile)
+	}
+	return &MTLSAuthenticator{config: config, clientCAs: pool}, nil
+}
+
+// ClientCAs returns the CAs that the TLS listener must verify the client certificates with
+func (a *MTLSAuthenticator) ClientCAs() *x509.CertPool {
+	return a.clientCAs
+}
+
+func (a *MTLSAuthenticator) Authenticate(c *fiber.Ctx) (identity Identity, ok bool, err error) {
+	state := c.Context().TLSConnectionState()
+	if state == nil || len(state.VerifiedChains) == 0 || len(state.VerifiedChains[0]) == 0 {
+		return identity, false, nil
+	}
+	identity, err = a.IdentityOf(state.VerifiedChains[0][0])
+	return identity, true, err
+}
+
+// IdentityOf returns the identity of the caller with the verified certificate
+func (a *MTLSAuthenticator) IdentityOf(cert *x509.Certificate) (Identity, error) {
+	identity := Identity{
+		ServiceAccountName: cert.Subject.CommonName,
+		Namespace:          a.config.Namespace,
+		Method:             MethodMTLS,
+	}
+	if identity.Namespace == "" && len(cert.Subject.Organization) > 0 {
+		identity.Namespace = cert.Subject.Organization[0]
+	}
+	if identity.ServiceAccountName == "" {
+		return identity, errors.New("common name of the client certificate is missing")
+	}
+	return identity, nil
+}
@@ -0,0 +1,160 @@
+package middleware
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/MicahParks/keyfunc/v2"
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/pkg/errors"
+)
+
+const defaultServiceAccountClaim = "sub"
+
+// ClaimMapping defines which claims of an OIDC token identify the caller.
+// Nested claims are separated by dots, e.g. `project.path`.
+type ClaimMapping struct {
+	// ServiceAccountName is the claim that is used as service account name. It defaults to `sub`.
+	ServiceAccountName string `yaml:"service_account_name" json:"service_account_name"`
+	// Namespace is the claim that is used as namespace. If empty, the namespace of the identity is empty.
+	Namespace string `yaml:"namespace" json:"namespace"`
+}
+
+type OIDCConfig struct {
+	Issuer string `yaml:"issuer" json:"issuer"`
+	// JWKSetURL defaults to the jwks_uri of the discovery document of the issuer
+	JWKSetURL string `yaml:"jwk_set_url" json:"jwk_set_url"`
+	// Audience must be contained in the tokens, so tokens that the issuer creates for other services are rejected
+	Audience     string       `yaml:"audience" json:"audience"`
+	ClaimMapping ClaimMapping `yaml:"claim_mapping" json:"claim_mapping"`
+}
+
+// Validate checks that the issuer and the audience are set
+func (c OIDCConfig) Validate() error {
+	if c.Issuer == "" {
+		return errors.New("issuer must be set")
+	}
+	if c.Audience == "" {
+		return errors.Errorf("audience of issuer %s must be set", c.Issuer)
+	}
+	return nil
+}
+
+var _ Authenticator = &OIDCAuthenticator{}
+
+// OIDCAuthenticator authenticates callers by the tokens of an OIDC issuer, e.g. of a CI pipeline.
+// Tokens of other issuers are left to the other authenticators.
+type OIDCAuthenticator struct {
+	config  OIDCConfig
+	keyfunc jwt.Keyfunc
+}
+
+// NewOIDCAuthenticator creates an authenticator for the issuer.
+// The keys of the issuer are fetched immediately and refreshed in the background.
+func NewOIDCAuthenticator(config OIDCConfig) (*OIDCAuthenticator, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	if config.ClaimMapping.ServiceAccountName == "" {
+		config.ClaimMapping.ServiceAccountName = defaultServiceAccountClaim
+	}
+	if config.JWKSetURL == "" {
+		jwksUrl, err := discoverJWKSetURL(config.Issuer)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to discover JWK set of %s", config.Issuer)
+		}
+		config.JWKSetURL = jwksUrl
+	}
+
+	jwks, err := keyfunc.Get(config.JWKSetURL, keyfunc.Options{
+		RefreshInterval:   time.Hour,
+		RefreshRateLimit:  5 * time.Minute,
+		RefreshTimeout:    10 * time.Second,
+		RefreshUnknownKID: true,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get JWK set of %s", config.Issuer)
+	}
+	return &OIDCAuthenticator{config: config, keyfunc: jwks.Keyfunc}, nil
+}
+
+func (a *OIDCAuthenticator) Authenticate(c *fiber.Ctx) (identity Identity, ok bool, err error) {
+	rawToken, found := strings.CutPrefix(c.Get(fiber.HeaderAuthorization), "Bearer ")
+	if !found {
+		return identity, false, nil
+	}
+	// The issuer is read first to find out if the token is meant for this authenticator
+	unverified := jwt.MapClaims{}
+	if _, _, err := jwt.NewParser().ParseUnverified(rawToken, unverified); err != nil {
+		return identity, false, nil
+	}
+	if issuer, _ := unverified.GetIssuer(); issuer != a.config.Issuer {
+		return identity, false, nil
+	}
+
+	opts := []jwt.ParserOption{jwt.WithIssuer(a.config.Issuer), jwt.WithAudience(a.config.Audience), jwt.WithExpirationRequired()}
+	claims := jwt.MapClaims{}
+	if _, err := jwt.ParseWithClaims(rawToken, claims, a.keyfunc, opts...); err != nil {
+		return identity, true, err
+	}
+
+	identity = Identity{
+		ServiceAccountName: claimString(claims, a.config.ClaimMapping.ServiceAccountName),
+		Namespace:          claimString(claims, a.config.ClaimMapping.Namespace),
+		Method:             MethodOIDC,
+	}
+	if identity.ServiceAccountName == "" {
+		return identity, true, errors.Errorf("claim %s is missing", a.config.ClaimMapping.ServiceAccountName)
+	}
+	return identity, true, nil
+}
+
+// claimString returns the claim at the dot-separated path if it is a string or a number
+func claimString(claims jwt.MapClaims, path string) string {
+	if path == "" {
+		return ""
+	}
+	var value any = map[string]any(claims)
+	for _, name := range strings.Split(path, ".") {
+		obj, ok := value.(map[string]any)
+		if !ok {
+			return ""
+		}
+		value = obj[name]
+	}
+	switch v := value.(type) {
+	case string:
+		return v
+	case float64:
+		return fmt.Sprint(v)
+	default:
+		return ""
+	}
+}
+
+func discoverJWKSetURL(issuer string) (string, error) {
+	client := &http.Client{Timeout: 10 * time.Second}
+	res, err := client.Get(strings.TrimSuffix(issuer, "/") + "/.well-known/openid-configuration")
+	if err != nil {
+		return "", err
+	}
+	defer res.Body.Close() //nolint:errcheck
+	if res.StatusCode != http.StatusOK {
+		return "", errors.Errorf("unexpected status %d", res.StatusCode)
+	}
+
+	discovery := struct {
+		JWKSetURL string `json:"jwks_uri"`
+	}{}
+	if err := json.NewDecoder(res.Body).Decode(&discovery); err != nil {
+		return "", errors.Wrap(err, "failed to decode discovery document")
+	}
+	if discovery.JWKSetURL == "" {
+		return "", errors.New("jwks_uri is missing")
+	}
+	return discovery.JWKSetURL, nil
+}
@@ -0,0 +1,243 @@
+package migration
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/backend"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/controller"
+)
+
+// Mode defines what the Migrator does
+type Mode string
+
+const (
+	// ModeMigrate onboards all scopes in the target and copies the secret values
+	ModeMigrate Mode = "migrate"
+	// ModeDryRun only reads the source and reports what would be migrated
+	ModeDryRun Mode = "dry-run"
+	// ModeVerify compares the values of the source with the values of the target without writing anything
+	ModeVerify Mode = "verify"
+)
+
+// Report is the result of a migration
+type Report struct {
+	Mode   Mode            `json:"mode"`
+	Scopes []backend.Scope `json:"scopes"`
+	// Mapping maps the old secret IDs to the new secret IDs.
+	// In dry-run mode, the new IDs do not contain a checksum.
+	Mapping map[string]string `json:"mapping"`
+	// Mismatches contains the old IDs of the secrets whose value differs in the target
+	Mismatches []string `json:"mismatches,omitempty"`
+	// Errors contains the errors by the old secret ID or the scope
+	Errors map[string]string `json:"errors,omitempty"`
+}
+
+// Failed returns true if any secret could not be migrated or verified
+func (r *Report) Failed() bool {
+	return len(r.Errors) > 0 || len(r.Mismatches) > 0
+}
+
+func (r *Report) addError(key string, err error) {
+	r.Errors[key] = err.Error()
+}
+
+// Migrator copies all onboarded environments, teams and applications and their secrets from one backend to another.
+// The scopes are discovered using the ScopeLister of the source onboarder.
+// The target onboards the scopes using its own blueprint, so its initial secrets are overwritten with the values of the source.
+type Migrator struct {
+	scopes backend.ScopeLister
+	source controller.Controller
+	target controller.Controller
+}
+
+func NewMigrator(scopes backend.ScopeLister, source, target controller.Controller) *Migrator {
+	return &Migrator{
+		scopes: scopes,
+		source: source,
+		target: target,
+	}
+}
+
+// Run migrates or verifies all secrets depending on the mode.
+// An error is only returned if the scopes cannot be listed. All other errors are collected in the report.
+func (m *Migrator) Run(ctx context.Context, mode Mode) (*Report, error) {
+	log := logr.FromContextOrDiscard(ctx)
+
+	switch mode {
+	case ModeMigrate, ModeDryRun, ModeVerify:
+	default:
+		return nil, errors.Errorf("unknown mode: %s", mode)
+	}
+
+	scopes, err := m.scopes.ListScopes(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list scopes")
+	}
+	report := &Report{
+		Mode:    mode,
+		Scopes:  sortScopes(scopes),
+		Mapping: make(map[string]string),
+		Errors:  make(map[string]string),
+	}
+
+	failed := make(map[backend.Scope]bool)
+	for _, scope := range report.Scopes {
+		if failed[parentOf(scope)] {
+			failed[scope] = true
+			report.addError(scope.String(), errors.New("parent scope has not been migrated"))
+			continue
+		}
+		log.Info("Migrating scope", "mode", mode, "env", scope.Env, "team", scope.Team, "app", scope.App)
+		if mode == ModeMigrate {
+			if err := m.onboard(ctx, scope); err != nil {
+				failed[scope] = true
+				report.addError(scope.String(), err)
+				continue
+			}
+		}
+		if err := m.migrateSecrets(ctx, scope, mode, report); err != nil {
+			report.addError(scope.String(), err)
+		}
+	}
+	return report, nil
+}
+
+func (m *Migrator) onboard(ctx context.Context, scope backend.Scope) (err error) {
+	switch {
+	case scope.App != "":
+		_, err = m.target.OnboardApplication(ctx, scope.Env, scope.Team, scope.App)
+	case scope.Team != "":
+		_, err = m.target.OnboardTeam(ctx, scope.Env, scope.Team)
+	default:
+		_, err = m.target.OnboardEnvironment(ctx, scope.Env)
+	}
+	return err
+}
+
+// migrateSecrets copies, plans or verifies the secrets of a single scope
+func (m *Migrator) migrateSecrets(ctx context.Context, scope backend.Scope, mode Mode, report *Report) error {
+	log := logr.FromContextOrDiscard(ctx)
+
+	names, err := m.listSecrets(ctx, scope)
+	if err != nil {
+		return errors.Wrap(err, "failed to list secrets")
+	}
+
+	for _, name := range names {
+		targetId := strings.Join([]string{scope.Env, scope.Team, scope.App, name, ""}, backend.Separator)
+		secret, err := m.source.GetSecret(ctx, targetId)
+		if err == nil && secret.Stale {
+			err = errors.New("only a stale value is available")
+		}
+		if err != nil {
+			report.addError(targetId, errors.Wrap(err, "failed to read secret"))
+			continue
+		}
+
+		switch mode {
+		case ModeDryRun:
+			report.Mapping[secret.Id] = targetId
+
+		case ModeVerify:
+			current, err := m.target.GetSecret(ctx, targetId)
+			if err != nil {
+				report.addError(secret.Id, errors.Wrap(err, "failed to read secret from target"))
+				continue
+			}
+			report.Mapping[secret.Id] = current.Id
+			if current.Value != secret.Value {
+				log.Info("Secret differs in target", "id", secret.Id)
+				report.Mismatches = append(report.Mismatches, secret.Id)
+			}
+
+		case ModeMigrate:
+			written, err := m.target.SetSecret(ctx, targetId, secret.Value)
+			if err != nil {
+				report.addError(secret.Id, errors.Wrap(err, "failed to write secret to target"))
+				continue
+			}
+			log.V(1).Info("Migrated secret", "oldId", secret.Id, "newId", written.Id)
+			report.Mapping[secret.Id] = written.Id
+		}
+	}
+	return nil
+}
+
+// listSecrets returns the names of all secrets of the scope by requesting all pages
+func (m *Migrator) listSecrets(ctx context.Context, scope backend.Scope) ([]string, error) {
+	names := []string{}
+	req := controller.ListSecretsRequest{
+		Env:   scope.Env,
+		Team:  scope.Team,
+		App:   scope.App,
+		Limit: controller.MaxListLimit,
+	}
+	for {
+		res, err := m.source.ListSecrets(ctx, req)
+		if backend.IsNotFoundErr(err) {
+			return names, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range res.Items {
+			names = append(names, item.Name)
+		}
+		if res.Next == "" {
+			return names, nil
+		}
+		req.Cursor = res.Next
+	}
+}
+
+// sortScopes returns the scopes in the order they must be onboarded,
+// i.e. environments before their teams and teams before their applications.
+// The parents of all scopes are added if they have not been listed.
+func sortScopes(scopes []backend.Scope) []backend.Scope {
+	seen := make(map[backend.Scope]bool, len(scopes))
+	sorted := make([]backend.Scope, 0, len(scopes))
+	var add func(scope backend.Scope)
+	add = func(scope backend.Scope) {
+		if seen[scope] {
+			return
+		}
+		seen[scope] = true
+		if scope.Team != "" {
+			add(parentOf(scope))
+		}
+		sorted = append(sorted, scope)
+	}
+	for _, scope := range scopes {
+		add(scope)
+	}
+
+	slices.SortFunc(sorted, func(a, b backend.Scope) int {
+		if depthOf(a) != depthOf(b) {
+			return depthOf(a) - depthOf(b)
+		}
+		return strings.Compare(a.String(), b.String())
+	})
+	return sorted
+}
+
+func parentOf(scope backend.Scope) backend.Scope {
+	if scope.App != "" {
+		return backend.Scope{Env: scope.Env, Team: scope.Team}
+	}
+	return backend.Scope{Env: scope.Env}
+}
+
+func depthOf(scope backend.Scope) int {
+	switch {
+	case scope.App != "":
+		return 2
+	case scope.Team != "":
+		return 1
+	default:
+		return 0
+	}
+}
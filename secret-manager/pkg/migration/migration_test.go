@@ -0,0 +1,212 @@
+package migration_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/backend"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/controller"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/migration"
+)
+
+// memoryController stores the secrets by their ID without checksum.
+// The checksum is the number of writes of the secret.
+type memoryController struct {
+	controller.Controller
+	prefix    string
+	values    map[string]string
+	versions  map[string]int
+	onboarded []backend.Scope
+	failOn    map[string]bool
+}
+
+func newMemoryController(prefix string) *memoryController {
+	return &memoryController{
+		prefix:   prefix,
+		values:   map[string]string{},
+		versions: map[string]int{},
+		failOn:   map[string]bool{},
+	}
+}
+
+func (c *memoryController) id(ref string) string {
+	return ref + c.prefix + strconv.Itoa(c.versions[ref])
+}
+
+func (c *memoryController) set(ref, value string) {
+	c.values[ref] = value
+	c.versions[ref]++
+}
+
+func (c *memoryController) ListScopes(_ context.Context) ([]backend.Scope, error) {
+	seen := map[backend.Scope]bool{}
+	scopes := []backend.Scope{}
+	for ref := range c.values {
+		parts := strings.Split(ref, backend.Separator)
+		scope := backend.Scope{Env: parts[0], Team: parts[1], App: parts[2]}
+		if !seen[scope] {
+			seen[scope] = true
+			scopes = append(scopes, scope)
+		}
+	}
+	return scopes, nil
+}
+
+func (c *memoryController) ListSecrets(_ context.Context, req controller.ListSecretsRequest) (controller.ListSecretsResponse, error) {
+	prefix := strings.Join([]string{req.Env, req.Team, req.App, ""}, backend.Separator)
+	names := []string{}
+	for ref := range c.values {
+		if name, ok := strings.CutPrefix(ref, prefix); ok {
+			names = append(names, strings.TrimSuffix(name, backend.Separator))
+		}
+	}
+	if len(names) == 0 {
+		return controller.ListSecretsResponse{}, backend.ErrNotFound()
+	}
+	slices.Sort(names)
+	res := controller.ListSecretsResponse{}
+	for _, name := range names {
+		res.Items = append(res.Items, controller.SecretRefResponse{Name: name, Id: prefix + name + backend.Separator})
+	}
+	return res, nil
+}
+
+func (c *memoryController) GetSecret(_ context.Context, rawId string) (controller.SecretResponse, error) {
+	value, ok := c.values[rawId]
+	if !ok {
+		return controller.SecretResponse{}, backend.ErrSecretNotFound(nil)
+	}
+	return controller.SecretResponse{Id: c.id(rawId), Value: value}, nil
+}
+
+func (c *memoryController) SetSecret(_ context.Context, rawId, value string) (controller.SecretResponse, error) {
+	if c.failOn[rawId] {
+		return controller.SecretResponse{}, backend.NewBackendError(nil, errors.New("unavailable"), "InternalError")
+	}
+	if c.values[rawId] != value {
+		c.set(rawId, value)
+	}
+	return controller.SecretResponse{Id: c.id(rawId), Value: value}, nil
+}
+
+func (c *memoryController) onboard(scope backend.Scope, names ...string) (controller.OnboardResponse, error) {
+	if c.failOn[scope.String()] {
+		return controller.OnboardResponse{}, backend.NewBackendError(nil, errors.New("unavailable"), "InternalError")
+	}
+	c.onboarded = append(c.onboarded, scope)
+	for _, name := range names {
+		ref := strings.Join([]string{scope.Env, scope.Team, scope.App, name, ""}, backend.Separator)
+		if _, ok := c.values[ref]; !ok {
+			c.set(ref, "initial")
+		}
+	}
+	return controller.OnboardResponse{}, nil
+}
+
+func (c *memoryController) OnboardEnvironment(_ context.Context, env string) (controller.OnboardResponse, error) {
+	return c.onboard(backend.Scope{Env: env}, "zones")
+}
+
+func (c *memoryController) OnboardTeam(_ context.Context, env, team string) (controller.OnboardResponse, error) {
+	return c.onboard(backend.Scope{Env: env, Team: team}, "clientSecret")
+}
+
+func (c *memoryController) OnboardApplication(_ context.Context, env, team, app string) (controller.OnboardResponse, error) {
+	return c.onboard(backend.Scope{Env: env, Team: team, App: app}, "clientSecret")
+}
+
+var _ = Describe("Migrator", func() {
+
+	var ctx context.Context
+	var source, target *memoryController
+	var migrator *migration.Migrator
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		source = newMemoryController("s")
+		target = newMemoryController("t")
+		migrator = migration.NewMigrator(source, source, target)
+
+		source.set("test:::zones:", "zone-a")
+		source.set("test:my-team::clientSecret:", "team-secret")
+		source.set("test:my-team:my-app:clientSecret:", "app-secret")
+		source.set("test:my-team:my-app:externalSecrets:", `{"foo":"bar"}`)
+	})
+
+	It("should copy all scopes and secrets", func() {
+		report, err := migrator.Run(ctx, migration.ModeMigrate)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(report.Failed()).To(BeFalse())
+
+		Expect(target.onboarded).To(Equal([]backend.Scope{
+			{Env: "test"},
+			{Env: "test", Team: "my-team"},
+			{Env: "test", Team: "my-team", App: "my-app"},
+		}))
+		Expect(target.values).To(Equal(source.values))
+		Expect(report.Mapping).To(Equal(map[string]string{
+			"test:::zones:s1":                        "test:::zones:t2",
+			"test:my-team::clientSecret:s1":          "test:my-team::clientSecret:t2",
+			"test:my-team:my-app:clientSecret:s1":    "test:my-team:my-app:clientSecret:t2",
+			"test:my-team:my-app:externalSecrets:s1": "test:my-team:my-app:externalSecrets:t1",
+		}))
+	})
+
+	It("should onboard the parents of the scopes", func() {
+		delete(source.values, "test:::zones:")
+		delete(source.values, "test:my-team::clientSecret:")
+
+		report, err := migrator.Run(ctx, migration.ModeMigrate)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(report.Failed()).To(BeFalse())
+		Expect(report.Scopes).To(HaveLen(3))
+		Expect(target.onboarded).To(HaveLen(3))
+	})
+
+	It("should not write anything in dry-run mode", func() {
+		report, err := migrator.Run(ctx, migration.ModeDryRun)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(report.Failed()).To(BeFalse())
+
+		Expect(target.onboarded).To(BeEmpty())
+		Expect(target.values).To(BeEmpty())
+		Expect(report.Mapping).To(HaveKeyWithValue("test:my-team::clientSecret:s1", "test:my-team::clientSecret:"))
+	})
+
+	It("should report the secrets that differ", func() {
+		_, err := migrator.Run(ctx, migration.ModeMigrate)
+		Expect(err).ToNot(HaveOccurred())
+		target.set("test:my-team::clientSecret:", "changed")
+		delete(target.values, "test:::zones:")
+
+		report, err := migrator.Run(ctx, migration.ModeVerify)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(report.Failed()).To(BeTrue())
+		Expect(report.Mismatches).To(ConsistOf("test:my-team::clientSecret:s1"))
+		Expect(report.Errors).To(HaveKey("test:::zones:s1"))
+		Expect(report.Mapping).To(HaveKeyWithValue("test:my-team::clientSecret:s1", "test:my-team::clientSecret:t3"))
+	})
+
+	It("should continue if a scope or secret fails", func() {
+		target.failOn["test:my-team:"] = true
+		target.failOn["test:::zones:"] = true
+
+		report, err := migrator.Run(ctx, migration.ModeMigrate)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(report.Failed()).To(BeTrue())
+		Expect(report.Errors).To(HaveKey("test:::zones:s1"))
+		Expect(report.Errors).To(HaveKey("test:my-team:"))
+		Expect(report.Errors).To(HaveKeyWithValue("test:my-team:my-app", "parent scope has not been migrated"))
+		Expect(target.onboarded).To(Equal([]backend.Scope{{Env: "test"}}))
+	})
+
+	It("should reject an unknown mode", func() {
+		_, err := migrator.Run(ctx, migration.Mode("unknown"))
+		Expect(err).To(MatchError("unknown mode: unknown"))
+	})
+})
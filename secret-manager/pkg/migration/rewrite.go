@@ -0,0 +1,117 @@
+package migration
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/api"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/backend"
+)
+
+var refPattern = regexp.MustCompile(regexp.QuoteMeta(api.StartTag) + `([^` + regexp.QuoteMeta(api.EndTag) + `]+)` + regexp.QuoteMeta(api.EndTag))
+
+// Rewriter replaces the secret references, e.g. $<env:team:app:clientSecret:1234>, using the mapping of a migration.
+// References with an outdated checksum and references to a sub-path of a secret, e.g. externalSecrets/foo,
+// are rewritten to the new ID of the migrated secret as the checksum belongs to the secret and not to the path.
+type Rewriter struct {
+	mapping map[string]string
+	// latest maps the old IDs without checksum to the new IDs
+	latest map[string]string
+}
+
+func NewRewriter(mapping map[string]string) *Rewriter {
+	r := &Rewriter{
+		mapping: mapping,
+		latest:  make(map[string]string, len(mapping)),
+	}
+	for oldId, newId := range mapping {
+		r.latest[withoutChecksum(oldId)] = newId
+	}
+	return r
+}
+
+// Rewrite replaces all references in the string.
+// It returns false if nothing has been replaced.
+func (r *Rewriter) Rewrite(s string) (string, bool) {
+	changed := false
+	res := refPattern.ReplaceAllStringFunc(s, func(ref string) string {
+		oldId, _ := api.FromRef(ref)
+		newId, ok := r.lookup(oldId)
+		if !ok || newId == oldId {
+			return ref
+		}
+		changed = true
+		return api.ToRef(newId)
+	})
+	return res, changed
+}
+
+// RewriteObject replaces the references in all strings of the object, e.g. the content of an unstructured object.
+// It returns false if nothing has been replaced.
+func (r *Rewriter) RewriteObject(obj map[string]any) bool {
+	changed := false
+	for key, value := range obj {
+		if newValue, ok := r.rewriteValue(value); ok {
+			obj[key] = newValue
+			changed = true
+		}
+	}
+	return changed
+}
+
+func (r *Rewriter) rewriteValue(value any) (any, bool) {
+	switch v := value.(type) {
+	case string:
+		return r.Rewrite(v)
+	case map[string]any:
+		return v, r.RewriteObject(v)
+	case []any:
+		changed := false
+		for i, item := range v {
+			if newItem, ok := r.rewriteValue(item); ok {
+				v[i] = newItem
+				changed = true
+			}
+		}
+		return v, changed
+	default:
+		return value, false
+	}
+}
+
+func (r *Rewriter) lookup(oldId string) (string, bool) {
+	if newId, ok := r.mapping[oldId]; ok {
+		return newId, true
+	}
+	parts := strings.Split(oldId, backend.Separator)
+	if len(parts) != 5 {
+		return "", false
+	}
+	if newId, ok := r.latest[withoutChecksum(oldId)]; ok {
+		return newId, true
+	}
+
+	// sub-paths are resolved using the secret that contains them
+	name, subPath, ok := strings.Cut(parts[3], "/")
+	if !ok {
+		return "", false
+	}
+	parts[3] = name
+	newId, ok := r.latest[withoutChecksum(strings.Join(parts, backend.Separator))]
+	if !ok {
+		return "", false
+	}
+	newParts := strings.Split(newId, backend.Separator)
+	if len(newParts) != 5 {
+		return "", false
+	}
+	newParts[3] += "/" + subPath
+	return strings.Join(newParts, backend.Separator), true
+}
+
+func withoutChecksum(id string) string {
+	if i := strings.LastIndex(id, backend.Separator); i >= 0 {
+		return id[:i+1]
+	}
+	return id
+}
@@ -0,0 +1,66 @@
+package migration_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/migration"
+)
+
+var _ = Describe("Rewriter", func() {
+
+	var rewriter *migration.Rewriter
+
+	BeforeEach(func() {
+		rewriter = migration.NewRewriter(map[string]string{
+			"test:my-team::clientSecret:123":         "test:my-team::clientSecret:v2",
+			"test:my-team:my-app:externalSecrets:45": "test:my-team:my-app:externalSecrets:v1",
+		})
+	})
+
+	It("should rewrite the references of the mapping", func() {
+		res, ok := rewriter.Rewrite("$<test:my-team::clientSecret:123>")
+		Expect(ok).To(BeTrue())
+		Expect(res).To(Equal("$<test:my-team::clientSecret:v2>"))
+	})
+
+	It("should rewrite references with a different checksum", func() {
+		res, ok := rewriter.Rewrite("$<test:my-team::clientSecret:100>")
+		Expect(ok).To(BeTrue())
+		Expect(res).To(Equal("$<test:my-team::clientSecret:v2>"))
+	})
+
+	It("should rewrite references to a sub-path", func() {
+		res, ok := rewriter.Rewrite("$<test:my-team:my-app:externalSecrets/foo:45>")
+		Expect(ok).To(BeTrue())
+		Expect(res).To(Equal("$<test:my-team:my-app:externalSecrets/foo:v1>"))
+	})
+
+	It("should rewrite all references in a string", func() {
+		res, ok := rewriter.Rewrite("user:$<test:my-team::clientSecret:123>@$<unknown:::foo:1>")
+		Expect(ok).To(BeTrue())
+		Expect(res).To(Equal("user:$<test:my-team::clientSecret:v2>@$<unknown:::foo:1>"))
+	})
+
+	It("should keep unknown references", func() {
+		res, ok := rewriter.Rewrite("$<test:other-team::clientSecret:123>")
+		Expect(ok).To(BeFalse())
+		Expect(res).To(Equal("$<test:other-team::clientSecret:123>"))
+	})
+
+	It("should rewrite nested objects", func() {
+		obj := map[string]any{
+			"clientSecret": "$<test:my-team::clientSecret:123>",
+			"replicas":     int64(1),
+			"backends": []any{
+				map[string]any{"password": "$<test:my-team:my-app:externalSecrets/db:45>"},
+				"plain",
+			},
+		}
+		Expect(rewriter.RewriteObject(obj)).To(BeTrue())
+		Expect(obj["clientSecret"]).To(Equal("$<test:my-team::clientSecret:v2>"))
+		Expect(obj["backends"].([]any)[0]).To(HaveKeyWithValue("password", "$<test:my-team:my-app:externalSecrets/db:v1>"))
+		Expect(obj["backends"].([]any)[1]).To(Equal("plain"))
+
+		Expect(rewriter.RewriteObject(obj)).To(BeFalse())
+	})
+})
@@ -0,0 +1,83 @@
+package policy
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/go-logr/logr"
+	"github.com/telekom/controlplane-mono/common-server/pkg/problems"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/backend"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/middleware"
+)
+
+// Authorizer checks the requests of the authenticated callers against the current policy
+type Authorizer struct {
+	store  *Store
+	labels LabelResolver
+}
+
+// NewAuthorizer creates an authorizer. The label resolver is only needed if the policy depends on labels.
+// Without it, deny rules that depend on labels apply to all callers.
+func NewAuthorizer(store *Store, labels LabelResolver) *Authorizer {
+	return &Authorizer{store: store, labels: labels}
+}
+
+// SubjectFromContext returns the caller from the identity of the authenticated request
+func SubjectFromContext(ctx context.Context) (Subject, bool) {
+	identity, ok := middleware.IdentityFromContext(ctx)
+	if !ok {
+		return Subject{}, false
+	}
+	return Subject{
+		ServiceAccount: identity.ServiceAccountName,
+		Namespace:      identity.Namespace,
+		Pod:            identity.Pod,
+	}, true
+}
+
+// Authorize returns a forbidden error if the caller may not execute the operation on the secret.
+// Requests without an authenticated caller are not checked, e.g. if security is disabled.
+func (a *Authorizer) Authorize(ctx context.Context, op Operation, secretId string) error {
+	if !a.Filter(ctx, op)(secretId) {
+		return problems.Forbidden("Access denied", fmt.Sprintf("Operation %s on %s is not allowed", op, secretId))
+	}
+	return nil
+}
+
+// Filter returns a function that checks if the caller may execute the operation on a secret, e.g. to filter lists
+func (a *Authorizer) Filter(ctx context.Context, op Operation) func(secretId string) bool {
+	subject, ok := SubjectFromContext(ctx)
+	if !ok {
+		return func(string) bool { return true }
+	}
+	log := logr.FromContextOrDiscard(ctx)
+	p := a.store.Get()
+	if p.UsesLabels() {
+		// Callers without a pod, e.g. authenticated by OIDC or mTLS, have no labels
+		switch {
+		case a.labels == nil || subject.Pod == "":
+			subject.LabelsUnresolved = true
+		default:
+			labels, err := a.labels.Labels(ctx, subject)
+			if err != nil {
+				log.Error(err, "Failed to resolve labels of the caller")
+				subject.LabelsUnresolved = true
+			}
+			subject.Labels = labels
+		}
+	}
+
+	return func(secretId string) bool {
+		decision := p.Evaluate(subject, op, secretId)
+		if !decision.Allowed {
+			log.V(1).Info("Denied by policy", "operation", op, "secretId", secretId, "rule", decision.Rule)
+		}
+		return decision.Allowed
+	}
+}
+
+// ScopeId returns the ID of an environment, team or application that is matched against the secret patterns
+func ScopeId(env, team, app string) string {
+	return strings.Join([]string{env, team, app, "", ""}, backend.Separator)
+}
@@ -0,0 +1,140 @@
+package policy
+
+import (
+	"context"
+	"strings"
+
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/api"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/backend"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/controller"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/generator"
+)
+
+var _ controller.Controller = &authorizingController{}
+
+// authorizingController checks each call against the policy before it is passed on
+type authorizingController struct {
+	controller.Controller
+	authorizer *Authorizer
+}
+
+// NewController wraps the controller to enforce the policy of the authorizer
+func NewController(c controller.Controller, a *Authorizer) controller.Controller {
+	return &authorizingController{Controller: c, authorizer: a}
+}
+
+func (c *authorizingController) GetSecret(ctx context.Context, rawId string) (controller.SecretResponse, error) {
+	if err := c.authorizer.Authorize(ctx, OperationRead, rawId); err != nil {
+		return controller.SecretResponse{}, err
+	}
+	return c.Controller.GetSecret(ctx, rawId)
+}
+
+func (c *authorizingController) GetPublicKey(ctx context.Context, rawId string) (controller.PublicKeyResponse, error) {
+	if err := c.authorizer.Authorize(ctx, OperationReadPublic, rawId); err != nil {
+		return controller.PublicKeyResponse{}, err
+	}
+	return c.Controller.GetPublicKey(ctx, rawId)
+}
+
+func (c *authorizingController) ListSecretVersions(ctx context.Context, rawId string) ([]controller.SecretVersionResponse, error) {
+	if err := c.authorizer.Authorize(ctx, OperationRead, rawId); err != nil {
+		return nil, err
+	}
+	return c.Controller.ListSecretVersions(ctx, rawId)
+}
+
+func (c *authorizingController) ListSecrets(ctx context.Context, req controller.ListSecretsRequest) (controller.ListSecretsResponse, error) {
+	allowed := c.authorizer.Filter(ctx, OperationRead)
+	filter := req.Filter
+	prefix := strings.Join([]string{req.Env, req.Team, req.App}, backend.Separator) + backend.Separator
+	req.Filter = func(name string) bool {
+		if filter != nil && !filter(name) {
+			return false
+		}
+		return allowed(prefix + name + backend.Separator)
+	}
+	return c.Controller.ListSecrets(ctx, req)
+}
+
+func (c *authorizingController) SetSecret(ctx context.Context, rawId, value string) (controller.SecretResponse, error) {
+	op := OperationWrite
+	if value == api.KeywordRotate {
+		op = OperationRotate
+	}
+	if err := c.authorizer.Authorize(ctx, op, rawId); err != nil {
+		return controller.SecretResponse{}, err
+	}
+	return c.Controller.SetSecret(ctx, rawId, value)
+}
+
+// GenerateSecret requires write as it may change the kind of the secret.
+// Certificates that are signed by the CA also require sign.
+func (c *authorizingController) GenerateSecret(ctx context.Context, rawId string, cfg generator.Config) (controller.SecretResponse, error) {
+	if err := c.authorizer.Authorize(ctx, OperationWrite, rawId); err != nil {
+		return controller.SecretResponse{}, err
+	}
+	if cfg.Type == generator.TypeCertificate && cfg.Issuer == generator.IssuerCA {
+		if err := c.authorizer.Authorize(ctx, OperationSign, rawId); err != nil {
+			return controller.SecretResponse{}, err
+		}
+	}
+	return c.Controller.GenerateSecret(ctx, rawId, cfg)
+}
+
+func (c *authorizingController) DeleteSecret(ctx context.Context, rawId string) error {
+	if err := c.authorizer.Authorize(ctx, OperationDelete, rawId); err != nil {
+		return err
+	}
+	return c.Controller.DeleteSecret(ctx, rawId)
+}
+
+// UndeleteSecret requires the same permission as deleting the secret
+func (c *authorizingController) UndeleteSecret(ctx context.Context, rawId string) (controller.SecretResponse, error) {
+	if err := c.authorizer.Authorize(ctx, OperationDelete, rawId); err != nil {
+		return controller.SecretResponse{}, err
+	}
+	return c.Controller.UndeleteSecret(ctx, rawId)
+}
+
+func (c *authorizingController) OnboardEnvironment(ctx context.Context, envId string) (controller.OnboardResponse, error) {
+	if err := c.authorizer.Authorize(ctx, OperationOnboard, ScopeId(envId, "", "")); err != nil {
+		return controller.OnboardResponse{}, err
+	}
+	return c.Controller.OnboardEnvironment(ctx, envId)
+}
+
+func (c *authorizingController) OnboardTeam(ctx context.Context, envId, teamId string) (controller.OnboardResponse, error) {
+	if err := c.authorizer.Authorize(ctx, OperationOnboard, ScopeId(envId, teamId, "")); err != nil {
+		return controller.OnboardResponse{}, err
+	}
+	return c.Controller.OnboardTeam(ctx, envId, teamId)
+}
+
+func (c *authorizingController) OnboardApplication(ctx context.Context, envId, teamId, appId string) (controller.OnboardResponse, error) {
+	if err := c.authorizer.Authorize(ctx, OperationOnboard, ScopeId(envId, teamId, appId)); err != nil {
+		return controller.OnboardResponse{}, err
+	}
+	return c.Controller.OnboardApplication(ctx, envId, teamId, appId)
+}
+
+func (c *authorizingController) DeleteEnvironment(ctx context.Context, envId string) error {
+	if err := c.authorizer.Authorize(ctx, OperationOnboard, ScopeId(envId, "", "")); err != nil {
+		return err
+	}
+	return c.Controller.DeleteEnvironment(ctx, envId)
+}
+
+func (c *authorizingController) DeleteTeam(ctx context.Context, envId, teamId string) error {
+	if err := c.authorizer.Authorize(ctx, OperationOnboard, ScopeId(envId, teamId, "")); err != nil {
+		return err
+	}
+	return c.Controller.DeleteTeam(ctx, envId, teamId)
+}
+
+func (c *authorizingController) DeleteApplication(ctx context.Context, envId, teamId, appId string) error {
+	if err := c.authorizer.Authorize(ctx, OperationOnboard, ScopeId(envId, teamId, appId)); err != nil {
+		return err
+	}
+	return c.Controller.DeleteApplication(ctx, envId, teamId, appId)
+}
@@ -0,0 +1,197 @@
+package policy_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/telekom/controlplane-mono/common-server/pkg/problems"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/api"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/controller"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/generator"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/middleware"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/policy"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+type fakeController struct {
+	controller.Controller
+	calls int
+}
+
+func (c *fakeController) GetSecret(_ context.Context, rawId string) (controller.SecretResponse, error) {
+	c.calls++
+	return controller.SecretResponse{Id: rawId, Value: "value"}, nil
+}
+
+func (c *fakeController) SetSecret(_ context.Context, rawId, _ string) (controller.SecretResponse, error) {
+	c.calls++
+	return controller.SecretResponse{Id: rawId}, nil
+}
+
+func (c *fakeController) ListSecrets(_ context.Context, req controller.ListSecretsRequest) (controller.ListSecretsResponse, error) {
+	c.calls++
+	res := controller.ListSecretsResponse{}
+	for _, name := range []string{"clientSecret", "teamToken", "externalSecrets/foo"} {
+		if req.Filter == nil || req.Filter(name) {
+			res.Items = append(res.Items, controller.SecretRefResponse{Name: name})
+		}
+	}
+	return res, nil
+}
+
+func (c *fakeController) GenerateSecret(_ context.Context, rawId string, _ generator.Config) (controller.SecretResponse, error) {
+	c.calls++
+	return controller.SecretResponse{Id: rawId}, nil
+}
+
+func (c *fakeController) OnboardTeam(_ context.Context, _, _ string) (controller.OnboardResponse, error) {
+	c.calls++
+	return controller.OnboardResponse{}, nil
+}
+
+func contextOf(sa, namespace, pod string) context.Context {
+	claims := &middleware.ServiceAccountTokenClaims{}
+	claims.Kubernetes.ServiceAccount.Name = sa
+	claims.Kubernetes.Namespace = namespace
+	claims.Kubernetes.Pod.Name = pod
+	return middleware.NewContextWithClaims(context.Background(), claims)
+}
+
+var _ = Describe("Controller", func() {
+
+	var fakeCtrl *fakeController
+	var ctrl controller.Controller
+	var ctx context.Context
+
+	BeforeEach(func() {
+		store, err := policy.NewStore(&policy.Policy{Rules: []policy.Rule{
+			{
+				Name:       "gateway-read",
+				Effect:     policy.EffectAllow,
+				Operations: []policy.Operation{policy.OperationRead},
+				Secrets:    []string{"prod:*:*:clientSecret:*", "prod:*:*:externalSecrets*:*"},
+				Conditions: policy.Conditions{Namespaces: []string{"gateway-system"}},
+			},
+			{
+				Name:       "rover-rotate",
+				Effect:     policy.EffectAllow,
+				Operations: []policy.Operation{policy.OperationRotate, policy.OperationOnboard},
+				Conditions: policy.Conditions{Labels: map[string]string{"app": "rover"}},
+			},
+		}})
+		Expect(err).ToNot(HaveOccurred())
+
+		k8sClient := fake.NewClientBuilder().WithObjects(&corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+			Name:      "rover-controller-manager-abc",
+			Namespace: "rover-system",
+			Labels:    map[string]string{"app": "rover"},
+		}}).Build()
+
+		fakeCtrl = &fakeController{}
+		ctrl = policy.NewController(fakeCtrl, policy.NewAuthorizer(store, policy.NewPodLabelResolver(k8sClient)))
+		ctx = contextOf("gateway-controller-manager", "gateway-system", "gateway-controller-manager-abc")
+	})
+
+	It("should pass on allowed calls", func() {
+		res, err := ctrl.GetSecret(ctx, "prod:team:app:clientSecret:v1")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(res.Value).To(Equal("value"))
+	})
+
+	It("should deny calls that are not allowed", func() {
+		_, err := ctrl.GetSecret(ctx, "prod:team::teamToken:v1")
+		var problem problems.Problem
+		Expect(errors.As(err, &problem)).To(BeTrue())
+		Expect(problem.Code()).To(Equal(http.StatusForbidden))
+
+		_, err = ctrl.SetSecret(ctx, "prod:team:app:clientSecret:v1", "value")
+		Expect(err).To(HaveOccurred())
+		Expect(fakeCtrl.calls).To(BeZero())
+	})
+
+	It("should filter the listed secrets", func() {
+		res, err := ctrl.ListSecrets(ctx, controller.ListSecretsRequest{Env: "prod", Team: "team", App: "app"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(res.Items).To(ConsistOf(
+			controller.SecretRefResponse{Name: "clientSecret"},
+			controller.SecretRefResponse{Name: "externalSecrets/foo"},
+		))
+	})
+
+	It("should resolve the labels of the caller", func() {
+		ctx := contextOf("rover-controller-manager", "rover-system", "rover-controller-manager-abc")
+		_, err := ctrl.SetSecret(ctx, "prod:team::teamToken:v1", api.KeywordRotate)
+		Expect(err).ToNot(HaveOccurred())
+		_, err = ctrl.OnboardTeam(ctx, "prod", "team")
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = ctrl.SetSecret(ctx, "prod:team::teamToken:v1", "value")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should not apply rules with labels if they cannot be resolved", func() {
+		ctx := contextOf("rover-controller-manager", "rover-system", "unknown")
+		_, err := ctrl.OnboardTeam(ctx, "prod", "team")
+		Expect(err).To(MatchError(ContainSubstring("Access denied")))
+	})
+
+	It("should apply deny rules with labels if they cannot be resolved", func() {
+		store, err := policy.NewStore(&policy.Policy{Rules: []policy.Rule{
+			{Name: "all", Effect: policy.EffectAllow},
+			{Name: "untrusted", Effect: policy.EffectDeny, Conditions: policy.Conditions{Labels: map[string]string{"trust": "low"}}},
+		}})
+		Expect(err).ToNot(HaveOccurred())
+		k8sClient := fake.NewClientBuilder().WithObjects(&corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+			Name:      "rover-controller-manager-abc",
+			Namespace: "rover-system",
+			Labels:    map[string]string{"trust": "high"},
+		}}).Build()
+		ctrl = policy.NewController(fakeCtrl, policy.NewAuthorizer(store, policy.NewPodLabelResolver(k8sClient)))
+
+		_, err = ctrl.GetSecret(contextOf("rover-controller-manager", "rover-system", "rover-controller-manager-abc"), "prod:team::teamToken:v1")
+		Expect(err).ToNot(HaveOccurred())
+
+		// The pod does not exist
+		_, err = ctrl.GetSecret(contextOf("rover-controller-manager", "rover-system", "unknown"), "prod:team::teamToken:v1")
+		Expect(err).To(MatchError(ContainSubstring("Access denied")))
+
+		// The caller has no pod, e.g. if it has been authenticated by OIDC
+		noPod := middleware.NewContextWithIdentity(context.Background(), middleware.Identity{ServiceAccountName: "rover", Namespace: "rover-system", Method: middleware.MethodOIDC})
+		_, err = ctrl.GetSecret(noPod, "prod:team::teamToken:v1")
+		Expect(err).To(MatchError(ContainSubstring("Access denied")))
+
+		// No label resolver is configured
+		ctrl = policy.NewController(fakeCtrl, policy.NewAuthorizer(store, nil))
+		_, err = ctrl.GetSecret(contextOf("rover-controller-manager", "rover-system", "rover-controller-manager-abc"), "prod:team::teamToken:v1")
+		Expect(err).To(MatchError(ContainSubstring("Access denied")))
+	})
+
+	It("should require sign to generate certificates that are signed by the CA", func() {
+		store, err := policy.NewStore(&policy.Policy{Rules: []policy.Rule{
+			{Name: "write", Effect: policy.EffectAllow, Operations: []policy.Operation{policy.OperationWrite}},
+			{Name: "sign", Effect: policy.EffectAllow, Operations: []policy.Operation{policy.OperationSign}, Secrets: []string{"prod:team:app:*:*"}},
+		}})
+		Expect(err).ToNot(HaveOccurred())
+		ctrl = policy.NewController(fakeCtrl, policy.NewAuthorizer(store, nil))
+
+		selfSigned := generator.Config{Type: generator.TypeCertificate, CommonName: "app"}
+		_, err = ctrl.GenerateSecret(ctx, "prod:team:other:tls:", selfSigned)
+		Expect(err).ToNot(HaveOccurred())
+
+		signed := generator.Config{Type: generator.TypeCertificate, CommonName: "app", Issuer: generator.IssuerCA}
+		_, err = ctrl.GenerateSecret(ctx, "prod:team:app:tls:", signed)
+		Expect(err).ToNot(HaveOccurred())
+		_, err = ctrl.GenerateSecret(ctx, "prod:team:other:tls:", signed)
+		Expect(err).To(MatchError(ContainSubstring("Access denied")))
+	})
+
+	It("should not check unauthenticated calls", func() {
+		_, err := ctrl.GetSecret(context.Background(), "prod:team::teamToken:v1")
+		Expect(err).ToNot(HaveOccurred())
+	})
+})
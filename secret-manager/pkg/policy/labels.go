@@ -0,0 +1,75 @@
+package policy
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// DefaultLabelCacheDuration is the default duration that the labels of a pod are cached
+const DefaultLabelCacheDuration = time.Minute
+
+// LabelResolver returns the labels of the caller
+type LabelResolver interface {
+	Labels(ctx context.Context, subject Subject) (map[string]string, error)
+}
+
+var _ LabelResolver = &PodLabelResolver{}
+
+type cachedLabels struct {
+	labels  map[string]string
+	expires time.Time
+}
+
+// PodLabelResolver reads the labels of the pod of the caller from the Kubernetes API.
+// The labels are cached, so most requests do not need to call the API.
+type PodLabelResolver struct {
+	client        client.Reader
+	cacheDuration time.Duration
+
+	mu    sync.Mutex
+	cache map[string]cachedLabels
+}
+
+func NewPodLabelResolver(c client.Reader) *PodLabelResolver {
+	return &PodLabelResolver{
+		client:        c,
+		cacheDuration: DefaultLabelCacheDuration,
+		cache:         map[string]cachedLabels{},
+	}
+}
+
+func (r *PodLabelResolver) Labels(ctx context.Context, subject Subject) (map[string]string, error) {
+	if subject.Pod == "" {
+		return nil, nil
+	}
+	key := subject.Namespace + "/" + subject.Pod
+	now := time.Now()
+
+	r.mu.Lock()
+	cached, ok := r.cache[key]
+	r.mu.Unlock()
+	if ok && now.Before(cached.expires) {
+		return cached.labels, nil
+	}
+
+	pod := &corev1.Pod{}
+	if err := r.client.Get(ctx, client.ObjectKey{Namespace: subject.Namespace, Name: subject.Pod}, pod); err != nil {
+		return nil, errors.Wrapf(err, "failed to get pod %s", key)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	// Remove the expired entries, so the cache does not grow with every restarted pod
+	for k, v := range r.cache {
+		if now.After(v.expires) {
+			delete(r.cache, k)
+		}
+	}
+	r.cache[key] = cachedLabels{labels: pod.Labels, expires: now.Add(r.cacheDuration)}
+	return pod.Labels, nil
+}
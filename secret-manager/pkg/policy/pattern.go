@@ -0,0 +1,129 @@
+package policy
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+var variableRegex = regexp.MustCompile(`\$\{([^}]+)\}`)
+
+// pattern is a glob pattern that is matched against secret IDs like `env:team:app:path:checksum`.
+// `*` matches any characters except the separator `:`, `**` matches any characters and `?` matches a single character.
+// Variables like `${namespace}` are replaced by the attributes of the caller before matching.
+type pattern struct {
+	raw string
+	// regex is only set if the pattern has no variables
+	regex *regexp.Regexp
+}
+
+func newPattern(raw string) (*pattern, error) {
+	p := &pattern{raw: raw}
+	for _, match := range variableRegex.FindAllStringSubmatch(raw, -1) {
+		if !isValidVariable(match[1]) {
+			return nil, errors.Errorf("unknown variable %s in pattern %q", match[0], raw)
+		}
+	}
+	if !variableRegex.MatchString(raw) {
+		regex, err := compileGlob(raw)
+		if err != nil {
+			return nil, err
+		}
+		p.regex = regex
+	}
+	return p, nil
+}
+
+func isValidVariable(name string) bool {
+	return name == "namespace" || name == "serviceAccount" || isLabelVariable(name)
+}
+
+func isLabelVariable(name string) bool {
+	return strings.HasPrefix(name, "labels.")
+}
+
+// Matches checks if the value matches the pattern after its variables have been replaced.
+// It never matches if a variable cannot be resolved, e.g. if the caller does not have the label.
+func (p *pattern) Matches(value string, subject Subject) bool {
+	return p.matches(value, subject, false)
+}
+
+// MatchesAnyLabels checks if the value matches the pattern for any labels of the caller, e.g. if they are unknown.
+// Label values never contain the separator, so the label variables match like `*`.
+func (p *pattern) MatchesAnyLabels(value string, subject Subject) bool {
+	return p.matches(value, subject, true)
+}
+
+func (p *pattern) matches(value string, subject Subject, anyLabels bool) bool {
+	regex := p.regex
+	if regex == nil {
+		resolved, ok := resolveVariables(p.raw, subject, anyLabels)
+		if !ok {
+			return false
+		}
+		var err error
+		if regex, err = compileGlob(resolved); err != nil {
+			return false
+		}
+	}
+	return regex.MatchString(value)
+}
+
+func resolveVariables(raw string, subject Subject, anyLabels bool) (string, bool) {
+	ok := true
+	resolved := variableRegex.ReplaceAllStringFunc(raw, func(variable string) string {
+		var value string
+		switch name := variable[2 : len(variable)-1]; {
+		case name == "namespace":
+			value = subject.Namespace
+		case name == "serviceAccount":
+			value = subject.ServiceAccount
+		case anyLabels:
+			return "*"
+		default:
+			value = subject.Labels[strings.TrimPrefix(name, "labels.")]
+		}
+		if value == "" {
+			ok = false
+		}
+		// The value must never act as a pattern itself
+		return escapeGlob(value)
+	})
+	return resolved, ok
+}
+
+func escapeGlob(value string) string {
+	return strings.NewReplacer(`\`, `\\`, "*", `\*`, "?", `\?`).Replace(value)
+}
+
+func compileGlob(glob string) (*regexp.Regexp, error) {
+	var b strings.Builder
+	b.WriteString("^")
+	for i := 0; i < len(glob); i++ {
+		switch glob[i] {
+		case '\\':
+			if i+1 < len(glob) {
+				i++
+				b.WriteString(regexp.QuoteMeta(glob[i : i+1]))
+			}
+		case '*':
+			if i+1 < len(glob) && glob[i+1] == '*' {
+				i++
+				b.WriteString(".*")
+			} else {
+				b.WriteString("[^:]*")
+			}
+		case '?':
+			b.WriteString("[^:]")
+		default:
+			b.WriteString(regexp.QuoteMeta(glob[i : i+1]))
+		}
+	}
+	b.WriteString("$")
+	regex, err := regexp.Compile(b.String())
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid pattern %q", glob)
+	}
+	return regex, nil
+}
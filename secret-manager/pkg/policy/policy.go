@@ -0,0 +1,216 @@
+package policy
+
+import (
+	"slices"
+
+	"github.com/pkg/errors"
+)
+
+type Operation string
+
+const (
+	// OperationRead covers getting and listing secrets and their versions as well as watching them
+	OperationRead Operation = "read"
+	// OperationReadPublic covers getting the public key or certificates of a secret, see generator.PublicKey
+	OperationReadPublic Operation = "read_public"
+	OperationWrite      Operation = "write"
+	OperationRotate     Operation = "rotate"
+	// OperationSign covers generating certificates that are signed by the CA of the server.
+	// It is required in addition to write and is not implied by it.
+	OperationSign   Operation = "sign"
+	OperationDelete Operation = "delete"
+	// OperationOnboard covers creating and deleting environments, teams and applications
+	OperationOnboard Operation = "onboard"
+)
+
+type Effect string
+
+const (
+	EffectAllow Effect = "allow"
+	EffectDeny  Effect = "deny"
+)
+
+// Subject is the caller that is authorized
+type Subject struct {
+	ServiceAccount string
+	Namespace      string
+	Pod            string
+	// Labels are the labels of the pod of the caller
+	Labels map[string]string
+	// LabelsUnresolved is set if the labels of the caller are unknown, e.g. if it has no pod or the API is not reachable.
+	// Deny rules that depend on the labels then apply, allow rules do not.
+	LabelsUnresolved bool
+}
+
+// Conditions restrict the callers that a rule applies to. All conditions must be met.
+type Conditions struct {
+	// ServiceAccounts are glob patterns of the names of the service accounts
+	ServiceAccounts []string `yaml:"service_accounts" json:"service_accounts"`
+	// Namespaces are glob patterns of the namespaces of the service accounts
+	Namespaces []string `yaml:"namespaces" json:"namespaces"`
+	// Labels must all be set on the pod of the caller
+	Labels map[string]string `yaml:"labels" json:"labels"`
+}
+
+type Rule struct {
+	Name   string `yaml:"name" json:"name"`
+	Effect Effect `yaml:"effect" json:"effect"`
+	// Operations that the rule applies to. If empty, it applies to all operations.
+	// Rules for write also apply to rotate and rules for read also apply to read_public.
+	Operations []Operation `yaml:"operations" json:"operations"`
+	// Secrets are glob patterns of the secret IDs, e.g. `prod:*:*:clientSecret:*`.
+	// For onboarding, the ID of the environment, team or application is used, e.g. `prod:my-team:::`.
+	// If empty, the rule applies to all secrets.
+	Secrets    []string   `yaml:"secrets" json:"secrets"`
+	Conditions Conditions `yaml:"conditions" json:"conditions"`
+
+	secrets         []*pattern
+	serviceAccounts []*pattern
+	namespaces      []*pattern
+}
+
+// usesLabels checks if the rule depends on the labels of the caller
+func (r *Rule) usesLabels() bool {
+	if len(r.Conditions.Labels) > 0 {
+		return true
+	}
+	for _, secret := range r.Secrets {
+		for _, match := range variableRegex.FindAllStringSubmatch(secret, -1) {
+			if isLabelVariable(match[1]) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Policy is a list of rules. Access is granted if at least one rule allows it and no rule denies it.
+type Policy struct {
+	Rules []Rule `yaml:"rules" json:"rules"`
+}
+
+// Decision is the result of the evaluation of a policy
+type Decision struct {
+	Allowed bool
+	// Rule is the name of the rule that denied or allowed the access.
+	// It is empty if no rule applies.
+	Rule string
+}
+
+// Compile validates the policy and prepares it for evaluation
+func (p *Policy) Compile() error {
+	for i := range p.Rules {
+		if err := p.Rules[i].compile(); err != nil {
+			return errors.Wrapf(err, "invalid rule %d (%s)", i, p.Rules[i].Name)
+		}
+	}
+	return nil
+}
+
+// Evaluate decides if the subject may execute the operation on the secret.
+// The policy must have been compiled.
+func (p *Policy) Evaluate(subject Subject, op Operation, secretId string) Decision {
+	decision := Decision{}
+	for i := range p.Rules {
+		rule := &p.Rules[i]
+		if !rule.applies(subject, op, secretId) {
+			continue
+		}
+		if rule.Effect == EffectDeny {
+			return Decision{Allowed: false, Rule: rule.Name}
+		}
+		if !decision.Allowed {
+			decision = Decision{Allowed: true, Rule: rule.Name}
+		}
+	}
+	return decision
+}
+
+// UsesLabels checks if any rule depends on the labels of the caller
+func (p *Policy) UsesLabels() bool {
+	for i := range p.Rules {
+		if p.Rules[i].usesLabels() {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Rule) compile() (err error) {
+	if r.Effect != EffectAllow && r.Effect != EffectDeny {
+		return errors.Errorf("effect must be %s or %s", EffectAllow, EffectDeny)
+	}
+	for _, op := range r.Operations {
+		if !slices.Contains([]Operation{OperationRead, OperationReadPublic, OperationWrite, OperationRotate, OperationSign, OperationDelete, OperationOnboard}, op) {
+			return errors.Errorf("unknown operation %s", op)
+		}
+	}
+	if r.secrets, err = compilePatterns(r.Secrets); err != nil {
+		return err
+	}
+	if r.serviceAccounts, err = compilePatterns(r.Conditions.ServiceAccounts); err != nil {
+		return err
+	}
+	if r.namespaces, err = compilePatterns(r.Conditions.Namespaces); err != nil {
+		return err
+	}
+	return nil
+}
+
+func compilePatterns(raw []string) ([]*pattern, error) {
+	patterns := make([]*pattern, 0, len(raw))
+	for _, r := range raw {
+		p, err := newPattern(r)
+		if err != nil {
+			return nil, err
+		}
+		patterns = append(patterns, p)
+	}
+	return patterns, nil
+}
+
+// impliedBy contains the operations that are covered by the rules of another operation
+var impliedBy = map[Operation]Operation{
+	OperationRotate:     OperationWrite,
+	OperationReadPublic: OperationRead,
+}
+
+func (r *Rule) applies(subject Subject, op Operation, secretId string) bool {
+	if len(r.Operations) > 0 && !slices.Contains(r.Operations, op) && !slices.Contains(r.Operations, impliedBy[op]) {
+		return false
+	}
+	if len(r.serviceAccounts) > 0 && !matchesAny(r.serviceAccounts, subject.ServiceAccount, subject) {
+		return false
+	}
+	if len(r.namespaces) > 0 && !matchesAny(r.namespaces, subject.Namespace, subject) {
+		return false
+	}
+	// Fail closed: if the labels are unknown, deny rules apply as if the caller had the labels they depend on
+	if subject.LabelsUnresolved && r.Effect == EffectDeny {
+		return len(r.secrets) == 0 || matchesAnyLabels(r.secrets, secretId, subject)
+	}
+	for key, value := range r.Conditions.Labels {
+		if actual, ok := subject.Labels[key]; !ok || actual != value {
+			return false
+		}
+	}
+	return len(r.secrets) == 0 || matchesAny(r.secrets, secretId, subject)
+}
+
+func matchesAny(patterns []*pattern, value string, subject Subject) bool {
+	for _, p := range patterns {
+		if p.Matches(value, subject) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesAnyLabels(patterns []*pattern, value string, subject Subject) bool {
+	for _, p := range patterns {
+		if p.MatchesAnyLabels(value, subject) {
+			return true
+		}
+	}
+	return false
+}
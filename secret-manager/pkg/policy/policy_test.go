@@ -0,0 +1,128 @@
+package policy_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/policy"
+)
+
+func compile(rules ...policy.Rule) *policy.Policy {
+	p := &policy.Policy{Rules: rules}
+	ExpectWithOffset(1, p.Compile()).To(Succeed())
+	return p
+}
+
+var _ = Describe("Policy", func() {
+
+	gateway := policy.Subject{ServiceAccount: "gateway-controller-manager", Namespace: "gateway-system"}
+
+	Context("Patterns", func() {
+
+		DescribeTable("should match secret IDs",
+			func(pattern, secretId string, expected bool) {
+				p := compile(policy.Rule{Name: "test", Effect: policy.EffectAllow, Secrets: []string{pattern}})
+				Expect(p.Evaluate(gateway, policy.OperationRead, secretId).Allowed).To(Equal(expected))
+			},
+			Entry("wildcard segments", "prod:*:*:clientSecret:*", "prod:team:app:clientSecret:v1", true),
+			Entry("empty segments", "prod:*:*:clientSecret:*", "prod:team::clientSecret:", true),
+			Entry("other environment", "prod:*:*:clientSecret:*", "dev:team:app:clientSecret:v1", false),
+			Entry("other secret", "prod:*:*:clientSecret:*", "prod:team:app:teamToken:v1", false),
+			Entry("no separator in wildcard", "prod:*:clientSecret:*", "prod:team:app:clientSecret:v1", false),
+			Entry("double wildcard", "prod:**", "prod:team:app:clientSecret:v1", true),
+			Entry("nested secrets", "prod:*:*:externalSecrets*:*", "prod:team:app:externalSecrets/foo:v1", true),
+			Entry("single character", "prod:team-?:*:*:*", "prod:team-a:app:clientSecret:v1", true),
+			Entry("special characters", "prod:team.a:*:*:*", "prod:teamXa:app:clientSecret:v1", false),
+		)
+
+		It("should replace the variables with the attributes of the caller", func() {
+			p := compile(policy.Rule{Name: "own-team", Effect: policy.EffectAllow, Secrets: []string{"*:${labels.team}:**"}})
+			subject := policy.Subject{Labels: map[string]string{"team": "hyperion"}}
+			Expect(p.Evaluate(subject, policy.OperationRead, "prod:hyperion:app:clientSecret:v1").Allowed).To(BeTrue())
+			Expect(p.Evaluate(subject, policy.OperationRead, "prod:other:app:clientSecret:v1").Allowed).To(BeFalse())
+			Expect(p.UsesLabels()).To(BeTrue())
+
+			// Missing labels never match
+			Expect(p.Evaluate(policy.Subject{}, policy.OperationRead, "prod::::").Allowed).To(BeFalse())
+		})
+
+		It("should not interpret the variables as patterns", func() {
+			p := compile(policy.Rule{Name: "own-namespace", Effect: policy.EffectAllow, Secrets: []string{"prod:${namespace}:*:*:*"}})
+			Expect(p.Evaluate(policy.Subject{Namespace: "*"}, policy.OperationRead, "prod:team:app:clientSecret:v1").Allowed).To(BeFalse())
+			Expect(p.Evaluate(policy.Subject{Namespace: "team"}, policy.OperationRead, "prod:team:app:clientSecret:v1").Allowed).To(BeTrue())
+			Expect(p.UsesLabels()).To(BeFalse())
+		})
+	})
+
+	Context("Rules", func() {
+
+		It("should deny if no rule allows the access", func() {
+			p := compile()
+			Expect(p.Evaluate(gateway, policy.OperationRead, "prod:team:app:clientSecret:v1")).To(Equal(policy.Decision{}))
+		})
+
+		It("should prefer deny over allow", func() {
+			p := compile(
+				policy.Rule{Name: "all", Effect: policy.EffectAllow},
+				policy.Rule{Name: "no-tokens", Effect: policy.EffectDeny, Secrets: []string{"*:*:*:teamToken:*"}},
+			)
+			Expect(p.Evaluate(gateway, policy.OperationRead, "prod:team::clientSecret:v1")).To(Equal(policy.Decision{Allowed: true, Rule: "all"}))
+			Expect(p.Evaluate(gateway, policy.OperationRead, "prod:team::teamToken:v1")).To(Equal(policy.Decision{Allowed: false, Rule: "no-tokens"}))
+		})
+
+		It("should only apply to the configured operations", func() {
+			p := compile(policy.Rule{Name: "write", Effect: policy.EffectAllow, Operations: []policy.Operation{policy.OperationWrite}})
+			Expect(p.Evaluate(gateway, policy.OperationWrite, "prod:team::clientSecret:v1").Allowed).To(BeTrue())
+			Expect(p.Evaluate(gateway, policy.OperationRotate, "prod:team::clientSecret:v1").Allowed).To(BeTrue())
+			Expect(p.Evaluate(gateway, policy.OperationRead, "prod:team::clientSecret:v1").Allowed).To(BeFalse())
+			Expect(p.Evaluate(gateway, policy.OperationDelete, "prod:team::clientSecret:v1").Allowed).To(BeFalse())
+			Expect(p.Evaluate(gateway, policy.OperationReadPublic, "prod:team::clientSecret:v1").Allowed).To(BeFalse())
+
+			p = compile(policy.Rule{Name: "read", Effect: policy.EffectAllow, Operations: []policy.Operation{policy.OperationRead}})
+			Expect(p.Evaluate(gateway, policy.OperationReadPublic, "prod:team::key:v1").Allowed).To(BeTrue())
+
+			p = compile(policy.Rule{Name: "read-public", Effect: policy.EffectAllow, Operations: []policy.Operation{policy.OperationReadPublic}})
+			Expect(p.Evaluate(gateway, policy.OperationReadPublic, "prod:team::key:v1").Allowed).To(BeTrue())
+			Expect(p.Evaluate(gateway, policy.OperationRead, "prod:team::key:v1").Allowed).To(BeFalse())
+		})
+
+		It("should only apply to callers that meet the conditions", func() {
+			p := compile(policy.Rule{Name: "gateway", Effect: policy.EffectAllow, Conditions: policy.Conditions{
+				ServiceAccounts: []string{"gateway-*"},
+				Namespaces:      []string{"gateway-system"},
+				Labels:          map[string]string{"app": "gateway"},
+			}})
+
+			Expect(p.Evaluate(gateway, policy.OperationRead, "prod:team::clientSecret:v1").Allowed).To(BeFalse())
+			gateway.Labels = map[string]string{"app": "gateway"}
+			Expect(p.Evaluate(gateway, policy.OperationRead, "prod:team::clientSecret:v1").Allowed).To(BeTrue())
+
+			other := gateway
+			other.Namespace = "rover-system"
+			Expect(p.Evaluate(other, policy.OperationRead, "prod:team::clientSecret:v1").Allowed).To(BeFalse())
+		})
+
+		It("should fail closed if the labels of the caller are unresolved", func() {
+			p := compile(
+				policy.Rule{Name: "all", Effect: policy.EffectAllow},
+				policy.Rule{Name: "untrusted", Effect: policy.EffectDeny, Secrets: []string{"prod:**"}, Conditions: policy.Conditions{
+					Labels: map[string]string{"trust": "low"},
+				}},
+				policy.Rule{Name: "other-team", Effect: policy.EffectDeny, Secrets: []string{"test:${labels.team}:**"}},
+			)
+			unresolved := policy.Subject{LabelsUnresolved: true}
+			Expect(p.Evaluate(unresolved, policy.OperationRead, "prod:team:app:clientSecret:v1")).To(Equal(policy.Decision{Allowed: false, Rule: "untrusted"}))
+			Expect(p.Evaluate(unresolved, policy.OperationRead, "test:team:app:clientSecret:v1")).To(Equal(policy.Decision{Allowed: false, Rule: "other-team"}))
+			Expect(p.Evaluate(unresolved, policy.OperationRead, "dev:team:app:clientSecret:v1").Allowed).To(BeTrue())
+
+			// Allow rules never apply to unresolved labels
+			p = compile(policy.Rule{Name: "gateway", Effect: policy.EffectAllow, Conditions: policy.Conditions{Labels: map[string]string{"app": "gateway"}}})
+			Expect(p.Evaluate(unresolved, policy.OperationRead, "prod:team:app:clientSecret:v1").Allowed).To(BeFalse())
+		})
+
+		It("should reject invalid rules", func() {
+			Expect((&policy.Policy{Rules: []policy.Rule{{Name: "a", Effect: "maybe"}}}).Compile()).To(MatchError(ContainSubstring("effect")))
+			Expect((&policy.Policy{Rules: []policy.Rule{{Name: "a", Effect: policy.EffectAllow, Operations: []policy.Operation{"list"}}}}).Compile()).To(MatchError(ContainSubstring("unknown operation")))
+			Expect((&policy.Policy{Rules: []policy.Rule{{Name: "a", Effect: policy.EffectAllow, Secrets: []string{"${pod}"}}}}).Compile()).To(MatchError(ContainSubstring("unknown variable")))
+		})
+	})
+})
@@ -0,0 +1,129 @@
+package policy
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultReloadInterval is the default interval in which the policy file is checked for changes
+const DefaultReloadInterval = 30 * time.Second
+
+// Store holds the current policy. It can be replaced at any time without restarting the server.
+type Store struct {
+	policy atomic.Pointer[Policy]
+}
+
+func NewStore(p *Policy) (*Store, error) {
+	s := &Store{}
+	if err := s.Set(p); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Get returns the current policy
+func (s *Store) Get() *Policy {
+	return s.policy.Load()
+}
+
+// Set compiles and replaces the current policy. If it is invalid, the current policy is kept.
+func (s *Store) Set(p *Policy) error {
+	if err := p.Compile(); err != nil {
+		return err
+	}
+	s.policy.Store(p)
+	return nil
+}
+
+// ReadPolicy reads a policy from a YAML or JSON file.
+// Unknown keys are rejected, as a misspelled condition like `namespace` would otherwise widen a rule silently.
+func ReadPolicy(data []byte) (*Policy, error) {
+	p := &Policy{}
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(p); err != nil && !errors.Is(err, io.EOF) {
+		return nil, errors.Wrap(err, "failed to decode policy")
+	}
+	return p, nil
+}
+
+// FileReloader reloads the policy whenever the file changes, e.g. if it is mounted from a ConfigMap
+type FileReloader struct {
+	store    *Store
+	filepath string
+	interval time.Duration
+	lastData []byte
+}
+
+func NewFileReloader(store *Store, filepath string) *FileReloader {
+	return &FileReloader{
+		store:    store,
+		filepath: filepath,
+		interval: DefaultReloadInterval,
+	}
+}
+
+func (r *FileReloader) WithInterval(interval time.Duration) *FileReloader {
+	r.interval = interval
+	return r
+}
+
+// Load reads the file and replaces the policy if the file has changed
+func (r *FileReloader) Load() (changed bool, err error) {
+	data, err := os.ReadFile(r.filepath)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to read policy file")
+	}
+	if r.lastData != nil && bytes.Equal(data, r.lastData) {
+		return false, nil
+	}
+	p, err := ReadPolicy(data)
+	if err != nil {
+		return false, err
+	}
+	if err := r.store.Set(p); err != nil {
+		return false, err
+	}
+	r.lastData = data
+	return true, nil
+}
+
+// Start loads the policy and keeps reloading it in the background until the context is done
+func (r *FileReloader) Start(ctx context.Context) error {
+	if _, err := r.Load(); err != nil {
+		return errors.Wrap(err, "failed to load policy")
+	}
+	go r.Watch(ctx)
+	return nil
+}
+
+func (r *FileReloader) Watch(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	log := logr.FromContextOrDiscard(ctx)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			changed, err := r.Load()
+			if err != nil {
+				log.Error(err, "failed to reload policy, keeping the current policy")
+				continue
+			}
+			if changed {
+				log.Info("policy reloaded", "rules", len(r.store.Get().Rules))
+			}
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package policy_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/policy"
+)
+
+const policyV1 = `
+rules:
+- name: read-client-secrets
+  effect: allow
+  operations: [read]
+  secrets: ["prod:*:*:clientSecret:*"]
+  conditions:
+    namespaces: [gateway-system]
+`
+
+const policyV2 = `
+rules:
+- name: read-all
+  effect: allow
+  operations: [read]
+`
+
+var _ = Describe("Store", func() {
+
+	var path string
+	var store *policy.Store
+	subject := policy.Subject{ServiceAccount: "gateway-controller-manager", Namespace: "gateway-system"}
+
+	BeforeEach(func() {
+		path = filepath.Join(GinkgoT().TempDir(), "policy.yaml")
+		Expect(os.WriteFile(path, []byte(policyV1), 0o600)).To(Succeed())
+
+		var err error
+		store, err = policy.NewStore(&policy.Policy{})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should load the policy from the file", func() {
+		changed, err := policy.NewFileReloader(store, path).Load()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(changed).To(BeTrue())
+
+		Expect(store.Get().Rules).To(HaveLen(1))
+		Expect(store.Get().Evaluate(subject, policy.OperationRead, "prod:team:app:clientSecret:v1").Allowed).To(BeTrue())
+	})
+
+	It("should only reload changed files", func() {
+		reloader := policy.NewFileReloader(store, path)
+		_, err := reloader.Load()
+		Expect(err).ToNot(HaveOccurred())
+
+		changed, err := reloader.Load()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(changed).To(BeFalse())
+	})
+
+	It("should keep the current policy if the new one is invalid", func() {
+		reloader := policy.NewFileReloader(store, path)
+		_, err := reloader.Load()
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(os.WriteFile(path, []byte("rules:\n- name: broken\n  effect: sometimes\n"), 0o600)).To(Succeed())
+		_, err = reloader.Load()
+		Expect(err).To(HaveOccurred())
+		Expect(store.Get().Rules[0].Name).To(Equal("read-client-secrets"))
+	})
+
+	It("should reject unknown keys", func() {
+		_, err := policy.ReadPolicy([]byte("rules:\n- name: read-all\n  effect: allow\n  operations: [read]\n  conditions:\n    namespace: [gateway-system]\n"))
+		Expect(err).To(MatchError(ContainSubstring("field namespace not found")))
+
+		reloader := policy.NewFileReloader(store, path)
+		_, err = reloader.Load()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(os.WriteFile(path, []byte("rules: []\nrule: []\n"), 0o600)).To(Succeed())
+		_, err = reloader.Load()
+		Expect(err).To(HaveOccurred())
+		Expect(store.Get().Rules[0].Name).To(Equal("read-client-secrets"))
+	})
+
+	It("should reload the policy in the background", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		Expect(policy.NewFileReloader(store, path).WithInterval(10 * time.Millisecond).Start(ctx)).To(Succeed())
+		Expect(store.Get().Evaluate(subject, policy.OperationRead, "prod:team:app:teamToken:v1").Allowed).To(BeFalse())
+
+		Expect(os.WriteFile(path, []byte(policyV2), 0o600)).To(Succeed())
+		Eventually(func() bool {
+			return store.Get().Evaluate(subject, policy.OperationRead, "prod:team:app:teamToken:v1").Allowed
+		}).Should(BeTrue())
+	})
+
+	It("should fail to start without a valid file", func() {
+		err := policy.NewFileReloader(store, filepath.Join(GinkgoT().TempDir(), "missing.yaml")).Start(context.Background())
+		Expect(err).To(HaveOccurred())
+	})
+})
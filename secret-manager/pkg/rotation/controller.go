@@ -0,0 +1,123 @@
+package rotation
+
+import (
+	"context"
+
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/api"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/controller"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/generator"
+)
+
+var _ controller.Controller = &rotatingController{}
+
+// rotatingController tracks the secrets that are onboarded or set
+// and uses the generator of their policy to rotate them manually.
+type rotatingController struct {
+	controller.Controller
+	scheduler *Scheduler
+}
+
+// NewController wraps the controller to keep the scheduler up to date.
+// The scheduler itself must use the wrapped controller to write the secrets.
+func NewController(c controller.Controller, s *Scheduler) controller.Controller {
+	return &rotatingController{Controller: c, scheduler: s}
+}
+
+func (c *rotatingController) SetSecret(ctx context.Context, rawId, value string) (res controller.SecretResponse, err error) {
+	rotate := value == api.KeywordRotate
+	// The generator is passed on, so it is kept as the generator of the secret
+	if p, g, ok := c.scheduler.Match(rawId); rotate && ok && g != nil {
+		res, err = c.Controller.GenerateSecret(ctx, rawId, p.Generator)
+	} else {
+		res, err = c.Controller.SetSecret(ctx, rawId, value)
+	}
+	if err != nil {
+		return res, err
+	}
+
+	if rotate {
+		err = c.scheduler.RecordRotation(ctx, rawId)
+	} else {
+		err = c.scheduler.Track(ctx, rawId)
+	}
+	controller.LogError(ctx, err, "Failed to track secret", "secret", rawId)
+	return res, nil
+}
+
+// GenerateSecret records the rotation of the secret, as it has a new value now
+func (c *rotatingController) GenerateSecret(ctx context.Context, rawId string, cfg generator.Config) (res controller.SecretResponse, err error) {
+	res, err = c.Controller.GenerateSecret(ctx, rawId, cfg)
+	if err != nil {
+		return res, err
+	}
+	controller.LogError(ctx, c.scheduler.RecordRotation(ctx, rawId), "Failed to track secret", "secret", rawId)
+	return res, nil
+}
+
+func (c *rotatingController) DeleteSecret(ctx context.Context, rawId string) error {
+	if err := c.Controller.DeleteSecret(ctx, rawId); err != nil {
+		return err
+	}
+	controller.LogError(ctx, c.scheduler.Untrack(ctx, rawId), "Failed to untrack secret", "secret", rawId)
+	return nil
+}
+
+func (c *rotatingController) UndeleteSecret(ctx context.Context, rawId string) (controller.SecretResponse, error) {
+	res, err := c.Controller.UndeleteSecret(ctx, rawId)
+	if err != nil {
+		return res, err
+	}
+	controller.LogError(ctx, c.scheduler.Track(ctx, res.Id), "Failed to track secret", "secret", rawId)
+	return res, nil
+}
+
+func (c *rotatingController) OnboardEnvironment(ctx context.Context, envId string) (controller.OnboardResponse, error) {
+	res, err := c.Controller.OnboardEnvironment(ctx, envId)
+	c.track(ctx, res, err)
+	return res, err
+}
+
+func (c *rotatingController) OnboardTeam(ctx context.Context, envId, teamId string) (controller.OnboardResponse, error) {
+	res, err := c.Controller.OnboardTeam(ctx, envId, teamId)
+	c.track(ctx, res, err)
+	return res, err
+}
+
+func (c *rotatingController) OnboardApplication(ctx context.Context, envId, teamId, appId string) (controller.OnboardResponse, error) {
+	res, err := c.Controller.OnboardApplication(ctx, envId, teamId, appId)
+	c.track(ctx, res, err)
+	return res, err
+}
+
+func (c *rotatingController) DeleteEnvironment(ctx context.Context, envId string) error {
+	if err := c.Controller.DeleteEnvironment(ctx, envId); err != nil {
+		return err
+	}
+	controller.LogError(ctx, c.scheduler.UntrackScope(ctx, envId, "", ""), "Failed to untrack environment", "env", envId)
+	return nil
+}
+
+func (c *rotatingController) DeleteTeam(ctx context.Context, envId, teamId string) error {
+	if err := c.Controller.DeleteTeam(ctx, envId, teamId); err != nil {
+		return err
+	}
+	controller.LogError(ctx, c.scheduler.UntrackScope(ctx, envId, teamId, ""), "Failed to untrack team", "env", envId, "team", teamId)
+	return nil
+}
+
+func (c *rotatingController) DeleteApplication(ctx context.Context, envId, teamId, appId string) error {
+	if err := c.Controller.DeleteApplication(ctx, envId, teamId, appId); err != nil {
+		return err
+	}
+	controller.LogError(ctx, c.scheduler.UntrackScope(ctx, envId, teamId, appId), "Failed to untrack application", "env", envId, "team", teamId, "app", appId)
+	return nil
+}
+
+func (c *rotatingController) track(ctx context.Context, res controller.OnboardResponse, err error) {
+	if err != nil {
+		return
+	}
+	for _, ref := range res.SecretRefs {
+		controller.LogError(ctx, c.scheduler.Track(ctx, ref), "Failed to track secret", "secret", ref)
+	}
+}
@@ -0,0 +1,78 @@
+package rotation
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+)
+
+// Event is sent to the notifiers after a secret has been rotated.
+// It never contains the value of the secret.
+type Event struct {
+	// Secret is the ID of the secret without checksum
+	Secret string `json:"secret"`
+	// Id is the ID of the new version of the secret
+	Id        string    `json:"id"`
+	Policy    string    `json:"policy"`
+	RotatedAt time.Time `json:"rotatedAt"`
+}
+
+// Notifier informs interested parties about rotated secrets
+type Notifier interface {
+	Notify(ctx context.Context, event Event) error
+}
+
+// NotifierFunc is an adapter to use a function as Notifier
+type NotifierFunc func(ctx context.Context, event Event) error
+
+func (f NotifierFunc) Notify(ctx context.Context, event Event) error {
+	return f(ctx, event)
+}
+
+// LogNotifier logs each event
+var LogNotifier Notifier = NotifierFunc(func(ctx context.Context, event Event) error {
+	logr.FromContextOrDiscard(ctx).Info("Secret rotated", "secret", event.Secret, "id", event.Id, "policy", event.Policy)
+	return nil
+})
+
+var _ Notifier = &WebhookNotifier{}
+
+// WebhookNotifier posts each event as JSON to the URL
+type WebhookNotifier struct {
+	URL    string
+	Client *http.Client
+}
+
+func NewWebhookNotifier(url string) *WebhookNotifier {
+	return &WebhookNotifier{
+		URL:    url,
+		Client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (n *WebhookNotifier) Notify(ctx context.Context, event Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return errors.Wrap(err, "failed to encode event")
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.URL, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "failed to create request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := n.Client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "failed to send event")
+	}
+	defer res.Body.Close() //nolint:errcheck
+	if res.StatusCode >= 300 {
+		return errors.Errorf("failed to send event: unexpected status %d", res.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package rotation
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/backend"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/generator"
+)
+
+type Scope string
+
+const (
+	ScopeEnvironment Scope = "environment"
+	ScopeTeam        Scope = "team"
+	ScopeApplication Scope = "application"
+)
+
+// Interval is a duration that additionally supports days, e.g. 90d
+type Interval time.Duration
+
+func ParseInterval(s string) (Interval, error) {
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, errors.Errorf("invalid interval %q", s)
+		}
+		return Interval(time.Duration(n) * 24 * time.Hour), nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, errors.Errorf("invalid interval %q", s)
+	}
+	return Interval(d), nil
+}
+
+func (i *Interval) UnmarshalText(text []byte) error {
+	interval, err := ParseInterval(string(text))
+	if err != nil {
+		return err
+	}
+	*i = interval
+	return nil
+}
+
+func (i Interval) Duration() time.Duration {
+	return time.Duration(i)
+}
+
+// Policy rotates the secret with the given path in all environments, teams or applications.
+type Policy struct {
+	Name string `yaml:"name" json:"name"`
+	// Secret is the path of the secret, e.g. teamToken or externalSecrets/apiKey
+	Secret string `yaml:"secret" json:"secret"`
+	// Scope restricts the policy to the secrets of environments, teams or applications.
+	// If it is empty, the policy applies to all of them.
+	Scope Scope `yaml:"scope,omitempty" json:"scope,omitempty"`
+	// Interval after which the secret is rotated
+	Interval Interval `yaml:"interval" json:"interval"`
+	// Generator of the new values. If no type is set, the secret is rotated like using the keyword rotate,
+	// i.e. using the generator that it has been generated with or a UUID.
+	Generator generator.Config `yaml:"generator,omitempty" json:"generator,omitempty"`
+	// Notify the notifiers of the scheduler after each rotation
+	Notify bool `yaml:"notify,omitempty" json:"notify,omitempty"`
+}
+
+func (p Policy) validate() error {
+	if p.Name == "" {
+		return errors.New("name cannot be empty")
+	}
+	if p.Secret == "" {
+		return errors.Errorf("secret of policy %s cannot be empty", p.Name)
+	}
+	if p.Interval <= 0 {
+		return errors.Errorf("interval of policy %s must be positive", p.Name)
+	}
+	switch p.Scope {
+	case "", ScopeEnvironment, ScopeTeam, ScopeApplication:
+	default:
+		return errors.Errorf("invalid scope %q of policy %s", p.Scope, p.Name)
+	}
+	return nil
+}
+
+// Matches checks if the policy applies to the secret
+func (p Policy) Matches(id backend.ParsedId) bool {
+	if id.Path != p.Secret {
+		return false
+	}
+	return p.Scope == "" || p.Scope == scopeOf(id.Scope)
+}
+
+func scopeOf(s backend.Scope) Scope {
+	if s.App != "" {
+		return ScopeApplication
+	}
+	if s.Team != "" {
+		return ScopeTeam
+	}
+	return ScopeEnvironment
+}
@@ -0,0 +1,64 @@
+package rotation_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/backend"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/rotation"
+	"gopkg.in/yaml.v3"
+)
+
+var _ = Describe("Policy", func() {
+
+	It("should parse intervals", func() {
+		interval, err := rotation.ParseInterval("90d")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(interval.Duration()).To(Equal(90 * 24 * time.Hour))
+
+		interval, err = rotation.ParseInterval("12h")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(interval.Duration()).To(Equal(12 * time.Hour))
+
+		_, err = rotation.ParseInterval("often")
+		Expect(err).To(MatchError(`invalid interval "often"`))
+	})
+
+	It("should decode a policy from yaml", func() {
+		policy := rotation.Policy{}
+		err := yaml.Unmarshal([]byte(`
+name: team-token
+secret: teamToken
+scope: team
+interval: 90d
+generator:
+  type: alphanumeric
+  length: 64
+notify: true
+`), &policy)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(policy.Secret).To(Equal("teamToken"))
+		Expect(policy.Scope).To(Equal(rotation.ScopeTeam))
+		Expect(policy.Interval.Duration()).To(Equal(90 * 24 * time.Hour))
+		Expect(policy.Generator.Length).To(Equal(64))
+		Expect(policy.Notify).To(BeTrue())
+	})
+
+	It("should match secrets by path and scope", func() {
+		policy := rotation.Policy{Secret: "clientSecret", Scope: rotation.ScopeApplication}
+
+		id, err := backend.ParseId("env:team:app:clientSecret:v3")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(policy.Matches(id)).To(BeTrue())
+
+		id, _ = backend.ParseId("env:team::clientSecret:v3")
+		Expect(policy.Matches(id)).To(BeFalse())
+
+		policy.Scope = ""
+		Expect(policy.Matches(id)).To(BeTrue())
+
+		id, _ = backend.ParseId("env:team::teamToken:")
+		Expect(policy.Matches(id)).To(BeFalse())
+	})
+})
@@ -0,0 +1,339 @@
+package rotation
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	pkgerrors "github.com/pkg/errors"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/api"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/backend"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/controller"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/generator"
+)
+
+// DefaultCheckInterval is the default interval in which the scheduler checks for due secrets
+const DefaultCheckInterval = time.Hour
+
+// SecretWriter is used by the scheduler to write the rotated secrets
+type SecretWriter interface {
+	SetSecret(ctx context.Context, rawId, value string) (controller.SecretResponse, error)
+	GenerateSecret(ctx context.Context, rawId string, cfg generator.Config) (controller.SecretResponse, error)
+}
+
+// SecretLister is used by the scheduler to discover the existing secrets
+type SecretLister interface {
+	ListSecrets(ctx context.Context, req controller.ListSecretsRequest) (controller.ListSecretsResponse, error)
+}
+
+type policy struct {
+	Policy
+	// generator is nil if the policy has no generator
+	generator generator.Generator
+}
+
+// Scheduler rotates the tracked secrets when they are due according to their policy.
+// Secrets are tracked when they are onboarded or set, see NewController.
+type Scheduler struct {
+	writer    SecretWriter
+	store     Store
+	policies  []policy
+	notifiers []Notifier
+	scopes    backend.ScopeLister
+	lister    SecretLister
+
+	// CheckInterval is the interval in which the scheduler checks for due secrets
+	CheckInterval time.Duration
+	now           func() time.Time
+}
+
+func NewScheduler(writer SecretWriter, store Store, policies []Policy) (*Scheduler, error) {
+	s := &Scheduler{
+		writer:        writer,
+		store:         store,
+		policies:      make([]policy, 0, len(policies)),
+		CheckInterval: DefaultCheckInterval,
+		now:           time.Now,
+	}
+	for _, p := range policies {
+		if err := p.validate(); err != nil {
+			return nil, err
+		}
+		var g generator.Generator
+		if p.Generator.Type != "" {
+			var err error
+			if g, err = generator.New(p.Generator); err != nil {
+				return nil, pkgerrors.Wrapf(err, "invalid generator of policy %s", p.Name)
+			}
+		}
+		s.policies = append(s.policies, policy{Policy: p, generator: g})
+	}
+	return s, nil
+}
+
+func (s *Scheduler) WithNotifier(n Notifier) *Scheduler {
+	s.notifiers = append(s.notifiers, n)
+	return s
+}
+
+// WithDiscovery enables to track the existing secrets of all onboarded scopes when the scheduler is started,
+// e.g. secrets that have been created before their policy
+func (s *Scheduler) WithDiscovery(scopes backend.ScopeLister, lister SecretLister) *Scheduler {
+	s.scopes = scopes
+	s.lister = lister
+	return s
+}
+
+// WithClock replaces the clock of the scheduler, e.g. for tests
+func (s *Scheduler) WithClock(now func() time.Time) *Scheduler {
+	s.now = now
+	return s
+}
+
+// Match returns the first policy that applies to the secret and its generator.
+// The generator is nil if the policy has none.
+func (s *Scheduler) Match(rawId string) (Policy, generator.Generator, bool) {
+	p, _, ok := s.match(rawId)
+	if !ok {
+		return Policy{}, nil, false
+	}
+	return p.Policy, p.generator, true
+}
+
+// match returns the first policy that applies to the secret and the ID of the secret without its checksum
+func (s *Scheduler) match(rawId string) (*policy, string, bool) {
+	id, err := backend.ParseId(rawId)
+	if err != nil {
+		return nil, "", false
+	}
+	for i := range s.policies {
+		if s.policies[i].Matches(id) {
+			return &s.policies[i], id.Ref(), true
+		}
+	}
+	return nil, "", false
+}
+
+func (s *Scheduler) policyByName(name string) (*policy, bool) {
+	for i := range s.policies {
+		if s.policies[i].Name == name {
+			return &s.policies[i], true
+		}
+	}
+	return nil, false
+}
+
+// Track starts to track the secret if a policy applies to it.
+// Secrets that are already tracked are not changed.
+func (s *Scheduler) Track(ctx context.Context, rawId string) error {
+	p, ref, ok := s.match(rawId)
+	if !ok {
+		return nil
+	}
+	_, exists, err := s.store.Get(ctx, ref)
+	if err != nil || exists {
+		return err
+	}
+	return s.store.Put(ctx, Record{Id: ref, Policy: p.Name, LastRotation: s.now()})
+}
+
+// RecordRotation records that the secret has been rotated now, e.g. manually.
+func (s *Scheduler) RecordRotation(ctx context.Context, rawId string) error {
+	p, ref, ok := s.match(rawId)
+	if !ok {
+		return nil
+	}
+	return s.store.Put(ctx, Record{Id: ref, Policy: p.Name, LastRotation: s.now()})
+}
+
+// Untrack stops tracking the secret
+func (s *Scheduler) Untrack(ctx context.Context, rawId string) error {
+	id, err := backend.ParseId(rawId)
+	if err != nil {
+		return nil
+	}
+	return s.store.Delete(ctx, id.Ref())
+}
+
+// UntrackScope stops tracking all secrets of an environment, team or application
+func (s *Scheduler) UntrackScope(ctx context.Context, env, team, app string) error {
+	records, err := s.store.List(ctx)
+	if err != nil {
+		return err
+	}
+	prefix := backend.Scope{Env: env, Team: team, App: app}.Prefix()
+	for _, record := range records {
+		if strings.HasPrefix(record.Id, prefix) {
+			if err := s.store.Delete(ctx, record.Id); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Discover tracks the existing secrets of all onboarded scopes that a policy applies to.
+// It returns the number of secrets that have not been tracked before.
+func (s *Scheduler) Discover(ctx context.Context) (int, error) {
+	if s.scopes == nil || s.lister == nil {
+		return 0, nil
+	}
+	scopes, err := s.scopes.ListScopes(ctx)
+	if err != nil {
+		return 0, pkgerrors.Wrap(err, "failed to list scopes")
+	}
+
+	count := 0
+	var errs []error
+	for _, scope := range scopes {
+		n, err := s.discoverScope(ctx, scope)
+		count += n
+		if err != nil {
+			errs = append(errs, pkgerrors.Wrapf(err, "failed to discover secrets of %s", scope))
+		}
+	}
+	return count, errors.Join(errs...)
+}
+
+func (s *Scheduler) discoverScope(ctx context.Context, scope backend.Scope) (int, error) {
+	count := 0
+	req := controller.ListSecretsRequest{Env: scope.Env, Team: scope.Team, App: scope.App, Limit: controller.MaxListLimit}
+	for {
+		res, err := s.lister.ListSecrets(ctx, req)
+		if err != nil {
+			if backend.IsNotFoundErr(err) {
+				return count, nil
+			}
+			return count, err
+		}
+		for _, item := range res.Items {
+			p, ref, ok := s.match(backend.ParsedId{Scope: scope, Path: item.Name}.String())
+			if !ok {
+				continue
+			}
+			_, exists, err := s.store.Get(ctx, ref)
+			if err != nil {
+				return count, err
+			}
+			if exists {
+				continue
+			}
+			if err := s.store.Put(ctx, Record{Id: ref, Policy: p.Name, LastRotation: s.now()}); err != nil {
+				return count, err
+			}
+			count++
+		}
+		if res.Next == "" {
+			return count, nil
+		}
+		req.Cursor = res.Next
+	}
+}
+
+// RotateDue rotates all tracked secrets whose interval has elapsed.
+// It returns the number of rotated secrets. A failed rotation does not stop the others.
+func (s *Scheduler) RotateDue(ctx context.Context) (int, error) {
+	log := logr.FromContextOrDiscard(ctx)
+	records, err := s.store.List(ctx)
+	if err != nil {
+		return 0, pkgerrors.Wrap(err, "failed to list tracked secrets")
+	}
+
+	count := 0
+	var errs []error
+	for _, record := range records {
+		p, ok := s.policyByName(record.Policy)
+		if !ok {
+			// The policy has been removed from the config
+			log.Info("Untracking secret without policy", "secret", record.Id, "policy", record.Policy)
+			if err := s.store.Delete(ctx, record.Id); err != nil {
+				errs = append(errs, err)
+			}
+			continue
+		}
+		if s.now().Sub(record.LastRotation) < p.Interval.Duration() {
+			continue
+		}
+		rotated, err := s.rotate(ctx, p, record)
+		if err != nil {
+			errs = append(errs, pkgerrors.Wrapf(err, "failed to rotate secret %s", record.Id))
+			continue
+		}
+		if rotated {
+			count++
+		}
+	}
+	return count, errors.Join(errs...)
+}
+
+// rotate rotates the secret. It returns false if the secret no longer exists.
+func (s *Scheduler) rotate(ctx context.Context, p *policy, record Record) (bool, error) {
+	log := logr.FromContextOrDiscard(ctx).WithValues("secret", record.Id, "policy", p.Name)
+
+	// The generator of the policy is passed on, so it is kept as the generator of the secret
+	var res controller.SecretResponse
+	var err error
+	if p.generator != nil {
+		res, err = s.writer.GenerateSecret(ctx, record.Id, p.Generator)
+	} else {
+		res, err = s.writer.SetSecret(ctx, record.Id, api.KeywordRotate)
+	}
+	if err != nil {
+		if backend.IsNotFoundErr(err) {
+			log.Info("Untracking deleted secret")
+			return false, s.store.Delete(ctx, record.Id)
+		}
+		return false, err
+	}
+
+	rotatedAt := s.now()
+	record.LastRotation = rotatedAt
+	if err := s.store.Put(ctx, record); err != nil {
+		return true, pkgerrors.Wrap(err, "secret has been rotated but its rotation could not be recorded")
+	}
+	log.Info("Rotated secret", "id", res.Id)
+
+	if p.Notify {
+		event := Event{Secret: record.Id, Id: res.Id, Policy: p.Name, RotatedAt: rotatedAt}
+		for _, n := range s.notifiers {
+			if err := n.Notify(ctx, event); err != nil {
+				log.Error(err, "Failed to notify about rotated secret")
+			}
+		}
+	}
+	return true, nil
+}
+
+// Start checks for due secrets in the CheckInterval until the context is done
+func (s *Scheduler) Start(ctx context.Context) {
+	log := logr.FromContextOrDiscard(ctx).WithName("rotation")
+	ctx = logr.NewContext(ctx, log)
+
+	count, err := s.Discover(ctx)
+	if err != nil {
+		log.Error(err, "Failed to discover secrets")
+	}
+	if count > 0 {
+		log.Info("Tracking discovered secrets", "count", count)
+	}
+
+	ticker := time.NewTicker(s.CheckInterval)
+	defer ticker.Stop()
+	for {
+		count, err := s.RotateDue(ctx)
+		if err != nil {
+			log.Error(err, "Failed to rotate secrets")
+		}
+		if count > 0 {
+			log.Info("Rotated due secrets", "count", count)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,250 @@
+package rotation_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/api"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/backend"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/controller"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/generator"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/rotation"
+)
+
+// fakeController stores the values of the secrets by their ID without checksum
+type fakeController struct {
+	controller.Controller
+	values map[string]string
+}
+
+func (c *fakeController) SetSecret(_ context.Context, rawId, value string) (controller.SecretResponse, error) {
+	id, _ := backend.ParseId(rawId)
+	if id.Team == "deleted" {
+		return controller.SecretResponse{}, backend.ErrSecretNotFound(nil)
+	}
+	c.values[id.Ref()] = value
+	return controller.SecretResponse{Id: id.Ref() + "v2"}, nil
+}
+
+func (c *fakeController) GenerateSecret(ctx context.Context, rawId string, cfg generator.Config) (controller.SecretResponse, error) {
+	g, err := generator.New(cfg)
+	if err != nil {
+		return controller.SecretResponse{}, err
+	}
+	value, err := g.Generate()
+	if err != nil {
+		return controller.SecretResponse{}, err
+	}
+	return c.SetSecret(ctx, rawId, value)
+}
+
+func (c *fakeController) OnboardTeam(_ context.Context, envId, teamId string) (controller.OnboardResponse, error) {
+	return controller.OnboardResponse{SecretRefs: map[string]string{
+		"clientSecret": envId + ":" + teamId + "::clientSecret:v1",
+		"teamToken":    envId + ":" + teamId + "::teamToken:v1",
+	}}, nil
+}
+
+func (c *fakeController) ListSecrets(_ context.Context, req controller.ListSecretsRequest) (controller.ListSecretsResponse, error) {
+	return controller.ListSecretsResponse{Items: []controller.SecretRefResponse{
+		{Name: "clientSecret", Id: req.Env + ":" + req.Team + "::clientSecret:v1"},
+		{Name: "teamToken", Id: req.Env + ":" + req.Team + "::teamToken:v1"},
+	}}, nil
+}
+
+func (c *fakeController) DeleteTeam(_ context.Context, _, _ string) error {
+	return nil
+}
+
+var _ = Describe("Scheduler", func() {
+
+	var ctx context.Context
+	var now time.Time
+	var ctrl *fakeController
+	var store *rotation.MemoryStore
+	var scheduler *rotation.Scheduler
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		now = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		ctrl = &fakeController{values: map[string]string{}}
+		store = rotation.NewMemoryStore()
+
+		var err error
+		scheduler, err = rotation.NewScheduler(ctrl, store, []rotation.Policy{
+			{
+				Name:      "team-token",
+				Secret:    "teamToken",
+				Scope:     rotation.ScopeTeam,
+				Interval:  rotation.Interval(90 * 24 * time.Hour),
+				Generator: generator.Config{Type: generator.TypeAlphanumeric, Length: 16},
+				Notify:    true,
+			},
+		})
+		Expect(err).ToNot(HaveOccurred())
+		scheduler.WithClock(func() time.Time { return now })
+	})
+
+	It("should fail for an invalid policy", func() {
+		_, err := rotation.NewScheduler(ctrl, store, []rotation.Policy{{Name: "invalid", Secret: "teamToken"}})
+		Expect(err).To(MatchError("interval of policy invalid must be positive"))
+
+		_, err = rotation.NewScheduler(ctrl, store, []rotation.Policy{{Name: "invalid", Secret: "teamToken", Interval: 1, Generator: generator.Config{Type: "unknown"}}})
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should only track secrets with a policy", func() {
+		Expect(scheduler.Track(ctx, "env:team::teamToken:v1")).To(Succeed())
+		Expect(scheduler.Track(ctx, "env:team::clientSecret:v1")).To(Succeed())
+		Expect(scheduler.Track(ctx, "env:team:app:teamToken:v1")).To(Succeed())
+
+		records, err := store.List(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(records).To(Equal([]rotation.Record{
+			{Id: "env:team::teamToken:", Policy: "team-token", LastRotation: now},
+		}))
+	})
+
+	It("should rotate due secrets", func() {
+		events := []rotation.Event{}
+		scheduler.WithNotifier(rotation.NotifierFunc(func(ctx context.Context, event rotation.Event) error {
+			events = append(events, event)
+			return nil
+		}))
+		Expect(scheduler.Track(ctx, "env:team::teamToken:v1")).To(Succeed())
+
+		now = now.Add(89 * 24 * time.Hour)
+		count, err := scheduler.RotateDue(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(count).To(Equal(0))
+
+		now = now.Add(24 * time.Hour)
+		count, err = scheduler.RotateDue(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(count).To(Equal(1))
+		Expect(ctrl.values["env:team::teamToken:"]).To(MatchRegexp(`^[a-zA-Z0-9]{16}$`))
+
+		record, ok, err := store.Get(ctx, "env:team::teamToken:")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ok).To(BeTrue())
+		Expect(record.LastRotation).To(Equal(now))
+
+		Expect(events).To(Equal([]rotation.Event{
+			{Secret: "env:team::teamToken:", Id: "env:team::teamToken:v2", Policy: "team-token", RotatedAt: now},
+		}))
+
+		count, err = scheduler.RotateDue(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(count).To(Equal(0))
+	})
+
+	It("should rotate like the keyword rotate if the policy has no generator", func() {
+		scheduler, err := rotation.NewScheduler(ctrl, store, []rotation.Policy{
+			{Name: "key", Secret: "key", Interval: rotation.Interval(24 * time.Hour)},
+		})
+		Expect(err).ToNot(HaveOccurred())
+		scheduler.WithClock(func() time.Time { return now })
+		Expect(scheduler.Track(ctx, "env:team::key:v1")).To(Succeed())
+
+		now = now.Add(24 * time.Hour)
+		count, err := scheduler.RotateDue(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(count).To(Equal(1))
+		Expect(ctrl.values["env:team::key:"]).To(Equal(api.KeywordRotate))
+	})
+
+	It("should track the existing secrets on discovery", func() {
+		Expect(store.Put(ctx, rotation.Record{Id: "env:old::teamToken:", Policy: "team-token", LastRotation: now.Add(-time.Hour)})).To(Succeed())
+		scheduler.WithDiscovery(scopeList{{Env: "env", Team: "team"}, {Env: "env", Team: "old"}}, ctrl)
+
+		count, err := scheduler.Discover(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(count).To(Equal(1))
+		Expect(store.List(ctx)).To(Equal([]rotation.Record{
+			{Id: "env:old::teamToken:", Policy: "team-token", LastRotation: now.Add(-time.Hour)},
+			{Id: "env:team::teamToken:", Policy: "team-token", LastRotation: now},
+		}))
+	})
+
+	It("should untrack secrets that no longer exist", func() {
+		Expect(store.Put(ctx, rotation.Record{Id: "env:deleted::teamToken:", Policy: "team-token"})).To(Succeed())
+		Expect(store.Put(ctx, rotation.Record{Id: "env:team::other:", Policy: "removed"})).To(Succeed())
+
+		count, err := scheduler.RotateDue(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(count).To(Equal(0))
+		Expect(store.List(ctx)).To(BeEmpty())
+	})
+
+	Context("Controller", func() {
+
+		var ctrlWithRotation controller.Controller
+
+		BeforeEach(func() {
+			ctrlWithRotation = rotation.NewController(ctrl, scheduler)
+		})
+
+		It("should track onboarded secrets", func() {
+			_, err := ctrlWithRotation.OnboardTeam(ctx, "env", "team")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(store.List(ctx)).To(HaveLen(1))
+
+			Expect(ctrlWithRotation.DeleteTeam(ctx, "env", "team")).To(Succeed())
+			Expect(store.List(ctx)).To(BeEmpty())
+		})
+
+		It("should use the generator of the policy to rotate manually", func() {
+			_, err := ctrlWithRotation.OnboardTeam(ctx, "env", "team")
+			Expect(err).ToNot(HaveOccurred())
+
+			now = now.Add(time.Hour)
+			_, err = ctrlWithRotation.SetSecret(ctx, "env:team::teamToken:v1", "rotate")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ctrl.values["env:team::teamToken:"]).To(MatchRegexp(`^[a-zA-Z0-9]{16}$`))
+
+			record, _, err := store.Get(ctx, "env:team::teamToken:")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(record.LastRotation).To(Equal(now))
+		})
+
+		It("should not change other secrets", func() {
+			_, err := ctrlWithRotation.SetSecret(ctx, "env:team::clientSecret:", "my-value")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ctrl.values["env:team::clientSecret:"]).To(Equal("my-value"))
+			Expect(store.List(ctx)).To(BeEmpty())
+		})
+	})
+})
+
+var _ = Describe("Webhook Notifier", func() {
+
+	It("should post the event", func() {
+		received := make(chan rotation.Event, 1)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			event := rotation.Event{}
+			Expect(json.NewDecoder(r.Body).Decode(&event)).To(Succeed())
+			received <- event
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+
+		event := rotation.Event{Secret: "env:team::teamToken:", Id: "env:team::teamToken:v2", Policy: "team-token"}
+		Expect(rotation.NewWebhookNotifier(server.URL).Notify(context.Background(), event)).To(Succeed())
+		Expect(<-received).To(Equal(event))
+	})
+
+	It("should fail on an error response", func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+
+		err := rotation.NewWebhookNotifier(server.URL).Notify(context.Background(), rotation.Event{})
+		Expect(err).To(MatchError("failed to send event: unexpected status 500"))
+	})
+})
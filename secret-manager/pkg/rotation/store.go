@@ -0,0 +1,133 @@
+package rotation
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/backend"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/metadata"
+)
+
+// Record contains the rotation state of a tracked secret
+type Record struct {
+	// Id of the secret without checksum
+	Id     string `json:"id"`
+	Policy string `json:"policy"`
+	// LastRotation is the time of the last rotation.
+	// For secrets that have never been rotated, it is the time when they have been tracked.
+	LastRotation time.Time `json:"lastRotation"`
+}
+
+// Store persists the records of the tracked secrets
+type Store interface {
+	Get(ctx context.Context, id string) (Record, bool, error)
+	Put(ctx context.Context, record Record) error
+	Delete(ctx context.Context, id string) error
+	List(ctx context.Context) ([]Record, error)
+}
+
+var _ Store = &MemoryStore{}
+
+// MemoryStore keeps the records in memory. They are lost on restart.
+type MemoryStore struct {
+	mux     sync.RWMutex
+	records map[string]Record
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{records: map[string]Record{}}
+}
+
+func (s *MemoryStore) Get(_ context.Context, id string) (Record, bool, error) {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+	record, ok := s.records[id]
+	return record, ok, nil
+}
+
+func (s *MemoryStore) Put(_ context.Context, record Record) error {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	s.records[record.Id] = record
+	return nil
+}
+
+func (s *MemoryStore) Delete(_ context.Context, id string) error {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	delete(s.records, id)
+	return nil
+}
+
+func (s *MemoryStore) List(_ context.Context) ([]Record, error) {
+	s.mux.RLock()
+	defer s.mux.RUnlock()
+	records := slices.Collect(maps.Values(s.records))
+	slices.SortFunc(records, func(a, b Record) int {
+		return strings.Compare(a.Id, b.Id)
+	})
+	return records, nil
+}
+
+var _ Store = &MetadataStore{}
+
+// MetadataStore keeps the records in the metadata of the secrets in the backend,
+// so they survive restarts. The records are listed from all onboarded scopes.
+type MetadataStore struct {
+	metadata *metadata.Store
+	scopes   backend.ScopeLister
+}
+
+func NewMetadataStore(m *metadata.Store, scopes backend.ScopeLister) *MetadataStore {
+	return &MetadataStore{metadata: m, scopes: scopes}
+}
+
+func (s *MetadataStore) Get(ctx context.Context, id string) (Record, bool, error) {
+	m, _, err := s.metadata.Get(ctx, id)
+	if err != nil || m.Rotation == nil {
+		return Record{}, false, err
+	}
+	return Record{Id: id, Policy: m.Rotation.Policy, LastRotation: m.Rotation.LastRotation}, true, nil
+}
+
+func (s *MetadataStore) Put(ctx context.Context, record Record) error {
+	return s.metadata.Update(ctx, record.Id, func(m *metadata.Metadata) {
+		m.Rotation = &metadata.Rotation{Policy: record.Policy, LastRotation: record.LastRotation}
+	})
+}
+
+func (s *MetadataStore) Delete(ctx context.Context, id string) error {
+	return s.metadata.Update(ctx, id, func(m *metadata.Metadata) {
+		m.Rotation = nil
+	})
+}
+
+func (s *MetadataStore) List(ctx context.Context) ([]Record, error) {
+	scopes, err := s.scopes.ListScopes(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list scopes")
+	}
+	records := []Record{}
+	for _, scope := range scopes {
+		entries, err := s.metadata.List(ctx, scope)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to list records of %s", scope)
+		}
+		for path, m := range entries {
+			if m.Rotation == nil {
+				continue
+			}
+			id := backend.ParsedId{Scope: scope, Path: path}.String()
+			records = append(records, Record{Id: id, Policy: m.Rotation.Policy, LastRotation: m.Rotation.LastRotation})
+		}
+	}
+	slices.SortFunc(records, func(a, b Record) int {
+		return strings.Compare(a.Id, b.Id)
+	})
+	return records, nil
+}
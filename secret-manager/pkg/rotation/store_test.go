@@ -0,0 +1,69 @@
+package rotation_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/backend"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/controller"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/metadata"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/rotation"
+)
+
+// backendController stores the values of the secrets by their ID, like a backend
+type backendController struct {
+	controller.Controller
+	values map[string]string
+}
+
+func (c *backendController) GetSecret(_ context.Context, rawId string) (controller.SecretResponse, error) {
+	value, ok := c.values[rawId]
+	if !ok {
+		return controller.SecretResponse{}, backend.ErrSecretNotFound(nil)
+	}
+	return controller.SecretResponse{Id: rawId, Value: value}, nil
+}
+
+func (c *backendController) SetSecret(_ context.Context, rawId, value string) (controller.SecretResponse, error) {
+	c.values[rawId] = value
+	return controller.SecretResponse{Id: rawId}, nil
+}
+
+type scopeList []backend.Scope
+
+func (l scopeList) ListScopes(_ context.Context) ([]backend.Scope, error) {
+	return l, nil
+}
+
+var _ = Describe("Metadata Store", func() {
+
+	It("should store the records in the backend", func() {
+		ctx := context.Background()
+		now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		ctrl := &backendController{values: map[string]string{}}
+		scopes := scopeList{{Env: "env", Team: "team"}, {Env: "env", Team: "team", App: "app"}}
+
+		store := rotation.NewMetadataStore(metadata.NewStore(ctrl), scopes)
+		Expect(store.Put(ctx, rotation.Record{Id: "env:team::teamToken:", Policy: "team-token", LastRotation: now})).To(Succeed())
+		Expect(store.Put(ctx, rotation.Record{Id: "env:team:app:clientSecret:", Policy: "client-secret", LastRotation: now})).To(Succeed())
+		Expect(store.Put(ctx, rotation.Record{Id: "env:team:app:externalSecrets/apiKey:", Policy: "api-key", LastRotation: now})).To(Succeed())
+		Expect(store.Delete(ctx, "env:team:app:clientSecret:")).To(Succeed())
+		Expect(ctrl.values).To(HaveKey("env:team::.metadata:"))
+
+		// A new store, e.g. after a restart, lists the same records
+		store = rotation.NewMetadataStore(metadata.NewStore(ctrl), scopes)
+		records, err := store.List(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(records).To(Equal([]rotation.Record{
+			{Id: "env:team::teamToken:", Policy: "team-token", LastRotation: now},
+			{Id: "env:team:app:externalSecrets/apiKey:", Policy: "api-key", LastRotation: now},
+		}))
+
+		record, ok, err := store.Get(ctx, "env:team::teamToken:")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ok).To(BeTrue())
+		Expect(record.LastRotation).To(Equal(now))
+	})
+})
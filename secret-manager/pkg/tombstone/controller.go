@@ -0,0 +1,165 @@
+package tombstone
+
+import (
+	"context"
+
+	pkgerrors "github.com/pkg/errors"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/api"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/backend"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/controller"
+)
+
+var _ controller.Controller = &softDeletingController{}
+
+// softDeletingController replaces deleted secrets by tombstones and hides them,
+// so they behave like secrets that do not exist until they are restored or purged.
+type softDeletingController struct {
+	controller.Controller
+	purger *Purger
+}
+
+// NewController wraps the controller to soft-delete secrets.
+// The purger must use the same controller c, as the returned controller hides the tombstones.
+func NewController(c controller.Controller, p *Purger) controller.Controller {
+	return &softDeletingController{Controller: c, purger: p}
+}
+
+func (c *softDeletingController) GetSecret(ctx context.Context, rawId string) (controller.SecretResponse, error) {
+	res, err := c.Controller.GetSecret(ctx, rawId)
+	if err != nil {
+		return res, err
+	}
+	ref, versioned := secretRef(rawId)
+	if _, ok := Decode(res.Value); ok {
+		if !versioned {
+			if _, err := c.purger.purgeExpired(ctx, ref, res.Value); err != nil {
+				return controller.SecretResponse{}, err
+			}
+		}
+		return controller.SecretResponse{}, errDeleted(rawId)
+	}
+
+	// Previous versions remain readable, unless the secret itself has been deleted
+	if versioned {
+		if err := c.checkNotDeleted(ctx, ref); err != nil {
+			return controller.SecretResponse{}, err
+		}
+	}
+	return res, nil
+}
+
+func (c *softDeletingController) GetPublicKey(ctx context.Context, rawId string) (controller.PublicKeyResponse, error) {
+	if _, err := c.GetSecret(ctx, rawId); err != nil {
+		return controller.PublicKeyResponse{}, err
+	}
+	return c.Controller.GetPublicKey(ctx, rawId)
+}
+
+func (c *softDeletingController) ListSecretVersions(ctx context.Context, rawId string) ([]controller.SecretVersionResponse, error) {
+	ref, _ := secretRef(rawId)
+	if err := c.checkNotDeleted(ctx, ref); err != nil {
+		return nil, err
+	}
+	return c.Controller.ListSecretVersions(ctx, rawId)
+}
+
+func (c *softDeletingController) ListSecrets(ctx context.Context, req controller.ListSecretsRequest) (controller.ListSecretsResponse, error) {
+	filter := req.Filter
+	scope := backend.Scope{Env: req.Env, Team: req.Team, App: req.App}
+	req.Filter = func(name string) bool {
+		if filter != nil && !filter(name) {
+			return false
+		}
+		secret, err := c.Controller.GetSecret(ctx, backend.ParsedId{Scope: scope, Path: name}.String())
+		if err != nil {
+			// Secrets that can not be read are listed, so they do not disappear while the backend is unavailable
+			return true
+		}
+		_, deleted := Decode(secret.Value)
+		return !deleted
+	}
+	return c.Controller.ListSecrets(ctx, req)
+}
+
+// SetSecret restores a deleted secret if a value is set. Deleted secrets can not be rotated.
+func (c *softDeletingController) SetSecret(ctx context.Context, rawId, value string) (controller.SecretResponse, error) {
+	if value == api.KeywordRotate {
+		ref, _ := secretRef(rawId)
+		if err := c.checkNotDeleted(ctx, ref); err != nil {
+			return controller.SecretResponse{}, err
+		}
+	}
+	return c.Controller.SetSecret(ctx, rawId, value)
+}
+
+// DeleteSecret replaces the secret by a tombstone that keeps its value for the retention
+func (c *softDeletingController) DeleteSecret(ctx context.Context, rawId string) error {
+	ref, _ := secretRef(rawId)
+	current, err := c.Controller.GetSecret(ctx, ref)
+	if err != nil {
+		return err
+	}
+	if _, ok := Decode(current.Value); ok {
+		return errDeleted(rawId)
+	}
+
+	value, err := Encode(Tombstone{DeletedAt: c.purger.now().UTC(), Value: current.Value})
+	if err != nil {
+		return pkgerrors.Wrap(err, "failed to encode tombstone")
+	}
+	_, err = c.Controller.SetSecret(ctx, ref, value)
+	return err
+}
+
+// UndeleteSecret restores the value of a deleted secret whose retention has not passed yet
+func (c *softDeletingController) UndeleteSecret(ctx context.Context, rawId string) (controller.SecretResponse, error) {
+	ref, _ := secretRef(rawId)
+	current, err := c.Controller.GetSecret(ctx, ref)
+	if err != nil {
+		return controller.SecretResponse{}, err
+	}
+	t, ok := Decode(current.Value)
+	if !ok {
+		return controller.SecretResponse{}, backend.NewBackendError(nil, pkgerrors.Errorf("secret %s is not deleted", ref), backend.TypeErrNotFound)
+	}
+	purged, err := c.purger.purgeExpired(ctx, ref, current.Value)
+	if err != nil {
+		return controller.SecretResponse{}, err
+	}
+	if purged {
+		return controller.SecretResponse{}, errDeleted(rawId)
+	}
+
+	return c.Controller.SetSecret(ctx, ref, t.Value)
+}
+
+// checkNotDeleted returns NotFound if the latest value of the secret is a tombstone.
+// Expired tombstones are purged on the way.
+func (c *softDeletingController) checkNotDeleted(ctx context.Context, ref string) error {
+	current, err := c.Controller.GetSecret(ctx, ref)
+	if err != nil {
+		return err
+	}
+	if _, ok := Decode(current.Value); !ok {
+		return nil
+	}
+	if _, err := c.purger.purgeExpired(ctx, ref, current.Value); err != nil {
+		return err
+	}
+	return errDeleted(ref)
+}
+
+func errDeleted(rawId string) error {
+	return backend.NewBackendError(nil, pkgerrors.Errorf("secret %s has been deleted", rawId), backend.TypeErrNotFound)
+}
+
+// secretRef returns the ID of the secret without its checksum
+// and whether the ID refers to a specific version of the secret
+func secretRef(rawId string) (string, bool) {
+	id, err := backend.ParseId(rawId)
+	if err != nil {
+		return rawId, false
+	}
+	_, versioned := backend.ParseVersion(id.Checksum)
+	return id.Ref(), versioned
+}
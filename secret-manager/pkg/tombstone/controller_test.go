@@ -0,0 +1,276 @@
+package tombstone_test
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/api"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/backend"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/controller"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/tombstone"
+)
+
+// fakeController keeps all versions of the secrets by their ID without checksum
+type fakeController struct {
+	controller.Controller
+	secrets map[string][]string
+}
+
+func (c *fakeController) split(rawId string) (string, string) {
+	i := strings.LastIndex(rawId, backend.Separator)
+	return rawId[:i+1], rawId[i+1:]
+}
+
+func (c *fakeController) GetSecret(_ context.Context, rawId string) (controller.SecretResponse, error) {
+	ref, checksum := c.split(rawId)
+	versions, ok := c.secrets[ref]
+	if !ok {
+		return controller.SecretResponse{}, backend.ErrNotFound()
+	}
+	if version, ok := backend.ParseVersion(checksum); ok {
+		if version > len(versions) {
+			return controller.SecretResponse{}, backend.ErrNotFound()
+		}
+		return controller.SecretResponse{Id: rawId, Value: versions[version-1]}, nil
+	}
+	return controller.SecretResponse{Id: ref + backend.MakeVersion(len(versions)), Value: versions[len(versions)-1]}, nil
+}
+
+func (c *fakeController) GetPublicKey(_ context.Context, rawId string) (controller.PublicKeyResponse, error) {
+	return controller.PublicKeyResponse{Id: rawId, PublicKey: "public"}, nil
+}
+
+func (c *fakeController) SetSecret(_ context.Context, rawId, value string) (controller.SecretResponse, error) {
+	ref, _ := c.split(rawId)
+	if value == api.KeywordRotate {
+		value = "rotated"
+	}
+	c.secrets[ref] = append(c.secrets[ref], value)
+	return controller.SecretResponse{Id: ref + backend.MakeVersion(len(c.secrets[ref])), Value: value}, nil
+}
+
+func (c *fakeController) DeleteSecret(_ context.Context, rawId string) error {
+	ref, _ := c.split(rawId)
+	if _, ok := c.secrets[ref]; !ok {
+		return backend.ErrNotFound()
+	}
+	delete(c.secrets, ref)
+	return nil
+}
+
+func (c *fakeController) ListSecretVersions(_ context.Context, rawId string) ([]controller.SecretVersionResponse, error) {
+	ref, _ := c.split(rawId)
+	res := []controller.SecretVersionResponse{}
+	for i := len(c.secrets[ref]); i > 0; i-- {
+		res = append(res, controller.SecretVersionResponse{Version: "v" + strconv.Itoa(i), Id: ref + backend.MakeVersion(i)})
+	}
+	return res, nil
+}
+
+func (c *fakeController) ListSecrets(_ context.Context, req controller.ListSecretsRequest) (controller.ListSecretsResponse, error) {
+	prefix := strings.Join([]string{req.Env, req.Team, req.App}, backend.Separator) + backend.Separator
+	res := controller.ListSecretsResponse{Items: []controller.SecretRefResponse{}}
+	for ref := range c.secrets {
+		if !strings.HasPrefix(ref, prefix) {
+			continue
+		}
+		name := strings.TrimSuffix(strings.TrimPrefix(ref, prefix), backend.Separator)
+		if req.Filter != nil && !req.Filter(name) {
+			continue
+		}
+		res.Items = append(res.Items, controller.SecretRefResponse{Name: name, Id: ref})
+	}
+	return res, nil
+}
+
+type fakeScopes []backend.Scope
+
+func (s fakeScopes) ListScopes(_ context.Context) ([]backend.Scope, error) {
+	return s, nil
+}
+
+var _ = Describe("Controller", func() {
+
+	var ctx context.Context
+	var now time.Time
+	var ctrl *fakeController
+	var purger *tombstone.Purger
+	var softDeleting controller.Controller
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		now = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		ctrl = &fakeController{secrets: map[string][]string{
+			"env:team:app:clientSecret:": {"old-secret", "topsecret"},
+			"env:team:app:other:":        {"other-secret"},
+		}}
+		purger = tombstone.NewPurger(ctrl, fakeScopes{{Env: "env", Team: "team", App: "app"}}, time.Hour).
+			WithClock(func() time.Time { return now })
+		softDeleting = tombstone.NewController(ctrl, purger)
+	})
+
+	It("should keep the value of a deleted secret in a tombstone", func() {
+		Expect(softDeleting.DeleteSecret(ctx, "env:team:app:clientSecret:v2")).To(Succeed())
+
+		versions := ctrl.secrets["env:team:app:clientSecret:"]
+		t, ok := tombstone.Decode(versions[len(versions)-1])
+		Expect(ok).To(BeTrue())
+		Expect(t.Value).To(Equal("topsecret"))
+		Expect(t.DeletedAt).To(Equal(now))
+	})
+
+	It("should return not found for a deleted secret", func() {
+		Expect(softDeleting.DeleteSecret(ctx, "env:team:app:clientSecret:")).To(Succeed())
+
+		_, err := softDeleting.GetSecret(ctx, "env:team:app:clientSecret:")
+		Expect(backend.IsNotFoundErr(err)).To(BeTrue())
+
+		_, err = softDeleting.GetSecret(ctx, "env:team:app:clientSecret:v1")
+		Expect(backend.IsNotFoundErr(err)).To(BeTrue())
+
+		_, err = softDeleting.ListSecretVersions(ctx, "env:team:app:clientSecret:")
+		Expect(backend.IsNotFoundErr(err)).To(BeTrue())
+
+		_, err = softDeleting.GetPublicKey(ctx, "env:team:app:clientSecret:")
+		Expect(backend.IsNotFoundErr(err)).To(BeTrue())
+
+		_, err = softDeleting.SetSecret(ctx, "env:team:app:clientSecret:", api.KeywordRotate)
+		Expect(backend.IsNotFoundErr(err)).To(BeTrue())
+
+		err = softDeleting.DeleteSecret(ctx, "env:team:app:clientSecret:")
+		Expect(backend.IsNotFoundErr(err)).To(BeTrue())
+	})
+
+	It("should not list deleted secrets", func() {
+		Expect(softDeleting.DeleteSecret(ctx, "env:team:app:clientSecret:")).To(Succeed())
+
+		res, err := softDeleting.ListSecrets(ctx, controller.ListSecretsRequest{Env: "env", Team: "team", App: "app"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(res.Items).To(HaveLen(1))
+		Expect(res.Items[0].Name).To(Equal("other"))
+	})
+
+	It("should restore a deleted secret", func() {
+		Expect(softDeleting.DeleteSecret(ctx, "env:team:app:clientSecret:")).To(Succeed())
+
+		res, err := softDeleting.UndeleteSecret(ctx, "env:team:app:clientSecret:")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(res.Id).To(Equal("env:team:app:clientSecret:v4"))
+
+		secret, err := softDeleting.GetSecret(ctx, "env:team:app:clientSecret:")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(secret.Value).To(Equal("topsecret"))
+
+		// The tombstone remains hidden in the versions
+		_, err = softDeleting.GetSecret(ctx, "env:team:app:clientSecret:v3")
+		Expect(backend.IsNotFoundErr(err)).To(BeTrue())
+		secret, err = softDeleting.GetSecret(ctx, "env:team:app:clientSecret:v1")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(secret.Value).To(Equal("old-secret"))
+	})
+
+	It("should recreate a deleted secret if a value is set", func() {
+		Expect(softDeleting.DeleteSecret(ctx, "env:team:app:clientSecret:")).To(Succeed())
+
+		_, err := softDeleting.SetSecret(ctx, "env:team:app:clientSecret:", "new-secret")
+		Expect(err).ToNot(HaveOccurred())
+
+		secret, err := softDeleting.GetSecret(ctx, "env:team:app:clientSecret:")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(secret.Value).To(Equal("new-secret"))
+
+		_, err = softDeleting.UndeleteSecret(ctx, "env:team:app:clientSecret:")
+		Expect(backend.IsNotFoundErr(err)).To(BeTrue())
+	})
+
+	It("should not restore a secret whose retention has passed", func() {
+		Expect(softDeleting.DeleteSecret(ctx, "env:team:app:clientSecret:")).To(Succeed())
+		now = now.Add(time.Hour)
+
+		_, err := softDeleting.UndeleteSecret(ctx, "env:team:app:clientSecret:")
+		Expect(backend.IsNotFoundErr(err)).To(BeTrue())
+		Expect(ctrl.secrets).ToNot(HaveKey("env:team:app:clientSecret:"))
+	})
+
+	It("should return not found when deleting a secret that does not exist", func() {
+		err := softDeleting.DeleteSecret(ctx, "env:team:app:unknown:")
+		Expect(backend.IsNotFoundErr(err)).To(BeTrue())
+	})
+})
+
+var _ = Describe("Purger", func() {
+
+	var ctx context.Context
+	var now time.Time
+	var ctrl *fakeController
+	var purger *tombstone.Purger
+	var softDeleting controller.Controller
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		now = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		ctrl = &fakeController{secrets: map[string][]string{
+			"env:team:app:clientSecret:": {"topsecret"},
+			"env:team:app:other:":        {"other-secret"},
+			"env:team::teamToken:":       {"team-secret"},
+		}}
+		purger = tombstone.NewPurger(ctrl, fakeScopes{{Env: "env", Team: "team"}, {Env: "env", Team: "team", App: "app"}}, time.Hour).
+			WithClock(func() time.Time { return now })
+		softDeleting = tombstone.NewController(ctrl, purger)
+	})
+
+	It("should purge the secrets whose retention has passed", func() {
+		Expect(softDeleting.DeleteSecret(ctx, "env:team:app:clientSecret:")).To(Succeed())
+		now = now.Add(30 * time.Minute)
+		Expect(softDeleting.DeleteSecret(ctx, "env:team::teamToken:")).To(Succeed())
+
+		count, err := purger.Purge(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(count).To(Equal(0))
+
+		now = now.Add(30 * time.Minute)
+		count, err = purger.Purge(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(count).To(Equal(1))
+		Expect(ctrl.secrets).ToNot(HaveKey("env:team:app:clientSecret:"))
+		Expect(ctrl.secrets).To(HaveKey("env:team::teamToken:"))
+		Expect(ctrl.secrets).To(HaveKey("env:team:app:other:"))
+	})
+
+	It("should purge an expired secret when it is read", func() {
+		Expect(softDeleting.DeleteSecret(ctx, "env:team:app:clientSecret:")).To(Succeed())
+		now = now.Add(2 * time.Hour)
+
+		_, err := softDeleting.GetSecret(ctx, "env:team:app:clientSecret:")
+		Expect(backend.IsNotFoundErr(err)).To(BeTrue())
+		Expect(ctrl.secrets).ToNot(HaveKey("env:team:app:clientSecret:"))
+	})
+})
+
+var _ = Describe("Tombstone", func() {
+
+	It("should encode and decode a tombstone", func() {
+		deletedAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		value, err := tombstone.Encode(tombstone.Tombstone{DeletedAt: deletedAt, Value: "topsecret"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(tombstone.IsTombstone(value)).To(BeTrue())
+
+		t, ok := tombstone.Decode(value)
+		Expect(ok).To(BeTrue())
+		Expect(t.DeletedAt).To(Equal(deletedAt))
+		Expect(t.Value).To(Equal("topsecret"))
+		Expect(t.Expired(deletedAt.Add(time.Hour), time.Hour)).To(BeTrue())
+		Expect(t.Expired(deletedAt.Add(time.Minute), time.Hour)).To(BeFalse())
+	})
+
+	It("should not decode other values", func() {
+		_, ok := tombstone.Decode(`{"value":"topsecret"}`)
+		Expect(ok).To(BeFalse())
+		_, ok = tombstone.Decode(`{"$tombstone":`)
+		Expect(ok).To(BeFalse())
+	})
+})
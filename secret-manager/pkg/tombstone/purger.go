@@ -0,0 +1,137 @@
+package tombstone
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	pkgerrors "github.com/pkg/errors"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/backend"
+	"github.com/telekom/controlplane-mono/secret-manager/pkg/controller"
+)
+
+// DefaultPurgeInterval is the default interval in which expired tombstones are purged
+const DefaultPurgeInterval = time.Hour
+
+// Purger deletes the secrets whose retention has passed from the backend
+type Purger struct {
+	ctrl   controller.Controller
+	scopes backend.ScopeLister
+
+	// Retention is the period in which deleted secrets can be restored
+	Retention time.Duration
+	// PurgeInterval is the interval in which all onboarded scopes are checked for expired tombstones
+	PurgeInterval time.Duration
+	now           func() time.Time
+}
+
+// NewPurger creates a purger that deletes the expired tombstones using the controller of the backend.
+// If scopes is nil, expired tombstones are only purged when they are read.
+func NewPurger(ctrl controller.Controller, scopes backend.ScopeLister, retention time.Duration) *Purger {
+	return &Purger{
+		ctrl:          ctrl,
+		scopes:        scopes,
+		Retention:     retention,
+		PurgeInterval: DefaultPurgeInterval,
+		now:           time.Now,
+	}
+}
+
+// WithClock replaces the clock of the purger, e.g. for tests
+func (p *Purger) WithClock(now func() time.Time) *Purger {
+	p.now = now
+	return p
+}
+
+// Purge deletes the expired tombstones of all onboarded scopes and returns how many have been deleted
+func (p *Purger) Purge(ctx context.Context) (int, error) {
+	if p.scopes == nil {
+		return 0, nil
+	}
+	scopes, err := p.scopes.ListScopes(ctx)
+	if err != nil {
+		return 0, pkgerrors.Wrap(err, "failed to list scopes")
+	}
+
+	count := 0
+	for _, scope := range scopes {
+		n, err := p.purgeScope(ctx, scope)
+		count += n
+		if err != nil {
+			return count, pkgerrors.Wrapf(err, "failed to purge scope %s", scope)
+		}
+	}
+	return count, nil
+}
+
+func (p *Purger) purgeScope(ctx context.Context, scope backend.Scope) (int, error) {
+	count := 0
+	req := controller.ListSecretsRequest{Env: scope.Env, Team: scope.Team, App: scope.App, Limit: controller.MaxListLimit}
+	for {
+		res, err := p.ctrl.ListSecrets(ctx, req)
+		if err != nil {
+			if backend.IsNotFoundErr(err) {
+				return count, nil
+			}
+			return count, err
+		}
+		for _, item := range res.Items {
+			ref := backend.ParsedId{Scope: scope, Path: item.Name}.String()
+			secret, err := p.ctrl.GetSecret(ctx, ref)
+			if err != nil {
+				if backend.IsNotFoundErr(err) {
+					continue
+				}
+				return count, err
+			}
+			purged, err := p.purgeExpired(ctx, ref, secret.Value)
+			if err != nil {
+				return count, err
+			}
+			if purged {
+				count++
+			}
+		}
+		if res.Next == "" {
+			return count, nil
+		}
+		req.Cursor = res.Next
+	}
+}
+
+// purgeExpired deletes the secret if its value is a tombstone whose retention has passed
+func (p *Purger) purgeExpired(ctx context.Context, ref, value string) (bool, error) {
+	t, ok := Decode(value)
+	if !ok || !t.Expired(p.now(), p.Retention) {
+		return false, nil
+	}
+	if err := p.ctrl.DeleteSecret(ctx, ref); err != nil && !backend.IsNotFoundErr(err) {
+		return false, pkgerrors.Wrapf(err, "failed to purge secret %s", ref)
+	}
+	logr.FromContextOrDiscard(ctx).Info("Purged deleted secret", "id", ref, "deletedAt", t.DeletedAt)
+	return true, nil
+}
+
+// Start purges the expired tombstones in the PurgeInterval until the context is done
+func (p *Purger) Start(ctx context.Context) {
+	log := logr.FromContextOrDiscard(ctx).WithName("tombstone")
+	ctx = logr.NewContext(ctx, log)
+
+	ticker := time.NewTicker(p.PurgeInterval)
+	defer ticker.Stop()
+	for {
+		count, err := p.Purge(ctx)
+		if err != nil {
+			log.Error(err, "Failed to purge deleted secrets")
+		}
+		if count > 0 {
+			log.Info("Purged deleted secrets", "count", count)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,58 @@
+// Package tombstone implements the soft-deletion of secrets.
+// A deleted secret is replaced by a tombstone in the backend, so it can be restored until its retention has passed.
+package tombstone
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+// DefaultRetention is the default period in which deleted secrets can be restored
+const DefaultRetention = 7 * 24 * time.Hour
+
+// prefix is the start of every encoded tombstone
+const prefix = `{"$tombstone":`
+
+// Tombstone is stored as value of a deleted secret until it is purged
+type Tombstone struct {
+	DeletedAt time.Time `json:"deletedAt"`
+	// Value is the value of the secret before it has been deleted
+	Value string `json:"value"`
+}
+
+type envelope struct {
+	Tombstone *Tombstone `json:"$tombstone"`
+}
+
+// Encode returns the value that is stored instead of the deleted secret
+func Encode(t Tombstone) (string, error) {
+	b, err := json.Marshal(envelope{Tombstone: &t})
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// Decode returns the tombstone if the value is one
+func Decode(value string) (Tombstone, bool) {
+	if !IsTombstone(value) {
+		return Tombstone{}, false
+	}
+	var e envelope
+	if err := json.Unmarshal([]byte(value), &e); err != nil || e.Tombstone == nil {
+		return Tombstone{}, false
+	}
+	return *e.Tombstone, true
+}
+
+// IsTombstone returns true if the value looks like a tombstone.
+// Such values are reserved and can not be set using the API.
+func IsTombstone(value string) bool {
+	return strings.HasPrefix(value, prefix)
+}
+
+// Expired returns true if the retention of the tombstone has passed at the given time
+func (t Tombstone) Expired(now time.Time, retention time.Duration) bool {
+	return !now.Before(t.DeletedAt.Add(retention))
+}
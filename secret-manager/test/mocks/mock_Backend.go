@@ -129,6 +129,67 @@ func (_c *MockBackend_Get_Call[T, S]) RunAndReturn(run func(context.Context, T)
 	return _c
 }
 
+// List provides a mock function with given fields: ctx, env, team, app
+func (_m *MockBackend[T, S]) List(ctx context.Context, env string, team string, app string) (map[string]T, error) {
+	ret := _m.Called(ctx, env, team, app)
+
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
+	var r0 map[string]T
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (map[string]T, error)); ok {
+		return rf(ctx, env, team, app)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) map[string]T); ok {
+		r0 = rf(ctx, env, team, app)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]T)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, env, team, app)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockBackend_List_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'List'
+type MockBackend_List_Call[T backend.SecretId, S backend.Secret[T]] struct {
+	*mock.Call
+}
+
+// List is a helper method to define mock.On call
+//   - ctx context.Context
+//   - env string
+//   - team string
+//   - app string
+func (_e *MockBackend_Expecter[T, S]) List(ctx interface{}, env interface{}, team interface{}, app interface{}) *MockBackend_List_Call[T, S] {
+	return &MockBackend_List_Call[T, S]{Call: _e.mock.On("List", ctx, env, team, app)}
+}
+
+func (_c *MockBackend_List_Call[T, S]) Run(run func(ctx context.Context, env string, team string, app string)) *MockBackend_List_Call[T, S] {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *MockBackend_List_Call[T, S]) Return(_a0 map[string]T, _a1 error) *MockBackend_List_Call[T, S] {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockBackend_List_Call[T, S]) RunAndReturn(run func(context.Context, string, string, string) (map[string]T, error)) *MockBackend_List_Call[T, S] {
+	_c.Call.Return(run)
+	return _c
+}
+
 // ParseSecretId provides a mock function with given fields: _a0
 func (_m *MockBackend[T, S]) ParseSecretId(_a0 string) (T, error) {
 	ret := _m.Called(_a0)
@@ -247,6 +308,65 @@ func (_c *MockBackend_Set_Call[T, S]) RunAndReturn(run func(context.Context, T,
 	return _c
 }
 
+// Versions provides a mock function with given fields: ctx, id
+func (_m *MockBackend[T, S]) Versions(ctx context.Context, id T) ([]T, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Versions")
+	}
+
+	var r0 []T
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, T) ([]T, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, T) []T); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]T)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, T) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockBackend_Versions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Versions'
+type MockBackend_Versions_Call[T backend.SecretId, S backend.Secret[T]] struct {
+	*mock.Call
+}
+
+// Versions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id T
+func (_e *MockBackend_Expecter[T, S]) Versions(ctx interface{}, id interface{}) *MockBackend_Versions_Call[T, S] {
+	return &MockBackend_Versions_Call[T, S]{Call: _e.mock.On("Versions", ctx, id)}
+}
+
+func (_c *MockBackend_Versions_Call[T, S]) Run(run func(ctx context.Context, id T)) *MockBackend_Versions_Call[T, S] {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(T))
+	})
+	return _c
+}
+
+func (_c *MockBackend_Versions_Call[T, S]) Return(_a0 []T, _a1 error) *MockBackend_Versions_Call[T, S] {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockBackend_Versions_Call[T, S]) RunAndReturn(run func(context.Context, T) ([]T, error)) *MockBackend_Versions_Call[T, S] {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockBackend creates a new instance of MockBackend. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockBackend[T backend.SecretId, S backend.Secret[T]](t interface {
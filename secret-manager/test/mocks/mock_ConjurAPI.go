@@ -130,6 +130,122 @@ func (_c *MockConjurAPI_LoadPolicy_Call) RunAndReturn(run func(conjurapi.PolicyM
 	return _c
 }
 
+// Resource provides a mock function with given fields: resourceID
+func (_m *MockConjurAPI) Resource(resourceID string) (map[string]interface{}, error) {
+	ret := _m.Called(resourceID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Resource")
+	}
+
+	var r0 map[string]interface{}
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (map[string]interface{}, error)); ok {
+		return rf(resourceID)
+	}
+	if rf, ok := ret.Get(0).(func(string) map[string]interface{}); ok {
+		r0 = rf(resourceID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]interface{})
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(resourceID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockConjurAPI_Resource_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Resource'
+type MockConjurAPI_Resource_Call struct {
+	*mock.Call
+}
+
+// Resource is a helper method to define mock.On call
+//   - resourceID string
+func (_e *MockConjurAPI_Expecter) Resource(resourceID interface{}) *MockConjurAPI_Resource_Call {
+	return &MockConjurAPI_Resource_Call{Call: _e.mock.On("Resource", resourceID)}
+}
+
+func (_c *MockConjurAPI_Resource_Call) Run(run func(resourceID string)) *MockConjurAPI_Resource_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockConjurAPI_Resource_Call) Return(_a0 map[string]interface{}, _a1 error) *MockConjurAPI_Resource_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockConjurAPI_Resource_Call) RunAndReturn(run func(string) (map[string]interface{}, error)) *MockConjurAPI_Resource_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Resources provides a mock function with given fields: filter
+func (_m *MockConjurAPI) Resources(filter *conjurapi.ResourceFilter) ([]map[string]interface{}, error) {
+	ret := _m.Called(filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Resources")
+	}
+
+	var r0 []map[string]interface{}
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*conjurapi.ResourceFilter) ([]map[string]interface{}, error)); ok {
+		return rf(filter)
+	}
+	if rf, ok := ret.Get(0).(func(*conjurapi.ResourceFilter) []map[string]interface{}); ok {
+		r0 = rf(filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]map[string]interface{})
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*conjurapi.ResourceFilter) error); ok {
+		r1 = rf(filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockConjurAPI_Resources_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Resources'
+type MockConjurAPI_Resources_Call struct {
+	*mock.Call
+}
+
+// Resources is a helper method to define mock.On call
+//   - filter *conjurapi.ResourceFilter
+func (_e *MockConjurAPI_Expecter) Resources(filter interface{}) *MockConjurAPI_Resources_Call {
+	return &MockConjurAPI_Resources_Call{Call: _e.mock.On("Resources", filter)}
+}
+
+func (_c *MockConjurAPI_Resources_Call) Run(run func(filter *conjurapi.ResourceFilter)) *MockConjurAPI_Resources_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*conjurapi.ResourceFilter))
+	})
+	return _c
+}
+
+func (_c *MockConjurAPI_Resources_Call) Return(_a0 []map[string]interface{}, _a1 error) *MockConjurAPI_Resources_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockConjurAPI_Resources_Call) RunAndReturn(run func(*conjurapi.ResourceFilter) ([]map[string]interface{}, error)) *MockConjurAPI_Resources_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // RetrieveBatchSecrets provides a mock function with given fields: variableIDs
 func (_m *MockConjurAPI) RetrieveBatchSecrets(variableIDs []string) (map[string][]byte, error) {
 	ret := _m.Called(variableIDs)
@@ -17,9 +17,9 @@ func (_m *MockEncrypter) EXPECT() *MockEncrypter_Expecter {
 	return &MockEncrypter_Expecter{mock: &_m.Mock}
 }
 
-// Decrypt provides a mock function with given fields: ciphertext
-func (_m *MockEncrypter) Decrypt(ciphertext string) (string, error) {
-	ret := _m.Called(ciphertext)
+// Decrypt provides a mock function with given fields: ciphertext, additionalData
+func (_m *MockEncrypter) Decrypt(ciphertext string, additionalData string) (string, error) {
+	ret := _m.Called(ciphertext, additionalData)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Decrypt")
@@ -27,17 +27,17 @@ func (_m *MockEncrypter) Decrypt(ciphertext string) (string, error) {
 
 	var r0 string
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (string, error)); ok {
-		return rf(ciphertext)
+	if rf, ok := ret.Get(0).(func(string, string) (string, error)); ok {
+		return rf(ciphertext, additionalData)
 	}
-	if rf, ok := ret.Get(0).(func(string) string); ok {
-		r0 = rf(ciphertext)
+	if rf, ok := ret.Get(0).(func(string, string) string); ok {
+		r0 = rf(ciphertext, additionalData)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(ciphertext)
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(ciphertext, additionalData)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -52,13 +52,14 @@ type MockEncrypter_Decrypt_Call struct {
 
 // Decrypt is a helper method to define mock.On call
 //   - ciphertext string
-func (_e *MockEncrypter_Expecter) Decrypt(ciphertext interface{}) *MockEncrypter_Decrypt_Call {
-	return &MockEncrypter_Decrypt_Call{Call: _e.mock.On("Decrypt", ciphertext)}
+//   - additionalData string
+func (_e *MockEncrypter_Expecter) Decrypt(ciphertext interface{}, additionalData interface{}) *MockEncrypter_Decrypt_Call {
+	return &MockEncrypter_Decrypt_Call{Call: _e.mock.On("Decrypt", ciphertext, additionalData)}
 }
 
-func (_c *MockEncrypter_Decrypt_Call) Run(run func(ciphertext string)) *MockEncrypter_Decrypt_Call {
+func (_c *MockEncrypter_Decrypt_Call) Run(run func(ciphertext string, additionalData string)) *MockEncrypter_Decrypt_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(string), args[1].(string))
 	})
 	return _c
 }
@@ -68,14 +69,14 @@ func (_c *MockEncrypter_Decrypt_Call) Return(_a0 string, _a1 error) *MockEncrypt
 	return _c
 }
 
-func (_c *MockEncrypter_Decrypt_Call) RunAndReturn(run func(string) (string, error)) *MockEncrypter_Decrypt_Call {
+func (_c *MockEncrypter_Decrypt_Call) RunAndReturn(run func(string, string) (string, error)) *MockEncrypter_Decrypt_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// Encrypt provides a mock function with given fields: plaintext
-func (_m *MockEncrypter) Encrypt(plaintext string) (string, error) {
-	ret := _m.Called(plaintext)
+// Encrypt provides a mock function with given fields: plaintext, additionalData
+func (_m *MockEncrypter) Encrypt(plaintext string, additionalData string) (string, error) {
+	ret := _m.Called(plaintext, additionalData)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Encrypt")
@@ -83,17 +84,17 @@ func (_m *MockEncrypter) Encrypt(plaintext string) (string, error) {
 
 	var r0 string
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (string, error)); ok {
-		return rf(plaintext)
+	if rf, ok := ret.Get(0).(func(string, string) (string, error)); ok {
+		return rf(plaintext, additionalData)
 	}
-	if rf, ok := ret.Get(0).(func(string) string); ok {
-		r0 = rf(plaintext)
+	if rf, ok := ret.Get(0).(func(string, string) string); ok {
+		r0 = rf(plaintext, additionalData)
 	} else {
 		r0 = ret.Get(0).(string)
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(plaintext)
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(plaintext, additionalData)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -108,13 +109,14 @@ type MockEncrypter_Encrypt_Call struct {
 
 // Encrypt is a helper method to define mock.On call
 //   - plaintext string
-func (_e *MockEncrypter_Expecter) Encrypt(plaintext interface{}) *MockEncrypter_Encrypt_Call {
-	return &MockEncrypter_Encrypt_Call{Call: _e.mock.On("Encrypt", plaintext)}
+//   - additionalData string
+func (_e *MockEncrypter_Expecter) Encrypt(plaintext interface{}, additionalData interface{}) *MockEncrypter_Encrypt_Call {
+	return &MockEncrypter_Encrypt_Call{Call: _e.mock.On("Encrypt", plaintext, additionalData)}
 }
 
-func (_c *MockEncrypter_Encrypt_Call) Run(run func(plaintext string)) *MockEncrypter_Encrypt_Call {
+func (_c *MockEncrypter_Encrypt_Call) Run(run func(plaintext string, additionalData string)) *MockEncrypter_Encrypt_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string))
+		run(args[0].(string), args[1].(string))
 	})
 	return _c
 }
@@ -124,7 +126,7 @@ func (_c *MockEncrypter_Encrypt_Call) Return(_a0 string, _a1 error) *MockEncrypt
 	return _c
 }
 
-func (_c *MockEncrypter_Encrypt_Call) RunAndReturn(run func(string) (string, error)) *MockEncrypter_Encrypt_Call {
+func (_c *MockEncrypter_Encrypt_Call) RunAndReturn(run func(string, string) (string, error)) *MockEncrypter_Encrypt_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -0,0 +1,271 @@
+// Package vaulttest provides an in-memory stand-in for the KV v2 secrets engine of Vault.
+// It implements the subset of the HTTP API that is used by the vault backend.
+package vaulttest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/vault/api"
+)
+
+const (
+	// Token is the only token that is accepted by the server
+	Token = "test-token"
+
+	defaultMaxVersions = 10
+)
+
+type version struct {
+	number  int
+	data    map[string]any
+	created time.Time
+}
+
+type secret struct {
+	versions    []version
+	maxVersions int
+}
+
+func (s *secret) current() int {
+	if len(s.versions) == 0 {
+		return 0
+	}
+	return s.versions[len(s.versions)-1].number
+}
+
+// Server is a KV v2 secrets engine that is mounted at MountPath.
+type Server struct {
+	*httptest.Server
+	MountPath string
+
+	mux     sync.Mutex
+	secrets map[string]*secret
+}
+
+// NewServer starts a new server. It must be closed by the caller.
+func NewServer(mountPath string) *Server {
+	s := &Server{
+		MountPath: mountPath,
+		secrets:   map[string]*secret{},
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+	return s
+}
+
+// NewClient returns a Vault client that is authenticated against the server
+func (s *Server) NewClient() (*api.Client, error) {
+	config := api.DefaultConfig()
+	config.Address = s.URL
+	config.MaxRetries = 0
+	client, err := api.NewClient(config)
+	if err != nil {
+		return nil, err
+	}
+	client.SetToken(Token)
+	return client, nil
+}
+
+// Data returns the data of the latest version of the secret at the path
+func (s *Server) Data(path string) (map[string]any, bool) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	sec, ok := s.secrets[path]
+	if !ok || len(sec.versions) == 0 {
+		return nil, false
+	}
+	return sec.versions[len(sec.versions)-1].data, true
+}
+
+// Paths returns the paths of all secrets, sorted
+func (s *Server) Paths() []string {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	paths := make([]string, 0, len(s.secrets))
+	for path := range s.secrets {
+		paths = append(paths, path)
+	}
+	slices.Sort(paths)
+	return paths
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("X-Vault-Token") != Token {
+		writeErrors(w, http.StatusForbidden, "permission denied")
+		return
+	}
+
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	prefix := "/v1/" + s.MountPath + "/"
+	rest, ok := strings.CutPrefix(r.URL.Path, prefix)
+	if !ok {
+		writeErrors(w, http.StatusNotFound, "no handler for route")
+		return
+	}
+	kind, path, _ := strings.Cut(rest, "/")
+	path = strings.Trim(path, "/")
+
+	switch {
+	case kind == "data" && r.Method == http.MethodGet:
+		s.readData(w, r, path)
+	case kind == "data" && (r.Method == http.MethodPost || r.Method == http.MethodPut):
+		s.writeData(w, r, path)
+	case kind == "metadata" && r.Method == http.MethodGet && r.URL.Query().Get("list") == "true":
+		s.list(w, path)
+	case kind == "metadata" && r.Method == http.MethodGet:
+		s.readMetadata(w, path)
+	case kind == "metadata" && (r.Method == http.MethodPost || r.Method == http.MethodPut):
+		s.writeMetadata(w, r, path)
+	case kind == "metadata" && r.Method == http.MethodDelete:
+		delete(s.secrets, path)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeErrors(w, http.StatusMethodNotAllowed, "unsupported operation")
+	}
+}
+
+func (s *Server) readData(w http.ResponseWriter, r *http.Request, path string) {
+	sec, ok := s.secrets[path]
+	if !ok || len(sec.versions) == 0 {
+		writeErrors(w, http.StatusNotFound)
+		return
+	}
+	number := sec.current()
+	if raw := r.URL.Query().Get("version"); raw != "" && raw != "0" {
+		number, _ = strconv.Atoi(raw)
+	}
+	for _, v := range sec.versions {
+		if v.number == number {
+			writeData(w, http.StatusOK, map[string]any{
+				"data":     v.data,
+				"metadata": versionMetadata(v),
+			})
+			return
+		}
+	}
+	writeErrors(w, http.StatusNotFound)
+}
+
+func (s *Server) writeData(w http.ResponseWriter, r *http.Request, path string) {
+	body := struct {
+		Data    map[string]any `json:"data"`
+		Options map[string]any `json:"options"`
+	}{}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeErrors(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	sec, ok := s.secrets[path]
+	if !ok {
+		sec = &secret{maxVersions: defaultMaxVersions}
+	}
+	if cas, ok := body.Options["cas"].(float64); ok && int(cas) != sec.current() {
+		writeErrors(w, http.StatusBadRequest, "check-and-set parameter did not match the current version")
+		return
+	}
+
+	v := version{number: sec.current() + 1, data: body.Data, created: time.Now().UTC()}
+	sec.versions = append(sec.versions, v)
+	if len(sec.versions) > sec.maxVersions {
+		sec.versions = sec.versions[len(sec.versions)-sec.maxVersions:]
+	}
+	s.secrets[path] = sec
+	writeData(w, http.StatusOK, versionMetadata(v))
+}
+
+func (s *Server) readMetadata(w http.ResponseWriter, path string) {
+	sec, ok := s.secrets[path]
+	if !ok {
+		writeErrors(w, http.StatusNotFound)
+		return
+	}
+	versions := map[string]any{}
+	for _, v := range sec.versions {
+		versions[strconv.Itoa(v.number)] = versionMetadata(v)
+	}
+	writeData(w, http.StatusOK, map[string]any{
+		"current_version": sec.current(),
+		"oldest_version":  sec.versions[0].number,
+		"max_versions":    sec.maxVersions,
+		"versions":        versions,
+	})
+}
+
+func (s *Server) writeMetadata(w http.ResponseWriter, r *http.Request, path string) {
+	body := struct {
+		MaxVersions int `json:"max_versions"`
+	}{}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeErrors(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	sec, ok := s.secrets[path]
+	if !ok {
+		sec = &secret{}
+		s.secrets[path] = sec
+	}
+	sec.maxVersions = body.MaxVersions
+	if sec.maxVersions <= 0 {
+		sec.maxVersions = defaultMaxVersions
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// list returns the names of the secrets and folders directly below the path.
+// Like Vault, a path can be both a secret and a folder.
+func (s *Server) list(w http.ResponseWriter, path string) {
+	prefix := ""
+	if path != "" {
+		prefix = path + "/"
+	}
+	keys := []string{}
+	for secretPath := range s.secrets {
+		rest, ok := strings.CutPrefix(secretPath, prefix)
+		if !ok || rest == "" {
+			continue
+		}
+		key := rest
+		if name, _, nested := strings.Cut(rest, "/"); nested {
+			key = name + "/"
+		}
+		if !slices.Contains(keys, key) {
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) == 0 {
+		writeErrors(w, http.StatusNotFound)
+		return
+	}
+	slices.Sort(keys)
+	writeData(w, http.StatusOK, map[string]any{"keys": keys})
+}
+
+func versionMetadata(v version) map[string]any {
+	return map[string]any{
+		"version":       v.number,
+		"created_time":  v.created.Format(time.RFC3339Nano),
+		"deletion_time": "",
+		"destroyed":     false,
+	}
+}
+
+func writeData(w http.ResponseWriter, status int, data any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]any{"data": data})
+}
+
+func writeErrors(w http.ResponseWriter, status int, errs ...string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]any{"errors": append([]string{}, errs...)})
+}
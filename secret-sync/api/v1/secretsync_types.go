@@ -0,0 +1,120 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"github.com/telekom/controlplane-mono/common/pkg/types"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// AllowedScopesAnnotation binds a namespace to the environments, teams or applications of the secret-manager
+	// whose secrets its SecretSyncs may reference, e.g. "poc:my-team,poc:other-team:my-app".
+	// The SecretSyncs of namespaces without the annotation can not reference any secret.
+	AllowedScopesAnnotation = "secretmanager.cp.ei.telekom.de/allowed-scopes"
+
+	// ManagedByLabel is set on the Kubernetes Secrets of the SecretSyncs. Only these Secrets are cached by the operator.
+	ManagedByLabel = "app.kubernetes.io/managed-by"
+	ManagedByValue = "secret-sync"
+)
+
+// SecretSyncItem maps a secret of the secret-manager to a key of the Kubernetes Secret
+type SecretSyncItem struct {
+	// Key of the data in the Kubernetes Secret
+	// +kubebuilder:validation:Pattern=`^[-._a-zA-Z0-9]+$`
+	Key string `json:"key"`
+	// Ref is the reference to the secret, e.g. $<env:team:app:clientSecret:1234>.
+	// The checksum is ignored, so the latest version of the secret is always synced.
+	Ref string `json:"ref"`
+}
+
+// SecretSyncSpec defines the desired state of SecretSync
+type SecretSyncSpec struct {
+	// SecretName is the name of the Kubernetes Secret in the namespace of the SecretSync
+	// +kubebuilder:validation:MinLength=1
+	SecretName string `json:"secretName"`
+	// Type of the Kubernetes Secret. It is only used when the Secret is created.
+	// +kubebuilder:default=Opaque
+	// +optional
+	Type corev1.SecretType `json:"type,omitempty"`
+	// +listType=map
+	// +listMapKey=key
+	// +kubebuilder:validation:MinItems=1
+	Secrets []SecretSyncItem `json:"secrets"`
+}
+
+// SecretSyncStatus defines the observed state of SecretSync
+type SecretSyncStatus struct {
+	// +listType=map
+	// +listMapKey=type
+	// +patchStrategy=merge
+	// +patchMergeKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,1,rep,name=conditions"`
+	// LastSyncTime is the time when the data of the Kubernetes Secret has changed the last time
+	// +optional
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Secret",type="string",JSONPath=".spec.secretName"
+// +kubebuilder:printcolumn:name="Last Sync",type="date",JSONPath=".status.lastSyncTime"
+
+// SecretSync is the Schema for the secretsyncs API
+type SecretSync struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SecretSyncSpec   `json:"spec,omitempty"`
+	Status SecretSyncStatus `json:"status,omitempty"`
+}
+
+var _ types.Object = &SecretSync{}
+
+func (e *SecretSync) GetConditions() []metav1.Condition {
+	return e.Status.Conditions
+}
+
+func (e *SecretSync) SetCondition(condition metav1.Condition) bool {
+	return meta.SetStatusCondition(&e.Status.Conditions, condition)
+}
+
+// +kubebuilder:object:root=true
+
+// SecretSyncList contains a list of SecretSync
+type SecretSyncList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SecretSync `json:"items"`
+}
+
+var _ types.ObjectList = &SecretSyncList{}
+
+func (el *SecretSyncList) GetItems() []types.Object {
+	items := make([]types.Object, len(el.Items))
+	for i := range el.Items {
+		items[i] = &el.Items[i]
+	}
+	return items
+}
+
+func init() {
+	SchemeBuilder.Register(&SecretSync{}, &SecretSyncList{})
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	commonController "github.com/telekom/controlplane-mono/common/pkg/controller"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	secrets "github.com/telekom/controlplane-mono/secret-manager/pkg/api"
+	secretsyncv1 "github.com/telekom/controlplane-mono/secret-sync/api/v1"
+	secretSyncHandler "github.com/telekom/controlplane-mono/secret-sync/internal/handler/secretsync"
+)
+
+// SecretSyncReconciler reconciles a SecretSync object
+type SecretSyncReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+	// Secrets is used to resolve and watch the secrets of the secret-manager
+	Secrets secrets.SecretManager
+
+	commonController.Controller[*secretsyncv1.SecretSync]
+}
+
+// +kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups=core,resources=namespaces,verbs=get;list;watch
+// +kubebuilder:rbac:groups=secretmanager.cp.ei.telekom.de,resources=secretsyncs,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=secretmanager.cp.ei.telekom.de,resources=secretsyncs/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=secretmanager.cp.ei.telekom.de,resources=secretsyncs/finalizers,verbs=update
+
+func (r *SecretSyncReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	return r.Controller.Reconcile(ctx, req, &secretsyncv1.SecretSync{})
+}
+
+// SetupWithManager sets up the controller with the Manager.
+// The SecretSyncs are reconciled again whenever one of their secrets changes in the secret-manager.
+// The cache of the manager must be limited to the Kubernetes Secrets with the ManagedByLabel, see CacheOptions.
+func (r *SecretSyncReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	r.Recorder = mgr.GetEventRecorderFor("secretsync-controller")
+	r.Controller = commonController.NewController(&secretSyncHandler.HandlerSecretSync{Secrets: r.Secrets, Namespaces: r.Client}, r.Client, r.Recorder)
+
+	events := make(chan event.GenericEvent, watchBufferLength)
+	if err := mgr.Add(&SecretWatcher{Client: r.Client, Watch: r.Secrets, Events: events}); err != nil {
+		return err
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&secretsyncv1.SecretSync{}).
+		Owns(&corev1.Secret{}).
+		WatchesRawSource(source.Channel(events, &handler.EnqueueRequestForObject{})).
+		WithOptions(controller.Options{
+			MaxConcurrentReconciles: 10,
+			RateLimiter:             workqueue.DefaultTypedItemBasedRateLimiter[reconcile.Request](),
+		}).
+		Complete(r)
+}
+
+// CacheOptions limits the cache of the manager to the Kubernetes Secrets of the SecretSyncs,
+// so the operator neither lists nor keeps all Secrets of the cluster
+func CacheOptions() cache.Options {
+	return cache.Options{
+		ByObject: map[client.Object]cache.ByObject{
+			&corev1.Secret{}: {
+				Label: labels.SelectorFromSet(labels.Set{secretsyncv1.ManagedByLabel: secretsyncv1.ManagedByValue}),
+			},
+		},
+	}
+}
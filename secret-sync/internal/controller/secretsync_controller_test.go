@@ -0,0 +1,145 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/telekom/controlplane-mono/common/pkg/condition"
+	"github.com/telekom/controlplane-mono/common/pkg/config"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8sclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	secrets "github.com/telekom/controlplane-mono/secret-manager/pkg/api"
+	secretsyncv1 "github.com/telekom/controlplane-mono/secret-sync/api/v1"
+)
+
+// fakeSecretManager stores the secrets by their ID without checksum and sends a change event on every update
+type fakeSecretManager struct {
+	secrets.SecretManager
+	lock    sync.Mutex
+	values  map[string]string
+	changes chan secrets.ChangeEvent
+}
+
+func newFakeSecretManager() *fakeSecretManager {
+	return &fakeSecretManager{
+		values:  map[string]string{},
+		changes: make(chan secrets.ChangeEvent, 10),
+	}
+}
+
+func (f *fakeSecretManager) set(id, value string) {
+	f.lock.Lock()
+	f.values[id] = value
+	f.lock.Unlock()
+	f.changes <- secrets.ChangeEvent{Type: secrets.EventTypeUpdated, Secret: id}
+}
+
+func (f *fakeSecretManager) GetMany(_ context.Context, ids []string) (map[string]string, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	values := map[string]string{}
+	batchErr := &secrets.BatchError{Errors: map[string]error{}}
+	for _, id := range ids {
+		value, ok := f.values[id]
+		if !ok {
+			batchErr.Errors[id] = secrets.ErrNotFound
+			continue
+		}
+		values[id] = value
+	}
+	if len(batchErr.Errors) > 0 {
+		return values, batchErr
+	}
+	return values, nil
+}
+
+func (f *fakeSecretManager) Watch(_ context.Context, _ string) (<-chan secrets.ChangeEvent, error) {
+	return f.changes, nil
+}
+
+func NewSecretSync(name, secretName string, items ...secretsyncv1.SecretSyncItem) *secretsyncv1.SecretSync {
+	return &secretsyncv1.SecretSync{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: testNamespace,
+			Labels: map[string]string{
+				config.EnvironmentLabelKey: testEnvironment,
+			},
+		},
+		Spec: secretsyncv1.SecretSyncSpec{
+			SecretName: secretName,
+			Secrets:    items,
+		},
+	}
+}
+
+var _ = Describe("SecretSync Controller", func() {
+	Context("When reconciling a resource", func() {
+		ctx := context.Background()
+
+		syncRef := k8sclient.ObjectKey{Name: "test-sync", Namespace: testNamespace}
+		secretRef := k8sclient.ObjectKey{Name: "test-secret", Namespace: testNamespace}
+
+		BeforeEach(func() {
+			secretManager.set("test:my-team:my-app:clientSecret:", "topsecret")
+
+			By("creating the custom resource for the Kind SecretSync")
+			sync := NewSecretSync(syncRef.Name, secretRef.Name, secretsyncv1.SecretSyncItem{
+				Key: "clientSecret",
+				Ref: "$<test:my-team:my-app:clientSecret:1234>",
+			})
+			Expect(k8sClient.Create(ctx, sync)).To(Succeed())
+		})
+
+		AfterEach(func() {
+			By("Cleanup the specific resource instance SecretSync")
+			sync := &secretsyncv1.SecretSync{}
+			Expect(k8sClient.Get(ctx, syncRef, sync)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, sync)).To(Succeed())
+		})
+
+		It("should create the secret and update it when the secret changes", func() {
+			Eventually(func(g Gomega) {
+				sync := &secretsyncv1.SecretSync{}
+				g.Expect(k8sClient.Get(ctx, syncRef, sync)).To(Succeed())
+				g.Expect(meta.IsStatusConditionTrue(sync.Status.Conditions, condition.ConditionTypeReady)).To(BeTrue())
+				g.Expect(sync.Status.LastSyncTime).ToNot(BeNil())
+
+				secret := &corev1.Secret{}
+				g.Expect(k8sClient.Get(ctx, secretRef, secret)).To(Succeed())
+				g.Expect(secret.Data).To(HaveKeyWithValue("clientSecret", []byte("topsecret")))
+				g.Expect(metav1.IsControlledBy(secret, sync)).To(BeTrue())
+			}, timeout, interval).Should(Succeed())
+
+			By("rotating the secret in the secret-manager")
+			secretManager.set("test:my-team:my-app:clientSecret:", "rotated")
+
+			Eventually(func(g Gomega) {
+				secret := &corev1.Secret{}
+				g.Expect(k8sClient.Get(ctx, secretRef, secret)).To(Succeed())
+				g.Expect(secret.Data).To(HaveKeyWithValue("clientSecret", []byte("rotated")))
+			}, timeout, interval).Should(Succeed())
+		})
+	})
+})
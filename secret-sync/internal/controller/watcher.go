@@ -0,0 +1,101 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	secrets "github.com/telekom/controlplane-mono/secret-manager/pkg/api"
+	secretsyncv1 "github.com/telekom/controlplane-mono/secret-sync/api/v1"
+)
+
+const (
+	watchBufferLength = 100
+	minWatchBackoff   = time.Second
+	maxWatchBackoff   = 30 * time.Second
+)
+
+var _ manager.Runnable = &SecretWatcher{}
+
+// SecretWatcher watches the changes of all secrets in the secret-manager
+// and sends an event for every SecretSync that references a changed secret.
+type SecretWatcher struct {
+	Client client.Client
+	Watch  secrets.WatchApi
+	Events chan<- event.GenericEvent
+}
+
+func (w *SecretWatcher) Start(ctx context.Context) error {
+	logger := log.FromContext(ctx).WithName("secret-watcher")
+	ctx = log.IntoContext(ctx, logger)
+
+	backoff := minWatchBackoff
+	for ctx.Err() == nil {
+		changes, err := w.Watch.Watch(ctx, "")
+		if err != nil {
+			logger.Error(err, "Failed to watch secrets", "retryAfter", backoff)
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-time.After(backoff):
+			}
+			backoff = min(2*backoff, maxWatchBackoff)
+			continue
+		}
+		backoff = minWatchBackoff
+
+		// The channel is only closed when the context is done as failed requests are retried by the client
+		for change := range changes {
+			if err := w.handle(ctx, change); err != nil {
+				logger.Error(err, "Failed to handle secret change", "secret", change.Secret)
+			}
+		}
+	}
+	return nil
+}
+
+func (w *SecretWatcher) handle(ctx context.Context, change secrets.ChangeEvent) error {
+	list := &secretsyncv1.SecretSyncList{}
+	if err := w.Client.List(ctx, list); err != nil {
+		return err
+	}
+
+	for i := range list.Items {
+		sync := &list.Items[i]
+		affected := slices.ContainsFunc(sync.Spec.Secrets, func(item secretsyncv1.SecretSyncItem) bool {
+			return change.Affects(item.Ref)
+		})
+		if !affected {
+			continue
+		}
+		log.FromContext(ctx).V(1).Info("Secret has changed", "secret", change.Secret, "type", change.Type,
+			"namespace", sync.Namespace, "name", sync.Name)
+		select {
+		case <-ctx.Done():
+			return nil
+		case w.Events <- event.GenericEvent{Object: sync}:
+		}
+	}
+	return nil
+}
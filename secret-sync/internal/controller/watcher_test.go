@@ -0,0 +1,84 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	secrets "github.com/telekom/controlplane-mono/secret-manager/pkg/api"
+	secretsyncv1 "github.com/telekom/controlplane-mono/secret-sync/api/v1"
+)
+
+var _ = Describe("SecretWatcher", func() {
+
+	var events chan event.GenericEvent
+	var watcher *SecretWatcher
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(secretsyncv1.AddToScheme(scheme)).To(Succeed())
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			NewSecretSync("app-sync", "app-secret", secretsyncv1.SecretSyncItem{Key: "clientSecret", Ref: "$<test:my-team:my-app:clientSecret:1234>"}),
+			NewSecretSync("team-sync", "team-secret", secretsyncv1.SecretSyncItem{Key: "clientSecret", Ref: "$<test:my-team::clientSecret:1234>"}),
+		).Build()
+		events = make(chan event.GenericEvent, 10)
+		watcher = &SecretWatcher{Client: c, Events: events}
+	})
+
+	names := func() []string {
+		res := []string{}
+		for len(events) > 0 {
+			res = append(res, (<-events).Object.GetName())
+		}
+		return res
+	}
+
+	It("should send an event for the affected SecretSyncs", func() {
+		Expect(watcher.handle(context.Background(), secrets.ChangeEvent{
+			Type:   secrets.EventTypeUpdated,
+			Secret: "test:my-team:my-app:clientSecret:",
+		})).To(Succeed())
+		Expect(names()).To(ConsistOf("app-sync"))
+	})
+
+	It("should send an event for all SecretSyncs of a deleted team", func() {
+		Expect(watcher.handle(context.Background(), secrets.ChangeEvent{
+			Type:   secrets.EventTypeDeleted,
+			Secret: "test:my-team:",
+		})).To(Succeed())
+		Expect(names()).To(ConsistOf("app-sync", "team-sync"))
+	})
+
+	It("should send an event for all SecretSyncs on reset", func() {
+		Expect(watcher.handle(context.Background(), secrets.ChangeEvent{Type: secrets.EventTypeReset})).To(Succeed())
+		Expect(names()).To(ConsistOf("app-sync", "team-sync"))
+	})
+
+	It("should ignore unrelated secrets", func() {
+		Expect(watcher.handle(context.Background(), secrets.ChangeEvent{
+			Type:   secrets.EventTypeUpdated,
+			Secret: "test:other-team::clientSecret:",
+		})).To(Succeed())
+		Expect(names()).To(BeEmpty())
+	})
+})
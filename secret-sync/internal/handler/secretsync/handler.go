@@ -0,0 +1,147 @@
+package secretsync
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	cc "github.com/telekom/controlplane-mono/common/pkg/client"
+	"github.com/telekom/controlplane-mono/common/pkg/handler"
+	"github.com/telekom/controlplane-mono/common/pkg/util/contextutil"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	secrets "github.com/telekom/controlplane-mono/secret-manager/pkg/api"
+	secretsyncv1 "github.com/telekom/controlplane-mono/secret-sync/api/v1"
+)
+
+const separator = ":"
+
+var _ handler.Handler[*secretsyncv1.SecretSync] = &HandlerSecretSync{}
+
+// errNotOwned is returned if the Kubernetes Secret already exists but is not controlled by the SecretSync
+var errNotOwned = errors.New("secret is not controlled by this SecretSync")
+
+type HandlerSecretSync struct {
+	Secrets secrets.SecretsApi
+	// Namespaces is used to read the allowed scopes of the namespaces of the SecretSyncs
+	Namespaces client.Reader
+}
+
+func (h *HandlerSecretSync) CreateOrUpdate(ctx context.Context, sync *secretsyncv1.SecretSync) error {
+	logger := log.FromContext(ctx)
+
+	if sync == nil {
+		return fmt.Errorf("secretSync is nil")
+	}
+
+	SetStatusProcessing(sync)
+
+	if err := h.checkScopes(ctx, sync); err != nil {
+		if errors.Is(err, errForbidden) {
+			contextutil.RecorderFromContextOrDie(ctx).
+				Eventf(sync, "Warning", "Forbidden", "%s", err.Error())
+			SetStatusForbidden(sync)
+			return nil
+		}
+		return errors.Wrap(err, "❌ failed to check allowed scopes")
+	}
+
+	data, err := h.resolve(ctx, sync)
+	if err != nil {
+		if errors.Is(err, secrets.ErrNotFound) {
+			contextutil.RecorderFromContextOrDie(ctx).
+				Eventf(sync, "Warning", "SecretNotFound", "%s", err.Error())
+			SetStatusSecretNotFound(sync)
+			return nil
+		}
+		return errors.Wrap(err, "❌ failed to resolve secrets")
+	}
+
+	c := cc.ClientFromContextOrDie(ctx)
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      sync.Spec.SecretName,
+			Namespace: sync.Namespace,
+		},
+	}
+	// Secrets that are not managed by the operator are not cached, so creating them fails if they already exist
+	res, err := c.CreateOrUpdate(ctx, secret, func() error {
+		// Existing secrets of others must never be overwritten
+		exists := secret.ResourceVersion != ""
+		if exists && !metav1.IsControlledBy(secret, sync) {
+			return errNotOwned
+		}
+		if err := controllerutil.SetControllerReference(sync, secret, c.Scheme()); err != nil {
+			return err
+		}
+		if !exists {
+			secret.Type = sync.Spec.Type
+		}
+		if secret.Labels == nil {
+			secret.Labels = map[string]string{}
+		}
+		secret.Labels[secretsyncv1.ManagedByLabel] = secretsyncv1.ManagedByValue
+		secret.Data = data
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, errNotOwned) || apierrors.IsAlreadyExists(err) {
+			contextutil.RecorderFromContextOrDie(ctx).
+				Eventf(sync, "Warning", "SecretNotOwned",
+					"Secret '%s' already exists and is not controlled by this SecretSync", sync.Spec.SecretName)
+			SetStatusNotOwned(sync)
+			return nil
+		}
+		return errors.Wrap(err, "❌ failed to create or update secret")
+	}
+
+	if res != controllerutil.OperationResultNone {
+		now := metav1.Now()
+		sync.Status.LastSyncTime = &now
+	}
+
+	SetStatusReady(sync)
+	var message = fmt.Sprintf("✅ Secret %s is synced", sync.Spec.SecretName)
+	logger.V(1).Info(message, "result", res)
+
+	return nil
+}
+
+func (h *HandlerSecretSync) Delete(ctx context.Context, sync *secretsyncv1.SecretSync) error {
+	// The Kubernetes Secret is deleted by the garbage collector as it is controlled by the SecretSync
+	return nil
+}
+
+// resolve returns the latest values of all secrets by their keys
+func (h *HandlerSecretSync) resolve(ctx context.Context, sync *secretsyncv1.SecretSync) (map[string][]byte, error) {
+	ids := make([]string, len(sync.Spec.Secrets))
+	for i, item := range sync.Spec.Secrets {
+		ids[i] = Latest(item.Ref)
+	}
+
+	values, err := h.Secrets.GetMany(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	data := make(map[string][]byte, len(ids))
+	for i, item := range sync.Spec.Secrets {
+		data[item.Key] = []byte(values[ids[i]])
+	}
+	return data, nil
+}
+
+// Latest returns the ID of the latest version of the referenced secret by removing its checksum
+func Latest(ref string) string {
+	id, _ := secrets.FromRef(ref)
+	if strings.Count(id, separator) != 4 {
+		return id
+	}
+	return id[:strings.LastIndex(id, separator)+1]
+}
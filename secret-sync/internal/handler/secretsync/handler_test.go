@@ -0,0 +1,207 @@
+package secretsync
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	cc "github.com/telekom/controlplane-mono/common/pkg/client"
+	"github.com/telekom/controlplane-mono/common/pkg/condition"
+	"github.com/telekom/controlplane-mono/common/pkg/util/contextutil"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	secrets "github.com/telekom/controlplane-mono/secret-manager/pkg/api"
+	secretsFake "github.com/telekom/controlplane-mono/secret-manager/pkg/api/fake"
+	secretsyncv1 "github.com/telekom/controlplane-mono/secret-sync/api/v1"
+)
+
+func newTestScheme(t *testing.T) *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, secretsyncv1.AddToScheme(scheme))
+	return scheme
+}
+
+func newSecretSync() *secretsyncv1.SecretSync {
+	return &secretsyncv1.SecretSync{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-sync", Namespace: "default", UID: "sync-uid"},
+		Spec: secretsyncv1.SecretSyncSpec{
+			SecretName: "my-secret",
+			Type:       corev1.SecretTypeOpaque,
+			Secrets: []secretsyncv1.SecretSyncItem{
+				{Key: "clientSecret", Ref: "$<test:my-team:my-app:clientSecret:1234>"},
+				{Key: "password", Ref: "$<test:my-team:my-app:externalSecrets/db:1234>"},
+			},
+		},
+	}
+}
+
+// newNamespace returns the namespace of the SecretSyncs that may reference the secrets of the scopes
+func newNamespace(scopes string) *corev1.Namespace {
+	return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+		Name:        "default",
+		Annotations: map[string]string{secretsyncv1.AllowedScopesAnnotation: scopes},
+	}}
+}
+
+func newContext(c client.Client) context.Context {
+	ctx := cc.WithClient(context.Background(), cc.NewJanitorClient(cc.NewScopedClient(c, "test")))
+	return contextutil.WithRecorder(ctx, record.NewFakeRecorder(10))
+}
+
+func TestLatestRemovesChecksum(t *testing.T) {
+	assert.Equal(t, "test:my-team:my-app:clientSecret:", Latest("$<test:my-team:my-app:clientSecret:1234>"))
+	assert.Equal(t, "test:my-team:my-app:clientSecret:", Latest("test:my-team:my-app:clientSecret:"))
+	assert.Equal(t, "invalid", Latest("invalid"))
+}
+
+func TestCreateOrUpdateCreatesSecret(t *testing.T) {
+	sync := newSecretSync()
+	c := fake.NewClientBuilder().WithScheme(newTestScheme(t)).WithObjects(newNamespace("test:my-team")).Build()
+	secretManager := secretsFake.NewMockSecretManager(t)
+	secretManager.EXPECT().GetMany(mock.Anything, []string{
+		"test:my-team:my-app:clientSecret:",
+		"test:my-team:my-app:externalSecrets/db:",
+	}).Return(map[string]string{
+		"test:my-team:my-app:clientSecret:":       "topsecret",
+		"test:my-team:my-app:externalSecrets/db:": "password",
+	}, nil)
+	h := &HandlerSecretSync{Secrets: secretManager, Namespaces: c}
+
+	err := h.CreateOrUpdate(newContext(c), sync)
+
+	require.NoError(t, err)
+	assert.True(t, meta.IsStatusConditionTrue(sync.GetConditions(), condition.ConditionTypeReady))
+	assert.NotNil(t, sync.Status.LastSyncTime)
+
+	secret := &corev1.Secret{}
+	require.NoError(t, c.Get(context.Background(), client.ObjectKey{Name: "my-secret", Namespace: "default"}, secret))
+	assert.Equal(t, corev1.SecretTypeOpaque, secret.Type)
+	assert.Equal(t, map[string][]byte{"clientSecret": []byte("topsecret"), "password": []byte("password")}, secret.Data)
+	assert.True(t, metav1.IsControlledBy(secret, sync))
+	assert.Equal(t, secretsyncv1.ManagedByValue, secret.Labels[secretsyncv1.ManagedByLabel])
+}
+
+func TestCreateOrUpdateKeepsLastSyncTimeIfUnchanged(t *testing.T) {
+	sync := newSecretSync()
+	c := fake.NewClientBuilder().WithScheme(newTestScheme(t)).WithObjects(newNamespace("test:my-team")).Build()
+	secretManager := secretsFake.NewMockSecretManager(t)
+	secretManager.EXPECT().GetMany(mock.Anything, []string{
+		"test:my-team:my-app:clientSecret:",
+		"test:my-team:my-app:externalSecrets/db:",
+	}).Return(map[string]string{
+		"test:my-team:my-app:clientSecret:":       "topsecret",
+		"test:my-team:my-app:externalSecrets/db:": "password",
+	}, nil)
+	h := &HandlerSecretSync{Secrets: secretManager, Namespaces: c}
+
+	require.NoError(t, h.CreateOrUpdate(newContext(c), sync))
+	lastSyncTime := metav1.NewTime(sync.Status.LastSyncTime.Add(-1))
+	sync.Status.LastSyncTime = &lastSyncTime
+
+	require.NoError(t, h.CreateOrUpdate(newContext(c), sync))
+	assert.Equal(t, &lastSyncTime, sync.Status.LastSyncTime)
+}
+
+func TestCreateOrUpdateBlocksIfSecretIsNotFound(t *testing.T) {
+	sync := newSecretSync()
+	c := fake.NewClientBuilder().WithScheme(newTestScheme(t)).WithObjects(newNamespace("test:my-team")).Build()
+	secretManager := secretsFake.NewMockSecretManager(t)
+	secretManager.EXPECT().GetMany(mock.Anything, []string{
+		"test:my-team:my-app:clientSecret:",
+		"test:my-team:my-app:externalSecrets/db:",
+	}).Return(map[string]string{
+		"test:my-team:my-app:clientSecret:": "topsecret",
+	}, &secrets.BatchError{Errors: map[string]error{"test:my-team:my-app:externalSecrets/db:": secrets.ErrNotFound}})
+	h := &HandlerSecretSync{Secrets: secretManager, Namespaces: c}
+
+	err := h.CreateOrUpdate(newContext(c), sync)
+
+	require.NoError(t, err)
+	assert.Equal(t, "SecretNotFound", meta.FindStatusCondition(sync.GetConditions(), condition.ConditionTypeReady).Reason)
+	err = c.Get(context.Background(), client.ObjectKey{Name: "my-secret", Namespace: "default"}, &corev1.Secret{})
+	assert.True(t, apierrors.IsNotFound(err))
+}
+
+func TestCreateOrUpdateDoesNotOverwriteForeignSecret(t *testing.T) {
+	sync := newSecretSync()
+	foreign := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-secret", Namespace: "default"},
+		Data:       map[string][]byte{"foo": []byte("bar")},
+	}
+	c := fake.NewClientBuilder().WithScheme(newTestScheme(t)).WithObjects(newNamespace("test:my-team"), foreign).Build()
+	secretManager := secretsFake.NewMockSecretManager(t)
+	secretManager.EXPECT().GetMany(mock.Anything, []string{
+		"test:my-team:my-app:clientSecret:",
+		"test:my-team:my-app:externalSecrets/db:",
+	}).Return(map[string]string{
+		"test:my-team:my-app:clientSecret:":       "topsecret",
+		"test:my-team:my-app:externalSecrets/db:": "password",
+	}, nil)
+	h := &HandlerSecretSync{Secrets: secretManager, Namespaces: c}
+
+	err := h.CreateOrUpdate(newContext(c), sync)
+
+	require.NoError(t, err)
+	assert.Equal(t, "SecretNotOwned", meta.FindStatusCondition(sync.GetConditions(), condition.ConditionTypeReady).Reason)
+	secret := &corev1.Secret{}
+	require.NoError(t, c.Get(context.Background(), client.ObjectKeyFromObject(foreign), secret))
+	assert.Equal(t, foreign.Data, secret.Data)
+}
+
+func TestCreateOrUpdateRejectsSecretsOfOtherTeams(t *testing.T) {
+	for name, namespace := range map[string]*corev1.Namespace{
+		"other team":            newNamespace("test:other-team"),
+		"team with same prefix": newNamespace("test:my-te"),
+		"other application":     newNamespace("test:my-team:other-app"),
+		"other environment":     newNamespace("prod:my-team"),
+		"no annotation":         {ObjectMeta: metav1.ObjectMeta{Name: "default"}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			sync := newSecretSync()
+			c := fake.NewClientBuilder().WithScheme(newTestScheme(t)).WithObjects(namespace).Build()
+			// The secret-manager must never be called
+			secretManager := secretsFake.NewMockSecretManager(t)
+			h := &HandlerSecretSync{Secrets: secretManager, Namespaces: c}
+
+			err := h.CreateOrUpdate(newContext(c), sync)
+
+			require.NoError(t, err)
+			assert.Equal(t, "Forbidden", meta.FindStatusCondition(sync.GetConditions(), condition.ConditionTypeReady).Reason)
+			err = c.Get(context.Background(), client.ObjectKey{Name: "my-secret", Namespace: "default"}, &corev1.Secret{})
+			assert.True(t, apierrors.IsNotFound(err))
+		})
+	}
+}
+
+func TestCreateOrUpdateRejectsIfOneSecretIsNotAllowed(t *testing.T) {
+	sync := newSecretSync()
+	sync.Spec.Secrets = append(sync.Spec.Secrets, secretsyncv1.SecretSyncItem{Key: "foreign", Ref: "$<test:other-team::teamToken:1234>"})
+	c := fake.NewClientBuilder().WithScheme(newTestScheme(t)).WithObjects(newNamespace("test:my-team")).Build()
+	h := &HandlerSecretSync{Secrets: secretsFake.NewMockSecretManager(t), Namespaces: c}
+
+	require.NoError(t, h.CreateOrUpdate(newContext(c), sync))
+	assert.Equal(t, "Forbidden", meta.FindStatusCondition(sync.GetConditions(), condition.ConditionTypeReady).Reason)
+}
+
+func TestScopeContains(t *testing.T) {
+	scopes := ParseScopes("test:my-team, prod:my-team:my-app,,invalid:a:b:c,test::")
+	require.Len(t, scopes, 2)
+
+	assert.True(t, scopes[0].Contains("test:my-team::teamToken:"))
+	assert.True(t, scopes[0].Contains("test:my-team:my-app:clientSecret:1234"))
+	assert.False(t, scopes[0].Contains("test:my-team-2:my-app:clientSecret:"))
+	assert.True(t, scopes[1].Contains("prod:my-team:my-app:externalSecrets/db:"))
+	assert.False(t, scopes[1].Contains("prod:my-team::teamToken:"))
+	assert.False(t, scopes[1].Contains("prod:my-team:my-app"))
+}
@@ -0,0 +1,62 @@
+package secretsync
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	secretsyncv1 "github.com/telekom/controlplane-mono/secret-sync/api/v1"
+)
+
+// errForbidden is returned if the SecretSync references a secret outside of the scopes of its namespace
+var errForbidden = errors.New("secret is not allowed in this namespace")
+
+// Scope is an environment, team or application of the secret-manager, e.g. [poc my-team]
+type Scope []string
+
+// ParseScopes parses the comma-separated scopes of the AllowedScopesAnnotation.
+// Invalid scopes are ignored, so they never allow any secret.
+func ParseScopes(raw string) []Scope {
+	var scopes []Scope
+	for _, s := range strings.Split(raw, ",") {
+		scope := Scope(strings.Split(strings.TrimSpace(s), separator))
+		if len(scope) > 3 || slices.Contains(scope, "") {
+			continue
+		}
+		scopes = append(scopes, scope)
+	}
+	return scopes
+}
+
+// Contains checks if the secret with the ID env:team:app:path:checksum belongs to the scope.
+// The segments are compared as a whole, so the team my-team does not contain the secrets of my-team-2.
+func (s Scope) Contains(id string) bool {
+	segments := strings.Split(id, separator)
+	if len(segments) != 5 {
+		return false
+	}
+	return slices.Equal(s, segments[:len(s)])
+}
+
+// checkScopes returns errForbidden if a secret of the SecretSync is not in the allowed scopes of its namespace.
+// The operator reads all secrets with its own identity, so it must not act on behalf of namespaces that may not read them.
+func (h *HandlerSecretSync) checkScopes(ctx context.Context, sync *secretsyncv1.SecretSync) error {
+	namespace := &corev1.Namespace{}
+	if err := h.Namespaces.Get(ctx, client.ObjectKey{Name: sync.Namespace}, namespace); err != nil {
+		return errors.Wrapf(err, "failed to get namespace %s", sync.Namespace)
+	}
+	scopes := ParseScopes(namespace.Annotations[secretsyncv1.AllowedScopesAnnotation])
+
+	for _, item := range sync.Spec.Secrets {
+		id := Latest(item.Ref)
+		allowed := slices.ContainsFunc(scopes, func(s Scope) bool { return s.Contains(id) })
+		if !allowed {
+			return errors.Wrapf(errForbidden, "secret %s of key %s", id, item.Key)
+		}
+	}
+	return nil
+}
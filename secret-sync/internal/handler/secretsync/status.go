@@ -0,0 +1,55 @@
+package secretsync
+
+import (
+	"github.com/telekom/controlplane-mono/common/pkg/condition"
+
+	secretsyncv1 "github.com/telekom/controlplane-mono/secret-sync/api/v1"
+)
+
+var (
+	// Processing
+	processingCondition = condition.NewProcessingCondition("SecretSyncProcessing",
+		"Processing secret sync")
+	processingNotReadyCondition = condition.NewNotReadyCondition("SecretSyncNotReady",
+		"Secret sync not ready")
+
+	// Blocked
+	secretNotFoundCondition         = condition.NewBlockedCondition("Secret not found")
+	secretNotFoundNotReadyCondition = condition.NewNotReadyCondition("SecretNotFound", "Secret not found")
+
+	notOwnedCondition         = condition.NewBlockedCondition("Secret is not controlled by this SecretSync")
+	notOwnedNotReadyCondition = condition.NewNotReadyCondition("SecretNotOwned",
+		"Secret is not controlled by this SecretSync")
+
+	forbiddenCondition         = condition.NewBlockedCondition("Secret is not allowed in this namespace")
+	forbiddenNotReadyCondition = condition.NewNotReadyCondition("Forbidden", "Secret is not allowed in this namespace")
+
+	// Ready
+	doneProcessingCondition = condition.NewDoneProcessingCondition("Synced Secret")
+	readyCondition          = condition.NewReadyCondition("Ready", "Secret is synced")
+)
+
+func SetStatusProcessing(sync *secretsyncv1.SecretSync) {
+	sync.SetCondition(processingCondition)
+	sync.SetCondition(processingNotReadyCondition)
+}
+
+func SetStatusSecretNotFound(sync *secretsyncv1.SecretSync) {
+	sync.SetCondition(secretNotFoundCondition)
+	sync.SetCondition(secretNotFoundNotReadyCondition)
+}
+
+func SetStatusNotOwned(sync *secretsyncv1.SecretSync) {
+	sync.SetCondition(notOwnedCondition)
+	sync.SetCondition(notOwnedNotReadyCondition)
+}
+
+func SetStatusForbidden(sync *secretsyncv1.SecretSync) {
+	sync.SetCondition(forbiddenCondition)
+	sync.SetCondition(forbiddenNotReadyCondition)
+}
+
+func SetStatusReady(sync *secretsyncv1.SecretSync) {
+	sync.SetCondition(doneProcessingCondition)
+	sync.SetCondition(readyCondition)
+}
@@ -0,0 +1,50 @@
+package secretsync
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/telekom/controlplane-mono/common/pkg/condition"
+	"k8s.io/apimachinery/pkg/api/meta"
+
+	secretsyncv1 "github.com/telekom/controlplane-mono/secret-sync/api/v1"
+)
+
+func TestSetStatusProcessingSetsConditions(t *testing.T) {
+	sync := &secretsyncv1.SecretSync{}
+
+	SetStatusProcessing(sync)
+
+	assert.True(t, meta.IsStatusConditionTrue(sync.GetConditions(), condition.ConditionTypeProcessing))
+	assert.True(t, meta.IsStatusConditionFalse(sync.GetConditions(), condition.ConditionTypeReady))
+}
+
+func TestSetStatusSecretNotFoundSetsConditions(t *testing.T) {
+	sync := &secretsyncv1.SecretSync{}
+
+	SetStatusSecretNotFound(sync)
+
+	assert.Equal(t, secretNotFoundCondition.Reason,
+		meta.FindStatusCondition(sync.GetConditions(), condition.ConditionTypeProcessing).Reason)
+	assert.Equal(t, "SecretNotFound", meta.FindStatusCondition(sync.GetConditions(), condition.ConditionTypeReady).Reason)
+}
+
+func TestSetStatusNotOwnedSetsConditions(t *testing.T) {
+	sync := &secretsyncv1.SecretSync{}
+
+	SetStatusNotOwned(sync)
+
+	assert.Equal(t, notOwnedCondition.Reason,
+		meta.FindStatusCondition(sync.GetConditions(), condition.ConditionTypeProcessing).Reason)
+	assert.Equal(t, "SecretNotOwned", meta.FindStatusCondition(sync.GetConditions(), condition.ConditionTypeReady).Reason)
+}
+
+func TestSetStatusReadySetsConditions(t *testing.T) {
+	sync := &secretsyncv1.SecretSync{}
+	SetStatusProcessing(sync)
+
+	SetStatusReady(sync)
+
+	assert.True(t, meta.IsStatusConditionFalse(sync.GetConditions(), condition.ConditionTypeProcessing))
+	assert.True(t, meta.IsStatusConditionTrue(sync.GetConditions(), condition.ConditionTypeReady))
+}